@@ -4,13 +4,16 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/prometheus/client_golang/prometheus"
@@ -21,6 +24,7 @@ import (
 	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/tracing"
 	"github.com/open-edge-platform/infra-onboarding/dkam/internal/dkammgr"
 	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/config"
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/manifest"
 )
 
 var (
@@ -37,6 +41,8 @@ var (
 		metrics.MetricsAddressDefault,
 		metrics.MetricsAddressDescription,
 	)
+	statusAddress = flag.String("statusAddress", "",
+		"Address of the HTTP server of the artifact manifest and build status, disabled if empty")
 	readyChan = make(chan bool, 1)
 	termChan  = make(chan bool, 1)
 	sigChan   = make(chan os.Signal, 1)
@@ -81,28 +87,39 @@ func main() {
 		startMetricsServer()
 	}
 
+	manifest.Start()
+	if *statusAddress != "" {
+		startStatusServer(*statusAddress)
+	}
+
 	if config.GetInfraConfig().SkipOSProvisioning {
 		zlog.InfraSec().Info().Msg("OS Provisioning is disabled, hence skipping download artifacts and signing")
 		zlog.InfraSec().Info().Msg("Curating vpro installer and place it in PVC")
 		if err := CurateVProInstaller(); err != nil {
 			zlog.InfraSec().Fatal().Err(err).Msg("Failed to curate vpro installer")
 		}
+		completeBuild()
 	} else {
 		zlog.InfraSec().Info().Msg("OS Provisioning is enabled.")
 
-		if err := GetArtifacts(context.Background()); err != nil {
-			zlog.InfraSec().Fatal().Err(err).Msg("Failed to get artifacts")
-		}
-
+		// A failed build is reported by the status server and keeps DKAM not ready, instead of restarting it.
+		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if err := BuildBinaries(); err != nil {
-				zlog.InfraSec().Fatal().Err(err).Msg("Failed to get artifacts")
+			err := GetArtifacts(context.Background())
+			if err == nil {
+				err = BuildBinaries()
 			}
+			if err != nil {
+				zlog.InfraSec().Error().Err(err).Msg("Failed to build artifacts")
+				manifest.Fail(err)
+				return
+			}
+			completeBuild()
 		}()
 	}
 
-	setupOamServerAndSetReady(*enableTracing, *oamServerAddress)
+	setupOamServer(*enableTracing, *oamServerAddress)
 
 	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT)
 	<-sigChan // blocking
@@ -141,8 +158,8 @@ func startMetricsServer() {
 		metrics.WithListenAddress(*metricsAddress))
 }
 
-func setupOamServerAndSetReady(enableTracing bool, oamServerAddress string) {
-	zlog.Info().Msg("Inside setupOamServerAndSetReady...")
+func setupOamServer(enableTracing bool, oamServerAddress string) {
+	zlog.Info().Msg("Inside setupOamServer...")
 	if oamServerAddress != "" {
 		// Add oam grpc server
 		wg.Add(1)
@@ -151,10 +168,35 @@ func setupOamServerAndSetReady(enableTracing bool, oamServerAddress string) {
 				zlog.InfraSec().Fatal().Err(err).Msg("Cannot start Inventory OAM gRPC server")
 			}
 		}()
-		readyChan <- true
 	}
 }
 
+// completeBuild writes the manifest of the artifacts built, and sets DKAM ready once it is written.
+func completeBuild() {
+	m, err := dkammgr.WriteManifest(Version)
+	if err != nil {
+		zlog.InfraSec().Error().Err(err).Msg("Failed to write the manifest of the artifacts")
+		manifest.Fail(err)
+		return
+	}
+	manifest.Complete(m)
+	readyChan <- true
+}
+
+func startStatusServer(address string) {
+	server := &http.Server{
+		Addr:              address,
+		Handler:           manifest.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		zlog.InfraSec().Info().Msgf("Serving the artifact manifest and build status on %s", address)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zlog.InfraSec().Fatal().Err(err).Msg("Cannot start the status server")
+		}
+	}()
+}
+
 func GetArtifacts(ctx context.Context) error {
 	outDir := filepath.Join(config.DownloadPath, "tmp")
 	// 0. cleanup
@@ -163,7 +205,7 @@ func GetArtifacts(ctx context.Context) error {
 	// Download release manifest.yaml file.
 	artifactsErr := dkammgr.DownloadArtifacts(ctx)
 	if artifactsErr != nil {
-		zlog.InfraSec().Fatal().Err(artifactsErr).Msgf("Error downloading file %v", artifactsErr)
+		zlog.InfraSec().Error().Err(artifactsErr).Msgf("Error downloading file %v", artifactsErr)
 		return artifactsErr
 	}
 	return nil
@@ -173,17 +215,23 @@ func BuildBinaries() error {
 	// Download and sign iPXE
 	signedIPXE, pxeErr := dkammgr.BuildSignIpxe()
 	if pxeErr != nil {
-		zlog.InfraSec().Fatal().Err(pxeErr).Msgf("Failed to sign MicroOS %v", pxeErr)
+		zlog.InfraSec().Error().Err(pxeErr).Msgf("Failed to sign iPXE %v", pxeErr)
 		return pxeErr
 	}
 	if signedIPXE {
 		zlog.InfraSec().Info().Msg("Signed IPXE and moved to PVC")
 	}
 
+	// Pack the signed iPXE of the sites without DHCP or PXE in their boot media.
+	if err := dkammgr.BuildBootMedia(); err != nil {
+		zlog.InfraSec().Error().Err(err).Msgf("Failed to build boot media %v", err)
+		return err
+	}
+
 	// Download and sign MicroOS.
 	signed, signerr := dkammgr.SignMicroOS()
 	if signerr != nil {
-		zlog.InfraSec().Fatal().Err(signerr).Msgf("Failed to sign MicroOS %v", signerr)
+		zlog.InfraSec().Error().Err(signerr).Msgf("Failed to sign MicroOS %v", signerr)
 		return signerr
 	}
 	if signed {
@@ -15,6 +15,7 @@ require (
 
 require (
 	github.com/Masterminds/sprig/v3 v3.3.0
+	github.com/klauspost/compress v1.18.0
 	github.com/open-edge-platform/infra-core/inventory/v2 v2.35.1
 	github.com/prometheus/client_golang v1.23.2
 	github.com/spf13/viper v1.21.0
@@ -11,8 +11,10 @@ import (
 
 	osv1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/os/v1"
 	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/logging"
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/bootmedia"
 	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/config"
 	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/download"
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/manifest"
 	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/script/vpro"
 	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/signing"
 )
@@ -66,6 +68,35 @@ func BuildSignIpxe() (bool, error) {
 	return true, nil
 }
 
+// BuildBootMedia builds the bootable ISO/USB images of the sites without DHCP or PXE from their signed iPXE.
+func BuildBootMedia() error {
+	infraConfig := config.GetInfraConfig()
+	if len(infraConfig.BootMedia) == 0 {
+		return nil
+	}
+	if err := bootmedia.Build(infraConfig); err != nil {
+		zlog.InfraSec().Error().Err(err).Msg("Failed to build boot media")
+		return err
+	}
+	zlog.InfraSec().Info().Msgf("Built %d boot media and moved to PVC", len(infraConfig.BootMedia))
+	return nil
+}
+
+// WriteManifest writes the manifest of the artifacts of the PVC, built by DKAM of the version, to the PVC.
+func WriteManifest(version string) (*manifest.Manifest, error) {
+	m, err := manifest.Build(config.GetInfraConfig(), version)
+	if err != nil {
+		return nil, err
+	}
+	if err = manifest.Write(m); err != nil {
+		zlog.InfraSec().Error().Err(err).Msg("Failed to write the manifest of the artifacts")
+		return nil, err
+	}
+	zlog.InfraSec().Info().Msgf("Manifest of %d artifacts written to PVC, config hash %s", len(m.Artifacts),
+		m.ConfigHash)
+	return m, nil
+}
+
 // CurateVProInstaller curates vPro installer script for Ubuntu and copies it to PVC.
 func CurateVProInstaller() error {
 	infraConfig := config.GetInfraConfig()
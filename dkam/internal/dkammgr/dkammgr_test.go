@@ -13,6 +13,7 @@ import (
 	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/logging"
 	"github.com/open-edge-platform/infra-onboarding/dkam/internal/dkammgr"
 	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/config"
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/manifest"
 	dkam_testing "github.com/open-edge-platform/infra-onboarding/dkam/testing"
 )
 
@@ -85,3 +86,20 @@ func TestBuildSignIpxe1(t *testing.T) {
 		t.Errorf("Expected error to be nil, got %v", err)
 	}
 }
+
+func TestWriteManifest(t *testing.T) {
+	if err := os.WriteFile(filepath.Join(config.PVC, "chain.ipxe"), []byte("#!ipxe\n"), 0o600); err != nil {
+		t.Fatalf("Error writing artifact: %v", err)
+	}
+
+	m, err := dkammgr.WriteManifest("1.0.0")
+	if err != nil {
+		t.Fatalf("Expected error to be nil, got %v", err)
+	}
+	if m.Version != "1.0.0" || len(m.Artifacts) == 0 {
+		t.Errorf("Unexpected manifest %+v", m)
+	}
+	if _, err = os.Stat(filepath.Join(config.PVC, manifest.File)); err != nil {
+		t.Errorf("Expected manifest in PVC, got %v", err)
+	}
+}
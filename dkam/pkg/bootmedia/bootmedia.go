@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bootmedia generates the bootable ISO/USB images of the sites where edge nodes cannot be onboarded with
+// DHCP and PXE. The image of a site boots a signed iPXE embedding the network settings of the site, which
+// chainloads the Micro-OS from the orchestrator as chain.ipxe does.
+package bootmedia
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/logging"
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/config"
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/curation"
+)
+
+var zlog = logging.GetLogger("InfraDKAMBootMedia")
+
+//go:embed boot_media.ipxe
+var scriptTemplate string
+
+const (
+	// Dir is the directory of the boot media, in the PVC and in the working directory of the iPXE build.
+	// The build script reads the iPXE script of each site from it, and the signed iPXE binaries of a site are in
+	// its subdirectory named after the site.
+	Dir = "boot_media"
+
+	dirMode  = 0o755
+	fileMode = 0o644
+)
+
+// efiBootFiles maps the architectures to the path of the UEFI removable media boot loader.
+var efiBootFiles = map[string]string{
+	config.ArchX86_64:  "EFI/BOOT/BOOTX64.EFI",
+	config.ArchAarch64: "EFI/BOOT/BOOTAA64.EFI",
+}
+
+// File is a file of a boot media image.
+type File struct {
+	Path string
+	Data []byte
+}
+
+// RenderScript returns the iPXE script embedded in the boot media of a site, which configures the network of
+// the site and chainloads the Micro-OS from the provisioning server.
+func RenderScript(media config.BootMedia, provisioningServerURL string) (string, error) {
+	if err := media.Validate(); err != nil {
+		return "", err
+	}
+	netif := fmt.Sprintf("net%d", media.Interface)
+	templateVariables := map[string]interface{}{
+		"name":         media.Name,
+		"tink_url":     provisioningServerURL,
+		"parent_netif": netif,
+		"netif":        netif,
+		"vlan":         media.VLAN,
+		"ip":           "",
+		"netmask":      "",
+		"gateway":      media.Gateway,
+		"dns":          "",
+	}
+	if media.VLAN != 0 {
+		templateVariables["netif"] = fmt.Sprintf("%s-%d", netif, media.VLAN)
+	}
+	if media.IP != "" {
+		prefix := netip.MustParsePrefix(media.IP)
+		templateVariables["ip"] = prefix.Addr().String()
+		templateVariables["netmask"] = net.IP(net.CIDRMask(prefix.Bits(), net.IPv4len*8)).String()
+	}
+	// iPXE supports a single DNS server.
+	if len(media.DNS) > 0 {
+		templateVariables["dns"] = media.DNS[0]
+	}
+	return curation.CurateFromTemplate(scriptTemplate, templateVariables)
+}
+
+// WriteScripts writes the iPXE script of the boot media of each site to dir, as <name>.ipxe. Scripts of sites
+// removed from the configuration are removed.
+func WriteScripts(dir string, infraConfig config.InfraConfig) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if len(infraConfig.BootMedia) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return err
+	}
+	for _, media := range infraConfig.BootMedia {
+		script, err := RenderScript(media, infraConfig.ProvisioningServerURL)
+		if err != nil {
+			return err
+		}
+		if err = os.WriteFile(filepath.Join(dir, media.Name+".ipxe"), []byte(script), fileMode); err != nil {
+			return err
+		}
+		zlog.InfraSec().Info().Msgf("iPXE script of boot media %s written", media.Name)
+	}
+	return nil
+}
+
+// SignedIpxeName returns the name of the signed iPXE binary of the architecture, as named by build_sign_ipxe.sh.
+func SignedIpxeName(arch string) string {
+	if arch == config.ArchX86_64 {
+		return "signed_ipxe.efi"
+	}
+	return "signed_ipxe_" + arch + ".efi"
+}
+
+// Build builds the ISO/USB image of each site from the signed iPXE binaries of the site in the PVC, and writes it
+// to the PVC as boot_media/<name>.iso along with its SHA-256 checksum.
+func Build(infraConfig config.InfraConfig) error {
+	mediaDir := filepath.Join(config.PVC, Dir)
+	for _, media := range infraConfig.BootMedia {
+		files, err := readFiles(filepath.Join(mediaDir, media.Name), infraConfig.Architectures())
+		if err != nil {
+			return err
+		}
+		img, err := BuildImage(media.Name, files)
+		if err != nil {
+			return err
+		}
+		isoPath := filepath.Join(mediaDir, media.Name+".iso")
+		if err = writeFile(isoPath, img); err != nil {
+			return err
+		}
+		sum := sha256.Sum256(img)
+		checksum := hex.EncodeToString(sum[:]) + "  " + media.Name + ".iso\n"
+		if err = writeFile(isoPath+".sha256", []byte(checksum)); err != nil {
+			return err
+		}
+		zlog.InfraSec().Info().Msgf("Boot media %s written to %s", media.Name, isoPath)
+	}
+	return nil
+}
+
+// BuildImage returns the hybrid ISO/USB image of the boot media of a site. The EFI system partition of the image
+// holds the files, the files of its root directory are also in the root directory of the ISO for technicians to
+// find, e.g. the orchestrator CA certificate.
+func BuildImage(name string, files []File) ([]byte, error) {
+	esp, err := buildFAT(files)
+	if err != nil {
+		return nil, err
+	}
+	var rootFiles []File
+	for _, file := range files {
+		if !strings.Contains(file.Path, "/") {
+			rootFiles = append(rootFiles, file)
+		}
+	}
+	return buildISO(isoVolumeID(name), esp, rootFiles)
+}
+
+// readFiles returns the files of the boot media of a site: the signed iPXE of each architecture as the UEFI
+// removable media boot loader, the orchestrator CA certificate and the Secure Boot certificate to enroll in the
+// UEFI BIOS.
+func readFiles(dir string, archs []string) ([]File, error) {
+	var files []File
+	for _, arch := range archs {
+		data, err := os.ReadFile(filepath.Join(dir, SignedIpxeName(arch)))
+		if err != nil {
+			zlog.InfraSec().Error().Err(err).Msgf("Signed iPXE of %s not found in %s", arch, dir)
+			return nil, err
+		}
+		files = append(files, File{Path: efiBootFiles[arch], Data: data})
+	}
+	for _, file := range []struct{ path, src string }{
+		{path: "CA.CRT", src: config.OrchCACertificateFile},
+		{path: "DB.DER", src: filepath.Join(config.PVC, "keys", "db.der")},
+	} {
+		data, err := os.ReadFile(file.src)
+		if err != nil {
+			zlog.InfraSec().Error().Err(err).Msgf("Failed to read %s", file.src)
+			return nil, err
+		}
+		files = append(files, File{Path: file.path, Data: data})
+	}
+	return files, nil
+}
+
+// writeFile writes the file through a temporary file, for the file server never to serve a partial image.
+func writeFile(path string, data []byte) error {
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, fileMode); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
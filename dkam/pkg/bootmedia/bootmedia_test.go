@@ -0,0 +1,278 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+//
+//nolint:testpackage // Keeping the test in the same package to parse the images with the unexported layout.
+package bootmedia
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/config"
+)
+
+const testTinkURL = "https://tinkerbell-haproxy.kind.internal"
+
+func TestRenderScript(t *testing.T) {
+	t.Run("DHCP", func(t *testing.T) {
+		script, err := RenderScript(config.BootMedia{Name: "site-1"}, testTinkURL)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(script, "#!ipxe\n"))
+		require.Contains(t, script, "set tink_url "+testTinkURL+"\n")
+		require.Contains(t, script, "set netif net0\n")
+		require.Contains(t, script, "ifconf --configurator dhcp --timeout=-1 ${netif}")
+		require.NotContains(t, script, "vcreate")
+		require.NotContains(t, script, "${netif}/ip")
+		require.NotContains(t, script, "<no value>")
+	})
+
+	t.Run("StaticVLAN", func(t *testing.T) {
+		script, err := RenderScript(config.BootMedia{
+			Name:      "site-1",
+			Interface: 1,
+			IP:        "10.0.0.10/24",
+			Gateway:   "10.0.0.1",
+			DNS:       []string{"10.0.0.2", "10.0.0.3"},
+			VLAN:      100,
+		}, testTinkURL)
+		require.NoError(t, err)
+		require.Contains(t, script, "set netif net1-100\n")
+		require.Contains(t, script, "vcreate --tag 100 net1 || goto ifconferror\n")
+		require.Contains(t, script, "set ${netif}/ip 10.0.0.10\n")
+		require.Contains(t, script, "set ${netif}/netmask 255.255.255.0\n")
+		require.Contains(t, script, "set ${netif}/gateway 10.0.0.1\n")
+		require.Contains(t, script, "set dns 10.0.0.2\n")
+		require.Contains(t, script, "ifopen ${netif} && goto networkconfig")
+		require.NotContains(t, script, "--configurator dhcp")
+		require.NotContains(t, script, "10.0.0.3")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := RenderScript(config.BootMedia{Name: "site-1", IP: "10.0.0.10"}, testTinkURL)
+		require.Error(t, err)
+	})
+}
+
+func TestWriteScripts(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), Dir)
+	infraConfig := config.InfraConfig{
+		ProvisioningServerURL: testTinkURL,
+		BootMedia:             []config.BootMedia{{Name: "site-1"}, {Name: "site-2", IP: "10.0.0.10/24"}},
+	}
+	require.NoError(t, WriteScripts(dir, infraConfig))
+	for _, name := range []string{"site-1", "site-2"} {
+		script, err := os.ReadFile(filepath.Join(dir, name+".ipxe"))
+		require.NoError(t, err)
+		require.Contains(t, string(script), "boot from the "+name+" boot media")
+	}
+
+	infraConfig.BootMedia = nil
+	require.NoError(t, WriteScripts(dir, infraConfig))
+	require.NoDirExists(t, dir)
+}
+
+func TestBuildImage(t *testing.T) {
+	ipxeX86 := bytes.Repeat([]byte("x86 iPXE"), 200000)
+	ipxeArm := bytes.Repeat([]byte("arm iPXE"), 100000)
+	caCert := []byte("-----BEGIN CERTIFICATE-----\nTESTCERTDATA\n-----END CERTIFICATE-----\n")
+	img, err := BuildImage("site-1", []File{
+		{Path: "EFI/BOOT/BOOTX64.EFI", Data: ipxeX86},
+		{Path: "EFI/BOOT/BOOTAA64.EFI", Data: ipxeArm},
+		{Path: "CA.CRT", Data: caCert},
+	})
+	require.NoError(t, err)
+	require.Zero(t, len(img)%isoSectorSize)
+
+	// ISO 9660 volume descriptors
+	pvd := isoSector(img, isoPVDSector)
+	require.Equal(t, []byte{1, 'C', 'D', '0', '0', '1', 1}, pvd[:7])
+	require.Equal(t, "SITE_1", strings.TrimSpace(string(pvd[40:72])))
+	require.Equal(t, uint32(len(img)/isoSectorSize), binary.LittleEndian.Uint32(pvd[80:]))
+	bootRecord := isoSector(img, isoBootRecordSector)
+	require.Equal(t, []byte{0, 'C', 'D', '0', '0', '1', 1}, bootRecord[:7])
+	require.Equal(t, elToritoSystemID, string(bytes.TrimRight(bootRecord[7:39], "\x00")))
+	require.Equal(t, []byte{0xff, 'C', 'D', '0', '0', '1', 1}, isoSector(img, isoTerminatorSector)[:7])
+
+	// Files of the root directory of the ISO
+	require.Equal(t, caCert, readISOFile(t, img, "CA.CRT"))
+	esp := readISOFile(t, img, espFileName)
+	espSector := isoFileSector(t, img, espFileName)
+
+	// El Torito boot catalog
+	catalog := isoSector(img, int(binary.LittleEndian.Uint32(bootRecord[71:])))
+	var sum uint16
+	for i := 0; i < elToritoEntrySize; i += 2 {
+		sum += binary.LittleEndian.Uint16(catalog[i:])
+	}
+	require.Zero(t, sum, "validation entry checksum")
+	require.Equal(t, byte(1), catalog[0])
+	require.Equal(t, byte(elToritoEFIPlatform), catalog[1])
+	require.Equal(t, []byte{0x55, 0xaa}, catalog[30:32])
+	entry := catalog[elToritoEntrySize:]
+	require.Equal(t, byte(elToritoBootable), entry[0])
+	require.Equal(t, byte(0), entry[1], "no emulation")
+	require.Equal(t, uint16(len(esp)/mbrSectorSize), binary.LittleEndian.Uint16(entry[6:]))
+	require.Equal(t, uint32(espSector), binary.LittleEndian.Uint32(entry[8:]))
+
+	// Hybrid MBR
+	require.Equal(t, []byte{0x55, 0xaa}, img[510:512])
+	partition := img[mbrPartitionOffset : mbrPartitionOffset+16]
+	require.Equal(t, byte(mbrESPType), partition[4])
+	require.Equal(t, uint32(espSector*isoSectorsPerMBRBlock), binary.LittleEndian.Uint32(partition[8:]))
+	require.Equal(t, uint32(len(esp)/mbrSectorSize), binary.LittleEndian.Uint32(partition[12:]))
+	start := espSector * isoSectorSize
+	require.Equal(t, esp, img[start:start+len(esp)])
+
+	// FAT16 EFI system partition
+	require.Equal(t, "FAT16   ", string(esp[54:62]))
+	require.Equal(t, []byte{0x55, 0xaa}, esp[510:512])
+	require.Equal(t, ipxeX86, readFATFile(t, esp, "EFI/BOOT/BOOTX64.EFI"))
+	require.Equal(t, ipxeArm, readFATFile(t, esp, "EFI/BOOT/BOOTAA64.EFI"))
+	require.Equal(t, caCert, readFATFile(t, esp, "CA.CRT"))
+}
+
+func TestBuildImage_InvalidFiles(t *testing.T) {
+	for name, files := range map[string][]File{
+		"LongName":    {{Path: "EFI/BOOT/BOOTLOADER.EFI"}},
+		"Lowercase":   {{Path: "ca.crt"}},
+		"Duplicate":   {{Path: "CA.CRT"}, {Path: "CA.CRT"}},
+		"FileAsDir":   {{Path: "EFI"}, {Path: "EFI/BOOT/BOOTX64.EFI"}},
+		"ReservedISO": {{Path: espFileName}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := BuildImage("site-1", files)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	pvc := t.TempDir()
+	defaultPVC, defaultCACert := config.PVC, config.OrchCACertificateFile
+	config.PVC = pvc
+	config.OrchCACertificateFile = filepath.Join(pvc, "ca.crt")
+	defer func() {
+		config.PVC, config.OrchCACertificateFile = defaultPVC, defaultCACert
+	}()
+
+	caCert := []byte("-----BEGIN CERTIFICATE-----\nTESTCERTDATA\n-----END CERTIFICATE-----\n")
+	require.NoError(t, os.WriteFile(config.OrchCACertificateFile, caCert, fileMode))
+	require.NoError(t, os.MkdirAll(filepath.Join(pvc, "keys"), dirMode))
+	require.NoError(t, os.WriteFile(filepath.Join(pvc, "keys", "db.der"), []byte("db"), fileMode))
+	siteDir := filepath.Join(pvc, Dir, "site-1")
+	require.NoError(t, os.MkdirAll(siteDir, dirMode))
+	require.NoError(t, os.WriteFile(filepath.Join(siteDir, "signed_ipxe.efi"), []byte("x86 iPXE"), fileMode))
+
+	infraConfig := config.InfraConfig{BootMedia: []config.BootMedia{{Name: "site-1"}}}
+	require.NoError(t, Build(infraConfig))
+
+	img, err := os.ReadFile(filepath.Join(pvc, Dir, "site-1.iso"))
+	require.NoError(t, err)
+	esp := readISOFile(t, img, espFileName)
+	require.Equal(t, []byte("x86 iPXE"), readFATFile(t, esp, "EFI/BOOT/BOOTX64.EFI"))
+	require.Equal(t, caCert, readFATFile(t, esp, "CA.CRT"))
+	require.Equal(t, []byte("db"), readISOFile(t, img, "DB.DER"))
+
+	checksum, err := os.ReadFile(filepath.Join(pvc, Dir, "site-1.iso.sha256"))
+	require.NoError(t, err)
+	sum := sha256.Sum256(img)
+	require.Equal(t, hex.EncodeToString(sum[:])+"  site-1.iso\n", string(checksum))
+
+	t.Run("MissingArchitecture", func(t *testing.T) {
+		infraConfig.EMBImageURLs = map[string]string{config.ArchAarch64: "uos/aarch64"}
+		require.Error(t, Build(infraConfig))
+	})
+}
+
+// isoFileSector returns the first sector of a file of the root directory of the ISO image.
+func isoFileSector(t *testing.T, img []byte, name string) int {
+	t.Helper()
+	record := isoDirRecord(t, img, name)
+	return int(binary.LittleEndian.Uint32(record[2:]))
+}
+
+// readISOFile returns the content of a file of the root directory of the ISO image.
+func readISOFile(t *testing.T, img []byte, name string) []byte {
+	t.Helper()
+	record := isoDirRecord(t, img, name)
+	start := int(binary.LittleEndian.Uint32(record[2:])) * isoSectorSize
+	return img[start : start+int(binary.LittleEndian.Uint32(record[10:]))]
+}
+
+// isoDirRecord returns the directory record of a file of the root directory of the ISO image, found from the
+// primary volume descriptor.
+func isoDirRecord(t *testing.T, img []byte, name string) []byte {
+	t.Helper()
+	root := isoSector(img, isoPVDSector)[156:]
+	start := int(binary.LittleEndian.Uint32(root[2:])) * isoSectorSize
+	dir := img[start : start+int(binary.LittleEndian.Uint32(root[10:]))]
+	for offset := 0; offset < len(dir); {
+		recordLen := int(dir[offset])
+		if recordLen == 0 {
+			// records do not span sectors, the next one is in the next sector
+			offset += isoSectorSize - offset%isoSectorSize
+			continue
+		}
+		record := dir[offset : offset+recordLen]
+		if string(record[33:33+int(record[32])]) == name+";1" {
+			return record
+		}
+		offset += recordLen
+	}
+	require.FailNow(t, "file not found in the ISO image", name)
+	return nil
+}
+
+// readFATFile returns the content of a file of the FAT16 image, found from its boot sector.
+func readFATFile(t *testing.T, img []byte, path string) []byte {
+	t.Helper()
+	sectorSize := int(binary.LittleEndian.Uint16(img[11:]))
+	clusterSize := sectorSize * int(img[13])
+	fatStart := int(binary.LittleEndian.Uint16(img[14:])) * sectorSize
+	fatSize := int(binary.LittleEndian.Uint16(img[22:])) * sectorSize
+	rootStart := fatStart + int(img[16])*fatSize
+	dataStart := rootStart + int(binary.LittleEndian.Uint16(img[17:]))*fatDirEntrySize
+
+	readChain := func(cluster, size int) []byte {
+		var data []byte
+		for cluster >= fatFirstDataCluster && cluster < fatEndOfChain-7 {
+			offset := dataStart + (cluster-fatFirstDataCluster)*clusterSize
+			data = append(data, img[offset:offset+clusterSize]...)
+			cluster = int(binary.LittleEndian.Uint16(img[fatStart+cluster*2:]))
+		}
+		if size >= 0 {
+			require.GreaterOrEqual(t, len(data), size)
+			data = data[:size]
+		}
+		return data
+	}
+
+	dir := img[rootStart:dataStart]
+	elems := strings.Split(path, "/")
+	for i, elem := range elems {
+		var entry []byte
+		for offset := 0; offset+fatDirEntrySize <= len(dir) && dir[offset] != 0; offset += fatDirEntrySize {
+			if string(dir[offset:offset+11]) == fatShortName(elem) {
+				entry = dir[offset : offset+fatDirEntrySize]
+				break
+			}
+		}
+		require.NotNil(t, entry, "%s not found in the FAT image", path)
+		cluster := int(binary.LittleEndian.Uint16(entry[26:]))
+		if i == len(elems)-1 {
+			require.Equal(t, byte(fatAttrArchive), entry[11])
+			return readChain(cluster, int(binary.LittleEndian.Uint32(entry[28:])))
+		}
+		require.Equal(t, byte(fatAttrDirectory), entry[11])
+		dir = readChain(cluster, -1)
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package bootmedia
+
+import (
+	"encoding/binary"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// FAT16 layout of the EFI system partition. Clusters of 2 KiB keep the FAT small, and the cluster count is kept
+// above the FAT12 limit for the firmware to detect the file system as FAT16.
+const (
+	fatSectorSize       = 512
+	fatSectorsPerClus   = 4
+	fatClusterSize      = fatSectorSize * fatSectorsPerClus
+	fatReservedSectors  = 1
+	fatNumFATs          = 2
+	fatRootEntries      = 512
+	fatDirEntrySize     = 32
+	fatRootDirSectors   = fatRootEntries * fatDirEntrySize / fatSectorSize
+	fatMinClusters      = 4096
+	fatMaxClusters      = 65524
+	fatSpareClusters    = 16
+	fatMediaDescriptor  = 0xf8
+	fatEndOfChain       = 0xffff
+	fatAttrDirectory    = 0x10
+	fatAttrArchive      = 0x20
+	fatFirstDataCluster = 2
+	// fatDate is 1980-01-01, the FAT epoch, for the images to be reproducible.
+	fatDate = 1<<5 | 1
+)
+
+// fatNameRegexp matches the 8.3 names of the files of the EFI system partition.
+var fatNameRegexp = regexp.MustCompile(`^[A-Z0-9_-]{1,8}(\.[A-Z0-9_-]{1,3})?$`)
+
+// fatNode is a file or a directory of the FAT image.
+type fatNode struct {
+	name     string
+	data     []byte
+	dir      bool
+	children []*fatNode
+	cluster  int
+	clusters int
+}
+
+// size returns the size of the content of the node, the entries for a directory.
+func (n *fatNode) size() int {
+	if !n.dir {
+		return len(n.data)
+	}
+	// . and .. entries
+	return (len(n.children) + 2) * fatDirEntrySize
+}
+
+// buildFAT returns a FAT16 image holding the files, which are given by their paths in the image, e.g.
+// EFI/BOOT/BOOTX64.EFI. Names must be uppercase 8.3 names, long file names are not supported.
+func buildFAT(files []File) ([]byte, error) {
+	root := &fatNode{dir: true}
+	for _, file := range files {
+		if err := root.add(strings.Split(file.Path, "/"), file.Data); err != nil {
+			return nil, err
+		}
+	}
+	if len(root.children) > fatRootEntries {
+		return nil, fmt.Errorf("too many files in the root directory of the FAT image: %d", len(root.children))
+	}
+
+	// Allocate the clusters of the directories and files, in the order they are written.
+	nodes := root.descendants()
+	next := fatFirstDataCluster
+	for _, node := range nodes {
+		node.clusters = (node.size() + fatClusterSize - 1) / fatClusterSize
+		if node.clusters > 0 {
+			node.cluster = next
+			next += node.clusters
+		}
+	}
+	clusters := max(next-fatFirstDataCluster+fatSpareClusters, fatMinClusters)
+	if clusters > fatMaxClusters {
+		return nil, fmt.Errorf("files too large for a FAT16 image: %d clusters", clusters)
+	}
+
+	fatSectors := ((clusters+fatFirstDataCluster)*2 + fatSectorSize - 1) / fatSectorSize
+	dataStart := fatReservedSectors + fatNumFATs*fatSectors + fatRootDirSectors
+	totalSectors := dataStart + clusters*fatSectorsPerClus
+	img := make([]byte, totalSectors*fatSectorSize)
+
+	writeFATBootSector(img[:fatSectorSize], totalSectors, fatSectors)
+
+	fat := make([]byte, fatSectors*fatSectorSize)
+	binary.LittleEndian.PutUint16(fat[0:], 0xff00|fatMediaDescriptor)
+	binary.LittleEndian.PutUint16(fat[2:], fatEndOfChain)
+	for _, node := range nodes {
+		for i := range node.clusters {
+			entry := node.cluster + i + 1
+			if i == node.clusters-1 {
+				entry = fatEndOfChain
+			}
+			binary.LittleEndian.PutUint16(fat[(node.cluster+i)*2:], uint16(entry)) //nolint:gosec // below fatMaxClusters
+		}
+	}
+	for i := range fatNumFATs {
+		copy(img[(fatReservedSectors+i*fatSectors)*fatSectorSize:], fat)
+	}
+
+	clusterOffset := func(cluster int) int {
+		return (dataStart + (cluster-fatFirstDataCluster)*fatSectorsPerClus) * fatSectorSize
+	}
+	rootDir := img[(fatReservedSectors+fatNumFATs*fatSectors)*fatSectorSize : dataStart*fatSectorSize]
+	writeFATDirEntries(rootDir, root.children)
+	for _, node := range nodes {
+		if node.clusters == 0 {
+			continue
+		}
+		content := img[clusterOffset(node.cluster) : clusterOffset(node.cluster)+node.clusters*fatClusterSize]
+		if !node.dir {
+			copy(content, node.data)
+			continue
+		}
+		writeFATDirEntry(content[0:], ".          ", fatAttrDirectory, node.cluster, 0)
+		// The parent of the directories of the root directory is cluster 0.
+		writeFATDirEntry(content[fatDirEntrySize:], "..         ", fatAttrDirectory, node.parentCluster(root), 0)
+		writeFATDirEntries(content[2*fatDirEntrySize:], node.children)
+	}
+	return img, nil
+}
+
+// add adds the file at the path below the directory, creating the intermediate directories.
+func (n *fatNode) add(elems []string, data []byte) error {
+	name := elems[0]
+	if !fatNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid FAT 8.3 name %q", name)
+	}
+	idx := slices.IndexFunc(n.children, func(child *fatNode) bool { return child.name == name })
+	if len(elems) == 1 {
+		if idx >= 0 {
+			return fmt.Errorf("duplicate file %q in the FAT image", name)
+		}
+		n.children = append(n.children, &fatNode{name: name, data: data})
+		return nil
+	}
+	if idx < 0 {
+		n.children = append(n.children, &fatNode{name: name, dir: true})
+		idx = len(n.children) - 1
+	}
+	child := n.children[idx]
+	if !child.dir {
+		return fmt.Errorf("%q is a file of the FAT image, not a directory", name)
+	}
+	return child.add(elems[1:], data)
+}
+
+// descendants returns the files and directories below the node, the children of a directory before their own
+// descendants.
+func (n *fatNode) descendants() []*fatNode {
+	nodes := slices.Clone(n.children)
+	for _, child := range n.children {
+		if child.dir {
+			nodes = append(nodes, child.descendants()...)
+		}
+	}
+	return nodes
+}
+
+// parentCluster returns the first cluster of the parent directory of the node below root, 0 for the root
+// directory.
+func (n *fatNode) parentCluster(root *fatNode) int {
+	for _, node := range append([]*fatNode{root}, root.descendants()...) {
+		if slices.Contains(node.children, n) {
+			return node.cluster
+		}
+	}
+	return 0
+}
+
+func writeFATBootSector(sector []byte, totalSectors, fatSectors int) {
+	copy(sector[0:], []byte{0xeb, 0x3c, 0x90})
+	copy(sector[3:], "DKAM    ")
+	binary.LittleEndian.PutUint16(sector[11:], fatSectorSize)
+	sector[13] = fatSectorsPerClus
+	binary.LittleEndian.PutUint16(sector[14:], fatReservedSectors)
+	sector[16] = fatNumFATs
+	binary.LittleEndian.PutUint16(sector[17:], fatRootEntries)
+	if totalSectors <= 0xffff {
+		binary.LittleEndian.PutUint16(sector[19:], uint16(totalSectors))
+	} else {
+		binary.LittleEndian.PutUint32(sector[32:], uint32(totalSectors)) //nolint:gosec // below fatMaxClusters
+	}
+	sector[21] = fatMediaDescriptor
+	binary.LittleEndian.PutUint16(sector[22:], uint16(fatSectors)) //nolint:gosec // below fatMaxClusters
+	binary.LittleEndian.PutUint16(sector[24:], 32)                 // sectors per track
+	binary.LittleEndian.PutUint16(sector[26:], 64)                 // heads
+	sector[36] = 0x80                                              // drive number
+	sector[38] = 0x29                                              // extended boot signature
+	copy(sector[43:], "EFIBOOT    ")
+	copy(sector[54:], "FAT16   ")
+	sector[510] = 0x55
+	sector[511] = 0xaa
+}
+
+func writeFATDirEntries(dir []byte, nodes []*fatNode) {
+	for i, node := range nodes {
+		attr, size := byte(fatAttrArchive), len(node.data)
+		if node.dir {
+			attr, size = fatAttrDirectory, 0
+		}
+		writeFATDirEntry(dir[i*fatDirEntrySize:], fatShortName(node.name), attr, node.cluster, size)
+	}
+}
+
+func writeFATDirEntry(entry []byte, name string, attr byte, cluster, size int) {
+	copy(entry[0:11], name)
+	entry[11] = attr
+	binary.LittleEndian.PutUint16(entry[16:], fatDate)         // creation date
+	binary.LittleEndian.PutUint16(entry[18:], fatDate)         // last access date
+	binary.LittleEndian.PutUint16(entry[24:], fatDate)         // write date
+	binary.LittleEndian.PutUint16(entry[26:], uint16(cluster)) //nolint:gosec // below fatMaxClusters
+	binary.LittleEndian.PutUint32(entry[28:], uint32(size))    //nolint:gosec // below the FAT16 size limit
+}
+
+// fatShortName returns the 8.3 name as stored in a directory entry, e.g. "BOOTX64 EFI".
+func fatShortName(name string) string {
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	return fmt.Sprintf("%-8s%-3s", base, strings.TrimPrefix(ext, "."))
+}
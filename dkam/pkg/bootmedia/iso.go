@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package bootmedia
+
+import (
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ISO 9660 layout of the boot media. The volume descriptors follow the 32 KiB system area, which holds the MBR
+// of the hybrid image. The path tables, the root directory, the El Torito boot catalog and the files follow.
+const (
+	isoSectorSize         = 2048
+	isoPVDSector          = 16
+	isoBootRecordSector   = 17
+	isoTerminatorSector   = 18
+	isoLPathTableSector   = 19
+	isoMPathTableSector   = 20
+	isoRootDirSector      = 21
+	isoPathTableSize      = 10
+	isoDirRecordSize      = 33
+	isoDirFlag            = 0x02
+	isoVolumeIDSize       = 32
+	elToritoSystemID      = "EL TORITO SPECIFICATION"
+	elToritoEFIPlatform   = 0xef
+	elToritoBootable      = 0x88
+	elToritoEntrySize     = 32
+	mbrPartitionOffset    = 446
+	mbrESPType            = 0xef
+	mbrSectorSize         = 512
+	isoSectorsPerMBRBlock = isoSectorSize / mbrSectorSize
+	// espFileName is the name of the EFI system partition image in the root directory of the ISO.
+	espFileName = "EFIBOOT.IMG"
+)
+
+// isoNameRegexp matches the ISO 9660 level 1 names of the files of the boot media.
+var isoNameRegexp = regexp.MustCompile(`^[A-Z0-9_]{1,8}(\.[A-Z0-9_]{1,3})?$`)
+
+// isoVolumeIDRegexp matches the characters not allowed in an ISO 9660 volume identifier.
+var isoVolumeIDRegexp = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// isoFile is a file of the root directory of the ISO image.
+type isoFile struct {
+	name   string
+	data   []byte
+	sector int
+}
+
+// buildISO returns a hybrid ISO 9660 image that boots the EFI system partition image esp with UEFI, from an
+// optical disc through its El Torito boot catalog and from a USB disk through the EFI system partition of its
+// MBR. files are added to the root directory of the image, along with the EFI system partition image.
+func buildISO(volumeID string, esp []byte, files []File) ([]byte, error) {
+	rootFiles := []*isoFile{{name: espFileName, data: esp}}
+	for _, file := range files {
+		if !isoNameRegexp.MatchString(file.Path) {
+			return nil, fmt.Errorf("invalid ISO 9660 name %q", file.Path)
+		}
+		if slices.ContainsFunc(rootFiles, func(f *isoFile) bool { return f.name == file.Path }) {
+			return nil, fmt.Errorf("duplicate file %q in the ISO image", file.Path)
+		}
+		rootFiles = append(rootFiles, &isoFile{name: file.Path, data: file.Data})
+	}
+	slices.SortFunc(rootFiles, func(a, b *isoFile) int { return strings.Compare(a.name, b.name) })
+
+	rootDirSize := isoRootDirSize(rootFiles)
+	catalogSector := isoRootDirSector + rootDirSize/isoSectorSize
+	next := catalogSector + 1
+	var espFile *isoFile
+	for _, file := range rootFiles {
+		file.sector = next
+		next += isoSectors(len(file.data))
+		if file.name == espFileName {
+			espFile = file
+		}
+	}
+	totalSectors := next
+	img := make([]byte, totalSectors*isoSectorSize)
+
+	writeMBR(img[:mbrSectorSize], espFile.sector*isoSectorsPerMBRBlock, (len(esp)+mbrSectorSize-1)/mbrSectorSize)
+	writePrimaryVolumeDescriptor(isoSector(img, isoPVDSector), volumeID, totalSectors, rootDirSize)
+	writeBootRecord(isoSector(img, isoBootRecordSector), catalogSector)
+	writeVolumeDescriptorHeader(isoSector(img, isoTerminatorSector), 0xff)
+	writePathTable(isoSector(img, isoLPathTableSector), binary.LittleEndian)
+	writePathTable(isoSector(img, isoMPathTableSector), binary.BigEndian)
+	writeRootDir(img[isoRootDirSector*isoSectorSize:catalogSector*isoSectorSize], rootDirSize, rootFiles)
+	writeBootCatalog(isoSector(img, catalogSector), espFile.sector, len(esp))
+	for _, file := range rootFiles {
+		copy(img[file.sector*isoSectorSize:], file.data)
+	}
+	return img, nil
+}
+
+// isoVolumeID returns the ISO 9660 volume identifier of the boot media of a site, e.g. SITE_1 for site-1.
+func isoVolumeID(name string) string {
+	volumeID := isoVolumeIDRegexp.ReplaceAllString(strings.ToUpper(name), "_")
+	return volumeID[:min(len(volumeID), isoVolumeIDSize)]
+}
+
+func isoSector(img []byte, sector int) []byte {
+	return img[sector*isoSectorSize : (sector+1)*isoSectorSize]
+}
+
+func isoSectors(size int) int {
+	return (size + isoSectorSize - 1) / isoSectorSize
+}
+
+// isoRootDirSize returns the size of the root directory, in whole sectors as directory records cannot span
+// sectors.
+func isoRootDirSize(files []*isoFile) int {
+	sectors, used := 1, 2*isoDirRecordLen(1)
+	for _, file := range files {
+		recordLen := isoDirRecordLen(len(file.name) + 2)
+		if used+recordLen > isoSectorSize {
+			sectors++
+			used = 0
+		}
+		used += recordLen
+	}
+	return sectors * isoSectorSize
+}
+
+// isoDirRecordLen returns the length of a directory record, padded to an even length.
+func isoDirRecordLen(nameLen int) int {
+	return isoDirRecordSize + nameLen + (nameLen+1)%2
+}
+
+func putBothEndian32(b []byte, v int) {
+	binary.LittleEndian.PutUint32(b[0:], uint32(v)) //nolint:gosec // ISO sizes fit in 32 bits
+	binary.BigEndian.PutUint32(b[4:], uint32(v))    //nolint:gosec // ISO sizes fit in 32 bits
+}
+
+func putBothEndian16(b []byte, v int) {
+	binary.LittleEndian.PutUint16(b[0:], uint16(v)) //nolint:gosec // small constants
+	binary.BigEndian.PutUint16(b[2:], uint16(v))    //nolint:gosec // small constants
+}
+
+// putPadded copies s to b, padded with spaces.
+func putPadded(b []byte, s string) {
+	for i := range b {
+		b[i] = ' '
+	}
+	copy(b, s)
+}
+
+func writeVolumeDescriptorHeader(sector []byte, descriptorType byte) {
+	sector[0] = descriptorType
+	copy(sector[1:6], "CD001")
+	sector[6] = 1
+}
+
+func writePrimaryVolumeDescriptor(sector []byte, volumeID string, totalSectors, rootDirSize int) {
+	writeVolumeDescriptorHeader(sector, 1)
+	putPadded(sector[8:40], "")
+	putPadded(sector[40:72], volumeID)
+	putBothEndian32(sector[80:], totalSectors)
+	putBothEndian16(sector[120:], 1) // volume set size
+	putBothEndian16(sector[124:], 1) // volume sequence number
+	putBothEndian16(sector[128:], isoSectorSize)
+	putBothEndian32(sector[132:], isoPathTableSize)
+	binary.LittleEndian.PutUint32(sector[140:], isoLPathTableSector)
+	binary.BigEndian.PutUint32(sector[148:], isoMPathTableSector)
+	writeDirRecord(sector[156:], "\x00", isoRootDirSector, rootDirSize, isoDirFlag)
+	putPadded(sector[190:318], "") // volume set
+	putPadded(sector[318:446], "") // publisher
+	putPadded(sector[446:574], "") // data preparer
+	putPadded(sector[574:702], "DKAM")
+	putPadded(sector[702:813], "") // copyright, abstract and bibliographic files
+	// Creation, modification, expiration and effective dates are not specified.
+	for _, offset := range []int{813, 830, 847, 864} {
+		copy(sector[offset:offset+16], "0000000000000000")
+	}
+	sector[881] = 1 // file structure version
+}
+
+func writeBootRecord(sector []byte, catalogSector int) {
+	writeVolumeDescriptorHeader(sector, 0)
+	copy(sector[7:39], elToritoSystemID)
+	binary.LittleEndian.PutUint32(sector[71:], uint32(catalogSector)) //nolint:gosec // small sector number
+}
+
+// writePathTable writes the path table of the image, which has only the root directory.
+func writePathTable(sector []byte, order binary.ByteOrder) {
+	sector[0] = 1 // length of the directory identifier
+	order.PutUint32(sector[2:], isoRootDirSector)
+	order.PutUint16(sector[6:], 1) // parent directory number
+}
+
+func writeRootDir(dir []byte, size int, files []*isoFile) {
+	offset := writeDirRecord(dir[0:], "\x00", isoRootDirSector, size, isoDirFlag)
+	offset += writeDirRecord(dir[offset:], "\x01", isoRootDirSector, size, isoDirFlag)
+	for _, file := range files {
+		name := file.name + ";1"
+		if offset%isoSectorSize+isoDirRecordLen(len(name)) > isoSectorSize {
+			offset += isoSectorSize - offset%isoSectorSize
+		}
+		offset += writeDirRecord(dir[offset:], name, file.sector, len(file.data), 0)
+	}
+}
+
+// writeDirRecord writes a directory record and returns its length. The recording date is not specified.
+func writeDirRecord(record []byte, name string, sector, size int, flags byte) int {
+	recordLen := isoDirRecordLen(len(name))
+	record[0] = byte(recordLen)
+	putBothEndian32(record[2:], sector)
+	putBothEndian32(record[10:], size)
+	record[25] = flags
+	putBothEndian16(record[28:], 1) // volume sequence number
+	record[32] = byte(len(name))
+	copy(record[33:], name)
+	return recordLen
+}
+
+// writeBootCatalog writes an El Torito boot catalog with a single no emulation entry for UEFI, loading the EFI
+// system partition image.
+func writeBootCatalog(sector []byte, espSector, espSize int) {
+	validation := sector[:elToritoEntrySize]
+	validation[0] = 1 // header ID
+	validation[1] = elToritoEFIPlatform
+	validation[30] = 0x55
+	validation[31] = 0xaa
+	var sum uint16
+	for i := 0; i < elToritoEntrySize; i += 2 {
+		sum += binary.LittleEndian.Uint16(validation[i:])
+	}
+	binary.LittleEndian.PutUint16(validation[28:], -sum)
+
+	entry := sector[elToritoEntrySize : 2*elToritoEntrySize]
+	entry[0] = elToritoBootable
+	// The sector count is in virtual 512 bytes sectors, firmware reads the whole image if it does not fit.
+	if count := (espSize + mbrSectorSize - 1) / mbrSectorSize; count <= 0xffff {
+		binary.LittleEndian.PutUint16(entry[6:], uint16(count))
+	}
+	binary.LittleEndian.PutUint32(entry[8:], uint32(espSector)) //nolint:gosec // small sector number
+}
+
+// writeMBR writes a master boot record with an EFI system partition over the EFI system partition image, for the
+// firmware to boot the image from a USB disk.
+func writeMBR(sector []byte, start, count int) {
+	partition := sector[mbrPartitionOffset : mbrPartitionOffset+16]
+	// CHS addresses are not used, LBA only.
+	copy(partition[1:4], []byte{0xfe, 0xff, 0xff})
+	partition[4] = mbrESPType
+	copy(partition[5:8], []byte{0xfe, 0xff, 0xff})
+	binary.LittleEndian.PutUint32(partition[8:], uint32(start))  //nolint:gosec // ISO sizes fit in 32 bits
+	binary.LittleEndian.PutUint32(partition[12:], uint32(count)) //nolint:gosec // ISO sizes fit in 32 bits
+	sector[510] = 0x55
+	sector[511] = 0xaa
+}
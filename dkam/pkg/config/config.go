@@ -7,6 +7,10 @@ package config
 import (
 	"context"
 	"flag"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -26,6 +30,11 @@ const (
 
 	// DownloadPath is the directory path for downloading artifacts.
 	DownloadPath = "/tmp"
+
+	// ArchX86_64 is the architecture of x86 edge nodes, as named in the uOS artifacts.
+	ArchX86_64 = "x86_64"
+	// ArchAarch64 is the architecture of arm64 edge nodes, as named in the uOS artifacts.
+	ArchAarch64 = "aarch64"
 )
 
 // InfraConfig holds the infrastructure configuration settings.
@@ -86,10 +95,143 @@ type InfraConfig struct {
 	ENManifest ENManifest
 
 	EMBImageURL string `mapstructure:"embImageURL" yaml:"embImageURL"`
+	// EMBImageURLs maps the architectures other than x86_64 to the path of their uOS image on the CDN,
+	// e.g. aarch64: files-edge-orch/.../emb_uos_aarch64.tar.gz. The x86_64 uOS image is EMBImageURL.
+	EMBImageURLs map[string]string `mapstructure:"embImageURLs" yaml:"embImageURLs"`
 	// Disable AOCO config
 	DisableCOProfile   bool `mapstructure:"disableCoProfile" yaml:"disableCoProfile"`
 	DisableO11YProfile bool `mapstructure:"disableO11YProfile" yaml:"disableO11YProfile"`
 	SkipOSProvisioning bool `mapstructure:"skipOSProvisioning" yaml:"skipOSProvisioning"`
+
+	// BootMedia lists the sites to generate bootable ISO/USB images for, to onboard edge nodes on networks
+	// without DHCP or PXE.
+	BootMedia []BootMedia `mapstructure:"bootMedia" yaml:"bootMedia"`
+
+	// Signing selects the backend holding the Secure Boot db key, which signs iPXE and the uOS artifacts.
+	Signing Signing `mapstructure:"signing" yaml:"signing"`
+}
+
+const (
+	// SigningBackendFile signs with a key file. Without a key file, DKAM generates a key at every start.
+	SigningBackendFile = "file"
+	// SigningBackendPKCS11 signs with a key of a PKCS#11 token, e.g. an HSM.
+	SigningBackendPKCS11 = "pkcs11"
+)
+
+// Signing holds the settings of the signing backend.
+type Signing struct {
+	// Backend is file or pkcs11, file if empty.
+	Backend string `mapstructure:"backend" yaml:"backend"`
+	// KeyFile is the private key of the file backend, in PEM.
+	KeyFile string `mapstructure:"keyFile" yaml:"keyFile"`
+	// CertificateFile is the certificate of the key, in PEM or DER. It is required with a key file or a token.
+	CertificateFile string `mapstructure:"certificateFile" yaml:"certificateFile"`
+	// PKCS11Module is the path of the PKCS#11 module of the token, e.g. /usr/lib/softhsm/libsofthsm2.so.
+	PKCS11Module string `mapstructure:"pkcs11Module" yaml:"pkcs11Module"`
+	// PKCS11Token and PKCS11Key are the labels of the token and of the private key in the token.
+	PKCS11Token string `mapstructure:"pkcs11Token" yaml:"pkcs11Token"`
+	PKCS11Key   string `mapstructure:"pkcs11Key" yaml:"pkcs11Key"`
+	// PKCS11PINFile is the file holding the user PIN of the token, e.g. mounted from a secret.
+	PKCS11PINFile string `mapstructure:"pkcs11PinFile" yaml:"pkcs11PinFile"`
+}
+
+// GeneratedKeys tells whether DKAM generates the signing keys, when no key is configured.
+func (s Signing) GeneratedKeys() bool {
+	return (s.Backend == "" || s.Backend == SigningBackendFile) && s.KeyFile == ""
+}
+
+// Validate returns an error if the settings of the signing backend are incomplete.
+func (s Signing) Validate() error {
+	switch s.Backend {
+	case "", SigningBackendFile:
+		if s.KeyFile != "" && s.CertificateFile == "" {
+			return inv_errors.Errorfc(codes.InvalidArgument, "Missing certificate of the signing key file")
+		}
+	case SigningBackendPKCS11:
+		if s.PKCS11Module == "" || s.PKCS11Token == "" || s.PKCS11Key == "" || s.CertificateFile == "" {
+			return inv_errors.Errorfc(codes.InvalidArgument,
+				"PKCS#11 signing requires the module, the token and key labels and the certificate of the key")
+		}
+	default:
+		return inv_errors.Errorfc(codes.InvalidArgument, "Unsupported signing backend %q", s.Backend)
+	}
+	return nil
+}
+
+// BootMedia holds the network settings of the iPXE embedded in the boot media of a site.
+type BootMedia struct {
+	// Name of the site or tenant, used to name the image.
+	Name string `mapstructure:"name" yaml:"name"`
+	// Interface is the index of the iPXE network interface to boot from, e.g. 0 for net0.
+	Interface int `mapstructure:"interface" yaml:"interface"`
+	// IP is the static address of the edge node in CIDR notation. DHCP is used if it is empty.
+	IP      string   `mapstructure:"ip" yaml:"ip"`
+	Gateway string   `mapstructure:"gateway" yaml:"gateway"`
+	DNS     []string `mapstructure:"dns" yaml:"dns"`
+	// VLAN is the ID of the VLAN of the management network, if it is tagged.
+	VLAN int `mapstructure:"vlan" yaml:"vlan"`
+}
+
+const (
+	maxBootMediaInterface = 31
+	maxVLANID             = 4094
+)
+
+var bootMediaNameRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,30}[a-z0-9])?$`)
+
+// Validate returns an error if the boot media settings cannot be embedded in iPXE.
+func (b BootMedia) Validate() error {
+	if !bootMediaNameRegexp.MatchString(b.Name) {
+		return inv_errors.Errorfc(codes.InvalidArgument,
+			"Invalid boot media name %q, expected up to 32 lowercase alphanumeric characters or '-'", b.Name)
+	}
+	if b.Interface < 0 || b.Interface > maxBootMediaInterface {
+		return inv_errors.Errorfc(codes.InvalidArgument, "Invalid interface %d of boot media %s", b.Interface, b.Name)
+	}
+	if b.VLAN < 0 || b.VLAN > maxVLANID {
+		return inv_errors.Errorfc(codes.InvalidArgument, "Invalid VLAN %d of boot media %s", b.VLAN, b.Name)
+	}
+	if b.IP == "" {
+		if b.Gateway != "" || len(b.DNS) != 0 {
+			return inv_errors.Errorfc(codes.InvalidArgument,
+				"Gateway and DNS of boot media %s require a static IP", b.Name)
+		}
+		return nil
+	}
+	prefix, err := netip.ParsePrefix(b.IP)
+	if err != nil || !prefix.Addr().Is4() {
+		return inv_errors.Errorfc(codes.InvalidArgument,
+			"Invalid IP %q of boot media %s, expected an IPv4 address in CIDR notation", b.IP, b.Name)
+	}
+	if b.Gateway != "" {
+		gateway, gwErr := netip.ParseAddr(b.Gateway)
+		if gwErr != nil || !gateway.Is4() || !prefix.Masked().Contains(gateway) {
+			return inv_errors.Errorfc(codes.InvalidArgument,
+				"Invalid gateway %q of boot media %s, expected an IPv4 address in %s", b.Gateway, b.Name, b.IP)
+		}
+	}
+	for _, dns := range b.DNS {
+		if addr, dnsErr := netip.ParseAddr(dns); dnsErr != nil || !addr.Is4() {
+			return inv_errors.Errorfc(codes.InvalidArgument, "Invalid DNS server %q of boot media %s", dns, b.Name)
+		}
+	}
+	return nil
+}
+
+// validateBootMedia returns an error if the settings of a boot media are invalid or two boot media have the
+// same name.
+func validateBootMedia(bootMedia []BootMedia) error {
+	names := make(map[string]bool, len(bootMedia))
+	for _, media := range bootMedia {
+		if err := media.Validate(); err != nil {
+			return err
+		}
+		if names[media.Name] {
+			return inv_errors.Errorfc(codes.InvalidArgument, "Duplicate boot media %s", media.Name)
+		}
+		names[media.Name] = true
+	}
+	return nil
 }
 
 // ENManifest represents the Edge Node Agents release manifest.
@@ -125,6 +267,50 @@ var (
 	ScriptPath             = "/home/appuser/pkg/script"
 )
 
+// NormalizeArchitecture returns the name DKAM gives to the architecture in the uOS and iPXE artifacts,
+// accepting the Go and Debian names (amd64, arm64) as well. It returns an empty string if the architecture
+// is not supported.
+func NormalizeArchitecture(arch string) string {
+	switch strings.ToLower(strings.TrimSpace(arch)) {
+	case ArchX86_64, "amd64", "x86-64":
+		return ArchX86_64
+	case ArchAarch64, "arm64":
+		return ArchAarch64
+	default:
+		return ""
+	}
+}
+
+// Architectures returns the architectures DKAM prepares the uOS and iPXE artifacts for, x86_64 first.
+// Unsupported architectures in EMBImageURLs are left out.
+func (c InfraConfig) Architectures() []string {
+	archs := []string{ArchX86_64}
+	for arch := range c.EMBImageURLs {
+		arch = NormalizeArchitecture(arch)
+		if arch != "" && !slices.Contains(archs, arch) {
+			archs = append(archs, arch)
+		}
+	}
+	slices.Sort(archs[1:])
+	return archs
+}
+
+// UOSImageURL returns the path of the uOS image of the architecture on the CDN.
+func (c InfraConfig) UOSImageURL(arch string) string {
+	switch arch = NormalizeArchitecture(arch); arch {
+	case "":
+		return ""
+	case ArchX86_64:
+		return c.EMBImageURL
+	}
+	for key, imageURL := range c.EMBImageURLs {
+		if NormalizeArchitecture(key) == arch {
+			return imageURL
+		}
+	}
+	return ""
+}
+
 // Read reads and validates the configuration from the config file.
 func Read() error {
 	zlog.Info().Msgf("Config file path: %s", *FlagConfigFilePath)
@@ -149,6 +335,16 @@ func Read() error {
 			return argErr
 		}
 
+		if signingErr := config.Signing.Validate(); signingErr != nil {
+			zlog.Error().Err(signingErr).Msg("")
+			return signingErr
+		}
+
+		if bootMediaErr := validateBootMedia(config.BootMedia); bootMediaErr != nil {
+			zlog.Error().Err(bootMediaErr).Msg("")
+			return bootMediaErr
+		}
+
 		enManifestData, err := DownloadENManifest(config.ENManifestRepo, config.ENAgentManifestTag)
 		if err != nil {
 			return err
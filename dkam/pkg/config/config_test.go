@@ -108,3 +108,116 @@ func TestSetGetInfraConfig(t *testing.T) {
 	got := config.GetInfraConfig()
 	require.Equal(t, testInfraConfig, got)
 }
+
+func TestArchitectures(t *testing.T) {
+	t.Run("X86Only", func(t *testing.T) {
+		infraConfig := config.InfraConfig{EMBImageURL: "uos/x86_64"}
+		require.Equal(t, []string{config.ArchX86_64}, infraConfig.Architectures())
+		require.Equal(t, "uos/x86_64", infraConfig.UOSImageURL("amd64"))
+		require.Empty(t, infraConfig.UOSImageURL(config.ArchAarch64))
+	})
+
+	t.Run("MultiArch", func(t *testing.T) {
+		infraConfig := config.InfraConfig{
+			EMBImageURL: "uos/x86_64",
+			EMBImageURLs: map[string]string{
+				"arm64":   "uos/aarch64",
+				"x86_64":  "uos/other",
+				"riscv64": "uos/riscv64",
+			},
+		}
+		require.Equal(t, []string{config.ArchX86_64, config.ArchAarch64}, infraConfig.Architectures())
+		require.Equal(t, "uos/x86_64", infraConfig.UOSImageURL(config.ArchX86_64))
+		require.Equal(t, "uos/aarch64", infraConfig.UOSImageURL(config.ArchAarch64))
+		require.Empty(t, infraConfig.UOSImageURL("riscv64"))
+	})
+}
+
+func TestNormalizeArchitecture(t *testing.T) {
+	for arch, want := range map[string]string{
+		"x86_64":  config.ArchX86_64,
+		"amd64":   config.ArchX86_64,
+		"AMD64":   config.ArchX86_64,
+		"aarch64": config.ArchAarch64,
+		"arm64":   config.ArchAarch64,
+		"riscv64": "",
+		"":        "",
+	} {
+		require.Equal(t, want, config.NormalizeArchitecture(arch), arch)
+	}
+}
+
+func TestBootMediaValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		media   config.BootMedia
+		wantErr bool
+	}{
+		{name: "DHCP", media: config.BootMedia{Name: "site-1"}},
+		{name: "StaticIP", media: config.BootMedia{
+			Name: "site-1", Interface: 1, IP: "10.0.0.10/24", Gateway: "10.0.0.1", DNS: []string{"10.0.0.2"}, VLAN: 100,
+		}},
+		{name: "InvalidName", media: config.BootMedia{Name: "Site_1"}, wantErr: true},
+		{name: "EmptyName", media: config.BootMedia{}, wantErr: true},
+		{name: "InvalidInterface", media: config.BootMedia{Name: "site-1", Interface: -1}, wantErr: true},
+		{name: "InvalidVLAN", media: config.BootMedia{Name: "site-1", VLAN: 4095}, wantErr: true},
+		{name: "IPWithoutPrefix", media: config.BootMedia{Name: "site-1", IP: "10.0.0.10"}, wantErr: true},
+		{name: "IPv6", media: config.BootMedia{Name: "site-1", IP: "fd00::10/64"}, wantErr: true},
+		{name: "GatewayOutsideSubnet", media: config.BootMedia{
+			Name: "site-1", IP: "10.0.0.10/24", Gateway: "10.0.1.1",
+		}, wantErr: true},
+		{name: "InvalidDNS", media: config.BootMedia{
+			Name: "site-1", IP: "10.0.0.10/24", DNS: []string{"dns.example.com"},
+		}, wantErr: true},
+		{name: "DNSWithoutIP", media: config.BootMedia{Name: "site-1", DNS: []string{"10.0.0.2"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.media.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSigningValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		signing       config.Signing
+		wantErr       bool
+		generatedKeys bool
+	}{
+		{name: "Default", signing: config.Signing{}, generatedKeys: true},
+		{name: "GeneratedFileKey", signing: config.Signing{Backend: config.SigningBackendFile}, generatedKeys: true},
+		{name: "FileKey", signing: config.Signing{KeyFile: "db.key", CertificateFile: "db.crt"}},
+		{name: "FileKeyWithoutCertificate", signing: config.Signing{KeyFile: "db.key"}, wantErr: true},
+		{name: "PKCS11", signing: config.Signing{
+			Backend:         config.SigningBackendPKCS11,
+			PKCS11Module:    "/usr/lib/softhsm/libsofthsm2.so",
+			PKCS11Token:     "dkam",
+			PKCS11Key:       "db",
+			CertificateFile: "db.crt",
+		}},
+		{name: "PKCS11WithoutToken", signing: config.Signing{
+			Backend:         config.SigningBackendPKCS11,
+			PKCS11Module:    "/usr/lib/softhsm/libsofthsm2.so",
+			PKCS11Key:       "db",
+			CertificateFile: "db.crt",
+		}, wantErr: true},
+		{name: "Unsupported", signing: config.Signing{Backend: "kms"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.signing.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.generatedKeys, tt.signing.GeneratedKeys())
+		})
+	}
+}
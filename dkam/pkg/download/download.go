@@ -33,43 +33,67 @@ const (
 	UOSFileName = "emb_uos_x86_64.tar.gz"
 )
 
-//nolint:revive,cyclop // Handles validation, download, and error handling
+// UOSFileNameForArch returns the filename of the micro OS archive of the architecture.
+func UOSFileNameForArch(arch string) string {
+	return "emb_uos_" + arch + ".tar.gz"
+}
+
+// DownloadMicroOS downloads the micro OS archive of every architecture of the infra config.
 func DownloadMicroOS(ctx context.Context) (bool, error) {
 	zlog.Info().Msgf("Inside Download and sign artifact... %s", config.DownloadPath)
-	fileServerAddress := config.GetInfraConfig().CDN
-	if fileServerAddress == "" {
+	infraConfig := config.GetInfraConfig()
+	if infraConfig.CDN == "" {
 		invErr := inv_errors.Errorf("FileServerURL is not set in the configuration")
 		zlog.Err(invErr).Msg("")
 		return false, invErr
 	}
 
-	embImgURL := config.GetInfraConfig().EMBImageURL
+	for _, arch := range infraConfig.Architectures() {
+		if err := downloadMicroOSForArch(ctx, infraConfig, arch); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// MicroOSURL returns the URL of the micro OS archive of the architecture on the CDN.
+func MicroOSURL(infraConfig config.InfraConfig, arch string) (string, error) {
+	embImgURL := infraConfig.UOSImageURL(arch)
 	if embImgURL == "" {
-		invErr := inv_errors.Errorf("EMBImageURL is not set in the configuration")
+		invErr := inv_errors.Errorf("EMBImageURL is not set in the configuration for %s", arch)
 		zlog.Err(invErr).Msg("")
-		return false, invErr
+		return "", invErr
 	}
 
-	uOSUrl, err := url.JoinPath(fileServerAddress, embImgURL)
+	uOSUrl, err := url.JoinPath(infraConfig.CDN, embImgURL)
 	if err != nil {
 		zlog.InfraSec().Error().Err(err).Msgf("Failed to generate MicroOS URL")
-		return false, err
+		return "", err
 	}
 	if !strings.HasPrefix(uOSUrl, "http://") && !strings.HasPrefix(uOSUrl, "https://") {
 		uOSUrl = "https://" + uOSUrl
 	}
-	zlog.InfraSec().Info().Msgf("Downloading uOS from URL: %s", uOSUrl)
+	return uOSUrl, nil
+}
+
+//nolint:cyclop // Handles validation, download, and error handling
+func downloadMicroOSForArch(ctx context.Context, infraConfig config.InfraConfig, arch string) error {
+	uOSUrl, err := MicroOSURL(infraConfig, arch)
+	if err != nil {
+		return err
+	}
+	zlog.InfraSec().Info().Msgf("Downloading %s uOS from URL: %s", arch, uOSUrl)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uOSUrl, http.NoBody)
 	if err != nil {
 		zlog.InfraSec().Error().Err(err).Msgf("Failed to create GET request to release server: %v", err)
-		return false, err
+		return err
 	}
 
 	// Perform the HTTP GET request
 	resp, err := Client.Do(req)
 	if err != nil {
 		zlog.InfraSec().Error().Err(err).Msgf("Failed to connect to release server to download package manifest: %v", err)
-		return false, err
+		return err
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -77,12 +101,12 @@ func DownloadMicroOS(ctx context.Context) (bool, error) {
 		}
 	}()
 
-	uOSFilePath := config.DownloadPath + "/" + UOSFileName
+	uOSFilePath := config.DownloadPath + "/" + UOSFileNameForArch(arch)
 
 	file, fileerr := os.Create(uOSFilePath)
 	if fileerr != nil {
 		zlog.InfraSec().Error().Err(fileerr).Msgf("Failed to create file:%v", fileerr)
-		return false, fileerr
+		return fileerr
 	}
 	defer func() {
 		if err := file.Close(); err != nil {
@@ -97,5 +121,5 @@ func DownloadMicroOS(ctx context.Context) (bool, error) {
 	}
 
 	zlog.InfraSec().Info().Msg("File downloaded")
-	return true, nil
+	return nil
 }
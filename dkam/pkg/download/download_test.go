@@ -90,3 +90,92 @@ func TestDownloadMicroOS_HTTPError(t *testing.T) {
 		t.Fatalf("expected HTTP error")
 	}
 }
+
+// PathRoundTripper implements http.RoundTripper for testing, returning the path of the request as the body.
+type PathRoundTripper struct{}
+
+func (PathRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(req.URL.Path)),
+	}, nil
+}
+
+func TestDownloadMicroOS_MultiArch(t *testing.T) {
+	oldClient := download.Client
+	download.Client = &http.Client{Transport: PathRoundTripper{}}
+	defer func() { download.Client = oldClient }()
+
+	cfg := config.InfraConfig{
+		CDN:          "localhost",
+		EMBImageURL:  "uos/x86_64",
+		EMBImageURLs: map[string]string{"arm64": "uos/aarch64"},
+	}
+	config.SetInfraConfig(cfg)
+
+	ok, err := download.DownloadMicroOS(context.Background())
+	if !ok || err != nil {
+		t.Fatalf("expected success, got err: %v", err)
+	}
+
+	for arch, want := range map[string]string{
+		config.ArchX86_64:  "/uos/x86_64",
+		config.ArchAarch64: "/uos/aarch64",
+	} {
+		filePath := config.DownloadPath + "/" + download.UOSFileNameForArch(arch)
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("expected file of %s to be created, got err: %v", arch, err)
+		}
+		if string(data) != want {
+			t.Fatalf("file contents of %s mismatch: got %s, want %s", arch, string(data), want)
+		}
+	}
+	if download.UOSFileNameForArch(config.ArchX86_64) != download.UOSFileName {
+		t.Fatalf("x86_64 uOS file name mismatch: got %s", download.UOSFileNameForArch(config.ArchX86_64))
+	}
+}
+
+func TestDownloadMicroOS_MissingArchImage(t *testing.T) {
+	cfg := config.InfraConfig{
+		CDN:          "localhost",
+		EMBImageURL:  "uos/x86_64",
+		EMBImageURLs: map[string]string{config.ArchAarch64: ""},
+	}
+	config.SetInfraConfig(cfg)
+
+	oldClient := download.Client
+	download.Client = &http.Client{Transport: PathRoundTripper{}}
+	defer func() { download.Client = oldClient }()
+
+	ok, err := download.DownloadMicroOS(context.Background())
+	if ok || err == nil {
+		t.Fatalf("expected failure due to missing aarch64 image")
+	}
+}
+
+func TestMicroOSURL(t *testing.T) {
+	cfg := config.InfraConfig{
+		CDN:          "files.example.com",
+		EMBImageURL:  "uos/1.0.0/emb_uos_x86_64.tar.gz",
+		EMBImageURLs: map[string]string{"arm64": "uos/1.0.0/emb_uos_aarch64.tar.gz"},
+	}
+	for arch, want := range map[string]string{
+		config.ArchX86_64:  "https://files.example.com/uos/1.0.0/emb_uos_x86_64.tar.gz",
+		config.ArchAarch64: "https://files.example.com/uos/1.0.0/emb_uos_aarch64.tar.gz",
+	} {
+		got, err := download.MicroOSURL(cfg, arch)
+		if err != nil || got != want {
+			t.Fatalf("URL of the %s uOS mismatch: got %s (%v), want %s", arch, got, err, want)
+		}
+	}
+
+	cfg.CDN = "http://files.example.com"
+	if got, err := download.MicroOSURL(cfg, config.ArchX86_64); err != nil ||
+		got != "http://files.example.com/uos/1.0.0/emb_uos_x86_64.tar.gz" {
+		t.Fatalf("URL with a scheme mismatch: got %s (%v)", got, err)
+	}
+	if _, err := download.MicroOSURL(cfg, "riscv64"); err == nil {
+		t.Fatalf("expected failure for an unsupported architecture")
+	}
+}
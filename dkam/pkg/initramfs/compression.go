@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package initramfs
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is the compression of an archive.
+type Compression string
+
+const (
+	// CompressionNone is the compression of an uncompressed archive.
+	CompressionNone Compression = "none"
+	// CompressionGzip is the gzip compression.
+	CompressionGzip Compression = "gzip"
+	// CompressionZstd is the Zstandard compression.
+	CompressionZstd Compression = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	xzMagic   = []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}
+)
+
+// decompress returns the decompressed content of r and its compression.
+func decompress(r io.Reader) (io.ReadCloser, Compression, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(xzMagic))
+	if err != nil && err != io.EOF {
+		return nil, "", err
+	}
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		zr, gzipErr := gzip.NewReader(br)
+		if gzipErr != nil {
+			return nil, "", gzipErr
+		}
+		return zr, CompressionGzip, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, zstdErr := zstd.NewReader(br, zstd.WithDecoderConcurrency(1))
+		if zstdErr != nil {
+			return nil, "", zstdErr
+		}
+		return zr.IOReadCloser(), CompressionZstd, nil
+	case bytes.HasPrefix(magic, xzMagic):
+		return nil, "", fmt.Errorf("unsupported xz compression")
+	default:
+		return io.NopCloser(br), CompressionNone, nil
+	}
+}
+
+// compress returns a writer compressing to w. The compressed content only depends on the uncompressed one: the
+// gzip header holds no name nor modification time, and zstd compresses on a single goroutine.
+func compress(w io.Writer, compression Compression) (io.WriteCloser, error) {
+	switch compression {
+	case CompressionNone:
+		return nopWriteCloser{w}, nil
+	case CompressionGzip:
+		return gzip.NewWriterLevel(w, gzip.BestCompression)
+	case CompressionZstd:
+		return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", compression)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,339 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package initramfs
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"strconv"
+	"time"
+)
+
+// The newc cpio format, the format of the Linux initramfs, see
+// https://docs.kernel.org/driver-api/early-userspace/buffer-format.html.
+const (
+	cpioMagic       = "070701"
+	cpioMagicCRC    = "070702"
+	cpioHeaderSize  = 110
+	cpioTrailerName = "TRAILER!!!"
+	// cpioBlockSize is the size the archive is padded to, as by GNU cpio.
+	cpioBlockSize = 512
+
+	cpioTypeMask = 0o170000
+	cpioSocket   = 0o140000
+	cpioSymlink  = 0o120000
+	cpioRegular  = 0o100000
+	cpioBlock    = 0o060000
+	cpioDir      = 0o040000
+	cpioChar     = 0o020000
+	cpioFIFO     = 0o010000
+	cpioSetuid   = 0o4000
+	cpioSetgid   = 0o2000
+	cpioSticky   = 0o1000
+)
+
+// cpioModeBits maps the setuid, setgid and sticky bits of the cpio modes to the file modes.
+var cpioModeBits = []struct {
+	cpio uint32
+	mode fs.FileMode
+}{
+	{cpio: cpioSetuid, mode: fs.ModeSetuid},
+	{cpio: cpioSetgid, mode: fs.ModeSetgid},
+	{cpio: cpioSticky, mode: fs.ModeSticky},
+}
+
+// cpioHeader is the header of a newc cpio entry.
+type cpioHeader struct {
+	ino, mode, uid, gid, nlink, mtime, size  uint32
+	devmajor, devminor, rdevmajor, rdevminor uint32
+	name                                     string
+}
+
+// cpioReader reads the entries of a newc cpio archive.
+type cpioReader struct {
+	r io.Reader
+	// offset is the offset in the archive, the entries and their content being aligned on 4 bytes.
+	offset int64
+	// remaining is the size of the content of the current entry left to read, and padding its padding.
+	remaining int64
+	padding   int64
+}
+
+func newCPIOReader(r io.Reader) *cpioReader {
+	return &cpioReader{r: r}
+}
+
+// Next returns the header of the next entry, io.EOF after the trailer.
+func (cr *cpioReader) Next() (*header, error) {
+	if err := cr.skip(cr.remaining + cr.padding); err != nil {
+		return nil, err
+	}
+	cr.remaining, cr.padding = 0, 0
+
+	var raw [cpioHeaderSize]byte
+	if err := cr.readFull(raw[:]); err != nil {
+		return nil, err
+	}
+	if magic := string(raw[:6]); magic != cpioMagic && magic != cpioMagicCRC {
+		return nil, fmt.Errorf("invalid cpio header magic %q", magic)
+	}
+	var fields [13]uint32
+	for i := range fields {
+		field, err := strconv.ParseUint(string(raw[6+8*i:14+8*i]), 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpio header: %w", err)
+		}
+		fields[i] = uint32(field)
+	}
+	h := &cpioHeader{
+		ino: fields[0], mode: fields[1], uid: fields[2], gid: fields[3], nlink: fields[4], mtime: fields[5],
+		size: fields[6], devmajor: fields[7], devminor: fields[8], rdevmajor: fields[9], rdevminor: fields[10],
+	}
+	nameSize := int64(fields[11])
+	if nameSize == 0 {
+		return nil, fmt.Errorf("invalid cpio header: empty name")
+	}
+	name := make([]byte, nameSize)
+	if err := cr.readFull(name); err != nil {
+		return nil, err
+	}
+	h.name = string(bytes.TrimRight(name, "\x00"))
+	if err := cr.skip(pad4(cr.offset)); err != nil {
+		return nil, err
+	}
+
+	if h.name == cpioTrailerName {
+		return nil, cr.readTrailerPadding()
+	}
+	cr.remaining, cr.padding = int64(h.size), pad4(int64(h.size))
+	entry := &header{
+		Name:    h.name,
+		Mode:    cpioFileMode(h.mode),
+		Size:    int64(h.size),
+		ModTime: time.Unix(int64(h.mtime), 0),
+		cpio:    h,
+	}
+	if entry.Mode.Type() == fs.ModeSymlink {
+		target := make([]byte, h.size)
+		if _, err := io.ReadFull(cr, target); err != nil {
+			return nil, err
+		}
+		entry.Linkname, entry.Size = string(target), 0
+	}
+	return entry, nil
+}
+
+// Read reads the content of the current entry.
+func (cr *cpioReader) Read(p []byte) (int, error) {
+	if cr.remaining == 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > cr.remaining {
+		p = p[:cr.remaining]
+	}
+	n, err := cr.r.Read(p)
+	cr.offset += int64(n)
+	cr.remaining -= int64(n)
+	if err == io.EOF && cr.remaining > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// readTrailerPadding reads the padding after the trailer, refusing archives concatenated to the first one.
+func (cr *cpioReader) readTrailerPadding() error {
+	buf := make([]byte, cpioBlockSize)
+	for {
+		n, err := cr.r.Read(buf)
+		if len(bytes.Trim(buf[:n], "\x00")) != 0 {
+			return fmt.Errorf("unsupported content after the cpio trailer")
+		}
+		if err == io.EOF {
+			return io.EOF
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (cr *cpioReader) readFull(p []byte) error {
+	n, err := io.ReadFull(cr.r, p)
+	cr.offset += int64(n)
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (cr *cpioReader) skip(n int64) error {
+	skipped, err := io.CopyN(io.Discard, cr.r, n)
+	cr.offset += skipped
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// cpioWriter writes a newc cpio archive. Entries of the read archive keep their inode, owner, links and devices,
+// the entries added to it are owned by root and numbered after the inodes of the archive.
+type cpioWriter struct {
+	w      io.Writer
+	offset int64
+	// remaining is the size of the content of the current entry left to write.
+	remaining int64
+	padding   int64
+	lastIno   uint32
+}
+
+func newCPIOWriter(w io.Writer) *cpioWriter {
+	return &cpioWriter{w: w}
+}
+
+// WriteHeader writes the header of an entry, and the target of a symbolic link as its content.
+func (cw *cpioWriter) WriteHeader(h *header) error {
+	if err := cw.finishEntry(); err != nil {
+		return err
+	}
+	var raw cpioHeader
+	if h.cpio != nil {
+		raw = *h.cpio
+	} else {
+		cw.lastIno++
+		raw = cpioHeader{ino: cw.lastIno, nlink: 1}
+		if h.Mode.IsDir() {
+			raw.nlink = 2
+		}
+	}
+	raw.name = h.Name
+	raw.mode = cpioMode(h.Mode)
+	raw.mtime = uint32(h.ModTime.Unix())
+	raw.size = uint32(h.Size)
+	if h.Mode.Type() == fs.ModeSymlink {
+		raw.size = uint32(len(h.Linkname))
+	}
+	cw.lastIno = max(cw.lastIno, raw.ino)
+	if err := cw.writeHeader(&raw); err != nil {
+		return err
+	}
+	cw.remaining, cw.padding = int64(raw.size), pad4(int64(raw.size))
+	if h.Mode.Type() == fs.ModeSymlink {
+		_, err := io.WriteString(cw, h.Linkname)
+		return err
+	}
+	return nil
+}
+
+// Write writes the content of the current entry.
+func (cw *cpioWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > cw.remaining {
+		return 0, fmt.Errorf("cpio entry content larger than its size")
+	}
+	n, err := cw.write(p)
+	cw.remaining -= int64(n)
+	return n, err
+}
+
+// Close writes the trailer and pads the archive.
+func (cw *cpioWriter) Close() error {
+	if err := cw.finishEntry(); err != nil {
+		return err
+	}
+	if err := cw.writeHeader(&cpioHeader{nlink: 1, name: cpioTrailerName}); err != nil {
+		return err
+	}
+	_, err := cw.write(make([]byte, (cpioBlockSize-cw.offset%cpioBlockSize)%cpioBlockSize))
+	return err
+}
+
+func (cw *cpioWriter) writeHeader(h *cpioHeader) error {
+	fields := []uint32{
+		h.ino, h.mode, h.uid, h.gid, h.nlink, h.mtime, h.size,
+		h.devmajor, h.devminor, h.rdevmajor, h.rdevminor, uint32(len(h.name) + 1), 0,
+	}
+	var buf bytes.Buffer
+	buf.WriteString(cpioMagic)
+	for _, field := range fields {
+		fmt.Fprintf(&buf, "%08X", field)
+	}
+	buf.WriteString(h.name)
+	buf.WriteByte(0)
+	buf.Write(make([]byte, pad4(cw.offset+int64(buf.Len()))))
+	_, err := cw.write(buf.Bytes())
+	return err
+}
+
+func (cw *cpioWriter) finishEntry() error {
+	if cw.remaining != 0 {
+		return fmt.Errorf("cpio entry content shorter than its size")
+	}
+	_, err := cw.write(make([]byte, cw.padding))
+	cw.padding = 0
+	return err
+}
+
+func (cw *cpioWriter) write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.offset += int64(n)
+	return n, err
+}
+
+// pad4 returns the padding aligning the offset on 4 bytes.
+func pad4(offset int64) int64 {
+	return (4 - offset%4) % 4
+}
+
+// cpioFileMode converts the mode of a cpio entry to a file mode.
+func cpioFileMode(mode uint32) fs.FileMode {
+	fileMode := fs.FileMode(mode & 0o777)
+	switch mode & cpioTypeMask {
+	case cpioDir:
+		fileMode |= fs.ModeDir
+	case cpioSymlink:
+		fileMode |= fs.ModeSymlink
+	case cpioChar:
+		fileMode |= fs.ModeDevice | fs.ModeCharDevice
+	case cpioBlock:
+		fileMode |= fs.ModeDevice
+	case cpioFIFO:
+		fileMode |= fs.ModeNamedPipe
+	case cpioSocket:
+		fileMode |= fs.ModeSocket
+	}
+	for _, bit := range cpioModeBits {
+		if mode&bit.cpio != 0 {
+			fileMode |= bit.mode
+		}
+	}
+	return fileMode
+}
+
+// cpioMode converts a file mode to the mode of a cpio entry.
+func cpioMode(fileMode fs.FileMode) uint32 {
+	mode := uint32(fileMode.Perm())
+	switch {
+	case fileMode.IsDir():
+		mode |= cpioDir
+	case fileMode&fs.ModeSymlink != 0:
+		mode |= cpioSymlink
+	case fileMode&fs.ModeCharDevice != 0:
+		mode |= cpioChar
+	case fileMode&fs.ModeDevice != 0:
+		mode |= cpioBlock
+	case fileMode&fs.ModeNamedPipe != 0:
+		mode |= cpioFIFO
+	case fileMode&fs.ModeSocket != 0:
+		mode |= cpioSocket
+	default:
+		mode |= cpioRegular
+	}
+	for _, bit := range cpioModeBits {
+		if fileMode&bit.mode != 0 {
+			mode |= bit.cpio
+		}
+	}
+	return mode
+}
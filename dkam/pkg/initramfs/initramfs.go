@@ -0,0 +1,352 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package initramfs rewrites the initramfs of the uOS, a newc cpio archive compressed with gzip or zstd, and the
+// archives nested in it, e.g. its rootfs tarball. Files are added, replaced or edited in the archive as it is
+// streamed, without extracting it to the filesystem, and the rewritten archive only depends on the read archive
+// and the overlay: identical inputs give byte-identical outputs.
+package initramfs
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// EditFunc returns the edited content of a file.
+type EditFunc func(data []byte) ([]byte, error)
+
+// File is a file added to an archive, in place of the entry of the same name if any.
+type File struct {
+	// Name is the path of the file in the archive, relative to its root.
+	Name string
+	// Mode is the type and the permissions of the file: a regular file, a directory or a symbolic link.
+	Mode fs.FileMode
+	Data []byte
+	// Linkname is the target of a symbolic link.
+	Linkname string
+	// From is the path of the file of the archive whose content is copied to the file, e.g. to instantiate a
+	// template systemd unit. Data is ignored.
+	From string
+}
+
+// Overlay is the changes made to an archive.
+type Overlay struct {
+	// Files are the files added to the archive. Parent directories missing in the archive are added as well.
+	Files []File
+	// Edits edit the regular files of the archive, by path, including the files of the overlay.
+	Edits map[string]EditFunc
+	// Archives are the overlays of the archives nested in the archive, by path.
+	Archives map[string]*Overlay
+	// ModTime is the modification time of the files of the overlay, of the edited files and of the rewritten
+	// nested archives. If zero, the one of the overlay of the parent archive, or the Unix epoch.
+	ModTime time.Time
+}
+
+// header is the header of an archive entry, a cpio or a tar one.
+type header struct {
+	// Name is the name of the entry, as in the archive.
+	Name     string
+	Mode     fs.FileMode
+	Size     int64
+	Linkname string
+	ModTime  time.Time
+
+	// cpio and tar are the raw headers of the entries of the read archive, nil for the added entries.
+	cpio *cpioHeader
+	tar  *tar.Header
+}
+
+type archiveReader interface {
+	// Next returns the header of the next entry, io.EOF at the end of the archive.
+	Next() (*header, error)
+	// Read reads the content of the current entry.
+	Read(p []byte) (int, error)
+}
+
+type archiveWriter interface {
+	WriteHeader(h *header) error
+	// Write writes the content of the current entry.
+	Write(p []byte) (int, error)
+	Close() error
+}
+
+// Rewrite reads the archive, a cpio or a tar one compressed or not, from r, applies the overlay to it and writes
+// it to w compressed with compression, the compression of the read archive if empty. It fails if a file to edit or
+// a nested archive is not in the archive.
+func Rewrite(r io.Reader, w io.Writer, compression Compression, overlay *Overlay) error {
+	dr, readCompression, err := decompress(r)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = dr.Close()
+	}()
+	if compression == "" {
+		compression = readCompression
+	}
+
+	br := bufio.NewReader(dr)
+	var isCPIOArchive bool
+	switch {
+	case isCPIO(br):
+		isCPIOArchive = true
+	case !isTar(br):
+		return errors.New("unsupported archive format, neither cpio nor tar")
+	}
+	cw, err := compress(w, compression)
+	if err != nil {
+		return err
+	}
+	var ar archiveReader = newTarReader(br)
+	var aw archiveWriter = newTarWriter(cw)
+	if isCPIOArchive {
+		ar, aw = newCPIOReader(br), newCPIOWriter(cw)
+	}
+	if err = rewrite(ar, aw, overlay); err != nil {
+		return err
+	}
+	// Reading the padding up to the end of the archive verifies its checksum, if compressed.
+	if _, err = io.Copy(io.Discard, br); err != nil {
+		return err
+	}
+	if err = aw.Close(); err != nil {
+		return err
+	}
+	return cw.Close()
+}
+
+func isCPIO(br *bufio.Reader) bool {
+	magic, err := br.Peek(len(cpioMagic))
+	return err == nil && (string(magic) == cpioMagic || string(magic) == cpioMagicCRC)
+}
+
+func isTar(br *bufio.Reader) bool {
+	// The magic of the ustar, pax and GNU formats.
+	const magicOffset, magic = 257, "ustar"
+	block, err := br.Peek(magicOffset + len(magic))
+	return err == nil && string(block[magicOffset:]) == magic
+}
+
+// rewriter applies an overlay to an archive as it is streamed.
+type rewriter struct {
+	overlay *Overlay
+	modTime time.Time
+	// files are the files of the overlay not written yet, by path.
+	files map[string]File
+	// sources are the contents of the files of the archive copied to files of the overlay, by path.
+	sources map[string][]byte
+	// written are the paths of the entries written.
+	written map[string]bool
+	// prefix is the prefix of the names of the entries of the archive, e.g. "./".
+	prefix string
+}
+
+func rewrite(ar archiveReader, aw archiveWriter, overlay *Overlay) error {
+	rw := &rewriter{
+		overlay: overlay,
+		modTime: overlay.ModTime,
+		files:   map[string]File{},
+		sources: map[string][]byte{},
+		written: map[string]bool{},
+	}
+	if rw.modTime.IsZero() {
+		rw.modTime = time.Unix(0, 0)
+	}
+	for _, file := range overlay.Files {
+		rw.files[cleanName(file.Name)] = file
+		if file.From != "" {
+			rw.sources[cleanName(file.From)] = nil
+		}
+	}
+
+	first := true
+	for {
+		h, err := ar.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		name := cleanName(h.Name)
+		if first && name != "." {
+			// The added entries are named as the first one, e.g. ./etc or etc.
+			rw.prefix, _, _ = strings.Cut(h.Name, name)
+			first = false
+		}
+		if err = rw.rewriteEntry(ar, aw, h, name); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		rw.written[name] = true
+	}
+	return rw.addFiles(aw)
+}
+
+// rewriteEntry writes an entry of the read archive, replaced, edited or rewritten by the overlay.
+func (rw *rewriter) rewriteEntry(ar archiveReader, aw archiveWriter, h *header, name string) error {
+	var content io.Reader = ar
+	if source, ok := rw.sources[name]; ok && source == nil {
+		data, err := io.ReadAll(ar)
+		if err != nil {
+			return err
+		}
+		rw.sources[name] = data
+		content = bytes.NewReader(data)
+	}
+
+	file, replaced := rw.files[name]
+	if replaced && file.From != "" {
+		// Files copied from the archive are added once it is read.
+		return nil
+	}
+	if replaced {
+		delete(rw.files, name)
+		return rw.writeFile(aw, h, file, file.Data)
+	}
+	if edit, ok := rw.overlay.Edits[name]; ok {
+		if !h.Mode.IsRegular() {
+			return errors.New("not a regular file")
+		}
+		data, err := io.ReadAll(content)
+		if err != nil {
+			return err
+		}
+		if data, err = edit(data); err != nil {
+			return err
+		}
+		h.Size, h.ModTime = int64(len(data)), rw.modTime
+		return writeEntry(aw, h, bytes.NewReader(data))
+	}
+	if nested, ok := rw.overlay.Archives[name]; ok {
+		return rw.rewriteNested(aw, h, content, nested)
+	}
+	return writeEntry(aw, h, content)
+}
+
+// rewriteNested rewrites an archive nested in the archive. It is rewritten to a temporary file, for its size to be
+// written in its header before its content.
+func (rw *rewriter) rewriteNested(aw archiveWriter, h *header, content io.Reader, overlay *Overlay) error {
+	tmp, err := os.CreateTemp("", "initramfs-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+	if overlay.ModTime.IsZero() {
+		inherited := *overlay
+		inherited.ModTime = rw.modTime
+		overlay = &inherited
+	}
+	if err = Rewrite(content, tmp, "", overlay); err != nil {
+		return err
+	}
+	if h.Size, err = tmp.Seek(0, io.SeekCurrent); err != nil {
+		return err
+	}
+	if _, err = tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	h.ModTime = rw.modTime
+	return writeEntry(aw, h, tmp)
+}
+
+// addFiles adds the files of the overlay not in the read archive, in the order of their paths, after their
+// missing parent directories.
+func (rw *rewriter) addFiles(aw archiveWriter) error {
+	names := make([]string, 0, len(rw.files))
+	for name := range rw.files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		var parents []string
+		for dir := path.Dir(name); dir != "." && !rw.written[dir]; dir = path.Dir(dir) {
+			parents = append(parents, dir)
+		}
+		for _, dir := range slices.Backward(parents) {
+			if err := rw.writeFile(aw, nil, File{Name: dir, Mode: fs.ModeDir | 0o755}, nil); err != nil {
+				return err
+			}
+			rw.written[dir] = true
+		}
+
+		file := rw.files[name]
+		data := file.Data
+		if file.From != "" {
+			source, ok := rw.sources[cleanName(file.From)]
+			if !ok || source == nil {
+				return fmt.Errorf("%s: %s not found in the archive", name, file.From)
+			}
+			data = source
+		}
+		if err := rw.writeFile(aw, nil, file, data); err != nil {
+			return err
+		}
+		rw.written[name] = true
+	}
+
+	for name := range rw.overlay.Edits {
+		if !rw.written[name] {
+			return fmt.Errorf("%s not found in the archive", name)
+		}
+	}
+	for name := range rw.overlay.Archives {
+		if !rw.written[name] {
+			return fmt.Errorf("%s not found in the archive", name)
+		}
+	}
+	return nil
+}
+
+// writeFile writes a file of the overlay, in place of the entry of the read archive h if not nil.
+func (rw *rewriter) writeFile(aw archiveWriter, h *header, file File, data []byte) error {
+	name := cleanName(file.Name)
+	if edit, ok := rw.overlay.Edits[name]; ok && file.Mode.IsRegular() {
+		var err error
+		if data, err = edit(data); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	if h == nil {
+		h = &header{Name: rw.prefix + name}
+		if file.Mode.IsDir() {
+			if _, isTar := aw.(*tarWriter); isTar {
+				h.Name += "/"
+			}
+		}
+	}
+	h.Mode, h.Linkname, h.ModTime = file.Mode, file.Linkname, rw.modTime
+	h.Size = 0
+	if file.Mode.IsRegular() {
+		h.Size = int64(len(data))
+	}
+	return writeEntry(aw, h, bytes.NewReader(data))
+}
+
+func writeEntry(aw archiveWriter, h *header, content io.Reader) error {
+	if err := aw.WriteHeader(h); err != nil {
+		return err
+	}
+	if h.Size == 0 {
+		return nil
+	}
+	_, err := io.CopyN(aw, content, h.Size)
+	return err
+}
+
+// cleanName returns the path of an entry relative to the root of the archive, e.g. etc/hosts for ./etc/hosts.
+func cleanName(name string) string {
+	return path.Clean(strings.TrimLeft(name, "/"))
+}
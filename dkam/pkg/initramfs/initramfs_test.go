@@ -0,0 +1,387 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package initramfs_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/initramfs"
+)
+
+const (
+	modeDir     = 0o040755
+	modeFile    = 0o100644
+	modeExec    = 0o100755
+	modeSymlink = 0o120777
+	modeChar    = 0o020600
+)
+
+// cpioEntry is an entry of a newc cpio archive, written and parsed independently of the package.
+type cpioEntry struct {
+	name                  string
+	ino, mode, uid, nlink uint32
+	mtime                 uint32
+	rdevmajor, rdevminor  uint32
+	data                  string
+}
+
+func makeCPIO(entries []cpioEntry) []byte {
+	var buf bytes.Buffer
+	for _, e := range append(entries, cpioEntry{name: "TRAILER!!!", nlink: 1}) {
+		fmt.Fprintf(&buf, "070701%08X%08X%08X%08X%08X%08X%08X%08X%08X%08X%08X%08X%08X",
+			e.ino, e.mode, e.uid, 0, e.nlink, e.mtime, len(e.data), 0, 0, e.rdevmajor, e.rdevminor, len(e.name)+1, 0)
+		buf.WriteString(e.name + "\x00")
+		buf.Write(make([]byte, (4-buf.Len()%4)%4))
+		buf.WriteString(e.data)
+		buf.Write(make([]byte, (4-buf.Len()%4)%4))
+	}
+	return buf.Bytes()
+}
+
+func parseCPIO(t *testing.T, data []byte) []cpioEntry {
+	t.Helper()
+	require.Zero(t, len(data)%512, "cpio archive not padded to 512 bytes")
+	var entries []cpioEntry
+	offset := 0
+	field := func(i int) uint32 {
+		v, err := strconv.ParseUint(string(data[offset+6+8*i:offset+14+8*i]), 16, 32)
+		require.NoError(t, err)
+		return uint32(v)
+	}
+	for {
+		require.Equal(t, "070701", string(data[offset:offset+6]))
+		e := cpioEntry{
+			ino: field(0), mode: field(1), uid: field(2), nlink: field(4), mtime: field(5),
+			rdevmajor: field(9), rdevminor: field(10),
+		}
+		size, nameSize := int(field(6)), int(field(11))
+		offset += 110
+		e.name = string(data[offset : offset+nameSize-1])
+		offset += nameSize + (4-(offset+nameSize)%4)%4
+		e.data = string(data[offset : offset+size])
+		offset += size + (4-(offset+size)%4)%4
+		if e.name == "TRAILER!!!" {
+			require.Empty(t, bytes.Trim(data[offset:], "\x00"))
+			return entries
+		}
+		entries = append(entries, e)
+	}
+}
+
+func makeTarGz(t *testing.T, headers []*tar.Header, contents map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for _, h := range headers {
+		h.Size = int64(len(contents[h.Name]))
+		require.NoError(t, tw.WriteHeader(h))
+		_, err := tw.Write([]byte(contents[h.Name]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func parseTarGz(t *testing.T, data []byte) ([]*tar.Header, map[string]string) {
+	t.Helper()
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tr := tar.NewReader(zr)
+	var headers []*tar.Header
+	contents := map[string]string{}
+	for {
+		h, nextErr := tr.Next()
+		if nextErr == io.EOF {
+			return headers, contents
+		}
+		require.NoError(t, nextErr)
+		content, readErr := io.ReadAll(tr)
+		require.NoError(t, readErr)
+		headers = append(headers, h)
+		contents[h.Name] = string(content)
+	}
+}
+
+func compressData(t *testing.T, data []byte, compression initramfs.Compression) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser = nopCloser{&buf}
+	switch compression {
+	case initramfs.CompressionGzip:
+		w = gzip.NewWriter(&buf)
+	case initramfs.CompressionZstd:
+		var err error
+		w, err = zstd.NewWriter(&buf)
+		require.NoError(t, err)
+	}
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func decompressData(t *testing.T, data []byte, compression initramfs.Compression) []byte {
+	t.Helper()
+	var r io.Reader = bytes.NewReader(data)
+	switch compression {
+	case initramfs.CompressionGzip:
+		zr, err := gzip.NewReader(r)
+		require.NoError(t, err)
+		r = zr
+	case initramfs.CompressionZstd:
+		zr, err := zstd.NewReader(r)
+		require.NoError(t, err)
+		defer zr.Close()
+		r = zr
+	}
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return out
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// testInitramfs returns a small initramfs holding a rootfs tarball, as the uOS one.
+func testInitramfs(t *testing.T) []byte {
+	t.Helper()
+	modTime := time.Unix(1700000000, 0)
+	rootfs := makeTarGz(t, []*tar.Header{
+		{Name: "./", Typeflag: tar.TypeDir, Mode: 0o755, ModTime: modTime},
+		{Name: "./usr/", Typeflag: tar.TypeDir, Mode: 0o755, ModTime: modTime},
+		{Name: "./usr/bin/", Typeflag: tar.TypeDir, Mode: 0o755, ModTime: modTime},
+		{
+			Name: "./usr/bin/ping", Typeflag: tar.TypeReg, Mode: 0o755, ModTime: modTime, Format: tar.FormatPAX,
+			PAXRecords: map[string]string{"SCHILY.xattr.security.capability": "cap_net_raw"},
+		},
+		{Name: "./usr/lib/", Typeflag: tar.TypeDir, Mode: 0o755, ModTime: modTime},
+		{Name: "./usr/lib/systemd/", Typeflag: tar.TypeDir, Mode: 0o755, ModTime: modTime},
+		{Name: "./usr/lib/systemd/system/", Typeflag: tar.TypeDir, Mode: 0o755, ModTime: modTime},
+		{Name: "./usr/lib/systemd/system/getty@.service", Typeflag: tar.TypeReg, Mode: 0o644, ModTime: modTime},
+		{Name: "./etc/", Typeflag: tar.TypeDir, Mode: 0o755, ModTime: modTime},
+		{Name: "./etc/systemd/", Typeflag: tar.TypeDir, Mode: 0o755, ModTime: modTime},
+		{Name: "./etc/systemd/system/", Typeflag: tar.TypeDir, Mode: 0o755, ModTime: modTime},
+		{Name: "./etc/systemd/system/getty.target.wants/", Typeflag: tar.TypeDir, Mode: 0o755, ModTime: modTime},
+		{
+			Name: "./etc/systemd/system/getty.target.wants/getty@tty1.service", Typeflag: tar.TypeSymlink,
+			Linkname: "/usr/lib/systemd/system/getty@.service", Mode: 0o777, ModTime: modTime,
+		},
+	}, map[string]string{
+		"./usr/bin/ping": "ping",
+		"./usr/lib/systemd/system/getty@.service": "[Service]\nExecStart=-/sbin/agetty -o '-p -- \\\\u' %I\n",
+	})
+	return makeCPIO([]cpioEntry{
+		{name: ".", ino: 1, mode: modeDir, nlink: 2, mtime: 1700000000},
+		{name: "init", ino: 2, mode: modeExec, nlink: 1, mtime: 1700000000, data: "#!/bin/sh\n"},
+		{name: "dev", ino: 3, mode: modeDir, nlink: 2, mtime: 1700000000},
+		{name: "dev/console", ino: 4, mode: modeChar, nlink: 1, mtime: 1700000000, rdevmajor: 5, rdevminor: 1},
+		{name: "bin", ino: 5, mode: modeDir, nlink: 2, mtime: 1700000000},
+		{name: "bin/sh", ino: 6, mode: modeSymlink, nlink: 1, mtime: 1700000000, data: "busybox"},
+		{name: "etc", ino: 7, mode: modeDir, nlink: 2, mtime: 1700000000},
+		{name: "etc/hostname", ino: 8, mode: modeFile, nlink: 1, mtime: 1700000000, data: "uos\n"},
+		{name: "rootfs.tar.gz", ino: 9, mode: modeFile, nlink: 1, mtime: 1700000000, data: string(rootfs)},
+	})
+}
+
+func testOverlay() *initramfs.Overlay {
+	return &initramfs.Overlay{
+		Files: []initramfs.File{
+			{Name: "etc/hostname", Mode: 0o644, Data: []byte("edge-node\n")},
+			{Name: "etc/emf/env_config", Mode: 0o600, Data: []byte("release_svc=release.example.com\n")},
+		},
+		Edits: map[string]initramfs.EditFunc{
+			"init": func(data []byte) ([]byte, error) {
+				return append(data, "exec /sbin/init\n"...), nil
+			},
+		},
+		Archives: map[string]*initramfs.Overlay{
+			"rootfs.tar.gz": {
+				Files: []initramfs.File{
+					{
+						Name: "etc/systemd/system/getty@tty1.service", Mode: 0o644,
+						From: "usr/lib/systemd/system/getty@.service",
+					},
+					{
+						Name: "etc/systemd/system/getty.target.wants/getty@tty1.service", Mode: fs.ModeSymlink | 0o777,
+						Linkname: "/etc/systemd/system/getty@tty1.service",
+					},
+					{Name: "etc/caddy/caddy_run.sh", Mode: 0o755, Data: []byte("#!/bin/sh\n")},
+				},
+				Edits: map[string]initramfs.EditFunc{
+					"etc/systemd/system/getty@tty1.service": func(data []byte) ([]byte, error) {
+						return []byte(strings.ReplaceAll(string(data), "-o '-p -- \\\\u'", "--autologin root")), nil
+					},
+				},
+			},
+		},
+		ModTime: time.Unix(1710000000, 0),
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	for _, compression := range []initramfs.Compression{
+		initramfs.CompressionNone, initramfs.CompressionGzip, initramfs.CompressionZstd,
+	} {
+		t.Run(string(compression), func(t *testing.T) {
+			in := compressData(t, testInitramfs(t), compression)
+
+			var out bytes.Buffer
+			require.NoError(t, initramfs.Rewrite(bytes.NewReader(in), &out, "", testOverlay()))
+			entries := parseCPIO(t, decompressData(t, out.Bytes(), compression))
+
+			names := make([]string, 0, len(entries))
+			byName := map[string]cpioEntry{}
+			for _, e := range entries {
+				names = append(names, e.name)
+				byName[e.name] = e
+			}
+			require.Equal(t, []string{
+				".", "init", "dev", "dev/console", "bin", "bin/sh", "etc", "etc/hostname", "rootfs.tar.gz",
+				"etc/emf", "etc/emf/env_config",
+			}, names)
+			require.Equal(t, cpioEntry{
+				name: "init", ino: 2, mode: modeExec, nlink: 1, mtime: 1710000000, data: "#!/bin/sh\nexec /sbin/init\n",
+			}, byName["init"])
+			require.Equal(t, cpioEntry{
+				name: "dev/console", ino: 4, mode: modeChar, nlink: 1, mtime: 1700000000, rdevmajor: 5, rdevminor: 1,
+			}, byName["dev/console"])
+			require.Equal(t, "busybox", byName["bin/sh"].data)
+			require.Equal(t, cpioEntry{
+				name: "etc/hostname", ino: 8, mode: modeFile, nlink: 1, mtime: 1710000000, data: "edge-node\n",
+			}, byName["etc/hostname"])
+			require.Equal(t, cpioEntry{
+				name: "etc/emf", ino: 10, mode: modeDir, nlink: 2, mtime: 1710000000,
+			}, byName["etc/emf"])
+			require.Equal(t, cpioEntry{
+				name: "etc/emf/env_config", ino: 11, mode: 0o100600, nlink: 1, mtime: 1710000000,
+				data: "release_svc=release.example.com\n",
+			}, byName["etc/emf/env_config"])
+
+			headers, contents := parseTarGz(t, []byte(byName["rootfs.tar.gz"].data))
+			tarNames := make([]string, 0, len(headers))
+			byTarName := map[string]*tar.Header{}
+			for _, h := range headers {
+				tarNames = append(tarNames, h.Name)
+				byTarName[h.Name] = h
+			}
+			require.Equal(t, []string{
+				"./", "./usr/", "./usr/bin/", "./usr/bin/ping", "./usr/lib/", "./usr/lib/systemd/",
+				"./usr/lib/systemd/system/", "./usr/lib/systemd/system/getty@.service", "./etc/", "./etc/systemd/",
+				"./etc/systemd/system/", "./etc/systemd/system/getty.target.wants/",
+				"./etc/systemd/system/getty.target.wants/getty@tty1.service",
+				"./etc/caddy/", "./etc/caddy/caddy_run.sh", "./etc/systemd/system/getty@tty1.service",
+			}, tarNames)
+			require.Equal(t, "cap_net_raw", byTarName["./usr/bin/ping"].PAXRecords["SCHILY.xattr.security.capability"])
+			require.Equal(t, "ping", contents["./usr/bin/ping"])
+			link := byTarName["./etc/systemd/system/getty.target.wants/getty@tty1.service"]
+			require.Equal(t, byte(tar.TypeSymlink), link.Typeflag)
+			require.Equal(t, "/etc/systemd/system/getty@tty1.service", link.Linkname)
+			require.Equal(t, "[Service]\nExecStart=-/sbin/agetty --autologin root %I\n",
+				contents["./etc/systemd/system/getty@tty1.service"])
+			require.Equal(t, "[Service]\nExecStart=-/sbin/agetty -o '-p -- \\\\u' %I\n",
+				contents["./usr/lib/systemd/system/getty@.service"])
+			caddyRun := byTarName["./etc/caddy/caddy_run.sh"]
+			require.Equal(t, int64(0o755), caddyRun.Mode)
+			require.Equal(t, "root", caddyRun.Uname)
+			require.Zero(t, caddyRun.Uid)
+			require.Equal(t, time.Unix(1710000000, 0), caddyRun.ModTime)
+
+			// Identical inputs give identical outputs.
+			var again bytes.Buffer
+			require.NoError(t, initramfs.Rewrite(bytes.NewReader(in), &again, "", testOverlay()))
+			require.Equal(t, out.Bytes(), again.Bytes())
+		})
+	}
+}
+
+func TestRewrite_Compression(t *testing.T) {
+	in := compressData(t, testInitramfs(t), initramfs.CompressionGzip)
+
+	var out bytes.Buffer
+	require.NoError(t, initramfs.Rewrite(bytes.NewReader(in), &out, initramfs.CompressionZstd, &initramfs.Overlay{}))
+	require.Equal(t, []byte{0x28, 0xb5, 0x2f, 0xfd}, out.Bytes()[:4])
+	entries := parseCPIO(t, decompressData(t, out.Bytes(), initramfs.CompressionZstd))
+	require.Len(t, entries, 9)
+
+	out.Reset()
+	require.NoError(t, initramfs.Rewrite(bytes.NewReader(in), &out, initramfs.CompressionNone, &initramfs.Overlay{}))
+	// An archive rewritten without overlay is the read one, padded.
+	raw := testInitramfs(t)
+	require.Equal(t, raw, out.Bytes()[:len(raw)])
+	require.Empty(t, bytes.Trim(out.Bytes()[len(raw):], "\x00"))
+
+	require.Error(t, initramfs.Rewrite(bytes.NewReader(in), &out, "lz4", &initramfs.Overlay{}))
+}
+
+func TestRewrite_Invalid(t *testing.T) {
+	in := testInitramfs(t)
+	for name, tc := range map[string]struct {
+		in      []byte
+		overlay *initramfs.Overlay
+	}{
+		"edited file not found": {
+			in: in,
+			overlay: &initramfs.Overlay{Edits: map[string]initramfs.EditFunc{
+				"etc/missing": func(data []byte) ([]byte, error) { return data, nil },
+			}},
+		},
+		"edited file not regular": {
+			in: in,
+			overlay: &initramfs.Overlay{Edits: map[string]initramfs.EditFunc{
+				"bin/sh": func(data []byte) ([]byte, error) { return data, nil },
+			}},
+		},
+		"copied file not found": {
+			in:      in,
+			overlay: &initramfs.Overlay{Files: []initramfs.File{{Name: "etc/copy", Mode: 0o644, From: "etc/missing"}}},
+		},
+		"nested archive not found": {
+			in:      in,
+			overlay: &initramfs.Overlay{Archives: map[string]*initramfs.Overlay{"missing.tar.gz": {}}},
+		},
+		"nested archive invalid": {
+			in:      in,
+			overlay: &initramfs.Overlay{Archives: map[string]*initramfs.Overlay{"etc/hostname": {}}},
+		},
+		"unsupported format": {
+			in:      []byte("not an archive"),
+			overlay: &initramfs.Overlay{},
+		},
+		"xz compression": {
+			in:      append([]byte{0xfd, '7', 'z', 'X', 'Z', 0x00}, in...),
+			overlay: &initramfs.Overlay{},
+		},
+		"truncated archive": {
+			in:      in[:len(in)/2],
+			overlay: &initramfs.Overlay{},
+		},
+		"concatenated archives": {
+			in:      append(append([]byte{}, in...), in...),
+			overlay: &initramfs.Overlay{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, initramfs.Rewrite(bytes.NewReader(tc.in), io.Discard, "", tc.overlay))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package initramfs
+
+import (
+	"archive/tar"
+	"io"
+	"io/fs"
+)
+
+// tarReader reads the entries of a tar archive.
+type tarReader struct {
+	*tar.Reader
+}
+
+func newTarReader(r io.Reader) *tarReader {
+	return &tarReader{tar.NewReader(r)}
+}
+
+// Next returns the header of the next entry, io.EOF at the end of the archive.
+func (tr *tarReader) Next() (*header, error) {
+	h, err := tr.Reader.Next()
+	if err != nil {
+		return nil, err
+	}
+	return &header{
+		Name:     h.Name,
+		Mode:     h.FileInfo().Mode(),
+		Size:     h.Size,
+		Linkname: h.Linkname,
+		ModTime:  h.ModTime,
+		tar:      h,
+	}, nil
+}
+
+// tarWriter writes a tar archive. Entries of the read archive keep their owner, links, devices and extended
+// attributes, the entries added to it are owned by root.
+type tarWriter struct {
+	*tar.Writer
+}
+
+func newTarWriter(w io.Writer) *tarWriter {
+	return &tarWriter{tar.NewWriter(w)}
+}
+
+// WriteHeader writes the header of an entry.
+func (tw *tarWriter) WriteHeader(h *header) error {
+	if h.tar != nil && !modified(h) {
+		return tw.Writer.WriteHeader(h.tar)
+	}
+	raw := &tar.Header{Uname: "root", Gname: "root"}
+	if h.tar != nil {
+		raw = h.tar
+	}
+	raw.Name = h.Name
+	raw.Size = h.Size
+	raw.Linkname = h.Linkname
+	raw.ModTime = h.ModTime
+	raw.Mode = int64(h.Mode.Perm())
+	switch {
+	case h.Mode.IsDir():
+		raw.Typeflag = tar.TypeDir
+	case h.Mode&fs.ModeSymlink != 0:
+		raw.Typeflag = tar.TypeSymlink
+	default:
+		raw.Typeflag = tar.TypeReg
+	}
+	return tw.Writer.WriteHeader(raw)
+}
+
+// modified tells whether the header of an entry of the read archive was modified.
+func modified(h *header) bool {
+	return h.Size != h.tar.Size || h.Linkname != h.tar.Linkname || h.Mode != h.tar.FileInfo().Mode() ||
+		!h.ModTime.Equal(h.tar.ModTime)
+}
@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package manifest records the artifacts DKAM curated in the PVC, with their checksums, sources and signing keys,
+// and the configuration they were built from. The manifest is written to the PVC next to the artifacts, for edge
+// nodes to verify the artifacts they download, and served with the status of the build.
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/logging"
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/config"
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/download"
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/signing"
+)
+
+var zlog = logging.GetLogger("InfraDKAMManifest")
+
+const (
+	// File is the file of the PVC holding the manifest.
+	File = "manifest.json"
+
+	fileMode = 0o644
+)
+
+// Manifest is the manifest of the artifacts of the PVC.
+type Manifest struct {
+	// Version is the version of DKAM which built the artifacts.
+	Version string `json:"version"`
+	// ConfigHash is the SHA-256 of the infra config the artifacts were built from.
+	ConfigHash    string `json:"configHash"`
+	ENManifestTag string `json:"enManifestTag,omitempty"`
+	// OrchCAFingerprint and BootsCAFingerprint are the SHA-256 fingerprints of the CA certificates embedded in
+	// the artifacts.
+	OrchCAFingerprint  string     `json:"orchCaFingerprint,omitempty"`
+	BootsCAFingerprint string     `json:"bootsCaFingerprint,omitempty"`
+	GeneratedAt        time.Time  `json:"generatedAt"`
+	Artifacts          []Artifact `json:"artifacts"`
+}
+
+// Artifact is an artifact of the PVC.
+type Artifact struct {
+	Name string `json:"name"`
+	// Path is the path of the artifact in the PVC.
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+	// SourceURL is the URL the artifact was built from, empty if built from the DKAM image only.
+	SourceURL string `json:"sourceUrl,omitempty"`
+	// Signature is the path of the detached signature of the artifact in the PVC.
+	Signature string `json:"signature,omitempty"`
+	// KeyID is the SHA-256 fingerprint of the certificate of the key which signed the artifact, empty if unsigned.
+	KeyID   string    `json:"keyId,omitempty"`
+	BuiltAt time.Time `json:"builtAt"`
+}
+
+// Build returns the manifest of the artifacts of the PVC, built by DKAM of the version from the infra config.
+func Build(infraConfig config.InfraConfig, version string) (*Manifest, error) {
+	configData, err := json.Marshal(infraConfig)
+	if err != nil {
+		return nil, err
+	}
+	configHash := sha256.Sum256(configData)
+	m := &Manifest{
+		Version:       version,
+		ConfigHash:    hex.EncodeToString(configHash[:]),
+		ENManifestTag: infraConfig.ENAgentManifestTag,
+		GeneratedAt:   time.Now().UTC(),
+		Artifacts:     []Artifact{},
+	}
+	if m.OrchCAFingerprint, err = certificateFingerprint(config.OrchCACertificateFile); err != nil {
+		return nil, err
+	}
+	if m.BootsCAFingerprint, err = certificateFingerprint(config.BootsCaCertificateFile); err != nil {
+		return nil, err
+	}
+
+	records, err := signing.ReadSignatureRecords()
+	if err != nil {
+		zlog.InfraSec().Error().Err(err).Msg("Failed to read the signature records")
+		return nil, err
+	}
+	sources := sourceURLs(infraConfig)
+	err = filepath.WalkDir(config.PVC, func(file string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil || !d.Type().IsRegular() {
+			return walkErr
+		}
+		rel, err := filepath.Rel(config.PVC, file)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == File || rel == signing.SignaturesFile || strings.HasSuffix(rel, ".tmp") {
+			return nil
+		}
+		artifact, err := readArtifact(file, rel)
+		if err != nil {
+			return err
+		}
+		artifact.SourceURL = sources[strings.TrimSuffix(rel, ".sig")]
+		for _, record := range records {
+			switch {
+			case record.Artifact == rel && record.SHA256 == artifact.SHA256:
+				// A record of a previous build of the artifact does not apply.
+				artifact.KeyID = record.KeyID
+				if record.Signature != "" {
+					artifact.Signature = record.Signature
+				}
+			case record.Signature == rel:
+				artifact.KeyID = record.KeyID
+			}
+		}
+		m.Artifacts = append(m.Artifacts, artifact)
+		return nil
+	})
+	if err != nil {
+		zlog.InfraSec().Error().Err(err).Msg("Failed to read the artifacts of the PVC")
+		return nil, err
+	}
+	return m, nil
+}
+
+// Write writes the manifest to the PVC.
+func Write(m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	out := filepath.Join(config.PVC, File)
+	if err = os.WriteFile(out+".tmp", data, fileMode); err != nil {
+		return err
+	}
+	return os.Rename(out+".tmp", out)
+}
+
+// sourceURLs maps the uOS artifacts of the PVC to the URL of the uOS archive they were built from.
+func sourceURLs(infraConfig config.InfraConfig) map[string]string {
+	sources := map[string]string{}
+	if infraConfig.CDN == "" {
+		return sources
+	}
+	for _, arch := range infraConfig.Architectures() {
+		uOSURL, err := download.MicroOSURL(infraConfig, arch)
+		if err != nil {
+			continue
+		}
+		sources["vmlinuz-"+arch] = uOSURL
+		sources["initramfs-"+arch] = uOSURL
+	}
+	return sources
+}
+
+func readArtifact(file, rel string) (Artifact, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return Artifact{}, err
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil {
+			zlog.InfraSec().Error().Err(closeErr).Msg("Failed to close artifact")
+		}
+	}()
+	info, err := f.Stat()
+	if err != nil {
+		return Artifact{}, err
+	}
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return Artifact{}, err
+	}
+	return Artifact{
+		Name:    path.Base(rel),
+		Path:    rel,
+		Size:    info.Size(),
+		SHA256:  hex.EncodeToString(h.Sum(nil)),
+		BuiltAt: info.ModTime().UTC(),
+	}, nil
+}
+
+// certificateFingerprint returns the SHA-256 fingerprint of the first certificate of the PEM file, empty if the
+// file is missing.
+func certificateFingerprint(certFile string) (string, error) {
+	data, err := os.ReadFile(certFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		data = block.Bytes
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/config"
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/manifest"
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/signing"
+)
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// setupPVC sets a temporary PVC holding the files and the signature records, and temporary CA certificates.
+func setupPVC(t *testing.T, files map[string]string, records []signing.SignatureRecord) {
+	t.Helper()
+	defaultPVC, defaultOrchCA, defaultBootsCA := config.PVC, config.OrchCACertificateFile, config.BootsCaCertificateFile
+	t.Cleanup(func() {
+		config.PVC, config.OrchCACertificateFile, config.BootsCaCertificateFile = defaultPVC, defaultOrchCA, defaultBootsCA
+	})
+	config.PVC = t.TempDir()
+	certDir := t.TempDir()
+	config.OrchCACertificateFile = filepath.Join(certDir, "orch-ca.crt")
+	config.BootsCaCertificateFile = filepath.Join(certDir, "boots-ca.crt")
+
+	for name, data := range files {
+		file := filepath.Join(config.PVC, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+		require.NoError(t, os.WriteFile(file, []byte(data), 0o600))
+	}
+	data, err := json.Marshal(records)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(config.PVC, signing.SignaturesFile), data, 0o600))
+}
+
+func TestBuild(t *testing.T) {
+	kernel, ipxe := "signed kernel", "signed iPXE"
+	setupPVC(t, map[string]string{
+		"vmlinuz-x86_64":                    kernel,
+		"vmlinuz-x86_64.sig":                "kernel signature",
+		"signed_ipxe.efi":                   ipxe,
+		"boot_media/site-1/signed_ipxe.efi": "site iPXE",
+		"keys/db.der":                       "db certificate",
+		manifest.File:                       "{}",
+		"initramfs-x86_64.tmp":              "partial",
+	}, []signing.SignatureRecord{
+		{Artifact: "vmlinuz-x86_64", SHA256: sha256Hex([]byte(kernel)), Type: signing.SignatureTypeAuthenticode, KeyID: "key"},
+		{
+			Artifact: "vmlinuz-x86_64", SHA256: sha256Hex([]byte(kernel)), Type: signing.SignatureTypeDetached,
+			Signature: "vmlinuz-x86_64.sig", KeyID: "key",
+		},
+		{Artifact: "signed_ipxe.efi", SHA256: sha256Hex([]byte(ipxe)), Type: signing.SignatureTypeAuthenticode, KeyID: "key"},
+		// The record of a previous build of the iPXE of the site does not apply to the current one.
+		{
+			Artifact: "boot_media/site-1/signed_ipxe.efi", SHA256: sha256Hex([]byte("previous")),
+			Type: signing.SignatureTypeAuthenticode, KeyID: "previous key",
+		},
+	})
+	orchCA := []byte("orch CA certificate")
+	require.NoError(t, os.WriteFile(config.OrchCACertificateFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: orchCA}), 0o600))
+
+	infraConfig := config.InfraConfig{
+		CDN:                "files.example.com",
+		EMBImageURL:        "uos/1.0.0/emb_uos_x86_64.tar.gz",
+		ENAgentManifestTag: "3.1.0",
+	}
+	m, err := manifest.Build(infraConfig, "1.2.3")
+	require.NoError(t, err)
+	require.Equal(t, "1.2.3", m.Version)
+	require.Equal(t, "3.1.0", m.ENManifestTag)
+	require.Equal(t, sha256Hex(orchCA), m.OrchCAFingerprint)
+	require.Empty(t, m.BootsCAFingerprint)
+	require.Len(t, m.ConfigHash, sha256.Size*2)
+
+	paths := make([]string, 0, len(m.Artifacts))
+	for _, artifact := range m.Artifacts {
+		paths = append(paths, artifact.Path)
+	}
+	require.Equal(t, []string{
+		"boot_media/site-1/signed_ipxe.efi", "keys/db.der", "signed_ipxe.efi", "vmlinuz-x86_64", "vmlinuz-x86_64.sig",
+	}, paths)
+
+	uOSURL := "https://files.example.com/uos/1.0.0/emb_uos_x86_64.tar.gz"
+	kernelArtifact := m.Artifacts[3]
+	require.Equal(t, "vmlinuz-x86_64", kernelArtifact.Name)
+	require.Equal(t, int64(len(kernel)), kernelArtifact.Size)
+	require.Equal(t, sha256Hex([]byte(kernel)), kernelArtifact.SHA256)
+	require.Equal(t, uOSURL, kernelArtifact.SourceURL)
+	require.Equal(t, "vmlinuz-x86_64.sig", kernelArtifact.Signature)
+	require.Equal(t, "key", kernelArtifact.KeyID)
+	require.False(t, kernelArtifact.BuiltAt.IsZero())
+
+	require.Equal(t, uOSURL, m.Artifacts[4].SourceURL)
+	require.Equal(t, "key", m.Artifacts[4].KeyID)
+	require.Equal(t, "signed_ipxe.efi", m.Artifacts[0].Name)
+	require.Empty(t, m.Artifacts[0].KeyID)
+	require.Empty(t, m.Artifacts[1].KeyID)
+	require.Equal(t, "key", m.Artifacts[2].KeyID)
+	require.Empty(t, m.Artifacts[2].SourceURL)
+
+	// The config hash only depends on the config.
+	again, err := manifest.Build(infraConfig, "1.2.3")
+	require.NoError(t, err)
+	require.Equal(t, m.ConfigHash, again.ConfigHash)
+	infraConfig.ENAgentManifestTag = "3.2.0"
+	changed, err := manifest.Build(infraConfig, "1.2.3")
+	require.NoError(t, err)
+	require.NotEqual(t, m.ConfigHash, changed.ConfigHash)
+
+	require.NoError(t, manifest.Write(m))
+	data, err := os.ReadFile(filepath.Join(config.PVC, manifest.File))
+	require.NoError(t, err)
+	var written manifest.Manifest
+	require.NoError(t, json.Unmarshal(data, &written))
+	require.Equal(t, m.Artifacts, written.Artifacts)
+}
+
+func TestHandler(t *testing.T) {
+	setupPVC(t, map[string]string{"signed_ipxe.efi": "signed iPXE"}, nil)
+	server := httptest.NewServer(manifest.Handler())
+	defer server.Close()
+
+	get := func(path string, v any) int {
+		t.Helper()
+		resp, err := http.Get(server.URL + path) //nolint:noctx // Test request.
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+		return resp.StatusCode
+	}
+
+	manifest.Start()
+	var status manifest.Status
+	require.Equal(t, http.StatusServiceUnavailable, get("/status", &status))
+	require.Equal(t, manifest.StateBuilding, status.State)
+	require.False(t, status.StartedAt.IsZero())
+	require.Equal(t, http.StatusServiceUnavailable, get("/manifest", &status))
+
+	m, err := manifest.Build(config.InfraConfig{}, "1.2.3")
+	require.NoError(t, err)
+	manifest.Complete(m)
+	require.Equal(t, http.StatusOK, get("/status", &status))
+	require.Equal(t, manifest.StateReady, status.State)
+	require.Equal(t, m.ConfigHash, status.ConfigHash)
+	require.Equal(t, 1, status.Artifacts)
+	var served manifest.Manifest
+	require.Equal(t, http.StatusOK, get("/manifest", &served))
+	require.Equal(t, m.Artifacts, served.Artifacts)
+
+	manifest.Start()
+	manifest.Fail(errors.New("download failed"))
+	status = manifest.Status{}
+	require.Equal(t, http.StatusServiceUnavailable, get("/status", &status))
+	require.Equal(t, manifest.StateFailed, status.State)
+	require.Equal(t, "download failed", status.Error)
+	require.Nil(t, manifest.GetManifest())
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// StateBuilding is the state of DKAM while it downloads, builds and signs the artifacts.
+	StateBuilding = "building"
+	// StateReady is the state of DKAM once the artifacts and their manifest are in the PVC.
+	StateReady = "ready"
+	// StateFailed is the state of DKAM when the artifacts could not be built.
+	StateFailed = "failed"
+)
+
+// Status is the status of the build of the artifacts.
+type Status struct {
+	State string `json:"state"`
+	// Error is the error the build failed with.
+	Error       string    `json:"error,omitempty"`
+	StartedAt   time.Time `json:"startedAt,omitzero"`
+	CompletedAt time.Time `json:"completedAt,omitzero"`
+	// ConfigHash and Artifacts are the config hash and the number of artifacts of the manifest, once ready.
+	ConfigHash string `json:"configHash,omitempty"`
+	Artifacts  int    `json:"artifacts"`
+}
+
+var (
+	statusLock      sync.RWMutex
+	currentStatus   = Status{State: StateBuilding}
+	currentManifest *Manifest
+)
+
+// Start records the start of a build of the artifacts.
+func Start() {
+	statusLock.Lock()
+	defer statusLock.Unlock()
+	currentStatus = Status{State: StateBuilding, StartedAt: time.Now().UTC()}
+	currentManifest = nil
+}
+
+// Complete records the manifest of the artifacts built.
+func Complete(m *Manifest) {
+	statusLock.Lock()
+	defer statusLock.Unlock()
+	currentStatus.State = StateReady
+	currentStatus.CompletedAt = time.Now().UTC()
+	currentStatus.ConfigHash = m.ConfigHash
+	currentStatus.Artifacts = len(m.Artifacts)
+	currentManifest = m
+}
+
+// Fail records the error the build of the artifacts failed with.
+func Fail(err error) {
+	statusLock.Lock()
+	defer statusLock.Unlock()
+	currentStatus.State = StateFailed
+	currentStatus.Error = err.Error()
+	currentStatus.CompletedAt = time.Now().UTC()
+}
+
+// GetStatus returns the status of the build of the artifacts.
+func GetStatus() Status {
+	statusLock.RLock()
+	defer statusLock.RUnlock()
+	return currentStatus
+}
+
+// GetManifest returns the manifest of the artifacts, nil until they are built.
+func GetManifest() *Manifest {
+	statusLock.RLock()
+	defer statusLock.RUnlock()
+	return currentManifest
+}
+
+// Handler returns the HTTP handler serving the manifest on /manifest and the status of the build on /status.
+// Both respond with 503 Service Unavailable until the artifacts are built.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /manifest", func(w http.ResponseWriter, _ *http.Request) {
+		if m := GetManifest(); m != nil {
+			writeJSON(w, http.StatusOK, m)
+			return
+		}
+		writeJSON(w, http.StatusServiceUnavailable, GetStatus())
+	})
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, _ *http.Request) {
+		status := GetStatus()
+		code := http.StatusOK
+		if status.State != StateReady {
+			code = http.StatusServiceUnavailable
+		}
+		writeJSON(w, code, status)
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		zlog.InfraSec().Error().Err(err).Msg("Failed to write response")
+	}
+}
@@ -5,12 +5,47 @@
 
 set -xuo pipefail
 working_dir=$1
+shift
+# architectures to build iPXE for, x86_64 if none is given
+architectures=("${@:-x86_64}")
 IPXE_DIR=$working_dir/ipxe
 SB_KEYS_DIR=$working_dir/sb_keys
 SERVER_CERT_DIR=$working_dir/server_certs
+# iPXE scripts of the boot media of the sites, written by DKAM as <site>.ipxe
+BOOT_MEDIA_DIR=$working_dir/boot_media
+# the iPXE binaries are left there for DKAM to sign, with the signing backend holding the db key
+UNSIGNED_DIR=$working_dir/unsigned
+# DKAM generates the Secure Boot keys unless its signing backend has a key
+GENERATE_SB_KEYS=${GENERATE_SB_KEYS:-true}
 RSA_KEY_SIZE=4096
 HASH_SIZE=512
 
+# ipxe_platform returns the iPXE build platform of the architecture.
+ipxe_platform() {
+	case "$1" in
+		x86_64) echo "bin-x86_64-efi" ;;
+		aarch64) echo "bin-arm64-efi" ;;
+		*) echo "======== Unsupported architecture $1 ========" >&2; exit 1 ;;
+	esac
+}
+
+# ipxe_cross returns the cross-compiler prefix of the architecture, if it is not the one of the build host.
+ipxe_cross() {
+	if [ "$1" != "$(uname -m)" ]; then
+		echo "$1-linux-gnu-"
+	fi
+}
+
+# signed_ipxe_name returns the name of the signed iPXE binary of the architecture. The x86_64 binary keeps the
+# name it had before other architectures were supported.
+signed_ipxe_name() {
+	if [ "$1" = "x86_64" ]; then
+		echo "signed_ipxe.efi"
+	else
+		echo "signed_ipxe_$1.efi"
+	fi
+}
+
 generate_bios_certs() {
 	echo "====== Generating BIOS Certificate ======="
 	#verify that pk kek db is already present.
@@ -120,7 +155,9 @@ build_ipxe_efi() {
 
 	cp chain.ipxe "$IPXE_DIR"/src
 	cd "$IPXE_DIR"/src || exit
-	make bin-x86_64-efi/ipxe.efi >> /dev/null
+	for arch in "${architectures[@]}"; do
+		make "$(ipxe_platform "$arch")"/ipxe.efi CROSS="$(ipxe_cross "$arch")" >> /dev/null
+	done
 
 	sed -i 's|//#define\tCONSOLE_FRAMEBUFFER|#define\tCONSOLE_FRAMEBUFFER|g' "$IPXE_DIR"/src/config/console.h && \
 	sed -Ei "s/^#undef([ \t]*DOWNLOAD_PROTO_(HTTPS|FTP|SLAM|NFS)[ \t]*)/#define\1/" "$IPXE_DIR"/src/config/general.h && \
@@ -135,40 +172,62 @@ build_ipxe_efi() {
 		exit 1
 	fi
 
-	echo "======== Embedding chain script while compiling iPXE ========"
-	make bin-x86_64-efi/ipxe.efi CERT="$SERVER_CERT_DIR"/Full_server.crt TRUST="$SERVER_CERT_DIR"/ca.crt EMBED=chain.ipxe
+	for arch in "${architectures[@]}"; do
+		echo "======== Embedding chain script while compiling iPXE for $arch ========"
+		make "$(ipxe_platform "$arch")"/ipxe.efi CROSS="$(ipxe_cross "$arch")" CERT="$SERVER_CERT_DIR"/Full_server.crt TRUST="$SERVER_CERT_DIR"/ca.crt EMBED=chain.ipxe
+	done
 
 	cd "$working_dir" || exit
 	echo "==========================================================================================="
 }
 
-sign_ipxe_efi() {
-	echo "======== Signing iPXE image ========= "
-	mkdir -p out
-	
-	sbsign --key "$SB_KEYS_DIR"/db.key --cert "$SB_KEYS_DIR"/db.crt --output ./out/signed_ipxe.efi "$IPXE_DIR"/src/bin-x86_64-efi/ipxe.efi
-	cp "$SB_KEYS_DIR"/db.der "$working_dir"/out
-	
+copy_ipxe_efi() {
+	echo "======== Copying iPXE image for DKAM to sign ========= "
+	mkdir -p "$UNSIGNED_DIR"
+
+	for arch in "${architectures[@]}"; do
+		cp "$IPXE_DIR"/src/"$(ipxe_platform "$arch")"/ipxe.efi "$UNSIGNED_DIR"/"$(signed_ipxe_name "$arch")"
+	done
+
 	if [ -d "/data" ]; then
 		echo "Path /data exists."
 		mkdir -p /data/keys
-		cp "$SB_KEYS_DIR"/db.der /data/keys
 		cp "$SERVER_CERT_DIR"/Full_server.crt /data/keys
-		cp "$working_dir"/out/signed_ipxe.efi /data
-    else
-        echo "Path /data does not exist."
-    fi
-      
-	echo "======== Save db.der file to enroll inside UEFI BIOS Secure Boot Settings ========="
+	else
+		echo "Path /data does not exist."
+	fi
+
+	echo "==========================================================================================="
+}
+
+build_boot_media_ipxe() {
+	if [ ! -d "$BOOT_MEDIA_DIR" ]; then
+		return
+	fi
+	for script in "$BOOT_MEDIA_DIR"/*.ipxe; do
+		[ -f "$script" ] || continue
+		site=$(basename "$script" .ipxe)
+		echo "======== Building iPXE of the $site boot media ========"
+		# embedded scripts are named after the site, for iPXE to rebuild the embedded image of each site
+		cp "$script" "$IPXE_DIR"/src/boot_media_"$site".ipxe
+		mkdir -p "$UNSIGNED_DIR"/boot_media/"$site"
+		cd "$IPXE_DIR"/src || exit
+		for arch in "${architectures[@]}"; do
+			make "$(ipxe_platform "$arch")"/ipxe.efi CROSS="$(ipxe_cross "$arch")" CERT="$SERVER_CERT_DIR"/Full_server.crt TRUST="$SERVER_CERT_DIR"/ca.crt EMBED=boot_media_"$site".ipxe
+			cp "$(ipxe_platform "$arch")"/ipxe.efi "$UNSIGNED_DIR"/boot_media/"$site"/"$(signed_ipxe_name "$arch")"
+		done
+		cd "$working_dir" || exit
+	done
 	echo "==========================================================================================="
-	
 }
 
 final_artifacts() {
 	echo " /**************************************************************************************/"
 	echo " /**************************************************************************************/"
-	echo "Signed IPXE signed_ipxe.efi is in out/"
-	echo "Certificate to enroll in UEFI BIOS Secure Boot Settings is in sb_keys/db.der"
+	for arch in "${architectures[@]}"; do
+		echo "IPXE $(signed_ipxe_name "$arch") for $arch is in unsigned/, for DKAM to sign"
+	done
+	echo "Certificate to enroll in UEFI BIOS Secure Boot Settings is written by DKAM to /data/keys/db.der"
 	echo "Certificate to enroll in UEFI BIOS HTTPS Settings is in server_certs/Full_server.crt"
 	echo " /**************************************************************************************/"
 	echo " /**************************************************************************************/"
@@ -185,12 +244,15 @@ fi
 if [ -d "$SERVER_CERT_DIR" ]; then
 	rm -rf "$SERVER_CERT_DIR"
 fi
-generate_bios_certs
+if [ "$GENERATE_SB_KEYS" = "true" ]; then
+	generate_bios_certs
+fi
 generate_https_certs
 verify_https_certs
 build_ipxe_efi
-sign_ipxe_efi
+copy_ipxe_efi
+build_boot_media_ipxe
 final_artifacts
 rm -rf "$IPXE_DIR"
-rm -rf "$working_dir"/out
 rm -rf "$working_dir"/chain.ipxe
+rm -rf "$BOOT_MEDIA_DIR"
@@ -63,6 +63,7 @@ export oci_release_svc="${oci_release_svc:-}"
 export tink_stack_svc="${tink_stack_svc:-}"
 export release_svc="${release_svc:-}"
 export tink_server_svc="${tink_server_svc:-}"
+export onboarding_manager_svc="${onboarding_manager_svc:-}"
 export logging_svc="${logging_svc:-}"
 
 if [ -z "$oci_release_svc" ]; then
@@ -85,6 +86,11 @@ if [ -z "$tink_server_svc" ]; then
     exit 1
 fi
 
+if [ -z "$onboarding_manager_svc" ]; then
+    echo "onboarding_manager_svc is empty. Exiting..."
+    exit 1
+fi
+
 if [ -z "$logging_svc" ]; then
     echo "logging_svc is empty. Exiting..."
     exit 1
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package signing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/config"
+)
+
+const (
+	// SignaturesFile is the file of the PVC recording which key signed each artifact.
+	SignaturesFile = "signatures.json"
+
+	// SignatureTypeAuthenticode is the type of the signatures embedded in PE binaries.
+	SignatureTypeAuthenticode = "authenticode"
+	// SignatureTypeDetached is the type of the detached CMS signatures.
+	SignatureTypeDetached = "detached"
+)
+
+// SignatureRecord records which key signed an artifact of the PVC.
+type SignatureRecord struct {
+	// Artifact is the path of the artifact in the PVC.
+	Artifact string `json:"artifact"`
+	// SHA256 is the checksum of the artifact, once signed for a PE binary.
+	SHA256 string `json:"sha256"`
+	Type   string `json:"type"`
+	// Signature is the path of the detached signature in the PVC.
+	Signature string `json:"signature,omitempty"`
+	Backend   string `json:"backend"`
+	// KeyID is the SHA-256 fingerprint of the certificate of the key.
+	KeyID    string    `json:"keyId"`
+	Subject  string    `json:"subject"`
+	SignedAt time.Time `json:"signedAt"`
+}
+
+var recordsLock sync.Mutex
+
+// ReadSignatureRecords returns the signature records of the artifacts of the PVC.
+func ReadSignatureRecords() ([]SignatureRecord, error) {
+	data, err := os.ReadFile(filepath.Join(config.PVC, SignaturesFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var records []SignatureRecord
+	if err = json.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// signPE signs the PE binary in with Authenticode into the artifact of the PVC and records it.
+func signPE(ctx context.Context, signer Signer, in, artifact string) error {
+	out := filepath.Join(config.PVC, artifact)
+	if err := os.MkdirAll(filepath.Dir(out), fileMode); err != nil {
+		return err
+	}
+	if err := signer.SignPE(ctx, in, out); err != nil {
+		return err
+	}
+	return recordSignature(signer, SignatureRecord{Artifact: artifact, Type: SignatureTypeAuthenticode})
+}
+
+// signDetached writes the detached signature of the artifact of the PVC to <artifact>.sig and records it.
+func signDetached(ctx context.Context, signer Signer, artifact string) error {
+	in := filepath.Join(config.PVC, artifact)
+	if err := signer.SignDetached(ctx, in, in+".sig"); err != nil {
+		return err
+	}
+	return recordSignature(signer, SignatureRecord{
+		Artifact:  artifact,
+		Type:      SignatureTypeDetached,
+		Signature: artifact + ".sig",
+	})
+}
+
+// recordSignature completes the record with the checksum of the artifact and the key of the signer, and writes
+// it to the signature records, in place of the previous record of the same signature of the artifact.
+func recordSignature(signer Signer, record SignatureRecord) error {
+	cert, err := signer.Certificate()
+	if err != nil {
+		zlog.InfraSec().Error().Err(err).Msg("Failed to read the certificate of the signing key")
+		return err
+	}
+	sum, err := fileSHA256(filepath.Join(config.PVC, record.Artifact))
+	if err != nil {
+		return err
+	}
+	record.SHA256 = sum
+	record.Backend = signer.Backend()
+	record.KeyID = KeyID(cert)
+	record.Subject = cert.Subject.String()
+	record.SignedAt = time.Now().UTC()
+
+	recordsLock.Lock()
+	defer recordsLock.Unlock()
+	records, err := ReadSignatureRecords()
+	if err != nil {
+		return err
+	}
+	kept := records[:0]
+	for _, r := range records {
+		if r.Artifact != record.Artifact || r.Type != record.Type {
+			kept = append(kept, r)
+		}
+	}
+	data, err := json.MarshalIndent(append(kept, record), "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(config.PVC, SignaturesFile)
+	if err = os.WriteFile(path+".tmp", data, artifactMode); err != nil {
+		return err
+	}
+	zlog.InfraSec().Info().Msgf("%s signed (%s) by key %s of the %s backend", record.Artifact, record.Type,
+		record.KeyID, record.Backend)
+	return os.Rename(path+".tmp", path)
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil {
+			zlog.InfraSec().Error().Err(closeErr).Msg("Failed to close signed artifact")
+		}
+	}()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package signing
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/config"
+)
+
+// Signer signs the Secure Boot binaries and the uOS artifacts with the db key, whatever holds the key.
+type Signer interface {
+	// Backend returns the name of the signing backend, e.g. file or pkcs11.
+	Backend() string
+	// Certificate returns the certificate of the signing key, the one to enroll in the UEFI BIOS db.
+	Certificate() (*x509.Certificate, error)
+	// SignPE signs the PE binary in, e.g. iPXE, a UKI or a kernel, with Authenticode and writes the signed binary
+	// to out. in and out may be the same file.
+	SignPE(ctx context.Context, in, out string) error
+	// SignDetached writes a detached CMS signature of the file in to sig, in DER.
+	SignDetached(ctx context.Context, in, sig string) error
+}
+
+// NewSigner returns the signer of the backend selected in the configuration.
+func NewSigner(signing config.Signing) (Signer, error) {
+	if err := signing.Validate(); err != nil {
+		return nil, err
+	}
+	switch signing.Backend {
+	case config.SigningBackendPKCS11:
+		return &pkcs11Signer{
+			module:   signing.PKCS11Module,
+			token:    signing.PKCS11Token,
+			key:      signing.PKCS11Key,
+			pinFile:  signing.PKCS11PINFile,
+			certFile: signing.CertificateFile,
+		}, nil
+	default:
+		keyFile, certFile := signing.KeyFile, signing.CertificateFile
+		if signing.GeneratedKeys() {
+			keyFile = filepath.Join(GeneratedKeysDir, "db.key")
+			certFile = filepath.Join(GeneratedKeysDir, "db.crt")
+		}
+		return &fileSigner{keyFile: keyFile, certFile: certFile}, nil
+	}
+}
+
+// KeyID returns the identifier of a signing key, the SHA-256 fingerprint of its certificate.
+func KeyID(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// fileSigner signs with a key file, mounted or generated by the build scripts.
+type fileSigner struct {
+	keyFile  string
+	certFile string
+}
+
+func (s *fileSigner) Backend() string {
+	return config.SigningBackendFile
+}
+
+func (s *fileSigner) Certificate() (*x509.Certificate, error) {
+	return readCertificate(s.certFile)
+}
+
+func (s *fileSigner) SignPE(ctx context.Context, in, out string) error {
+	return runSigningCommand(ctx, nil,
+		"sbsign", "--key", s.keyFile, "--cert", s.certFile, "--output", out, in)
+}
+
+func (s *fileSigner) SignDetached(ctx context.Context, in, sig string) error {
+	return runSigningCommand(ctx, nil, "openssl", cmsSignArgs(in, sig, s.certFile, s.keyFile)...)
+}
+
+// pkcs11Signer signs with a key that never leaves a PKCS#11 token, e.g. an HSM, through the OpenSSL pkcs11
+// engine. The certificate of the key is read from a file, as sbsign requires.
+type pkcs11Signer struct {
+	module   string
+	token    string
+	key      string
+	pinFile  string
+	certFile string
+}
+
+func (s *pkcs11Signer) Backend() string {
+	return config.SigningBackendPKCS11
+}
+
+func (s *pkcs11Signer) Certificate() (*x509.Certificate, error) {
+	return readCertificate(s.certFile)
+}
+
+func (s *pkcs11Signer) SignPE(ctx context.Context, in, out string) error {
+	env, cleanup, err := s.engineEnv()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return runSigningCommand(ctx, env,
+		"sbsign", "--engine", "pkcs11", "--key", s.keyURI(), "--cert", s.certFile, "--output", out, in)
+}
+
+func (s *pkcs11Signer) SignDetached(ctx context.Context, in, sig string) error {
+	env, cleanup, err := s.engineEnv()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	args := append(cmsSignArgs(in, sig, s.certFile, s.keyURI()), "-engine", "pkcs11", "-keyform", "engine")
+	return runSigningCommand(ctx, env, "openssl", args...)
+}
+
+// keyURI returns the RFC 7512 PKCS#11 URI of the private key.
+func (s *pkcs11Signer) keyURI() string {
+	return fmt.Sprintf("pkcs11:token=%s;object=%s;type=private", url.PathEscape(s.token), url.PathEscape(s.key))
+}
+
+// engineEnv returns the environment of the OpenSSL commands, pointing at an OpenSSL configuration that loads the
+// PKCS#11 module in the pkcs11 engine. The PIN is set in the configuration rather than on the command line, for
+// it not to be visible to the other processes. The returned function removes the configuration.
+func (s *pkcs11Signer) engineEnv() ([]string, func(), error) {
+	conf := "openssl_conf = openssl_init\n\n" +
+		"[openssl_init]\nengines = engine_section\n\n" +
+		"[engine_section]\npkcs11 = pkcs11_section\n\n" +
+		"[pkcs11_section]\nengine_id = pkcs11\nMODULE_PATH = " + s.module + "\ninit = 0\n"
+	if s.pinFile != "" {
+		pin, err := os.ReadFile(s.pinFile)
+		if err != nil {
+			zlog.InfraSec().Error().Err(err).Msgf("Failed to read the PKCS#11 PIN from %s", s.pinFile)
+			return nil, nil, err
+		}
+		conf += "PIN = " + strings.TrimRight(string(pin), "\r\n") + "\n"
+	}
+	dir, err := os.MkdirTemp("", "dkam-pkcs11-")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() {
+		if removeErr := os.RemoveAll(dir); removeErr != nil {
+			zlog.InfraSec().Error().Err(removeErr).Msg("Failed to remove the OpenSSL configuration")
+		}
+	}
+	confPath := filepath.Join(dir, "openssl.cnf")
+	if err = os.WriteFile(confPath, []byte(conf), writeMode); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return append(os.Environ(), "OPENSSL_CONF="+confPath), cleanup, nil
+}
+
+// cmsSignArgs returns the arguments of openssl writing a detached CMS signature of in to sig, as verified by
+// the iPXE imgverify command.
+func cmsSignArgs(in, sig, certFile, key string) []string {
+	return []string{
+		"cms", "-sign", "-binary", "-noattr", "-md", "sha256",
+		"-in", in, "-signer", certFile, "-inkey", key, "-outform", "DER", "-out", sig,
+	}
+}
+
+func runSigningCommand(ctx context.Context, env []string, name string, args ...string) error {
+	//nolint:gosec // The command and arguments are built from the trusted signing configuration.
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = env
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		zlog.InfraSec().Error().Err(err).Msgf("Failed to sign with %s: %s", name, string(output))
+		return fmt.Errorf("%s failed: %w", name, err)
+	}
+	return nil
+}
+
+// readCertificate reads a certificate in PEM or DER.
+func readCertificate(certFile string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		data = block.Bytes
+	}
+	return x509.ParseCertificate(data)
+}
@@ -0,0 +1,270 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+//
+//nolint:testpackage // Keeping the test in the same package due to dependencies on unexported fields.
+package signing
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/config"
+)
+
+const testPIN = "1234"
+
+// softHSMModules are the usual paths of the SoftHSM PKCS#11 module, SOFTHSM2_MODULE overrides them.
+var softHSMModules = []string{
+	"/usr/lib/softhsm/libsofthsm2.so",
+	"/usr/lib/x86_64-linux-gnu/softhsm/libsofthsm2.so",
+	"/usr/lib/aarch64-linux-gnu/softhsm/libsofthsm2.so",
+	"/usr/local/lib/softhsm/libsofthsm2.so",
+}
+
+// fakeSigner signs by appending a marker, to test the signing of the artifacts without the signing tools.
+type fakeSigner struct {
+	certFile string
+}
+
+func (s *fakeSigner) Backend() string {
+	return "fake"
+}
+
+func (s *fakeSigner) Certificate() (*x509.Certificate, error) {
+	return readCertificate(s.certFile)
+}
+
+func (s *fakeSigner) SignPE(_ context.Context, in, out string) error {
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, append(data, []byte(" authenticode")...), artifactMode)
+}
+
+func (s *fakeSigner) SignDetached(_ context.Context, _, sig string) error {
+	return os.WriteFile(sig, []byte("detached"), artifactMode)
+}
+
+// writeTestKey writes a self-signed db key and certificate in PEM to dir.
+func writeTestKey(t *testing.T, dir string) (keyFile, certFile string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Secure Boot DB"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	keyFile, certFile = filepath.Join(dir, "db.key"), filepath.Join(dir, "db.crt")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), writeMode))
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), writeMode))
+	return keyFile, certFile
+}
+
+// verifyDetached verifies the detached CMS signature of the file with openssl.
+func verifyDetached(t *testing.T, file, sig, certFile string) {
+	t.Helper()
+	out, err := exec.CommandContext(context.Background(), "openssl", "cms", "-verify", "-binary", "-inform", "DER",
+		"-in", sig, "-content", file, "-certfile", certFile, "-noverify", "-out", os.DevNull).CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func requireTools(t *testing.T, tools ...string) {
+	t.Helper()
+	for _, tool := range tools {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s is not installed", tool)
+		}
+	}
+}
+
+func TestNewSigner(t *testing.T) {
+	signer, err := NewSigner(config.Signing{})
+	require.NoError(t, err)
+	require.Equal(t, &fileSigner{
+		keyFile:  filepath.Join(GeneratedKeysDir, "db.key"),
+		certFile: filepath.Join(GeneratedKeysDir, "db.crt"),
+	}, signer)
+
+	signer, err = NewSigner(config.Signing{KeyFile: "/keys/db.key", CertificateFile: "/keys/db.crt"})
+	require.NoError(t, err)
+	require.Equal(t, &fileSigner{keyFile: "/keys/db.key", certFile: "/keys/db.crt"}, signer)
+
+	signer, err = NewSigner(config.Signing{
+		Backend:         config.SigningBackendPKCS11,
+		PKCS11Module:    "/usr/lib/softhsm/libsofthsm2.so",
+		PKCS11Token:     "dkam token",
+		PKCS11Key:       "db",
+		CertificateFile: "/keys/db.crt",
+	})
+	require.NoError(t, err)
+	require.Equal(t, config.SigningBackendPKCS11, signer.Backend())
+	pkcs11, ok := signer.(*pkcs11Signer)
+	require.True(t, ok)
+	require.Equal(t, "pkcs11:token=dkam%20token;object=db;type=private", pkcs11.keyURI())
+
+	_, err = NewSigner(config.Signing{Backend: config.SigningBackendPKCS11})
+	require.Error(t, err)
+}
+
+func TestSignArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	defaultPVC, defaultUnsignedDir := config.PVC, UnsignedDir
+	config.PVC, UnsignedDir = filepath.Join(dir, "data"), filepath.Join(dir, "unsigned")
+	defer func() {
+		config.PVC, UnsignedDir = defaultPVC, defaultUnsignedDir
+	}()
+	_, certFile := writeTestKey(t, dir)
+	signer := &fakeSigner{certFile: certFile}
+
+	require.NoError(t, os.MkdirAll(filepath.Join(UnsignedDir, "boot_media", "site-1"), fileMode))
+	for _, file := range []string{
+		"signed_ipxe.efi", "boot_media/site-1/signed_ipxe.efi", "vmlinuz-x86_64", "initramfs-x86_64",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(UnsignedDir, file), []byte(file), writeMode))
+	}
+	infraConfig := config.InfraConfig{
+		// the aarch64 artifacts were not built and are skipped
+		EMBImageURLs: map[string]string{config.ArchAarch64: "uos/aarch64"},
+		BootMedia:    []config.BootMedia{{Name: "site-1"}},
+	}
+
+	ctx := context.Background()
+	require.NoError(t, signIpxe(ctx, signer, infraConfig))
+	require.NoError(t, signUOS(ctx, signer, infraConfig.Architectures()))
+	// Signing again replaces the records.
+	require.NoError(t, signUOS(ctx, signer, infraConfig.Architectures()))
+
+	signedIpxe, err := os.ReadFile(filepath.Join(config.PVC, "boot_media", "site-1", "signed_ipxe.efi"))
+	require.NoError(t, err)
+	require.Equal(t, "boot_media/site-1/signed_ipxe.efi authenticode", string(signedIpxe))
+	initramfs, err := os.ReadFile(filepath.Join(config.PVC, "initramfs-x86_64"))
+	require.NoError(t, err)
+	require.Equal(t, "initramfs-x86_64", string(initramfs))
+	require.FileExists(t, filepath.Join(config.PVC, "initramfs-x86_64.sig"))
+	require.NoFileExists(t, filepath.Join(config.PVC, "signed_ipxe_aarch64.efi"))
+
+	cert, err := readCertificate(certFile)
+	require.NoError(t, err)
+	dbCert, err := os.ReadFile(filepath.Join(config.PVC, "keys", "db.der"))
+	require.NoError(t, err)
+	require.Equal(t, cert.Raw, dbCert)
+
+	records, err := ReadSignatureRecords()
+	require.NoError(t, err)
+	signatures := map[string]string{}
+	for _, record := range records {
+		signatures[record.Artifact+" "+record.Type] = record.Signature
+		require.Equal(t, "fake", record.Backend)
+		require.Equal(t, KeyID(cert), record.KeyID)
+		require.Equal(t, "CN=Secure Boot DB", record.Subject)
+		sum, sumErr := fileSHA256(filepath.Join(config.PVC, record.Artifact))
+		require.NoError(t, sumErr)
+		require.Equal(t, sum, record.SHA256)
+	}
+	require.Equal(t, map[string]string{
+		"signed_ipxe.efi authenticode":                   "",
+		"boot_media/site-1/signed_ipxe.efi authenticode": "",
+		"vmlinuz-x86_64 authenticode":                    "",
+		"vmlinuz-x86_64 detached":                        "vmlinuz-x86_64.sig",
+		"initramfs-x86_64 detached":                      "initramfs-x86_64.sig",
+	}, signatures)
+}
+
+func TestFileSigner_SignDetached(t *testing.T) {
+	requireTools(t, "openssl")
+	dir := t.TempDir()
+	keyFile, certFile := writeTestKey(t, dir)
+	file := filepath.Join(dir, "initramfs-x86_64")
+	require.NoError(t, os.WriteFile(file, []byte("initramfs"), writeMode))
+
+	signer := &fileSigner{keyFile: keyFile, certFile: certFile}
+	require.NoError(t, signer.SignDetached(context.Background(), file, file+".sig"))
+	verifyDetached(t, file, file+".sig", certFile)
+
+	require.NoError(t, os.WriteFile(file, []byte("tampered"), writeMode))
+	out, err := exec.CommandContext(context.Background(), "openssl", "cms", "-verify", "-binary", "-inform", "DER",
+		"-in", file+".sig", "-content", file, "-certfile", certFile, "-noverify", "-out", os.DevNull).CombinedOutput()
+	require.Error(t, err, string(out))
+}
+
+// TestPKCS11Signer_SoftHSM signs with a key imported in a SoftHSM token, standing in for an HSM. It requires
+// SoftHSM, openssl and the OpenSSL pkcs11 engine, e.g. the softhsm2 and libengine-pkcs11-openssl Debian packages.
+func TestPKCS11Signer_SoftHSM(t *testing.T) {
+	requireTools(t, "softhsm2-util", "openssl")
+	module := os.Getenv("SOFTHSM2_MODULE")
+	for _, path := range softHSMModules {
+		if _, err := os.Stat(path); module == "" && err == nil {
+			module = path
+		}
+	}
+	if module == "" {
+		t.Skip("SoftHSM PKCS#11 module not found, set SOFTHSM2_MODULE")
+	}
+	if out, err := exec.CommandContext(context.Background(), "openssl", "engine", "pkcs11", "-t").CombinedOutput(); err != nil {
+		t.Skipf("OpenSSL pkcs11 engine not available: %v: %s", err, out)
+	}
+
+	dir := t.TempDir()
+	tokenDir := filepath.Join(dir, "tokens")
+	require.NoError(t, os.MkdirAll(tokenDir, fileMode))
+	softHSMConf := filepath.Join(dir, "softhsm2.conf")
+	require.NoError(t, os.WriteFile(softHSMConf, []byte("directories.tokendir = "+tokenDir+"\n"), writeMode))
+	t.Setenv("SOFTHSM2_CONF", softHSMConf)
+
+	keyFile, certFile := writeTestKey(t, dir)
+	for _, args := range [][]string{
+		{"--init-token", "--free", "--label", "dkam", "--so-pin", testPIN, "--pin", testPIN},
+		{"--import", keyFile, "--token", "dkam", "--label", "db", "--id", "01", "--pin", testPIN},
+	} {
+		out, err := exec.CommandContext(context.Background(), "softhsm2-util", args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	pinFile := filepath.Join(dir, "pin")
+	require.NoError(t, os.WriteFile(pinFile, []byte(testPIN+"\n"), writeMode))
+	// The key is in the token only.
+	require.NoError(t, os.Remove(keyFile))
+
+	signer, err := NewSigner(config.Signing{
+		Backend:         config.SigningBackendPKCS11,
+		PKCS11Module:    module,
+		PKCS11Token:     "dkam",
+		PKCS11Key:       "db",
+		PKCS11PINFile:   pinFile,
+		CertificateFile: certFile,
+	})
+	require.NoError(t, err)
+
+	file := filepath.Join(dir, "vmlinuz-x86_64")
+	require.NoError(t, os.WriteFile(file, []byte("vmlinuz"), writeMode))
+	require.NoError(t, signer.SignDetached(context.Background(), file, file+".sig"))
+	verifyDetached(t, file, file+".sig", certFile)
+
+	if pe := os.Getenv("TEST_PE_BINARY"); pe != "" {
+		requireTools(t, "sbsign", "sbverify")
+		signed := filepath.Join(dir, "signed.efi")
+		require.NoError(t, signer.SignPE(context.Background(), pe, signed))
+		out, verifyErr := exec.CommandContext(context.Background(), "sbverify", "--cert", certFile, signed).CombinedOutput()
+		require.NoError(t, verifyErr, string(out))
+	}
+}
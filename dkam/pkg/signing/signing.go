@@ -9,200 +9,85 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/logging"
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/bootmedia"
 	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/config"
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/download"
 )
 
 var zlog = logging.GetLogger("InfraDKAMAuth")
 
 const (
-	fileMode  = 0o755
-	writeMode = 0o600
+	fileMode     = 0o755
+	writeMode    = 0o600
+	artifactMode = 0o644
 )
 
-// SignMicroOS signs the MicroOS kernel image with secure boot keys.
+var (
+	// GeneratedKeysDir is the directory of the Secure Boot keys generated by build_sign_ipxe.sh, when the signing
+	// backend has no key.
+	GeneratedKeysDir = config.DownloadPath + "/sb_keys"
+	// UnsignedDir is the directory the build scripts leave the artifacts in for DKAM to sign.
+	UnsignedDir = config.DownloadPath + "/unsigned"
+)
+
+// SignMicroOS prepares the MicroOS of every architecture for the orchestrator, and signs it with secure boot keys.
 func SignMicroOS() (bool, error) {
-	zlog.InfraSec().Info().Msgf("Script dir %s", config.ScriptPath)
-	buildScriptPath, err := setupUOSDirectories()
+	infraConfig := config.GetInfraConfig()
+	signer, err := NewSigner(infraConfig.Signing)
 	if err != nil {
 		return false, err
 	}
 
-	infraConfig := config.GetInfraConfig()
-
 	zlog.InfraSec().Info().Msgf("CDN boot DNS name %s", infraConfig.ProvisioningServerURL)
 	zlog.InfraSec().Info().Msgf("Domain: %s", infraConfig.ProvisioningService)
 
-	content, err := os.ReadFile("config")
+	serverCert, caBundle, err := readUOSCertificates()
 	if err != nil {
-		zlog.InfraSec().Fatal().Err(err).Msgf("Error %v", err)
-	}
-	modifiedConfig := replaceConfigPlaceholders(content)
-	// Write the modified config back to the file
-	errconf := os.WriteFile("config", []byte(modifiedConfig), writeMode)
-	if errconf != nil {
-		zlog.InfraSec().Fatal().Err(errconf).Msgf("Error writing modified config file: %v", errconf)
-	}
-	cpioPath := buildScriptPath + "/cpio_build"
-	zlog.InfraSec().Info().Msgf("cpioPath dir %s", cpioPath)
-
-	errcpio := os.Chdir(cpioPath)
-	if errcpio != nil {
-		zlog.InfraSec().Fatal().Err(errcpio).Msgf("Error changing working directory: %v\n", errcpio)
-		return false, errcpio
-	}
-
-	modeCmd := exec.CommandContext(context.Background(), "chmod", "+x", "secure_uos.sh")
-	result, modeErr := modeCmd.CombinedOutput()
-	if modeErr != nil {
-		zlog.InfraSec().Fatal().Err(modeErr).Msgf("Failed to change mode secure_uos %v", modeErr)
-		return false, modeErr
+		return false, err
 	}
-	zlog.Info().Msgf("Script output: %s", string(result))
-
-	mdCmd := exec.CommandContext(context.Background(), "chmod", "+x", "update_initramfs.sh")
-	mdresult, mdErr := mdCmd.CombinedOutput()
-	if mdErr != nil {
-		zlog.InfraSec().Fatal().Err(mdErr).Msgf("Failed to change mode of update_initramfs.sh script %v", mdErr)
-		return false, mdErr
+	if serverCert != nil {
+		overlay, overlayErr := uOSOverlay(infraConfig, serverCert, caBundle)
+		if overlayErr != nil {
+			return false, overlayErr
+		}
+		if err = os.MkdirAll(UnsignedDir, fileMode); err != nil {
+			return false, err
+		}
+		for _, arch := range infraConfig.Architectures() {
+			uosFile := filepath.Join(config.DownloadPath, download.UOSFileNameForArch(arch))
+			if err = prepareUOS(context.Background(), uosFile, arch, overlay); err != nil {
+				return false, err
+			}
+		}
 	}
-	zlog.Info().Msgf("Script output: %s", string(mdresult))
 
-	// Ensure the working directory is correct before running the script
-	if err := verifyWorkingDirectory(cpioPath); err != nil {
-		zlog.InfraSec().Fatal().Err(err).Msgf("Working directory verification failed: %v", err)
+	if err = signUOS(context.Background(), signer, infraConfig.Architectures()); err != nil {
+		zlog.InfraSec().Error().Err(err).Msg("Failed to sign microOS")
 		return false, err
 	}
-
-	//nolint:gosec // The script and arguments are trusted and validated before execution.
-	buildCmd := exec.CommandContext(context.Background(), "bash", "./update_initramfs.sh", config.DownloadPath)
-	output, buildErr := buildCmd.CombinedOutput()
-	if buildErr != nil {
-		zlog.InfraSec().Fatal().Err(buildErr).Msgf("Failed to sign microOS script %v", buildErr)
-		return false, buildErr
-	}
-	zlog.Info().Msgf("Script output: %s", string(output))
-	errch := os.Chdir(config.ScriptPath)
-	if errch != nil {
-		zlog.InfraSec().Fatal().Err(errch).Msgf("Error changing working directory: %v\n", errch)
-		return false, errch
+	if err = removeSigningInputs(infraConfig.Signing); err != nil {
+		return false, err
 	}
 	return true, nil
 }
 
-func verifyWorkingDirectory(expected string) error {
-	wd, err := os.Getwd()
+// BuildSignIpxe builds and signs the iPXE bootloader with secure boot keys.
+func BuildSignIpxe() (bool, error) {
+	infraConfig := config.GetInfraConfig()
+	signer, err := NewSigner(infraConfig.Signing)
 	if err != nil {
-		zlog.InfraSec().Fatal().Err(err).Msgf("Error getting current working directory: %v", err)
-		return err
-	}
-	if wd != expected {
-		zlog.InfraSec().Fatal().Msgf("Working directory mismatch: expected %s, got %s", expected, wd)
-		return os.ErrInvalid
-	}
-	return nil
-}
-
-func setupUOSDirectories() (string, error) {
-	uosDir := config.ScriptPath + "/uos"
-	buildScriptPath := config.DownloadPath + "/uos"
-	zlog.InfraSec().Info().Msgf("UOS dir %s", buildScriptPath)
-	if _, err := os.Stat(buildScriptPath); err == nil {
-		removeErr := os.RemoveAll(buildScriptPath)
-		if removeErr != nil {
-			zlog.InfraSec().Error().Err(removeErr).Msgf("Error removing existing directory: %v", removeErr)
-			return "", removeErr
-		}
-	}
-	mkdirErr := os.MkdirAll(buildScriptPath, fileMode)
-	if mkdirErr != nil {
-		zlog.InfraSec().Error().Err(mkdirErr).Msgf("Error creating directory: %v", mkdirErr)
-		return "", mkdirErr
-	}
-	if err := copyDir(uosDir, buildScriptPath); err != nil {
-		zlog.InfraSec().Info().Msgf("Error copying directory:%v", err)
-		return "", err
-	}
-
-	errp := os.Chdir(buildScriptPath)
-	if errp != nil {
-		zlog.InfraSec().Fatal().Err(errp).Msgf("Error changing working directory: %v\n", errp)
-		return "", errp
+		return false, err
 	}
-
-	return buildScriptPath, nil
-}
-
-func replaceConfigPlaceholders(content []byte) string {
-	infraConfig := config.GetInfraConfig()
-
-	modifiedConfig := strings.ReplaceAll(string(content), "__http_proxy__", infraConfig.ENProxyHTTP)
-	modifiedConfig = strings.ReplaceAll(modifiedConfig, "__https_proxy__", infraConfig.ENProxyHTTPS)
-	modifiedConfig = strings.ReplaceAll(modifiedConfig, "__ftp_proxy__", infraConfig.ENProxyFTP)
-	modifiedConfig = strings.ReplaceAll(modifiedConfig, "__socks_proxy__", infraConfig.ENProxySocks)
-	modifiedConfig = strings.ReplaceAll(modifiedConfig, "__no_proxy__", infraConfig.ENProxyNoProxy)
-
-	modifiedConfig = strings.ReplaceAll(modifiedConfig, "__release_svc__", infraConfig.CDN)
-	modifiedConfig = strings.ReplaceAll(modifiedConfig, "__tink_stack_svc__", infraConfig.ProvisioningService)
-	modifiedConfig = strings.ReplaceAll(modifiedConfig, "__tink_server_svc__", infraConfig.TinkServerURL)
-	modifiedConfig = strings.ReplaceAll(modifiedConfig, "__keycloak_url__", infraConfig.KeycloakURL)
-	modifiedConfig = strings.ReplaceAll(
-		modifiedConfig,
-		"__oci_release_svc__",
-		strings.Split(infraConfig.RegistryURL, ":")[0],
-	)
-	modifiedConfig = strings.ReplaceAll(modifiedConfig, "__logging_svc__",
-		strings.Split(infraConfig.LogsObservabilityURL, ":")[0])
-	modifiedConfig = strings.ReplaceAll(modifiedConfig, "__onboarding_manager_svc__", infraConfig.OnboardingURL)
-	modifiedConfig = strings.ReplaceAll(modifiedConfig, "__onboarding_stream_svc__", infraConfig.OnboardingStreamURL)
-
-	return modifiedConfig
-}
-
-func copyDir(src, dst string) error {
-	// Create the destination directory
-	if err := os.MkdirAll(dst, fileMode); err != nil {
-		return err
+	if err = os.RemoveAll(UnsignedDir); err != nil {
+		return false, err
 	}
-
-	// Walk through the source directory
-	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Get the relative path to the file or directory
-		relPath, err := filepath.Rel(src, path)
-		if err != nil {
-			return err
-		}
-
-		// Create the destination path
-		dstPath := filepath.Join(dst, relPath)
-
-		// If it's a directory, create it in the destination
-		if info.IsDir() {
-			if err := os.MkdirAll(dstPath, fileMode); err != nil {
-				return err
-			}
-		} else {
-			// If it's a file, copy it to the destination
-			if err := copyFile(path, dstPath); err != nil {
-				return err
-			}
-		}
-
-		return nil
-	})
-}
-
-// BuildSignIpxe builds and signs the iPXE bootloader with secure boot keys.
-func BuildSignIpxe() (bool, error) {
-	provisioningServerURL := config.GetInfraConfig().ProvisioningServerURL
+	provisioningServerURL := infraConfig.ProvisioningServerURL
 	zlog.InfraSec().Info().Msgf("CDN boot DNS name %s", provisioningServerURL)
-	zlog.InfraSec().Info().Msgf("Domain: %s", config.GetInfraConfig().ProvisioningService)
+	zlog.InfraSec().Info().Msgf("Domain: %s", infraConfig.ProvisioningService)
 
 	tinkURLString := "<TINK_STACK_URL>"
 	ipxePath := config.ScriptPath + "/ipxe"
@@ -235,13 +120,22 @@ func BuildSignIpxe() (bool, error) {
 		zlog.Info().Msg("Search string not found in the file.")
 	}
 
-	errIpxe := os.Chdir(ipxePath)
-	if errIpxe != nil {
-		zlog.InfraSec().Fatal().Err(errIpxe).Msgf("Error changing working directory: %v\n", errIpxe)
-		return false, errIpxe
+	// The signed iPXE of the boot media are built along with the one of chain.ipxe, to be signed by the same keys.
+	if err = os.RemoveAll(filepath.Join(config.PVC, bootmedia.Dir)); err != nil {
+		zlog.InfraSec().Error().Err(err).Msg("Failed to remove the previous boot media")
+		return false, err
 	}
+	err = bootmedia.WriteScripts(filepath.Join(config.DownloadPath, bootmedia.Dir), infraConfig)
+	if err != nil {
+		zlog.InfraSec().Error().Err(err).Msg("Failed to write the iPXE scripts of the boot media")
+		return false, err
+	}
+
 	//nolint:gosec // The script and arguments are trusted and validated before execution.
-	cmd := exec.CommandContext(context.Background(), "bash", "./build_sign_ipxe.sh", config.DownloadPath)
+	cmd := exec.CommandContext(context.Background(), "bash",
+		scriptArgs("./build_sign_ipxe.sh", config.DownloadPath, infraConfig.Architectures())...)
+	cmd.Dir = ipxePath
+	cmd.Env = append(os.Environ(), "GENERATE_SB_KEYS="+strconv.FormatBool(infraConfig.Signing.GeneratedKeys()))
 	zlog.Info().Msgf("signCmd: %s", cmd)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -249,9 +143,103 @@ func BuildSignIpxe() (bool, error) {
 		return false, err
 	}
 	zlog.Info().Msgf("Script output: %s", string(output))
+
+	if err = signIpxe(context.Background(), signer, infraConfig); err != nil {
+		zlog.InfraSec().Error().Err(err).Msg("Failed to sign iPXE")
+		return false, err
+	}
+	if err = os.RemoveAll(UnsignedDir); err != nil {
+		return false, err
+	}
 	return true, nil
 }
 
+// signIpxe signs the iPXE binaries built by build_sign_ipxe.sh, of chain.ipxe and of the boot media, into the PVC
+// and writes the certificate of the signing key next to them. Binaries the script did not build are skipped.
+func signIpxe(ctx context.Context, signer Signer, infraConfig config.InfraConfig) error {
+	var artifacts []string
+	for _, arch := range infraConfig.Architectures() {
+		artifacts = append(artifacts, bootmedia.SignedIpxeName(arch))
+		for _, media := range infraConfig.BootMedia {
+			artifacts = append(artifacts, filepath.Join(bootmedia.Dir, media.Name, bootmedia.SignedIpxeName(arch)))
+		}
+	}
+	signed := 0
+	for _, artifact := range artifacts {
+		in := filepath.Join(UnsignedDir, artifact)
+		if _, err := os.Stat(in); err != nil {
+			zlog.InfraSec().Warn().Msgf("%s was not built, skipping its signature", artifact)
+			continue
+		}
+		if err := signPE(ctx, signer, in, artifact); err != nil {
+			return err
+		}
+		signed++
+	}
+	if signed == 0 {
+		return nil
+	}
+	return writeDBCertificate(signer)
+}
+
+// signUOS signs the kernel of the uOS of each architecture with Authenticode into the PVC, and writes the
+// detached signatures of the kernel and the initramfs. Architectures the script did not prepare are skipped.
+func signUOS(ctx context.Context, signer Signer, archs []string) error {
+	for _, arch := range archs {
+		kernel, initramfs := "vmlinuz-"+arch, "initramfs-"+arch
+		if _, err := os.Stat(filepath.Join(UnsignedDir, kernel)); err != nil {
+			zlog.InfraSec().Warn().Msgf("%s was not built, skipping its signature", kernel)
+			continue
+		}
+		if err := signPE(ctx, signer, filepath.Join(UnsignedDir, kernel), kernel); err != nil {
+			return err
+		}
+		if err := signDetached(ctx, signer, kernel); err != nil {
+			return err
+		}
+		if err := copyFile(filepath.Join(UnsignedDir, initramfs), filepath.Join(config.PVC, initramfs)); err != nil {
+			return err
+		}
+		if err := signDetached(ctx, signer, initramfs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeDBCertificate writes the certificate of the signing key to the PVC, for technicians to enroll it in the
+// UEFI BIOS db.
+func writeDBCertificate(signer Signer) error {
+	cert, err := signer.Certificate()
+	if err != nil {
+		zlog.InfraSec().Error().Err(err).Msg("Failed to read the certificate of the signing key")
+		return err
+	}
+	keysDir := filepath.Join(config.PVC, "keys")
+	if err = os.MkdirAll(keysDir, fileMode); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(keysDir, "db.der"), cert.Raw, artifactMode)
+}
+
+// removeSigningInputs removes the unsigned artifacts and the generated keys, once the uOS, the last artifact
+// signed with them, is signed.
+func removeSigningInputs(signing config.Signing) error {
+	if err := os.RemoveAll(UnsignedDir); err != nil {
+		return err
+	}
+	if signing.GeneratedKeys() {
+		return os.RemoveAll(GeneratedKeysDir)
+	}
+	return nil
+}
+
+// scriptArgs returns the arguments of a build script run in the working directory for the architectures.
+// The scripts build the artifacts of all the architectures in one run, for them to be signed by the same keys.
+func scriptArgs(script, workingDir string, archs []string) []string {
+	return append([]string{script, workingDir}, archs...)
+}
+
 func copyFile(src, dst string) error {
 	source, err := os.Open(src)
 	if err != nil {
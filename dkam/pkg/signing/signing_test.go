@@ -59,44 +59,3 @@ func Test_copyFile(t *testing.T) {
 		})
 	}
 }
-
-func Test_copyDir(t *testing.T) {
-	type args struct {
-		src string
-		dst string
-	}
-	wd, err := os.Getwd()
-	if err != nil {
-		fmt.Println("error while getting directory : ", err)
-	}
-	tests := []struct {
-		name    string
-		args    args
-		wantErr bool
-	}{
-		{
-			name: "Test Case",
-			args: args{
-				dst: "",
-			},
-			wantErr: true,
-		},
-		{
-			name: "Test Case",
-			args: args{
-				dst: wd + "dummy",
-			},
-			wantErr: true,
-		},
-	}
-	defer func() {
-		_ = os.RemoveAll(wd + "dummy")
-	}()
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if err := copyDir(tt.args.src, tt.args.dst); (err != nil) != tt.wantErr {
-				t.Errorf("copyDir() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
-	}
-}
@@ -0,0 +1,330 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package signing
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/config"
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/initramfs"
+)
+
+const (
+	// uOSOverlayDir is the directory of the files added to /etc of the uOS rootfs, in the script directory.
+	uOSOverlayDir = "uos/etc"
+	// uOSRootfs is the rootfs tarball of the uOS initramfs.
+	uOSRootfs = "rootfs.tar.gz"
+	// uOSEnvConfig is the environment of the uOS agents.
+	uOSEnvConfig = "etc/emf/env_config"
+	uOSGettyUnit = "etc/systemd/system/getty@tty1.service"
+)
+
+// uOSUnitEdits are the edits of the systemd units of the uOS rootfs: the agents wait for an IP address and for
+// Caddy, the proxy to the orchestrator, and the console logs in automatically.
+var uOSUnitEdits = map[string]initramfs.EditFunc{
+	"usr/lib/systemd/system/device-discovery.service": editUnit(
+		insertBefore(`^ExecStart=`, "ExecStartPre=/etc/ip-assignment/wait_for_ip.sh"),
+	),
+	"usr/lib/systemd/system/caddy.service": editUnit(
+		replace("", regexp.QuoteMeta("User=caddy"), "User=root"),
+		replace("", regexp.QuoteMeta("Group=caddy"), "Group=root"),
+		replace("", regexp.QuoteMeta("ExecStartPre=/usr/bin/caddy validate --config /etc/caddy/Caddyfile"), ""),
+		replace("", regexp.QuoteMeta("ExecReload=/usr/bin/caddy reload --config /etc/caddy/Caddyfile"), ""),
+		replace("", regexp.QuoteMeta("ExecStart=/usr/bin/caddy run --environ --config /etc/caddy/Caddyfile"),
+			"ExecStart=/etc/caddy/caddy_run.sh"),
+		insertAfter(`^ExecStart=.*caddy_run\.sh$`, "ReadWritePaths=/etc/pki/ca-trust"),
+		replace("Unit", `^After=network.target network-online.target`,
+			"After=network.target network-online.target device-discovery.service"),
+		replace("Unit", `^Requires=network-online.target`, "Requires=network-online.target device-discovery.service"),
+	),
+	"usr/lib/systemd/system/fluent-bit.service": editUnit(
+		replace("", regexp.QuoteMeta("ExecStart=/usr/bin/fluent-bit -c /etc/fluent-bit/fluent-bit.conf"),
+			"ExecStart=/etc/fluent-bit/fluentbit_run.sh"),
+		replace("Unit", `^After=network.target`, "After=network.target caddy.service"),
+		replace("Unit", `^Requires=network.target`, "Requires=network.target caddy.service"),
+	),
+	"usr/lib/systemd/system/tink-worker.service": editUnit(
+		replace("Unit", `^After=network-online.target`, "After=network-online.target caddy.service"),
+		insertAfter(`^After=network-online.target caddy.service$`, "Requires=caddy.service"),
+		insertBefore(`^ExecStart=`, "ExecStartPre=-/etc/kpi-instrumentation/report_boot_statistics.sh"),
+	),
+	uOSGettyUnit: editUnit(
+		replace("", `^ExecStart=.*agetty.*`, "ExecStart=-/usr/sbin/agetty --autologin root --noclear %I"),
+		insertAfter(`^ConditionPathExists=`, "Requires=device-discovery.service"),
+		insertAfter(`^ConditionPathExists=`, "After=device-discovery.service"),
+		insertAfter(`^DefaultInstance=tty1`, "Alias=getty@tty1.service"),
+	),
+}
+
+// readUOSCertificates returns the CA certificates trusted by the uOS: the orchestrator CA, and the bundle of the
+// orchestrator and boots CAs. It returns nil certificates if a CA certificate is missing, for the uOS not to be
+// prepared.
+func readUOSCertificates() (serverCert, caBundle []byte, err error) {
+	certs := make([][]byte, 0, 2)
+	for _, certFile := range []string{config.OrchCACertificateFile, config.BootsCaCertificateFile} {
+		cert, readErr := os.ReadFile(certFile)
+		if errors.Is(readErr, os.ErrNotExist) || (readErr == nil && len(cert) == 0) {
+			zlog.InfraSec().Warn().Msgf("CA certificate %s missing or empty, the uOS is not prepared", certFile)
+			return nil, nil, nil
+		}
+		if readErr != nil {
+			zlog.InfraSec().Error().Err(readErr).Msgf("Failed to read CA certificate %s", certFile)
+			return nil, nil, readErr
+		}
+		certs = append(certs, cert)
+	}
+	return certs[0], bytes.Join(certs, []byte("\n")), nil
+}
+
+// uOSEnvironment returns the environment of the uOS agents, the orchestrator services and proxies.
+func uOSEnvironment(infraConfig config.InfraConfig) []byte {
+	var env strings.Builder
+	if infraConfig.KeycloakURL != "" {
+		fmt.Fprintf(&env, "KEYCLOAK_URL=%s\n", infraConfig.KeycloakURL)
+	}
+	if infraConfig.CDN != "" {
+		fmt.Fprintf(&env, "release_svc=%s\n", infraConfig.CDN)
+		fmt.Fprintf(&env, "oci_release_svc=%s\n", strings.Split(infraConfig.RegistryURL, ":")[0])
+		fmt.Fprintf(&env, "tink_stack_svc=%s\n", infraConfig.ProvisioningService)
+		fmt.Fprintf(&env, "tink_server_svc=%s\n", infraConfig.TinkServerURL)
+		fmt.Fprintf(&env, "onboarding_manager_svc=%s\n", infraConfig.OnboardingURL)
+		fmt.Fprintf(&env, "onboarding_stream_svc=%s\n", infraConfig.OnboardingStreamURL)
+		env.WriteString("OBM_PORT=443\n")
+	}
+	if loggingService := strings.Split(infraConfig.LogsObservabilityURL, ":")[0]; loggingService != "" {
+		fmt.Fprintf(&env, "logging_svc=%s\n", loggingService)
+	}
+	if infraConfig.ENProxyHTTPS != "" {
+		fmt.Fprintf(&env, "http_proxy=%s\n", infraConfig.ENProxyHTTP)
+		fmt.Fprintf(&env, "https_proxy=%s\n", infraConfig.ENProxyHTTPS)
+		fmt.Fprintf(&env, "no_proxy=%s\n", infraConfig.ENProxyNoProxy)
+	}
+	return []byte(env.String())
+}
+
+// uOSOverlay returns the overlay of the uOS initramfs, adding to its rootfs the environment of the agents, the CA
+// certificates, the scripts of the agents and editing their systemd units.
+func uOSOverlay(infraConfig config.InfraConfig, serverCert, caBundle []byte) (*initramfs.Overlay, error) {
+	env := uOSEnvironment(infraConfig)
+	files := []initramfs.File{
+		{Name: uOSEnvConfig, Mode: artifactMode, Data: env},
+		{Name: "etc/hook/env_config", Mode: artifactMode, Data: env},
+		{Name: "etc/idp/server_cert.pem", Mode: artifactMode, Data: serverCert},
+		{Name: "etc/idp/ca.pem", Mode: artifactMode, Data: caBundle},
+		{Name: "etc/pki/ca-trust/source/anchors/server_cert.pem", Mode: artifactMode, Data: serverCert},
+		{Name: "etc/pki/ca-trust/source/anchors/ca.pem", Mode: artifactMode, Data: caBundle},
+		{Name: uOSGettyUnit, Mode: artifactMode, From: "usr/lib/systemd/system/getty@.service"},
+		{
+			Name:     "etc/systemd/system/getty.target.wants/getty@tty1.service",
+			Mode:     fs.ModeSymlink | os.ModePerm,
+			Linkname: "/" + uOSGettyUnit,
+		},
+	}
+
+	overlayDir := filepath.Join(config.ScriptPath, uOSOverlayDir)
+	err := filepath.WalkDir(overlayDir, func(file string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil || !d.Type().IsRegular() {
+			return walkErr
+		}
+		rel, err := filepath.Rel(overlayDir, file)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		mode := fs.FileMode(artifactMode)
+		if strings.HasSuffix(file, ".sh") {
+			mode = fileMode
+		}
+		files = append(files, initramfs.File{Name: path.Join("etc", filepath.ToSlash(rel)), Mode: mode, Data: data})
+		return nil
+	})
+	if err != nil {
+		zlog.InfraSec().Error().Err(err).Msgf("Failed to read the uOS files from %s", overlayDir)
+		return nil, err
+	}
+
+	return &initramfs.Overlay{
+		Archives: map[string]*initramfs.Overlay{
+			uOSRootfs: {Files: files, Edits: uOSUnitEdits},
+		},
+	}, nil
+}
+
+// prepareUOS extracts the kernel and the initramfs of the uOS archive of the architecture to UnsignedDir, for them
+// to be signed, applying the overlay to the initramfs. The initramfs is recompressed with xz.
+func prepareUOS(ctx context.Context, uosFile, arch string, overlay *initramfs.Overlay) error {
+	f, err := os.Open(uosFile)
+	if err != nil {
+		zlog.InfraSec().Error().Err(err).Msgf("uOS of %s not found", arch)
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil {
+			zlog.InfraSec().Error().Err(closeErr).Msg("Failed to close uOS archive")
+		}
+	}()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+
+	kernel, initrd := "vmlinuz-"+arch, "initramfs-"+arch
+	var kernelFound, initrdFound bool
+	tr := tar.NewReader(zr)
+	for !kernelFound || !initrdFound {
+		h, nextErr := tr.Next()
+		if errors.Is(nextErr, io.EOF) {
+			break
+		}
+		if nextErr != nil {
+			return nextErr
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Base(h.Name)
+		switch {
+		case !kernelFound && strings.HasPrefix(name, "vmlinuz"):
+			kernelFound = true
+			if err = writeUnsigned(kernel, func(w io.Writer) error {
+				_, copyErr := io.Copy(w, tr)
+				return copyErr
+			}); err != nil {
+				return err
+			}
+		case !initrdFound && strings.HasPrefix(name, "initramfs"):
+			initrdFound = true
+			// The files added to the initramfs are dated as the initramfs, for it to only depend on the uOS release
+			// and the configuration.
+			initrdOverlay := *overlay
+			initrdOverlay.ModTime = h.ModTime
+			if err = writeUnsigned(initrd, func(w io.Writer) error {
+				return compressXZ(ctx, w, func(xzw io.Writer) error {
+					return initramfs.Rewrite(tr, xzw, initramfs.CompressionNone, &initrdOverlay)
+				})
+			}); err != nil {
+				zlog.InfraSec().Error().Err(err).Msgf("Failed to prepare the initramfs of the uOS of %s", arch)
+				return err
+			}
+		}
+	}
+	if !kernelFound || !initrdFound {
+		return fmt.Errorf("kernel or initramfs not found in %s", uosFile)
+	}
+	zlog.InfraSec().Info().Msgf("uOS of %s prepared", arch)
+	return nil
+}
+
+// writeUnsigned writes an artifact to UnsignedDir through a temporary file, for a failure not to leave a partial
+// artifact to sign.
+func writeUnsigned(artifact string, write func(w io.Writer) error) error {
+	out := filepath.Join(UnsignedDir, artifact)
+	f, err := os.Create(out + ".tmp")
+	if err != nil {
+		return err
+	}
+	if err = write(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(out + ".tmp")
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(out+".tmp", out)
+}
+
+// compressXZ writes the content written by write to w compressed with xz, with the CRC32 check the kernel requires.
+func compressXZ(ctx context.Context, w io.Writer, write func(w io.Writer) error) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "xz", "--check=crc32", "-T", "6")
+	cmd.Stdout, cmd.Stderr = w, &stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err = cmd.Start(); err != nil {
+		return err
+	}
+	writeErr := write(stdin)
+	closeErr := stdin.Close()
+	if err = cmd.Wait(); err != nil {
+		zlog.InfraSec().Error().Err(err).Msgf("xz failed: %s", stderr.String())
+	}
+	return errors.Join(writeErr, closeErr, err)
+}
+
+// unitRule rewrites a line of a systemd unit into the lines replacing it. section is the section of the line,
+// until the empty line ending it.
+type unitRule func(section, line string) []string
+
+// editUnit returns the edit of a systemd unit applying the rules one after the other.
+func editUnit(rules ...unitRule) initramfs.EditFunc {
+	return func(data []byte) ([]byte, error) {
+		lines := strings.Split(string(data), "\n")
+		for _, rule := range rules {
+			edited := make([]string, 0, len(lines))
+			section := ""
+			for _, line := range lines {
+				if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+					section = strings.Trim(trimmed, "[]")
+				} else if trimmed == "" {
+					section = ""
+				}
+				edited = append(edited, rule(section, line)...)
+			}
+			lines = edited
+		}
+		return []byte(strings.Join(lines, "\n")), nil
+	}
+}
+
+// insertBefore inserts a line before the lines matching the pattern.
+func insertBefore(pattern, inserted string) unitRule {
+	re := regexp.MustCompile(pattern)
+	return func(_, line string) []string {
+		if re.MatchString(line) {
+			return []string{inserted, line}
+		}
+		return []string{line}
+	}
+}
+
+// insertAfter inserts a line after the lines matching the pattern.
+func insertAfter(pattern, inserted string) unitRule {
+	re := regexp.MustCompile(pattern)
+	return func(_, line string) []string {
+		if re.MatchString(line) {
+			return []string{line, inserted}
+		}
+		return []string{line}
+	}
+}
+
+// replace replaces the first match of the pattern in the lines of the section, of any section if empty.
+func replace(section, pattern, replacement string) unitRule {
+	re := regexp.MustCompile(pattern)
+	return func(lineSection, line string) []string {
+		if loc := re.FindStringIndex(line); loc != nil && (section == "" || section == lineSection) {
+			return []string{line[:loc[0]] + replacement + line[loc[1]:]}
+		}
+		return []string{line}
+	}
+}
@@ -0,0 +1,296 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+//
+//nolint:testpackage // Keeping the test in the same package due to dependencies on unexported fields.
+package signing
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/config"
+	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/initramfs"
+)
+
+const (
+	testCaddyUnit = `[Unit]
+Description=Caddy
+After=network.target network-online.target
+Requires=network-online.target
+
+[Service]
+User=caddy
+Group=caddy
+ExecStartPre=/usr/bin/caddy validate --config /etc/caddy/Caddyfile
+ExecStart=/usr/bin/caddy run --environ --config /etc/caddy/Caddyfile
+ExecReload=/usr/bin/caddy reload --config /etc/caddy/Caddyfile
+
+[Install]
+WantedBy=multi-user.target
+`
+	testGettyUnit = `[Unit]
+Description=Getty on %I
+After=systemd-user-sessions.service
+ConditionPathExists=/dev/tty0
+
+[Service]
+ExecStart=-/sbin/agetty -o '-p -- \\u' --noclear - $TERM
+
+[Install]
+WantedBy=getty.target
+DefaultInstance=tty1
+`
+	testTinkWorkerUnit = `[Unit]
+Description=Tink worker
+After=network-online.target
+
+[Service]
+ExecStart=/usr/bin/tink-worker
+`
+)
+
+func TestEditUnit(t *testing.T) {
+	caddy, err := uOSUnitEdits["usr/lib/systemd/system/caddy.service"]([]byte(testCaddyUnit))
+	require.NoError(t, err)
+	require.Equal(t, `[Unit]
+Description=Caddy
+After=network.target network-online.target device-discovery.service
+Requires=network-online.target device-discovery.service
+
+[Service]
+User=root
+Group=root
+
+ExecStart=/etc/caddy/caddy_run.sh
+ReadWritePaths=/etc/pki/ca-trust
+
+
+[Install]
+WantedBy=multi-user.target
+`, string(caddy))
+
+	getty, err := uOSUnitEdits[uOSGettyUnit]([]byte(testGettyUnit))
+	require.NoError(t, err)
+	require.Equal(t, `[Unit]
+Description=Getty on %I
+After=systemd-user-sessions.service
+ConditionPathExists=/dev/tty0
+After=device-discovery.service
+Requires=device-discovery.service
+
+[Service]
+ExecStart=-/usr/sbin/agetty --autologin root --noclear %I
+
+[Install]
+WantedBy=getty.target
+DefaultInstance=tty1
+Alias=getty@tty1.service
+`, string(getty))
+
+	// The rules only apply to the lines of their section.
+	edited, err := editUnit(replace("Unit", `^After=`, "Before="))([]byte("[Service]\nAfter=a\n[Unit]\nAfter=b\n\nAfter=c\n"))
+	require.NoError(t, err)
+	require.Equal(t, "[Service]\nAfter=a\n[Unit]\nBefore=b\n\nAfter=c\n", string(edited))
+}
+
+func TestUOSEnvironment(t *testing.T) {
+	require.Empty(t, uOSEnvironment(config.InfraConfig{}))
+	require.Equal(t, `KEYCLOAK_URL=keycloak.example.com
+release_svc=release.example.com
+oci_release_svc=registry.example.com
+tink_stack_svc=tinkerbell.example.com
+tink_server_svc=tink-server.example.com
+onboarding_manager_svc=onboarding.example.com
+onboarding_stream_svc=onboarding-stream.example.com
+OBM_PORT=443
+logging_svc=logs.example.com
+http_proxy=http://proxy.example.com:911
+https_proxy=http://proxy.example.com:912
+no_proxy=localhost
+`, string(uOSEnvironment(config.InfraConfig{
+		KeycloakURL:          "keycloak.example.com",
+		CDN:                  "release.example.com",
+		RegistryURL:          "registry.example.com:443",
+		ProvisioningService:  "tinkerbell.example.com",
+		TinkServerURL:        "tink-server.example.com",
+		OnboardingURL:        "onboarding.example.com",
+		OnboardingStreamURL:  "onboarding-stream.example.com",
+		LogsObservabilityURL: "logs.example.com:443",
+		ENProxyHTTP:          "http://proxy.example.com:911",
+		ENProxyHTTPS:         "http://proxy.example.com:912",
+		ENProxyNoProxy:       "localhost",
+	})))
+}
+
+func TestReadUOSCertificates(t *testing.T) {
+	dir := t.TempDir()
+	defaultOrchCA, defaultBootsCA := config.OrchCACertificateFile, config.BootsCaCertificateFile
+	config.OrchCACertificateFile = filepath.Join(dir, "orch-ca.crt")
+	config.BootsCaCertificateFile = filepath.Join(dir, "boots-ca.crt")
+	defer func() {
+		config.OrchCACertificateFile, config.BootsCaCertificateFile = defaultOrchCA, defaultBootsCA
+	}()
+
+	serverCert, caBundle, err := readUOSCertificates()
+	require.NoError(t, err)
+	require.Nil(t, serverCert)
+	require.Nil(t, caBundle)
+
+	require.NoError(t, os.WriteFile(config.OrchCACertificateFile, []byte("orch CA"), writeMode))
+	require.NoError(t, os.WriteFile(config.BootsCaCertificateFile, nil, writeMode))
+	serverCert, _, err = readUOSCertificates()
+	require.NoError(t, err)
+	require.Nil(t, serverCert)
+
+	require.NoError(t, os.WriteFile(config.BootsCaCertificateFile, []byte("boots CA"), writeMode))
+	serverCert, caBundle, err = readUOSCertificates()
+	require.NoError(t, err)
+	require.Equal(t, "orch CA", string(serverCert))
+	require.Equal(t, "orch CA\nboots CA", string(caBundle))
+}
+
+// writeTarGz writes a gzipped tarball of the files, by name.
+func writeTarGz(t *testing.T, files map[string][]byte, names ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for _, name := range names {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(files[name])),
+			ModTime: time.Unix(1700000000, 0),
+		}))
+		_, err := tw.Write(files[name])
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// writeCPIOGz writes a gzipped newc cpio archive of a single file.
+func writeCPIOGz(t *testing.T, name string, data []byte) []byte {
+	t.Helper()
+	var cpio bytes.Buffer
+	for _, e := range []struct {
+		name string
+		mode int
+		data []byte
+	}{{name: name, mode: 0o100644, data: data}, {name: "TRAILER!!!"}} {
+		fmt.Fprintf(&cpio, "070701%08X%08X%08X%08X%08X%08X%08X%08X%08X%08X%08X%08X%08X",
+			1, e.mode, 0, 0, 1, 1700000000, len(e.data), 0, 0, 0, 0, len(e.name)+1, 0)
+		cpio.WriteString(e.name + "\x00")
+		cpio.Write(make([]byte, (4-cpio.Len()%4)%4))
+		cpio.Write(e.data)
+		cpio.Write(make([]byte, (4-cpio.Len()%4)%4))
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(cpio.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestPrepareUOS(t *testing.T) {
+	if _, err := exec.LookPath("xz"); err != nil {
+		t.Skip("xz is not installed")
+	}
+	dir := t.TempDir()
+	defaultScriptPath, defaultUnsignedDir := config.ScriptPath, UnsignedDir
+	config.ScriptPath, UnsignedDir = filepath.Join("..", "script"), filepath.Join(dir, "unsigned")
+	defer func() {
+		config.ScriptPath, UnsignedDir = defaultScriptPath, defaultUnsignedDir
+	}()
+	require.NoError(t, os.MkdirAll(UnsignedDir, fileMode))
+
+	units := map[string][]byte{
+		"./usr/lib/systemd/system/caddy.service":            []byte(testCaddyUnit),
+		"./usr/lib/systemd/system/device-discovery.service": []byte("[Service]\nExecStart=/usr/bin/device-discovery\n"),
+		"./usr/lib/systemd/system/fluent-bit.service":       []byte("[Unit]\nAfter=network.target\n"),
+		"./usr/lib/systemd/system/getty@.service":           []byte(testGettyUnit),
+		"./usr/lib/systemd/system/tink-worker.service":      []byte(testTinkWorkerUnit),
+	}
+	rootfs := writeTarGz(t, units, "./usr/lib/systemd/system/caddy.service",
+		"./usr/lib/systemd/system/device-discovery.service", "./usr/lib/systemd/system/fluent-bit.service",
+		"./usr/lib/systemd/system/getty@.service", "./usr/lib/systemd/system/tink-worker.service")
+	uos := writeTarGz(t, map[string][]byte{
+		"boot/vmlinuz-6.6.0":   []byte("kernel"),
+		"boot/initramfs-6.6.0": writeCPIOGz(t, uOSRootfs, rootfs),
+	}, "boot/vmlinuz-6.6.0", "boot/initramfs-6.6.0")
+	uosFile := filepath.Join(dir, "emb_uos_x86_64.tar.gz")
+	require.NoError(t, os.WriteFile(uosFile, uos, writeMode))
+
+	overlay, err := uOSOverlay(config.InfraConfig{CDN: "release.example.com"}, []byte("orch CA"), []byte("CA bundle"))
+	require.NoError(t, err)
+	require.NoError(t, prepareUOS(context.Background(), uosFile, config.ArchX86_64, overlay))
+
+	kernel, err := os.ReadFile(filepath.Join(UnsignedDir, "vmlinuz-x86_64"))
+	require.NoError(t, err)
+	require.Equal(t, "kernel", string(kernel))
+	initrd, err := exec.CommandContext(context.Background(), "xz", "-dc",
+		filepath.Join(UnsignedDir, "initramfs-x86_64")).Output()
+	require.NoError(t, err)
+
+	// The content of the rewritten rootfs is read through edits leaving it unchanged.
+	read := map[string]string{}
+	readFile := func(name string) initramfs.EditFunc {
+		return func(data []byte) ([]byte, error) {
+			read[name] = string(data)
+			return data, nil
+		}
+	}
+	edits := map[string]initramfs.EditFunc{}
+	for _, name := range []string{
+		uOSEnvConfig, "etc/hook/env_config", "etc/pki/ca-trust/source/anchors/ca.pem", "etc/idp/server_cert.pem",
+		"etc/caddy/caddy_run.sh", "usr/lib/systemd/system/tink-worker.service", uOSGettyUnit,
+	} {
+		edits[name] = readFile(name)
+	}
+	err = initramfs.Rewrite(bytes.NewReader(initrd), &bytes.Buffer{}, "", &initramfs.Overlay{
+		Archives: map[string]*initramfs.Overlay{uOSRootfs: {Edits: edits}},
+	})
+	require.NoError(t, err)
+
+	env := "release_svc=release.example.com\noci_release_svc=\ntink_stack_svc=\ntink_server_svc=\n" +
+		"onboarding_manager_svc=\nonboarding_stream_svc=\nOBM_PORT=443\n"
+	require.Equal(t, env, read[uOSEnvConfig])
+	require.Equal(t, env, read["etc/hook/env_config"])
+	require.Equal(t, "CA bundle", read["etc/pki/ca-trust/source/anchors/ca.pem"])
+	require.Equal(t, "orch CA", read["etc/idp/server_cert.pem"])
+	caddyRun, err := os.ReadFile(filepath.Join("..", "script", uOSOverlayDir, "caddy", "caddy_run.sh"))
+	require.NoError(t, err)
+	require.Equal(t, string(caddyRun), read["etc/caddy/caddy_run.sh"])
+	require.Equal(t, `[Unit]
+Description=Tink worker
+After=network-online.target caddy.service
+Requires=caddy.service
+
+[Service]
+ExecStartPre=-/etc/kpi-instrumentation/report_boot_statistics.sh
+ExecStart=/usr/bin/tink-worker
+`, read["usr/lib/systemd/system/tink-worker.service"])
+	require.Contains(t, read[uOSGettyUnit], "ExecStart=-/usr/sbin/agetty --autologin root --noclear %I\n")
+
+	// The initramfs only depends on the uOS and the configuration.
+	require.NoError(t, prepareUOS(context.Background(), uosFile, config.ArchX86_64, overlay))
+	again, err := exec.CommandContext(context.Background(), "xz", "-dc",
+		filepath.Join(UnsignedDir, "initramfs-x86_64")).Output()
+	require.NoError(t, err)
+	require.Equal(t, initrd, again)
+
+	// A uOS without initramfs is rejected.
+	require.NoError(t, os.WriteFile(uosFile, writeTarGz(t, map[string][]byte{"vmlinuz": []byte("kernel")}, "vmlinuz"),
+		writeMode))
+	require.Error(t, prepareUOS(context.Background(), uosFile, config.ArchX86_64, overlay))
+}
@@ -27,6 +27,8 @@ const (
 	EmptyTestManifestTag = "empty"
 	// TestMicroOSfileName is the filename for test micro OS files.
 	TestMicroOSfileName = "test-uos-file"
+	// TestMicroOSArm64fileName is the filename for test arm64 micro OS files.
+	TestMicroOSArm64fileName = "test-uos-file-aarch64"
 )
 
 func exampleManifest(digest string, fileLen int) string {
@@ -109,6 +111,12 @@ packages:
 			// return test data
 			_, _ = w.Write([]byte("testdata"))
 		})
+	mux.HandleFunc("/"+TestMicroOSArm64fileName,
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			// return test data
+			_, _ = w.Write([]byte("testdata-aarch64"))
+		})
 
 	svr := httptest.NewServer(mux)
 	config.SetInfraConfig(config.InfraConfig{
@@ -116,6 +124,7 @@ packages:
 		ENAgentManifestTag: CorrectTestManifestTag,
 		CDN:                svr.URL,
 		EMBImageURL:        TestMicroOSfileName,
+		EMBImageURLs:       map[string]string{config.ArchAarch64: TestMicroOSArm64fileName},
 	})
 
 	testRegistryEndpoint, _ := strings.CutPrefix(svr.URL, "http://")
@@ -23,7 +23,8 @@ no_proxy=${no_proxy:-}
 CGO_ENABLED=0
 export CGO_ENABLED
 export GOOS=linux
-export GOARCH=amd64
+# set GOARCH=arm64 to build for arm64 edge nodes
+export GOARCH=${GOARCH:-amd64}
 
 go build -v -o app
 
@@ -215,12 +215,42 @@ func deviceDiscovery(debug bool, timeout time.Duration, obsSVC string, obmSVC st
 	}
 }
 
+// statusConsolePath returns the console the status screen is rendered on.
+func statusConsolePath() string {
+	if path := os.Getenv("STATUS_CONSOLE"); path != "" {
+		return path
+	}
+	return defaultStatusConsole
+}
+
 func grpcClient(ctx context.Context, obsSVC string, obmSVC string, obmPort int, keycloakURL string, macAddr string, uuid string, serialNumber string, ipAddress string, caCertPath string) {
 	// grpc streaming starts here
 	// time.Sleep(time.Second * 20)
-	clientID, clientSecret, err, fallback := grpcStreamClient(ctx, obsSVC, obmPort, macAddr, uuid, serialNumber, ipAddress, caCertPath)
+	screen := openStatusScreen(statusConsolePath(), nodeStatus{
+		uuid:   uuid,
+		serial: serialNumber,
+		mac:    macAddr,
+		ip:     ipAddress,
+		state:  "Onboarding",
+	})
+	if screen != nil {
+		statusCtx, stopStatus := context.WithCancel(ctx)
+		defer stopStatus()
+		go watchOnboardingStatus(statusCtx, obsSVC, obmPort, caCertPath, screen, &pb_om.GetOnboardingStatusRequest{
+			Uuid:      uuid,
+			Serialnum: serialNumber,
+			MacId:     macAddr,
+		})
+	}
+
+	tpmID := openTPMIdentity()
+	if tpmID != nil {
+		defer tpmID.close()
+	}
+	clientID, clientSecret, err, fallback := grpcStreamClient(ctx, obsSVC, obmPort, macAddr, uuid, serialNumber, ipAddress, caCertPath, tpmID)
 	if fallback {
 		fmt.Printf("Executing fallback method because of error: %s\n", err)
+		screen.setState("Interactive onboarding")
 		// Interactive client Auth starts here
 		cmd := exec.CommandContext(ctx, "/bin/sh", "client-auth.sh")
 		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
@@ -257,6 +287,7 @@ func grpcClient(ctx context.Context, obsSVC string, obmSVC string, obmPort int,
 			err := grpcInfraOnboardNodeJWT(ctx, obmSVC, obmPort, macAddr, ipAddress, uuid, serialNumber, caCertPath, accessTokenFile)
 			if err == nil {
 				fmt.Println("Device discovery done")
+				screen.setState("Registered")
 				return
 			}
 
@@ -268,11 +299,14 @@ func grpcClient(ctx context.Context, obsSVC string, obmSVC string, obmPort int,
 			}
 		}
 		// If we exhausted the retries
+		screen.setError(fmt.Errorf("could not complete device discovery after %d attempts", maxRetries))
 		log.Fatalf("Max retries reached. Could not complete device discovery.")
 	} else {
 		if err != nil {
+			screen.setError(err)
 			log.Fatalf("Error Case: %v", err)
 		}
+		screen.setState("Onboarded")
 		if err := saveToFile(clientIDPath, clientID); err != nil {
 			log.Fatalf("error writing clientID: %v", err)
 		}
@@ -311,6 +345,7 @@ func main() {
 
 	optionalVars := []string{
 		"EXTRA_HOSTS",
+		"STATUS_CONSOLE",
 	}
 
 	// Load environment variables from env_config
@@ -381,5 +416,15 @@ func main() {
 	}
 	// logic to detect serial, uuid, and ip based on mac ends here
 
+	if len(os.Args) > 1 && os.Args[1] == statusCommand {
+		screen := openStatusScreen(statusConsolePath(), nodeStatus{uuid: uuid, serial: serialNumber, mac: macAddr, ip: ipAddress})
+		if screen == nil {
+			os.Exit(1)
+		}
+		watchOnboardingStatus(context.Background(), envVars["onboarding_stream_svc"], obmPort, caCertPath, screen,
+			&pb_om.GetOnboardingStatusRequest{Uuid: uuid, Serialnum: serialNumber, MacId: macAddr})
+		return
+	}
+
 	deviceDiscovery(debug, timeout, envVars["onboarding_stream_svc"], envVars["onboarding_manager_svc"], obmPort, envVars["KEYCLOAK_URL"], macAddr, uuid, serialNumber, ipAddress, caCertPath)
 }
@@ -3,21 +3,26 @@
 
 module device-discovery
 
-// remains with Go 1.24.1 till EMT Go is updated to support Go 1.24.4
-go 1.24.1
+// follows the Go version of the in-tree onboarding-manager API, see replace below
+go 1.26.3
 
 require (
+	github.com/google/go-tpm v0.9.8
 	github.com/open-edge-platform/infra-onboarding/onboarding-manager v1.33.0
-	golang.org/x/oauth2 v0.34.0
-	google.golang.org/grpc v1.79.3
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
+	golang.org/x/oauth2 v0.35.0
+	google.golang.org/grpc v1.80.0
 )
 
 require (
 	cloud.google.com/go/compute/metadata v0.9.0 // indirect
 	github.com/envoyproxy/protoc-gen-validate v1.3.0 // indirect
-	golang.org/x/net v0.48.0 // indirect
-	golang.org/x/sys v0.39.0 // indirect
-	golang.org/x/text v0.32.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20251202230838-ff82c1b0f217 // indirect
-	google.golang.org/protobuf v1.36.10 // indirect
+	github.com/google/go-tpm-tools v0.3.13-0.20230620182252-4639ecce2aba // indirect
+	golang.org/x/net v0.52.0 // indirect
+	golang.org/x/sys v0.42.0 // indirect
+	golang.org/x/text v0.35.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260401024825-9d38bb4040a9 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
 )
+
+replace github.com/open-edge-platform/infra-onboarding/onboarding-manager => ../../onboarding-manager
@@ -20,6 +20,9 @@ import (
 	"google.golang.org/grpc/credentials"
 )
 
+// defaultKeepaliveTimeout applies when the server does not announce its keepalive interval
+const defaultKeepaliveTimeout = 90 * time.Second
+
 func createSecureConnection(ctx context.Context, target string, caCertPath string) (*grpc.ClientConn, error) {
 	// Load the CA certificate
 	caCert, err := os.ReadFile(caCertPath)
@@ -50,7 +53,7 @@ func createSecureConnection(ctx context.Context, target string, caCertPath strin
 	return conn, nil
 }
 
-func grpcStreamClient(ctx context.Context, address string, port int, mac string, uuid string, serial string, ipAddress string, caCertPath string) (string, string, error, bool) {
+func grpcStreamClient(ctx context.Context, address string, port int, mac string, uuid string, serial string, ipAddress string, caCertPath string, tpmID *tpmIdentity) (string, string, error, bool) {
 	var fallback = false
 	target := fmt.Sprintf("%s:%d", address, port)
 	conn, err := createSecureConnection(ctx, target, caCertPath)
@@ -62,26 +65,44 @@ func grpcStreamClient(ctx context.Context, address string, port int, mac string,
 	cli := pb.NewNonInteractiveOnboardingServiceClient(conn)
 
 	// Establish a stream with the server
-	stream, err := cli.OnboardNodeStream(ctx)
+	streamCtx, cancelStream := context.WithCancel(ctx)
+	defer cancelStream()
+	stream, err := cli.OnboardNodeStream(streamCtx)
 	if err != nil {
 		return "", "", fmt.Errorf("could not create stream: %v", err), fallback
 	}
 	defer stream.CloseSend()
 
+	// The watchdog cancels the stream when no keepalive is received while awaiting approval
+	watchdog := time.AfterFunc(time.Hour, cancelStream)
+	watchdog.Stop()
+	defer watchdog.Stop()
+
 	// Send a request over the stream
 	request := &pb.OnboardNodeStreamRequest{
 		MacId:     mac,
 		Uuid:      uuid,
 		Serialnum: serial,
 		HostIp:    ipAddress,
+		// Let the server pick the artifacts of the architecture of the machine
+		Architecture: getArchitecture(),
+		// Ask the server to push the approval instead of polling for it
+		AwaitApproval: true,
 	}
+	if tpmID != nil {
+		request.TpmAttestation = tpmID.attestation()
+	}
+	// next is the request sent at the start of the next iteration, nil while awaiting approval
+	next := request
 
 	// Receiving response from server
 	var backoff time.Duration = 2 * time.Second
 	maxBackoff := 32 * time.Second
 	for {
-		if err := stream.Send(request); err != nil {
-			return "", "", fmt.Errorf("could not send data to server: %v", err), fallback
+		if next != nil {
+			if err := stream.Send(next); err != nil {
+				return "", "", fmt.Errorf("could not send data to server: %v", err), fallback
+			}
 		}
 		// Ensure stream is not nil
 		if stream == nil {
@@ -90,6 +111,10 @@ func grpcStreamClient(ctx context.Context, address string, port int, mac string,
 
 		// Receive response from the server
 		resp, err := stream.Recv()
+		watchdog.Stop()
+		if streamCtx.Err() != nil && ctx.Err() == nil {
+			return "", "", fmt.Errorf("no keepalive received from server while awaiting approval"), fallback
+		}
 		if err == io.EOF {
 			return "", "", fmt.Errorf("stream closed by server"), fallback
 		}
@@ -106,7 +131,17 @@ func grpcStreamClient(ctx context.Context, address string, port int, mac string,
 		if resp.Status.Code == int32(codes.OK) {
 			switch resp.NodeState {
 			case pb.OnboardNodeStreamResponse_NODE_STATE_REGISTERED:
+				if resp.AwaitingApproval {
+					// The server pushes the approval on the stream, only keepalives are received meanwhile
+					if next != nil {
+						fmt.Println("Edge node registered. Awaiting approval for onboarding...")
+					}
+					next = nil
+					watchdog.Reset(keepaliveTimeout(resp.KeepaliveIntervalSeconds))
+					continue
+				}
 				fmt.Println("Edge node registered. Waiting for the edge node to become ready for onboarding...")
+				next = request
 
 				// Sleep for a randomized backoff duration
 				time.Sleep(backoff + time.Duration(rand.Intn(1000))*time.Millisecond)
@@ -117,6 +152,24 @@ func grpcStreamClient(ctx context.Context, address string, port int, mac string,
 					backoff = 2 * time.Second
 				}
 
+			case pb.OnboardNodeStreamResponse_NODE_STATE_ATTESTATION_CHALLENGE:
+				if tpmID == nil {
+					return "", "", fmt.Errorf("received TPM challenge but no TPM is available"), fallback
+				}
+				fmt.Println("Answering TPM credential activation challenge...")
+				secret, err := tpmID.activateCredential(resp.TpmChallenge)
+				if err != nil {
+					return "", "", err, fallback
+				}
+				next = &pb.OnboardNodeStreamRequest{
+					MacId:          mac,
+					Uuid:           uuid,
+					Serialnum:      serial,
+					HostIp:         ipAddress,
+					Architecture:   getArchitecture(),
+					TpmAttestation: &pb.TpmAttestation{ActivatedSecret: secret},
+				}
+
 			case pb.OnboardNodeStreamResponse_NODE_STATE_ONBOARDED:
 				clientID := resp.ClientId
 				clientSecret := resp.ClientSecret
@@ -146,10 +199,18 @@ func grpcStreamClient(ctx context.Context, address string, port int, mac string,
 			}
 		} else if resp.Status.Code == int32(codes.NotFound) {
 			fallback = true
-			return "", "", fmt.Errorf(resp.Status.Message), fallback
+			return "", "", fmt.Errorf("%s", resp.Status.Message), fallback
 		} else {
-			return "", "", fmt.Errorf(resp.Status.Message), fallback
+			return "", "", fmt.Errorf("%s", resp.Status.Message), fallback
 		}
 	}
 
 }
+
+// keepaliveTimeout returns how long to wait for a keepalive from the server before giving up on the stream.
+func keepaliveTimeout(intervalSeconds uint32) time.Duration {
+	if intervalSeconds == 0 {
+		return defaultKeepaliveTimeout
+	}
+	return 3 * time.Duration(intervalSeconds) * time.Second
+}
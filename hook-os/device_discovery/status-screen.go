@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/api/onboardingmgr/v1"
+	qrcode "github.com/skip2/go-qrcode"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultStatusConsole is free while device discovery runs, the getty is started afterwards
+	defaultStatusConsole = "/dev/tty1"
+	statusPollInterval   = 10 * time.Second
+	// statusCommand only displays the status, e.g. from a console service running during provisioning
+	statusCommand = "status"
+)
+
+// nodeStatus is the onboarding status of the Edge Node shown on the console.
+type nodeStatus struct {
+	uuid      string
+	serial    string
+	mac       string
+	ip        string
+	state     string
+	action    string
+	lastError string
+	updated   time.Time
+}
+
+// statusScreen renders the onboarding status of the Edge Node on the console, so that technicians at the rack
+// can tell an Edge Node awaiting approval from a stuck one. A nil statusScreen discards updates.
+type statusScreen struct {
+	mu     sync.Mutex
+	out    io.Writer
+	status nodeStatus
+}
+
+// openStatusScreen opens the console at path and renders the initial status on it.
+// It returns nil if the console cannot be opened.
+func openStatusScreen(path string, initial nodeStatus) *statusScreen {
+	console, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		fmt.Printf("Status screen disabled, failed to open console %s: %v\n", path, err)
+		return nil
+	}
+	s := &statusScreen{out: console}
+	s.update(func(st *nodeStatus) { *st = initial })
+	return s
+}
+
+// update applies fn to the status and renders it.
+func (s *statusScreen) update(fn func(*nodeStatus)) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.status)
+	s.status.updated = time.Now().UTC()
+	if err := renderStatus(s.out, s.status); err != nil {
+		fmt.Printf("Failed to render status screen: %v\n", err)
+	}
+}
+
+// setState sets the state and clears the last error.
+func (s *statusScreen) setState(state string) {
+	s.update(func(st *nodeStatus) {
+		st.state = state
+		st.lastError = ""
+	})
+}
+
+// setError records err as the last error.
+func (s *statusScreen) setError(err error) {
+	s.update(func(st *nodeStatus) { st.lastError = err.Error() })
+}
+
+// renderStatus clears the screen and writes the status followed by a QR code of the identifiers.
+func renderStatus(w io.Writer, st nodeStatus) error {
+	var b strings.Builder
+	// move the cursor home and clear the screen
+	b.WriteString("\033[H\033[2J")
+	b.WriteString("Edge Node onboarding status\n")
+	b.WriteString("===========================\n\n")
+	for _, field := range [][2]string{
+		{"UUID", st.uuid},
+		{"Serial number", st.serial},
+		{"MAC address", st.mac},
+		{"IP address", st.ip},
+		{"State", st.state},
+		{"Current action", st.action},
+		{"Last error", st.lastError},
+		{"Updated", st.updated.Format(time.DateTime + " MST")},
+	} {
+		value := field[1]
+		if value == "" {
+			value = "-"
+		}
+		fmt.Fprintf(&b, "%-16s%s\n", field[0]+":", value)
+	}
+
+	qr, err := qrcode.New(st.qrContent(), qrcode.Medium)
+	if err != nil {
+		return err
+	}
+	b.WriteString("\n")
+	b.WriteString(qr.ToSmallString(false))
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// qrContent returns the identifiers of the Edge Node encoded in the QR code.
+func (st nodeStatus) qrContent() string {
+	return fmt.Sprintf("UUID:%s\nSN:%s\nMAC:%s\nIP:%s", st.uuid, st.serial, st.mac, st.ip)
+}
+
+// describeState returns a short description of the state of the Edge Node reported by the onboarding manager.
+func describeState(resp *pb.GetOnboardingStatusResponse) string {
+	if resp.AwaitingApproval {
+		return "Registered, awaiting approval for onboarding"
+	}
+	state := strings.TrimPrefix(resp.CurrentState, "HOST_STATE_")
+	if resp.OnboardingStatus != "" {
+		state += " (" + resp.OnboardingStatus + ")"
+	}
+	if resp.ProvisioningStatus != "" {
+		state += ", " + resp.ProvisioningStatus
+	}
+	return state
+}
+
+// watchOnboardingStatus periodically queries the onboarding status of the Edge Node and renders it on screen,
+// until ctx is done.
+func watchOnboardingStatus(ctx context.Context, address string, port int, caCertPath string, screen *statusScreen,
+	req *pb.GetOnboardingStatusRequest) {
+	target := fmt.Sprintf("%s:%d", address, port)
+	conn, err := createSecureConnection(ctx, target, caCertPath)
+	if err != nil {
+		screen.setError(fmt.Errorf("status unavailable: %v", err))
+		return
+	}
+	defer conn.Close()
+	cli := pb.NewNonInteractiveOnboardingServiceClient(conn)
+
+	ticker := time.NewTicker(statusPollInterval)
+	defer ticker.Stop()
+	for {
+		resp, err := cli.GetOnboardingStatus(ctx, req)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			screen.update(func(st *nodeStatus) {
+				st.lastError = "status unavailable: " + status.Convert(err).Message()
+			})
+		default:
+			screen.update(func(st *nodeStatus) {
+				st.state = describeState(resp)
+				st.action = resp.CurrentAction
+				st.lastError = resp.LastError
+			})
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
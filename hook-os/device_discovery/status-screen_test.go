@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	pb "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/api/onboardingmgr/v1"
+)
+
+func TestRenderStatus(t *testing.T) {
+	var b strings.Builder
+	err := renderStatus(&b, nodeStatus{
+		uuid:   "44414747-3031-3052-b030-453347474122",
+		serial: "ABCDEFG",
+		state:  "Registered, awaiting approval for onboarding",
+	})
+	if err != nil {
+		t.Fatalf("renderStatus() error = %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"UUID:           44414747-3031-3052-b030-453347474122",
+		"Serial number:  ABCDEFG",
+		"State:          Registered, awaiting approval for onboarding",
+		"Last error:     -",
+		"█",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("renderStatus() output does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDescribeState(t *testing.T) {
+	tests := []struct {
+		resp *pb.GetOnboardingStatusResponse
+		want string
+	}{
+		{
+			resp: &pb.GetOnboardingStatusResponse{CurrentState: "HOST_STATE_REGISTERED", AwaitingApproval: true},
+			want: "Registered, awaiting approval for onboarding",
+		},
+		{
+			resp: &pb.GetOnboardingStatusResponse{
+				CurrentState:       "HOST_STATE_ONBOARDED",
+				OnboardingStatus:   "Onboarded",
+				ProvisioningStatus: "Provisioning In Progress",
+			},
+			want: "ONBOARDED (Onboarded), Provisioning In Progress",
+		},
+	}
+	for _, tt := range tests {
+		if got := describeState(tt.resp); got != tt.want {
+			t.Errorf("describeState() = %q, want %q", got, tt.want)
+		}
+	}
+}
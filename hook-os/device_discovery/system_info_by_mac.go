@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net"
 	"os/exec"
+	"runtime"
 	"strings"
 )
 
@@ -35,6 +36,24 @@ func getUUID() (string, error) {
 	return strings.TrimSpace(out.String()), nil
 }
 
+// getArchitecture returns the CPU architecture of the machine as named by its kernel (uname -m), which is the
+// architecture the binary is built for.
+func getArchitecture() string {
+	return architectureName(runtime.GOARCH)
+}
+
+// architectureName returns the kernel name of the Go architecture.
+func architectureName(goarch string) string {
+	switch goarch {
+	case "amd64":
+		return "x86_64"
+	case "arm64":
+		return "aarch64"
+	default:
+		return goarch
+	}
+}
+
 // getIPAddress retrieves the IP address associated with a given MAC address.
 func getIPAddress(macAddr string) (string, error) {
 	interfaces, err := net.Interfaces()
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import "testing"
+
+func TestArchitectureName(t *testing.T) {
+	for goarch, want := range map[string]string{
+		"amd64":   "x86_64",
+		"arm64":   "aarch64",
+		"riscv64": "riscv64",
+	} {
+		if got := architectureName(goarch); got != want {
+			t.Errorf("architectureName(%q) = %q, want %q", goarch, got, want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"crypto/x509"
+	"fmt"
+	"io"
+	"os"
+
+	pb "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/api/onboardingmgr/v1"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpm2/transport"
+	"github.com/google/go-tpm/tpm2/transport/linuxtpm"
+)
+
+const (
+	tpmDevicePath = "/dev/tpmrm0"
+	// NV index of the RSA 2048 EK certificate, TCG EK Credential Profile section 2.2.1.4.
+	ekCertNVIndexRSA = 0x01c00002
+	// maximum number of bytes read from an NV index in a single command.
+	nvReadChunkSize = 768
+)
+
+// akTemplate is the restricted signing key the credential activation challenge is bound to.
+var akTemplate = tpm2.TPMTPublic{
+	Type:    tpm2.TPMAlgECC,
+	NameAlg: tpm2.TPMAlgSHA256,
+	ObjectAttributes: tpm2.TPMAObject{
+		FixedTPM:            true,
+		FixedParent:         true,
+		SensitiveDataOrigin: true,
+		UserWithAuth:        true,
+		Restricted:          true,
+		SignEncrypt:         true,
+	},
+	Parameters: tpm2.NewTPMUPublicParms(tpm2.TPMAlgECC, &tpm2.TPMSECCParms{
+		Scheme: tpm2.TPMTECCScheme{
+			Scheme: tpm2.TPMAlgECDSA,
+			Details: tpm2.NewTPMUAsymScheme(tpm2.TPMAlgECDSA,
+				&tpm2.TPMSSigSchemeECDSA{HashAlg: tpm2.TPMAlgSHA256}),
+		},
+		CurveID: tpm2.TPMECCNistP256,
+	}),
+}
+
+// tpmIdentity holds the TPM keys used to prove the device identity to the onboarding manager.
+type tpmIdentity struct {
+	tpm    transport.TPM
+	ek     *tpm2.CreatePrimaryResponse
+	ak     *tpm2.CreatePrimaryResponse
+	ekCert []byte
+	ekPub  []byte
+	closer io.Closer
+}
+
+// openDeviceTPM opens the TPM resource manager of the device, if present.
+func openDeviceTPM() (transport.TPMCloser, error) {
+	if _, err := os.Stat(tpmDevicePath); err != nil {
+		return nil, fmt.Errorf("no TPM found at %s: %v", tpmDevicePath, err)
+	}
+	return linuxtpm.Open(tpmDevicePath)
+}
+
+// openTPMIdentity returns the TPM identity of the device, or nil if the device has no usable TPM,
+// in which case onboarding proceeds without TPM evidence.
+func openTPMIdentity() *tpmIdentity {
+	tpm, err := openDeviceTPM()
+	if err != nil {
+		fmt.Printf("Onboarding without TPM attestation: %v\n", err)
+		return nil
+	}
+	id, err := newTPMIdentity(tpm)
+	if err != nil {
+		fmt.Printf("Onboarding without TPM attestation: %v\n", err)
+		tpm.Close()
+		return nil
+	}
+	id.closer = tpm
+	return id
+}
+
+// newTPMIdentity creates the EK and the AK in the TPM and reads the EK certificate, if provisioned.
+func newTPMIdentity(tpm transport.TPM) (*tpmIdentity, error) {
+	ek, err := tpm2.CreatePrimary{
+		PrimaryHandle: tpm2.TPMRHEndorsement,
+		InPublic:      tpm2.New2B(tpm2.RSAEKTemplate),
+	}.Execute(tpm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create EK: %v", err)
+	}
+	id := &tpmIdentity{tpm: tpm, ek: ek}
+
+	ak, err := tpm2.CreatePrimary{
+		PrimaryHandle: tpm2.TPMRHEndorsement,
+		InPublic:      tpm2.New2B(akTemplate),
+	}.Execute(tpm)
+	if err != nil {
+		id.close()
+		return nil, fmt.Errorf("failed to create AK: %v", err)
+	}
+	id.ak = ak
+
+	ekPublic, err := ek.OutPublic.Contents()
+	if err != nil {
+		id.close()
+		return nil, fmt.Errorf("failed to read EK public area: %v", err)
+	}
+	ekKey, err := tpm2.Pub(*ekPublic)
+	if err != nil {
+		id.close()
+		return nil, fmt.Errorf("failed to decode EK public key: %v", err)
+	}
+	if id.ekPub, err = x509.MarshalPKIXPublicKey(ekKey); err != nil {
+		id.close()
+		return nil, fmt.Errorf("failed to encode EK public key: %v", err)
+	}
+
+	// the EK certificate is optional, hosts without one must have their EK pinned at registration
+	if id.ekCert, err = readNV(tpm, ekCertNVIndexRSA); err != nil {
+		fmt.Printf("No EK certificate found in the TPM, sending EK public key only: %v\n", err)
+	}
+	return id, nil
+}
+
+// attestation returns the TPM evidence sent with the onboarding request.
+func (id *tpmIdentity) attestation() *pb.TpmAttestation {
+	return &pb.TpmAttestation{
+		EkCert: id.ekCert,
+		EkPub:  id.ekPub,
+		AkName: id.ak.Name.Buffer,
+	}
+}
+
+// activateCredential answers a credential activation challenge with the EK and AK of the TPM.
+func (id *tpmIdentity) activateCredential(challenge *pb.TpmChallenge) ([]byte, error) {
+	if challenge == nil {
+		return nil, fmt.Errorf("received empty TPM challenge")
+	}
+	rsp, err := tpm2.ActivateCredential{
+		ActivateHandle: tpm2.NamedHandle{Handle: id.ak.ObjectHandle, Name: id.ak.Name},
+		KeyHandle: tpm2.AuthHandle{
+			Handle: id.ek.ObjectHandle,
+			Name:   id.ek.Name,
+			// the EK template requires PolicySecret on the endorsement hierarchy
+			Auth: tpm2.Policy(tpm2.TPMAlgSHA256, 16, func(tpm transport.TPM, handle tpm2.TPMISHPolicy, _ tpm2.TPM2BNonce) error {
+				_, err := tpm2.PolicySecret{
+					AuthHandle:    tpm2.TPMRHEndorsement,
+					PolicySession: handle,
+				}.Execute(tpm)
+				return err
+			}),
+		},
+		CredentialBlob: tpm2.TPM2BIDObject{Buffer: challenge.CredentialBlob},
+		Secret:         tpm2.TPM2BEncryptedSecret{Buffer: challenge.EncryptedSecret},
+	}.Execute(id.tpm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to activate credential: %v", err)
+	}
+	return rsp.CertInfo.Buffer, nil
+}
+
+// close flushes the transient keys from the TPM and closes it if it was opened by openTPMIdentity.
+func (id *tpmIdentity) close() {
+	for _, key := range []*tpm2.CreatePrimaryResponse{id.ak, id.ek} {
+		if key == nil {
+			continue
+		}
+		if _, err := (tpm2.FlushContext{FlushHandle: key.ObjectHandle}).Execute(id.tpm); err != nil {
+			fmt.Printf("Failed to flush TPM key: %v\n", err)
+		}
+	}
+	if id.closer != nil {
+		id.closer.Close()
+	}
+}
+
+// readNV reads the full content of an NV index.
+func readNV(tpm transport.TPM, index tpm2.TPMHandle) ([]byte, error) {
+	pub, err := tpm2.NVReadPublic{NVIndex: index}.Execute(tpm)
+	if err != nil {
+		return nil, err
+	}
+	nvPublic, err := pub.NVPublic.Contents()
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, 0, nvPublic.DataSize)
+	for offset := uint16(0); offset < nvPublic.DataSize; {
+		size := min(nvPublic.DataSize-offset, nvReadChunkSize)
+		rsp, err := tpm2.NVRead{
+			AuthHandle: tpm2.AuthHandle{Handle: index, Name: pub.NVName, Auth: tpm2.PasswordAuth(nil)},
+			NVIndex:    tpm2.NamedHandle{Handle: index, Name: pub.NVName},
+			Size:       size,
+			Offset:     offset,
+		}.Execute(tpm)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, rsp.Data.Buffer...)
+		offset += size
+	}
+	return data, nil
+}
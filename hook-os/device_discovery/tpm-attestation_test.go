@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/binary"
+	"testing"
+
+	pb "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/api/onboardingmgr/v1"
+
+	legacy "github.com/google/go-tpm/legacy/tpm2"
+	"github.com/google/go-tpm/legacy/tpm2/credactivation"
+	"github.com/google/go-tpm/tpm2/transport/simulator"
+)
+
+func TestTPMIdentityActivateCredential(t *testing.T) {
+	tpm, err := simulator.OpenSimulator()
+	if err != nil {
+		t.Fatalf("failed to open TPM simulator: %v", err)
+	}
+	defer tpm.Close()
+
+	id, err := newTPMIdentity(tpm)
+	if err != nil {
+		t.Fatalf("newTPMIdentity() error = %v", err)
+	}
+	defer id.close()
+
+	evidence := id.attestation()
+	if len(evidence.EkCert) != 0 {
+		t.Errorf("expected no EK certificate in the simulator, got %d bytes", len(evidence.EkCert))
+	}
+	ekPub, err := x509.ParsePKIXPublicKey(evidence.EkPub)
+	if err != nil {
+		t.Fatalf("failed to parse EK public key: %v", err)
+	}
+
+	// build the challenge the same way the onboarding manager does
+	name := &legacy.HashValue{
+		Alg:   legacy.Algorithm(binary.BigEndian.Uint16(evidence.AkName[:2])),
+		Value: evidence.AkName[2:],
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		t.Fatal(err)
+	}
+	idObject, encSecret, err := credactivation.Generate(name, ekPub, 16, secret)
+	if err != nil {
+		t.Fatalf("failed to generate challenge: %v", err)
+	}
+
+	activated, err := id.activateCredential(&pb.TpmChallenge{
+		CredentialBlob:  idObject[2:],
+		EncryptedSecret: encSecret[2:],
+	})
+	if err != nil {
+		t.Fatalf("activateCredential() error = %v", err)
+	}
+	if !bytes.Equal(activated, secret) {
+		t.Errorf("activateCredential() = %x, want %x", activated, secret)
+	}
+
+	if _, err := id.activateCredential(nil); err == nil {
+		t.Error("activateCredential(nil) expected error")
+	}
+}
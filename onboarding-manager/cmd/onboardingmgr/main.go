@@ -24,13 +24,25 @@ import (
 	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/secretprovider"
 	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/tracing"
 	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/config"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/attestation"
 	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/env"
 	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/handlers/controller"
 	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/handlers/southbound"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/handlers/southbound/grpcserver"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/handlers/southbound/nioguard"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/hwprofile"
 	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/invclient"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/maintenance"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/redfish"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/sanitization"
 )
 
-const envNameOnboardingCredentialsSecretName = "ONBOARDING_CREDENTIALS_SECRET_NAME"
+const (
+	envNameOnboardingCredentialsSecretName = "ONBOARDING_CREDENTIALS_SECRET_NAME"
+	// envNameRedfishCredentialsSecretName names the secret holding the username and password of the BMCs.
+	envNameRedfishCredentialsSecretName = "REDFISH_CREDENTIALS_SECRET_NAME"
+)
 
 const (
 	ServerAddressNio = "serverAddressNio"
@@ -50,6 +62,63 @@ var (
 	traceURL         = flag.String(tracing.TraceURL, "", tracing.TraceURLDescription)
 	enableAuth       = flag.Bool(rbac.EnableAuth, true, rbac.EnableAuthDescription)
 	rbacRules        = flag.String(rbac.RbacRules, "/rego/authz.rego", rbac.RbacRulesDescription)
+
+	nioTLSCertPath     = flag.String("nioTlsCertPath", "", "TLS certificate of the grpc server for nio, TLS is disabled if unset")
+	nioTLSKeyPath      = flag.String("nioTlsKeyPath", "", "TLS private key of the grpc server for nio")
+	nioTLSClientCAPath = flag.String("nioTlsClientCaPath", "", "CA bundle used to verify nio client certificates")
+	nioTLSClientAuth   = flag.String("nioTlsClientAuth", nioguard.ClientAuthNone,
+		"nio client certificate mode: none, optional or require")
+	nioRatePerSource = flag.Float64("nioRatePerSource", nioguard.DefaultRatePerSource,
+		"nio requests per second allowed from a single source IP, 0 disables the limit")
+	nioBurstPerSource = flag.Int("nioBurstPerSource", nioguard.DefaultBurstPerSource,
+		"nio request burst allowed from a single source IP")
+	nioRatePerIdentity = flag.Float64("nioRatePerIdentity", nioguard.DefaultRatePerIdentity,
+		"nio requests per second allowed for a single UUID/serial number, 0 disables the limit")
+	nioBurstPerIdentity = flag.Int("nioBurstPerIdentity", nioguard.DefaultBurstPerIdentity,
+		"nio request burst allowed for a single UUID/serial number")
+	nioMaxConns = flag.Int("nioMaxConns", nioguard.DefaultMaxConns,
+		"maximum concurrent nio connections, 0 disables the limit")
+	nioMaxConnsPerSource = flag.Int("nioMaxConnsPerSource", nioguard.DefaultMaxConnsPerSource,
+		"maximum concurrent nio connections from a single source IP, 0 disables the limit")
+	nioMaxStreamsPerSource = flag.Int("nioMaxStreamsPerSource", nioguard.DefaultMaxStreamsPerSrc,
+		"maximum concurrent nio streams from a single source IP, 0 disables the limit")
+	nioLockoutThreshold = flag.Int("nioLockoutThreshold", nioguard.DefaultLockoutThreshold,
+		"failed UUID/serial number matches before a nio source is locked out, 0 disables the lockout")
+	nioLockoutBase = flag.Duration("nioLockoutBase", nioguard.DefaultLockoutBase,
+		"first nio lockout duration, doubled on every further failure")
+	nioLockoutMax = flag.Duration("nioLockoutMax", nioguard.DefaultLockoutMax, "maximum nio lockout duration")
+
+	tpmAttestationPolicy = flag.String("tpmAttestationPolicy", string(attestation.PolicyOptional),
+		"nio TPM attestation policy: disabled, optional (hosts flagged tpm-required must attest) or required")
+	tpmManufacturerCABundle = flag.String("tpmManufacturerCaBundle", "",
+		"PEM bundle of TPM manufacturer CAs used to verify EK certificates of hosts without a pinned EK")
+	nioMaxAwaitingStreams = flag.Int("nioMaxAwaitingStreams", grpcserver.DefaultMaxAwaitingStreams,
+		"maximum Edge Nodes awaiting approval on their nio stream, others poll; 0 disables server push")
+	nioKeepaliveInterval = flag.Duration("nioKeepaliveInterval", grpcserver.DefaultKeepaliveInterval,
+		"interval of keepalives sent to Edge Nodes awaiting approval on their nio stream")
+	enableRedfish = flag.Bool("enableRedfish", false,
+		"network boot hosts with a redfish-endpoint in their metadata through their BMC")
+	redfishBootTarget = flag.String("redfishBootTarget", string(redfish.BootTargetPXE),
+		"boot source of hosts network booted through Redfish: pxe or uefi-http")
+	redfishPowerOffOnFailure = flag.Bool("redfishPowerOffOnFailure", false,
+		"power off hosts with a Redfish endpoint whose provisioning failed")
+	redfishTimeout       = flag.Duration("redfishTimeout", redfish.DefaultTimeout, "timeout of the Redfish operations on a host")
+	redfishCAPath        = flag.String("redfishCaPath", "", "PEM bundle of CAs used to verify BMC certificates, system CAs if unset")
+	redfishInsecure      = flag.Bool("redfishInsecureSkipVerify", false, "do not verify BMC certificates")
+	workerSilenceTimeout = flag.Duration("workerSilenceTimeout", 0,
+		"time tink-worker may go without reporting a heartbeat before its workflow is reported unresponsive, 0 disables it")
+	failUnresponsiveWorkflows = flag.Bool("failUnresponsiveWorkflows", false,
+		"fail the workflows whose tink-worker is unresponsive rather than waiting for their timeout")
+	maxProvisioningPerSite = flag.Int("maxProvisioningPerSite", 0,
+		"maximum provisioning workflows running at once in a site, others are queued; 0 disables the limit")
+	maxProvisioningPerRegion = flag.Int("maxProvisioningPerRegion", 0,
+		"maximum provisioning workflows running at once in a region, others are queued; 0 disables the limit")
+	maxProvisioningPerTenant = flag.Int("maxProvisioningPerTenant", 0,
+		"maximum provisioning workflows running at once for a tenant, others are queued; 0 disables the limit")
+	sanitizationSigningKey = flag.String("sanitizationSigningKey", "",
+		"PEM PKCS #8 Ed25519 key signing the disk sanitization records, disk sanitization is disabled if unset")
+	sanitizeDisksOnDelete = flag.Bool("sanitizeDisksOnDelete", false,
+		"sanitize the disks of every deleted host, unless its metadata opts out; otherwise only of the hosts opting in")
 	// see also internal/common/flags.go for other flags.
 
 	wg        = sync.WaitGroup{}
@@ -98,6 +167,31 @@ func setupOamServerAndSetReady(enableTracing bool, oamServerAddress string) {
 	}
 }
 
+// setupRedfish network boots and powers off hosts with a Redfish endpoint through their BMC.
+func setupRedfish(credentialsSecretName string) {
+	bootController, err := redfish.NewBootController(redfish.Config{
+		BootTarget:         redfish.BootTarget(*redfishBootTarget),
+		PowerOffOnFailure:  *redfishPowerOffOnFailure,
+		Timeout:            *redfishTimeout,
+		CACertPath:         *redfishCAPath,
+		InsecureSkipVerify: *redfishInsecure,
+	}, func() (redfish.Credentials, error) {
+		creds := redfish.Credentials{
+			Username: secretprovider.GetSecret(credentialsSecretName, "username"),
+			Password: secretprovider.GetSecret(credentialsSecretName, "password"),
+		}
+		if creds.Username == "" || creds.Password == "" {
+			return creds, inv_errors.Errorf("BMC credentials are missing from secret %s", credentialsSecretName)
+		}
+		return creds, nil
+	})
+	if err != nil {
+		zlog.InfraSec().Fatal().Err(err).Msgf("Unable to create Redfish boot controller")
+	}
+	onboarding.NetbootTriggerFactory = func() onboarding.NetbootTrigger { return bootController }
+	zlog.InfraSec().Info().Msgf("Redfish boot orchestration enabled, hosts boot from %s", *redfishBootTarget)
+}
+
 //nolint:cyclop,funlen // it's a main, complexity is 11
 func main() {
 	// Print a summary of the build
@@ -142,10 +236,42 @@ func main() {
 		zlog.InfraSec().Fatal().Err(invErr).Msgf("")
 	}
 
-	if initErr := secretprovider.Init(context.Background(), []string{onboardingCredentialsSecretName}); initErr != nil {
+	secretNames := []string{onboardingCredentialsSecretName}
+	redfishCredentialsSecretName := os.Getenv(envNameRedfishCredentialsSecretName)
+	if *enableRedfish {
+		if redfishCredentialsSecretName == "" {
+			invErr := inv_errors.Errorf("%s env variable is required by Redfish", envNameRedfishCredentialsSecretName)
+			zlog.InfraSec().Fatal().Err(invErr).Msgf("")
+		}
+		secretNames = append(secretNames, redfishCredentialsSecretName)
+	}
+
+	if initErr := secretprovider.Init(context.Background(), secretNames); initErr != nil {
 		zlog.InfraSec().Fatal().Err(initErr).Msgf("Unable to initialize required secrets")
 	}
 
+	if *enableRedfish {
+		setupRedfish(redfishCredentialsSecretName)
+	}
+	onboarding.WorkerSilenceTimeout = *workerSilenceTimeout
+	onboarding.FailUnresponsiveWorkflows = *failUnresponsiveWorkflows
+	onboarding.Admission = onboarding.AdmissionLimits{
+		PerSite:   *maxProvisioningPerSite,
+		PerRegion: *maxProvisioningPerRegion,
+		PerTenant: *maxProvisioningPerTenant,
+	}
+	onboarding.MaintenanceWindowsFunc = maintenance.NewResolver(invClient).Windows
+	onboarding.HardwareProfileFunc = hwprofile.NewResolver(invClient).Profile
+	if *sanitizationSigningKey != "" {
+		signer, signerErr := sanitization.LoadSigner(*sanitizationSigningKey)
+		if signerErr != nil {
+			zlog.InfraSec().Fatal().Err(signerErr).Msgf("Unable to load the disk sanitization signing key")
+		}
+		onboarding.Decommission = onboarding.DecommissionConfig{SanitizeByDefault: *sanitizeDisksOnDelete, Signer: signer}
+	} else if *sanitizeDisksOnDelete {
+		zlog.InfraSec().Fatal().Msgf("sanitizeDisksOnDelete requires sanitizationSigningKey")
+	}
+
 	if authInitErr := auth.Init(); authInitErr != nil {
 		zlog.InfraSec().Fatal().Err(authInitErr).Msgf("Unable to initialize auth service")
 	}
@@ -199,6 +325,29 @@ func main() {
 		ServerAddressNio: *serverAddressNio,
 		EnableTracing:    *enableTracing,
 		InventoryAddress: *inventoryAddress,
+		TLS: nioguard.TLSConfig{
+			CertPath:     *nioTLSCertPath,
+			KeyPath:      *nioTLSKeyPath,
+			ClientCAPath: *nioTLSClientCAPath,
+			ClientAuth:   *nioTLSClientAuth,
+		},
+		Guard: nioguard.Config{
+			RatePerSource:       *nioRatePerSource,
+			BurstPerSource:      *nioBurstPerSource,
+			RatePerIdentity:     *nioRatePerIdentity,
+			BurstPerIdentity:    *nioBurstPerIdentity,
+			MaxConns:            *nioMaxConns,
+			MaxConnsPerSource:   *nioMaxConnsPerSource,
+			MaxStreamsPerSource: *nioMaxStreamsPerSource,
+			LockoutThreshold:    *nioLockoutThreshold,
+			LockoutBase:         *nioLockoutBase,
+			LockoutMax:          *nioLockoutMax,
+			EntryTTL:            nioguard.DefaultEntryTTL,
+		},
+		TPMAttestationPolicy:    attestation.Policy(*tpmAttestationPolicy),
+		TPMManufacturerCABundle: *tpmManufacturerCABundle,
+		MaxAwaitingStreams:      *nioMaxAwaitingStreams,
+		KeepaliveInterval:       *nioKeepaliveInterval,
 	})
 	if err != nil {
 		zlog.InfraSec().Fatal().Err(err).Msgf("Unable to create southbound handler")
@@ -0,0 +1,280 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package attestation provides TPM endorsement key (EK) verification and credential activation
+// challenges used to bind non-interactive onboarding to the device TPM.
+package attestation
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"strings"
+
+	"github.com/google/go-tpm/legacy/tpm2"
+	"github.com/google/go-tpm/legacy/tpm2/credactivation"
+	"google.golang.org/grpc/codes"
+
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/util"
+)
+
+// Policy defines whether TPM attestation is enforced during non-interactive onboarding.
+type Policy string
+
+const (
+	// PolicyDisabled skips TPM attestation, even if the device presents TPM evidence.
+	PolicyDisabled Policy = "disabled"
+	// PolicyOptional verifies TPM evidence when the device presents it, or when the host requires it.
+	PolicyOptional Policy = "optional"
+	// PolicyRequired requires TPM attestation from every device.
+	PolicyRequired Policy = "required"
+)
+
+const (
+	// TPMRequiredMetadataKey is the host metadata key flagging a host as "TPM required".
+	TPMRequiredMetadataKey = "tpm-required"
+	// EKFingerprintMetadataKey is the host metadata key pinning the SHA-256 fingerprint
+	// of the DER (PKIX) encoded EK public key at registration.
+	EKFingerprintMetadataKey = "tpm-ek-sha256"
+
+	// secretSize is the size of the credential activation secret.
+	secretSize = 32
+	// symBlockSize is the symmetric block size of the TCG EK templates (AES-128).
+	symBlockSize = 16
+	// tpm2bSizeLen is the length of the size prefix of a TPM2B structure.
+	tpm2bSizeLen = 2
+)
+
+// oidSubjectAltName is the SAN extension, marked critical in TCG EK certificates which have an empty subject.
+var oidSubjectAltName = asn1.ObjectIdentifier{2, 5, 29, 17}
+
+// HostPolicy is the per-host attestation policy, stored in the host metadata at registration.
+type HostPolicy struct {
+	Required      bool
+	EKFingerprint string
+}
+
+// HostPolicyFromMetadata parses the host metadata, a JSON list of key/value pairs, into a HostPolicy.
+func HostPolicyFromMetadata(metadata string) (HostPolicy, error) {
+	var hostPolicy HostPolicy
+	required, err := util.MetadataValues(metadata, TPMRequiredMetadataKey)
+	if err != nil {
+		return hostPolicy, err
+	}
+	for _, value := range required {
+		hostPolicy.Required = strings.EqualFold(value, "true")
+	}
+	fingerprints, err := util.MetadataValues(metadata, EKFingerprintMetadataKey)
+	if err != nil {
+		return hostPolicy, err
+	}
+	for _, value := range fingerprints {
+		hostPolicy.EKFingerprint = strings.ToLower(strings.TrimSpace(value))
+	}
+	// a pinned EK implies the host must attest with it
+	if hostPolicy.EKFingerprint != "" {
+		hostPolicy.Required = true
+	}
+	return hostPolicy, nil
+}
+
+// Evidence is the TPM evidence presented by a device.
+type Evidence struct {
+	// EKCert is the DER encoded EK certificate.
+	EKCert []byte
+	// EKPub is the DER (PKIX) encoded EK public key, used when no EK certificate is available.
+	EKPub []byte
+	// AKName is the TPM name of the key the credential is bound to.
+	AKName []byte
+}
+
+// Fingerprint returns the EKFingerprint of the presented EK. The EK is not verified.
+func (e Evidence) Fingerprint() (string, error) {
+	_, ekPub, err := e.parseEK()
+	if err != nil {
+		return "", err
+	}
+	return EKFingerprint(ekPub)
+}
+
+func (e Evidence) parseEK() (*x509.Certificate, crypto.PublicKey, error) {
+	switch {
+	case len(e.EKCert) > 0:
+		ekCert, err := x509.ParseCertificate(e.EKCert)
+		if err != nil {
+			return nil, nil, inv_errors.Errorfc(codes.InvalidArgument, "Failed to parse EK certificate: %v", err)
+		}
+		return ekCert, ekCert.PublicKey, nil
+	case len(e.EKPub) > 0:
+		ekPub, err := x509.ParsePKIXPublicKey(e.EKPub)
+		if err != nil {
+			return nil, nil, inv_errors.Errorfc(codes.InvalidArgument, "Failed to parse EK public key: %v", err)
+		}
+		return nil, ekPub, nil
+	default:
+		return nil, nil, inv_errors.Errorfc(codes.InvalidArgument, "No EK certificate or public key provided")
+	}
+}
+
+// Verifier verifies device EKs against pinned fingerprints or a manufacturer CA bundle.
+type Verifier struct {
+	policy Policy
+	roots  *x509.CertPool
+}
+
+// NewVerifier creates a Verifier enforcing policy. caBundlePath, if set, is a PEM bundle of
+// TPM manufacturer CAs used to verify EK certificates of hosts without a pinned EK.
+func NewVerifier(policy Policy, caBundlePath string) (*Verifier, error) {
+	switch policy {
+	case PolicyDisabled, PolicyOptional, PolicyRequired:
+	default:
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "Unsupported TPM attestation policy %q", policy)
+	}
+	v := &Verifier{policy: policy}
+	if caBundlePath == "" {
+		return v, nil
+	}
+	caPEM, err := os.ReadFile(caBundlePath)
+	if err != nil {
+		return nil, inv_errors.Errorf("Failed to read TPM manufacturer CA bundle: %v", err)
+	}
+	v.roots = x509.NewCertPool()
+	if !v.roots.AppendCertsFromPEM(caPEM) {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "No valid certificates found in TPM manufacturer CA bundle")
+	}
+	return v, nil
+}
+
+// Enabled reports whether attestation may be performed at all.
+func (v *Verifier) Enabled() bool {
+	return v != nil && v.policy != PolicyDisabled
+}
+
+// Required reports whether a host with the given policy must attest.
+func (v *Verifier) Required(hostPolicy HostPolicy) bool {
+	if !v.Enabled() {
+		return false
+	}
+	return v.policy == PolicyRequired || hostPolicy.Required
+}
+
+// VerifyEK checks the EK in evidence against the pinned fingerprint of the host, or, if none is pinned,
+// against the manufacturer CA bundle. It returns the verified EK public key.
+func (v *Verifier) VerifyEK(evidence Evidence, hostPolicy HostPolicy) (crypto.PublicKey, error) {
+	ekCert, ekPub, err := evidence.parseEK()
+	if err != nil {
+		return nil, err
+	}
+
+	if hostPolicy.EKFingerprint != "" {
+		fingerprint, fpErr := EKFingerprint(ekPub)
+		if fpErr != nil {
+			return nil, fpErr
+		}
+		if subtle.ConstantTimeCompare([]byte(fingerprint), []byte(hostPolicy.EKFingerprint)) != 1 {
+			return nil, inv_errors.Errorfc(codes.PermissionDenied, "EK does not match the EK pinned at registration")
+		}
+		return ekPub, nil
+	}
+
+	if ekCert == nil {
+		return nil, inv_errors.Errorfc(codes.PermissionDenied, "EK certificate is required when no EK is pinned")
+	}
+	if v.roots == nil {
+		return nil, inv_errors.Errorfc(codes.PermissionDenied, "No EK pinned and no TPM manufacturer CA configured")
+	}
+	if err := verifyEKCertificate(ekCert, v.roots); err != nil {
+		return nil, err
+	}
+	return ekPub, nil
+}
+
+func verifyEKCertificate(ekCert *x509.Certificate, roots *x509.CertPool) error {
+	// EK certificates carry the TPM manufacturer and model in a critical SAN that
+	// the x509 package does not understand, it is informational only.
+	unhandled := ekCert.UnhandledCriticalExtensions[:0]
+	for _, ext := range ekCert.UnhandledCriticalExtensions {
+		if !ext.Equal(oidSubjectAltName) {
+			unhandled = append(unhandled, ext)
+		}
+	}
+	ekCert.UnhandledCriticalExtensions = unhandled
+
+	if _, err := ekCert.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return inv_errors.Errorfc(codes.PermissionDenied, "EK certificate verification failed: %v", err)
+	}
+	return nil
+}
+
+// EKFingerprint returns the hex encoded SHA-256 fingerprint of the DER (PKIX) encoded EK public key.
+func EKFingerprint(ekPub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(ekPub)
+	if err != nil {
+		return "", inv_errors.Errorfc(codes.InvalidArgument, "Failed to encode EK public key: %v", err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Challenge is a credential activation challenge that only the TPM holding the EK can answer.
+type Challenge struct {
+	// CredentialBlob is the TPM2B_ID_OBJECT buffer passed to TPM2_ActivateCredential.
+	CredentialBlob []byte
+	// EncryptedSecret is the TPM2B_ENCRYPTED_SECRET buffer passed to TPM2_ActivateCredential.
+	EncryptedSecret []byte
+
+	secret []byte
+}
+
+// NewChallenge creates a credential activation challenge for ekPub, bound to the key named akName.
+func NewChallenge(ekPub crypto.PublicKey, akName []byte) (*Challenge, error) {
+	name, err := decodeName(akName)
+	if err != nil {
+		return nil, err
+	}
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, inv_errors.Errorf("Failed to generate credential secret: %v", err)
+	}
+	idObject, encSecret, err := credactivation.Generate(name, ekPub, symBlockSize, secret)
+	if err != nil {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "Failed to generate credential challenge: %v", err)
+	}
+	return &Challenge{
+		CredentialBlob:  idObject[tpm2bSizeLen:],
+		EncryptedSecret: encSecret[tpm2bSizeLen:],
+		secret:          secret,
+	}, nil
+}
+
+// Verify reports whether activated is the secret recovered by TPM2_ActivateCredential.
+func (c *Challenge) Verify(activated []byte) bool {
+	return len(activated) == len(c.secret) && subtle.ConstantTimeCompare(activated, c.secret) == 1
+}
+
+// decodeName decodes a TPM name (algorithm identifier followed by the digest of the public area).
+func decodeName(name []byte) (*tpm2.HashValue, error) {
+	if len(name) <= tpm2bSizeLen {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "Invalid AK name")
+	}
+	alg := tpm2.Algorithm(binary.BigEndian.Uint16(name[:tpm2bSizeLen]))
+	hash, err := alg.Hash()
+	if err != nil {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "Unsupported AK name algorithm: %v", err)
+	}
+	digest := name[tpm2bSizeLen:]
+	if len(digest) != hash.Size() {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "Invalid AK name digest size %d", len(digest))
+	}
+	return &tpm2.HashValue{Alg: alg, Value: digest}, nil
+}
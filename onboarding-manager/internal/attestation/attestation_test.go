@@ -0,0 +1,271 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package attestation_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpm2/transport"
+	"github.com/google/go-tpm/tpm2/transport/simulator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/attestation"
+)
+
+// akTemplate is a restricted signing key used as the credential activation subject.
+var akTemplate = tpm2.TPMTPublic{
+	Type:    tpm2.TPMAlgECC,
+	NameAlg: tpm2.TPMAlgSHA256,
+	ObjectAttributes: tpm2.TPMAObject{
+		FixedTPM:            true,
+		FixedParent:         true,
+		SensitiveDataOrigin: true,
+		UserWithAuth:        true,
+		Restricted:          true,
+		SignEncrypt:         true,
+	},
+	Parameters: tpm2.NewTPMUPublicParms(tpm2.TPMAlgECC, &tpm2.TPMSECCParms{
+		Scheme: tpm2.TPMTECCScheme{
+			Scheme: tpm2.TPMAlgECDSA,
+			Details: tpm2.NewTPMUAsymScheme(tpm2.TPMAlgECDSA,
+				&tpm2.TPMSSigSchemeECDSA{HashAlg: tpm2.TPMAlgSHA256}),
+		},
+		CurveID: tpm2.TPMECCNistP256,
+	}),
+}
+
+// softwareTPM is a device TPM backed by the software TPM simulator.
+type softwareTPM struct {
+	tpm   transport.TPMCloser
+	ek    *tpm2.CreatePrimaryResponse
+	ak    *tpm2.CreatePrimaryResponse
+	ekPub crypto.PublicKey
+}
+
+func newSoftwareTPM(t *testing.T) *softwareTPM {
+	t.Helper()
+	tpm, err := simulator.OpenSimulator()
+	require.NoError(t, err)
+	t.Cleanup(func() { tpm.Close() })
+
+	ek, err := tpm2.CreatePrimary{
+		PrimaryHandle: tpm2.TPMRHEndorsement,
+		InPublic:      tpm2.New2B(tpm2.RSAEKTemplate),
+	}.Execute(tpm)
+	require.NoError(t, err)
+	ak, err := tpm2.CreatePrimary{
+		PrimaryHandle: tpm2.TPMRHEndorsement,
+		InPublic:      tpm2.New2B(akTemplate),
+	}.Execute(tpm)
+	require.NoError(t, err)
+
+	ekPublic, err := ek.OutPublic.Contents()
+	require.NoError(t, err)
+	ekPub, err := tpm2.Pub(*ekPublic)
+	require.NoError(t, err)
+
+	return &softwareTPM{tpm: tpm, ek: ek, ak: ak, ekPub: ekPub}
+}
+
+func (s *softwareTPM) evidence(t *testing.T) attestation.Evidence {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(s.ekPub)
+	require.NoError(t, err)
+	return attestation.Evidence{EKPub: der, AKName: s.ak.Name.Buffer}
+}
+
+func (s *softwareTPM) activate(t *testing.T, challenge *attestation.Challenge) []byte {
+	t.Helper()
+	rsp, err := tpm2.ActivateCredential{
+		ActivateHandle: tpm2.NamedHandle{Handle: s.ak.ObjectHandle, Name: s.ak.Name},
+		KeyHandle: tpm2.AuthHandle{
+			Handle: s.ek.ObjectHandle,
+			Name:   s.ek.Name,
+			Auth: tpm2.Policy(tpm2.TPMAlgSHA256, 16, func(tpm transport.TPM, handle tpm2.TPMISHPolicy, _ tpm2.TPM2BNonce) error {
+				_, err := tpm2.PolicySecret{
+					AuthHandle:    tpm2.TPMRHEndorsement,
+					PolicySession: handle,
+				}.Execute(tpm)
+				return err
+			}),
+		},
+		CredentialBlob: tpm2.TPM2BIDObject{Buffer: challenge.CredentialBlob},
+		Secret:         tpm2.TPM2BEncryptedSecret{Buffer: challenge.EncryptedSecret},
+	}.Execute(s.tpm)
+	require.NoError(t, err)
+	return rsp.CertInfo.Buffer
+}
+
+func TestChallenge_SoftwareTPM(t *testing.T) {
+	device := newSoftwareTPM(t)
+	fingerprint, err := attestation.EKFingerprint(device.ekPub)
+	require.NoError(t, err)
+
+	presented, err := device.evidence(t).Fingerprint()
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint, presented)
+
+	verifier, err := attestation.NewVerifier(attestation.PolicyOptional, "")
+	require.NoError(t, err)
+	ekPub, err := verifier.VerifyEK(device.evidence(t), attestation.HostPolicy{EKFingerprint: fingerprint})
+	require.NoError(t, err)
+
+	challenge, err := attestation.NewChallenge(ekPub, device.evidence(t).AKName)
+	require.NoError(t, err)
+	assert.True(t, challenge.Verify(device.activate(t, challenge)))
+	assert.False(t, challenge.Verify(make([]byte, 32)))
+	assert.False(t, challenge.Verify(nil))
+}
+
+func TestChallenge_OtherTPMCannotActivate(t *testing.T) {
+	device := newSoftwareTPM(t)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	// a challenge for another EK cannot be activated with the device EK
+	challenge, err := attestation.NewChallenge(&otherKey.PublicKey, device.evidence(t).AKName)
+	require.NoError(t, err)
+	_, err = tpm2.ActivateCredential{
+		ActivateHandle: tpm2.NamedHandle{Handle: device.ak.ObjectHandle, Name: device.ak.Name},
+		KeyHandle: tpm2.AuthHandle{
+			Handle: device.ek.ObjectHandle,
+			Name:   device.ek.Name,
+			Auth: tpm2.Policy(tpm2.TPMAlgSHA256, 16, func(tpm transport.TPM, handle tpm2.TPMISHPolicy, _ tpm2.TPM2BNonce) error {
+				_, policyErr := tpm2.PolicySecret{AuthHandle: tpm2.TPMRHEndorsement, PolicySession: handle}.Execute(tpm)
+				return policyErr
+			}),
+		},
+		CredentialBlob: tpm2.TPM2BIDObject{Buffer: challenge.CredentialBlob},
+		Secret:         tpm2.TPM2BEncryptedSecret{Buffer: challenge.EncryptedSecret},
+	}.Execute(device.tpm)
+	require.Error(t, err)
+}
+
+func TestVerifier_VerifyEK_Pinned(t *testing.T) {
+	device := newSoftwareTPM(t)
+	verifier, err := attestation.NewVerifier(attestation.PolicyOptional, "")
+	require.NoError(t, err)
+
+	_, err = verifier.VerifyEK(device.evidence(t), attestation.HostPolicy{EKFingerprint: "00ff"})
+	require.Error(t, err)
+
+	// without a pin and without a CA bundle nothing can be trusted
+	_, err = verifier.VerifyEK(device.evidence(t), attestation.HostPolicy{})
+	require.Error(t, err)
+
+	_, err = verifier.VerifyEK(attestation.Evidence{}, attestation.HostPolicy{})
+	require.Error(t, err)
+}
+
+func TestVerifier_VerifyEK_ManufacturerCA(t *testing.T) {
+	device := newSoftwareTPM(t)
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "TPM Manufacturer CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	// TCG EK certificates have an empty subject and a critical SAN with the TPM manufacturer
+	san, err := asn1.Marshal([]asn1.RawValue{{Class: asn1.ClassContextSpecific, Tag: 4, IsCompound: true,
+		Bytes: mustMarshal(t, pkix.Name{CommonName: "id:494E5443"}.ToRDNSequence())}})
+	require.NoError(t, err)
+	ekTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment,
+		ExtraExtensions: []pkix.Extension{
+			{Id: asn1.ObjectIdentifier{2, 5, 29, 17}, Critical: true, Value: san},
+		},
+	}
+	ekDER, err := x509.CreateCertificate(rand.Reader, ekTemplate, caCert, device.ekPub, caKey)
+	require.NoError(t, err)
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), 0o600))
+	verifier, err := attestation.NewVerifier(attestation.PolicyRequired, bundle)
+	require.NoError(t, err)
+
+	evidence := device.evidence(t)
+	evidence.EKCert = ekDER
+	ekPub, err := verifier.VerifyEK(evidence, attestation.HostPolicy{})
+	require.NoError(t, err)
+	challenge, err := attestation.NewChallenge(ekPub, evidence.AKName)
+	require.NoError(t, err)
+	assert.True(t, challenge.Verify(device.activate(t, challenge)))
+
+	otherVerifier, err := attestation.NewVerifier(attestation.PolicyRequired, "")
+	require.NoError(t, err)
+	_, err = otherVerifier.VerifyEK(evidence, attestation.HostPolicy{})
+	require.Error(t, err)
+}
+
+func TestHostPolicyFromMetadata(t *testing.T) {
+	hostPolicy, err := attestation.HostPolicyFromMetadata("")
+	require.NoError(t, err)
+	assert.Equal(t, attestation.HostPolicy{}, hostPolicy)
+
+	hostPolicy, err = attestation.HostPolicyFromMetadata(`[{"key":"tpm-required","value":"true"}]`)
+	require.NoError(t, err)
+	assert.Equal(t, attestation.HostPolicy{Required: true}, hostPolicy)
+
+	hostPolicy, err = attestation.HostPolicyFromMetadata(`[{"key":"tpm-ek-sha256","value":" ABCD "}]`)
+	require.NoError(t, err)
+	assert.Equal(t, attestation.HostPolicy{Required: true, EKFingerprint: "abcd"}, hostPolicy)
+
+	_, err = attestation.HostPolicyFromMetadata(`{`)
+	require.Error(t, err)
+}
+
+func TestVerifier_Required(t *testing.T) {
+	_, err := attestation.NewVerifier("sometimes", "")
+	require.Error(t, err)
+
+	disabled, err := attestation.NewVerifier(attestation.PolicyDisabled, "")
+	require.NoError(t, err)
+	assert.False(t, disabled.Required(attestation.HostPolicy{Required: true}))
+
+	optional, err := attestation.NewVerifier(attestation.PolicyOptional, "")
+	require.NoError(t, err)
+	assert.False(t, optional.Required(attestation.HostPolicy{}))
+	assert.True(t, optional.Required(attestation.HostPolicy{Required: true}))
+
+	required, err := attestation.NewVerifier(attestation.PolicyRequired, "")
+	require.NoError(t, err)
+	assert.True(t, required.Required(attestation.HostPolicy{}))
+
+	var nilVerifier *attestation.Verifier
+	assert.False(t, nilVerifier.Enabled())
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	b, err := asn1.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
@@ -171,6 +171,12 @@ func (obc *OnboardingController) handleInternalEvent(event *client.ResourceTenan
 func (obc *OnboardingController) handleInventoryEvent(event *client.WatchEvents) {
 	zlog.Debug().Msgf("Inventory event: event=%v", event.Event)
 
+	// wake up Edge Nodes waiting on the NIO stream for the host to be approved
+	if kind, err := util.GetResourceKindFromResourceID(event.Event.GetResourceId()); err == nil &&
+		kind == inv_v1.ResourceKind_RESOURCE_KIND_HOST {
+		obc.invClient.NotifyHostEvent(event.Event.GetResourceId())
+	}
+
 	if !obc.filterEvent(event.Event) {
 		zlog.Debug().Msgf("Event %v is not allowed by filter", event.Event)
 		return
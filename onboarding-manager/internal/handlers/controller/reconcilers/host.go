@@ -14,6 +14,7 @@ import (
 	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/logging"
 	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/tracing"
 	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/invclient"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding"
 	om_status "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/status"
 	rec_v2 "github.com/open-edge-platform/orch-library/go/pkg/controller/v2"
 )
@@ -142,6 +143,17 @@ func (hr *HostReconciler) deleteHost(
 		zlogHost.Debug().Err(err).Msgf("Failed to update status detail for host %s", host.GetResourceId())
 	}
 
+	// the disks are sanitized while the host still has its credentials, that the decommission workflow needs
+	if detail, err := onboarding.SanitizeHostDisks(ctx, host); err != nil {
+		if detail != "" {
+			if statusErr := hr.invClient.SetHostStatusDetail(ctx, host.GetTenantId(), host.GetResourceId(),
+				om_status.ModernHostStatusDeletingWithDetails(detail)); statusErr != nil {
+				zlogHost.Debug().Err(statusErr).Msgf("Failed to update status detail for host %s", host.GetResourceId())
+			}
+		}
+		return err
+	}
+
 	// if the current state is Untrusted, host certificates are already revoked
 	if host.GetCurrentState() != computev1.HostState_HOST_STATE_UNTRUSTED {
 		if err := kk_auth.RevokeHostCredentials(ctx, host.GetTenantId(), host.GetUuid()); err != nil {
@@ -178,6 +190,11 @@ func (hr *HostReconciler) deleteHost(
 		return err
 	}
 
+	if err := onboarding.DeleteDecommissionWorkflowIfExists(ctx, host.GetUuid()); err != nil {
+		// the workflow of a previous host is replaced if a host with the same UUID is deleted later
+		zlogHost.Warn().Err(err).Msgf("Failed to delete the decommission workflow of host %s", host.GetResourceId())
+	}
+
 	return nil
 }
 
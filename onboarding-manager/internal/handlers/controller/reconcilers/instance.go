@@ -26,6 +26,8 @@ import (
 	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/invclient"
 	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding"
 	onboarding_types "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding/types"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/redfish"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
 	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/util"
 	om_status "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/status"
 	rec_v2 "github.com/open-edge-platform/orch-library/go/pkg/controller/v2"
@@ -189,7 +191,7 @@ func (ir *InstanceReconciler) handleErrorState(instance *computev1.InstanceResou
 		// ATM I (Tomasz) believe that a user should delete via UI and re-configure host again,
 		// once the issue is fixed (e.g., wrong BIOS settings, etc.)
 		zlogInst.Warn().Msgf(
-			"Provisioning status is failed. Reconciliation won't happen until the Instance is re-created.")
+			"Provisioning status is failed. Reconciliation won't happen until the Instance is re-created or reprovisioned.")
 		return request.Ack()
 	}
 	return nil
@@ -323,6 +325,11 @@ func (ir *InstanceReconciler) reconcileInstance(
 				return directive
 			}
 		}
+		if err := onboarding.DeleteReprovisionRecordIfExists(ctx, instance); err != nil {
+			if directive := HandleProvisioningError(err, request); directive != nil {
+				return directive
+			}
+		}
 
 		err := ir.invClient.UpdateInstanceCurrentState(
 			ctx,
@@ -436,11 +443,18 @@ func convertInstanceToDeviceInfo(instance *computev1.InstanceResource,
 		}
 	}
 
+	// BmcIp is the IP of the host itself for hosts without BMC, the BMC is only known from the host metadata
+	redfishEndpoint, err := redfish.EndpointFromMetadata(host.GetMetadata())
+	if err != nil {
+		zlogInst.Warn().Err(err).Msgf("Ignoring the Redfish endpoint of host %s", host.GetResourceId())
+	}
+
 	deviceInfo := onboarding_types.DeviceInfo{
 		GUID:              host.GetUuid(),
 		HwSerialID:        host.GetSerialNumber(),
 		HwMacID:           host.GetPxeMac(),
 		HwIP:              host.GetBmcIp(),
+		Architecture:      tinkerbell.HostArchitecture(host.GetCpuArchitecture()),
 		UserLVMSize:       uint64(host.GetUserLvmSize()),
 		Hostname:          host.GetResourceId(), // we use resource ID as hostname to uniquely identify a host
 		SecurityFeature:   instance.GetSecurityFeature(),
@@ -454,6 +468,7 @@ func convertInstanceToDeviceInfo(instance *computev1.InstanceResource,
 		KernelVersion:     kernelVersion,
 		SkipKernelUpgrade: skipKernelUpgrade,
 		OSImageCompressed: osImageCompressed,
+		RedfishEndpoint:   redfishEndpoint,
 	}
 
 	zlogInst.Debug().Msgf("DeviceInfo generated from OS resource (%s): %+v",
@@ -481,6 +496,9 @@ func (ir *InstanceReconciler) tryProvisionInstance(ctx context.Context, instance
 			instance.GetResourceId(), instance.GetHost().GetUuid())
 		return err
 	}
+	if deviceInfo.ReprovisionAttempt, err = onboarding.ReprovisionAttempt(ctx, instance); err != nil {
+		return err
+	}
 
 	//nolint:errcheck // proto.Clone returns interface{} which cannot fail type assertion
 	oldInstance := proto.Clone(instance).(*computev1.InstanceResource)
@@ -488,11 +506,12 @@ func (ir *InstanceReconciler) tryProvisionInstance(ctx context.Context, instance
 	zlogInst.Debug().Msgf("Trying to provision Instance %s with OS %s",
 		instance.GetResourceId(), instance.GetOs().GetName())
 
+	_, done, failed := om_status.ProvisioningStatuses(deviceInfo.ReprovisionAttempt)
 	defer func() {
 		// if unrecoverable error, report error provisioning status
 		if grpc_status.Convert(err).Code() == codes.Aborted {
 			// report error
-			util.PopulateInstanceProvisioningStatus(instance, om_status.ProvisioningStatusFailed)
+			util.PopulateInstanceProvisioningStatus(instance, failed)
 		}
 		// should be safe to not return an error
 		// if the inventory client fails, this will be eventually fixed in the next reconciliation cycle
@@ -501,6 +520,13 @@ func (ir *InstanceReconciler) tryProvisionInstance(ctx context.Context, instance
 		ir.updateHostInstanceStatusAndCurrentState(ctx, oldInstance, instance)
 	}()
 
+	// the OS image is installed by the uOS of the host, it must be built for the architecture of the host
+	if err = tinkerbell.CheckOSArchitecture(instance.GetOs().GetArchitecture(), deviceInfo.Architecture); err != nil {
+		zlogInst.InfraSec().Err(err).Msgf("Cannot provision Instance %s with OS %s on Host UUID %s",
+			instance.GetResourceId(), instance.GetOs().GetResourceId(), instance.GetHost().GetUuid())
+		return err
+	}
+
 	// Check status of Prod Workflow and initiate if it's not running.
 	if err := onboarding.CheckStatusOrRunProdWorkflow(ctx, deviceInfo, instance); err != nil {
 		zlogInst.InfraSec().Err(err).Msgf("Failed CheckStatusOrRunProdWorkflow - Instance %s with Host UUID %s and Error is %s",
@@ -508,8 +534,7 @@ func (ir *InstanceReconciler) tryProvisionInstance(ctx context.Context, instance
 		return err
 	}
 
-	util.PopulateInstanceStatusAndCurrentState(instance, computev1.InstanceState_INSTANCE_STATE_RUNNING,
-		om_status.ProvisioningStatusDone)
+	util.PopulateInstanceStatusAndCurrentState(instance, computev1.InstanceState_INSTANCE_STATE_RUNNING, done)
 
 	return nil
 }
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package grpcserver
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/policy/rbac"
+	inv_tenant "github.com/open-edge-platform/infra-core/inventory/v2/pkg/tenant"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding"
+	pb "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/api/onboardingmgr/v1"
+)
+
+// ReportActionMessage sets the message of the running action of a workflow of an Edge Node, e.g. its progress,
+// its heartbeat, or the report and the exit status of the action once it finished. The message is kept in the
+// status of the workflow, where the Tinkerbell server keeps it as the action goes on. With authentication
+// enabled, only the Edge Node itself, authenticated with its credentials, can report on its workflows.
+func (s *InteractiveOnboardingService) ReportActionMessage(ctx context.Context, req *pb.ReportActionMessageRequest) (
+	*pb.ReportActionMessageResponse, error,
+) {
+	zlog.Debug().Msgf("ReportActionMessage")
+
+	if err := req.Validate(); err != nil {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "%v", err)
+	}
+
+	if s.authEnabled {
+		// checking if JWT contains write permission
+		if !s.rbac.IsRequestAuthorized(ctx, rbac.CreateKey) {
+			err := inv_errors.Errorfc(codes.PermissionDenied, "Request is blocked by RBAC")
+			zlog.InfraSec().InfraErr(err).Msgf("Request ReportActionMessage is not authenticated")
+			return nil, err
+		}
+	}
+
+	tenantID, present := inv_tenant.GetTenantIDFromContext(ctx)
+	if !present {
+		// This should never happen! Interceptor should either fail or set it!
+		err := inv_errors.Errorfc(codes.Unauthenticated, "Tenant ID is not present in context")
+		zlog.InfraSec().InfraErr(err).Msg("Request ReportActionMessage is not authenticated")
+		return nil, err
+	}
+
+	if s.authEnabled {
+		if err := checkCallerIsHost(ctx, tenantID, req.GetUuid()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := onboarding.ReportActionMessage(ctx, req.GetUuid(), req.GetWorkflowId(), req.GetTaskName(),
+		req.GetActionName(), req.GetMessage()); err != nil {
+		return nil, err
+	}
+	return &pb.ReportActionMessageResponse{}, nil
+}
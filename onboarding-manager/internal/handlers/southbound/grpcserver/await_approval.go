@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	google_rpc "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	pb "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/api/onboardingmgr/v1"
+)
+
+const (
+	// DefaultMaxAwaitingStreams is the default number of Edge Nodes awaiting approval on their stream.
+	DefaultMaxAwaitingStreams = 1000
+	// DefaultKeepaliveInterval is the default interval of keepalives sent to Edge Nodes awaiting approval.
+	DefaultKeepaliveInterval = 30 * time.Second
+)
+
+// WithServerPush lets up to maxAwaiting Edge Nodes in the REGISTERED state wait on their stream until the host
+// is approved for onboarding, sending them a REGISTERED keepalive every keepalive interval. Edge Nodes beyond
+// maxAwaiting, or that do not ask to await approval, keep polling. A zero maxAwaiting disables server push.
+func WithServerPush(maxAwaiting int, keepalive time.Duration) NonInteractiveOnboardingOption {
+	return func(s *NonInteractiveOnboardingService) {
+		if maxAwaiting <= 0 || keepalive <= 0 {
+			return
+		}
+		s.awaitSlots = make(chan struct{}, maxAwaiting)
+		s.keepalive = keepalive
+	}
+}
+
+// acquireAwaitSlot reserves a slot for an Edge Node awaiting approval, it never blocks.
+func (s *NonInteractiveOnboardingService) acquireAwaitSlot() bool {
+	if s.awaitSlots == nil {
+		return false
+	}
+	select {
+	case s.awaitSlots <- struct{}{}:
+		return true
+	default:
+		zlog.Debug().Msgf("Too many Edge Nodes awaiting approval, falling back to polling")
+		return false
+	}
+}
+
+func (s *NonInteractiveOnboardingService) releaseAwaitSlot() {
+	<-s.awaitSlots
+}
+
+// registeredResponse returns the REGISTERED response for the host.
+func (s *NonInteractiveOnboardingService) registeredResponse(hostInv *computev1.HostResource,
+	awaiting bool,
+) *pb.OnboardNodeStreamResponse {
+	response := &pb.OnboardNodeStreamResponse{
+		Status:    &google_rpc.Status{Code: int32(codes.OK)},
+		NodeState: pb.OnboardNodeStreamResponse_NODE_STATE_REGISTERED,
+		ProjectId: hostInv.GetTenantId(),
+	}
+	if awaiting {
+		response.AwaitingApproval = true
+		response.KeepaliveIntervalSeconds = uint32(s.keepalive / time.Second) // #nosec G115
+	}
+	return response
+}
+
+// awaitApproval keeps the stream of a REGISTERED Edge Node open until the desired state of the host changes,
+// then onboards it if approved. Inventory events of the host are watched, so the Edge Node does not poll.
+// It reports whether the host was onboarded.
+func (s *NonInteractiveOnboardingService) awaitApproval(stream pb.NonInteractiveOnboardingService_OnboardNodeStreamServer,
+	hostInv *computev1.HostResource, req *pb.OnboardNodeStreamRequest,
+) (bool, error) {
+	// subscribe before registering, so that an approval right after the registration is not missed
+	sub := s.invClient.SubscribeHostEvents(hostInv.GetResourceId())
+	defer sub.Close()
+
+	if err := s.registerHost(stream, hostInv, req, true); err != nil {
+		return false, err
+	}
+	zlog.Debug().Msgf("Host %s awaiting approval for onboarding", hostInv.GetResourceId())
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	pumped := pumpStream(ctx, stream)
+	keepalive := time.NewTicker(s.keepalive)
+	defer keepalive.Stop()
+
+	// hostInv was read before subscribing, the host may have been approved in between
+	if done, onboarded, err := s.checkApproval(ctx, pumped, hostInv, req); done || err != nil {
+		return onboarded, err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+
+		case <-keepalive.C:
+			// an event may have been lost while the host could not be read, re-check before the keepalive
+			if done, onboarded, err := s.checkApproval(ctx, pumped, hostInv, req); done || err != nil {
+				return onboarded, err
+			}
+			if err := sendOnboardStreamResponse(stream, s.registeredResponse(hostInv, true)); err != nil {
+				return false, err
+			}
+
+		case msg := <-pumped.msgs:
+			if errors.Is(msg.err, io.EOF) {
+				zlog.Info().Msgf("OnboardNodeStream client has closed the stream while awaiting approval")
+				return false, nil
+			}
+			if msg.err != nil {
+				return false, msg.err
+			}
+			// requests are not expected while awaiting approval, answer with the current state
+			if err := sendOnboardStreamResponse(stream, s.registeredResponse(hostInv, true)); err != nil {
+				return false, err
+			}
+
+		case <-sub.C:
+			if done, onboarded, err := s.checkApproval(ctx, pumped, hostInv, req); done || err != nil {
+				return onboarded, err
+			}
+		}
+	}
+}
+
+// checkApproval reads the desired state of the host awaiting approval and onboards the host if approved.
+// It reports whether the wait is over and whether the host was onboarded. A failure to read the host does
+// not end the wait, the next event or keepalive retries.
+func (s *NonInteractiveOnboardingService) checkApproval(ctx context.Context, pumped *pumpedStream,
+	hostInv *computev1.HostResource, req *pb.OnboardNodeStreamRequest,
+) (done, onboarded bool, err error) {
+	host, err := s.invClient.GetHostResourceByResourceID(ctx, hostInv.GetTenantId(), hostInv.GetResourceId())
+	if inv_errors.IsNotFound(err) {
+		return true, false, sendStreamErrorResponse(pumped, codes.NotFound, "Device not found")
+	}
+	if err != nil {
+		zlog.InfraSec().InfraErr(err).Msgf("Failed to get host %s awaiting approval", hostInv.GetResourceId())
+		return false, false, nil
+	}
+	switch host.GetDesiredState() {
+	case computev1.HostState_HOST_STATE_REGISTERED:
+		return false, false, nil
+	case computev1.HostState_HOST_STATE_ONBOARDED:
+		zlog.Debug().Msgf("Host %s approved for onboarding", hostInv.GetResourceId())
+		if err = s.onboardHost(pumped, host, req); err != nil {
+			return true, false, err
+		}
+		return true, true, nil
+	default:
+		return true, false, s.handleDefaultState(pumped)
+	}
+}
+
+type recvResult struct {
+	req *pb.OnboardNodeStreamRequest
+	err error
+}
+
+// pumpedStream receives from the stream in the background, so that the stream can be
+// waited on together with other events. Recv must only be called through the pumpedStream.
+type pumpedStream struct {
+	pb.NonInteractiveOnboardingService_OnboardNodeStreamServer
+	msgs chan recvResult
+}
+
+func pumpStream(ctx context.Context, stream pb.NonInteractiveOnboardingService_OnboardNodeStreamServer) *pumpedStream {
+	p := &pumpedStream{
+		NonInteractiveOnboardingService_OnboardNodeStreamServer: stream,
+		msgs: make(chan recvResult),
+	}
+	go func() {
+		for {
+			// Recv returns once the handler returns, at the latest
+			req, err := stream.Recv()
+			select {
+			case p.msgs <- recvResult{req: req, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return p
+}
+
+// Recv returns the next message received from the stream.
+func (p *pumpedStream) Recv() (*pb.OnboardNodeStreamRequest, error) {
+	select {
+	case msg := <-p.msgs:
+		return msg.req, msg.err
+	case <-p.Context().Done():
+		return nil, p.Context().Err()
+	}
+}
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package grpcserver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	statusv1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/status/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/policy/rbac"
+	inv_status "github.com/open-edge-platform/infra-core/inventory/v2/pkg/status"
+	inv_tenant "github.com/open-edge-platform/infra-core/inventory/v2/pkg/tenant"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding"
+	pb "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/api/onboardingmgr/v1"
+	om_status "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/status"
+)
+
+// ConfirmFirstBoot confirms the first boot of the OS installed on an Edge Node, once all its agents are ready.
+// The Instance of the Edge Node is then provisioned and its workflow deleted. With authentication enabled,
+// only the Edge Node itself, authenticated with its credentials, can confirm its first boot.
+func (s *InteractiveOnboardingService) ConfirmFirstBoot(ctx context.Context, req *pb.ConfirmFirstBootRequest) (
+	*pb.ConfirmFirstBootResponse, error,
+) {
+	zlog.Info().Msgf("ConfirmFirstBoot")
+
+	if err := req.Validate(); err != nil {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "%v", err)
+	}
+
+	if s.authEnabled {
+		// checking if JWT contains write permission
+		if !s.rbac.IsRequestAuthorized(ctx, rbac.CreateKey) {
+			err := inv_errors.Errorfc(codes.PermissionDenied, "Request is blocked by RBAC")
+			zlog.InfraSec().InfraErr(err).Msgf("Request ConfirmFirstBoot is not authenticated")
+			return nil, err
+		}
+	}
+
+	tenantID, present := inv_tenant.GetTenantIDFromContext(ctx)
+	if !present {
+		// This should never happen! Interceptor should either fail or set it!
+		err := inv_errors.Errorfc(codes.Unauthenticated, "Tenant ID is not present in context")
+		zlog.InfraSec().InfraErr(err).Msg("Request ConfirmFirstBoot is not authenticated")
+		return nil, err
+	}
+
+	if s.authEnabled {
+		if err := checkCallerIsHost(ctx, tenantID, req.GetUuid()); err != nil {
+			return nil, err
+		}
+	}
+
+	host, err := s.invClient.GetHostResourceByUUID(ctx, tenantID, req.GetUuid())
+	if err != nil {
+		zlog.InfraSec().InfraErr(err).Msgf("Failed to get host %s, tID=%s", req.GetUuid(), tenantID)
+		return nil, err
+	}
+	if host.GetInstance() == nil {
+		return nil, inv_errors.Errorfc(codes.FailedPrecondition, "Host %s has no Instance", host.GetResourceId())
+	}
+	instance, err := s.invClient.GetInstanceResourceByResourceID(ctx, tenantID, host.GetInstance().GetResourceId())
+	if err != nil {
+		zlog.InfraSec().InfraErr(err).Msgf("Failed to get Instance %s, tID=%s", host.GetInstance().GetResourceId(), tenantID)
+		return nil, err
+	}
+
+	attempt, err := onboarding.ReprovisionAttempt(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	_, done, _ := om_status.ProvisioningStatuses(attempt)
+
+	resp := &pb.ConfirmFirstBootResponse{InstanceId: instance.GetResourceId()}
+	if isFirstBootConfirmed(instance, done) {
+		// the Edge Node did not receive the previous response
+		resp.Confirmed = true
+		return resp, nil
+	}
+	if !om_status.IsVerifyingFirstBoot(instance.GetProvisioningStatus()) {
+		return nil, inv_errors.Errorfc(codes.FailedPrecondition, "Instance %s is not verifying its first boot",
+			instance.GetResourceId())
+	}
+
+	for _, agent := range req.GetAgents() {
+		if !agent.GetReady() {
+			resp.PendingAgents = append(resp.PendingAgents, agent.GetName())
+		}
+	}
+	if len(resp.PendingAgents) > 0 {
+		// the provisioning status is left untouched, it records when the verification started
+		zlog.Debug().Msgf("First boot of host %s waiting for agents %v", host.GetResourceId(), resp.PendingAgents)
+		return resp, nil
+	}
+
+	if err = onboarding.DeleteTinkerbellWorkflowIfExists(ctx, host.GetUuid()); err != nil {
+		return nil, err
+	}
+	if err = s.invClient.UpdateInstance(ctx, tenantID, instance.GetResourceId(),
+		computev1.InstanceState_INSTANCE_STATE_RUNNING,
+		om_status.NewStatusWithDetails(done, fmt.Sprintf("first boot confirmed, kernel %s", req.GetKernelVersion())),
+		instance.GetOs()); err != nil {
+		zlog.InfraSec().InfraErr(err).Msgf("Failed to update Instance %s, tID=%s", instance.GetResourceId(), tenantID)
+		return nil, err
+	}
+	zlog.InfraSec().Info().Msgf("First boot of host %s confirmed, Instance %s provisioned, boot ID %s, kernel %s, tID=%s",
+		host.GetResourceId(), instance.GetResourceId(), req.GetBootId(), req.GetKernelVersion(), tenantID)
+
+	resp.Confirmed = true
+	return resp, nil
+}
+
+// isFirstBootConfirmed returns true if the Instance is provisioned, including by a previous ConfirmFirstBoot.
+// done is the provisioning status reported once the current provisioning or reprovisioning attempt is done.
+func isFirstBootConfirmed(instance *computev1.InstanceResource, done inv_status.ResourceStatus) bool {
+	return instance.GetCurrentState() == computev1.InstanceState_INSTANCE_STATE_RUNNING &&
+		instance.GetProvisioningStatusIndicator() == statusv1.StatusIndication_STATUS_INDICATION_IDLE &&
+		strings.HasPrefix(instance.GetProvisioningStatus(), done.Status)
+}
+
+// checkCallerIsHost verifies that the JWT of the request was issued to the EN credentials of the host.
+// The JWT itself is verified by RBAC.
+func checkCallerIsHost(ctx context.Context, tenantID, uuid string) error {
+	callerID, err := callerClientID(ctx)
+	if err != nil {
+		zlog.InfraSec().InfraErr(err).Msgf("Failed to get the client ID of the caller")
+		return err
+	}
+	clientID, err := onboarding.GetClientID(ctx, tenantID, uuid)
+	if err != nil {
+		return inv_errors.Errorfc(codes.PermissionDenied, "No EN credentials found for host %s", uuid)
+	}
+	if callerID != clientID {
+		err = inv_errors.Errorfc(codes.PermissionDenied, "Caller is not the host %s", uuid)
+		zlog.InfraSec().InfraErr(err).Msgf("Client %s acting as another host", callerID)
+		return err
+	}
+	return nil
+}
+
+// tokenClaims are the claims of the bearer token of a request the onboarding manager checks the caller with.
+type tokenClaims struct {
+	AuthorizedParty string `json:"azp"`
+	RealmAccess     struct {
+		Roles []string `json:"roles"`
+	} `json:"realm_access"`
+}
+
+// callerClaims returns the claims of the bearer token of the request. The token itself is verified by RBAC.
+func callerClaims(ctx context.Context) (tokenClaims, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, authorization := range md.Get("authorization") {
+		token, found := strings.CutPrefix(authorization, "Bearer ")
+		if !found {
+			continue
+		}
+		parts := strings.Split(token, ".")
+		if len(parts) != 3 { //nolint:mnd // header, payload and signature
+			break
+		}
+		payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+		if err != nil {
+			break
+		}
+		var claims tokenClaims
+		if err := json.Unmarshal(payload, &claims); err != nil {
+			break
+		}
+		return claims, true
+	}
+	return tokenClaims{}, false
+}
+
+// callerClientID returns the client the bearer token of the request was issued to, i.e. its authorized party.
+func callerClientID(ctx context.Context) (string, error) {
+	claims, ok := callerClaims(ctx)
+	if !ok || claims.AuthorizedParty == "" {
+		return "", inv_errors.Errorfc(codes.Unauthenticated, "No client found in the bearer token")
+	}
+	return claims.AuthorizedParty, nil
+}
@@ -12,6 +12,7 @@ import (
 
 	google_rpc "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
 
 	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
 	inventoryv1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/inventory/v1"
@@ -25,6 +26,9 @@ import (
 	inv_status "github.com/open-edge-platform/infra-core/inventory/v2/pkg/status"
 	inv_tenant "github.com/open-edge-platform/infra-core/inventory/v2/pkg/tenant"
 	"github.com/open-edge-platform/infra-onboarding/dkam/pkg/config"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/attestation"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/handlers/southbound/nioguard"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/identity"
 	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/invclient"
 	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding"
 	onboarding_types "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding/types"
@@ -63,9 +67,25 @@ type (
 	NonInteractiveOnboardingService struct {
 		pb.UnimplementedNonInteractiveOnboardingServiceServer
 		InventoryClientService
+		guard    *nioguard.Guard
+		verifier *attestation.Verifier
+		identity *identity.Resolver
+		// awaitSlots bounds the Edge Nodes awaiting approval on their stream, nil disables server push.
+		awaitSlots chan struct{}
+		keepalive  time.Duration
 	}
+
+	// NonInteractiveOnboardingOption configures the NonInteractiveOnboardingService.
+	NonInteractiveOnboardingOption func(*NonInteractiveOnboardingService)
 )
 
+// WithGuard sets the guard used to track failed UUID/serial attempts and lock out abusive sources.
+func WithGuard(guard *nioguard.Guard) NonInteractiveOnboardingOption {
+	return func(s *NonInteractiveOnboardingService) {
+		s.guard = guard
+	}
+}
+
 // NewInteractiveOnboardingService to start the gRPC server - IO.
 func NewInteractiveOnboardingService(invClient *invclient.OnboardingInventoryClient,
 	inventoryAdr string, enableTracing bool,
@@ -111,9 +131,24 @@ func NewInteractiveOnboardingService(invClient *invclient.OnboardingInventoryCli
 	}, nil
 }
 
+// WithAttestationVerifier sets the verifier used for TPM attestation before client credentials are released.
+func WithAttestationVerifier(verifier *attestation.Verifier) NonInteractiveOnboardingOption {
+	return func(s *NonInteractiveOnboardingService) {
+		s.verifier = verifier
+	}
+}
+
+// WithIdentityResolver sets the resolver of the host identity policies of the tenants, used to match Edge Nodes
+// to hosts. Without it, identity.DefaultPolicy is enforced for all tenants.
+func WithIdentityResolver(resolver *identity.Resolver) NonInteractiveOnboardingOption {
+	return func(s *NonInteractiveOnboardingService) {
+		s.identity = resolver
+	}
+}
+
 // NewNonInteractiveOnboardingService to start the gRPC server - NIO.
 func NewNonInteractiveOnboardingService(invClient *invclient.OnboardingInventoryClient, inventoryAdr string,
-	enableTracing bool,
+	enableTracing bool, opts ...NonInteractiveOnboardingOption,
 ) (*NonInteractiveOnboardingService, error) {
 	if invClient == nil {
 		return nil, inv_errors.Errorf("invClient is nil in NonInteractiveOnboardingService")
@@ -134,12 +169,16 @@ func NewNonInteractiveOnboardingService(invClient *invclient.OnboardingInventory
 			return nil, inv_errors.Errorf("Unable to start onboarding inventory API server client %v", err)
 		}
 	}
-	return &NonInteractiveOnboardingService{
+	s := &NonInteractiveOnboardingService{
 		InventoryClientService: InventoryClientService{
 			invClient:    invClient,
 			invClientAPI: invClientAPI,
 		},
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
 }
 
 // CopyNodeReqToNodeData performs operations for onboarding management.
@@ -217,12 +256,14 @@ func (s *NonInteractiveOnboardingService) receiveFromStream(stream pb.NonInterac
 func (s *NonInteractiveOnboardingService) handleRegisteredState(stream pb.NonInteractiveOnboardingService_OnboardNodeStreamServer,
 	hostInv *computev1.HostResource, req *pb.OnboardNodeStreamRequest,
 ) error {
-	response := &pb.OnboardNodeStreamResponse{
-		Status:    &google_rpc.Status{Code: int32(codes.OK)},
-		NodeState: pb.OnboardNodeStreamResponse_NODE_STATE_REGISTERED,
-		ProjectId: hostInv.GetTenantId(),
-	}
-	if err := sendOnboardStreamResponse(stream, response); err != nil {
+	return s.registerHost(stream, hostInv, req, false)
+}
+
+// registerHost sends the REGISTERED response and records the registration of the host.
+func (s *NonInteractiveOnboardingService) registerHost(stream pb.NonInteractiveOnboardingService_OnboardNodeStreamServer,
+	hostInv *computev1.HostResource, req *pb.OnboardNodeStreamRequest, awaiting bool,
+) error {
+	if err := sendOnboardStreamResponse(stream, s.registeredResponse(hostInv, awaiting)); err != nil {
 		return err
 	}
 
@@ -237,6 +278,15 @@ func (s *NonInteractiveOnboardingService) handleRegisteredState(stream pb.NonInt
 		zlog.Error().Err(err).Msgf("Update failed for host resource id %v", hostInv.ResourceId)
 		return err
 	}
+
+	// the architecture selects the uOS, action and OS images the host is provisioned with
+	if arch := req.GetArchitecture(); arch != "" && arch != hostInv.GetCpuArchitecture() {
+		if err := s.invClient.SetHostCPUArchitecture(context.Background(),
+			hostInv.GetTenantId(), hostInv.GetResourceId(), arch); err != nil {
+			zlog.Error().Err(err).Msgf("Failed to record the CPU architecture of host resource id %v", hostInv.ResourceId)
+			return err
+		}
+	}
 	return nil
 }
 
@@ -277,6 +327,16 @@ func (s *NonInteractiveOnboardingService) handleOnboardedState(stream pb.NonInte
 	return nil
 }
 
+// onboardHost attests the host and releases its client credentials.
+func (s *NonInteractiveOnboardingService) onboardHost(stream pb.NonInteractiveOnboardingService_OnboardNodeStreamServer,
+	hostInv *computev1.HostResource, req *pb.OnboardNodeStreamRequest,
+) error {
+	if err := s.attestHost(stream, hostInv, req); err != nil {
+		return err
+	}
+	return s.handleOnboardedState(stream, hostInv, req)
+}
+
 // handleDefaultState processes the UNSPECIFIED state.
 func (s *NonInteractiveOnboardingService) handleDefaultState(
 	stream pb.NonInteractiveOnboardingService_OnboardNodeStreamServer,
@@ -290,6 +350,97 @@ func (s *NonInteractiveOnboardingService) handleDefaultState(
 	})
 }
 
+// attestHost verifies the TPM EK of the host and runs a credential activation challenge
+// before client credentials are released. Hosts without TPM evidence are let through
+// unless attestation is required by policy.
+func (s *NonInteractiveOnboardingService) attestHost(stream pb.NonInteractiveOnboardingService_OnboardNodeStreamServer,
+	hostInv *computev1.HostResource, req *pb.OnboardNodeStreamRequest,
+) error {
+	if !s.verifier.Enabled() {
+		return nil
+	}
+	hostPolicy, err := attestation.HostPolicyFromMetadata(hostInv.GetMetadata())
+	if err != nil {
+		zlog.InfraSec().InfraErr(err).Msgf("Failed to read TPM policy of host %s", hostInv.GetResourceId())
+		return s.rejectAttestation(stream, req, codes.Internal, "Failed to read TPM policy", err)
+	}
+	evidence := req.GetTpmAttestation()
+	if evidence == nil {
+		if s.verifier.Required(hostPolicy) {
+			return s.rejectAttestation(stream, req, codes.FailedPrecondition, "TPM attestation is required",
+				inv_errors.Errorfc(codes.FailedPrecondition, "TPM attestation is required for host %s", hostInv.GetResourceId()))
+		}
+		zlog.Debug().Msgf("Host %s presented no TPM evidence, attestation is not required", hostInv.GetResourceId())
+		return nil
+	}
+
+	ekPub, err := s.verifier.VerifyEK(attestation.Evidence{
+		EKCert: evidence.GetEkCert(),
+		EKPub:  evidence.GetEkPub(),
+		AKName: evidence.GetAkName(),
+	}, hostPolicy)
+	if err != nil {
+		return s.rejectAttestation(stream, req, codes.PermissionDenied, "TPM attestation failed", err)
+	}
+	challenge, err := attestation.NewChallenge(ekPub, evidence.GetAkName())
+	if err != nil {
+		return s.rejectAttestation(stream, req, codes.InvalidArgument, "TPM attestation failed", err)
+	}
+	if err := sendOnboardStreamResponse(stream, &pb.OnboardNodeStreamResponse{
+		Status:    &google_rpc.Status{Code: int32(codes.OK)},
+		NodeState: pb.OnboardNodeStreamResponse_NODE_STATE_ATTESTATION_CHALLENGE,
+		TpmChallenge: &pb.TpmChallenge{
+			CredentialBlob:  challenge.CredentialBlob,
+			EncryptedSecret: challenge.EncryptedSecret,
+		},
+	}); err != nil {
+		return err
+	}
+
+	answer, err := s.receiveFromStream(stream)
+	if err != nil {
+		return err
+	}
+	if !challenge.Verify(answer.GetTpmAttestation().GetActivatedSecret()) {
+		return s.rejectAttestation(stream, req, codes.PermissionDenied, "TPM attestation failed",
+			inv_errors.Errorfc(codes.PermissionDenied, "Credential activation failed for host %s", hostInv.GetResourceId()))
+	}
+	zlog.InfraSec().Info().Msgf("TPM attestation succeeded for host %s", hostInv.GetResourceId())
+	return nil
+}
+
+// rejectAttestation reports a failed TPM attestation to the Edge Node and returns err to close the stream.
+func (s *NonInteractiveOnboardingService) rejectAttestation(
+	stream pb.NonInteractiveOnboardingService_OnboardNodeStreamServer, req *pb.OnboardNodeStreamRequest,
+	code codes.Code, message string, err error,
+) error {
+	zlog.InfraSec().InfraErr(err).Msgf("TPM attestation rejected for UUID %s", req.GetUuid())
+	s.recordFailedAttempt(stream.Context(), req.GetUuid(), req.GetSerialnum())
+	if sendErr := sendStreamErrorResponse(stream, code, message); sendErr != nil {
+		return sendErr
+	}
+	return err
+}
+
+func (s *NonInteractiveOnboardingService) recordFailedAttempt(ctx context.Context, uuid, serial string) {
+	if s.guard == nil {
+		return
+	}
+	s.guard.RecordFailure(nioguard.SourceFromContext(ctx), attemptIdentity(uuid, serial))
+}
+
+func (s *NonInteractiveOnboardingService) recordSuccessfulAttempt(ctx context.Context, uuid, serial string) {
+	if s.guard == nil {
+		return
+	}
+	s.guard.RecordSuccess(nioguard.SourceFromContext(ctx), attemptIdentity(uuid, serial))
+}
+
+// attemptIdentity identifies a UUID/serial pair in the failed attempts recorded by the NIO guard.
+func attemptIdentity(uuid, serial string) string {
+	return fmt.Sprintf("uuid=%s serial=%s", uuid, serial)
+}
+
 func serialNumberValidationError(err error) bool {
 	var validationErr pb.OnboardNodeStreamRequestValidationError
 	if errors.As(err, &validationErr) {
@@ -300,138 +451,138 @@ func serialNumberValidationError(err error) bool {
 	return false
 }
 
-//nolint:cyclop,funlen // reason: function is long due to necessary logic; cyclomatic complexity is high due to necessary handling
-func (s *NonInteractiveOnboardingService) getHostResource(req *pb.OnboardNodeStreamRequest) (*computev1.HostResource, error) {
-	var hostResource *computev1.HostResource
-	var serialNumberMatch, uuidMatch bool
-	var hostResourceByUUID, hostResourceBySN *computev1.HostResource
-
-	// Check if UUID is provided
-	if req.Uuid != "" {
-		var errUUID error
-		hostResourceByUUID, errUUID = s.invClient.GetHostResource(context.Background(), computev1.HostResourceFieldUuid, req.Uuid)
-		if errUUID != nil {
-			if inv_errors.IsNotFound(errUUID) {
-				zlog.Debug().Msgf("Node doesn't exist for UUID: %v", req.Uuid)
-			} else {
-				zlog.Debug().Msgf("Error retrieving host resource by UUID: %v", req.Uuid)
-				return nil, inv_errors.Errorfc(codes.Internal, "Error retrieving host resource by UUID")
-			}
+// presentedIdentity returns the host identity presented in the request.
+func presentedIdentity(req *pb.OnboardNodeStreamRequest) identity.Identity {
+	presented := identity.Identity{
+		UUID:         req.GetUuid(),
+		SerialNumber: req.GetSerialnum(),
+		PXEMac:       req.GetMacId(),
+	}
+	if presented.SerialNumber == serialNumNotAvailable {
+		presented.SerialNumber = ""
+	}
+	if evidence := req.GetTpmAttestation(); len(evidence.GetEkCert()) > 0 || len(evidence.GetEkPub()) > 0 {
+		fingerprint, err := attestation.Evidence{EKCert: evidence.GetEkCert(), EKPub: evidence.GetEkPub()}.Fingerprint()
+		if err != nil {
+			zlog.Debug().Msgf("Ignoring presented TPM EK: %v", err)
 		} else {
-			uuidMatch = true
-			hostResource = hostResourceByUUID
-			zlog.Debug().Msgf("Node exists for UUID %v", req.Uuid)
-
-			// Check the associated serial number
-			if hostResource.SerialNumber == "" {
-				zlog.Debug().Msgf("Proceeding with registration for UUID %v with no Serial Number in inventory", req.Uuid)
-				return hostResource, nil
-			}
+			presented.EKFingerprint = fingerprint
 		}
 	}
+	return presented
+}
 
-	// Check if Serial Number is provided
-	if req.Serialnum != "" {
-		var errSN error
-		hostResourceBySN, errSN = s.invClient.GetHostResource(
-			context.Background(),
-			computev1.HostResourceFieldSerialNumber,
-			req.Serialnum,
-		)
-		if errSN != nil {
-			if inv_errors.IsNotFound(errSN) {
-				zlog.Debug().Msgf("Node doesn't exist for serial number: %v", req.Serialnum)
-			} else {
-				zlog.Debug().Msgf("Error retrieving host resource by serial number: %v", req.Serialnum)
-				return nil, inv_errors.Errorfc(codes.Internal, "Error retrieving host resource by serial number")
-			}
-		} else {
-			serialNumberMatch = true
-			if hostResource == nil {
-				hostResource = hostResourceBySN
-			}
-			zlog.Debug().Msgf("Node exists for serial number %v", req.Serialnum)
-
-			if hostResource.Uuid == "" {
-				hostResource.Uuid = req.Uuid
-				errUpdate := s.invClient.UpdateHostResource(context.Background(), hostResource.GetTenantId(), hostResource)
-				if errUpdate != nil {
-					zlog.Error().Err(errUpdate).Msgf("failed to updated the host resource uuid: %v", errUpdate)
-					return nil, inv_errors.Errorfc(codes.Internal, "failed to updated the host resource uuid")
-				}
-				zlog.Debug().Msgf("Proceeding with registration for Serial Number %v with no UUID in inventory",
-					req.Serialnum)
-				return hostResource, nil
-			}
-		}
-	} else if uuidMatch {
-		// If UUID is found and serial number is empty, proceed with UUID-based provisioning
-		zlog.Debug().Msgf("Proceeding with registration for UUID %v with empty Serial Number", req.Uuid)
-		return hostResource, nil
-	}
-
-	// Handle mismatches between the two resources
-	if uuidMatch && serialNumberMatch {
-		// Ensure both resources are not nil
-		if hostResourceByUUID != nil && hostResourceBySN != nil {
-			if hostResourceByUUID.ResourceId != hostResourceBySN.ResourceId {
-				zlog.Debug().Msgf("Mismatch: UUID %v and Serial Number %v refer to different resources", req.Uuid, req.Serialnum)
-				return nil, inv_errors.Errorfc(codes.InvalidArgument, "UUID and Serial Number refer to different resources")
-			}
-			// Set hostResource to one of them (either works)
-			hostResource = hostResourceByUUID // or hostResourceBySN, both are the same in this case
-		} else {
-			zlog.Debug().Msg("One of the resources is nil while checking for UUID and Serial Number match")
-			return nil, inv_errors.Errorfc(codes.Internal, "Error: One of the host resources is nil")
-		}
+// recordedIdentity returns the identity recorded in inventory for the host.
+func recordedIdentity(host *computev1.HostResource) identity.Identity {
+	recorded := identity.Identity{
+		UUID:         host.GetUuid(),
+		SerialNumber: host.GetSerialNumber(),
+		PXEMac:       host.GetPxeMac(),
+	}
+	if hostPolicy, err := attestation.HostPolicyFromMetadata(host.GetMetadata()); err == nil {
+		recorded.EKFingerprint = hostPolicy.EKFingerprint
 	}
+	return recorded
+}
 
-	// Handle cases based on matches found
-	if (uuidMatch && !serialNumberMatch) || (!uuidMatch && serialNumberMatch) {
-		var detail string
-		var status inv_status.ResourceStatus
-		var errorType string
+func hostResourceField(id identity.Identifier) string {
+	switch id {
+	case identity.UUID:
+		return computev1.HostResourceFieldUuid
+	case identity.SerialNumber:
+		return computev1.HostResourceFieldSerialNumber
+	case identity.PXEMac:
+		return computev1.HostResourceFieldPxeMac
+	default:
+		return ""
+	}
+}
 
-		if !serialNumberMatch {
-			detail = req.Serialnum
-			status = om_status.HostRegistrationSerialNumFailedWithDetails(detail)
-			errorType = computev1.HostResourceFieldSerialNumber
-			zlog.Error().Msgf("Node doesn't exist for serial number: %v", detail)
-		} else {
-			detail = req.Uuid
-			status = om_status.HostRegistrationUUIDFailedWithDetails(detail)
-			errorType = computev1.HostResourceFieldUuid
-			zlog.Debug().Msgf("Node doesn't exist for UUID: %v", detail)
-			zlog.Error().Msgf("Node doesn't exist for UUID")
+// getHostResource finds the host matching the identifiers presented by the Edge Node, according to the
+// host identity policy of its tenant.
+func (s *NonInteractiveOnboardingService) getHostResource(req *pb.OnboardNodeStreamRequest) (*computev1.HostResource, error) {
+	hostResource, presented, hosts, err := s.resolveHostResource(context.Background(), presentedIdentity(req))
+	var mismatch *identity.MismatchError
+	var ambiguous *identity.AmbiguousError
+	var conflict *identity.ConflictError
+	switch {
+	case errors.As(err, &mismatch):
+		zlog.Error().Msgf("Node doesn't exist for %s: %v", mismatch.Identifier, mismatch.Presented)
+		host := hosts[mismatch.ResourceID]
+		if updateErr := s.invClient.UpdateHostRegState(context.Background(), host.GetTenantId(),
+			host.GetResourceId(), host.GetCurrentState(), "", "", identifierMismatchStatus(mismatch),
+		); updateErr != nil {
+			return nil, updateErr
 		}
+		return nil, inv_errors.Errorfc(codes.NotFound, "Node doesn't exist for %s: %v", mismatch.Identifier, mismatch.Presented)
+	case errors.As(err, &ambiguous):
+		zlog.Error().Msgf("Ambiguous Edge Node identity %+v: %v", presented, ambiguous)
+		return nil, err
+	case errors.As(err, &conflict):
+		zlog.Debug().Msgf("Mismatch: %v", conflict)
+		return nil, err
+	case grpc_status.Code(err) == codes.InvalidArgument:
+		zlog.Info().Msgf("Rejecting Edge Node identity: %v", err)
+		return nil, err
+	case err != nil:
+		return nil, err
+	}
 
-		// Update host details if hostResource is not nil
-		if hostResource != nil {
-			if updateErr := s.invClient.UpdateHostRegState(context.Background(), hostResource.GetTenantId(),
-				hostResource.ResourceId, hostResource.CurrentState, "", "", status,
-			); updateErr != nil {
-				return nil, updateErr
-			}
+	if hostResource.GetUuid() == "" && presented.UUID != "" {
+		hostResource.Uuid = presented.UUID
+		if errUpdate := s.invClient.UpdateHostResource(context.Background(), hostResource.GetTenantId(),
+			hostResource); errUpdate != nil {
+			zlog.Error().Err(errUpdate).Msgf("failed to updated the host resource uuid: %v", errUpdate)
+			return nil, inv_errors.Errorfc(codes.Internal, "failed to updated the host resource uuid")
 		}
-		// Return a NotFound error with relevant details
-		return nil, inv_errors.Errorfc(codes.NotFound, "Node doesn't exist for %s: %v", errorType, detail)
+		zlog.Debug().Msgf("Proceeding with registration for Serial Number %v with no UUID in inventory",
+			presented.SerialNumber)
 	}
+	zlog.Debug().Msgf("Node exists for presented identity %+v", presented)
+	return hostResource, nil
+}
 
-	if uuidMatch && serialNumberMatch {
-		zlog.Debug().Msgf("Both UUID and Serial Number match: %v", hostResource)
-		return hostResource, nil // Return the matched host resource
+// resolveHostResource finds the host matching the presented identity without updating inventory, and returns it
+// with the presented identity validated by the policy of its tenant. It also returns the hosts found while
+// resolving, indexed by resource ID.
+func (s *NonInteractiveOnboardingService) resolveHostResource(ctx context.Context, presented identity.Identity) (
+	*computev1.HostResource, identity.Identity, map[string]*computev1.HostResource, error,
+) {
+	hosts := make(map[string]*computev1.HostResource)
+	resourceID, valid, err := s.identity.Resolve(ctx, presented,
+		func(id identity.Identifier, value string) ([]identity.Candidate, error) {
+			found, listErr := s.invClient.ListHostResources(ctx, hostResourceField(id), value)
+			if listErr != nil {
+				zlog.Debug().Msgf("Error retrieving host resources by %s: %v", id, value)
+				return nil, inv_errors.Errorfc(codes.Internal, "Error retrieving host resources by %s", id)
+			}
+			candidates := make([]identity.Candidate, 0, len(found))
+			for _, host := range found {
+				hosts[host.GetResourceId()] = host
+				candidates = append(candidates, identity.Candidate{
+					ResourceID: host.GetResourceId(),
+					TenantID:   host.GetTenantId(),
+					Identity:   recordedIdentity(host),
+				})
+			}
+			return candidates, nil
+		})
+	if err != nil {
+		return nil, valid, hosts, err
 	}
+	return hosts[resourceID], valid, hosts, nil
+}
 
-	// If both UUID and serial number are not found
-	if !uuidMatch && !serialNumberMatch {
-		zlog.Info().Msg("Device not found for provided UUID and Serial Number")
-		return nil, inv_errors.Errorfc(
-			codes.NotFound,
-			"Device not found for both UUID and Serial Number",
-		)
+// identifierMismatchStatus returns the registration status of a host whose identifier differs from the presented one.
+func identifierMismatchStatus(mismatch *identity.MismatchError) inv_status.ResourceStatus {
+	switch mismatch.Identifier {
+	case identity.UUID:
+		return om_status.HostRegistrationUUIDFailedWithDetails(mismatch.Presented)
+	case identity.SerialNumber:
+		return om_status.HostRegistrationSerialNumFailedWithDetails(mismatch.Presented)
+	default:
+		return om_status.HostRegistrationIdentifierFailedWithDetails(string(mismatch.Identifier), mismatch.Presented)
 	}
-
-	return hostResource, nil
 }
 
 // OnboardNodeStream performs operations for the receiver.
@@ -481,6 +632,19 @@ func (s *NonInteractiveOnboardingService) OnboardNodeStream(
 		// Retrieves the host resource based on UUID or Serial Number.
 		hostInv, err = s.getHostResource(req)
 		if err != nil {
+			if inv_errors.IsNotFound(err) || grpc_status.Code(err) == codes.InvalidArgument {
+				// unknown or mismatched UUID/serial number, count it towards the source lockout
+				s.recordFailedAttempt(stream.Context(), req.GetUuid(), req.GetSerialnum())
+			}
+			var ambiguous *identity.AmbiguousError
+			if errors.As(err, &ambiguous) {
+				return sendStreamErrorResponse(stream, codes.FailedPrecondition,
+					"Presented identifiers match more than one host")
+			}
+			if grpc_status.Code(err) == codes.InvalidArgument {
+				return sendStreamErrorResponse(stream, codes.InvalidArgument,
+					"Presented identifiers are invalid or refer to different hosts")
+			}
 			if inv_errors.IsNotFound(err) {
 				zlog.Error().Err(err).Msg("Device not found")
 				if errdevNotFound := sendStreamErrorResponse(stream, codes.NotFound,
@@ -499,6 +663,8 @@ func (s *NonInteractiveOnboardingService) OnboardNodeStream(
 			return nil // Close the stream
 		}
 
+		s.recordSuccessfulAttempt(stream.Context(), req.GetUuid(), req.GetSerialnum())
+
 		// 2. If the UUID is found but the current state is ONBOARDED,
 		// the OM sends a FAILED_PRECONDITION
 		if hostInv.CurrentState == computev1.HostState_HOST_STATE_ONBOARDED ||
@@ -522,6 +688,13 @@ func (s *NonInteractiveOnboardingService) OnboardNodeStream(
 		// Allow the EN to retry but do not close the stream.
 		// Assume SI initalially configure desiredstate as REGISTERED
 		case computev1.HostState_HOST_STATE_REGISTERED:
+			if req.GetAwaitApproval() && s.acquireAwaitSlot() {
+				// the EN waits on the stream and the approval is pushed to it
+				onboarded, err := s.awaitApproval(stream, hostInv, req)
+				s.releaseAwaitSlot()
+				startZeroTouchAfterClose = onboarded
+				return err
+			}
 			if err := s.handleRegisteredState(stream, hostInv, req); err != nil {
 				return err
 			}
@@ -535,7 +708,7 @@ func (s *NonInteractiveOnboardingService) OnboardNodeStream(
 				communicates with Keycloak to create EN secrets, sends a SUCCESS response
 				with the client_id and client_secret, and then returns nil, closing the stream
 			*/
-			if err := s.handleOnboardedState(stream, hostInv, req); err != nil {
+			if err := s.onboardHost(stream, hostInv, req); err != nil {
 				return err
 			}
 			startZeroTouchAfterClose = true
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package grpcserver
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	statusv1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/status/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding"
+	pb "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/api/onboardingmgr/v1"
+)
+
+// GetOnboardingStatus returns the onboarding and provisioning status of the host matching the identifiers
+// presented by the Edge Node, so that it can be displayed on the console of the Edge Node.
+// Unlike OnboardNodeStream, it never updates inventory.
+func (s *NonInteractiveOnboardingService) GetOnboardingStatus(ctx context.Context,
+	req *pb.GetOnboardingStatusRequest,
+) (*pb.GetOnboardingStatusResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "%v", err)
+	}
+
+	host, _, _, err := s.resolveHostResource(ctx, presentedIdentity(&pb.OnboardNodeStreamRequest{
+		Uuid:      req.GetUuid(),
+		Serialnum: req.GetSerialnum(),
+		MacId:     req.GetMacId(),
+	}))
+	if err != nil {
+		if inv_errors.IsNotFound(err) || grpc_status.Code(err) == codes.InvalidArgument {
+			s.recordFailedAttempt(ctx, req.GetUuid(), req.GetSerialnum())
+		}
+		return nil, err
+	}
+	s.recordSuccessfulAttempt(ctx, req.GetUuid(), req.GetSerialnum())
+
+	return s.onboardingStatus(ctx, host), nil
+}
+
+// onboardingStatus summarizes the status of the host and of its instance.
+func (s *NonInteractiveOnboardingService) onboardingStatus(ctx context.Context,
+	host *computev1.HostResource,
+) *pb.GetOnboardingStatusResponse {
+	instance := host.GetInstance()
+	resp := &pb.GetOnboardingStatusResponse{
+		CurrentState: host.GetCurrentState().String(),
+		DesiredState: host.GetDesiredState().String(),
+		AwaitingApproval: host.GetDesiredState() == computev1.HostState_HOST_STATE_REGISTERED &&
+			host.GetCurrentState() == computev1.HostState_HOST_STATE_REGISTERED,
+		RegistrationStatus: host.GetRegistrationStatus(),
+		OnboardingStatus:   host.GetOnboardingStatus(),
+		ProvisioningStatus: instance.GetProvisioningStatus(),
+	}
+
+	// the most advanced stage that failed is the most relevant to the Edge Node
+	switch {
+	case instance.GetProvisioningStatusIndicator() == statusv1.StatusIndication_STATUS_INDICATION_ERROR:
+		resp.LastError = instance.GetProvisioningStatus()
+	case host.GetOnboardingStatusIndicator() == statusv1.StatusIndication_STATUS_INDICATION_ERROR:
+		resp.LastError = host.GetOnboardingStatus()
+	case host.GetRegistrationStatusIndicator() == statusv1.StatusIndication_STATUS_INDICATION_ERROR:
+		resp.LastError = host.GetRegistrationStatus()
+	}
+
+	if instance != nil && host.GetUuid() != "" {
+		action, err := onboarding.GetWorkflowStatusDetail(ctx, host.GetUuid())
+		if err != nil {
+			// the status is still useful without the current action
+			zlog.Debug().Msgf("Failed to get the workflow status of host %s: %v", host.GetResourceId(), err)
+		}
+		resp.CurrentAction = action
+	}
+	return resp
+}
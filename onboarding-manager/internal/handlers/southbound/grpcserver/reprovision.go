@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package grpcserver
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	osv1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/os/v1"
+	statusv1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/status/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/policy/rbac"
+	inv_tenant "github.com/open-edge-platform/infra-core/inventory/v2/pkg/tenant"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding"
+	pb "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/api/onboardingmgr/v1"
+	om_status "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/status"
+)
+
+// onboardingRoleSuffix suffixes the role of the onboarding users of a tenant, "<tenant ID>_en-ob". Unlike the
+// en-agent-rw role of the EN credentials, it is not granted to the Edge Nodes.
+const onboardingRoleSuffix = "_en-ob"
+
+// ReprovisionInstance reinstalls a provisioned Instance with its current OS and configuration.
+// The previous workflow of the Host is invalidated and the Instance is handed back to the instance reconciler,
+// which runs a new production workflow and network boots the Host. The Host resource and the credentials
+// of the Edge Node are preserved. With authentication enabled, only the onboarding users of the tenant can
+// reprovision its Instances, not the Edge Nodes.
+func (s *InteractiveOnboardingService) ReprovisionInstance(ctx context.Context, req *pb.ReprovisionInstanceRequest) (
+	*pb.ReprovisionInstanceResponse, error,
+) {
+	zlog.Info().Msgf("ReprovisionInstance")
+
+	if err := req.Validate(); err != nil {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "%v", err)
+	}
+
+	if s.authEnabled {
+		// checking if JWT contains write permission
+		if !s.rbac.IsRequestAuthorized(ctx, rbac.CreateKey) {
+			err := inv_errors.Errorfc(codes.PermissionDenied, "Request is blocked by RBAC")
+			zlog.InfraSec().InfraErr(err).Msgf("Request ReprovisionInstance is not authenticated")
+			return nil, err
+		}
+	}
+
+	tenantID, present := inv_tenant.GetTenantIDFromContext(ctx)
+	if !present {
+		// This should never happen! Interceptor should either fail or set it!
+		err := inv_errors.Errorfc(codes.Unauthenticated, "Tenant ID is not present in context")
+		zlog.InfraSec().InfraErr(err).Msg("Request ReprovisionInstance is not authenticated")
+		return nil, err
+	}
+
+	if s.authEnabled {
+		if err := checkCallerIsOnboardingUser(ctx, tenantID); err != nil {
+			return nil, err
+		}
+	}
+
+	instance, err := s.invClient.GetInstanceResourceByResourceID(ctx, tenantID, req.GetResourceId())
+	if err != nil {
+		zlog.InfraSec().InfraErr(err).Msgf("Failed to get Instance %s, tID=%s", req.GetResourceId(), tenantID)
+		return nil, err
+	}
+	if err = checkReprovisionable(instance); err != nil {
+		return nil, err
+	}
+
+	// the workflow of the previous provisioning is normally deleted once it completes
+	if err = onboarding.DeleteTinkerbellWorkflowIfExists(ctx, instance.GetHost().GetUuid()); err != nil {
+		return nil, err
+	}
+
+	attempt, err := onboarding.NextReprovisionAttempt(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	inProgress, _, _ := om_status.ProvisioningStatuses(attempt)
+	if err = s.invClient.UpdateInstance(ctx, tenantID, instance.GetResourceId(),
+		computev1.InstanceState_INSTANCE_STATE_UNSPECIFIED, inProgress, instance.GetOs()); err != nil {
+		zlog.InfraSec().InfraErr(err).Msgf("Failed to update Instance %s, tID=%s", instance.GetResourceId(), tenantID)
+		return nil, err
+	}
+	zlog.InfraSec().Info().Msgf("Reprovisioning Instance %s of host %s, attempt %d, tID=%s",
+		instance.GetResourceId(), instance.GetHost().GetResourceId(), attempt, tenantID)
+
+	s.invClient.SendInternalEvent(tenantID, instance.GetResourceId())
+
+	return &pb.ReprovisionInstanceResponse{
+		ResourceId: instance.GetResourceId(),
+		Attempt:    uint32(attempt), // #nosec G115
+	}, nil
+}
+
+// checkReprovisionable returns an error if the Instance cannot be reprovisioned by the onboarding manager.
+// Provisioned Instances, and Instances whose provisioning failed, can be reprovisioned.
+func checkReprovisionable(instance *computev1.InstanceResource) error {
+	host := instance.GetHost()
+	switch {
+	case host.GetProvider() != nil:
+		return inv_errors.Errorfc(codes.FailedPrecondition, "Instance %s is managed by provider %s",
+			instance.GetResourceId(), host.GetProvider().GetName())
+	case instance.GetOs().GetOsProvider() != osv1.OsProviderKind_OS_PROVIDER_KIND_INFRA:
+		return inv_errors.Errorfc(codes.FailedPrecondition, "OS of Instance %s is not provisioned by the onboarding manager",
+			instance.GetResourceId())
+	case instance.GetDesiredState() != computev1.InstanceState_INSTANCE_STATE_RUNNING:
+		return inv_errors.Errorfc(codes.FailedPrecondition, "Instance %s is not desired to be running",
+			instance.GetResourceId())
+	case host.GetCurrentState() != computev1.HostState_HOST_STATE_ONBOARDED ||
+		host.GetDesiredState() != computev1.HostState_HOST_STATE_ONBOARDED:
+		return inv_errors.Errorfc(codes.FailedPrecondition, "Host %s of Instance %s is not onboarded",
+			host.GetResourceId(), instance.GetResourceId())
+	case instance.GetProvisioningStatusIndicator() == statusv1.StatusIndication_STATUS_INDICATION_IN_PROGRESS:
+		return inv_errors.Errorfc(codes.FailedPrecondition, "Instance %s is being provisioned", instance.GetResourceId())
+	case instance.GetCurrentState() == computev1.InstanceState_INSTANCE_STATE_RUNNING,
+		instance.GetProvisioningStatusIndicator() == statusv1.StatusIndication_STATUS_INDICATION_ERROR:
+		return nil
+	default:
+		return inv_errors.Errorfc(codes.FailedPrecondition, "Instance %s is not provisioned yet", instance.GetResourceId())
+	}
+}
+
+// checkCallerIsOnboardingUser verifies that the JWT of the request was issued to an onboarding user of the tenant.
+// The JWT itself is verified by RBAC.
+func checkCallerIsOnboardingUser(ctx context.Context, tenantID string) error {
+	claims, ok := callerClaims(ctx)
+	if !ok {
+		err := inv_errors.Errorfc(codes.Unauthenticated, "No claims found in the bearer token")
+		zlog.InfraSec().InfraErr(err).Msgf("Failed to get the roles of the caller")
+		return err
+	}
+	if !slices.Contains(claims.RealmAccess.Roles, tenantID+onboardingRoleSuffix) {
+		err := inv_errors.Errorfc(codes.PermissionDenied, "Caller is not an onboarding user of the tenant")
+		zlog.InfraSec().InfraErr(err).Msgf("Client %s reprovisioning an Instance, tID=%s",
+			claims.AuthorizedParty, tenantID)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,336 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package nioguard provides abuse protection for the non-interactive onboarding (NIO) server:
+// per-source and per-identity rate limiting, connection caps, exponential lockout after
+// repeated mismatched UUID/serial attempts and audit hooks for rejected attempts.
+package nioguard
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Default values for the NIO guard configuration.
+const (
+	DefaultRatePerSource     = 2.0
+	DefaultBurstPerSource    = 10
+	DefaultRatePerIdentity   = 0.5
+	DefaultBurstPerIdentity  = 5
+	DefaultMaxConns          = 1024
+	DefaultMaxConnsPerSource = 8
+	DefaultMaxStreamsPerSrc  = 4
+	DefaultLockoutThreshold  = 5
+	DefaultLockoutBase       = 30 * time.Second
+	DefaultLockoutMax        = 1 * time.Hour
+	DefaultEntryTTL          = 10 * time.Minute
+)
+
+const (
+	defaultSweepInterval      = 1 * time.Minute
+	lockoutMaxShift           = 16
+	unknownSource             = "unknown"
+	auditReasonRateSource     = "source rate limit exceeded"
+	auditReasonRateIdentity   = "identity rate limit exceeded"
+	auditReasonStreamCap      = "too many concurrent streams from source"
+	auditReasonConnCap        = "too many connections"
+	auditReasonConnCapSource  = "too many connections from source"
+	auditReasonLockedOut      = "source is locked out"
+	auditReasonFailedAttempt  = "failed identity match"
+	auditReasonLockoutStarted = "source locked out after repeated failed identity matches"
+)
+
+// Config defines the limits enforced by the Guard. A zero or negative value disables the related limit.
+type Config struct {
+	// RatePerSource is the number of requests per second allowed from a single source IP.
+	RatePerSource float64
+	// BurstPerSource is the burst size allowed from a single source IP.
+	BurstPerSource int
+	// RatePerIdentity is the number of requests per second allowed for a single UUID/serial number.
+	RatePerIdentity float64
+	// BurstPerIdentity is the burst size allowed for a single UUID/serial number.
+	BurstPerIdentity int
+	// MaxConns is the maximum number of concurrent connections accepted by the server.
+	MaxConns int
+	// MaxConnsPerSource is the maximum number of concurrent connections from a single source IP.
+	MaxConnsPerSource int
+	// MaxStreamsPerSource is the maximum number of concurrent streams from a single source IP.
+	MaxStreamsPerSource int
+	// LockoutThreshold is the number of consecutive failed identity matches after which a source is locked out.
+	LockoutThreshold int
+	// LockoutBase is the duration of the first lockout, doubled on every further failure.
+	LockoutBase time.Duration
+	// LockoutMax caps the lockout duration.
+	LockoutMax time.Duration
+	// EntryTTL is the idle time after which per-source and per-identity state is forgotten.
+	EntryTTL time.Duration
+}
+
+// DefaultConfig returns the default NIO guard configuration.
+func DefaultConfig() Config {
+	return Config{
+		RatePerSource:       DefaultRatePerSource,
+		BurstPerSource:      DefaultBurstPerSource,
+		RatePerIdentity:     DefaultRatePerIdentity,
+		BurstPerIdentity:    DefaultBurstPerIdentity,
+		MaxConns:            DefaultMaxConns,
+		MaxConnsPerSource:   DefaultMaxConnsPerSource,
+		MaxStreamsPerSource: DefaultMaxStreamsPerSrc,
+		LockoutThreshold:    DefaultLockoutThreshold,
+		LockoutBase:         DefaultLockoutBase,
+		LockoutMax:          DefaultLockoutMax,
+		EntryTTL:            DefaultEntryTTL,
+	}
+}
+
+// AuditEvent describes a rejected or suspicious onboarding attempt.
+type AuditEvent struct {
+	Source   string
+	Identity string
+	Reason   string
+	// LockedUntil is set when the source is (or has just been) locked out.
+	LockedUntil time.Time
+}
+
+// AuditFunc is invoked for every rejected or failed onboarding attempt.
+type AuditFunc func(AuditEvent)
+
+// Option configures a Guard.
+type Option func(*Guard)
+
+// WithClock sets the clock used by the Guard, mainly for testing.
+func WithClock(now func() time.Time) Option {
+	return func(g *Guard) {
+		g.now = now
+	}
+}
+
+// WithAuditFunc sets the function invoked for every audited event.
+func WithAuditFunc(audit AuditFunc) Option {
+	return func(g *Guard) {
+		g.audit = audit
+	}
+}
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+type lockoutEntry struct {
+	failures int
+	// identities counts the failures of each identity, so that a successful match only clears its own.
+	identities  map[string]int
+	lockedUntil time.Time
+	lastSeen    time.Time
+}
+
+// Guard tracks per-source and per-identity state for the NIO server.
+type Guard struct {
+	cfg   Config
+	now   func() time.Time
+	audit AuditFunc
+
+	mu         sync.Mutex
+	sources    map[string]*limiterEntry
+	identities map[string]*limiterEntry
+	lockouts   map[string]*lockoutEntry
+	streams    map[string]int
+	lastSweep  time.Time
+}
+
+// New creates a Guard enforcing the given configuration.
+func New(cfg Config, opts ...Option) *Guard {
+	g := &Guard{
+		cfg:        cfg,
+		now:        time.Now,
+		audit:      func(AuditEvent) {},
+		sources:    make(map[string]*limiterEntry),
+		identities: make(map[string]*limiterEntry),
+		lockouts:   make(map[string]*lockoutEntry),
+		streams:    make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Config returns the configuration enforced by the Guard.
+func (g *Guard) Config() Config {
+	return g.cfg
+}
+
+// AllowSource reports whether a new request from source is allowed.
+// Requests are rejected while the source is locked out or above its rate limit.
+func (g *Guard) AllowSource(source string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := g.now()
+	g.sweepLocked(now)
+
+	if until, locked := g.lockedUntilLocked(source, now); locked {
+		g.audit(AuditEvent{Source: source, Reason: auditReasonLockedOut, LockedUntil: until})
+		return false
+	}
+	if !g.allowLocked(g.sources, source, g.cfg.RatePerSource, g.cfg.BurstPerSource, now) {
+		g.audit(AuditEvent{Source: source, Reason: auditReasonRateSource})
+		return false
+	}
+	return true
+}
+
+// AllowIdentity reports whether a new request for the given identity (UUID or serial number) is allowed.
+func (g *Guard) AllowIdentity(source, identity string) bool {
+	if identity == "" {
+		return true
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := g.now()
+
+	if !g.allowLocked(g.identities, identity, g.cfg.RatePerIdentity, g.cfg.BurstPerIdentity, now) {
+		g.audit(AuditEvent{Source: source, Identity: identity, Reason: auditReasonRateIdentity})
+		return false
+	}
+	return true
+}
+
+// AcquireStream registers a new stream from source and reports whether it is within the per-source cap.
+// Every successful call must be paired with ReleaseStream.
+func (g *Guard) AcquireStream(source string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.cfg.MaxStreamsPerSource > 0 && g.streams[source] >= g.cfg.MaxStreamsPerSource {
+		g.audit(AuditEvent{Source: source, Reason: auditReasonStreamCap})
+		return false
+	}
+	g.streams[source]++
+	return true
+}
+
+// ReleaseStream releases a stream previously acquired with AcquireStream.
+func (g *Guard) ReleaseStream(source string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.streams[source]--
+	if g.streams[source] <= 0 {
+		delete(g.streams, source)
+	}
+}
+
+// RecordFailure records a failed (mismatched or unknown) UUID/serial attempt from source.
+// Once the lockout threshold is reached, the source is locked out for an exponentially growing duration.
+func (g *Guard) RecordFailure(source, identity string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := g.now()
+
+	entry, ok := g.lockouts[source]
+	if !ok {
+		entry = &lockoutEntry{identities: make(map[string]int)}
+		g.lockouts[source] = entry
+	}
+	entry.failures++
+	entry.identities[identity]++
+	entry.lastSeen = now
+	g.audit(AuditEvent{Source: source, Identity: identity, Reason: auditReasonFailedAttempt})
+
+	if g.cfg.LockoutThreshold <= 0 || entry.failures < g.cfg.LockoutThreshold {
+		return
+	}
+	entry.lockedUntil = now.Add(g.lockoutDuration(entry.failures - g.cfg.LockoutThreshold))
+	g.audit(AuditEvent{
+		Source:      source,
+		Identity:    identity,
+		Reason:      auditReasonLockoutStarted,
+		LockedUntil: entry.lockedUntil,
+	})
+}
+
+// RecordSuccess forgets the failures of identity from source after it matched successfully.
+// The failures of other identities are kept, so that interleaving a valid UUID/serial pair
+// with guesses does not avoid the lockout, and an ongoing lockout is not lifted.
+func (g *Guard) RecordSuccess(source, identity string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	entry, ok := g.lockouts[source]
+	if !ok {
+		return
+	}
+	entry.failures -= entry.identities[identity]
+	delete(entry.identities, identity)
+	if entry.failures == 0 && !g.now().Before(entry.lockedUntil) {
+		delete(g.lockouts, source)
+	}
+}
+
+// LockedUntil returns the time until which source is locked out, and whether it is currently locked out.
+func (g *Guard) LockedUntil(source string) (time.Time, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.lockedUntilLocked(source, g.now())
+}
+
+// Audit reports a rejected attempt through the configured audit function.
+func (g *Guard) Audit(event AuditEvent) {
+	g.audit(event)
+}
+
+func (g *Guard) lockoutDuration(excess int) time.Duration {
+	if excess > lockoutMaxShift {
+		excess = lockoutMaxShift
+	}
+	d := g.cfg.LockoutBase << excess
+	if g.cfg.LockoutMax > 0 && (d > g.cfg.LockoutMax || d <= 0) {
+		d = g.cfg.LockoutMax
+	}
+	return d
+}
+
+func (g *Guard) lockedUntilLocked(source string, now time.Time) (time.Time, bool) {
+	entry, ok := g.lockouts[source]
+	if !ok || !now.Before(entry.lockedUntil) {
+		return time.Time{}, false
+	}
+	return entry.lockedUntil, true
+}
+
+func (g *Guard) allowLocked(entries map[string]*limiterEntry, key string, r float64, burst int, now time.Time) bool {
+	if r <= 0 {
+		return true
+	}
+	entry, ok := entries[key]
+	if !ok {
+		entry = &limiterEntry{limiter: rate.NewLimiter(rate.Limit(r), max(burst, 1))}
+		entries[key] = entry
+	}
+	entry.lastSeen = now
+	return entry.limiter.AllowN(now, 1)
+}
+
+// sweepLocked drops idle state so that a scanner cycling through sources or identities
+// cannot grow the Guard's memory without bound.
+func (g *Guard) sweepLocked(now time.Time) {
+	if g.cfg.EntryTTL <= 0 || now.Sub(g.lastSweep) < defaultSweepInterval {
+		return
+	}
+	g.lastSweep = now
+	for key, entry := range g.sources {
+		if now.Sub(entry.lastSeen) > g.cfg.EntryTTL {
+			delete(g.sources, key)
+		}
+	}
+	for key, entry := range g.identities {
+		if now.Sub(entry.lastSeen) > g.cfg.EntryTTL {
+			delete(g.identities, key)
+		}
+	}
+	for key, entry := range g.lockouts {
+		if now.After(entry.lockedUntil) && now.Sub(entry.lastSeen) > g.cfg.EntryTTL {
+			delete(g.lockouts, key)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package nioguard_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/handlers/southbound/nioguard"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+type auditRecorder struct {
+	mu     sync.Mutex
+	events []nioguard.AuditEvent
+}
+
+func (a *auditRecorder) record(e nioguard.AuditEvent) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.events = append(a.events, e)
+}
+
+func (a *auditRecorder) len() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.events)
+}
+
+func newGuard(cfg nioguard.Config) (*nioguard.Guard, *fakeClock, *auditRecorder) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	audit := &auditRecorder{}
+	g := nioguard.New(cfg, nioguard.WithClock(clock.Now), nioguard.WithAuditFunc(audit.record))
+	return g, clock, audit
+}
+
+func TestGuard_AllowSource(t *testing.T) {
+	cfg := nioguard.Config{RatePerSource: 1, BurstPerSource: 3}
+	g, clock, audit := newGuard(cfg)
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, g.AllowSource("10.0.0.1"), "request %d within burst", i)
+	}
+	assert.False(t, g.AllowSource("10.0.0.1"))
+	assert.Equal(t, 1, audit.len())
+
+	// other sources have their own bucket
+	assert.True(t, g.AllowSource("10.0.0.2"))
+
+	clock.Advance(time.Second)
+	assert.True(t, g.AllowSource("10.0.0.1"))
+}
+
+func TestGuard_AllowIdentity(t *testing.T) {
+	cfg := nioguard.Config{RatePerIdentity: 1, BurstPerIdentity: 1}
+	g, clock, audit := newGuard(cfg)
+
+	assert.True(t, g.AllowIdentity("10.0.0.1", "uuid-1"))
+	// same identity from a different source is still limited
+	assert.False(t, g.AllowIdentity("10.0.0.2", "uuid-1"))
+	assert.True(t, g.AllowIdentity("10.0.0.2", "uuid-2"))
+	assert.True(t, g.AllowIdentity("10.0.0.2", ""))
+	require.Equal(t, 1, audit.len())
+	assert.Equal(t, "uuid-1", audit.events[0].Identity)
+
+	clock.Advance(time.Second)
+	assert.True(t, g.AllowIdentity("10.0.0.1", "uuid-1"))
+}
+
+func TestGuard_Lockout(t *testing.T) {
+	cfg := nioguard.Config{
+		LockoutThreshold: 3,
+		LockoutBase:      10 * time.Second,
+		LockoutMax:       30 * time.Second,
+	}
+	g, clock, _ := newGuard(cfg)
+	source := "10.0.0.1"
+
+	g.RecordFailure(source, "SN001")
+	g.RecordFailure(source, "SN002")
+	assert.True(t, g.AllowSource(source))
+
+	g.RecordFailure(source, "SN003")
+	until, locked := g.LockedUntil(source)
+	require.True(t, locked)
+	assert.Equal(t, clock.Now().Add(10*time.Second), until)
+	assert.False(t, g.AllowSource(source))
+	assert.True(t, g.AllowSource("10.0.0.2"))
+
+	clock.Advance(10 * time.Second)
+	assert.True(t, g.AllowSource(source))
+
+	// every further failure doubles the lockout, up to the maximum
+	g.RecordFailure(source, "SN004")
+	until, _ = g.LockedUntil(source)
+	assert.Equal(t, clock.Now().Add(20*time.Second), until)
+	g.RecordFailure(source, "SN005")
+	until, _ = g.LockedUntil(source)
+	assert.Equal(t, clock.Now().Add(30*time.Second), until)
+	for i := 0; i < 100; i++ {
+		g.RecordFailure(source, "SN006")
+	}
+	until, _ = g.LockedUntil(source)
+	assert.Equal(t, clock.Now().Add(30*time.Second), until)
+
+	// a successful match does not lift the lockout
+	g.RecordSuccess(source, "SN006")
+	_, locked = g.LockedUntil(source)
+	assert.True(t, locked)
+
+	// nor the failures of the other identities
+	clock.Advance(30 * time.Second)
+	g.RecordFailure(source, "SN007")
+	_, locked = g.LockedUntil(source)
+	assert.True(t, locked)
+}
+
+func TestGuard_LockoutInterleavedSuccess(t *testing.T) {
+	cfg := nioguard.Config{
+		LockoutThreshold: 3,
+		LockoutBase:      10 * time.Second,
+		LockoutMax:       30 * time.Second,
+	}
+	g, _, _ := newGuard(cfg)
+	source := "10.0.0.1"
+	valid := "uuid=uuid-1 serial=SN000"
+
+	// a valid pair interleaved with guesses does not reset the failures of the guesses
+	g.RecordFailure(source, "uuid=uuid-1 serial=SN001")
+	g.RecordSuccess(source, valid)
+	g.RecordFailure(source, "uuid=uuid-1 serial=SN002")
+	g.RecordSuccess(source, valid)
+	assert.True(t, g.AllowSource(source))
+	g.RecordFailure(source, "uuid=uuid-1 serial=SN003")
+	_, locked := g.LockedUntil(source)
+	assert.True(t, locked)
+	assert.False(t, g.AllowSource(source))
+
+	// a host that mistyped its own pair is forgiven once it matches
+	other := "10.0.0.2"
+	g.RecordFailure(other, valid)
+	g.RecordFailure(other, valid)
+	g.RecordSuccess(other, valid)
+	g.RecordFailure(other, "uuid=uuid-2 serial=SN004")
+	g.RecordFailure(other, "uuid=uuid-2 serial=SN005")
+	_, locked = g.LockedUntil(other)
+	assert.False(t, locked)
+}
+
+func TestGuard_StreamCap(t *testing.T) {
+	g, _, audit := newGuard(nioguard.Config{MaxStreamsPerSource: 2})
+
+	assert.True(t, g.AcquireStream("10.0.0.1"))
+	assert.True(t, g.AcquireStream("10.0.0.1"))
+	assert.False(t, g.AcquireStream("10.0.0.1"))
+	assert.True(t, g.AcquireStream("10.0.0.2"))
+	assert.Equal(t, 1, audit.len())
+
+	g.ReleaseStream("10.0.0.1")
+	assert.True(t, g.AcquireStream("10.0.0.1"))
+}
+
+func TestGuard_UnaryServerInterceptor(t *testing.T) {
+	g, _, _ := newGuard(nioguard.Config{RatePerIdentity: 1, BurstPerIdentity: 1})
+	interceptor := g.UnaryServerInterceptor(func(msg any) string {
+		identity, _ := msg.(string)
+		return identity
+	})
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	resp, err := interceptor(context.Background(), "uuid-1", &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = interceptor(context.Background(), "uuid-1", &grpc.UnaryServerInfo{}, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestLimitListener(t *testing.T) {
+	lis, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	g, _, audit := newGuard(nioguard.Config{MaxConns: 10, MaxConnsPerSource: 1})
+	limited := nioguard.LimitListener(lis, g)
+	defer limited.Close()
+
+	accepted := make(chan net.Conn, 2)
+	go func() {
+		for {
+			conn, acceptErr := limited.Accept()
+			if acceptErr != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	dialer := &net.Dialer{}
+	first, err := dialer.DialContext(context.Background(), "tcp", lis.Addr().String())
+	require.NoError(t, err)
+	defer first.Close()
+	serverSide := <-accepted
+
+	// the second connection from the same source is closed by the server
+	second, err := dialer.DialContext(context.Background(), "tcp", lis.Addr().String())
+	require.NoError(t, err)
+	defer second.Close()
+	require.NoError(t, second.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = second.Read(make([]byte, 1))
+	require.Error(t, err)
+	assert.Eventually(t, func() bool { return audit.len() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// closing the first connection frees its slot
+	require.NoError(t, serverSide.Close())
+	third, err := dialer.DialContext(context.Background(), "tcp", lis.Addr().String())
+	require.NoError(t, err)
+	defer third.Close()
+	select {
+	case conn := <-accepted:
+		conn.Close()
+	case <-time.After(5 * time.Second):
+		t.Fatal("connection was not accepted after a slot was released")
+	}
+}
+
+func TestNewServerTLSConfig(t *testing.T) {
+	_, err := nioguard.NewServerTLSConfig(nioguard.TLSConfig{CertPath: "cert.pem"})
+	require.Error(t, err)
+
+	_, err = nioguard.NewServerTLSConfig(nioguard.TLSConfig{CertPath: "missing.pem", KeyPath: "missing.key"})
+	require.Error(t, err)
+
+	assert.False(t, nioguard.TLSConfig{}.Enabled())
+	assert.True(t, nioguard.TLSConfig{CertPath: "cert.pem", KeyPath: "key.pem"}.Enabled())
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package nioguard
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+)
+
+// IdentityFunc extracts the device identity (UUID or serial number) from a received message.
+// It returns an empty string when the message carries no identity.
+type IdentityFunc func(msg any) string
+
+// SourceFromContext returns the source IP of the gRPC peer stored in ctx.
+func SourceFromContext(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return unknownSource
+	}
+	return sourceFromAddr(p.Addr)
+}
+
+// StreamServerInterceptor enforces the per-source stream cap, the lockout and the rate limits
+// both when a stream is opened and for every message received on it.
+func (g *Guard) StreamServerInterceptor(identity IdentityFunc) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		source := SourceFromContext(ss.Context())
+		if !g.AcquireStream(source) {
+			return inv_errors.Errorfc(codes.ResourceExhausted, "too many concurrent streams")
+		}
+		defer g.ReleaseStream(source)
+
+		if !g.AllowSource(source) {
+			return inv_errors.Errorfc(codes.ResourceExhausted, "too many requests")
+		}
+		return handler(srv, &guardedStream{ServerStream: ss, guard: g, source: source, identity: identity})
+	}
+}
+
+// UnaryServerInterceptor enforces the lockout and the rate limits for unary calls.
+func (g *Guard) UnaryServerInterceptor(identity IdentityFunc) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		source := SourceFromContext(ctx)
+		if !g.AllowSource(source) {
+			return nil, inv_errors.Errorfc(codes.ResourceExhausted, "too many requests")
+		}
+		if identity != nil && !g.AllowIdentity(source, identity(req)) {
+			return nil, inv_errors.Errorfc(codes.ResourceExhausted, "too many requests for device")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// guardedStream applies the Guard to every message received on a stream.
+type guardedStream struct {
+	grpc.ServerStream
+	guard    *Guard
+	source   string
+	identity IdentityFunc
+	received bool
+}
+
+// RecvMsg receives a message and rejects it if the source or identity is over its limits.
+func (s *guardedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	// the first message is already accounted for when the stream was opened
+	if s.received && !s.guard.AllowSource(s.source) {
+		return inv_errors.Errorfc(codes.ResourceExhausted, "too many requests")
+	}
+	s.received = true
+	if s.identity != nil && !s.guard.AllowIdentity(s.source, s.identity(m)) {
+		return inv_errors.Errorfc(codes.ResourceExhausted, "too many requests for device")
+	}
+	return nil
+}
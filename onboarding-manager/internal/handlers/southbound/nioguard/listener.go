@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package nioguard
+
+import (
+	"net"
+	"sync"
+)
+
+// limitListener wraps a net.Listener enforcing the total and per-source connection caps of a Guard.
+type limitListener struct {
+	net.Listener
+	guard *Guard
+
+	mu      sync.Mutex
+	total   int
+	sources map[string]int
+}
+
+// LimitListener returns a listener that closes incoming connections above the
+// MaxConns and MaxConnsPerSource limits of the Guard before any TLS or gRPC work is done.
+func LimitListener(lis net.Listener, g *Guard) net.Listener {
+	return &limitListener{
+		Listener: lis,
+		guard:    g,
+		sources:  make(map[string]int),
+	}
+}
+
+// Accept waits for and returns the next connection within the configured caps.
+func (l *limitListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		source := sourceFromAddr(conn.RemoteAddr())
+		if reason, ok := l.acquire(source); !ok {
+			l.guard.Audit(AuditEvent{Source: source, Reason: reason})
+			//nolint:errcheck // connection is rejected, nothing to do on close failure
+			conn.Close()
+			continue
+		}
+		return &limitConn{Conn: conn, release: func() { l.release(source) }}, nil
+	}
+}
+
+func (l *limitListener) acquire(source string) (string, bool) {
+	cfg := l.guard.Config()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if cfg.MaxConns > 0 && l.total >= cfg.MaxConns {
+		return auditReasonConnCap, false
+	}
+	if cfg.MaxConnsPerSource > 0 && l.sources[source] >= cfg.MaxConnsPerSource {
+		return auditReasonConnCapSource, false
+	}
+	l.total++
+	l.sources[source]++
+	return "", true
+}
+
+func (l *limitListener) release(source string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.total--
+	l.sources[source]--
+	if l.sources[source] <= 0 {
+		delete(l.sources, source)
+	}
+}
+
+// limitConn releases its slot in the limitListener exactly once when closed.
+type limitConn struct {
+	net.Conn
+	once    sync.Once
+	release func()
+}
+
+// Close closes the connection and releases its slot.
+func (c *limitConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(c.release)
+	return err
+}
+
+func sourceFromAddr(addr net.Addr) string {
+	if addr == nil {
+		return unknownSource
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package nioguard
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+
+	"google.golang.org/grpc/codes"
+
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+)
+
+// Client certificate modes supported by the NIO server.
+const (
+	ClientAuthNone     = "none"
+	ClientAuthOptional = "optional"
+	ClientAuthRequire  = "require"
+)
+
+// TLSConfig defines the NIO server TLS settings.
+type TLSConfig struct {
+	CertPath     string
+	KeyPath      string
+	ClientCAPath string
+	// ClientAuth is one of ClientAuthNone, ClientAuthOptional or ClientAuthRequire.
+	ClientAuth string
+}
+
+// Enabled reports whether server TLS is configured.
+func (c TLSConfig) Enabled() bool {
+	return c.CertPath != "" || c.KeyPath != ""
+}
+
+// NewServerTLSConfig loads the server certificate and, if set, the client CA bundle used to
+// verify client certificates.
+func NewServerTLSConfig(c TLSConfig) (*tls.Config, error) {
+	if c.CertPath == "" || c.KeyPath == "" {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "Both TLS certificate and key paths must be set")
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertPath, c.KeyPath)
+	if err != nil {
+		return nil, inv_errors.Errorf("Failed to load TLS key pair: %v", err)
+	}
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		ClientAuth:   tls.NoClientCert,
+	}
+
+	switch c.ClientAuth {
+	case "", ClientAuthNone:
+		return tlsCfg, nil
+	case ClientAuthOptional:
+		tlsCfg.ClientAuth = tls.VerifyClientCertIfGiven
+	case ClientAuthRequire:
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "Unsupported client auth mode %q", c.ClientAuth)
+	}
+
+	if c.ClientCAPath == "" {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument,
+			"Client CA path must be set for client auth mode %q", c.ClientAuth)
+	}
+	caPEM, err := os.ReadFile(c.ClientCAPath)
+	if err != nil {
+		return nil, inv_errors.Errorf("Failed to read client CA: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "No valid certificates found in client CA")
+	}
+	tlsCfg.ClientCAs = pool
+	return tlsCfg, nil
+}
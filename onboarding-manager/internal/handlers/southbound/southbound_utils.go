@@ -6,16 +6,22 @@ package southbound
 import (
 	"context"
 	"net"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/logging"
 	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/metrics"
 	inv_tenant "github.com/open-edge-platform/infra-core/inventory/v2/pkg/tenant"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/attestation"
 	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/handlers/southbound/grpcserver"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/handlers/southbound/nioguard"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/identity"
 	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/invclient"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding"
 	pb "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/api/onboardingmgr/v1"
 )
 
@@ -42,6 +48,18 @@ type SBHandlerNioConfig struct {
 	ServerAddressNio string
 	InventoryAddress string
 	EnableTracing    bool
+	// TLS enables server TLS and, optionally, client certificate verification.
+	TLS nioguard.TLSConfig
+	// Guard defines rate limits, connection caps and lockout; zero values disable the related limit.
+	Guard nioguard.Config
+	// TPMAttestationPolicy is one of disabled, optional or required; empty disables attestation.
+	TPMAttestationPolicy attestation.Policy
+	// TPMManufacturerCABundle is a PEM bundle of TPM manufacturer CAs used to verify EK certificates.
+	TPMManufacturerCABundle string
+	// MaxAwaitingStreams bounds the Edge Nodes awaiting approval on their stream; zero disables server push.
+	MaxAwaitingStreams int
+	// KeepaliveInterval is the interval of keepalives sent to Edge Nodes awaiting approval.
+	KeepaliveInterval time.Duration
 }
 
 // SBNioHandler provides functionality for onboarding management.
@@ -110,7 +128,7 @@ func (sbh *SBHandler) Start() error {
 	if sbh.cfg.EnableMetrics {
 		// Register metrics
 		srvMetrics.InitializeMetrics(sbh.server)
-		metrics.StartMetricsExporter([]prometheus.Collector{cliMetrics, srvMetrics},
+		metrics.StartMetricsExporter([]prometheus.Collector{cliMetrics, srvMetrics, onboarding.ProvisioningFailures},
 			metrics.WithListenAddress(sbh.cfg.MetricsAddress))
 	}
 	// Run go routine to start the gRPC server.
@@ -156,12 +174,42 @@ func NewSBNioHandlerWithListener(listener net.Listener,
 // Start performs operations for the receiver.
 // start SB Nio server.
 func (sbhnio *SBNioHandler) Start() error {
+	guard := nioguard.New(sbhnio.cfg.Guard, nioguard.WithAuditFunc(auditNioAttempt))
+	opts := []grpcserver.NonInteractiveOnboardingOption{
+		grpcserver.WithGuard(guard),
+		grpcserver.WithIdentityResolver(identity.NewResolver(sbhnio.invClient)),
+	}
+	if sbhnio.cfg.TPMAttestationPolicy != "" {
+		verifier, verifierErr := attestation.NewVerifier(sbhnio.cfg.TPMAttestationPolicy, sbhnio.cfg.TPMManufacturerCABundle)
+		if verifierErr != nil {
+			return verifierErr
+		}
+		opts = append(opts, grpcserver.WithAttestationVerifier(verifier))
+		zlog.InfraSec().Info().Msgf("SB NIO handler TPM attestation policy: %s", sbhnio.cfg.TPMAttestationPolicy)
+	}
+	if sbhnio.cfg.MaxAwaitingStreams > 0 {
+		opts = append(opts, grpcserver.WithServerPush(sbhnio.cfg.MaxAwaitingStreams, sbhnio.cfg.KeepaliveInterval))
+	}
 	interactiveOnboardingService, err := grpcserver.NewNonInteractiveOnboardingService(sbhnio.invClient,
-		sbhnio.cfg.InventoryAddress, sbhnio.cfg.EnableTracing)
+		sbhnio.cfg.InventoryAddress, sbhnio.cfg.EnableTracing, opts...)
 	if err != nil {
 		return err
 	}
-	var srvOpts []grpc.ServerOption
+	srvOpts := []grpc.ServerOption{
+		grpc.ChainStreamInterceptor(guard.StreamServerInterceptor(nioRequestIdentity)),
+		grpc.ChainUnaryInterceptor(guard.UnaryServerInterceptor(nioRequestIdentity)),
+	}
+	if sbhnio.cfg.TLS.Enabled() {
+		tlsCfg, tlsErr := nioguard.NewServerTLSConfig(sbhnio.cfg.TLS)
+		if tlsErr != nil {
+			return tlsErr
+		}
+		srvOpts = append(srvOpts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+		zlog.InfraSec().Info().Msgf("SB NIO handler TLS enabled, client auth: %s", sbhnio.cfg.TLS.ClientAuth)
+	} else {
+		zlog.InfraSec().Warn().Msgf("SB NIO handler TLS is disabled")
+	}
+	sbhnio.lis = nioguard.LimitListener(sbhnio.lis, guard)
 	sbhnio.server = grpc.NewServer(srvOpts...)
 	pb.RegisterNonInteractiveOnboardingServiceServer(sbhnio.server, interactiveOnboardingService)
 	// Register reflection service on gRPC server.
@@ -182,3 +230,30 @@ func (sbhnio *SBNioHandler) Stop() {
 	sbhnio.server.Stop()
 	zlog.InfraSec().Info().Msgf("SB NIO handler stopped")
 }
+
+// nioRequestIdentity returns the device identity used for per-identity rate limiting.
+func nioRequestIdentity(msg any) string {
+	req, ok := msg.(interface {
+		GetUuid() string
+		GetSerialnum() string
+	})
+	if !ok {
+		return ""
+	}
+	if req.GetUuid() != "" {
+		return req.GetUuid()
+	}
+	return req.GetSerialnum()
+}
+
+// auditNioAttempt logs rejected and failed attempts on the NIO server.
+func auditNioAttempt(event nioguard.AuditEvent) {
+	logEvent := zlog.InfraSec().Warn().
+		Str("source", event.Source).
+		Str("identity", event.Identity).
+		Str("reason", event.Reason)
+	if !event.LockedUntil.IsZero() {
+		logEvent = logEvent.Time("lockedUntil", event.LockedUntil)
+	}
+	logEvent.Msg("SB NIO onboarding attempt rejected")
+}
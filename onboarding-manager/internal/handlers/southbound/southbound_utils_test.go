@@ -24,6 +24,7 @@ import (
 	inv_testing "github.com/open-edge-platform/infra-core/inventory/v2/pkg/testing"
 	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/util"
 	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/handlers/southbound"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/handlers/southbound/nioguard"
 	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/invclient"
 	om_testing "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/testing"
 	pb "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/api/onboardingmgr/v1"
@@ -296,3 +297,15 @@ func TestSBNioHandler_Start(t *testing.T) {
 		t.Errorf("sbNioHandler.Start() = %v", startErr)
 	}
 }
+
+func TestSBNioHandler_StartWithInvalidTLS(t *testing.T) {
+	sbNioHandler, err := southbound.NewSBNioHandler(om_testing.InvClient, southbound.SBHandlerNioConfig{
+		TLS: nioguard.TLSConfig{
+			CertPath: "missing-cert.pem",
+			KeyPath:  "missing-key.pem",
+		},
+		Guard: nioguard.DefaultConfig(),
+	})
+	require.NoError(t, err)
+	require.Error(t, sbNioHandler.Start())
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package hwprofile resolves the hardware profile the hardware-preflight action checks a host against before its
+// disks are erased, e.g.
+//
+//	{"cpuFlags":["vmx|svm","aes"],"minMemoryMiB":8192,"tpm2":true,"secureBoot":true,"minDiskSizeGiB":64}
+//
+// The fields of the profile are validated by the action, see tinker-actions/src/hardware_preflight.
+package hwprofile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc/codes"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/invclient"
+	onboarding_types "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding/types"
+)
+
+// MetadataKey is the key of the OS metadata holding the hardware profile the OS requires, as a JSON string.
+const MetadataKey = "hardwareprofile"
+
+// FromOSMetadata returns the hardware profile in OS metadata, a JSON object of strings.
+func FromOSMetadata(metadata string) (string, error) {
+	if metadata == "" {
+		return "", nil
+	}
+	var values map[string]string
+	if err := json.Unmarshal([]byte(metadata), &values); err != nil {
+		return "", inv_errors.Errorfc(codes.InvalidArgument, "Failed to parse OS metadata: %v", err)
+	}
+	return compact(values[MetadataKey])
+}
+
+// FromProviderConfig returns the hardware profile in the configuration of a tenant provider, a JSON object whose
+// "hardwareProfile" field is the profile of all the hosts of the tenant.
+func FromProviderConfig(config string) (string, error) {
+	if config == "" {
+		return "", nil
+	}
+	var pconf struct {
+		HardwareProfile json.RawMessage `json:"hardwareProfile"`
+	}
+	if err := json.Unmarshal([]byte(config), &pconf); err != nil {
+		return "", inv_errors.Errorfc(codes.InvalidArgument, "Failed to parse provider configuration: %v", err)
+	}
+	if len(pconf.HardwareProfile) == 0 || string(pconf.HardwareProfile) == "null" {
+		return "", nil
+	}
+	return compact(string(pconf.HardwareProfile))
+}
+
+// compact returns a hardware profile on a single line, for it to be passed in an environment variable.
+func compact(profile string) (string, error) {
+	if profile == "" {
+		return "", nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(profile), &fields); err != nil {
+		return "", inv_errors.Errorfc(codes.InvalidArgument, "Invalid hardware profile %q: expected a JSON object", profile)
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(profile)); err != nil {
+		return "", inv_errors.Errorfc(codes.InvalidArgument, "Invalid hardware profile %q: %v", profile, err)
+	}
+	return buf.String(), nil
+}
+
+// ProviderConfigFunc returns the raw configuration of the provider of a tenant.
+type ProviderConfigFunc func(ctx context.Context, tenantID string) (string, error)
+
+// Resolver resolves the hardware profiles of Instances: the profile of their OS if it has one, otherwise the
+// profile of the provider configuration of their tenant.
+type Resolver struct {
+	providerConfig ProviderConfigFunc
+}
+
+// NewResolver returns a Resolver reading the provider configurations from inventory.
+func NewResolver(c *invclient.OnboardingInventoryClient) *Resolver {
+	return NewResolverWithProviderConfig(func(ctx context.Context, tenantID string) (string, error) {
+		provider, err := invclient.GetProviderResourceByName(ctx, tenantID, c, onboarding_types.DefaultProviderName)
+		if err != nil {
+			return "", err
+		}
+		return provider.GetConfig(), nil
+	})
+}
+
+// NewResolverWithProviderConfig returns a Resolver reading the provider configurations with providerConfig.
+func NewResolverWithProviderConfig(providerConfig ProviderConfigFunc) *Resolver {
+	return &Resolver{providerConfig: providerConfig}
+}
+
+// Profile returns the hardware profile of an Instance as compact JSON, empty if the hardware is not checked.
+func (r *Resolver) Profile(ctx context.Context, instance *computev1.InstanceResource) (string, error) {
+	osProfile, err := FromOSMetadata(instance.GetOs().GetMetadata())
+	if err != nil || osProfile != "" {
+		return osProfile, err
+	}
+
+	config, err := r.providerConfig(ctx, instance.GetTenantId())
+	if inv_errors.IsNotFound(err) {
+		// tenants without provider have no hardware profile
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return FromProviderConfig(config)
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package hwprofile_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	osv1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/os/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/hwprofile"
+)
+
+func TestFromOSMetadata(t *testing.T) {
+	profile, err := hwprofile.FromOSMetadata("")
+	require.NoError(t, err)
+	assert.Empty(t, profile)
+
+	profile, err = hwprofile.FromOSMetadata(`{"kernelversion":"6.12"}`)
+	require.NoError(t, err)
+	assert.Empty(t, profile)
+
+	profile, err = hwprofile.FromOSMetadata(`{"hardwareprofile":"{ \"tpm2\": true,\n \"minMemoryMiB\": 8192 }"}`)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"tpm2":true,"minMemoryMiB":8192}`, profile)
+	assert.NotContains(t, profile, "\n")
+
+	for _, metadata := range []string{
+		`{"hardwareprofile":"tpm2"}`,
+		`{"hardwareprofile":"[\"tpm2\"]"}`,
+		`[{"key":"hardwareprofile","value":"{}"}]`,
+	} {
+		_, err = hwprofile.FromOSMetadata(metadata)
+		assert.Equal(t, codes.InvalidArgument, grpc_status.Code(err), metadata)
+	}
+}
+
+func TestResolver_Profile(t *testing.T) {
+	providerConfigs := map[string]string{
+		"tenant-profile":    `{"defaultOs":"os-12345678","hardwareProfile":{"minDisks":2}}`,
+		"tenant-no-profile": `{"defaultOs":"os-12345678","autoProvision":true}`,
+		"tenant-null":       `{"hardwareProfile":null}`,
+		"tenant-invalid":    `{"hardwareProfile":"minDisks=2"}`,
+	}
+	resolver := hwprofile.NewResolverWithProviderConfig(func(_ context.Context, tenantID string) (string, error) {
+		config, ok := providerConfigs[tenantID]
+		if !ok {
+			return "", inv_errors.Errorfc(codes.NotFound, "no provider")
+		}
+		return config, nil
+	})
+	instance := func(tenantID, osMetadata string) *computev1.InstanceResource {
+		return &computev1.InstanceResource{
+			TenantId: tenantID,
+			Os:       &osv1.OperatingSystemResource{Metadata: osMetadata},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		instance *computev1.InstanceResource
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "OS profile",
+			instance: instance("tenant-profile", `{"hardwareprofile":"{\"tpm2\":true}"}`),
+			want:     `{"tpm2":true}`,
+		},
+		{
+			name:     "Tenant profile",
+			instance: instance("tenant-profile", `{"kernelversion":"6.12"}`),
+			want:     `{"minDisks":2}`,
+		},
+		{
+			name:     "No profile",
+			instance: instance("tenant-no-profile", ""),
+		},
+		{
+			name:     "Null profile",
+			instance: instance("tenant-null", ""),
+		},
+		{
+			name:     "No provider",
+			instance: instance("tenant-unknown", ""),
+		},
+		{
+			name:     "Invalid OS profile",
+			instance: instance("tenant-profile", `{"hardwareprofile":"tpm2"}`),
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid tenant profile",
+			instance: instance("tenant-invalid", ""),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := resolver.Profile(context.Background(), tt.instance)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, profile)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MismatchError reports that the only host found for the presented identity has a different
+// value recorded for one of the matched identifiers.
+type MismatchError struct {
+	ResourceID string
+	Identifier Identifier
+	Presented  string
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("host %s does not match the presented %s %q", e.ResourceID, e.Identifier, e.Presented)
+}
+
+// GRPCStatus returns a NotFound status, as no host matches the presented identity.
+func (e *MismatchError) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
+// ConflictError reports that the presented identifiers point to different hosts.
+type ConflictError struct {
+	ResourceIDs []string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("presented identifiers refer to different hosts: %s", strings.Join(e.ResourceIDs, ", "))
+}
+
+// GRPCStatus returns an InvalidArgument status.
+func (e *ConflictError) GRPCStatus() *status.Status {
+	return status.New(codes.InvalidArgument, e.Error())
+}
+
+// AmbiguousError reports that more than one host matches the presented identity.
+type AmbiguousError struct {
+	ResourceIDs []string
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("presented identifiers match %d hosts: %s", len(e.ResourceIDs), strings.Join(e.ResourceIDs, ", "))
+}
+
+// GRPCStatus returns a FailedPrecondition status, the hosts must be disambiguated in inventory.
+func (e *AmbiguousError) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
@@ -0,0 +1,355 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package identity matches the identifiers presented by an Edge Node during non-interactive onboarding
+// against the hosts registered in inventory, according to the host identity policy of their tenant.
+package identity
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/logging"
+)
+
+var zlog = logging.GetLogger("HostIdentity")
+
+// Identifier names a host identifier.
+type Identifier string
+
+const (
+	// UUID is the SMBIOS system UUID.
+	UUID Identifier = "uuid"
+	// SerialNumber is the SMBIOS system serial number.
+	SerialNumber Identifier = "serial"
+	// PXEMac is the MAC address of the interface the Edge Node booted from.
+	PXEMac Identifier = "pxe-mac"
+	// TPMEK is the SHA-256 fingerprint of the TPM endorsement key. It cannot be used to look up hosts,
+	// only to cross-check them, and possession of the EK is proven by TPM attestation.
+	TPMEK Identifier = "tpm-ek"
+)
+
+// identifiers lists the supported identifiers, in the order hosts are looked up.
+var identifiers = []Identifier{UUID, SerialNumber, PXEMac, TPMEK}
+
+// Lookupable reports whether hosts can be looked up in inventory by the identifier.
+func (id Identifier) Lookupable() bool {
+	return id != TPMEK
+}
+
+// Policy defines how the identifiers presented by an Edge Node are validated and matched.
+type Policy struct {
+	// Required lists the identifiers the Edge Node must present with a valid value. Required identifiers
+	// are also matched.
+	Required []Identifier `json:"required"`
+	// Match lists the identifiers used to look up and cross-check hosts.
+	Match []Identifier `json:"match"`
+	// Patterns maps identifiers to the regular expression their normalized value must match.
+	// Values that do not match are ignored, as if they were not presented.
+	Patterns map[Identifier]string `json:"patterns"`
+	// Bogus maps identifiers to known placeholder values set by vendors, compared case-insensitively.
+	// Bogus values are ignored, as if they were not presented.
+	Bogus map[Identifier][]string `json:"bogus"`
+}
+
+// DefaultPolicy returns the policy used when none is configured. It matches hosts by UUID and serial number
+// and keeps the historical serial number format.
+func DefaultPolicy() Policy {
+	return Policy{
+		Match: []Identifier{UUID, SerialNumber},
+		Patterns: map[Identifier]string{
+			SerialNumber: `^[A-Za-z0-9]{5,20}$`,
+		},
+		Bogus: map[Identifier][]string{
+			UUID: {
+				"00000000-0000-0000-0000-000000000000",
+				"ffffffff-ffff-ffff-ffff-ffffffffffff",
+				"03000200-0400-0500-0006-000700080009",
+			},
+			SerialNumber: {
+				"To Be Filled By O.E.M.",
+				"To be filled by O.E.M",
+				"Default string",
+				"System Serial Number",
+				"Chassis Serial Number",
+				"Not Specified",
+				"Not Applicable",
+				"None",
+				"N/A",
+				"0",
+				"0123456789",
+			},
+		},
+	}
+}
+
+// FromProviderConfig returns the host identity policy in the configuration of a tenant provider, a JSON object
+// whose "hostIdentityPolicy" field is the policy of all the hosts of the tenant. Fields missing from it keep their
+// DefaultPolicy value, patterns and bogus values are overridden per identifier.
+func FromProviderConfig(config string) (Policy, error) {
+	policy := DefaultPolicy()
+	if config == "" {
+		return policy, nil
+	}
+	var pconf struct {
+		HostIdentityPolicy json.RawMessage `json:"hostIdentityPolicy"`
+	}
+	if err := json.Unmarshal([]byte(config), &pconf); err != nil {
+		return policy, inv_errors.Errorfc(codes.InvalidArgument, "Failed to parse provider configuration: %v", err)
+	}
+	if len(pconf.HostIdentityPolicy) == 0 || string(pconf.HostIdentityPolicy) == "null" {
+		return policy, nil
+	}
+	if err := json.Unmarshal(pconf.HostIdentityPolicy, &policy); err != nil {
+		return policy, inv_errors.Errorfc(codes.InvalidArgument, "Failed to parse host identity policy: %v", err)
+	}
+	return policy, nil
+}
+
+// Identity holds the identifiers of a host, either presented by an Edge Node or recorded in inventory.
+type Identity struct {
+	UUID          string
+	SerialNumber  string
+	PXEMac        string
+	EKFingerprint string
+}
+
+// Get returns the value of the identifier.
+func (i Identity) Get(id Identifier) string {
+	switch id {
+	case UUID:
+		return i.UUID
+	case SerialNumber:
+		return i.SerialNumber
+	case PXEMac:
+		return i.PXEMac
+	case TPMEK:
+		return i.EKFingerprint
+	default:
+		return ""
+	}
+}
+
+func (i *Identity) set(id Identifier, value string) {
+	switch id {
+	case UUID:
+		i.UUID = value
+	case SerialNumber:
+		i.SerialNumber = value
+	case PXEMac:
+		i.PXEMac = value
+	case TPMEK:
+		i.EKFingerprint = value
+	}
+}
+
+// Normalize returns the identity with all identifiers in their canonical form.
+func (i Identity) Normalize() Identity {
+	var n Identity
+	for _, id := range identifiers {
+		n.set(id, normalize(id, i.Get(id)))
+	}
+	return n
+}
+
+func normalize(id Identifier, value string) string {
+	value = strings.TrimSpace(value)
+	switch id {
+	case UUID, TPMEK:
+		return strings.ToLower(value)
+	case PXEMac:
+		return strings.ToLower(strings.ReplaceAll(value, "-", ":"))
+	default:
+		return value
+	}
+}
+
+// Candidate is a host found in inventory for one of the presented identifiers.
+type Candidate struct {
+	ResourceID string
+	TenantID   string
+	// Identity is the identity recorded for the host, empty identifiers are not yet known.
+	Identity Identity
+}
+
+// LookupFunc returns the hosts whose identifier id equals value.
+type LookupFunc func(id Identifier, value string) ([]Candidate, error)
+
+// Matcher enforces a host identity policy.
+type Matcher struct {
+	required []Identifier
+	match    []Identifier
+	patterns map[Identifier]*regexp.Regexp
+	bogus    map[Identifier][]string
+}
+
+var defaultMatcher = func() *Matcher {
+	m, err := NewMatcher(DefaultPolicy())
+	if err != nil {
+		panic(err)
+	}
+	return m
+}()
+
+// NewMatcher validates policy and creates a Matcher enforcing it.
+func NewMatcher(policy Policy) (*Matcher, error) {
+	m := &Matcher{
+		patterns: make(map[Identifier]*regexp.Regexp),
+		bogus:    make(map[Identifier][]string),
+	}
+	for _, id := range slices.Concat(policy.Required, policy.Match) {
+		if !slices.Contains(identifiers, id) {
+			return nil, inv_errors.Errorfc(codes.InvalidArgument, "Unsupported host identifier %q", id)
+		}
+	}
+	m.required = slices.Clone(policy.Required)
+	// identifiers are always matched in lookup order, whatever the order in the policy
+	for _, id := range identifiers {
+		if slices.Contains(policy.Match, id) || slices.Contains(policy.Required, id) {
+			m.match = append(m.match, id)
+		}
+	}
+	if !slices.ContainsFunc(m.match, Identifier.Lookupable) {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument,
+			"Host identity policy must match on at least one of %s, %s or %s", UUID, SerialNumber, PXEMac)
+	}
+	for id, pattern := range policy.Patterns {
+		if !slices.Contains(identifiers, id) {
+			return nil, inv_errors.Errorfc(codes.InvalidArgument, "Unsupported host identifier %q", id)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, inv_errors.Errorfc(codes.InvalidArgument, "Invalid pattern for host identifier %s: %v", id, err)
+		}
+		m.patterns[id] = re
+	}
+	for id, values := range policy.Bogus {
+		if !slices.Contains(identifiers, id) {
+			return nil, inv_errors.Errorfc(codes.InvalidArgument, "Unsupported host identifier %q", id)
+		}
+		for _, value := range values {
+			m.bogus[id] = append(m.bogus[id], normalize(id, value))
+		}
+	}
+	return m, nil
+}
+
+// Validate normalizes the presented identity and drops identifiers that are bogus or do not match
+// their pattern. It fails if a required identifier is missing afterwards.
+func (m *Matcher) Validate(presented Identity) (Identity, error) {
+	if m == nil {
+		m = defaultMatcher
+	}
+	valid := presented.Normalize()
+	for _, id := range identifiers {
+		value := valid.Get(id)
+		if value == "" {
+			continue
+		}
+		if reason := m.reject(id, value); reason != "" {
+			zlog.Info().Msgf("Ignoring presented %s %q: %s", id, value, reason)
+			valid.set(id, "")
+		}
+	}
+	for _, id := range m.required {
+		if valid.Get(id) == "" {
+			return valid, inv_errors.Errorfc(codes.InvalidArgument, "Required host identifier %s is missing or invalid", id)
+		}
+	}
+	return valid, nil
+}
+
+func (m *Matcher) reject(id Identifier, value string) string {
+	if slices.ContainsFunc(m.bogus[id], func(bogus string) bool { return strings.EqualFold(bogus, value) }) {
+		return "known placeholder value"
+	}
+	if re, ok := m.patterns[id]; ok && !re.MatchString(value) {
+		return fmt.Sprintf("does not match %q", re)
+	}
+	return ""
+}
+
+// Resolve finds the single host matching the presented identity, validated with Validate.
+// Hosts are looked up by each matched identifier, and a host is a match only if none of its
+// recorded identifiers differ from the presented ones. It returns a NotFound error if no host is found,
+// a *MismatchError if the only host found differs, a *ConflictError if the identifiers point to different
+// hosts and an *AmbiguousError if more than one host matches.
+func (m *Matcher) Resolve(presented Identity, lookup LookupFunc) (string, error) {
+	if m == nil {
+		m = defaultMatcher
+	}
+	var candidates []Candidate
+	for _, id := range m.match {
+		value := presented.Get(id)
+		if value == "" || !id.Lookupable() {
+			continue
+		}
+		found, err := lookup(id, value)
+		if err != nil {
+			return "", err
+		}
+		for _, candidate := range found {
+			if !slices.ContainsFunc(candidates, func(c Candidate) bool { return c.ResourceID == candidate.ResourceID }) {
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return "", inv_errors.Errorfc(codes.NotFound, "No host found for the presented identifiers")
+	}
+
+	var matches []string
+	var mismatch *MismatchError
+	for _, candidate := range candidates {
+		if id, ok := m.mismatch(presented, candidate, candidates); ok {
+			mismatch = &MismatchError{ResourceID: candidate.ResourceID, Identifier: id, Presented: presented.Get(id)}
+			continue
+		}
+		matches = append(matches, candidate.ResourceID)
+	}
+
+	switch {
+	case len(matches) == 1:
+		return matches[0], nil
+	case len(matches) > 1:
+		return "", &AmbiguousError{ResourceIDs: matches}
+	case len(candidates) == 1:
+		return "", mismatch
+	default:
+		ids := make([]string, 0, len(candidates))
+		for _, candidate := range candidates {
+			ids = append(ids, candidate.ResourceID)
+		}
+		return "", &ConflictError{ResourceIDs: ids}
+	}
+}
+
+// mismatch returns the first matched identifier whose recorded value differs from the presented one.
+// An identifier not recorded for the candidate also differs if another candidate has it recorded.
+func (m *Matcher) mismatch(presented Identity, candidate Candidate, candidates []Candidate) (Identifier, bool) {
+	recorded := candidate.Identity.Normalize()
+	for _, id := range m.match {
+		value := presented.Get(id)
+		if value == "" {
+			continue
+		}
+		if want := recorded.Get(id); want != "" {
+			if value != want {
+				return id, true
+			}
+			continue
+		}
+		if slices.ContainsFunc(candidates, func(other Candidate) bool {
+			return other.ResourceID != candidate.ResourceID && other.Identity.Normalize().Get(id) == value
+		}) {
+			return id, true
+		}
+	}
+	return "", false
+}
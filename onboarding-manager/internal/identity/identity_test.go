@@ -0,0 +1,358 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package identity_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/identity"
+)
+
+const (
+	uuid1 = "44414747-3031-3052-b030-453347474122"
+	uuid2 = "44414747-3031-3052-b030-453347474166"
+)
+
+// inventory is a fake host inventory indexed by resource ID.
+type inventory map[string]identity.Identity
+
+func (inv inventory) lookup(id identity.Identifier, value string) ([]identity.Candidate, error) {
+	var found []identity.Candidate
+	for resourceID, recorded := range inv {
+		if recorded.Normalize().Get(id) == value {
+			found = append(found, identity.Candidate{ResourceID: resourceID, Identity: recorded})
+		}
+	}
+	return found, nil
+}
+
+func TestMatcher_Validate(t *testing.T) {
+	m, err := identity.NewMatcher(identity.DefaultPolicy())
+	require.NoError(t, err)
+
+	valid, err := m.Validate(identity.Identity{
+		UUID:         " 44414747-3031-3052-B030-453347474122 ",
+		SerialNumber: "To Be Filled By O.E.M.",
+		PXEMac:       "AA-BB-CC-DD-EE-FF",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, identity.Identity{UUID: uuid1, PXEMac: "aa:bb:cc:dd:ee:ff"}, valid)
+
+	// the default policy keeps the historical serial number format
+	valid, err = m.Validate(identity.Identity{UUID: "03000200-0400-0500-0006-000700080009", SerialNumber: "SN-0001"})
+	require.NoError(t, err)
+	assert.Equal(t, identity.Identity{}, valid)
+
+	// a nil matcher enforces the default policy
+	var nilMatcher *identity.Matcher
+	valid, err = nilMatcher.Validate(identity.Identity{SerialNumber: "ABCDEFG"})
+	require.NoError(t, err)
+	assert.Equal(t, "ABCDEFG", valid.SerialNumber)
+}
+
+func TestMatcher_ValidateRequired(t *testing.T) {
+	policy := identity.DefaultPolicy()
+	policy.Required = []identity.Identifier{identity.SerialNumber, identity.PXEMac}
+	policy.Patterns[identity.SerialNumber] = `^[A-Za-z0-9-]{5,32}$`
+	m, err := identity.NewMatcher(policy)
+	require.NoError(t, err)
+
+	valid, err := m.Validate(identity.Identity{SerialNumber: "SN-0001", PXEMac: "aa:bb:cc:dd:ee:ff"})
+	require.NoError(t, err)
+	assert.Equal(t, "SN-0001", valid.SerialNumber)
+
+	_, err = m.Validate(identity.Identity{SerialNumber: "Default string", PXEMac: "aa:bb:cc:dd:ee:ff"})
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, grpc_status.Code(err))
+
+	_, err = m.Validate(identity.Identity{SerialNumber: "SN-0001"})
+	require.Error(t, err)
+}
+
+func TestNewMatcher_InvalidPolicy(t *testing.T) {
+	_, err := identity.NewMatcher(identity.Policy{Match: []identity.Identifier{"mac"}})
+	require.Error(t, err)
+
+	_, err = identity.NewMatcher(identity.Policy{Match: []identity.Identifier{identity.TPMEK}})
+	require.Error(t, err)
+
+	_, err = identity.NewMatcher(identity.Policy{
+		Match:    []identity.Identifier{identity.UUID},
+		Patterns: map[identity.Identifier]string{identity.SerialNumber: "("},
+	})
+	require.Error(t, err)
+}
+
+func TestMatcher_Resolve(t *testing.T) {
+	policy := identity.DefaultPolicy()
+	policy.Match = append(policy.Match, identity.PXEMac, identity.TPMEK)
+	m, err := identity.NewMatcher(policy)
+	require.NoError(t, err)
+
+	inv := inventory{
+		"host-1": {UUID: uuid1, SerialNumber: "ABCDEFG"},
+		"host-2": {SerialNumber: "HIJKLMN", EKFingerprint: "abcd"},
+		"host-3": {UUID: uuid2, PXEMac: "AA:BB:CC:DD:EE:FF"},
+	}
+
+	tests := []struct {
+		name      string
+		presented identity.Identity
+		want      string
+		check     func(t *testing.T, err error)
+	}{
+		{
+			name:      "uuid and serial",
+			presented: identity.Identity{UUID: uuid1, SerialNumber: "ABCDEFG"},
+			want:      "host-1",
+		},
+		{
+			name:      "identifiers of different hosts",
+			presented: identity.Identity{UUID: uuid2, SerialNumber: "HIJKLMN", EKFingerprint: "abcd"},
+			check: func(t *testing.T, err error) {
+				t.Helper()
+				var conflict *identity.ConflictError
+				require.ErrorAs(t, err, &conflict)
+				assert.ElementsMatch(t, []string{"host-2", "host-3"}, conflict.ResourceIDs)
+				assert.Equal(t, codes.InvalidArgument, grpc_status.Code(err))
+			},
+		},
+		{
+			name:      "serial with unknown uuid",
+			presented: identity.Identity{UUID: "44414747-3031-3052-b030-453347474100", SerialNumber: "HIJKLMN"},
+			want:      "host-2",
+		},
+		{
+			name:      "pinned EK differs",
+			presented: identity.Identity{SerialNumber: "HIJKLMN", EKFingerprint: "ef01"},
+			check: func(t *testing.T, err error) {
+				t.Helper()
+				var mismatch *identity.MismatchError
+				require.ErrorAs(t, err, &mismatch)
+				assert.Equal(t, identity.MismatchError{ResourceID: "host-2", Identifier: identity.TPMEK, Presented: "ef01"},
+					*mismatch)
+				assert.True(t, inv_errors.IsNotFound(err))
+			},
+		},
+		{
+			name:      "serial differs",
+			presented: identity.Identity{UUID: uuid1, SerialNumber: "OTHERSN"},
+			check: func(t *testing.T, err error) {
+				t.Helper()
+				var mismatch *identity.MismatchError
+				require.ErrorAs(t, err, &mismatch)
+				assert.Equal(t, identity.SerialNumber, mismatch.Identifier)
+			},
+		},
+		{
+			name:      "pxe mac",
+			presented: identity.Identity{PXEMac: "aa:bb:cc:dd:ee:ff"},
+			want:      "host-3",
+		},
+		{
+			name:      "unknown host",
+			presented: identity.Identity{UUID: "44414747-3031-3052-b030-453347474100"},
+			check: func(t *testing.T, err error) {
+				t.Helper()
+				assert.True(t, inv_errors.IsNotFound(err))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.Resolve(tt.presented, inv.lookup)
+			if tt.check != nil {
+				tt.check(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatcher_ResolveAmbiguous(t *testing.T) {
+	m, err := identity.NewMatcher(identity.DefaultPolicy())
+	require.NoError(t, err)
+
+	// whitebox servers often share the same UUID
+	inv := inventory{
+		"host-1": {UUID: uuid1, SerialNumber: "ABCDEFG"},
+		"host-2": {UUID: uuid1, SerialNumber: "HIJKLMN"},
+		"host-3": {UUID: uuid1},
+	}
+
+	// the serial number disambiguates, host-3 cannot have the serial number recorded for host-1
+	got, err := m.Resolve(identity.Identity{UUID: uuid1, SerialNumber: "ABCDEFG"}, inv.lookup)
+	require.NoError(t, err)
+	assert.Equal(t, "host-1", got)
+
+	inv["host-4"] = identity.Identity{UUID: uuid1}
+	_, err = m.Resolve(identity.Identity{UUID: uuid1, SerialNumber: "OPQRSTU"}, inv.lookup)
+	var ambiguous *identity.AmbiguousError
+	require.ErrorAs(t, err, &ambiguous)
+	assert.ElementsMatch(t, []string{"host-3", "host-4"}, ambiguous.ResourceIDs)
+	assert.Equal(t, codes.FailedPrecondition, grpc_status.Code(err))
+
+	lookupErr := errors.New("inventory unavailable")
+	_, err = m.Resolve(identity.Identity{UUID: uuid1}, func(identity.Identifier, string) ([]identity.Candidate, error) {
+		return nil, lookupErr
+	})
+	require.ErrorIs(t, err, lookupErr)
+}
+
+func TestFromProviderConfig(t *testing.T) {
+	policy, err := identity.FromProviderConfig(`{
+		"defaultOs": "os-12345678",
+		"hostIdentityPolicy": {
+			"required": ["serial"],
+			"patterns": {"serial": "^[A-Za-z0-9-]{5,32}$"}
+		}
+	}`)
+	require.NoError(t, err)
+	assert.Equal(t, []identity.Identifier{identity.SerialNumber}, policy.Required)
+	assert.Equal(t, identity.DefaultPolicy().Match, policy.Match)
+	assert.Equal(t, "^[A-Za-z0-9-]{5,32}$", policy.Patterns[identity.SerialNumber])
+	assert.Equal(t, identity.DefaultPolicy().Bogus, policy.Bogus)
+
+	for _, config := range []string{"", `{"defaultOs":"os-12345678"}`, `{"hostIdentityPolicy":null}`} {
+		policy, err = identity.FromProviderConfig(config)
+		require.NoError(t, err)
+		assert.Equal(t, identity.DefaultPolicy(), policy)
+	}
+
+	_, err = identity.FromProviderConfig(`{"hostIdentityPolicy":{"required":"serial"}}`)
+	assert.Equal(t, codes.InvalidArgument, grpc_status.Code(err))
+	_, err = identity.FromProviderConfig(`not json`)
+	assert.Equal(t, codes.InvalidArgument, grpc_status.Code(err))
+}
+
+// tenantInventory is a fake host inventory of several tenants, indexed by tenant then resource ID.
+type tenantInventory map[string]inventory
+
+func (inv tenantInventory) lookup(id identity.Identifier, value string) ([]identity.Candidate, error) {
+	var found []identity.Candidate
+	for tenantID, hosts := range inv {
+		candidates, err := hosts.lookup(id, value)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range candidates {
+			candidate.TenantID = tenantID
+			found = append(found, candidate)
+		}
+	}
+	return found, nil
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	providerConfigs := map[string]string{
+		// whitebox servers with dashes in their serial number and no usable UUID
+		"tenant-whitebox": `{"hostIdentityPolicy":{"required":["serial"],"match":["serial"],` +
+			`"patterns":{"serial":"^[A-Za-z0-9-]{5,32}$"}}}`,
+		"tenant-default": `{"defaultOs":"os-12345678"}`,
+		"tenant-invalid": `{"hostIdentityPolicy":{"match":["mac"]}}`,
+	}
+	resolver := identity.NewResolverWithProviderConfig(func(_ context.Context, tenantID string) (string, error) {
+		config, ok := providerConfigs[tenantID]
+		if !ok {
+			return "", inv_errors.Errorfc(codes.NotFound, "no provider")
+		}
+		return config, nil
+	})
+	inv := tenantInventory{
+		"tenant-whitebox": {"host-1": {UUID: uuid1, SerialNumber: "WB-0001"}},
+		"tenant-default":  {"host-2": {UUID: uuid2, SerialNumber: "ABCDEFG"}},
+		"tenant-none":     {"host-3": {SerialNumber: "HIJKLMN"}},
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		presented identity.Identity
+		want      string
+		wantValid identity.Identity
+		wantCode  codes.Code
+	}{
+		{
+			name:      "Tenant policy",
+			presented: identity.Identity{UUID: uuid1, SerialNumber: "WB-0001"},
+			want:      "host-1",
+			// the UUID is not matched by the policy of the tenant, but is kept
+			wantValid: identity.Identity{UUID: uuid1, SerialNumber: "WB-0001"},
+		},
+		{
+			name:      "Default policy of the provider configuration",
+			presented: identity.Identity{UUID: strings.ToUpper(uuid2), SerialNumber: "ABCDEFG"},
+			want:      "host-2",
+			wantValid: identity.Identity{UUID: uuid2, SerialNumber: "ABCDEFG"},
+		},
+		{
+			name:      "Default policy without provider",
+			presented: identity.Identity{UUID: "00000000-0000-0000-0000-000000000000", SerialNumber: "HIJKLMN"},
+			want:      "host-3",
+			wantValid: identity.Identity{SerialNumber: "HIJKLMN"},
+		},
+		{
+			name:      "Serial number allowed by the tenant policy only",
+			presented: identity.Identity{SerialNumber: "WB-0001"},
+			want:      "host-1",
+			wantValid: identity.Identity{SerialNumber: "WB-0001"},
+		},
+		{
+			name:      "Required identifier of the tenant missing",
+			presented: identity.Identity{UUID: uuid1},
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:      "No host",
+			presented: identity.Identity{SerialNumber: "OPQRSTU"},
+			wantCode:  codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, valid, err := resolver.Resolve(ctx, tt.presented, inv.lookup)
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, grpc_status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantValid, valid)
+		})
+	}
+
+	// hosts of different tenants sharing the same serial number
+	inv["tenant-default"]["host-4"] = identity.Identity{SerialNumber: "HIJKLMN"}
+	_, _, err := resolver.Resolve(ctx, identity.Identity{SerialNumber: "HIJKLMN"}, inv.lookup)
+	var ambiguous *identity.AmbiguousError
+	require.ErrorAs(t, err, &ambiguous)
+	assert.Equal(t, []string{"host-4", "host-3"}, ambiguous.ResourceIDs)
+
+	// the presented UUID differs from the one recorded for the only host with the presented serial number
+	_, _, err = resolver.Resolve(ctx, identity.Identity{UUID: uuid1, SerialNumber: "ABCDEFG"}, inv.lookup)
+	var mismatch *identity.MismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, "host-2", mismatch.ResourceID)
+
+	inv["tenant-invalid"] = inventory{"host-5": {SerialNumber: "VWXYZAB"}}
+	_, _, err = resolver.Resolve(ctx, identity.Identity{SerialNumber: "VWXYZAB"}, inv.lookup)
+	assert.Equal(t, codes.InvalidArgument, grpc_status.Code(err))
+
+	// a nil Resolver enforces the default policy
+	got, _, err := (*identity.Resolver)(nil).Resolve(ctx, identity.Identity{UUID: uuid2}, inv.lookup)
+	require.NoError(t, err)
+	assert.Equal(t, "host-2", got)
+}
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package identity
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/invclient"
+	onboarding_types "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding/types"
+)
+
+// ProviderConfigFunc returns the raw configuration of the provider of a tenant.
+type ProviderConfigFunc func(ctx context.Context, tenantID string) (string, error)
+
+// Resolver matches Edge Nodes to hosts according to the host identity policy of the provider configuration
+// of the tenant of each host, DefaultPolicy if it has none. A nil Resolver enforces DefaultPolicy for all tenants.
+type Resolver struct {
+	providerConfig ProviderConfigFunc
+}
+
+// NewResolver returns a Resolver reading the provider configurations from inventory.
+func NewResolver(c *invclient.OnboardingInventoryClient) *Resolver {
+	return NewResolverWithProviderConfig(func(ctx context.Context, tenantID string) (string, error) {
+		provider, err := invclient.GetProviderResourceByName(ctx, tenantID, c, onboarding_types.DefaultProviderName)
+		if err != nil {
+			return "", err
+		}
+		return provider.GetConfig(), nil
+	})
+}
+
+// NewResolverWithProviderConfig returns a Resolver reading the provider configurations with providerConfig.
+func NewResolverWithProviderConfig(providerConfig ProviderConfigFunc) *Resolver {
+	return &Resolver{providerConfig: providerConfig}
+}
+
+// Matcher returns the Matcher enforcing the host identity policy of a tenant.
+func (r *Resolver) Matcher(ctx context.Context, tenantID string) (*Matcher, error) {
+	if r == nil {
+		return defaultMatcher, nil
+	}
+	config, err := r.providerConfig(ctx, tenantID)
+	if inv_errors.IsNotFound(err) {
+		// tenants without provider have the default policy
+		return defaultMatcher, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	policy, err := FromProviderConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return NewMatcher(policy)
+}
+
+type lookupKey struct {
+	id    Identifier
+	value string
+}
+
+// Resolve finds the single host matching the presented identity, and returns it with the presented identity
+// validated by the policy of its tenant. As the Edge Node does not know its tenant, hosts are first looked up
+// by every presented identifier across tenants, then each tenant with candidates validates and resolves the
+// presented identity among its own hosts with Matcher.Validate and Matcher.Resolve.
+// It fails like them if no tenant has a match, and with an *AmbiguousError if several tenants have one.
+func (r *Resolver) Resolve(ctx context.Context, presented Identity, lookup LookupFunc) (string, Identity, error) {
+	presented = presented.Normalize()
+	found := make(map[lookupKey][]Candidate)
+	var tenants []string
+	for _, id := range identifiers {
+		value := presented.Get(id)
+		if value == "" || !id.Lookupable() {
+			continue
+		}
+		candidates, err := lookup(id, value)
+		if err != nil {
+			return "", presented, err
+		}
+		found[lookupKey{id: id, value: value}] = candidates
+		for _, candidate := range candidates {
+			if !slices.Contains(tenants, candidate.TenantID) {
+				tenants = append(tenants, candidate.TenantID)
+			}
+		}
+	}
+	if len(tenants) == 0 {
+		return "", presented, inv_errors.Errorfc(codes.NotFound, "No host found for the presented identifiers")
+	}
+	slices.Sort(tenants)
+
+	var matches []string
+	var valid Identity
+	var tenantErr error
+	for _, tenantID := range tenants {
+		m, err := r.Matcher(ctx, tenantID)
+		if err != nil {
+			zlog.InfraSec().InfraErr(err).Msgf("Failed to get the host identity policy of tenant %s", tenantID)
+			return "", presented, err
+		}
+		tenantValid, err := m.Validate(presented)
+		if err == nil {
+			var resourceID string
+			resourceID, err = m.Resolve(tenantValid, func(id Identifier, value string) ([]Candidate, error) {
+				return slices.DeleteFunc(slices.Clone(found[lookupKey{id: id, value: value}]), func(c Candidate) bool {
+					return c.TenantID != tenantID
+				}), nil
+			})
+			if err == nil {
+				matches = append(matches, resourceID)
+				valid = tenantValid
+				continue
+			}
+		}
+		// report why a tenant with candidates has no match rather than that another tenant has none
+		if tenantErr == nil || isNoHost(tenantErr) {
+			tenantErr = err
+		}
+	}
+
+	switch {
+	case len(matches) == 1:
+		return matches[0], valid, nil
+	case len(matches) > 1:
+		return "", presented, &AmbiguousError{ResourceIDs: matches}
+	default:
+		return "", presented, tenantErr
+	}
+}
+
+// isNoHost reports whether err reports that no host was found, as opposed to hosts that do not match.
+func isNoHost(err error) bool {
+	var mismatch *MismatchError
+	return grpc_status.Code(err) == codes.NotFound && !errors.As(err, &mismatch)
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package invclient
+
+import (
+	"sync"
+)
+
+// hostEventHub fans out inventory events of hosts to the subscribers of each host.
+type hostEventHub struct {
+	mu   sync.Mutex
+	subs map[string]map[*HostEventSubscription]struct{}
+}
+
+// HostEventSubscription notifies its subscriber of inventory events of a single host.
+type HostEventSubscription struct {
+	// C receives a value when the host changed since the last receive. Events are coalesced,
+	// the subscriber is expected to read the current state of the host from inventory.
+	C <-chan struct{}
+
+	c          chan struct{}
+	resourceID string
+	hub        *hostEventHub
+}
+
+// SubscribeHostEvents subscribes to inventory events of the host resourceID, as propagated by NotifyHostEvent.
+// The subscription must be closed once no longer needed.
+func (c *OnboardingInventoryClient) SubscribeHostEvents(resourceID string) *HostEventSubscription {
+	ch := make(chan struct{}, 1)
+	sub := &HostEventSubscription{C: ch, c: ch, resourceID: resourceID, hub: &c.hostEvents}
+
+	c.hostEvents.mu.Lock()
+	defer c.hostEvents.mu.Unlock()
+	if c.hostEvents.subs == nil {
+		c.hostEvents.subs = make(map[string]map[*HostEventSubscription]struct{})
+	}
+	if c.hostEvents.subs[resourceID] == nil {
+		c.hostEvents.subs[resourceID] = make(map[*HostEventSubscription]struct{})
+	}
+	c.hostEvents.subs[resourceID][sub] = struct{}{}
+	return sub
+}
+
+// NotifyHostEvent notifies the subscribers of the host resourceID that it changed. It never blocks.
+func (c *OnboardingInventoryClient) NotifyHostEvent(resourceID string) {
+	c.hostEvents.mu.Lock()
+	defer c.hostEvents.mu.Unlock()
+	for sub := range c.hostEvents.subs[resourceID] {
+		select {
+		case sub.c <- struct{}{}:
+		default:
+			// a notification is already pending
+		}
+	}
+}
+
+// Close removes the subscription.
+func (s *HostEventSubscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	delete(s.hub.subs[s.resourceID], s)
+	if len(s.hub.subs[s.resourceID]) == 0 {
+		delete(s.hub.subs, s.resourceID)
+	}
+}
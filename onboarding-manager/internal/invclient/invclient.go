@@ -63,6 +63,7 @@ type OnboardingInventoryClient struct {
 	Client          client.TenantAwareInventoryClient
 	Watcher         chan *client.WatchEvents
 	InternalWatcher chan *client.ResourceTenantIDCarrier // Channel to propagate internal events to the controller.
+	hostEvents      hostEventHub                         // Subscribers to inventory events of hosts.
 }
 
 // Options provides functionality for onboarding management.
@@ -922,6 +923,20 @@ func (c *OnboardingInventoryClient) UpdateHostRegState(ctx context.Context, tena
 	})
 }
 
+// SetHostCPUArchitecture records the CPU architecture reported by the host.
+func (c *OnboardingInventoryClient) SetHostCPUArchitecture(ctx context.Context, tenantID string, hostID string,
+	cpuArchitecture string,
+) error {
+	updateHost := &computev1.HostResource{
+		ResourceId:      hostID,
+		CpuArchitecture: cpuArchitecture,
+	}
+
+	return c.UpdateInvResourceFields(ctx, tenantID, updateHost, []string{
+		computev1.HostResourceFieldCpuArchitecture,
+	})
+}
+
 // UpdateHostCurrentStateNOnboardStatus performs operations for the receiver.
 func (c *OnboardingInventoryClient) UpdateHostCurrentStateNOnboardStatus(ctx context.Context, tenantID string, resourceID string,
 	hostIP string, macid string, hostCurrentState computev1.HostState, onboardingStatus inv_status.ResourceStatus,
@@ -976,6 +991,31 @@ func (c *OnboardingInventoryClient) GetHostResource(
 	return c.listAndReturnHost(ctx, filter)
 }
 
+// ListHostResources returns all hosts, across tenants, whose UUID, serial number or PXE MAC equals filterValue.
+func (c *OnboardingInventoryClient) ListHostResources(
+	ctx context.Context,
+	filterType string,
+	filterValue string,
+) ([]*computev1.HostResource, error) {
+	switch filterType {
+	case computev1.HostResourceFieldUuid, computev1.HostResourceFieldSerialNumber, computev1.HostResourceFieldPxeMac:
+	default:
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "invalid filter type")
+	}
+	filter := &inv_v1.ResourceFilter{
+		Resource: &inv_v1.Resource{
+			Resource: &inv_v1.Resource_Host{},
+		},
+		Filter: fmt.Sprintf("%s = %q", filterType, filterValue),
+	}
+
+	resources, err := c.listAllResources(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return util.GetSpecificResourceList[*computev1.HostResource](resources)
+}
+
 // UpdateHostResourceStatus performs operations for the receiver.
 // UpdateHostStatus : update host required fields ,onboarding and registration status.
 func (c *OnboardingInventoryClient) UpdateHostResourceStatus(ctx context.Context, tenantID string, resourceID string,
@@ -1979,6 +1979,19 @@ func TestOnboardingInventoryClient_UpdateHostRegState(t *testing.T) {
 	}
 }
 
+func TestOnboardingInventoryClient_SetHostCPUArchitecture(t *testing.T) {
+	CreateOnboardingClientForTesting(t)
+	invClient := OnboardingTestClient
+	host := inv_testing.CreateHost(t, nil, nil)
+
+	err := invClient.SetHostCPUArchitecture(context.Background(), host.GetTenantId(), host.GetResourceId(), "aarch64")
+	require.NoError(t, err)
+
+	got, err := invClient.GetHostResourceByResourceID(context.Background(), host.GetTenantId(), host.GetResourceId())
+	require.NoError(t, err)
+	assert.Equal(t, "aarch64", got.GetCpuArchitecture())
+}
+
 func TestOnboardingInventoryClient_GetHostResource(t *testing.T) {
 	type fields struct {
 		Client  client.TenantAwareInventoryClient
@@ -2028,3 +2041,63 @@ func TestOnboardingInventoryClient_GetHostResource(t *testing.T) {
 		})
 	}
 }
+
+func TestOnboardingInventoryClient_ListHostResources(t *testing.T) {
+	CreateOnboardingClientForTesting(t)
+	invClient := OnboardingTestClient
+	host := inv_testing.CreateHost(t, nil, nil)
+
+	t.Run("Invalid_FilterType", func(t *testing.T) {
+		hosts, err := invClient.ListHostResources(context.Background(), computev1.HostResourceFieldName, host.Name)
+		require.Error(t, err)
+		require.Nil(t, hosts)
+	})
+
+	t.Run("UUID", func(t *testing.T) {
+		hosts, err := invClient.ListHostResources(context.Background(), computev1.HostResourceFieldUuid, host.Uuid)
+		require.NoError(t, err)
+		require.Len(t, hosts, 1)
+		require.Equal(t, host.GetResourceId(), hosts[0].GetResourceId())
+	})
+
+	t.Run("Not_Found", func(t *testing.T) {
+		hosts, err := invClient.ListHostResources(context.Background(), computev1.HostResourceFieldSerialNumber, "NOSUCHSN")
+		require.NoError(t, err)
+		require.Empty(t, hosts)
+	})
+}
+
+func TestOnboardingInventoryClient_HostEvents(t *testing.T) {
+	c := &invclient.OnboardingInventoryClient{}
+	sub1 := c.SubscribeHostEvents("host-12345678")
+	sub2 := c.SubscribeHostEvents("host-12345678")
+	other := c.SubscribeHostEvents("host-87654321")
+	defer other.Close()
+
+	// events are coalesced and never block the notifier
+	c.NotifyHostEvent("host-12345678")
+	c.NotifyHostEvent("host-12345678")
+	for _, sub := range []*invclient.HostEventSubscription{sub1, sub2} {
+		select {
+		case <-sub.C:
+		case <-time.After(time.Second):
+			t.Fatal("host event not received")
+		}
+		select {
+		case <-sub.C:
+			t.Fatal("host events were not coalesced")
+		default:
+		}
+	}
+	select {
+	case <-other.C:
+		t.Fatal("host event received for another host")
+	default:
+	}
+
+	sub1.Close()
+	c.NotifyHostEvent("host-12345678")
+	assert.Empty(t, sub1.C)
+	assert.Len(t, sub2.C, 1)
+	sub2.Close()
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package maintenance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchLimit bounds the search of the next time matching a schedule, e.g. for February 30th.
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+// field is the set of values of a cron field, as a bit set.
+type field uint64
+
+func (f field) has(v int) bool {
+	return f&(1<<uint(v)) != 0
+}
+
+// fieldBounds are the bounds of a cron field and the names of its values, if any.
+type fieldBounds struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteBounds = fieldBounds{name: "minute", min: 0, max: 59}
+	hourBounds   = fieldBounds{name: "hour", min: 0, max: 23}
+	domBounds    = fieldBounds{name: "day of month", min: 1, max: 31}
+	monthBounds  = fieldBounds{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is Sunday as well
+	dowBounds = fieldBounds{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// schedule is a standard 5-field cron schedule evaluated in a time zone.
+type schedule struct {
+	minute, hour, dom, month, dow field
+	// domAny and dowAny are true if the day of month or the day of week is "*", days then match the other field
+	// only. Otherwise days matching either field match, as in cron.
+	domAny, dowAny bool
+	loc            *time.Location
+}
+
+// parseSchedule parses the fields "minute hour day-of-month month day-of-week" of a cron expression.
+func parseSchedule(fields []string, loc *time.Location) (*schedule, error) {
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 cron fields, got %d", len(fields))
+	}
+	s := &schedule{loc: loc, domAny: fields[2] == "*", dowAny: fields[4] == "*"}
+	for i, target := range []struct {
+		f      *field
+		bounds fieldBounds
+	}{
+		{&s.minute, minuteBounds},
+		{&s.hour, hourBounds},
+		{&s.dom, domBounds},
+		{&s.month, monthBounds},
+		{&s.dow, dowBounds},
+	} {
+		f, err := parseField(fields[i], target.bounds)
+		if err != nil {
+			return nil, err
+		}
+		*target.f = f
+	}
+	if s.dow.has(7) {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// parseField parses a comma-separated list of "*", values, ranges "a-b", and steps "*/n" or "a-b/n".
+func parseField(expr string, bounds fieldBounds) (field, error) {
+	var f field
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid %s step %q", bounds.name, stepExpr)
+			}
+		}
+
+		low, high := bounds.min, bounds.max
+		if rangeExpr != "*" {
+			lowExpr, highExpr, isRange := strings.Cut(rangeExpr, "-")
+			var err error
+			if low, err = bounds.value(lowExpr); err != nil {
+				return 0, err
+			}
+			high = low
+			if isRange {
+				if high, err = bounds.value(highExpr); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				// "a/n" is "a-max/n"
+				high = bounds.max
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid %s range %q", bounds.name, rangeExpr)
+			}
+		}
+		for v := low; v <= high; v += step {
+			f |= 1 << uint(v)
+		}
+	}
+	return f, nil
+}
+
+func (b fieldBounds) value(expr string) (int, error) {
+	if v, ok := b.names[strings.ToLower(expr)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(expr)
+	if err != nil || v < b.min || v > b.max {
+		return 0, fmt.Errorf("invalid %s %q, expected %d-%d", b.name, expr, b.min, b.max)
+	}
+	return v, nil
+}
+
+func (s *schedule) dayMatches(t time.Time) bool {
+	domMatch, dowMatch := s.dom.has(t.Day()), s.dow.has(int(t.Weekday()))
+	switch {
+	case s.domAny:
+		return dowMatch
+	case s.dowAny:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
+
+// next returns the first minute matching the schedule strictly after t, or the zero time if there is none
+// within the search limit.
+func (s *schedule) next(t time.Time) time.Time {
+	t = t.In(s.loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(searchLimit)
+	for t.Before(limit) {
+		switch {
+		case !s.month.has(int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.loc)
+		case !s.hour.has(t.Hour()):
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.loc)
+			if !next.After(t) {
+				// the hour is repeated when daylight saving time ends
+				next = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			}
+			t = next
+		case !s.minute.has(t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package maintenance
+
+import (
+	"context"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/invclient"
+	onboarding_types "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding/types"
+)
+
+// ProviderConfigFunc returns the raw configuration of the provider of a tenant.
+type ProviderConfigFunc func(ctx context.Context, tenantID string) (string, error)
+
+// Resolver resolves the maintenance windows of Instances: the windows of the site of their host if it has any,
+// otherwise the windows of the provider configuration of their tenant.
+type Resolver struct {
+	providerConfig ProviderConfigFunc
+}
+
+// NewResolver returns a Resolver reading the provider configurations from inventory.
+func NewResolver(c *invclient.OnboardingInventoryClient) *Resolver {
+	return NewResolverWithProviderConfig(func(ctx context.Context, tenantID string) (string, error) {
+		provider, err := invclient.GetProviderResourceByName(ctx, tenantID, c, onboarding_types.DefaultProviderName)
+		if err != nil {
+			return "", err
+		}
+		return provider.GetConfig(), nil
+	})
+}
+
+// NewResolverWithProviderConfig returns a Resolver reading the provider configurations with providerConfig.
+func NewResolverWithProviderConfig(providerConfig ProviderConfigFunc) *Resolver {
+	return &Resolver{providerConfig: providerConfig}
+}
+
+// Windows returns the maintenance windows of an Instance, none if provisioning is allowed at any time.
+func (r *Resolver) Windows(ctx context.Context, instance *computev1.InstanceResource) (Windows, error) {
+	siteWindows, err := FromMetadata(instance.GetHost().GetSite().GetMetadata())
+	if err != nil || len(siteWindows) > 0 {
+		return siteWindows, err
+	}
+
+	config, err := r.providerConfig(ctx, instance.GetTenantId())
+	if inv_errors.IsNotFound(err) {
+		// tenants without provider have no maintenance windows
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return FromProviderConfig(config)
+}
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package maintenance implements the maintenance windows of sites and tenants, outside of which the provisioning
+// of their Instances does not start.
+//
+// A maintenance window is a cron schedule of its openings followed by how long it stays open, optionally prefixed
+// by the time zone of the schedule, UTC by default:
+//
+//	CRON_TZ=Europe/Berlin 0 22 * * mon-fri 8h
+//
+// opens every weekday at 22:00 Berlin time until 06:00 the next day. The cron schedule has the standard fields
+// "minute hour day-of-month month day-of-week", with lists, ranges, steps and English month and day names.
+package maintenance
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/util"
+)
+
+const (
+	// MetadataKey is the key of the site metadata defining a maintenance window. It may be repeated to define
+	// several windows.
+	MetadataKey = "maintenance-window"
+
+	// tzPrefix prefixes the time zone of a maintenance window.
+	tzPrefix = "CRON_TZ="
+)
+
+// Window is a recurring maintenance window.
+type Window struct {
+	spec     string
+	schedule *schedule
+	duration time.Duration
+}
+
+// Parse parses a maintenance window such as "CRON_TZ=Europe/Berlin 0 22 * * mon-fri 8h".
+func Parse(spec string) (*Window, error) {
+	fields := strings.Fields(spec)
+	loc := time.UTC
+	if len(fields) > 0 && strings.HasPrefix(fields[0], tzPrefix) {
+		var err error
+		if loc, err = time.LoadLocation(strings.TrimPrefix(fields[0], tzPrefix)); err != nil {
+			return nil, inv_errors.Errorfc(codes.InvalidArgument, "Invalid maintenance window %q: %v", spec, err)
+		}
+		fields = fields[1:]
+	}
+	if len(fields) != 6 {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument,
+			"Invalid maintenance window %q: expected a cron schedule followed by a duration", spec)
+	}
+
+	duration, err := time.ParseDuration(fields[5])
+	if err != nil || duration < time.Minute {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument,
+			"Invalid maintenance window %q: invalid duration %q, expected at least 1m", spec, fields[5])
+	}
+	sched, err := parseSchedule(fields[:5], loc)
+	if err != nil {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "Invalid maintenance window %q: %v", spec, err)
+	}
+	return &Window{spec: spec, schedule: sched, duration: duration}, nil
+}
+
+// String returns the window as parsed.
+func (w *Window) String() string {
+	return w.spec
+}
+
+// IsOpen returns true if the window is open at t.
+func (w *Window) IsOpen(t time.Time) bool {
+	// the window is open if it opened less than its duration ago
+	opening := w.schedule.next(t.Add(-w.duration))
+	return !opening.IsZero() && !opening.After(t)
+}
+
+// NextOpening returns the next time the window opens after t, in the time zone of the window,
+// or the zero time if it never opens.
+func (w *Window) NextOpening(t time.Time) time.Time {
+	return w.schedule.next(t)
+}
+
+// Windows are the maintenance windows of a site or a tenant. Provisioning is allowed while any of them is open,
+// and at any time if there are none.
+type Windows []*Window
+
+// Open returns true if provisioning is allowed at t. Otherwise it returns the next opening of the windows,
+// the zero time if they never open.
+func (ws Windows) Open(t time.Time) (bool, time.Time) {
+	var next time.Time
+	for _, w := range ws {
+		if w.IsOpen(t) {
+			return true, time.Time{}
+		}
+		if opening := w.NextOpening(t); !opening.IsZero() && (next.IsZero() || opening.Before(next)) {
+			next = opening
+		}
+	}
+	return len(ws) == 0, next
+}
+
+// ParseAll parses maintenance windows, see Parse.
+func ParseAll(specs []string) (Windows, error) {
+	windows := make(Windows, 0, len(specs))
+	for _, spec := range specs {
+		w, err := Parse(spec)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+// FromMetadata returns the maintenance windows in site metadata, a JSON list of key/value pairs.
+func FromMetadata(metadata string) (Windows, error) {
+	specs, err := util.MetadataValues(metadata, MetadataKey)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAll(specs)
+}
+
+// FromProviderConfig returns the maintenance windows in the configuration of a tenant provider, a JSON object
+// whose "maintenanceWindows" field lists the windows of all the sites of the tenant.
+func FromProviderConfig(config string) (Windows, error) {
+	if config == "" {
+		return nil, nil
+	}
+	var pconf struct {
+		MaintenanceWindows []string `json:"maintenanceWindows"`
+	}
+	if err := json.Unmarshal([]byte(config), &pconf); err != nil {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "Failed to parse provider configuration: %v", err)
+	}
+	return ParseAll(pconf.MaintenanceWindows)
+}
@@ -0,0 +1,266 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package maintenance_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	locationv1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/location/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/maintenance"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"0 22 * * *",
+		"0 22 * * * 8h extra",
+		"CRON_TZ=Mars/Olympus 0 22 * * * 8h",
+		"0 22 * * * 30s",
+		"0 22 * * * forever",
+		"60 22 * * * 8h",
+		"0 24 * * * 8h",
+		"0 22 0 * * 8h",
+		"0 22 * 13 * 8h",
+		"0 22 * * 8 8h",
+		"0 22 * * fri-mon 8h",
+		"*/0 22 * * * 8h",
+		"0 22 * * someday 8h",
+	} {
+		_, err := maintenance.Parse(spec)
+		assert.Error(t, err, spec)
+		assert.Equal(t, codes.InvalidArgument, grpc_status.Code(err), spec)
+	}
+}
+
+func TestWindow_IsOpen(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	tests := []struct {
+		name string
+		spec string
+		at   time.Time
+		want bool
+	}{
+		{
+			name: "Overnight window opened the evening before",
+			spec: "CRON_TZ=Europe/Berlin 0 22 * * mon-fri 8h",
+			at:   time.Date(2025, 6, 3, 5, 59, 0, 0, berlin), // Tuesday
+			want: true,
+		},
+		{
+			name: "Overnight window closed",
+			spec: "CRON_TZ=Europe/Berlin 0 22 * * mon-fri 8h",
+			at:   time.Date(2025, 6, 3, 6, 0, 0, 0, berlin),
+			want: false,
+		},
+		{
+			name: "Window opening",
+			spec: "CRON_TZ=Europe/Berlin 0 22 * * mon-fri 8h",
+			at:   time.Date(2025, 6, 3, 22, 0, 0, 0, berlin),
+			want: true,
+		},
+		{
+			name: "No window on weekends",
+			spec: "CRON_TZ=Europe/Berlin 0 22 * * mon-fri 8h",
+			at:   time.Date(2025, 6, 7, 23, 0, 0, 0, berlin), // Saturday
+			want: false,
+		},
+		{
+			name: "Friday window open on Saturday morning",
+			spec: "CRON_TZ=Europe/Berlin 0 22 * * mon-fri 8h",
+			at:   time.Date(2025, 6, 7, 1, 0, 0, 0, berlin),
+			want: true,
+		},
+		{
+			name: "Time zone of the window",
+			spec: "CRON_TZ=Europe/Berlin 0 22 * * * 1h",
+			at:   time.Date(2025, 6, 3, 20, 30, 0, 0, time.UTC), // 22:30 in Berlin
+			want: true,
+		},
+		{
+			name: "UTC by default",
+			spec: "0 22 * * * 1h",
+			at:   time.Date(2025, 6, 3, 20, 30, 0, 0, time.UTC),
+			want: false,
+		},
+		{
+			name: "Steps and lists",
+			spec: "0 */6 1,15 * * 2h",
+			at:   time.Date(2025, 6, 15, 13, 0, 0, 0, time.UTC),
+			want: true,
+		},
+		{
+			name: "Day of month or day of week",
+			spec: "0 2 1 * sun 1h",
+			at:   time.Date(2025, 6, 8, 2, 30, 0, 0, time.UTC), // Sunday
+			want: true,
+		},
+		{
+			name: "Sunday as 7",
+			spec: "0 2 * * 7 1h",
+			at:   time.Date(2025, 6, 8, 2, 30, 0, 0, time.UTC),
+			want: true,
+		},
+		{
+			name: "Month names",
+			spec: "0 0 * jan-mar * 24h",
+			at:   time.Date(2025, 6, 8, 2, 30, 0, 0, time.UTC),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := maintenance.Parse(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, w.IsOpen(tt.at))
+		})
+	}
+}
+
+func TestWindow_NextOpening(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	w, err := maintenance.Parse("CRON_TZ=Europe/Berlin 30 2 * * * 1h")
+	require.NoError(t, err)
+
+	next := w.NextOpening(time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC))
+	assert.True(t, next.Equal(time.Date(2025, 6, 4, 2, 30, 0, 0, berlin)), next)
+	assert.Equal(t, berlin, next.Location())
+
+	// 02:30 does not exist when daylight saving time starts, as in cron the opening is skipped
+	next = w.NextOpening(time.Date(2025, 3, 30, 0, 0, 0, 0, berlin))
+	assert.True(t, next.Equal(time.Date(2025, 3, 31, 2, 30, 0, 0, berlin)), next)
+
+	// 02:30 happens twice when daylight saving time ends
+	next = w.NextOpening(time.Date(2025, 10, 26, 2, 45, 0, 0, berlin))
+	assert.True(t, next.After(time.Date(2025, 10, 26, 2, 45, 0, 0, berlin)), next)
+	assert.True(t, next.Before(time.Date(2025, 10, 27, 2, 31, 0, 0, berlin)), next)
+
+	never, err := maintenance.Parse("0 0 30 feb * 1h")
+	require.NoError(t, err)
+	assert.True(t, never.NextOpening(time.Now()).IsZero())
+	assert.False(t, never.IsOpen(time.Now()))
+}
+
+func TestWindows_Open(t *testing.T) {
+	at := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+
+	open, next := maintenance.Windows(nil).Open(at)
+	assert.True(t, open)
+	assert.True(t, next.IsZero())
+
+	windows, err := maintenance.ParseAll([]string{"0 22 * * * 2h", "0 18 * * * 1h"})
+	require.NoError(t, err)
+	open, next = windows.Open(at)
+	assert.False(t, open)
+	assert.Equal(t, time.Date(2025, 6, 3, 18, 0, 0, 0, time.UTC), next)
+
+	open, _ = windows.Open(at.Add(6*time.Hour + 30*time.Minute))
+	assert.True(t, open)
+}
+
+func TestFromMetadata(t *testing.T) {
+	windows, err := maintenance.FromMetadata("")
+	require.NoError(t, err)
+	assert.Empty(t, windows)
+
+	windows, err = maintenance.FromMetadata(`[{"key":"maintenance-window","value":"0 22 * * * 2h"},` +
+		`{"key":"region","value":"eu"},{"key":"maintenance-window","value":"0 3 * * sat,sun 4h"}]`)
+	require.NoError(t, err)
+	require.Len(t, windows, 2)
+	assert.Equal(t, "0 3 * * sat,sun 4h", windows[1].String())
+
+	_, err = maintenance.FromMetadata(`[{"key":"maintenance-window","value":"sometimes"}]`)
+	assert.Error(t, err)
+	_, err = maintenance.FromMetadata(`{"maintenance-window":"0 22 * * * 2h"}`)
+	assert.Error(t, err)
+}
+
+func TestResolver_Windows(t *testing.T) {
+	providerConfigs := map[string]string{
+		"tenant-windows":    `{"defaultOs":"os-12345678","maintenanceWindows":["0 22 * * * 2h"]}`,
+		"tenant-no-windows": `{"defaultOs":"os-12345678","autoProvision":true}`,
+		"tenant-invalid":    `{"maintenanceWindows":"0 22 * * * 2h"}`,
+	}
+	resolver := maintenance.NewResolverWithProviderConfig(func(_ context.Context, tenantID string) (string, error) {
+		config, ok := providerConfigs[tenantID]
+		if !ok {
+			return "", inv_errors.Errorfc(codes.NotFound, "no provider")
+		}
+		return config, nil
+	})
+	instance := func(tenantID, siteMetadata string) *computev1.InstanceResource {
+		return &computev1.InstanceResource{
+			TenantId: tenantID,
+			Host: &computev1.HostResource{
+				Site: &locationv1.SiteResource{Metadata: siteMetadata},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		instance *computev1.InstanceResource
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "Site windows",
+			instance: instance("tenant-windows", `[{"key":"maintenance-window","value":"0 1 * * * 3h"}]`),
+			want:     []string{"0 1 * * * 3h"},
+		},
+		{
+			name:     "Tenant windows",
+			instance: instance("tenant-windows", `[{"key":"region","value":"eu"}]`),
+			want:     []string{"0 22 * * * 2h"},
+		},
+		{
+			name:     "No windows",
+			instance: instance("tenant-no-windows", ""),
+		},
+		{
+			name:     "No provider",
+			instance: instance("tenant-unknown", ""),
+		},
+		{
+			name:     "Invalid site windows",
+			instance: instance("tenant-windows", `[{"key":"maintenance-window","value":"0 1 * * *"}]`),
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid tenant windows",
+			instance: instance("tenant-invalid", ""),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			windows, err := resolver.Windows(context.Background(), tt.instance)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var got []string
+			for _, w := range windows {
+				got = append(got, w.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package onboarding
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/env"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
+)
+
+// ReportActionMessage sets the message of the running action of a workflow of a host, as reported by the
+// tink-worker of the host. workflowID is the ID given to tink-worker by the Tinkerbell server, "<namespace>/<name>".
+// Only the production and decommission workflows of the host itself can be reported on.
+func ReportActionMessage(ctx context.Context, hostUUID, workflowID, taskName, actionName, message string) error {
+	kubeClient, err := tinkerbell.K8sClientFactory()
+	if err != nil {
+		return err
+	}
+	return reportActionMessage(ctx, kubeClient, hostUUID, workflowID, taskName, actionName, message)
+}
+
+func reportActionMessage(ctx context.Context, kubeClient client.Client,
+	hostUUID, workflowID, taskName, actionName, message string,
+) error {
+	namespace, name, _ := strings.Cut(workflowID, "/")
+	if namespace != env.K8sNamespace ||
+		(name != generateWorkflowName(hostUUID) && name != generateDecommissionWorkflowName(hostUUID)) {
+		err := inv_errors.Errorfc(codes.PermissionDenied, "Workflow %s is not a workflow of host %s", workflowID, hostUUID)
+		zlog.InfraSec().InfraErr(err).Msgf("Rejecting the message of action %s", actionName)
+		return err
+	}
+	return tinkerbell.SetRunningActionMessage(ctx, kubeClient, client.ObjectKey{Namespace: namespace, Name: name},
+		taskName, actionName, message)
+}
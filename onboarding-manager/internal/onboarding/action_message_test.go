@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // Keeping the test in the same package due to dependencies on unexported fields.
+package onboarding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/env"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
+)
+
+const testTaskName = "os-installation"
+
+// newWorkflowStatusClient returns a fake client updating the status of the workflows through their status
+// subresource only, as the Tinkerbell server and the onboarding manager do.
+func newWorkflowStatusClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	assert.NilError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NilError(t, tink.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithStatusSubresource(&tink.Workflow{}).Build()
+}
+
+// newPendingWorkflow returns a workflow whose actions are all pending, as created by the Tinkerbell controller.
+func newPendingWorkflow(name, taskName string, actions ...string) *tink.Workflow {
+	workflow := &tink.Workflow{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: env.K8sNamespace}}
+	workflow.Status = pendingWorkflowStatus(taskName, actions...)
+	return workflow
+}
+
+// pendingWorkflowStatus returns the status the Tinkerbell controller sets to a new workflow of a single task.
+func pendingWorkflowStatus(taskName string, actions ...string) tink.WorkflowStatus {
+	status := tink.WorkflowStatus{State: tink.WorkflowStatePending, Tasks: []tink.Task{{Name: taskName}}}
+	for _, action := range actions {
+		status.Tasks[0].Actions = append(status.Tasks[0].Actions,
+			tink.Action{Name: action, Status: tink.WorkflowStatePending, Timeout: 60})
+	}
+	return status
+}
+
+// reportActionStatus reports the state of the current action of a workflow as tink-worker does, and updates the
+// workflow as the Tinkerbell server v0.12.2 does (internal/server/kubernetes_api_workflow.go, modifyWorkflowState):
+// the current action is the first one that did not succeed, its state is set with the time it started or took, and
+// the message reported along is not kept.
+func reportActionStatus(t *testing.T, k8sCli client.Client, key client.ObjectKey, state tink.WorkflowState,
+	now time.Time,
+) {
+	t.Helper()
+	workflow := &tink.Workflow{}
+	assert.NilError(t, k8sCli.Get(context.Background(), key, workflow))
+	actions := workflow.Status.Tasks[0].Actions
+	current := 0
+	for current < len(actions) && actions[current].Status == tink.WorkflowStateSuccess {
+		current++
+	}
+	assert.Assert(t, current < len(actions), "no current action")
+	action := &actions[current]
+	action.Status = state
+	switch state {
+	case tink.WorkflowStateRunning:
+		workflow.Status.State = state
+		startedAt := metav1.NewTime(now)
+		action.StartedAt = &startedAt
+	case tink.WorkflowStateFailed, tink.WorkflowStateTimeout:
+		workflow.Status.State = state
+		action.Seconds = int64(now.Sub(action.StartedAt.Time).Seconds())
+	case tink.WorkflowStateSuccess:
+		action.Seconds = int64(now.Sub(action.StartedAt.Time).Seconds())
+		if current == len(actions)-1 {
+			workflow.Status.State = tink.WorkflowStatePost
+		}
+	default:
+		t.Fatalf("no update requested")
+	}
+	assert.NilError(t, k8sCli.Status().Update(context.Background(), workflow))
+}
+
+// postProcessWorkflow completes a workflow whose actions all succeeded, as the Tinkerbell controller does.
+func postProcessWorkflow(t *testing.T, k8sCli client.Client, key client.ObjectKey) {
+	t.Helper()
+	workflow := &tink.Workflow{}
+	assert.NilError(t, k8sCli.Get(context.Background(), key, workflow))
+	assert.Equal(t, workflow.Status.State, tink.WorkflowStatePost)
+	workflow.Status.State = tink.WorkflowStateSuccess
+	assert.NilError(t, k8sCli.Status().Update(context.Background(), workflow))
+}
+
+func TestReportActionMessage(t *testing.T) {
+	hostUUID := "7f1c9a52-2b8e-4c1d-9e3a-5d6f7a8b9c0d"
+	workflow := newPendingWorkflow(generateWorkflowName(hostUUID), testTaskName,
+		tinkerbell.ActionSecureBootStatusFlagRead, tinkerbell.ActionStreamOSImage)
+	k8sCli := newWorkflowStatusClient(t, workflow)
+	key := client.ObjectKeyFromObject(workflow)
+	workflowID := env.K8sNamespace + "/" + workflow.Name
+	now := time.Now()
+
+	reportActionStatus(t, k8sCli, key, tink.WorkflowStateRunning, now)
+	assert.NilError(t, reportActionMessage(context.Background(), k8sCli, hostUUID, workflowID, testTaskName,
+		tinkerbell.ActionSecureBootStatusFlagRead, "finished execution successfully"))
+	reportActionStatus(t, k8sCli, key, tink.WorkflowStateSuccess, now.Add(time.Second))
+	reportActionStatus(t, k8sCli, key, tink.WorkflowStateRunning, now.Add(time.Second))
+	// tink-worker reports the message of an action before its final state
+	assert.NilError(t, reportActionMessage(context.Background(), k8sCli, hostUUID, workflowID, testTaskName,
+		tinkerbell.ActionStreamOSImage, "exit status 84"))
+	reportActionStatus(t, k8sCli, key, tink.WorkflowStateFailed, now.Add(time.Minute))
+
+	got := &tink.Workflow{}
+	assert.NilError(t, k8sCli.Get(context.Background(), key, got))
+	code, action := tinkerbell.ErrorCodeFromWorkflow(got)
+	assert.Equal(t, code, tinkerbell.ErrorCodeDiskWriteFailed)
+	assert.Equal(t, action, tinkerbell.ActionStreamOSImage)
+	assert.Equal(t, got.Status.Tasks[0].Actions[0].Message, "finished execution successfully")
+
+	// the message of a finished action is kept
+	err := reportActionMessage(context.Background(), k8sCli, hostUUID, workflowID, testTaskName,
+		tinkerbell.ActionStreamOSImage, "exit status 1")
+	assert.Equal(t, grpc_status.Code(err), codes.FailedPrecondition)
+
+	// a host only reports on its own production and decommission workflows
+	for _, workflowID := range []string{
+		env.K8sNamespace + "/" + generateWorkflowName("d2a6c1e0-4f3b-4a8e-8c7d-1b2e3f4a5b6c"),
+		"kube-system/" + generateWorkflowName(hostUUID),
+		generateDecommissionWorkflowName(hostUUID),
+	} {
+		err = reportActionMessage(context.Background(), k8sCli, hostUUID, workflowID, testTaskName,
+			tinkerbell.ActionStreamOSImage, "exit status 1")
+		assert.Equal(t, grpc_status.Code(err), codes.PermissionDenied, workflowID)
+	}
+	err = reportActionMessage(context.Background(), k8sCli, hostUUID,
+		env.K8sNamespace+"/"+generateDecommissionWorkflowName(hostUUID), testTaskName, tinkerbell.ActionSanitizeDisks, "")
+	assert.Equal(t, grpc_status.Code(err), codes.NotFound)
+}
+
+func TestReportActionMessage_Outputs(t *testing.T) {
+	hostUUID := "7f1c9a52-2b8e-4c1d-9e3a-5d6f7a8b9c0d"
+	workflow := newPendingWorkflow(generateWorkflowName(hostUUID), testTaskName,
+		tinkerbell.ActionStreamOSImage, tinkerbell.ActionSecureBootStatusFlagRead)
+	k8sCli := newWorkflowStatusClient(t, workflow)
+	key := client.ObjectKeyFromObject(workflow)
+	workflowID := env.K8sNamespace + "/" + workflow.Name
+	now := time.Now()
+
+	reportActionStatus(t, k8sCli, key, tink.WorkflowStateRunning, now)
+	assert.NilError(t, reportActionMessage(context.Background(), k8sCli, hostUUID, workflowID, testTaskName,
+		tinkerbell.ActionStreamOSImage, `outputs: {"TARGET_DISK":"/dev/sda","IMAGE_SHA256":"abc"}`))
+	reportActionStatus(t, k8sCli, key, tink.WorkflowStateSuccess, now.Add(time.Minute))
+	reportActionStatus(t, k8sCli, key, tink.WorkflowStateRunning, now.Add(time.Minute))
+	reportActionStatus(t, k8sCli, key, tink.WorkflowStateSuccess, now.Add(2*time.Minute))
+	postProcessWorkflow(t, k8sCli, key)
+
+	got := &tink.Workflow{}
+	assert.NilError(t, k8sCli.Get(context.Background(), key, got))
+	assert.DeepEqual(t, tinkerbell.WorkflowOutputs(got), map[string]string{
+		tinkerbell.OutputTargetDisk: "/dev/sda", tinkerbell.OutputImageSHA256: "abc",
+	})
+}
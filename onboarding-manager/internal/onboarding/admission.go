@@ -0,0 +1,273 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package onboarding
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/env"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/util"
+)
+
+const (
+	// WorkflowLabelTenant labels the provisioning workflows with the tenant of their Instance.
+	WorkflowLabelTenant = "onboarding.edge-orchestrator.intel.com/tenant"
+	// WorkflowLabelRegion labels the provisioning workflows with the region of the site of their host.
+	WorkflowLabelRegion = "onboarding.edge-orchestrator.intel.com/region"
+	// WorkflowLabelSite labels the provisioning workflows with the site of their host.
+	WorkflowLabelSite = "onboarding.edge-orchestrator.intel.com/site"
+	// WorkflowLabelHost labels the provisioning workflows with the UUID of their host.
+	WorkflowLabelHost = "onboarding.edge-orchestrator.intel.com/host"
+
+	// PriorityMetadataKey is the key of the host metadata setting the provisioning priority of the host,
+	// an integer. Queued hosts with a higher priority are admitted first, the default priority is 0.
+	PriorityMetadataKey = "provisioning-priority"
+
+	// admissionTTL is how long a queued host is kept without being reconciled, and how long an admitted host
+	// counts as running until its workflow is listed.
+	admissionTTL = 5 * time.Minute
+	// admissionListTimeout bounds the listing of the workflows of a scope.
+	admissionListTimeout = 3 * time.Second
+)
+
+// AdmissionLimits limits the number of provisioning workflows running at once in a site, a region and a tenant.
+// A zero limit disables the limit of its scope. Instances beyond the limits are queued until a running workflow
+// of their scope completes.
+type AdmissionLimits struct {
+	PerSite   int
+	PerRegion int
+	PerTenant int
+}
+
+// Admission are the admission limits of the provisioning workflows, no limits by default.
+var Admission AdmissionLimits
+
+// admissionScope is a scope limited by the AdmissionLimits, identified by a workflow label and its value.
+type admissionScope struct {
+	label string
+	value string
+	limit int
+}
+
+// admissionEntry is a host queued for admission, or admitted until its workflow is listed.
+type admissionEntry struct {
+	hostUUID   string
+	labels     map[string]string
+	priority   int
+	enqueuedAt time.Time
+	seenAt     time.Time
+}
+
+// admissionQueue admits hosts to provisioning within the AdmissionLimits. Running workflows are counted from
+// the workflows in Kubernetes, so that limits hold across restarts; only the queue order is kept in memory.
+type admissionQueue struct {
+	mu       sync.Mutex
+	queued   map[string]*admissionEntry
+	admitted map[string]*admissionEntry
+	now      func() time.Time
+}
+
+var provisioningQueue = newAdmissionQueue()
+
+func newAdmissionQueue() *admissionQueue {
+	return &admissionQueue{
+		queued:   make(map[string]*admissionEntry),
+		admitted: make(map[string]*admissionEntry),
+		now:      time.Now,
+	}
+}
+
+// workflowLabels returns the labels of the provisioning workflow of an Instance. Scopes the Instance is not
+// related to, e.g. a host without a site, are not labeled.
+func workflowLabels(instance *computev1.InstanceResource) map[string]string {
+	labels := map[string]string{WorkflowLabelHost: instance.GetHost().GetUuid()}
+	for label, value := range map[string]string{
+		WorkflowLabelTenant: instance.GetTenantId(),
+		WorkflowLabelSite:   instance.GetHost().GetSite().GetResourceId(),
+		WorkflowLabelRegion: instance.GetHost().GetSite().GetRegion().GetResourceId(),
+	} {
+		if value != "" {
+			labels[label] = value
+		}
+	}
+	return labels
+}
+
+// enabled returns true if any limit is set.
+func (l AdmissionLimits) enabled() bool {
+	return l.PerSite > 0 || l.PerRegion > 0 || l.PerTenant > 0
+}
+
+// scopes returns the limited scopes of a workflow with labels.
+func (l AdmissionLimits) scopes(labels map[string]string) []admissionScope {
+	var scopes []admissionScope
+	for _, scope := range []admissionScope{
+		{label: WorkflowLabelSite, limit: l.PerSite},
+		{label: WorkflowLabelRegion, limit: l.PerRegion},
+		{label: WorkflowLabelTenant, limit: l.PerTenant},
+	} {
+		scope.value = labels[scope.label]
+		if scope.limit > 0 && scope.value != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// priorityFromMetadata returns the provisioning priority in the host metadata, a JSON list of key/value pairs.
+func priorityFromMetadata(metadata string) (int, error) {
+	values, err := util.MetadataValues(metadata, PriorityMetadataKey)
+	if err != nil || len(values) == 0 {
+		return 0, err
+	}
+	priority, err := strconv.Atoi(values[0])
+	if err != nil {
+		return 0, inv_errors.Errorf("Invalid %s %q: %v", PriorityMetadataKey, values[0], err)
+	}
+	return priority, nil
+}
+
+// admitProvisioning returns 0 if the workflow of an Instance can be started within the Admission limits,
+// otherwise the position of the Instance in the provisioning queue.
+func admitProvisioning(ctx context.Context, k8sCli client.Client, instance *computev1.InstanceResource) (int, error) {
+	if !Admission.enabled() {
+		return 0, nil
+	}
+	priority, err := priorityFromMetadata(instance.GetHost().GetMetadata())
+	if err != nil {
+		// a host with an invalid priority is still provisioned
+		zlog.Warn().Err(err).Msgf("Using default provisioning priority for host %s", instance.GetHost().GetUuid())
+	}
+	return provisioningQueue.admit(ctx, k8sCli, Admission, &admissionEntry{
+		hostUUID: instance.GetHost().GetUuid(),
+		labels:   workflowLabels(instance),
+		priority: priority,
+	})
+}
+
+// admit admits entry if the running and admitted workflows, and the entries queued ahead of it, leave room
+// in every limited scope of entry. Otherwise entry is queued and its position returned.
+func (q *admissionQueue) admit(ctx context.Context, k8sCli client.Client, limits AdmissionLimits,
+	entry *admissionEntry,
+) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.now()
+	q.prune(now)
+	entry.enqueuedAt, entry.seenAt = now, now
+	if queued, ok := q.queued[entry.hostUUID]; ok {
+		entry.enqueuedAt = queued.enqueuedAt
+	}
+
+	position := 0
+	for _, scope := range limits.scopes(entry.labels) {
+		running, err := q.countRunning(ctx, k8sCli, scope)
+		if err != nil {
+			return 0, err
+		}
+		ahead := q.countAhead(entry, scope)
+		if running+ahead >= scope.limit {
+			position = max(position, ahead+1)
+		}
+	}
+	if position > 0 {
+		q.queued[entry.hostUUID] = entry
+		zlog.Debug().Msgf("Host %s queued for provisioning at position %d", entry.hostUUID, position)
+		return position, nil
+	}
+
+	delete(q.queued, entry.hostUUID)
+	q.admitted[entry.hostUUID] = entry
+	return 0, nil
+}
+
+// countRunning returns the number of running workflows in scope, including the admitted hosts whose workflow
+// is not listed yet.
+func (q *admissionQueue) countRunning(ctx context.Context, k8sCli client.Client, scope admissionScope) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, admissionListTimeout)
+	defer cancel()
+
+	workflows := &tink.WorkflowList{}
+	if err := k8sCli.List(ctx, workflows, client.InNamespace(env.K8sNamespace),
+		client.MatchingLabels{scope.label: scope.value}); err != nil {
+		zlog.InfraSec().InfraErr(err).Msgf("Failed to list workflows of %s %s", scope.label, scope.value)
+		return 0, inv_errors.Errorf("Failed to list workflows of %s %s", scope.label, scope.value)
+	}
+
+	listed := make(map[string]bool, len(workflows.Items))
+	running := 0
+	for _, workflow := range workflows.Items {
+		listed[workflow.Labels[WorkflowLabelHost]] = true
+		switch workflow.Status.State {
+		case tink.WorkflowStateSuccess, tink.WorkflowStateFailed, tink.WorkflowStateTimeout:
+		default:
+			running++
+		}
+	}
+	for hostUUID, admitted := range q.admitted {
+		if admitted.labels[scope.label] != scope.value {
+			continue
+		}
+		if listed[hostUUID] {
+			delete(q.admitted, hostUUID)
+			continue
+		}
+		running++
+	}
+	return running, nil
+}
+
+// countAhead returns the number of entries of scope queued ahead of entry.
+func (q *admissionQueue) countAhead(entry *admissionEntry, scope admissionScope) int {
+	ahead := 0
+	for _, queued := range q.queued {
+		if queued.hostUUID != entry.hostUUID && queued.labels[scope.label] == scope.value && queued.before(entry) {
+			ahead++
+		}
+	}
+	return ahead
+}
+
+// before returns true if e is admitted before other: by priority, then in order of arrival.
+func (e *admissionEntry) before(other *admissionEntry) bool {
+	if e.priority != other.priority {
+		return e.priority > other.priority
+	}
+	if !e.enqueuedAt.Equal(other.enqueuedAt) {
+		return e.enqueuedAt.Before(other.enqueuedAt)
+	}
+	return e.hostUUID < other.hostUUID
+}
+
+// prune drops the queued hosts that are no longer reconciled, e.g. whose Instance was deleted, and the admitted
+// hosts whose workflow could not be created.
+func (q *admissionQueue) prune(now time.Time) {
+	for hostUUID, queued := range q.queued {
+		if now.Sub(queued.seenAt) > admissionTTL {
+			delete(q.queued, hostUUID)
+		}
+	}
+	for hostUUID, admitted := range q.admitted {
+		if now.Sub(admitted.seenAt) > admissionTTL {
+			delete(q.admitted, hostUUID)
+		}
+	}
+}
+
+// release removes a host from the queue and from the admitted hosts.
+func (q *admissionQueue) release(hostUUID string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.queued, hostUUID)
+	delete(q.admitted, hostUUID)
+}
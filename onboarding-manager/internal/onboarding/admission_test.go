@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // Keeping the test in the same package due to dependencies on unexported fields.
+package onboarding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	locationv1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/location/v1"
+	om_testing "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/testing"
+	om_status "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/status"
+)
+
+// workflowListClient lists workflows matching the label selector of the List call.
+type workflowListClient struct {
+	om_testing.MockK8sClient
+	workflows []tink.Workflow
+}
+
+func (c *workflowListClient) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	workflows, ok := list.(*tink.WorkflowList)
+	if !ok {
+		return nil
+	}
+	for _, workflow := range c.workflows {
+		if listOpts.LabelSelector == nil || listOpts.LabelSelector.Matches(labels.Set(workflow.Labels)) {
+			workflows.Items = append(workflows.Items, workflow)
+		}
+	}
+	return nil
+}
+
+func (c *workflowListClient) addWorkflow(hostUUID, site string, state tink.WorkflowState) {
+	c.workflows = append(c.workflows, tink.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   generateWorkflowName(hostUUID),
+			Labels: map[string]string{WorkflowLabelHost: hostUUID, WorkflowLabelSite: site},
+		},
+		Status: tink.WorkflowStatus{State: state},
+	})
+}
+
+func siteEntry(hostUUID, site string, priority int) *admissionEntry {
+	return &admissionEntry{
+		hostUUID: hostUUID,
+		labels:   map[string]string{WorkflowLabelHost: hostUUID, WorkflowLabelSite: site},
+		priority: priority,
+	}
+}
+
+func TestAdmissionQueue_Admit(t *testing.T) {
+	ctx := context.Background()
+	limits := AdmissionLimits{PerSite: 2}
+	start := time.Now()
+	now := start
+	q := newAdmissionQueue()
+	q.now = func() time.Time { return now }
+
+	k8sCli := &workflowListClient{}
+	k8sCli.addWorkflow("host-running", "site-a", tink.WorkflowStateRunning)
+	k8sCli.addWorkflow("host-done", "site-a", tink.WorkflowStateSuccess)
+	k8sCli.addWorkflow("host-other-site", "site-b", tink.WorkflowStatePending)
+
+	admit := func(entry *admissionEntry) int {
+		t.Helper()
+		position, err := q.admit(ctx, k8sCli, limits, entry)
+		assert.NilError(t, err)
+		return position
+	}
+
+	// one workflow running in site-a, room for one more
+	assert.Equal(t, admit(siteEntry("host-1", "site-a", 0)), 0)
+	// host-1 is admitted, its workflow is not listed yet
+	assert.Equal(t, admit(siteEntry("host-2", "site-a", 0)), 1)
+	now = now.Add(time.Second)
+	assert.Equal(t, admit(siteEntry("host-3", "site-a", 0)), 2)
+	// a higher priority is queued ahead
+	assert.Equal(t, admit(siteEntry("host-4", "site-a", 10)), 1)
+	assert.Equal(t, admit(siteEntry("host-2", "site-a", 0)), 2)
+	assert.Equal(t, admit(siteEntry("host-3", "site-a", 0)), 3)
+	// other sites are not limited by site-a
+	assert.Equal(t, admit(siteEntry("host-5", "site-b", 0)), 0)
+	assert.Equal(t, admit(siteEntry("host-6", "", 0)), 0)
+
+	// the workflow of host-1 is created and the running workflow completes
+	k8sCli.addWorkflow("host-1", "site-a", tink.WorkflowStateRunning)
+	k8sCli.workflows[0].Status.State = tink.WorkflowStateSuccess
+	assert.Equal(t, admit(siteEntry("host-2", "site-a", 0)), 2)
+	assert.Equal(t, admit(siteEntry("host-4", "site-a", 10)), 0)
+	assert.Equal(t, admit(siteEntry("host-2", "site-a", 0)), 1)
+
+	// deleted Instances leave the queue
+	q.release("host-2")
+	assert.Equal(t, admit(siteEntry("host-3", "site-a", 0)), 1)
+
+	// Instances no longer reconciled leave the queue, admitted hosts without a workflow stop counting
+	now = now.Add(admissionTTL + time.Second)
+	assert.Equal(t, admit(siteEntry("host-7", "site-a", 0)), 0)
+	assert.Equal(t, len(q.queued), 0)
+}
+
+func TestAdmitProvisioning(t *testing.T) {
+	currAdmission, currQueue := Admission, provisioningQueue
+	defer func() {
+		Admission, provisioningQueue = currAdmission, currQueue
+	}()
+	provisioningQueue = newAdmissionQueue()
+
+	instance := func(hostUUID, priority string) *computev1.InstanceResource {
+		return &computev1.InstanceResource{
+			TenantId: "tenant-1",
+			Host: &computev1.HostResource{
+				Uuid:     hostUUID,
+				Metadata: `[{"key":"provisioning-priority","value":"` + priority + `"}]`,
+				Site: &locationv1.SiteResource{
+					ResourceId: "site-12345678",
+					Region:     &locationv1.RegionResource{ResourceId: "region-12345678"},
+				},
+			},
+		}
+	}
+	assert.DeepEqual(t, workflowLabels(instance("host-1", "0")), map[string]string{
+		WorkflowLabelHost:   "host-1",
+		WorkflowLabelTenant: "tenant-1",
+		WorkflowLabelSite:   "site-12345678",
+		WorkflowLabelRegion: "region-12345678",
+	})
+
+	k8sCli := &workflowListClient{}
+	k8sCli.workflows = append(k8sCli.workflows, tink.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Labels: workflowLabels(instance("host-running", "0"))},
+	})
+
+	// no limits by default
+	position, err := admitProvisioning(context.Background(), k8sCli, instance("host-1", "0"))
+	assert.NilError(t, err)
+	assert.Equal(t, position, 0)
+
+	Admission = AdmissionLimits{PerRegion: 1}
+	position, err = admitProvisioning(context.Background(), k8sCli, instance("host-1", "0"))
+	assert.NilError(t, err)
+	assert.Equal(t, position, 1)
+	position, err = admitProvisioning(context.Background(), k8sCli, instance("host-2", "5"))
+	assert.NilError(t, err)
+	assert.Equal(t, position, 1)
+	// an invalid priority defaults to 0
+	position, err = admitProvisioning(context.Background(), k8sCli, instance("host-3", "high"))
+	assert.NilError(t, err)
+	assert.Equal(t, position, 3)
+}
+
+func TestPriorityFromMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		want     int
+		wantErr  bool
+	}{
+		{name: "No metadata", metadata: "", want: 0},
+		{name: "No priority", metadata: `[{"key":"redfish-endpoint","value":"https://bmc"}]`, want: 0},
+		{name: "Priority", metadata: `[{"key":"provisioning-priority","value":"-2"}]`, want: -2},
+		{name: "Invalid priority", metadata: `[{"key":"provisioning-priority","value":"high"}]`, wantErr: true},
+		{name: "Invalid metadata", metadata: `{"provisioning-priority":1}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := priorityFromMetadata(tt.metadata)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestQueuedStatus(t *testing.T) {
+	status := om_status.QueuedStatus(3, 0)
+	assert.Equal(t, status.Status, "Queued for provisioning (position 3)")
+
+	status = om_status.QueuedStatus(1, 2)
+	assert.Equal(t, status.Status, "Queued for reprovisioning (attempt 2) (position 1)")
+}
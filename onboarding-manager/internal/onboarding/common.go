@@ -43,3 +43,19 @@ func FetchClientSecret(ctx context.Context, tenantID, uuid string) (clientID, cl
 
 	return clientID, clientSecret, nil
 }
+
+// GetClientID returns the client ID of the EN credentials of the host, without creating them.
+func GetClientID(ctx context.Context, tenantID, uuid string) (string, error) {
+	authService, err := auth.AuthServiceFactory(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer authService.Logout(ctx)
+
+	clientID, _, err := authService.GetCredentialsByUUID(ctx, tenantID, uuid)
+	if err != nil {
+		zlogCliSecret.InfraSec().InfraErr(err).Msgf("Failed to get EN credentials for host %s", uuid)
+		return "", err
+	}
+	return clientID, nil
+}
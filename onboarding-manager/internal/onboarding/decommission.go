@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package onboarding
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/env"
+	onboarding_types "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding/types"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/redfish"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/sanitization"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell/templates"
+)
+
+// DecommissionConfig configures the sanitization of the disks of hosts before they are deleted.
+type DecommissionConfig struct {
+	// SanitizeByDefault sanitizes the disks of all the hosts, except the ones whose metadata opts out.
+	SanitizeByDefault bool
+	// Signer signs the sanitization records. The disks are never sanitized without a Signer.
+	Signer *sanitization.Signer
+}
+
+// Decommission is the decommission configuration, set at startup.
+var Decommission DecommissionConfig
+
+// decommissionAnnotationHost annotates the decommission workflows with the resource ID of their host, to tell
+// the workflow of a host from the one left over by a previous host of the same UUID.
+const decommissionAnnotationHost = "onboarding.edge-orchestrator.intel.com/host-resource-id"
+
+// generateDecommissionWorkflowName returns workflow name in format "decommission-<UUID>".
+func generateDecommissionWorkflowName(uuid string) string {
+	return fmt.Sprintf("decommission-%s", uuid)
+}
+
+// SanitizeHostDisks sanitizes the disks of a host being deleted with the decommission workflow, and keeps the signed
+// record of the sanitization. Until the workflow completes, it returns the current step of the workflow as status
+// detail and an OPERATION_IN_PROGRESS error. It returns nil once the disks are sanitized, or if the disks of the
+// host are not to be sanitized. The workflow of a sanitized host is kept until DeleteDecommissionWorkflowIfExists
+// is called once the host is deleted, so that the disks are not sanitized again if the deletion is retried.
+// A failed sanitization is recorded and blocks the deletion of the host, until its metadata opts out of the
+// sanitization, or its workflow is deleted to retry the sanitization.
+func SanitizeHostDisks(ctx context.Context, host *computev1.HostResource) (string, error) {
+	if Decommission.Signer == nil {
+		return "", nil
+	}
+	policy, err := sanitization.PolicyFromMetadata(host.GetMetadata())
+	if err != nil {
+		return "", err
+	}
+	workflowName := generateDecommissionWorkflowName(host.GetUuid())
+	if !policy.Sanitize(Decommission.SanitizeByDefault) {
+		// the workflow of a failed sanitization is kept until the host opts out
+		return "", tinkerbell.DeleteWorkflowIfExists(ctx, env.K8sNamespace, workflowName)
+	}
+
+	kubeClient, err := tinkerbell.K8sClientFactory()
+	if err != nil {
+		return "", err
+	}
+	workflow := &tink.Workflow{}
+	clientErr := kubeClient.Get(ctx, types.NamespacedName{Namespace: env.K8sNamespace, Name: workflowName}, workflow)
+	if clientErr != nil && errors.IsNotFound(clientErr) {
+		return runDecommissionWorkflow(ctx, kubeClient, host)
+	}
+	if clientErr != nil {
+		zlog.InfraSec().InfraErr(clientErr).Msgf("Failed to get workflow %s status", workflowName)
+		return "", inv_errors.Errorf("Failed to get workflow %s status.", workflowName)
+	}
+	if workflow.Annotations[decommissionAnnotationHost] != host.GetResourceId() {
+		zlog.InfraSec().Info().Msgf("Deleting workflow %s of a previous host %s", workflowName, host.GetUuid())
+		if err := tinkerbell.DeleteWorkflowIfExists(ctx, env.K8sNamespace, workflowName); err != nil {
+			return "", err
+		}
+		return runDecommissionWorkflow(ctx, kubeClient, host)
+	}
+
+	detail := tinkerbell.GenerateStatusDetailFromWorkflowState(workflow)
+	zlog.Debug().Msgf("Workflow %s status for host %s is %s. Workflow state: %q", workflow.Name, host.GetUuid(),
+		workflow.Status.State, detail)
+
+	record := sanitization.Record{
+		HostUUID:       host.GetUuid(),
+		HostResourceID: host.GetResourceId(),
+		TenantID:       host.GetTenantId(),
+		SerialNumber:   host.GetSerialNumber(),
+		Result:         sanitization.ResultFailed,
+		RecordedAt:     time.Now().UTC(),
+	}
+	switch workflow.Status.State {
+	case tink.WorkflowStateSuccess:
+		if record.Report, err = reportFromDecommissionWorkflow(workflow); err != nil {
+			record.Error = err.Error()
+		} else {
+			record.Result = sanitization.ResultSanitized
+		}
+	case tink.WorkflowStateFailed, tink.WorkflowStateTimeout:
+		record.Error = detail
+		// the report of a failed sanitization tells the disks that were sanitized from the ones that were not
+		if report, reportErr := reportFromDecommissionWorkflow(workflow); reportErr == nil {
+			record.Report = report
+		}
+	case "", tink.WorkflowStateRunning, tink.WorkflowStatePending:
+		return detail, inv_errors.Errorfr(inv_errors.Reason_OPERATION_IN_PROGRESS, "Disk sanitization in progress")
+	default:
+		zlog.InfraSec().InfraError("Unknown workflow state %s", workflow.Status.State)
+		return detail, inv_errors.Errorf("Unknown workflow state %s", workflow.Status.State)
+	}
+
+	if err := saveSanitizationRecord(ctx, kubeClient, workflow, record); err != nil {
+		return detail, err
+	}
+	if record.Result != sanitization.ResultSanitized {
+		zlog.InfraSec().Warn().Msgf("Disk sanitization of host %s failed: %s", host.GetUuid(), record.Error)
+		return detail, inv_errors.Errorfc(codes.Aborted, "Disk sanitization failed: %s", record.Error)
+	}
+	zlog.InfraSec().Info().Msgf("Disks of host %s sanitized", host.GetUuid())
+	return "", nil
+}
+
+// DeleteDecommissionWorkflowIfExists deletes the decommission workflow of a deleted host.
+func DeleteDecommissionWorkflowIfExists(ctx context.Context, hostUUID string) error {
+	if Decommission.Signer == nil {
+		return nil
+	}
+	return tinkerbell.DeleteWorkflowIfExists(ctx, env.K8sNamespace, generateDecommissionWorkflowName(hostUUID))
+}
+
+// runDecommissionWorkflow creates the decommission workflow of a host and network boots the host to run it.
+func runDecommissionWorkflow(ctx context.Context, k8sCli client.Client, host *computev1.HostResource) (string, error) {
+	redfishEndpoint, err := redfish.EndpointFromMetadata(host.GetMetadata())
+	if err != nil {
+		zlog.Warn().Err(err).Msgf("Ignoring the Redfish endpoint of host %s", host.GetResourceId())
+	}
+	deviceInfo := onboarding_types.DeviceInfo{
+		GUID:            host.GetUuid(),
+		HwSerialID:      host.GetSerialNumber(),
+		HwMacID:         host.GetPxeMac(),
+		Architecture:    tinkerbell.HostArchitecture(host.GetCpuArchitecture()),
+		Hostname:        host.GetResourceId(),
+		TinkerVersion:   env.TinkerActionVersion,
+		RedfishEndpoint: redfishEndpoint,
+	}
+
+	workflow := tinkerbell.NewWorkflow(
+		generateDecommissionWorkflowName(deviceInfo.GUID),
+		env.K8sNamespace,
+		tinkerbell.DummyHardwareName,
+		templates.DecommissionTemplateName,
+		tinkerbell.GenerateDecommissionWorkflowInputs(deviceInfo))
+	// not labeled with the scopes of the admission control, that only limits provisioning
+	workflow.Labels = map[string]string{WorkflowLabelHost: deviceInfo.GUID}
+	workflow.Annotations = map[string]string{decommissionAnnotationHost: host.GetResourceId()}
+	if err := tinkerbell.CreateWorkflowIfNotExists(ctx, k8sCli, workflow); err != nil {
+		return "", err
+	}
+	zlog.InfraSec().Info().Msgf("Decommission workflow %s for host %s created", workflow.Name, deviceInfo.GUID)
+
+	if err := NetbootTriggerFactory().Netboot(ctx, deviceInfo); err != nil {
+		zlog.InfraSec().InfraErr(err).Msgf("Failed to network boot host %s for disk sanitization", deviceInfo.GUID)
+		// created again, and the host network booted, on the next attempt
+		if delErr := tinkerbell.DeleteWorkflowIfExists(ctx, env.K8sNamespace, workflow.Name); delErr != nil {
+			return "", delErr
+		}
+		return "", inv_errors.Errorfc(codes.Unavailable, "%v", err)
+	}
+	return tinkerbell.WorkflowStepToStatusDetail[tinkerbell.ActionSanitizeDisks],
+		inv_errors.Errorfr(inv_errors.Reason_OPERATION_IN_PROGRESS, "Decommission workflow started")
+}
+
+// reportFromDecommissionWorkflow returns the sanitization report of a successful decommission workflow.
+func reportFromDecommissionWorkflow(workflow *tink.Workflow) (*sanitization.Report, error) {
+	for _, task := range workflow.Status.Tasks {
+		for _, action := range task.Actions {
+			if action.Name != tinkerbell.ActionSanitizeDisks {
+				continue
+			}
+			data, ok := tinkerbell.ReportFromAction(action)
+			if !ok {
+				return nil, inv_errors.Errorfc(codes.InvalidArgument, "%s reported no sanitization report", action.Name)
+			}
+			return sanitization.ParseReport(data)
+		}
+	}
+	return nil, inv_errors.Errorfc(codes.InvalidArgument, "No %s action in workflow %s",
+		tinkerbell.ActionSanitizeDisks, workflow.Name)
+}
+
+// saveSanitizationRecord signs record and saves it in the namespace of the workflows. The record of a workflow is
+// saved once, however many times the deletion of the host is reconciled.
+func saveSanitizationRecord(ctx context.Context, k8sCli client.Client, workflow *tink.Workflow,
+	record sanitization.Record,
+) error {
+	signed, err := Decommission.Signer.Sign(record)
+	if err != nil {
+		return err
+	}
+	name := sanitization.RecordName(record.HostUUID, workflow.CreationTimestamp.Unix())
+	return sanitization.NewStore(k8sCli, env.K8sNamespace).Save(ctx, name, record, signed)
+}
@@ -0,0 +1,306 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // Keeping the test in the same package due to dependencies on unexported fields.
+package onboarding
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/env"
+	onboarding_types "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding/types"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/sanitization"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell/templates"
+)
+
+const sanitizeReport = `{"startedAt":"2025-06-02T10:00:00Z","completedAt":"2025-06-02T10:02:13Z","disks":[` +
+	`{"device":"/dev/sda","serial":"BTYF12345678","sizeBytes":480103981056,"method":"ata-enhanced-secure-erase",` +
+	`"result":"sanitized","verified":false,"startedAt":"2025-06-02T10:00:00Z","completedAt":"2025-06-02T10:02:13Z"}]}`
+
+func newDecommissionClient(t *testing.T) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	assert.NilError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NilError(t, tink.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).Build()
+}
+
+func getDecommissionWorkflow(t *testing.T, k8sCli client.Client, hostUUID string) (*tink.Workflow, error) {
+	t.Helper()
+	workflow := &tink.Workflow{}
+	err := k8sCli.Get(context.Background(), types.NamespacedName{
+		Namespace: env.K8sNamespace, Name: generateDecommissionWorkflowName(hostUUID),
+	}, workflow)
+	return workflow, err
+}
+
+func setDecommissionWorkflowState(t *testing.T, k8sCli client.Client, hostUUID string, state tink.WorkflowState,
+	action tink.Action,
+) {
+	t.Helper()
+	workflow, err := getDecommissionWorkflow(t, k8sCli, hostUUID)
+	assert.NilError(t, err)
+	workflow.Status = tink.WorkflowStatus{State: state, Tasks: []tink.Task{{Actions: []tink.Action{action}}}}
+	assert.NilError(t, k8sCli.Update(context.Background(), workflow))
+}
+
+func decommissionRecords(t *testing.T, k8sCli client.Client, pub ed25519.PublicKey) []sanitization.Record {
+	t.Helper()
+	var cms corev1.ConfigMapList
+	assert.NilError(t, k8sCli.List(context.Background(), &cms))
+	records := make([]sanitization.Record, 0, len(cms.Items))
+	for _, cm := range cms.Items {
+		var signed sanitization.SignedRecord
+		assert.NilError(t, json.Unmarshal([]byte(cm.Data[sanitization.RecordKey]), &signed))
+		record, err := sanitization.Verify(pub, signed)
+		assert.NilError(t, err)
+		records = append(records, record)
+	}
+	return records
+}
+
+func setupDecommission(t *testing.T) (client.Client, ed25519.PublicKey) {
+	t.Helper()
+	currK8sClientFactory := tinkerbell.K8sClientFactory
+	currNetbootTriggerFactory := NetbootTriggerFactory
+	currDecommission := Decommission
+	t.Cleanup(func() {
+		tinkerbell.K8sClientFactory = currK8sClientFactory
+		NetbootTriggerFactory = currNetbootTriggerFactory
+		Decommission = currDecommission
+	})
+	k8sCli := newDecommissionClient(t)
+	tinkerbell.K8sClientFactory = func() (client.Client, error) { return k8sCli, nil }
+
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NilError(t, err)
+	Decommission = DecommissionConfig{Signer: sanitization.NewSigner(key)}
+	return k8sCli, pub
+}
+
+func TestSanitizeHostDisks(t *testing.T) {
+	k8sCli, pub := setupDecommission(t)
+	ctx := context.Background()
+	host := &computev1.HostResource{
+		ResourceId:   "host-12345678",
+		TenantId:     "tenant-1",
+		Uuid:         "57ed598c-4b94-11ee-806c-3a7c7693aac3",
+		SerialNumber: "SN12345",
+		PxeMac:       "90:49:fa:ff:ff:ff",
+		Metadata:     `[{"key":"disk-sanitization","value":"required"}]`,
+	}
+	var booted []onboarding_types.DeviceInfo
+	NetbootTriggerFactory = func() NetbootTrigger {
+		return netbootFunc(func(_ context.Context, deviceInfo onboarding_types.DeviceInfo) error {
+			booted = append(booted, deviceInfo)
+			return nil
+		})
+	}
+
+	// the workflow is created and the host network booted to run it
+	detail, err := SanitizeHostDisks(ctx, host)
+	assert.Assert(t, inv_errors.IsOperationInProgress(err))
+	assert.Equal(t, detail, "Sanitizing disks")
+	assert.Equal(t, len(booted), 1)
+	assert.Equal(t, booted[0].HwMacID, host.PxeMac)
+	workflow, err := getDecommissionWorkflow(t, k8sCli, host.Uuid)
+	assert.NilError(t, err)
+	assert.Equal(t, workflow.Spec.TemplateRef, templates.DecommissionTemplateName)
+	assert.Equal(t, workflow.Spec.HardwareMap["DeviceInfoHwMacID"], host.PxeMac)
+	assert.Assert(t, workflow.Spec.HardwareMap["TinkerActionImageDiskSanitize"] != "")
+	assert.DeepEqual(t, workflow.Labels, map[string]string{WorkflowLabelHost: host.Uuid})
+
+	setDecommissionWorkflowState(t, k8sCli, host.Uuid, tink.WorkflowStateRunning, tink.Action{
+		Name: tinkerbell.ActionSanitizeDisks, Status: tink.WorkflowStateRunning,
+	})
+	detail, err = SanitizeHostDisks(ctx, host)
+	assert.Assert(t, inv_errors.IsOperationInProgress(err))
+	assert.Equal(t, detail, "1/1: Sanitizing disks")
+	assert.Equal(t, len(booted), 1)
+
+	setDecommissionWorkflowState(t, k8sCli, host.Uuid, tink.WorkflowStateSuccess, tink.Action{
+		Name: tinkerbell.ActionSanitizeDisks, Status: tink.WorkflowStateSuccess, Message: "report: " + sanitizeReport,
+	})
+	detail, err = SanitizeHostDisks(ctx, host)
+	assert.NilError(t, err)
+	assert.Equal(t, detail, "")
+	// reconciled again, e.g. if a later step of the deletion failed
+	_, err = SanitizeHostDisks(ctx, host)
+	assert.NilError(t, err)
+	assert.Equal(t, len(booted), 1)
+
+	records := decommissionRecords(t, k8sCli, pub)
+	assert.Equal(t, len(records), 1)
+	assert.Equal(t, records[0].HostUUID, host.Uuid)
+	assert.Equal(t, records[0].HostResourceID, host.ResourceId)
+	assert.Equal(t, records[0].SerialNumber, host.SerialNumber)
+	assert.Equal(t, records[0].Result, sanitization.ResultSanitized)
+	assert.Equal(t, records[0].Report.Disks[0].Serial, "BTYF12345678")
+
+	assert.NilError(t, DeleteDecommissionWorkflowIfExists(ctx, host.Uuid))
+	_, err = getDecommissionWorkflow(t, k8sCli, host.Uuid)
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestSanitizeHostDisks_Failure(t *testing.T) {
+	k8sCli, pub := setupDecommission(t)
+	Decommission.SanitizeByDefault = true
+	ctx := context.Background()
+	host := &computev1.HostResource{ResourceId: "host-12345678", Uuid: "57ed598c-4b94-11ee-806c-3a7c7693aac3"}
+	NetbootTriggerFactory = func() NetbootTrigger {
+		return netbootFunc(func(context.Context, onboarding_types.DeviceInfo) error { return nil })
+	}
+
+	_, err := SanitizeHostDisks(ctx, host)
+	assert.Assert(t, inv_errors.IsOperationInProgress(err))
+	setDecommissionWorkflowState(t, k8sCli, host.Uuid, tink.WorkflowStateFailed, tink.Action{
+		Name: tinkerbell.ActionSanitizeDisks, Status: tink.WorkflowStateFailed,
+		Message: "exit status 91\nreport: " + sanitizeReport,
+	})
+
+	// the failure blocks the deletion, and is recorded once
+	for range 2 {
+		detail, err := SanitizeHostDisks(ctx, host)
+		assert.Equal(t, grpc_status.Code(err), codes.Aborted)
+		assert.Equal(t, detail, "1/1: Sanitizing disks failed (DISK_ERASE_FAILED): exit status 91")
+	}
+	records := decommissionRecords(t, k8sCli, pub)
+	assert.Equal(t, len(records), 1)
+	assert.Equal(t, records[0].Result, sanitization.ResultFailed)
+	assert.Equal(t, records[0].Error, "1/1: Sanitizing disks failed (DISK_ERASE_FAILED): exit status 91")
+	// the report of the failed sanitization is recorded too
+	assert.Assert(t, records[0].Report != nil)
+	assert.Equal(t, records[0].Report.Disks[0].Serial, "BTYF12345678")
+
+	// the host opts out, its workflow is deleted
+	host.Metadata = `[{"key":"disk-sanitization","value":"skip"}]`
+	detail, err := SanitizeHostDisks(ctx, host)
+	assert.NilError(t, err)
+	assert.Equal(t, detail, "")
+	_, err = getDecommissionWorkflow(t, k8sCli, host.Uuid)
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestSanitizeHostDisks_ReportedByWorker(t *testing.T) {
+	k8sCli, pub := setupDecommission(t)
+	// the workflow is updated as by the Tinkerbell server, through its status only
+	k8sCli = newWorkflowStatusClient(t)
+	tinkerbell.K8sClientFactory = func() (client.Client, error) { return k8sCli, nil }
+	Decommission.SanitizeByDefault = true
+	ctx := context.Background()
+	host := &computev1.HostResource{ResourceId: "host-12345678", Uuid: "57ed598c-4b94-11ee-806c-3a7c7693aac3"}
+	NetbootTriggerFactory = func() NetbootTrigger {
+		return netbootFunc(func(context.Context, onboarding_types.DeviceInfo) error { return nil })
+	}
+
+	_, err := SanitizeHostDisks(ctx, host)
+	assert.Assert(t, inv_errors.IsOperationInProgress(err))
+	workflow, err := getDecommissionWorkflow(t, k8sCli, host.Uuid)
+	assert.NilError(t, err)
+	workflow.Status = pendingWorkflowStatus("Disk sanitization", tinkerbell.ActionSanitizeDisks)
+	assert.NilError(t, k8sCli.Status().Update(ctx, workflow))
+
+	// tink-worker reports the report to the onboarding manager before the success to the Tinkerbell server, which
+	// drops the message reported along
+	key := client.ObjectKeyFromObject(workflow)
+	now := time.Now()
+	reportActionStatus(t, k8sCli, key, tink.WorkflowStateRunning, now)
+	assert.NilError(t, reportActionMessage(ctx, k8sCli, host.Uuid, env.K8sNamespace+"/"+workflow.Name,
+		"Disk sanitization", tinkerbell.ActionSanitizeDisks, "report: "+sanitizeReport))
+	reportActionStatus(t, k8sCli, key, tink.WorkflowStateSuccess, now.Add(2*time.Minute))
+	postProcessWorkflow(t, k8sCli, key)
+
+	detail, err := SanitizeHostDisks(ctx, host)
+	assert.NilError(t, err)
+	assert.Equal(t, detail, "")
+	records := decommissionRecords(t, k8sCli, pub)
+	assert.Equal(t, len(records), 1)
+	assert.Equal(t, records[0].Result, sanitization.ResultSanitized)
+	assert.Equal(t, records[0].Report.Disks[0].Serial, "BTYF12345678")
+}
+
+func TestSanitizeHostDisks_PreviousHostWorkflow(t *testing.T) {
+	k8sCli, _ := setupDecommission(t)
+	Decommission.SanitizeByDefault = true
+	ctx := context.Background()
+	NetbootTriggerFactory = func() NetbootTrigger {
+		return netbootFunc(func(context.Context, onboarding_types.DeviceInfo) error { return nil })
+	}
+	previous := &computev1.HostResource{ResourceId: "host-11111111", Uuid: "57ed598c-4b94-11ee-806c-3a7c7693aac3"}
+	_, err := SanitizeHostDisks(ctx, previous)
+	assert.Assert(t, inv_errors.IsOperationInProgress(err))
+	setDecommissionWorkflowState(t, k8sCli, previous.Uuid, tink.WorkflowStateSuccess, tink.Action{
+		Name: tinkerbell.ActionSanitizeDisks, Status: tink.WorkflowStateSuccess, Message: "report: " + sanitizeReport,
+	})
+
+	// the successful workflow of a previous host of the same UUID is not taken for the one of the host
+	host := &computev1.HostResource{ResourceId: "host-22222222", Uuid: previous.Uuid}
+	_, err = SanitizeHostDisks(ctx, host)
+	assert.Assert(t, inv_errors.IsOperationInProgress(err))
+	workflow, err := getDecommissionWorkflow(t, k8sCli, host.Uuid)
+	assert.NilError(t, err)
+	assert.Equal(t, workflow.Annotations[decommissionAnnotationHost], host.ResourceId)
+	assert.Equal(t, workflow.Status.State, tink.WorkflowState(""))
+}
+
+func TestSanitizeHostDisks_Disabled(t *testing.T) {
+	k8sCli, _ := setupDecommission(t)
+	ctx := context.Background()
+	host := &computev1.HostResource{Uuid: "57ed598c-4b94-11ee-806c-3a7c7693aac3"}
+
+	// sanitization is opt-in unless enabled for all the hosts
+	detail, err := SanitizeHostDisks(ctx, host)
+	assert.NilError(t, err)
+	assert.Equal(t, detail, "")
+	_, err = getDecommissionWorkflow(t, k8sCli, host.Uuid)
+	assert.ErrorContains(t, err, "not found")
+
+	host.Metadata = `[{"key":"disk-sanitization","value":"always"}]`
+	_, err = SanitizeHostDisks(ctx, host)
+	assert.Equal(t, grpc_status.Code(err), codes.InvalidArgument)
+
+	// never sanitized without a signing key
+	Decommission.Signer = nil
+	host.Metadata = `[{"key":"disk-sanitization","value":"required"}]`
+	detail, err = SanitizeHostDisks(ctx, host)
+	assert.NilError(t, err)
+	assert.Equal(t, detail, "")
+	assert.NilError(t, DeleteDecommissionWorkflowIfExists(ctx, host.Uuid))
+}
+
+func TestSanitizeHostDisks_NetbootFailure(t *testing.T) {
+	k8sCli, _ := setupDecommission(t)
+	Decommission.SanitizeByDefault = true
+	host := &computev1.HostResource{ResourceId: "host-12345678", Uuid: "57ed598c-4b94-11ee-806c-3a7c7693aac3"}
+	NetbootTriggerFactory = func() NetbootTrigger {
+		return netbootFunc(func(context.Context, onboarding_types.DeviceInfo) error {
+			return errors.New("BMC rejected the request")
+		})
+	}
+
+	// the workflow is deleted, so that the host is network booted again on the next attempt
+	_, err := SanitizeHostDisks(context.Background(), host)
+	assert.Equal(t, grpc_status.Code(err), codes.Unavailable)
+	_, err = getDecommissionWorkflow(t, k8sCli, host.Uuid)
+	assert.ErrorContains(t, err, "not found")
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package onboarding
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	inv_status "github.com/open-edge-platform/infra-core/inventory/v2/pkg/status"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/util"
+	om_status "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/status"
+)
+
+// FirstBootTimeout is how long the installed OS has to confirm its first boot once the workflow of an Instance
+// succeeded, before the provisioning of the Instance fails. Zero disables the first boot confirmation,
+// Instances are then provisioned as soon as their workflow succeeds. It is left disabled, and not exposed as a
+// flag, until the installed OSes ship a caller of the ConfirmFirstBoot RPC: every provisioning would fail otherwise.
+var FirstBootTimeout time.Duration
+
+// awaitFirstBoot keeps an Instance whose workflow succeeded in the verifying status until its first boot is
+// confirmed through the ConfirmFirstBoot RPC, which also deletes the workflow. The verification starts when
+// the verifying status is first reported, as recorded by the provisioning status timestamp.
+func awaitFirstBoot(instance *computev1.InstanceResource,
+	verifyingProvisioningStatus, onFailureProvisioningStatus inv_status.ResourceStatus,
+) error {
+	if !om_status.IsVerifyingFirstBoot(instance.GetProvisioningStatus()) {
+		util.PopulateInstanceStatusAndCurrentState(
+			instance, computev1.InstanceState_INSTANCE_STATE_UNSPECIFIED, verifyingProvisioningStatus)
+
+		isStandalone, err := util.IsStandalone(instance)
+		if err != nil {
+			return err
+		}
+		if !isStandalone {
+			// set host status to "rebooting" since every successful workflow ends with a reboot
+			util.PopulateHostStatus(instance, om_status.HostStatusRebooting)
+		}
+		zlog.Debug().Msgf("Waiting up to %s for the first boot of host %s", FirstBootTimeout, instance.GetHost().GetUuid())
+		return inv_errors.Errorfr(inv_errors.Reason_OPERATION_IN_PROGRESS, "Waiting for the first boot confirmation")
+	}
+
+	startedAt := instance.GetProvisioningStatusTimestamp()
+	if startedAt != 0 && time.Since(time.Unix(int64(startedAt), 0)) > FirstBootTimeout { // #nosec G115
+		zlog.InfraSec().Warn().Msgf("First boot of host %s not confirmed within %s",
+			instance.GetHost().GetUuid(), FirstBootTimeout)
+		util.PopulateInstanceProvisioningStatus(instance, om_status.NewStatusWithDetails(onFailureProvisioningStatus,
+			fmt.Sprintf("first boot not confirmed within %s", FirstBootTimeout)))
+		return inv_errors.Errorfc(codes.Aborted, "First boot not confirmed within %s", FirstBootTimeout)
+	}
+	return inv_errors.Errorfr(inv_errors.Reason_OPERATION_IN_PROGRESS, "Waiting for the first boot confirmation")
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package onboarding
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
+)
+
+// WorkerSilenceTimeout is how long the tink-worker running the workflow of an Instance may go without reporting
+// a heartbeat, e.g. after a kernel panic of the Hook OS or a network outage, before the workflow is reported as
+// unresponsive. Zero disables the detection, such workflows then run until their timeout.
+var WorkerSilenceTimeout time.Duration
+
+// FailUnresponsiveWorkflows fails the workflows whose worker is unresponsive rather than only reporting them, so
+// that the provisioning fails, and can be retried, without waiting for the timeout of the workflow.
+var FailUnresponsiveWorkflows bool
+
+// workerHeartbeat is the last heartbeat seen for the running action of a workflow, and when it was seen.
+type workerHeartbeat struct {
+	action string
+	seq    int64
+	seenAt time.Time
+	// sends tells whether the worker was seen sending heartbeats, workers that don't are never unresponsive
+	sends bool
+}
+
+var (
+	heartbeatsMu sync.Mutex
+	heartbeats   = make(map[string]workerHeartbeat)
+)
+
+// workerSilence returns for how long the worker running the workflow has not sent a new heartbeat, or started a
+// new action. It returns false if no action is running, or if its worker was not seen sending heartbeats.
+// The silence is measured from when a heartbeat is first seen by the onboarding manager rather than by the clock of
+// the host, and so starts over when the onboarding manager restarts.
+func workerSilence(workflow *tink.Workflow, now time.Time) (time.Duration, bool) {
+	heartbeatsMu.Lock()
+	defer heartbeatsMu.Unlock()
+
+	action, running := tinkerbell.RunningAction(workflow)
+	if workflow.Status.State != tink.WorkflowStateRunning || !running {
+		delete(heartbeats, workflow.Name)
+		return 0, false
+	}
+
+	heartbeat, ok := tinkerbell.HeartbeatFromAction(action)
+	last, seen := heartbeats[workflow.Name]
+	switch {
+	case !seen || last.action != action.Name:
+		// the worker was alive when it started the action
+		last = workerHeartbeat{action: action.Name, seq: heartbeat.Seq, seenAt: now, sends: last.sends || ok}
+	case ok && heartbeat.Seq != last.seq:
+		last = workerHeartbeat{action: action.Name, seq: heartbeat.Seq, seenAt: now, sends: true}
+	}
+	heartbeats[workflow.Name] = last
+
+	if !last.sends {
+		return 0, false
+	}
+	return now.Sub(last.seenAt), true
+}
+
+// forgetWorkerHeartbeats forgets the heartbeats seen for a workflow.
+func forgetWorkerHeartbeats(workflowName string) {
+	heartbeatsMu.Lock()
+	defer heartbeatsMu.Unlock()
+	delete(heartbeats, workflowName)
+}
+
+// unresponsiveWorker returns why the worker running the workflow is unresponsive, e.g. "worker unresponsive for
+// 10m0s", an empty string if it is not.
+func unresponsiveWorker(workflow *tink.Workflow) string {
+	if WorkerSilenceTimeout <= 0 {
+		return ""
+	}
+	silence, ok := workerSilence(workflow, time.Now())
+	if !ok || silence < WorkerSilenceTimeout {
+		return ""
+	}
+	return fmt.Sprintf("%s for %s", tinkerbell.WorkerUnresponsiveMessage, silence.Round(time.Second))
+}
+
+// failUnresponsiveWorkflow fails the running action of the workflow if its worker is unresponsive. The workflow is
+// then handled as any failed workflow.
+func failUnresponsiveWorkflow(ctx context.Context, k8sCli client.Client, workflow *tink.Workflow) error {
+	unresponsive := unresponsiveWorker(workflow)
+	if unresponsive == "" {
+		return nil
+	}
+	zlog.InfraSec().Warn().Msgf("Failing workflow %s: %s", workflow.Name, unresponsive)
+	if err := tinkerbell.FailRunningAction(ctx, k8sCli, workflow, unresponsive); err != nil {
+		return err
+	}
+	forgetWorkerHeartbeats(workflow.Name)
+	return nil
+}
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // Keeping the test in the same package due to dependencies on unexported fields.
+package onboarding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+	"gotest.tools/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/env"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
+)
+
+func heartbeatWorkflow(action, message string) *tink.Workflow {
+	return &tink.Workflow{
+		Status: tink.WorkflowStatus{
+			State: tink.WorkflowStateRunning,
+			Tasks: []tink.Task{{Actions: []tink.Action{
+				{Name: "erase-non-removable-disk", Status: tink.WorkflowStateSuccess},
+				{Name: action, Status: tink.WorkflowStateRunning, Message: message},
+			}}},
+		},
+	}
+}
+
+func Test_workerSilence(t *testing.T) {
+	now := time.Now()
+	wf := heartbeatWorkflow("stream-os-image", "Started execution")
+	wf.Name = "workflow-heartbeat"
+	defer forgetWorkerHeartbeats(wf.Name)
+
+	// the worker is not known to send heartbeats yet
+	_, ok := workerSilence(wf, now)
+	assert.Assert(t, !ok)
+
+	wf = heartbeatWorkflow("stream-os-image", `heartbeat: {"seq":1,"action":"stream-os-image"}`)
+	wf.Name = "workflow-heartbeat"
+	silence, ok := workerSilence(wf, now.Add(time.Minute))
+	assert.Assert(t, ok)
+	assert.Equal(t, silence, time.Duration(0))
+
+	// the same heartbeat seen again
+	silence, ok = workerSilence(wf, now.Add(3*time.Minute))
+	assert.Assert(t, ok)
+	assert.Equal(t, silence, 2*time.Minute)
+
+	// a new heartbeat, along with a progress
+	wf = heartbeatWorkflow("stream-os-image",
+		"progress: {\"stage\":\"writing OS image\"}\nheartbeat: {\"seq\":2,\"action\":\"stream-os-image\"}")
+	wf.Name = "workflow-heartbeat"
+	silence, ok = workerSilence(wf, now.Add(4*time.Minute))
+	assert.Assert(t, ok)
+	assert.Equal(t, silence, time.Duration(0))
+
+	// a new action started, before its first heartbeat
+	wf = heartbeatWorkflow("reboot", "Started execution")
+	wf.Name = "workflow-heartbeat"
+	silence, ok = workerSilence(wf, now.Add(6*time.Minute))
+	assert.Assert(t, ok)
+	assert.Equal(t, silence, time.Duration(0))
+	silence, _ = workerSilence(wf, now.Add(16*time.Minute))
+	assert.Equal(t, silence, 10*time.Minute)
+
+	// the workflow is not running anymore
+	wf.Status.State = tink.WorkflowStateFailed
+	_, ok = workerSilence(wf, now.Add(17*time.Minute))
+	assert.Assert(t, !ok)
+}
+
+func Test_workerSilence_ReportedHeartbeats(t *testing.T) {
+	hostUUID := "7f1c9a52-2b8e-4c1d-9e3a-5d6f7a8b9c0d"
+	workflow := newPendingWorkflow(generateWorkflowName(hostUUID), testTaskName, tinkerbell.ActionStreamOSImage)
+	k8sCli := newWorkflowStatusClient(t, workflow)
+	key := client.ObjectKeyFromObject(workflow)
+	workflowID := env.K8sNamespace + "/" + workflow.Name
+	defer forgetWorkerHeartbeats(workflow.Name)
+	now := time.Now()
+	reportActionStatus(t, k8sCli, key, tink.WorkflowStateRunning, now)
+	started := &tink.Workflow{}
+	assert.NilError(t, k8sCli.Get(context.Background(), key, started))
+
+	// the heartbeats reported by tink-worker are kept, and do not restart the action
+	for seq, at := range []time.Duration{time.Minute, 2 * time.Minute} {
+		assert.NilError(t, reportActionMessage(context.Background(), k8sCli, hostUUID, workflowID, testTaskName,
+			tinkerbell.ActionStreamOSImage, fmt.Sprintf(`heartbeat: {"seq":%d,"action":"stream-os-image"}`, seq+1)))
+		got := &tink.Workflow{}
+		assert.NilError(t, k8sCli.Get(context.Background(), key, got))
+		silence, ok := workerSilence(got, now.Add(at))
+		assert.Assert(t, ok)
+		assert.Equal(t, silence, time.Duration(0))
+		assert.DeepEqual(t, got.Status.Tasks[0].Actions[0].StartedAt, started.Status.Tasks[0].Actions[0].StartedAt)
+	}
+}
+
+func Test_unresponsiveWorker(t *testing.T) {
+	currTimeout := WorkerSilenceTimeout
+	defer func() {
+		WorkerSilenceTimeout = currTimeout
+	}()
+	wf := heartbeatWorkflow("stream-os-image", `heartbeat: {"seq":1,"action":"stream-os-image"}`)
+	wf.Name = "workflow-unresponsive"
+	defer forgetWorkerHeartbeats(wf.Name)
+
+	WorkerSilenceTimeout = 0
+	assert.Equal(t, unresponsiveWorker(wf), "")
+
+	WorkerSilenceTimeout = time.Minute
+	assert.Equal(t, unresponsiveWorker(wf), "")
+
+	heartbeatsMu.Lock()
+	last := heartbeats[wf.Name]
+	last.seenAt = last.seenAt.Add(-10 * time.Minute)
+	heartbeats[wf.Name] = last
+	heartbeatsMu.Unlock()
+	assert.Equal(t, unresponsiveWorker(wf), "worker unresponsive for 10m0s")
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package onboarding
+
+import (
+	"context"
+	"time"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/maintenance"
+	onboarding_types "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding/types"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/util"
+	om_status "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/status"
+)
+
+// MaintenanceWindowsFunc returns the maintenance windows the workflow of an Instance may only be started in,
+// e.g. maintenance.Resolver.Windows. Workflows are started at any time if nil. Running workflows are never
+// interrupted when a window closes.
+var MaintenanceWindowsFunc func(ctx context.Context, instance *computev1.InstanceResource) (maintenance.Windows, error)
+
+// maintenanceClock returns the time maintenance windows are evaluated at.
+var maintenanceClock = time.Now
+
+// awaitMaintenanceWindow returns an OPERATION_IN_PROGRESS error and reports the next opening in the provisioning
+// status if the maintenance windows of an Instance are closed.
+func awaitMaintenanceWindow(ctx context.Context,
+	deviceInfo onboarding_types.DeviceInfo,
+	instance *computev1.InstanceResource,
+) error {
+	if MaintenanceWindowsFunc == nil {
+		return nil
+	}
+	windows, err := MaintenanceWindowsFunc(ctx, instance)
+	if err != nil {
+		zlog.InfraSec().InfraErr(err).Msgf("Failed to get the maintenance windows of host %s", deviceInfo.GUID)
+		return err
+	}
+
+	open, next := windows.Open(maintenanceClock())
+	if open {
+		return nil
+	}
+	zlog.Debug().Msgf("Provisioning of host %s waits for the maintenance window opening at %s", deviceInfo.GUID, next)
+	util.PopulateInstanceStatusAndCurrentState(instance, computev1.InstanceState_INSTANCE_STATE_UNSPECIFIED,
+		om_status.MaintenanceWindowStatus(next, deviceInfo.ReprovisionAttempt))
+	return inv_errors.Errorfr(inv_errors.Reason_OPERATION_IN_PROGRESS, "Waiting for a maintenance window")
+}
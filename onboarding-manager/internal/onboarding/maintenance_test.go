@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // Keeping the test in the same package due to dependencies on unexported fields.
+package onboarding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+	"gotest.tools/assert"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/maintenance"
+	onboarding_types "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding/types"
+	om_status "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/status"
+)
+
+func TestAwaitMaintenanceWindow(t *testing.T) {
+	currWindowsFunc, currClock := MaintenanceWindowsFunc, maintenanceClock
+	defer func() {
+		MaintenanceWindowsFunc, maintenanceClock = currWindowsFunc, currClock
+	}()
+
+	now := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+	maintenanceClock = func() time.Time { return now }
+	ctx := context.Background()
+
+	// no maintenance windows
+	MaintenanceWindowsFunc = nil
+	assert.NilError(t, awaitMaintenanceWindow(ctx, onboarding_types.DeviceInfo{}, &computev1.InstanceResource{}))
+
+	windows, err := maintenance.ParseAll([]string{"0 22 * * * 2h"})
+	assert.NilError(t, err)
+	MaintenanceWindowsFunc = func(context.Context, *computev1.InstanceResource) (maintenance.Windows, error) {
+		return windows, nil
+	}
+
+	instance := &computev1.InstanceResource{}
+	err = awaitMaintenanceWindow(ctx, onboarding_types.DeviceInfo{}, instance)
+	assert.Assert(t, inv_errors.IsOperationInProgress(err))
+	assert.Equal(t, instance.ProvisioningStatus, "Waiting for maintenance window (opens 2025-06-03 22:00 UTC)")
+
+	instance = &computev1.InstanceResource{}
+	err = awaitMaintenanceWindow(ctx, onboarding_types.DeviceInfo{ReprovisionAttempt: 2}, instance)
+	assert.Assert(t, inv_errors.IsOperationInProgress(err))
+	assert.Equal(t, instance.ProvisioningStatus, "Waiting for maintenance window (attempt 2) (opens 2025-06-03 22:00 UTC)")
+
+	// the window is open
+	now = time.Date(2025, 6, 3, 23, 59, 0, 0, time.UTC)
+	instance = &computev1.InstanceResource{}
+	assert.NilError(t, awaitMaintenanceWindow(ctx, onboarding_types.DeviceInfo{}, instance))
+	assert.Equal(t, instance.ProvisioningStatus, "")
+
+	// invalid maintenance windows
+	MaintenanceWindowsFunc = func(context.Context, *computev1.InstanceResource) (maintenance.Windows, error) {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "Invalid maintenance window")
+	}
+	err = awaitMaintenanceWindow(ctx, onboarding_types.DeviceInfo{}, &computev1.InstanceResource{})
+	assert.Equal(t, grpc_status.Code(err), codes.InvalidArgument)
+}
@@ -0,0 +1,15 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package onboarding
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ProvisioningFailures counts the failed provisioning workflows, by error code and name of the failed action.
+// It is exported along with the gRPC metrics of the southbound handler.
+var ProvisioningFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "onboarding_manager_provisioning_failures_total",
+	Help: "Number of failed provisioning workflows, by error code and name of the failed action.",
+}, []string{"code", "action"})
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package onboarding
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/env"
+	onboarding_types "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding/types"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/redfish"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/util"
+	om_status "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/status"
+)
+
+// NetbootTrigger makes a host boot from the network once, so that it picks up the workflow created for it.
+type NetbootTrigger interface {
+	Netboot(ctx context.Context, deviceInfo onboarding_types.DeviceInfo) error
+}
+
+// PowerOffTrigger is implemented by NetbootTriggers that power off hosts whose provisioning failed.
+type PowerOffTrigger interface {
+	PowerOff(ctx context.Context, deviceInfo onboarding_types.DeviceInfo) error
+}
+
+// NetbootTriggerFactory returns the NetbootTrigger used to reprovision hosts, e.g. a redfish.BootController.
+var NetbootTriggerFactory = func() NetbootTrigger { return manualNetboot{} }
+
+// manualNetboot stands in for a BMC integration, the host has to be rebooted from the network by an operator.
+type manualNetboot struct{}
+
+func (manualNetboot) Netboot(_ context.Context, deviceInfo onboarding_types.DeviceInfo) error {
+	zlog.Info().Msgf("No BMC integration, host %s must be rebooted from the network to run reprovisioning attempt %d",
+		deviceInfo.GUID, deviceInfo.ReprovisionAttempt)
+	return nil
+}
+
+// netbootForReprovisioning network boots a host to be reprovisioned. Unlike during the initial provisioning,
+// the host runs its OS and does not look for a workflow on its own. If the host cannot be network booted,
+// its workflow is deleted so that the next reconciliation cycle creates it and retries.
+// Hosts with a BMC report whether it could be reached in their host status.
+func netbootForReprovisioning(ctx context.Context, deviceInfo onboarding_types.DeviceInfo,
+	instance *computev1.InstanceResource,
+) error {
+	netbootErr := NetbootTriggerFactory().Netboot(ctx, deviceInfo)
+	if netbootErr == nil {
+		if deviceInfo.RedfishEndpoint != "" {
+			util.PopulateHostStatus(instance, om_status.HostStatusRebooting)
+		}
+		return nil
+	}
+	zlog.InfraSec().InfraErr(netbootErr).Msgf("Failed to network boot host %s for reprovisioning", deviceInfo.GUID)
+	if err := tinkerbell.DeleteWorkflowIfExists(ctx, env.K8sNamespace, generateWorkflowName(deviceInfo.GUID)); err != nil {
+		return err
+	}
+	if reportBMCUnreachable(instance, netbootErr) {
+		// retried with backoff, the BMC may come back
+		return inv_errors.Errorfc(codes.Unavailable, "%v", netbootErr)
+	}
+	return netbootErr
+}
+
+// powerOffFailedHost powers off a host whose provisioning failed, if the NetbootTrigger supports it.
+// Errors are only logged, the provisioning failure is what is reported to the user.
+func powerOffFailedHost(ctx context.Context, deviceInfo onboarding_types.DeviceInfo, instance *computev1.InstanceResource) {
+	trigger, ok := NetbootTriggerFactory().(PowerOffTrigger)
+	if !ok {
+		return
+	}
+	if err := trigger.PowerOff(ctx, deviceInfo); err != nil {
+		zlog.InfraSec().InfraErr(err).Msgf("Failed to power off host %s after provisioning failure", deviceInfo.GUID)
+		reportBMCUnreachable(instance, err)
+	}
+}
+
+// reportBMCUnreachable sets the host status to BMC Unreachable if err reports so.
+func reportBMCUnreachable(instance *computev1.InstanceResource, err error) bool {
+	var unreachableErr *redfish.UnreachableError
+	if !errors.As(err, &unreachableErr) {
+		return false
+	}
+	util.PopulateHostStatus(instance, om_status.HostStatusBMCUnreachable)
+	return true
+}
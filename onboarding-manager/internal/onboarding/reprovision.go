@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package onboarding
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/env"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
+)
+
+// reprovisionAttemptKey is the key of the reprovisioning attempt in the data of the reprovisioning records.
+const reprovisionAttemptKey = "attempt"
+
+// reprovisionRecordName returns the name of the ConfigMap recording the reprovisioning attempt of an Instance.
+// The record outlives the workflows of the Instance, which are deleted once they complete.
+func reprovisionRecordName(instanceID string) string {
+	return "reprovision-" + instanceID
+}
+
+// getReprovisionRecord returns the reprovisioning record of the Instance and whether it exists.
+func getReprovisionRecord(ctx context.Context, k8sCli client.Client, instance *computev1.InstanceResource,
+) (*corev1.ConfigMap, bool, error) {
+	record := &corev1.ConfigMap{}
+	name := reprovisionRecordName(instance.GetResourceId())
+	err := k8sCli.Get(ctx, types.NamespacedName{Namespace: env.K8sNamespace, Name: name}, record)
+	if errors.IsNotFound(err) {
+		return record, false, nil
+	}
+	if err != nil {
+		zlog.InfraSec().InfraErr(err).Msgf("Failed to get the reprovisioning record %s", name)
+		return nil, false, inv_errors.Errorf("Failed to get the reprovisioning record %s", name)
+	}
+	return record, true, nil
+}
+
+// reprovisionAttempt returns the attempt recorded by a reprovisioning record, 0 if none.
+func reprovisionAttempt(record *corev1.ConfigMap) (int, error) {
+	value, ok := record.Data[reprovisionAttemptKey]
+	if !ok {
+		return 0, nil
+	}
+	attempt, err := strconv.Atoi(value)
+	if err != nil || attempt < 0 {
+		return 0, inv_errors.Errorfc(codes.Internal, "Invalid reprovisioning attempt %q in record %s",
+			value, record.GetName())
+	}
+	return attempt, nil
+}
+
+// ReprovisionAttempt returns the reprovisioning attempt of the Instance, 0 if it was never reprovisioned.
+func ReprovisionAttempt(ctx context.Context, instance *computev1.InstanceResource) (int, error) {
+	kubeClient, err := tinkerbell.K8sClientFactory()
+	if err != nil {
+		return 0, err
+	}
+	record, _, err := getReprovisionRecord(ctx, kubeClient, instance)
+	if err != nil {
+		return 0, err
+	}
+	return reprovisionAttempt(record)
+}
+
+// NextReprovisionAttempt records and returns the next reprovisioning attempt of the Instance.
+func NextReprovisionAttempt(ctx context.Context, instance *computev1.InstanceResource) (int, error) {
+	kubeClient, err := tinkerbell.K8sClientFactory()
+	if err != nil {
+		return 0, err
+	}
+	record, found, err := getReprovisionRecord(ctx, kubeClient, instance)
+	if err != nil {
+		return 0, err
+	}
+	attempt, err := reprovisionAttempt(record)
+	if err != nil {
+		return 0, err
+	}
+	attempt++
+
+	record.Data = map[string]string{reprovisionAttemptKey: strconv.Itoa(attempt)}
+	if found {
+		err = kubeClient.Update(ctx, record)
+	} else {
+		record.ObjectMeta = metav1.ObjectMeta{
+			Name:      reprovisionRecordName(instance.GetResourceId()),
+			Namespace: env.K8sNamespace,
+			Labels: map[string]string{
+				WorkflowLabelHost:   instance.GetHost().GetUuid(),
+				WorkflowLabelTenant: instance.GetTenantId(),
+			},
+		}
+		err = kubeClient.Create(ctx, record)
+	}
+	if err != nil {
+		// the update conflicts if the record changed since it was read, concurrent attempts never share a number
+		zlog.InfraSec().InfraErr(err).Msgf("Failed to record reprovisioning attempt %d of Instance %s",
+			attempt, instance.GetResourceId())
+		return 0, inv_errors.Errorf("Failed to record reprovisioning attempt %d of Instance %s",
+			attempt, instance.GetResourceId())
+	}
+	return attempt, nil
+}
+
+// DeleteReprovisionRecordIfExists deletes the reprovisioning record of a deleted Instance.
+func DeleteReprovisionRecordIfExists(ctx context.Context, instance *computev1.InstanceResource) error {
+	kubeClient, err := tinkerbell.K8sClientFactory()
+	if err != nil {
+		return err
+	}
+	record := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name:      reprovisionRecordName(instance.GetResourceId()),
+		Namespace: env.K8sNamespace,
+	}}
+	if err = kubeClient.Delete(ctx, record); err != nil && !errors.IsNotFound(err) {
+		zlog.InfraSec().InfraErr(err).Msgf("Failed to delete the reprovisioning record of Instance %s",
+			instance.GetResourceId())
+		return inv_errors.Errorf("Failed to delete the reprovisioning record of Instance %s", instance.GetResourceId())
+	}
+	return nil
+}
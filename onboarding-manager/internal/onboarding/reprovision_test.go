@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:testpackage // Keeping the test in the same package due to dependencies on unexported fields.
+package onboarding
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/env"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
+	om_status "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/status"
+)
+
+func TestReprovisionAttempt(t *testing.T) {
+	currK8sClientFactory := tinkerbell.K8sClientFactory
+	t.Cleanup(func() {
+		tinkerbell.K8sClientFactory = currK8sClientFactory
+	})
+	k8sCli := newDecommissionClient(t)
+	tinkerbell.K8sClientFactory = func() (client.Client, error) { return k8sCli, nil }
+
+	ctx := context.Background()
+	instance := &computev1.InstanceResource{
+		ResourceId: "inst-084d9b08",
+		TenantId:   "11111111-1111-1111-1111-111111111111",
+		Host:       &computev1.HostResource{Uuid: "57ed598c-4b94-11ee-806c-3a7c7693aac3"},
+	}
+
+	// the Instance was never reprovisioned
+	attempt, err := ReprovisionAttempt(ctx, instance)
+	assert.NilError(t, err)
+	assert.Equal(t, attempt, 0)
+
+	for want := 1; want <= 2; want++ {
+		attempt, err = NextReprovisionAttempt(ctx, instance)
+		assert.NilError(t, err)
+		assert.Equal(t, attempt, want)
+		attempt, err = ReprovisionAttempt(ctx, instance)
+		assert.NilError(t, err)
+		assert.Equal(t, attempt, want)
+	}
+
+	// the attempt does not depend on the provisioning status, which any writer may replace
+	instance.ProvisioningStatus = om_status.ProvisioningStatusInProgress.Status
+	attempt, err = ReprovisionAttempt(ctx, instance)
+	assert.NilError(t, err)
+	assert.Equal(t, attempt, 2)
+
+	var record corev1.ConfigMap
+	assert.NilError(t, k8sCli.Get(ctx, types.NamespacedName{
+		Namespace: env.K8sNamespace, Name: "reprovision-inst-084d9b08",
+	}, &record))
+	assert.Equal(t, record.Labels[WorkflowLabelHost], instance.GetHost().GetUuid())
+	assert.Equal(t, record.Labels[WorkflowLabelTenant], instance.GetTenantId())
+
+	// another Instance of the host starts over
+	attempt, err = ReprovisionAttempt(ctx, &computev1.InstanceResource{ResourceId: "inst-1a2b3c4d"})
+	assert.NilError(t, err)
+	assert.Equal(t, attempt, 0)
+
+	assert.NilError(t, DeleteReprovisionRecordIfExists(ctx, instance))
+	assert.NilError(t, DeleteReprovisionRecordIfExists(ctx, instance))
+	attempt, err = ReprovisionAttempt(ctx, instance)
+	assert.NilError(t, err)
+	assert.Equal(t, attempt, 0)
+
+	// a corrupted record is reported rather than restarting from the first attempt
+	record.ResourceVersion = ""
+	record.Data[reprovisionAttemptKey] = "two"
+	assert.NilError(t, k8sCli.Create(ctx, &record))
+	_, err = NextReprovisionAttempt(ctx, instance)
+	assert.ErrorContains(t, err, "Invalid reprovisioning attempt")
+}
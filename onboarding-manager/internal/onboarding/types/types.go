@@ -21,6 +21,8 @@ type (
 		HwMacID string
 		// HwIP IP address of the management NIC of a host.
 		HwIP string
+		// Architecture CPU architecture of a host as named in the uOS artifacts (e.g., x86_64, aarch64).
+		Architecture string
 		// OSImageURL a URL pointing to the OS location on the EN's reverse proxy.
 		OSImageURL string
 		// Gateway IP gateway of a local subnet where a host is located.
@@ -67,5 +69,12 @@ type (
 		SkipKernelUpgrade bool
 		// OSImageCompressed indicates whether the OS image is compressed
 		OSImageCompressed bool
+		// ReprovisionAttempt is the reprovisioning attempt of the host, 0 for the initial provisioning
+		ReprovisionAttempt int
+		// RedfishEndpoint is the Redfish endpoint of the BMC of a host, empty if the host has none
+		RedfishEndpoint string
+		// HardwareProfile is the JSON hardware profile checked before the disks of a host are erased, empty if the
+		// hardware is not checked
+		HardwareProfile string
 	}
 )
@@ -13,6 +13,7 @@ import (
 
 	tink "github.com/tinkerbell/tink/api/v1alpha1"
 	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -39,6 +40,10 @@ var (
 	actionSuccessDuration = make(map[string]int64)
 )
 
+// HardwareProfileFunc returns the hardware profile the hardware of an Instance is checked against before its disks
+// are erased, e.g. hwprofile.Resolver.Profile. The hardware is not checked if nil.
+var HardwareProfileFunc func(ctx context.Context, instance *computev1.InstanceResource) (string, error)
+
 // generateWorkflowName returns workflow name in format "workflow-<UUID>".
 func generateWorkflowName(uuid string) string {
 	return fmt.Sprintf("workflow-%s", uuid)
@@ -82,8 +87,23 @@ func CheckStatusOrRunProdWorkflow(ctx context.Context,
 		// This may happen if:
 		// 1) workflow for Instance is not created yet -> proceed to runProdWorkflow()
 		// 2) we already finished & removed workflow for Instance -> in this case we should never get here
+		if windowErr := awaitMaintenanceWindow(ctx, deviceInfo, instance); windowErr != nil {
+			return windowErr
+		}
+		position, admitErr := admitProvisioning(ctx, kubeClient, instance)
+		if admitErr != nil {
+			return admitErr
+		}
+		if position > 0 {
+			util.PopulateInstanceStatusAndCurrentState(instance, computev1.InstanceState_INSTANCE_STATE_UNSPECIFIED,
+				om_status.QueuedStatus(position, deviceInfo.ReprovisionAttempt))
+			return inv_errors.Errorfr(inv_errors.Reason_OPERATION_IN_PROGRESS,
+				"Prod workflow queued at position %d, waiting for admission", position)
+		}
+
 		runErr := runProdWorkflow(ctx, kubeClient, deviceInfo, instance)
 		if runErr != nil {
+			provisioningQueue.release(deviceInfo.GUID)
 			zlog.Error().Err(runErr).Msgf("Failed to run Prod workflow for host %s and Error is %s",
 				deviceInfo.GUID, runErr.Error())
 			return runErr
@@ -101,12 +121,24 @@ func CheckStatusOrRunProdWorkflow(ctx context.Context,
 		return err
 	}
 
-	util.PopulateInstanceStatusAndCurrentState(
-		instance, computev1.InstanceState_INSTANCE_STATE_UNSPECIFIED,
-		om_status.ProvisioningStatusInProgress)
+	inProgress, done, failed := om_status.ProvisioningStatuses(deviceInfo.ReprovisionAttempt)
+	if workflow.Status.State == tink.WorkflowStateSuccess && FirstBootTimeout > 0 {
+		err = awaitFirstBoot(instance, om_status.FirstBootStatus(deviceInfo.ReprovisionAttempt), failed)
+	} else {
+		util.PopulateInstanceStatusAndCurrentState(
+			instance, computev1.InstanceState_INSTANCE_STATE_UNSPECIFIED, inProgress)
 
-	return handleWorkflowStatus(instance, workflow,
-		om_status.ProvisioningStatusDone, om_status.ProvisioningStatusFailed)
+		if FailUnresponsiveWorkflows {
+			if failErr := failUnresponsiveWorkflow(ctx, kubeClient, workflow); failErr != nil {
+				return failErr
+			}
+		}
+		err = handleWorkflowStatus(instance, workflow, inProgress, done, failed)
+	}
+	if grpc_status.Code(err) == codes.Aborted {
+		powerOffFailedHost(ctx, deviceInfo, instance)
+	}
+	return err
 }
 
 func runProdWorkflow(
@@ -136,6 +168,13 @@ func runProdWorkflow(
 		}
 	}
 
+	if HardwareProfileFunc != nil {
+		if deviceInfo.HardwareProfile, err = HardwareProfileFunc(ctx, instance); err != nil {
+			zlog.InfraSec().InfraErr(err).Msgf("Failed to get the hardware profile of host %s", deviceInfo.GUID)
+			return err
+		}
+	}
+
 	templateName, found := templates.OSTypeToTemplateName[deviceInfo.OsType]
 	if !found {
 		return inv_errors.Errorf("Cannot find Tinkerbell template for OS type %s", deviceInfo.OsType)
@@ -153,6 +192,7 @@ func runProdWorkflow(
 		tinkerbell.DummyHardwareName,
 		templateName,
 		workflowHardwareMap)
+	prodWorkflow.Labels = workflowLabels(instance)
 
 	if createWFErr := tinkerbell.CreateWorkflowIfNotExists(ctx, k8sCli, prodWorkflow); createWFErr != nil {
 		return createWFErr
@@ -160,6 +200,10 @@ func runProdWorkflow(
 
 	zlog.Debug().Msgf("Prod workflow %s for host %s created successfully", prodWorkflow.Name, deviceInfo.GUID)
 
+	if deviceInfo.ReprovisionAttempt > 0 {
+		return netbootForReprovisioning(ctx, deviceInfo, instance)
+	}
+
 	return nil
 }
 
@@ -291,11 +335,35 @@ func createENCredentialsIfNotExists(
 
 // DeleteTinkerbellWorkflowIfExists performs operations for onboarding management.
 func DeleteTinkerbellWorkflowIfExists(ctx context.Context, hostUUID string) error {
+	provisioningQueue.release(hostUUID)
+	forgetWorkerHeartbeats(generateWorkflowName(hostUUID))
 	return tinkerbell.DeleteWorkflowIfExists(ctx, env.K8sNamespace, generateWorkflowName(hostUUID))
 }
 
+// GetWorkflowStatusDetail returns the current step of the provisioning workflow of the host, as reported in the
+// provisioning status detail. It returns an empty string if the host has no workflow.
+func GetWorkflowStatusDetail(ctx context.Context, hostUUID string) (string, error) {
+	kubeClient, err := tinkerbell.K8sClientFactory()
+	if err != nil {
+		return "", err
+	}
+
+	// getWorkflow is not used, its instrumentation is only meant to be run by the reconciler
+	workflowName := generateWorkflowName(hostUUID)
+	workflow := &tink.Workflow{}
+	clientErr := kubeClient.Get(ctx, types.NamespacedName{Namespace: env.K8sNamespace, Name: workflowName}, workflow)
+	if clientErr != nil && errors.IsNotFound(clientErr) {
+		return "", nil
+	}
+	if clientErr != nil {
+		zlog.InfraSec().InfraErr(clientErr).Msgf("Failed to get workflow %s status", workflowName)
+		return "", inv_errors.Errorf("Failed to get workflow %s status.", workflowName)
+	}
+	return tinkerbell.GenerateStatusDetailFromWorkflowState(workflow), nil
+}
+
 func handleWorkflowStatus(instance *computev1.InstanceResource, workflow *tink.Workflow,
-	onSuccessProvisioningStatus, onFailureProvisioningStatus inv_status.ResourceStatus,
+	inProgressProvisioningStatus, onSuccessProvisioningStatus, onFailureProvisioningStatus inv_status.ResourceStatus,
 ) error {
 	intermediateWorkflowState := tinkerbell.GenerateStatusDetailFromWorkflowState(workflow)
 
@@ -304,10 +372,12 @@ func handleWorkflowStatus(instance *computev1.InstanceResource, workflow *tink.W
 
 	switch workflow.Status.State {
 	case tink.WorkflowStateSuccess:
-		// success, proceed further
+		// success, proceed further, recording what the actions selected and installed
+		outputs := tinkerbell.WorkflowOutputs(workflow)
+		zlog.Debug().Msgf("Workflow %s of host %s outputs: %v", workflow.Name, instance.GetHost().GetUuid(), outputs)
 		util.PopulateInstanceStatusAndCurrentState(
 			instance, computev1.InstanceState_INSTANCE_STATE_RUNNING,
-			om_status.NewStatusWithDetails(onSuccessProvisioningStatus, intermediateWorkflowState))
+			om_status.NewStatusWithDetails(onSuccessProvisioningStatus, tinkerbell.OutputsStatusDetail(outputs)))
 
 		// don't set Rebooting for Standalone ENs as we don't have agents that will converge to Running eventually
 		isStandalone, err := util.IsStandalone(instance)
@@ -320,13 +390,30 @@ func handleWorkflowStatus(instance *computev1.InstanceResource, workflow *tink.W
 		}
 		return nil
 	case tink.WorkflowStateFailed, tink.WorkflowStateTimeout:
+		errorCode, failedAction := tinkerbell.ErrorCodeFromWorkflow(workflow)
+		if errorCode == "" {
+			// the workflow itself failed or timed out, not one of its actions
+			errorCode = tinkerbell.ErrorCodeUnknown
+			if workflow.Status.State == tink.WorkflowStateTimeout {
+				errorCode = tinkerbell.ErrorCodeTimeout
+			}
+		}
+		zlog.InfraSec().Warn().Msgf("Workflow %s of host %s failed with error code %s, action %q",
+			workflow.Name, instance.GetHost().GetUuid(), errorCode, failedAction)
+		ProvisioningFailures.WithLabelValues(string(errorCode), failedAction).Inc()
+
 		ProvisioningStatusFailed := om_status.NewStatusWithDetails(onFailureProvisioningStatus,
 			intermediateWorkflowState)
 		// report error provisioning status
 		util.PopulateInstanceProvisioningStatus(instance, ProvisioningStatusFailed)
 		return inv_errors.Errorfc(codes.Aborted, "Workflow failed or timed out")
 	case "", tink.WorkflowStateRunning, tink.WorkflowStatePending:
-		ProvisioningStatusInProgress := om_status.NewStatusWithDetails(om_status.ProvisioningStatusInProgress,
+		if unresponsive := unresponsiveWorker(workflow); unresponsive != "" {
+			zlog.InfraSec().Warn().Msgf("Workflow %s of host %s: %s",
+				workflow.Name, instance.GetHost().GetUuid(), unresponsive)
+			intermediateWorkflowState = fmt.Sprintf("%s (%s)", intermediateWorkflowState, unresponsive)
+		}
+		ProvisioningStatusInProgress := om_status.NewStatusWithDetails(inProgressProvisioningStatus,
 			intermediateWorkflowState)
 		util.PopulateInstanceStatusAndCurrentState(
 			instance, computev1.InstanceState_INSTANCE_STATE_UNSPECIFIED, ProvisioningStatusInProgress)
@@ -11,11 +11,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	tink "github.com/tinkerbell/tink/api/v1alpha1"
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
 	"gotest.tools/assert"
 	kubeErr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -24,10 +28,13 @@ import (
 	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
 	osv1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/os/v1"
 	statusv1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/status/v1"
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
 	inv_status "github.com/open-edge-platform/infra-core/inventory/v2/pkg/status"
 	onboarding_types "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding/types"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/redfish"
 	om_testing "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/testing"
 	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
+	om_status "github.com/open-edge-platform/infra-onboarding/onboarding-manager/pkg/status"
 )
 
 func TestCheckStatusOrRunProdWorkflow(t *testing.T) {
@@ -111,6 +118,23 @@ func TestDeleteTinkerbellWorkflowIfExists(t *testing.T) {
 	}
 }
 
+func TestGetWorkflowStatusDetail(t *testing.T) {
+	currK8sClientFactory := tinkerbell.K8sClientFactory
+	t.Cleanup(func() {
+		tinkerbell.K8sClientFactory = currK8sClientFactory
+	})
+
+	tinkerbell.K8sClientFactory = om_testing.K8sCliMockFactory(false, true, false)
+	_, err := GetWorkflowStatusDetail(context.Background(), "test-uuid")
+	assert.ErrorContains(t, err, "Failed to get workflow")
+
+	// the mocked workflow succeeded, so there is no intermediate step to report
+	tinkerbell.K8sClientFactory = om_testing.K8sCliMockFactory(false, false, false)
+	detail, err := GetWorkflowStatusDetail(context.Background(), "test-uuid")
+	assert.NilError(t, err)
+	assert.Equal(t, "", detail)
+}
+
 func Test_handleWorkflowStatus(t *testing.T) {
 	type args struct {
 		instance                  *computev1.InstanceResource
@@ -138,8 +162,8 @@ func Test_handleWorkflowStatus(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := handleWorkflowStatus(tt.args.instance, tt.args.workflow, tt.args.onSuccessOnboardingStatus,
-				tt.args.onFailureOnboardingStatus); (err != nil) != tt.wantErr {
+			if err := handleWorkflowStatus(tt.args.instance, tt.args.workflow, om_status.ProvisioningStatusInProgress,
+				tt.args.onSuccessOnboardingStatus, tt.args.onFailureOnboardingStatus); (err != nil) != tt.wantErr {
 				t.Errorf("handleWorkflowStatus() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -183,8 +207,8 @@ func Test_handleWorkflowStatus_Case(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := handleWorkflowStatus(tt.args.instance, tt.args.workflow, tt.args.onSuccessOnboardingStatus,
-				tt.args.onFailureOnboardingStatus); (err != nil) != tt.wantErr {
+			if err := handleWorkflowStatus(tt.args.instance, tt.args.workflow, om_status.ProvisioningStatusInProgress,
+				tt.args.onSuccessOnboardingStatus, tt.args.onFailureOnboardingStatus); (err != nil) != tt.wantErr {
 				t.Errorf("handleWorkflowStatus() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -238,8 +262,8 @@ func Test_handleWorkflowStatus_Case1(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := handleWorkflowStatus(tt.args.instance, tt.args.workflow, tt.args.onSuccessOnboardingStatus,
-				tt.args.onFailureOnboardingStatus); (err != nil) != tt.wantErr {
+			if err := handleWorkflowStatus(tt.args.instance, tt.args.workflow, om_status.ProvisioningStatusInProgress,
+				tt.args.onSuccessOnboardingStatus, tt.args.onFailureOnboardingStatus); (err != nil) != tt.wantErr {
 				t.Errorf("handleWorkflowStatus() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -356,8 +380,8 @@ func Test_handleWorkflowStatus_Case4(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := handleWorkflowStatus(tt.args.instance, tt.args.workflow, tt.args.onSuccessProvisioningStatus,
-				tt.args.onFailureProvisioningStatus)
+			err := handleWorkflowStatus(tt.args.instance, tt.args.workflow, om_status.ProvisioningStatusInProgress,
+				tt.args.onSuccessProvisioningStatus, tt.args.onFailureProvisioningStatus)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("handleWorkflowStatus() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -407,11 +431,47 @@ func Test_handleWorkflowStatus_withCustomConfigsActions(t *testing.T) {
 	onSuccess := inv_status.New("Provisioned", statusv1.StatusIndication_STATUS_INDICATION_IDLE)
 	onFailure := inv_status.New("Provisioning Failed", statusv1.StatusIndication_STATUS_INDICATION_ERROR)
 
-	err := handleWorkflowStatus(instance, workflow, onSuccess, onFailure)
+	err := handleWorkflowStatus(instance, workflow, om_status.ProvisioningStatusInProgress, onSuccess, onFailure)
 	assert.ErrorContains(t, err, "") // Should be in progress, so error is returned
 	assert.Equal(t, instance.ProvisioningStatus, "Provisioning In Progress: 2/2: Installing custom cloud-init configs")
 }
 
+func Test_handleWorkflowStatus_withOutputs(t *testing.T) {
+	instance := &computev1.InstanceResource{
+		Host: &computev1.HostResource{
+			ResourceId: "host-084d9b08",
+			Uuid:       uuid.NewString(),
+		},
+	}
+	workflow := &tink.Workflow{
+		Status: tink.WorkflowStatus{
+			State: tink.WorkflowStateSuccess,
+			Tasks: []tink.Task{
+				{
+					Actions: []tink.Action{
+						{
+							Name: tinkerbell.ActionStreamOSImage, Status: tink.WorkflowStateSuccess,
+							Message: `outputs: {"IMAGE_SHA256":"9f86d081","TARGET_DISK":"/dev/nvme0n1"}`,
+						},
+						{
+							Name: tinkerbell.ActionKernelupgrade, Status: tink.WorkflowStateSuccess,
+							Message: `outputs: {"INSTALLED_KERNEL":"6.8.0-52-generic"}`,
+						},
+						{Name: tinkerbell.ActionReboot, Status: tink.WorkflowStateSuccess},
+					},
+				},
+			},
+		},
+	}
+	onSuccess := inv_status.New("Provisioned", statusv1.StatusIndication_STATUS_INDICATION_IDLE)
+	onFailure := inv_status.New("Provisioning Failed", statusv1.StatusIndication_STATUS_INDICATION_ERROR)
+
+	err := handleWorkflowStatus(instance, workflow, om_status.ProvisioningStatusInProgress, onSuccess, onFailure)
+	assert.NilError(t, err)
+	assert.Equal(t, "Provisioned: disk /dev/nvme0n1, kernel 6.8.0-52-generic, image sha256 9f86d081",
+		instance.ProvisioningStatus)
+}
+
 func createTestCase(name string, workflowState tink.WorkflowState, expectedStatus string, wantErr bool) handleWorkflowTestCase {
 	return handleWorkflowTestCase{
 		name: name,
@@ -441,3 +501,195 @@ func createTestCase(name string, workflowState tink.WorkflowState, expectedStatu
 		wantErr:                    wantErr,
 	}
 }
+
+func Test_handleWorkflowStatus_Reprovisioning(t *testing.T) {
+	inProgress, done, failed := om_status.ProvisioningStatuses(2)
+	instance := &computev1.InstanceResource{
+		Host: &computev1.HostResource{
+			ResourceId: "host-084d9b08",
+			Uuid:       uuid.NewString(),
+		},
+	}
+	workflow := &tink.Workflow{
+		Status: tink.WorkflowStatus{
+			State: tink.WorkflowStateRunning,
+			Tasks: []tink.Task{
+				{
+					Actions: []tink.Action{
+						{Name: "custom-configs", Status: tink.WorkflowStateSuccess},
+						{Name: "custom-configs-split", Status: tink.WorkflowStateRunning},
+					},
+				},
+			},
+		},
+	}
+
+	err := handleWorkflowStatus(instance, workflow, inProgress, done, failed)
+	assert.ErrorContains(t, err, "")
+	assert.Equal(t, instance.ProvisioningStatus,
+		"Reprovisioning In Progress (attempt 2): 2/2: Installing custom cloud-init configs")
+
+	workflow.Status.State = tink.WorkflowStateFailed
+	err = handleWorkflowStatus(instance, workflow, inProgress, done, failed)
+	assert.ErrorContains(t, err, "Workflow failed")
+	assert.Equal(t, instance.ProvisioningStatusIndicator, statusv1.StatusIndication_STATUS_INDICATION_ERROR)
+	assert.Assert(t, strings.HasPrefix(instance.ProvisioningStatus, failed.Status))
+
+	workflow.Status.State = tink.WorkflowStateSuccess
+	workflow.Status.Tasks = nil
+	err = handleWorkflowStatus(instance, workflow, inProgress, done, failed)
+	assert.NilError(t, err)
+	assert.Equal(t, instance.ProvisioningStatus, "Reprovisioned (attempt 2)")
+	assert.Equal(t, instance.CurrentState, computev1.InstanceState_INSTANCE_STATE_RUNNING)
+}
+
+func Test_handleWorkflowStatus_ErrorCode(t *testing.T) {
+	instance := &computev1.InstanceResource{
+		Host: &computev1.HostResource{ResourceId: "host-084d9b08", Uuid: uuid.NewString()},
+	}
+	workflow := &tink.Workflow{
+		Status: tink.WorkflowStatus{
+			State: tink.WorkflowStateFailed,
+			Tasks: []tink.Task{{Actions: []tink.Action{
+				{Name: tinkerbell.ActionSecureBootStatusFlagRead, Status: tink.WorkflowStateFailed, Message: "exit status 85"},
+				{Name: tinkerbell.ActionStreamOSImage, Status: tink.WorkflowStatePending},
+			}}},
+		},
+	}
+
+	err := handleWorkflowStatus(instance, workflow, om_status.ProvisioningStatusInProgress,
+		om_status.ProvisioningStatusDone, om_status.ProvisioningStatusFailed)
+	assert.Equal(t, grpc_status.Code(err), codes.Aborted)
+	assert.Equal(t, instance.ProvisioningStatusIndicator, statusv1.StatusIndication_STATUS_INDICATION_ERROR)
+	assert.Equal(t, instance.ProvisioningStatus, fmt.Sprintf("%s: 1/2: %s failed (SECURE_BOOT_MISMATCH): exit status 85",
+		om_status.ProvisioningStatusFailed.Status,
+		tinkerbell.WorkflowStepToStatusDetail[tinkerbell.ActionSecureBootStatusFlagRead]))
+}
+
+type netbootFunc func(ctx context.Context, deviceInfo onboarding_types.DeviceInfo) error
+
+func (f netbootFunc) Netboot(ctx context.Context, deviceInfo onboarding_types.DeviceInfo) error {
+	return f(ctx, deviceInfo)
+}
+
+func Test_netbootForReprovisioning(t *testing.T) {
+	currK8sClientFactory := tinkerbell.K8sClientFactory
+	currNetbootTriggerFactory := NetbootTriggerFactory
+	t.Cleanup(func() {
+		tinkerbell.K8sClientFactory = currK8sClientFactory
+		NetbootTriggerFactory = currNetbootTriggerFactory
+	})
+	tinkerbell.K8sClientFactory = om_testing.K8sCliMockFactory(false, false, false)
+	deviceInfo := onboarding_types.DeviceInfo{GUID: uuid.NewString(), ReprovisionAttempt: 1}
+	instance := &computev1.InstanceResource{Host: &computev1.HostResource{}}
+
+	var booted []onboarding_types.DeviceInfo
+	NetbootTriggerFactory = func() NetbootTrigger {
+		return netbootFunc(func(_ context.Context, deviceInfo onboarding_types.DeviceInfo) error {
+			booted = append(booted, deviceInfo)
+			return nil
+		})
+	}
+	assert.NilError(t, netbootForReprovisioning(context.Background(), deviceInfo, instance))
+	assert.DeepEqual(t, booted, []onboarding_types.DeviceInfo{deviceInfo})
+	assert.Equal(t, instance.Host.HostStatus, "")
+
+	// hosts with a BMC report it is reachable
+	deviceInfo.RedfishEndpoint = "https://bmc.example.com"
+	assert.NilError(t, netbootForReprovisioning(context.Background(), deviceInfo, instance))
+	assert.Equal(t, instance.Host.HostStatus, om_status.HostStatusRebooting.Status)
+
+	// the workflow is deleted, so that the next reconciliation cycle retries
+	bmcErr := errors.New("BMC rejected the request")
+	NetbootTriggerFactory = func() NetbootTrigger {
+		return netbootFunc(func(context.Context, onboarding_types.DeviceInfo) error { return bmcErr })
+	}
+	err := netbootForReprovisioning(context.Background(), deviceInfo, instance)
+	assert.Assert(t, errors.Is(err, bmcErr))
+
+	NetbootTriggerFactory = func() NetbootTrigger {
+		return netbootFunc(func(context.Context, onboarding_types.DeviceInfo) error {
+			return &redfish.UnreachableError{Endpoint: deviceInfo.RedfishEndpoint, Err: bmcErr}
+		})
+	}
+	err = netbootForReprovisioning(context.Background(), deviceInfo, instance)
+	assert.Equal(t, grpc_status.Code(err), codes.Unavailable)
+	assert.Equal(t, instance.Host.HostStatus, om_status.HostStatusBMCUnreachable.Status)
+	assert.Equal(t, instance.Host.HostStatusIndicator, statusv1.StatusIndication_STATUS_INDICATION_ERROR)
+
+	tinkerbell.K8sClientFactory = om_testing.K8sCliMockFactory(false, false, true)
+	err = netbootForReprovisioning(context.Background(), deviceInfo, instance)
+	assert.ErrorContains(t, err, "Failed to delete Tinkerbell Workflow")
+
+	// the stand-in for a BMC always succeeds
+	NetbootTriggerFactory = currNetbootTriggerFactory
+	deviceInfo.RedfishEndpoint = ""
+	assert.NilError(t, netbootForReprovisioning(context.Background(), deviceInfo, instance))
+}
+
+type powerOffTrigger struct {
+	netbootFunc
+	poweredOff []string
+	err        error
+}
+
+func (p *powerOffTrigger) PowerOff(_ context.Context, deviceInfo onboarding_types.DeviceInfo) error {
+	p.poweredOff = append(p.poweredOff, deviceInfo.GUID)
+	return p.err
+}
+
+func Test_powerOffFailedHost(t *testing.T) {
+	currNetbootTriggerFactory := NetbootTriggerFactory
+	t.Cleanup(func() {
+		NetbootTriggerFactory = currNetbootTriggerFactory
+	})
+	deviceInfo := onboarding_types.DeviceInfo{GUID: uuid.NewString(), RedfishEndpoint: "https://bmc.example.com"}
+	instance := &computev1.InstanceResource{Host: &computev1.HostResource{}}
+
+	// the stand-in for a BMC cannot power off hosts
+	powerOffFailedHost(context.Background(), deviceInfo, instance)
+	assert.Equal(t, instance.Host.HostStatus, "")
+
+	trigger := &powerOffTrigger{}
+	NetbootTriggerFactory = func() NetbootTrigger { return trigger }
+	powerOffFailedHost(context.Background(), deviceInfo, instance)
+	assert.DeepEqual(t, trigger.poweredOff, []string{deviceInfo.GUID})
+	assert.Equal(t, instance.Host.HostStatus, "")
+
+	trigger.err = &redfish.UnreachableError{Endpoint: deviceInfo.RedfishEndpoint, Err: errors.New("timeout")}
+	powerOffFailedHost(context.Background(), deviceInfo, instance)
+	assert.Equal(t, instance.Host.HostStatus, om_status.HostStatusBMCUnreachable.Status)
+}
+
+func Test_awaitFirstBoot(t *testing.T) {
+	currFirstBootTimeout := FirstBootTimeout
+	t.Cleanup(func() {
+		FirstBootTimeout = currFirstBootTimeout
+	})
+	FirstBootTimeout = time.Minute
+	_, _, failed := om_status.ProvisioningStatuses(2)
+	verifying := om_status.FirstBootStatus(2)
+	instance := &computev1.InstanceResource{
+		Host:               &computev1.HostResource{},
+		ProvisioningStatus: "Reprovisioning In Progress (attempt 2): 10/10: Rebooting",
+	}
+
+	// the workflow just succeeded
+	err := awaitFirstBoot(instance, verifying, failed)
+	assert.Assert(t, inv_errors.IsOperationInProgress(err))
+	assert.Equal(t, instance.ProvisioningStatus, "Verifying First Boot (attempt 2)")
+	assert.Equal(t, instance.CurrentState, computev1.InstanceState_INSTANCE_STATE_UNSPECIFIED)
+	assert.Equal(t, instance.Host.HostStatus, om_status.HostStatusRebooting.Status)
+
+	// the verifying status was persisted
+	instance.ProvisioningStatusTimestamp = uint64(time.Now().Add(-time.Second).Unix())
+	err = awaitFirstBoot(instance, verifying, failed)
+	assert.Assert(t, inv_errors.IsOperationInProgress(err))
+	assert.Equal(t, instance.ProvisioningStatus, "Verifying First Boot (attempt 2)")
+
+	instance.ProvisioningStatusTimestamp = uint64(time.Now().Add(-2 * time.Minute).Unix())
+	err = awaitFirstBoot(instance, verifying, failed)
+	assert.Equal(t, grpc_status.Code(err), codes.Aborted)
+	assert.Equal(t, instance.ProvisioningStatus, "Reprovisioning Failed (attempt 2): first boot not confirmed within 1m0s")
+	assert.Equal(t, instance.ProvisioningStatusIndicator, statusv1.StatusIndication_STATUS_INDICATION_ERROR)
+}
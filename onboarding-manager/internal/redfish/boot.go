@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package redfish
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	onboarding_types "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding/types"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/util"
+)
+
+const (
+	// EndpointMetadataKey is the host metadata key of the Redfish endpoint of the BMC of a host.
+	EndpointMetadataKey = "redfish-endpoint"
+
+	// DefaultTimeout is the default timeout of the Redfish operations on a host.
+	DefaultTimeout = 30 * time.Second
+)
+
+// EndpointFromMetadata returns the Redfish endpoint in the host metadata, a JSON list of key/value pairs,
+// or an empty string if the host has none.
+func EndpointFromMetadata(metadata string) (string, error) {
+	values, err := util.MetadataValues(metadata, EndpointMetadataKey)
+	if err != nil || len(values) == 0 {
+		return "", err
+	}
+	return values[0], nil
+}
+
+// CredentialsFunc returns the credentials of the BMCs. It is called for every operation,
+// so that rotated credentials are picked up.
+type CredentialsFunc func() (Credentials, error)
+
+// Config configures the BootController.
+type Config struct {
+	// BootTarget is the boot source hosts are network booted from.
+	BootTarget BootTarget
+	// PowerOffOnFailure powers off hosts whose provisioning failed.
+	PowerOffOnFailure bool
+	// Timeout bounds the Redfish operations on a host.
+	Timeout time.Duration
+	// CACertPath is the PEM bundle of CAs used to verify the certificates of the BMCs, system CAs are used if unset.
+	CACertPath string
+	// InsecureSkipVerify disables the verification of the certificates of the BMCs.
+	InsecureSkipVerify bool
+}
+
+// BootController network boots and powers off hosts through the Redfish API of their BMC.
+// Hosts without a Redfish endpoint in their metadata are left to an operator.
+type BootController struct {
+	cfg         Config
+	credentials CredentialsFunc
+	httpClient  *http.Client
+}
+
+// BootControllerOption configures optional settings of the BootController.
+type BootControllerOption func(*BootController)
+
+// WithHTTPClient replaces the HTTP client used to reach the BMCs, e.g. with the client of a test server.
+func WithHTTPClient(httpClient *http.Client) BootControllerOption {
+	return func(b *BootController) {
+		b.httpClient = httpClient
+	}
+}
+
+// NewBootController returns a BootController authenticating to BMCs with the credentials returned by credentials.
+func NewBootController(cfg Config, credentials CredentialsFunc, opts ...BootControllerOption) (*BootController, error) {
+	if _, ok := bootSourceOverrideTargets[cfg.BootTarget]; !ok {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "Unsupported boot target %q", cfg.BootTarget)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, // #nosec G402 -- opt-in for BMCs with self-signed certificates
+	}
+	if cfg.CACertPath != "" {
+		caPEM, err := os.ReadFile(cfg.CACertPath)
+		if err != nil {
+			return nil, inv_errors.Errorf("Failed to read BMC CA bundle %s: %v", cfg.CACertPath, err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, inv_errors.Errorfc(codes.InvalidArgument, "No certificate found in BMC CA bundle %s", cfg.CACertPath)
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:errcheck // DefaultTransport is a *http.Transport
+	transport.TLSClientConfig = tlsConfig
+
+	b := &BootController{
+		cfg:         cfg,
+		credentials: credentials,
+		httpClient:  &http.Client{Transport: transport},
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b, nil
+}
+
+// Netboot sets a one-time network boot override on the host and power cycles it.
+func (b *BootController) Netboot(ctx context.Context, deviceInfo onboarding_types.DeviceInfo) error {
+	if deviceInfo.RedfishEndpoint == "" {
+		zlog.Info().Msgf("Host %s has no Redfish endpoint, it must be rebooted from the network by an operator",
+			deviceInfo.GUID)
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, b.cfg.Timeout)
+	defer cancel()
+
+	client, err := b.client(deviceInfo.RedfishEndpoint)
+	if err != nil {
+		return err
+	}
+	if err := client.SetOneTimeBoot(ctx, b.cfg.BootTarget); err != nil {
+		return err
+	}
+	if err := client.PowerCycle(ctx); err != nil {
+		return err
+	}
+	zlog.InfraSec().Info().Msgf("Host %s power cycled to boot from %s", deviceInfo.GUID, b.cfg.BootTarget)
+	return nil
+}
+
+// PowerOff powers off the host if PowerOffOnFailure is configured.
+func (b *BootController) PowerOff(ctx context.Context, deviceInfo onboarding_types.DeviceInfo) error {
+	if !b.cfg.PowerOffOnFailure || deviceInfo.RedfishEndpoint == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, b.cfg.Timeout)
+	defer cancel()
+
+	client, err := b.client(deviceInfo.RedfishEndpoint)
+	if err != nil {
+		return err
+	}
+	if err := client.PowerOff(ctx); err != nil {
+		return err
+	}
+	zlog.InfraSec().Info().Msgf("Host %s powered off", deviceInfo.GUID)
+	return nil
+}
+
+func (b *BootController) client(endpoint string) (*Client, error) {
+	creds, err := b.credentials()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(endpoint, creds, b.httpClient)
+}
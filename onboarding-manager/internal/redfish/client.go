@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redfish orchestrates the power and boot of hosts through the Redfish API of their BMC.
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-core/inventory/v2/pkg/logging"
+)
+
+const (
+	// ServiceRootPath is the path of the Redfish service root.
+	ServiceRootPath = "/redfish/v1/"
+	// SystemsPath is the path of the Redfish computer system collection.
+	SystemsPath = "/redfish/v1/Systems"
+
+	// PowerStateOn is the Redfish power state of a powered on system.
+	PowerStateOn = "On"
+	// PowerStateOff is the Redfish power state of a powered off system.
+	PowerStateOff = "Off"
+
+	resetActionPath = "/Actions/ComputerSystem.Reset"
+	// maxResponseSize bounds the responses read from BMCs.
+	maxResponseSize = 1 << 20
+)
+
+var zlog = logging.GetLogger("Redfish")
+
+// BootTarget is the boot source a host is network booted from.
+type BootTarget string
+
+const (
+	// BootTargetPXE network boots the host with PXE.
+	BootTargetPXE BootTarget = "pxe"
+	// BootTargetUEFIHTTP network boots the host with UEFI HTTP boot.
+	BootTargetUEFIHTTP BootTarget = "uefi-http"
+)
+
+// bootSourceOverrideTargets maps the boot targets to the Redfish BootSourceOverrideTarget values.
+var bootSourceOverrideTargets = map[BootTarget]string{
+	BootTargetPXE:      "Pxe",
+	BootTargetUEFIHTTP: "UefiHttp",
+}
+
+// Credentials authenticate requests to a BMC.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// UnreachableError is returned when the BMC cannot be reached, as opposed to the BMC rejecting a request.
+type UnreachableError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *UnreachableError) Error() string {
+	return fmt.Sprintf("BMC %s is unreachable: %v", e.Endpoint, e.Err)
+}
+
+func (e *UnreachableError) Unwrap() error {
+	return e.Err
+}
+
+// Client manages the power and boot of a single computer system through the Redfish API of its BMC.
+type Client struct {
+	endpoint   *url.URL
+	systemPath string
+	creds      Credentials
+	httpClient *http.Client
+}
+
+// NewClient returns a Client for the Redfish endpoint of a BMC. The endpoint is either the address of the BMC,
+// e.g. https://10.0.0.10, in which case the first computer system of the BMC is managed, or the URL of
+// the computer system, e.g. https://10.0.0.10/redfish/v1/Systems/1.
+func NewClient(endpoint string, creds Credentials, httpClient *http.Client) (*Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "Invalid Redfish endpoint %q", endpoint)
+	}
+	// credentials are sent with every request
+	if u.Scheme != "https" {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "Redfish endpoint %q must use https", endpoint)
+	}
+
+	c := &Client{
+		endpoint:   &url.URL{Scheme: u.Scheme, Host: u.Host},
+		creds:      creds,
+		httpClient: httpClient,
+	}
+	if systemPath := strings.TrimSuffix(u.Path, "/"); strings.HasPrefix(systemPath, SystemsPath+"/") {
+		c.systemPath = systemPath
+	}
+	return c, nil
+}
+
+// Ping checks that the BMC is reachable and accepts the credentials.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, ServiceRootPath, nil, nil)
+}
+
+// SetOneTimeBoot makes the computer system boot from target on its next boot only.
+func (c *Client) SetOneTimeBoot(ctx context.Context, target BootTarget) error {
+	overrideTarget, ok := bootSourceOverrideTargets[target]
+	if !ok {
+		return inv_errors.Errorfc(codes.InvalidArgument, "Unsupported boot target %q", target)
+	}
+	systemPath, err := c.system(ctx)
+	if err != nil {
+		return err
+	}
+	patch := map[string]any{
+		"Boot": map[string]string{
+			"BootSourceOverrideEnabled": "Once",
+			"BootSourceOverrideTarget":  overrideTarget,
+			// Edge Nodes are provisioned in UEFI mode, which UEFI HTTP boot requires anyway
+			"BootSourceOverrideMode": "UEFI",
+		},
+	}
+	return c.do(ctx, http.MethodPatch, systemPath, patch, nil)
+}
+
+// PowerState returns the power state of the computer system.
+func (c *Client) PowerState(ctx context.Context) (string, error) {
+	systemPath, err := c.system(ctx)
+	if err != nil {
+		return "", err
+	}
+	var system struct {
+		PowerState string `json:"PowerState"`
+	}
+	if err := c.do(ctx, http.MethodGet, systemPath, nil, &system); err != nil {
+		return "", err
+	}
+	return system.PowerState, nil
+}
+
+// PowerCycle restarts the computer system, or powers it on if it is off.
+func (c *Client) PowerCycle(ctx context.Context) error {
+	powerState, err := c.PowerState(ctx)
+	if err != nil {
+		return err
+	}
+	resetType := "ForceRestart"
+	if powerState == PowerStateOff {
+		resetType = PowerStateOn
+	}
+	return c.reset(ctx, resetType)
+}
+
+// PowerOff powers off the computer system, it is a no-op if the system is already off.
+func (c *Client) PowerOff(ctx context.Context) error {
+	powerState, err := c.PowerState(ctx)
+	if err != nil {
+		return err
+	}
+	if powerState == PowerStateOff {
+		return nil
+	}
+	return c.reset(ctx, "ForceOff")
+}
+
+func (c *Client) reset(ctx context.Context, resetType string) error {
+	systemPath, err := c.system(ctx)
+	if err != nil {
+		return err
+	}
+	zlog.Debug().Msgf("Resetting Redfish system %s%s: %s", c.endpoint, systemPath, resetType)
+	return c.do(ctx, http.MethodPost, systemPath+resetActionPath, map[string]string{"ResetType": resetType}, nil)
+}
+
+// system returns the path of the managed computer system, discovering it if the endpoint does not name one.
+func (c *Client) system(ctx context.Context) (string, error) {
+	if c.systemPath != "" {
+		return c.systemPath, nil
+	}
+	var systems struct {
+		Members []struct {
+			ID string `json:"@odata.id"`
+		} `json:"Members"`
+	}
+	if err := c.do(ctx, http.MethodGet, SystemsPath, nil, &systems); err != nil {
+		return "", err
+	}
+	if len(systems.Members) == 0 || systems.Members[0].ID == "" {
+		return "", inv_errors.Errorfc(codes.NotFound, "BMC %s does not manage any computer system", c.endpoint)
+	}
+	c.systemPath = strings.TrimSuffix(systems.Members[0].ID, "/")
+	return c.systemPath, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return inv_errors.Errorf("Failed to encode Redfish request: %v", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint.JoinPath(path).String(), reqBody)
+	if err != nil {
+		return inv_errors.Errorf("Failed to create Redfish request: %v", err)
+	}
+	req.SetBasicAuth(c.creds.Username, c.creds.Password)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return &UnreachableError{Endpoint: c.endpoint.String(), Err: err}
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return inv_errors.Errorfc(codes.PermissionDenied, "BMC %s rejected the credentials: %s %s returned %s",
+			c.endpoint, method, path, resp.Status)
+	case resp.StatusCode == http.StatusNotFound:
+		return inv_errors.Errorfc(codes.NotFound, "BMC %s: %s %s returned %s", c.endpoint, method, path, resp.Status)
+	case resp.StatusCode >= http.StatusBadRequest:
+		return inv_errors.Errorfc(codes.Internal, "BMC %s: %s %s returned %s", c.endpoint, method, path, resp.Status)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(result); err != nil {
+		return inv_errors.Errorfc(codes.Internal, "Failed to decode the response of BMC %s to %s %s: %v",
+			c.endpoint, method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package redfish_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+
+	onboarding_types "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding/types"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/redfish"
+	om_testing "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/testing"
+)
+
+var mockCredentials = redfish.Credentials{
+	Username: om_testing.RedfishMockUsername,
+	Password: om_testing.RedfishMockPassword,
+}
+
+func TestClient(t *testing.T) {
+	bmc := om_testing.NewRedfishMock()
+	defer bmc.Close()
+	ctx := context.Background()
+
+	client, err := redfish.NewClient(bmc.URL, mockCredentials, bmc.Client())
+	require.NoError(t, err)
+	require.NoError(t, client.Ping(ctx))
+
+	require.NoError(t, client.SetOneTimeBoot(ctx, redfish.BootTargetUEFIHTTP))
+	assert.Equal(t, map[string]string{
+		"BootSourceOverrideEnabled": "Once",
+		"BootSourceOverrideTarget":  "UefiHttp",
+		"BootSourceOverrideMode":    "UEFI",
+	}, bmc.Boot())
+
+	// a running host is restarted, a powered off host is powered on
+	require.NoError(t, client.PowerCycle(ctx))
+	require.NoError(t, client.PowerOff(ctx))
+	require.NoError(t, client.PowerOff(ctx))
+	require.NoError(t, client.PowerCycle(ctx))
+	assert.Equal(t, []string{"ForceRestart", "ForceOff", "On"}, bmc.Resets())
+	assert.Equal(t, redfish.PowerStateOn, bmc.PowerState())
+
+	err = client.SetOneTimeBoot(ctx, "floppy")
+	assert.Equal(t, codes.InvalidArgument, grpc_status.Code(err))
+}
+
+func TestClient_SystemEndpoint(t *testing.T) {
+	bmc := om_testing.NewRedfishMock()
+	defer bmc.Close()
+
+	client, err := redfish.NewClient(bmc.URL+om_testing.RedfishMockSystemPath, mockCredentials, bmc.Client())
+	require.NoError(t, err)
+	require.NoError(t, client.SetOneTimeBoot(context.Background(), redfish.BootTargetPXE))
+	assert.Equal(t, "Pxe", bmc.Boot()["BootSourceOverrideTarget"])
+
+	client, err = redfish.NewClient(bmc.URL+"/redfish/v1/Systems/2", mockCredentials, bmc.Client())
+	require.NoError(t, err)
+	err = client.PowerCycle(context.Background())
+	assert.Equal(t, codes.NotFound, grpc_status.Code(err))
+}
+
+func TestClient_Errors(t *testing.T) {
+	_, err := redfish.NewClient("://bmc", mockCredentials, nil)
+	assert.Equal(t, codes.InvalidArgument, grpc_status.Code(err))
+	_, err = redfish.NewClient("http://bmc.example.com", mockCredentials, nil)
+	assert.Equal(t, codes.InvalidArgument, grpc_status.Code(err))
+
+	bmc := om_testing.NewRedfishMock()
+	client, err := redfish.NewClient(bmc.URL, redfish.Credentials{Username: "admin", Password: "wrong"}, bmc.Client())
+	require.NoError(t, err)
+	err = client.Ping(context.Background())
+	assert.Equal(t, codes.PermissionDenied, grpc_status.Code(err))
+
+	// the BMC is gone
+	client, err = redfish.NewClient(bmc.URL, mockCredentials, bmc.Client())
+	require.NoError(t, err)
+	bmc.Close()
+	err = client.Ping(context.Background())
+	var unreachableErr *redfish.UnreachableError
+	require.ErrorAs(t, err, &unreachableErr)
+	assert.Equal(t, bmc.URL, unreachableErr.Endpoint)
+}
+
+func TestEndpointFromMetadata(t *testing.T) {
+	endpoint, err := redfish.EndpointFromMetadata("")
+	require.NoError(t, err)
+	assert.Empty(t, endpoint)
+
+	endpoint, err = redfish.EndpointFromMetadata(`[{"key":"tpm-required","value":"true"}]`)
+	require.NoError(t, err)
+	assert.Empty(t, endpoint)
+
+	endpoint, err = redfish.EndpointFromMetadata(`[{"key":"redfish-endpoint","value":"https://10.0.0.10"}]`)
+	require.NoError(t, err)
+	assert.Equal(t, "https://10.0.0.10", endpoint)
+
+	_, err = redfish.EndpointFromMetadata("not json")
+	assert.Equal(t, codes.InvalidArgument, grpc_status.Code(err))
+}
+
+func TestBootController(t *testing.T) {
+	bmc := om_testing.NewRedfishMock()
+	defer bmc.Close()
+	ctx := context.Background()
+	credentials := func() (redfish.Credentials, error) { return mockCredentials, nil }
+
+	_, err := redfish.NewBootController(redfish.Config{BootTarget: "floppy"}, credentials)
+	assert.Equal(t, codes.InvalidArgument, grpc_status.Code(err))
+
+	controller, err := redfish.NewBootController(redfish.Config{BootTarget: redfish.BootTargetPXE, Timeout: time.Second},
+		credentials, redfish.WithHTTPClient(bmc.Client()))
+	require.NoError(t, err)
+
+	// hosts without a BMC are left alone
+	deviceInfo := onboarding_types.DeviceInfo{GUID: "host-without-bmc"}
+	require.NoError(t, controller.Netboot(ctx, deviceInfo))
+	assert.Empty(t, bmc.Resets())
+
+	deviceInfo = onboarding_types.DeviceInfo{GUID: "host-with-bmc", RedfishEndpoint: bmc.URL}
+	require.NoError(t, controller.Netboot(ctx, deviceInfo))
+	assert.Equal(t, "Pxe", bmc.Boot()["BootSourceOverrideTarget"])
+	assert.Equal(t, []string{"ForceRestart"}, bmc.Resets())
+
+	// powering off after a provisioning failure is opt-in
+	require.NoError(t, controller.PowerOff(ctx, deviceInfo))
+	assert.Equal(t, redfish.PowerStateOn, bmc.PowerState())
+
+	controller, err = redfish.NewBootController(redfish.Config{BootTarget: redfish.BootTargetPXE, PowerOffOnFailure: true},
+		credentials, redfish.WithHTTPClient(bmc.Client()))
+	require.NoError(t, err)
+	require.NoError(t, controller.PowerOff(ctx, deviceInfo))
+	assert.Equal(t, redfish.PowerStateOff, bmc.PowerState())
+
+	// credentials are read on every operation
+	secretErr := errors.New("secret not found")
+	controller, err = redfish.NewBootController(redfish.Config{BootTarget: redfish.BootTargetPXE},
+		func() (redfish.Credentials, error) { return redfish.Credentials{}, secretErr },
+		redfish.WithHTTPClient(bmc.Client()))
+	require.NoError(t, err)
+	require.ErrorIs(t, controller.Netboot(ctx, deviceInfo), secretErr)
+}
@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sanitization signs and keeps the disk sanitization reports of the hosts sanitized by the decommission
+// workflow before they are deleted. The reports are written by the disk_sanitize tinker action, see
+// tinker-actions/src/disk_sanitize.
+package sanitization
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/util"
+)
+
+// Policy defines whether the disks of a host are sanitized before it is deleted.
+type Policy string
+
+const (
+	// PolicyDefault sanitizes the disks of the host if sanitization is enabled for all the hosts.
+	PolicyDefault Policy = ""
+	// PolicyRequired sanitizes the disks of the host.
+	PolicyRequired Policy = "required"
+	// PolicySkip deletes the host without sanitizing its disks.
+	PolicySkip Policy = "skip"
+)
+
+const (
+	// MetadataKey is the host metadata key of the sanitization Policy of a host.
+	MetadataKey = "disk-sanitization"
+
+	// Algorithm is the signature algorithm of the records.
+	Algorithm = "Ed25519"
+
+	// The results of a sanitization, as reported by the action.
+	ResultSanitized = "sanitized"
+	ResultFailed    = "failed"
+
+	keyIDLength = 8
+)
+
+// PolicyFromMetadata returns the sanitization Policy in the host metadata, a JSON list of key/value pairs.
+func PolicyFromMetadata(metadata string) (Policy, error) {
+	values, err := util.MetadataValues(metadata, MetadataKey)
+	if err != nil || len(values) == 0 {
+		return PolicyDefault, err
+	}
+	switch policy := Policy(strings.ToLower(values[0])); policy {
+	case PolicyRequired, PolicySkip:
+		return policy, nil
+	default:
+		return PolicyDefault, inv_errors.Errorfc(codes.InvalidArgument,
+			"Invalid %s %q, must be %q or %q", MetadataKey, values[0], PolicyRequired, PolicySkip)
+	}
+}
+
+// Sanitize returns whether the disks of a host with policy are sanitized, given whether sanitization is enabled
+// for all the hosts.
+func (p Policy) Sanitize(byDefault bool) bool {
+	return p == PolicyRequired || (p == PolicyDefault && byDefault)
+}
+
+// DiskReport is the sanitization report of a disk, as written by the action.
+type DiskReport struct {
+	Device      string    `json:"device"`
+	Model       string    `json:"model,omitempty"`
+	Serial      string    `json:"serial,omitempty"`
+	SizeBytes   int64     `json:"sizeBytes"`
+	Method      string    `json:"method,omitempty"`
+	Passes      int       `json:"passes,omitempty"`
+	Result      string    `json:"result"`
+	Error       string    `json:"error,omitempty"`
+	Verified    bool      `json:"verified"`
+	Notes       []string  `json:"notes,omitempty"`
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+// Report is the sanitization report of a host, as written by the action.
+type Report struct {
+	StartedAt   time.Time    `json:"startedAt"`
+	CompletedAt time.Time    `json:"completedAt"`
+	Disks       []DiskReport `json:"disks"`
+}
+
+// ParseReport parses the report written by the action.
+func ParseReport(data []byte) (*Report, error) {
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "Failed to parse the sanitization report: %v", err)
+	}
+	return &report, nil
+}
+
+// Record is the signed record of the sanitization of a host.
+type Record struct {
+	HostUUID       string `json:"hostUuid"`
+	HostResourceID string `json:"hostResourceId"`
+	TenantID       string `json:"tenantId"`
+	SerialNumber   string `json:"serialNumber,omitempty"`
+	// Result is ResultSanitized if all the disks of the host were sanitized.
+	Result string `json:"result"`
+	// Error is the reason of the failure if the action did not report how the disks were sanitized.
+	Error      string    `json:"error,omitempty"`
+	Report     *Report   `json:"report,omitempty"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// SignedRecord is a Record and its signature. The signature is computed on the exact bytes of the record, so that
+// it is verified without re-encoding the record.
+type SignedRecord struct {
+	Record    json.RawMessage `json:"record"`
+	Algorithm string          `json:"algorithm"`
+	KeyID     string          `json:"keyId"`
+	Signature []byte          `json:"signature"`
+}
+
+// Signer signs the sanitization records with an Ed25519 key.
+type Signer struct {
+	key   ed25519.PrivateKey
+	keyID string
+}
+
+// NewSigner returns a Signer signing with key.
+func NewSigner(key ed25519.PrivateKey) *Signer {
+	pub, _ := key.Public().(ed25519.PublicKey)
+	return &Signer{key: key, keyID: KeyID(pub)}
+}
+
+// LoadSigner returns a Signer signing with the PEM encoded PKCS #8 Ed25519 private key at path, e.g. generated
+// with "openssl genpkey -algorithm ed25519".
+func LoadSigner(path string) (*Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, inv_errors.Errorfc(codes.Internal, "Failed to read the signing key: %v", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "No PEM block in the signing key %s", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "Failed to parse the signing key: %v", err)
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "The signing key %s is not an Ed25519 key", path)
+	}
+	return NewSigner(edKey), nil
+}
+
+// KeyID returns the identifier of a public key, the first bytes of the SHA-256 digest of the key.
+func KeyID(pub ed25519.PublicKey) string {
+	digest := sha256.Sum256(pub)
+	return hex.EncodeToString(digest[:keyIDLength])
+}
+
+// PublicKey returns the public key verifying the signatures.
+func (s *Signer) PublicKey() ed25519.PublicKey {
+	pub, _ := s.key.Public().(ed25519.PublicKey)
+	return pub
+}
+
+// Sign signs record.
+func (s *Signer) Sign(record Record) (SignedRecord, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return SignedRecord{}, inv_errors.Errorfc(codes.Internal, "Failed to encode the sanitization record: %v", err)
+	}
+	return SignedRecord{
+		Record:    data,
+		Algorithm: Algorithm,
+		KeyID:     s.keyID,
+		Signature: ed25519.Sign(s.key, data),
+	}, nil
+}
+
+// Verify verifies the signature of signed with pub and returns its record.
+func Verify(pub ed25519.PublicKey, signed SignedRecord) (Record, error) {
+	if signed.Algorithm != Algorithm {
+		return Record{}, inv_errors.Errorfc(codes.InvalidArgument, "Unsupported signature algorithm %q", signed.Algorithm)
+	}
+	if !ed25519.Verify(pub, signed.Record, signed.Signature) {
+		return Record{}, inv_errors.Errorfc(codes.Unauthenticated, "Invalid signature of the sanitization record")
+	}
+	var record Record
+	if err := json.Unmarshal(signed.Record, &record); err != nil {
+		return Record{}, inv_errors.Errorfc(codes.InvalidArgument, "Failed to parse the sanitization record: %v", err)
+	}
+	return record, nil
+}
@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package sanitization_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/sanitization"
+)
+
+const actionReport = `{"startedAt":"2025-06-02T10:00:00Z","completedAt":"2025-06-02T10:02:13Z","disks":[` +
+	`{"device":"/dev/nvme0n1","model":"SAMSUNG MZVL2512HCJQ","serial":"S675NX0T123456","sizeBytes":512110190592,` +
+	`"method":"nvme-sanitize-crypto-erase","result":"sanitized","verified":false,` +
+	`"startedAt":"2025-06-02T10:00:00Z","completedAt":"2025-06-02T10:02:13Z"}]}`
+
+func TestPolicyFromMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		want     sanitization.Policy
+		wantErr  bool
+	}{
+		{"No metadata", "", sanitization.PolicyDefault, false},
+		{"Other keys", `[{"key":"redfish-endpoint","value":"https://10.0.0.1"}]`, sanitization.PolicyDefault, false},
+		{"Required", `[{"key":"disk-sanitization","value":"required"}]`, sanitization.PolicyRequired, false},
+		{"Skip", `[{"key":"disk-sanitization","value":"Skip"}]`, sanitization.PolicySkip, false},
+		{"Invalid value", `[{"key":"disk-sanitization","value":"yes"}]`, sanitization.PolicyDefault, true},
+		{"Invalid metadata", `{"disk-sanitization":"required"}`, sanitization.PolicyDefault, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sanitization.PolicyFromMetadata(tt.metadata)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.True(t, sanitization.PolicyRequired.Sanitize(false))
+	assert.True(t, sanitization.PolicyDefault.Sanitize(true))
+	assert.False(t, sanitization.PolicyDefault.Sanitize(false))
+	assert.False(t, sanitization.PolicySkip.Sanitize(true))
+}
+
+func writeKey(t *testing.T, key any) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	return path
+}
+
+func TestSignAndVerify(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := sanitization.LoadSigner(writeKey(t, key))
+	require.NoError(t, err)
+
+	report, err := sanitization.ParseReport([]byte(actionReport))
+	require.NoError(t, err)
+	require.Len(t, report.Disks, 1)
+	assert.Equal(t, "S675NX0T123456", report.Disks[0].Serial)
+
+	record := sanitization.Record{
+		HostUUID:       "57ed598c-4b94-11ee-806c-3a7c7693aac3",
+		HostResourceID: "host-12345678",
+		TenantID:       "11111111-1111-1111-1111-111111111111",
+		Result:         sanitization.ResultSanitized,
+		Report:         report,
+		RecordedAt:     time.Date(2025, 6, 2, 10, 3, 0, 0, time.UTC),
+	}
+	signed, err := signer.Sign(record)
+	require.NoError(t, err)
+	assert.Equal(t, sanitization.Algorithm, signed.Algorithm)
+	assert.Equal(t, sanitization.KeyID(signer.PublicKey()), signed.KeyID)
+	assert.Len(t, signed.KeyID, 16)
+
+	// the signature survives the encoding of the signed record
+	data, err := json.Marshal(signed)
+	require.NoError(t, err)
+	var decoded sanitization.SignedRecord
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	got, err := sanitization.Verify(signer.PublicKey(), decoded)
+	require.NoError(t, err)
+	assert.Equal(t, record, got)
+
+	tampered := decoded
+	tampered.Record = json.RawMessage(
+		`{"hostUuid":"57ed598c-4b94-11ee-806c-3a7c7693aac3","result":"sanitized","recordedAt":"2025-06-02T10:03:00Z"}`)
+	_, err = sanitization.Verify(signer.PublicKey(), tampered)
+	require.Error(t, err)
+
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, err = sanitization.Verify(otherPub, decoded)
+	require.Error(t, err)
+}
+
+func TestLoadSigner_Errors(t *testing.T) {
+	_, err := sanitization.LoadSigner(filepath.Join(t.TempDir(), "missing.pem"))
+	require.Error(t, err)
+
+	notPEM := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("key"), 0o600))
+	_, err = sanitization.LoadSigner(notPEM)
+	require.Error(t, err)
+
+	// an RSA key is rejected, the records are signed with Ed25519 only
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, err = sanitization.LoadSigner(writeKey(t, rsaKey))
+	require.Error(t, err)
+}
+
+func TestStore_Save(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := fake.NewClientBuilder().Build()
+	store := sanitization.NewStore(k8sClient, "orch-infra")
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	record := sanitization.Record{
+		HostUUID: "57ed598c-4b94-11ee-806c-3a7c7693aac3",
+		TenantID: "11111111-1111-1111-1111-111111111111",
+		Result:   sanitization.ResultFailed,
+		Error:    "sanitize-disks failed (DISK_ERASE_FAILED): exit status 91",
+	}
+	signed, err := sanitization.NewSigner(key).Sign(record)
+	require.NoError(t, err)
+
+	name := sanitization.RecordName(record.HostUUID, 1748858400)
+	assert.Equal(t, "disk-sanitization-57ed598c-4b94-11ee-806c-3a7c7693aac3-1748858400", name)
+	require.NoError(t, store.Save(ctx, name, record, signed))
+	// saving again, e.g. when the deletion of the host is retried, is a no-op
+	require.NoError(t, store.Save(ctx, name, record, signed))
+
+	var cm corev1.ConfigMap
+	require.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "orch-infra", Name: name}, &cm))
+	assert.Equal(t, map[string]string{
+		sanitization.LabelHost:   record.HostUUID,
+		sanitization.LabelTenant: record.TenantID,
+		sanitization.LabelResult: sanitization.ResultFailed,
+	}, cm.Labels)
+
+	var saved sanitization.SignedRecord
+	require.NoError(t, json.Unmarshal([]byte(cm.Data[sanitization.RecordKey]), &saved))
+	got, err := sanitization.Verify(key.Public().(ed25519.PublicKey), saved)
+	require.NoError(t, err)
+	assert.Equal(t, record, got)
+}
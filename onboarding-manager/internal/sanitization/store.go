@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package sanitization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+)
+
+const (
+	// LabelHost labels the sanitization records with the UUID of their host.
+	LabelHost = "onboarding.edge-orchestrator.intel.com/host"
+	// LabelTenant labels the sanitization records with the tenant of their host.
+	LabelTenant = "onboarding.edge-orchestrator.intel.com/tenant"
+	// LabelResult labels the sanitization records with their result.
+	LabelResult = "onboarding.edge-orchestrator.intel.com/sanitization-result"
+
+	// RecordKey is the key of the signed record in the ConfigMap data.
+	RecordKey = "record.json"
+)
+
+// Store keeps the signed sanitization records in ConfigMaps, which outlive the inventory resources of the hosts.
+type Store struct {
+	client    client.Client
+	namespace string
+}
+
+// NewStore returns a Store of the records in namespace.
+func NewStore(k8sClient client.Client, namespace string) *Store {
+	return &Store{client: k8sClient, namespace: namespace}
+}
+
+// RecordName returns the name of the record of a sanitization of a host. The sanitizations of a host are told apart
+// by id, e.g. the creation time of their workflow, so that saving a record again is a no-op.
+func RecordName(hostUUID string, id int64) string {
+	return fmt.Sprintf("disk-sanitization-%s-%d", hostUUID, id)
+}
+
+// Save saves signed, the signed record of record, as name.
+func (s *Store) Save(ctx context.Context, name string, record Record, signed SignedRecord) error {
+	data, err := json.Marshal(signed)
+	if err != nil {
+		return inv_errors.Errorfc(codes.Internal, "Failed to encode the sanitization record: %v", err)
+	}
+	labels := map[string]string{LabelHost: record.HostUUID, LabelResult: record.Result}
+	if record.TenantID != "" {
+		labels[LabelTenant] = record.TenantID
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: s.namespace, Labels: labels},
+		Data:       map[string]string{RecordKey: string(data)},
+	}
+	if err := s.client.Create(ctx, cm); err != nil && !k8s_errors.IsAlreadyExists(err) {
+		return inv_errors.Errorfc(codes.Internal, "Failed to save the sanitization record %s: %v", name, err)
+	}
+	return nil
+}
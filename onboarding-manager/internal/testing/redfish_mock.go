@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package testing //nolint:revive,nolintlint // used for testing only
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+const (
+	// RedfishMockUsername is the username accepted by the RedfishMock.
+	RedfishMockUsername = "admin"
+	// RedfishMockPassword is the password accepted by the RedfishMock.
+	RedfishMockPassword = "password"
+	// RedfishMockSystemPath is the path of the computer system managed by the RedfishMock.
+	RedfishMockSystemPath = "/redfish/v1/Systems/1"
+)
+
+// RedfishMock is an in-process BMC serving the subset of the Redfish API used by the onboarding manager.
+// It manages a single computer system and records the boot overrides and resets it receives.
+type RedfishMock struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	powerState string
+	boot       map[string]string
+	resets     []string
+}
+
+// NewRedfishMock starts a RedfishMock with a powered on computer system. It must be closed after use.
+func NewRedfishMock() *RedfishMock {
+	m := &RedfishMock{powerState: "On"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /redfish/v1/", m.serviceRoot)
+	mux.HandleFunc("GET /redfish/v1/Systems", m.systems)
+	mux.HandleFunc("GET "+RedfishMockSystemPath, m.system)
+	mux.HandleFunc("PATCH "+RedfishMockSystemPath, m.patchSystem)
+	mux.HandleFunc("POST "+RedfishMockSystemPath+"/Actions/ComputerSystem.Reset", m.reset)
+	m.Server = httptest.NewTLSServer(m.authenticate(mux))
+	return m
+}
+
+// SetPowerState sets the power state of the computer system.
+func (m *RedfishMock) SetPowerState(powerState string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.powerState = powerState
+}
+
+// PowerState returns the power state of the computer system.
+func (m *RedfishMock) PowerState() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.powerState
+}
+
+// Boot returns the boot properties set on the computer system.
+func (m *RedfishMock) Boot() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	boot := make(map[string]string, len(m.boot))
+	for k, v := range m.boot {
+		boot[k] = v
+	}
+	return boot
+}
+
+// Resets returns the reset types received, in order.
+func (m *RedfishMock) Resets() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.resets...)
+}
+
+func (m *RedfishMock) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != RedfishMockUsername || password != RedfishMockPassword {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *RedfishMock) serviceRoot(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/redfish/v1/" {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, map[string]any{"RedfishVersion": "1.15.0", "Systems": map[string]string{"@odata.id": "/redfish/v1/Systems"}})
+}
+
+func (m *RedfishMock) systems(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, map[string]any{"Members": []map[string]string{{"@odata.id": RedfishMockSystemPath}}})
+}
+
+func (m *RedfishMock) system(w http.ResponseWriter, _ *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	writeJSON(w, map[string]any{"@odata.id": RedfishMockSystemPath, "PowerState": m.powerState, "Boot": m.boot})
+}
+
+func (m *RedfishMock) patchSystem(w http.ResponseWriter, r *http.Request) {
+	var patch struct {
+		Boot map[string]string `json:"Boot"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.boot = patch.Boot
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *RedfishMock) reset(w http.ResponseWriter, r *http.Request) {
+	var action struct {
+		ResetType string `json:"ResetType"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&action); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch action.ResetType {
+	case "On", "ForceRestart":
+		m.powerState = "On"
+	case "ForceOff":
+		m.powerState = "Off"
+	default:
+		http.Error(w, "unsupported ResetType", http.StatusBadRequest)
+		return
+	}
+	m.resets = append(m.resets, action.ResetType)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package tinkerbell
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/codes"
+
+	inv_errors "github.com/open-edge-platform/infra-core/inventory/v2/pkg/errors"
+)
+
+const (
+	// ArchX86_64 is the architecture of x86 hosts, as named in the uOS artifacts of DKAM.
+	ArchX86_64 = "x86_64"
+	// ArchAarch64 is the architecture of arm64 hosts, as named in the uOS artifacts of DKAM.
+	ArchAarch64 = "aarch64"
+)
+
+// packageArchitectures maps the architectures of the hosts to the names used by container images and Debian
+// packages.
+var packageArchitectures = map[string]string{
+	ArchX86_64:  "amd64",
+	ArchAarch64: "arm64",
+}
+
+// HostArchitecture returns the architecture of a host as named in the uOS artifacts, given the architecture
+// reported by the host. Hosts that predate multi-architecture support do not report it and are x86_64.
+// Unsupported architectures are returned as reported.
+func HostArchitecture(reported string) string {
+	if reported == "" {
+		return ArchX86_64
+	}
+	if arch := normalizeArchitecture(reported); arch != "" {
+		return arch
+	}
+	return reported
+}
+
+// normalizeArchitecture returns the name of the architecture in the uOS artifacts, accepting the Go and Debian
+// names (amd64, arm64) as well. It returns an empty string if the architecture is not supported.
+func normalizeArchitecture(arch string) string {
+	switch strings.ToLower(strings.TrimSpace(arch)) {
+	case ArchX86_64, "amd64", "x86-64":
+		return ArchX86_64
+	case ArchAarch64, "arm64":
+		return ArchAarch64
+	default:
+		return ""
+	}
+}
+
+// CheckOSArchitecture returns an error if an OS of the architecture cannot be provisioned on a host of the
+// architecture. OS resources that do not tell their architecture can be provisioned on any supported host.
+func CheckOSArchitecture(osArch, hostArch string) error {
+	if _, ok := packageArchitectures[hostArch]; !ok {
+		return inv_errors.Errorfc(codes.Aborted, "Unsupported host architecture %s", hostArch)
+	}
+	if osArch != "" && HostArchitecture(osArch) != hostArch {
+		return inv_errors.Errorfc(codes.Aborted, "OS architecture %s does not match host architecture %s",
+			osArch, hostArch)
+	}
+	return nil
+}
+
+// PackageArchitecture returns the name of the architecture used by container images and Debian packages,
+// e.g. arm64 for aarch64.
+func PackageArchitecture(arch string) string {
+	if pkgArch, ok := packageArchitectures[arch]; ok {
+		return pkgArch
+	}
+	return packageArchitectures[ArchX86_64]
+}
+
+// actionImageForArch returns the tinker action image of the architecture. Action images are built for a single
+// architecture, the x86_64 images are tagged with the version only and the images of the other architectures
+// with the version suffixed by the architecture, e.g. image2disk:v1.0.0-arm64. Images pinned by digest are
+// returned as is.
+func actionImageForArch(image, arch string) string {
+	if arch == "" || arch == ArchX86_64 || strings.Contains(image, "@") {
+		return image
+	}
+	return image + "-" + PackageArchitecture(arch)
+}
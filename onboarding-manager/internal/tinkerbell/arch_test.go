@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package tinkerbell_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+
+	onboarding_types "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/onboarding/types"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
+)
+
+func TestHostArchitecture(t *testing.T) {
+	for reported, want := range map[string]string{
+		"":        "x86_64",
+		"x86_64":  "x86_64",
+		"amd64":   "x86_64",
+		"aarch64": "aarch64",
+		"arm64":   "aarch64",
+		"riscv64": "riscv64",
+	} {
+		assert.Equal(t, want, tinkerbell.HostArchitecture(reported), reported)
+	}
+}
+
+func TestCheckOSArchitecture(t *testing.T) {
+	tests := []struct {
+		name     string
+		osArch   string
+		hostArch string
+		wantErr  bool
+	}{
+		{name: "OS without architecture", osArch: "", hostArch: "aarch64"},
+		{name: "Same architecture", osArch: "x86_64", hostArch: "x86_64"},
+		{name: "Same architecture with another name", osArch: "arm64", hostArch: "aarch64"},
+		{name: "x86 OS on arm host", osArch: "x86_64", hostArch: "aarch64", wantErr: true},
+		{name: "arm OS on x86 host", osArch: "aarch64", hostArch: "x86_64", wantErr: true},
+		{name: "Unsupported host", osArch: "", hostArch: "riscv64", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tinkerbell.CheckOSArchitecture(tt.osArch, tt.hostArch)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Equal(t, codes.Aborted, grpc_status.Code(err))
+		})
+	}
+}
+
+func TestPackageArchitecture(t *testing.T) {
+	assert.Equal(t, "amd64", tinkerbell.PackageArchitecture("x86_64"))
+	assert.Equal(t, "arm64", tinkerbell.PackageArchitecture("aarch64"))
+}
+
+func TestGenerateDecommissionWorkflowInputs_Architecture(t *testing.T) {
+	tests := []struct {
+		arch        string
+		wantSuffix  string
+		wantPackage string
+	}{
+		{arch: "", wantSuffix: ":v1.0.0", wantPackage: "amd64"},
+		{arch: "x86_64", wantSuffix: ":v1.0.0", wantPackage: "amd64"},
+		{arch: "aarch64", wantSuffix: ":v1.0.0-arm64", wantPackage: "arm64"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arch, func(t *testing.T) {
+			inputs := tinkerbell.GenerateDecommissionWorkflowInputs(onboarding_types.DeviceInfo{
+				Architecture:  tt.arch,
+				TinkerVersion: "v1.0.0",
+			})
+			image := inputs["TinkerActionImageDiskSanitize"]
+			assert.Truef(t, strings.HasSuffix(image, tt.wantSuffix), "image %s", image)
+			assert.Equal(t, tt.wantPackage, inputs["PackageArchitecture"])
+			assert.Equal(t, tt.arch, inputs["DeviceInfoArchitecture"])
+		})
+	}
+}
@@ -8,10 +8,12 @@ import (
 	"time"
 
 	tinkv1alpha1 "github.com/tinkerbell/tink/api/v1alpha1"
+	"google.golang.org/grpc/codes"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -185,6 +187,81 @@ func CreateWorkflowIfNotExists(ctx context.Context, k8sCli client.Client, workfl
 	return nil
 }
 
+// FailRunningAction fails the running action of a workflow with message, and so the workflow, as the Tinkerbell
+// controller does when a workflow times out. The workflow is updated in place.
+func FailRunningAction(ctx context.Context, k8sCli client.Client, workflow *tinkv1alpha1.Workflow, message string) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultK8sClientTimeout)
+	defer cancel()
+
+	for i := range workflow.Status.Tasks {
+		for j := range workflow.Status.Tasks[i].Actions {
+			action := &workflow.Status.Tasks[i].Actions[j]
+			if action.Status == tinkv1alpha1.WorkflowStateRunning {
+				action.Status = tinkv1alpha1.WorkflowStateFailed
+				action.Message = message
+			}
+		}
+	}
+	workflow.Status.State = tinkv1alpha1.WorkflowStateFailed
+
+	if err := k8sCli.Status().Update(ctx, workflow); err != nil {
+		zlog.InfraSec().InfraErr(err).Msgf("")
+		return inv_errors.Errorf("Failed to fail Tinkerbell workflow %s", workflow.Name)
+	}
+	zlog.Debug().Msgf("Tinkerbell workflow %q failed: %s", workflow.Name, message)
+	return nil
+}
+
+// SetRunningActionMessage sets the message of the running action of a workflow, as reported by its worker. The
+// Tinkerbell server does not set the message of the actions, but keeps it when it updates their status, so the
+// message of an action is kept once it finishes. It fails with FailedPrecondition if the action is not running.
+func SetRunningActionMessage(ctx context.Context, k8sCli client.Client, key client.ObjectKey,
+	taskName, actionName, message string,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultK8sClientTimeout)
+	defer cancel()
+
+	var notRunning error
+	// the Tinkerbell server and controller update the status of the workflow concurrently
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		workflow := &tinkv1alpha1.Workflow{}
+		if err := k8sCli.Get(ctx, key, workflow); err != nil {
+			return err
+		}
+		action := findAction(workflow, taskName, actionName)
+		if action == nil || action.Status != tinkv1alpha1.WorkflowStateRunning {
+			notRunning = inv_errors.Errorfc(codes.FailedPrecondition, "Action %s of Tinkerbell workflow %s is not running",
+				actionName, key.Name)
+			return nil
+		}
+		action.Message = message
+		return k8sCli.Status().Update(ctx, workflow)
+	})
+	if errors.IsNotFound(err) {
+		return inv_errors.Errorfc(codes.NotFound, "Tinkerbell workflow %s not found", key.Name)
+	}
+	if err != nil {
+		zlog.InfraSec().InfraErr(err).Msgf("")
+		return inv_errors.Errorf("Failed to set the action message of Tinkerbell workflow %s", key.Name)
+	}
+	return notRunning
+}
+
+// findAction returns the action of a task of the workflow, nil if there is none.
+func findAction(workflow *tinkv1alpha1.Workflow, taskName, actionName string) *tinkv1alpha1.Action {
+	for i := range workflow.Status.Tasks {
+		if workflow.Status.Tasks[i].Name != taskName {
+			continue
+		}
+		for j := range workflow.Status.Tasks[i].Actions {
+			if workflow.Status.Tasks[i].Actions[j].Name == actionName {
+				return &workflow.Status.Tasks[i].Actions[j]
+			}
+		}
+	}
+	return nil
+}
+
 // DeleteWorkflowIfExists performs operations for onboarding management.
 func DeleteWorkflowIfExists(ctx context.Context, k8sNamespace, workflowName string) error {
 	ctx, cancel := context.WithTimeout(ctx, defaultK8sClientTimeout)
@@ -12,10 +12,14 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	tink "github.com/tinkerbell/tink/api/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	error_k8 "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	om_testing "github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/testing"
 )
@@ -222,6 +226,99 @@ func TestCreateWorkflowIfNotExists(t *testing.T) {
 	}
 }
 
+func TestFailRunningAction(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := tink.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	workflow := &tink.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "workflow-host", Namespace: "default"},
+		Status: tink.WorkflowStatus{
+			State: tink.WorkflowStateRunning,
+			Tasks: []tink.Task{{Actions: []tink.Action{
+				{Name: ActionEraseNonRemovableDisk, Status: tink.WorkflowStateSuccess},
+				{Name: ActionStreamOSImage, Status: tink.WorkflowStateRunning, Message: "progress: {}"},
+				{Name: ActionReboot, Status: tink.WorkflowStatePending},
+			}}},
+		},
+	}
+	k8sCli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(workflow).WithStatusSubresource(workflow).Build()
+
+	if err := FailRunningAction(context.Background(), k8sCli, workflow, "worker unresponsive for 10m0s"); err != nil {
+		t.Fatalf("FailRunningAction() error = %v", err)
+	}
+	got := &tink.Workflow{}
+	if err := k8sCli.Get(context.Background(), client.ObjectKeyFromObject(workflow), got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.State != tink.WorkflowStateFailed {
+		t.Errorf("expected failed workflow, got %s", got.Status.State)
+	}
+	wantStatuses := []tink.WorkflowState{tink.WorkflowStateSuccess, tink.WorkflowStateFailed, tink.WorkflowStatePending}
+	for i, action := range got.Status.Tasks[0].Actions {
+		if action.Status != wantStatuses[i] {
+			t.Errorf("expected action %s to be %s, got %s", action.Name, wantStatuses[i], action.Status)
+		}
+	}
+	if code, action := ErrorCodeFromWorkflow(got); code != ErrorCodeWorkerUnresponsive || action != ActionStreamOSImage {
+		t.Errorf("expected %s error code of action %s, got %s of %s", ErrorCodeWorkerUnresponsive, ActionStreamOSImage, code, action)
+	}
+
+	missing := &tink.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "workflow-missing", Namespace: "default"}}
+	if err := FailRunningAction(context.Background(), k8sCli, missing, "worker unresponsive"); err == nil {
+		t.Error("expected an error failing a missing workflow")
+	}
+}
+
+func TestSetRunningActionMessage(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := tink.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	workflow := &tink.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "workflow-host", Namespace: "default"},
+		Status: tink.WorkflowStatus{
+			State: tink.WorkflowStateRunning,
+			Tasks: []tink.Task{{Name: "os-installation", Actions: []tink.Action{
+				{Name: ActionEraseNonRemovableDisk, Status: tink.WorkflowStateSuccess, Message: "report: {}"},
+				{Name: ActionStreamOSImage, Status: tink.WorkflowStateRunning},
+			}}},
+		},
+	}
+	k8sCli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(workflow).WithStatusSubresource(workflow).Build()
+	key := client.ObjectKeyFromObject(workflow)
+
+	message := `progress: {"stage":"writing OS image","bytesWritten":1000,"totalBytes":4000,"percent":25}`
+	if err := SetRunningActionMessage(context.Background(), k8sCli, key, "os-installation", ActionStreamOSImage,
+		message); err != nil {
+		t.Fatalf("SetRunningActionMessage() error = %v", err)
+	}
+	got := &tink.Workflow{}
+	if err := k8sCli.Get(context.Background(), key, got); err != nil {
+		t.Fatal(err)
+	}
+	if progress, ok := ProgressFromAction(got.Status.Tasks[0].Actions[1]); !ok || progress.Percent != 25 {
+		t.Errorf("expected the progress of the running action to be set, got %+v", got.Status.Tasks[0].Actions[1])
+	}
+
+	// the message of a finished action is kept
+	for _, action := range []string{ActionEraseNonRemovableDisk, ActionReboot} {
+		err := SetRunningActionMessage(context.Background(), k8sCli, key, "os-installation", action, message)
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected FailedPrecondition setting the message of action %s, got %v", action, err)
+		}
+	}
+	err := SetRunningActionMessage(context.Background(), k8sCli, key, "other-task", ActionStreamOSImage, message)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition setting the message of an action of another task, got %v", err)
+	}
+	err = SetRunningActionMessage(context.Background(), k8sCli, client.ObjectKey{Namespace: "default", Name: "missing"},
+		"os-installation", ActionStreamOSImage, message)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound setting the message of a missing workflow, got %v", err)
+	}
+}
+
 func TestDeleteProdWorkflowResourcesIfExist(t *testing.T) {
 	type args struct {
 		ctx          context.Context
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package tinkerbell
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+)
+
+// ErrorCode is a machine-readable class of provisioning failure.
+type ErrorCode string
+
+// Error codes of the failed actions. The tinker actions report them through their exit status, as defined by the
+// catalogue of the tinker actions (tinker-actions/pkg/errcodes), and tink-worker reports the exit status in the
+// message of the failed action.
+const (
+	ErrorCodeUnknown              ErrorCode = "UNKNOWN"
+	ErrorCodeTimeout              ErrorCode = "TIMEOUT"
+	ErrorCodeInvalidConfiguration ErrorCode = "INVALID_CONFIGURATION"
+	ErrorCodeNoTargetDisk         ErrorCode = "NO_TARGET_DISK"
+	ErrorCodeDownloadFailed       ErrorCode = "DOWNLOAD_FAILED"
+	ErrorCodeImageDigestMismatch  ErrorCode = "IMAGE_DIGEST_MISMATCH"
+	ErrorCodeDiskWriteFailed      ErrorCode = "DISK_WRITE_FAILED"
+	ErrorCodeSecureBootMismatch   ErrorCode = "SECURE_BOOT_MISMATCH"
+	ErrorCodeFDEFailed            ErrorCode = "FDE_FAILED"
+	ErrorCodeCommandFailed        ErrorCode = "COMMAND_FAILED"
+	ErrorCodeBootConfigFailed     ErrorCode = "BOOT_CONFIG_FAILED"
+	ErrorCodePartitioningFailed   ErrorCode = "PARTITIONING_FAILED"
+	ErrorCodeKernelUpgradeFailed  ErrorCode = "KERNEL_UPGRADE_FAILED"
+	ErrorCodeDiskEraseFailed      ErrorCode = "DISK_ERASE_FAILED"
+	ErrorCodeHardwareNonCompliant ErrorCode = "HARDWARE_NONCOMPLIANT"
+	// ErrorCodeWorkerUnresponsive is not reported by an action: the onboarding manager fails the running action
+	// when its worker stops sending heartbeats.
+	ErrorCodeWorkerUnresponsive ErrorCode = "WORKER_UNRESPONSIVE"
+)
+
+// ExitStatusToErrorCode maps the exit statuses of the tinker actions onto their error codes.
+var ExitStatusToErrorCode = map[int]ErrorCode{
+	80: ErrorCodeInvalidConfiguration,
+	81: ErrorCodeNoTargetDisk,
+	82: ErrorCodeDownloadFailed,
+	83: ErrorCodeImageDigestMismatch,
+	84: ErrorCodeDiskWriteFailed,
+	85: ErrorCodeSecureBootMismatch,
+	86: ErrorCodeFDEFailed,
+	87: ErrorCodeCommandFailed,
+	88: ErrorCodeBootConfigFailed,
+	89: ErrorCodePartitioningFailed,
+	90: ErrorCodeKernelUpgradeFailed,
+	91: ErrorCodeDiskEraseFailed,
+	92: ErrorCodeHardwareNonCompliant,
+}
+
+var exitStatusMessage = regexp.MustCompile(`^exit status (\d+)$`)
+
+// ErrorCodeFromAction returns the error code of a failed or timed out action, an empty code for other actions.
+func ErrorCodeFromAction(action tink.Action) ErrorCode {
+	switch action.Status {
+	case tink.WorkflowStateTimeout:
+		return ErrorCodeTimeout
+	case tink.WorkflowStateFailed:
+		if strings.HasPrefix(action.Message, WorkerUnresponsiveMessage) {
+			return ErrorCodeWorkerUnresponsive
+		}
+		m := exitStatusMessage.FindStringSubmatch(failureMessage(action))
+		if m == nil {
+			return ErrorCodeUnknown
+		}
+		exitStatus, err := strconv.Atoi(m[1])
+		if err != nil {
+			return ErrorCodeUnknown
+		}
+		if code, ok := ExitStatusToErrorCode[exitStatus]; ok {
+			return code
+		}
+		return ErrorCodeUnknown
+	default:
+		return ""
+	}
+}
+
+// ErrorCodeFromWorkflow returns the error code and the name of the first failed or timed out action of a workflow,
+// empty strings if no action failed.
+func ErrorCodeFromWorkflow(workflow *tink.Workflow) (ErrorCode, string) {
+	if workflow == nil || len(workflow.Status.Tasks) == 0 {
+		return "", ""
+	}
+	// NOTE: we assume there is always 1 task for a workflow (see template_data.go).
+	for _, action := range workflow.Status.Tasks[0].Actions {
+		if code := ErrorCodeFromAction(action); code != "" {
+			return code, action.Name
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package tinkerbell_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell/templates"
+)
+
+// builtinActionErrorCodes are the error codes the built-in tinker actions exit with, by template image.
+var builtinActionErrorCodes = map[string][]tinkerbell.ErrorCode{
+	"TinkerActionImageSecureBootFlagRead": {
+		tinkerbell.ErrorCodeInvalidConfiguration, tinkerbell.ErrorCodeSecureBootMismatch,
+	},
+	"TinkerActionImageEraseNonRemovableDisk": {tinkerbell.ErrorCodeDiskEraseFailed},
+	"TinkerActionImageHardwarePreflight": {
+		tinkerbell.ErrorCodeInvalidConfiguration, tinkerbell.ErrorCodeHardwareNonCompliant,
+	},
+	"TinkerActionImageDiskSanitize": {
+		tinkerbell.ErrorCodeInvalidConfiguration, tinkerbell.ErrorCodeDiskEraseFailed,
+	},
+	"TinkerActionImageStreamOSImageToDisk": {
+		tinkerbell.ErrorCodeInvalidConfiguration, tinkerbell.ErrorCodeNoTargetDisk, tinkerbell.ErrorCodeDownloadFailed,
+		tinkerbell.ErrorCodeImageDigestMismatch, tinkerbell.ErrorCodeDiskWriteFailed,
+	},
+	"TinkerActionImageWriteFile": {
+		tinkerbell.ErrorCodeInvalidConfiguration, tinkerbell.ErrorCodeNoTargetDisk, tinkerbell.ErrorCodeDiskWriteFailed,
+	},
+	"TinkerActionImageCexec": {
+		tinkerbell.ErrorCodeInvalidConfiguration, tinkerbell.ErrorCodeNoTargetDisk, tinkerbell.ErrorCodeCommandFailed,
+	},
+	"TinkerActionImageFdeDmv":        {tinkerbell.ErrorCodeNoTargetDisk, tinkerbell.ErrorCodeFDEFailed},
+	"TinkerActionImageKernelUpgrade": {tinkerbell.ErrorCodeKernelUpgradeFailed},
+	"TinkerActionImageEfibootset":    {tinkerbell.ErrorCodeBootConfigFailed},
+}
+
+func exitStatusOf(t *testing.T, code tinkerbell.ErrorCode) int {
+	t.Helper()
+	for exitStatus, c := range tinkerbell.ExitStatusToErrorCode {
+		if c == code {
+			return exitStatus
+		}
+	}
+	t.Fatalf("No exit status for error code %s", code)
+	return 0
+}
+
+func TestErrorCodeFromAction(t *testing.T) {
+	for exitStatus, code := range tinkerbell.ExitStatusToErrorCode {
+		action := tink.Action{Status: tink.WorkflowStateFailed, Message: fmt.Sprintf("exit status %d", exitStatus)}
+		assert.Equal(t, code, tinkerbell.ErrorCodeFromAction(action))
+	}
+
+	tests := []struct {
+		name   string
+		action tink.Action
+		want   tinkerbell.ErrorCode
+	}{
+		{"Generic exit status", tink.Action{Status: tink.WorkflowStateFailed, Message: "exit status 1"},
+			tinkerbell.ErrorCodeUnknown},
+		{"Exit status and report", tink.Action{Status: tink.WorkflowStateFailed, Message: "exit status 91\nreport: {}"},
+			tinkerbell.ErrorCodeDiskEraseFailed},
+		{"Worker error", tink.Action{Status: tink.WorkflowStateFailed, Message: "pull image: not found"},
+			tinkerbell.ErrorCodeUnknown},
+		{"No message", tink.Action{Status: tink.WorkflowStateFailed}, tinkerbell.ErrorCodeUnknown},
+		{"Worker unresponsive", tink.Action{Status: tink.WorkflowStateFailed, Message: "worker unresponsive for 10m0s"},
+			tinkerbell.ErrorCodeWorkerUnresponsive},
+		{"Timeout", tink.Action{Status: tink.WorkflowStateTimeout, Message: "timeout"}, tinkerbell.ErrorCodeTimeout},
+		{"Running", tink.Action{Status: tink.WorkflowStateRunning, Message: "Started execution"}, ""},
+		{"Success", tink.Action{Status: tink.WorkflowStateSuccess, Message: "finished execution successfully"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tinkerbell.ErrorCodeFromAction(tt.action))
+		})
+	}
+}
+
+func TestErrorCodeFromWorkflow(t *testing.T) {
+	code, action := tinkerbell.ErrorCodeFromWorkflow(nil)
+	assert.Empty(t, code)
+	assert.Empty(t, action)
+
+	code, action = tinkerbell.ErrorCodeFromWorkflow(&tink.Workflow{Status: tink.WorkflowStatus{
+		Tasks: []tink.Task{{Actions: []tink.Action{
+			{Name: tinkerbell.ActionSecureBootStatusFlagRead, Status: tink.WorkflowStateSuccess},
+			{Name: tinkerbell.ActionStreamOSImage, Status: tink.WorkflowStateRunning},
+		}}},
+	}})
+	assert.Empty(t, code)
+	assert.Empty(t, action)
+
+	code, action = tinkerbell.ErrorCodeFromWorkflow(&tink.Workflow{Status: tink.WorkflowStatus{
+		Tasks: []tink.Task{{Actions: []tink.Action{
+			{Name: tinkerbell.ActionSecureBootStatusFlagRead, Status: tink.WorkflowStateSuccess},
+			{Name: tinkerbell.ActionStreamOSImage, Status: tink.WorkflowStateFailed, Message: "exit status 83"},
+			{Name: tinkerbell.ActionReboot, Status: tink.WorkflowStatePending},
+		}}},
+	}})
+	assert.Equal(t, tinkerbell.ErrorCodeImageDigestMismatch, code)
+	assert.Equal(t, tinkerbell.ActionStreamOSImage, action)
+}
+
+// TestBuiltinActionErrorCodes verifies that the error codes of every built-in action of the templates are
+// reported in the status detail of a failed workflow.
+func TestBuiltinActionErrorCodes(t *testing.T) {
+	actionImage := regexp.MustCompile(`- name: "([^"]+)"\s+image: {{ \.(TinkerActionImage\w+) }}`)
+
+	for _, tmpl := range [][]byte{
+		templates.UbuntuTemplate, templates.MicrovisorTemplate, templates.DecommissionTemplate,
+	} {
+		matches := actionImage.FindAllStringSubmatch(string(tmpl), -1)
+		require.NotEmpty(t, matches)
+
+		for _, m := range matches {
+			actionName, image := m[1], m[2]
+			codes, ok := builtinActionErrorCodes[image]
+			require.Truef(t, ok, "No error codes defined for image %s of action %q", image, actionName)
+
+			for _, code := range codes {
+				t.Run(fmt.Sprintf("%s_%s", actionName, code), func(t *testing.T) {
+					message := fmt.Sprintf("exit status %d", exitStatusOf(t, code))
+					workflow := &tink.Workflow{Status: tink.WorkflowStatus{
+						State: tink.WorkflowStateFailed,
+						Tasks: []tink.Task{{Actions: []tink.Action{
+							{Name: actionName, Status: tink.WorkflowStateFailed, Message: message},
+						}}},
+					}}
+
+					gotCode, gotAction := tinkerbell.ErrorCodeFromWorkflow(workflow)
+					assert.Equal(t, code, gotCode)
+					assert.Equal(t, actionName, gotAction)
+					assert.Equal(t, fmt.Sprintf("1/1: %s failed (%s): %s",
+						tinkerbell.WorkflowStepToStatusDetail[actionName], code, message),
+						tinkerbell.GenerateStatusDetailFromWorkflowState(workflow))
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package tinkerbell
+
+import (
+	"encoding/json"
+
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+)
+
+// actionHeartbeatPrefix prefixes the heartbeat reported by tink-worker in the message of a running action.
+const actionHeartbeatPrefix = "heartbeat: "
+
+// WorkerUnresponsiveMessage is the message of the action of a workflow failed by the onboarding manager because
+// the worker running it stopped sending heartbeats.
+const WorkerUnresponsiveMessage = "worker unresponsive"
+
+// WorkerHeartbeat is the heartbeat periodically reported by tink-worker while an action runs, with a snapshot of
+// the resources of the host. Seq increases with every heartbeat of the worker.
+type WorkerHeartbeat struct {
+	Seq                     int64  `json:"seq"`
+	Action                  string `json:"action"`
+	MemTotal                int64  `json:"memTotal"`
+	MemAvailable            int64  `json:"memAvailable"`
+	DiskReadBytesPerSecond  int64  `json:"diskReadBytesPerSecond"`
+	DiskWriteBytesPerSecond int64  `json:"diskWriteBytesPerSecond"`
+}
+
+// HeartbeatFromAction returns the last heartbeat reported for a running action, false if none was reported, e.g.
+// by a tink-worker with heartbeats disabled.
+func HeartbeatFromAction(action tink.Action) (WorkerHeartbeat, bool) {
+	data, ok := messageLine(action.Message, actionHeartbeatPrefix)
+	if action.Status != tink.WorkflowStateRunning || !ok {
+		return WorkerHeartbeat{}, false
+	}
+	var heartbeat WorkerHeartbeat
+	if err := json.Unmarshal([]byte(data), &heartbeat); err != nil {
+		zlog.Debug().Msgf("Invalid heartbeat of action %s: %v", action.Name, err)
+		return WorkerHeartbeat{}, false
+	}
+	return heartbeat, true
+}
+
+// RunningAction returns the running action of a workflow, false if none is running.
+func RunningAction(workflow *tink.Workflow) (tink.Action, bool) {
+	if workflow == nil || len(workflow.Status.Tasks) == 0 {
+		return tink.Action{}, false
+	}
+	// NOTE: we assume there is always 1 task for a workflow (see template_data.go).
+	for _, action := range workflow.Status.Tasks[0].Actions {
+		if action.Status == tink.WorkflowStateRunning {
+			return action, true
+		}
+	}
+	return tink.Action{}, false
+}
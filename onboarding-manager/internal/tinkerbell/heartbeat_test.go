@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package tinkerbell_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
+)
+
+func TestHeartbeatFromAction(t *testing.T) {
+	tests := []struct {
+		name   string
+		action tink.Action
+		want   tinkerbell.WorkerHeartbeat
+		wantOk bool
+	}{
+		{
+			"Heartbeat",
+			tink.Action{Status: tink.WorkflowStateRunning, Message: `heartbeat: {"seq":12,"action":"stream-os-image",` +
+				`"memTotal":16000000000,"memAvailable":8000000000,"diskReadBytesPerSecond":0,` +
+				`"diskWriteBytesPerSecond":48000000}`},
+			tinkerbell.WorkerHeartbeat{
+				Seq: 12, Action: "stream-os-image", MemTotal: 16000000000, MemAvailable: 8000000000,
+				DiskWriteBytesPerSecond: 48000000,
+			},
+			true,
+		},
+		{
+			"Progress and heartbeat",
+			tink.Action{Status: tink.WorkflowStateRunning, Message: `progress: {"stage":"writing OS image"}` +
+				"\n" + `heartbeat: {"seq":13,"action":"stream-os-image"}`},
+			tinkerbell.WorkerHeartbeat{Seq: 13, Action: "stream-os-image"},
+			true,
+		},
+		{"Started", tink.Action{Status: tink.WorkflowStateRunning, Message: "Started execution"}, tinkerbell.WorkerHeartbeat{}, false},
+		{"Invalid", tink.Action{Status: tink.WorkflowStateRunning, Message: "heartbeat: 12"}, tinkerbell.WorkerHeartbeat{}, false},
+		{
+			"Not running",
+			tink.Action{Status: tink.WorkflowStateSuccess, Message: `heartbeat: {"seq":12}`},
+			tinkerbell.WorkerHeartbeat{},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tinkerbell.HeartbeatFromAction(tt.action)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunningAction(t *testing.T) {
+	workflow := &tink.Workflow{Status: tink.WorkflowStatus{Tasks: []tink.Task{{Actions: []tink.Action{
+		{Name: "erase-non-removable-disk", Status: tink.WorkflowStateSuccess},
+		{Name: "stream-os-image", Status: tink.WorkflowStateRunning},
+		{Name: "reboot", Status: tink.WorkflowStatePending},
+	}}}}}
+	action, ok := tinkerbell.RunningAction(workflow)
+	assert.True(t, ok)
+	assert.Equal(t, "stream-os-image", action.Name)
+
+	workflow.Status.Tasks[0].Actions[1].Status = tink.WorkflowStateSuccess
+	_, ok = tinkerbell.RunningAction(workflow)
+	assert.False(t, ok)
+	_, ok = tinkerbell.RunningAction(&tink.Workflow{})
+	assert.False(t, ok)
+}
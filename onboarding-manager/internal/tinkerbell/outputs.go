@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package tinkerbell
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+)
+
+// actionOutputsPrefix prefixes the outputs published by an action in the message of the successful action, which
+// tink-worker reports to the onboarding manager before the success of the action, see SetRunningActionMessage.
+const actionOutputsPrefix = "outputs: "
+
+// The outputs of the actions recorded in the instance, as defined by the tinker actions
+// (tinker-actions/pkg/outputs).
+const (
+	OutputTargetDisk      = "TARGET_DISK"
+	OutputInstalledKernel = "INSTALLED_KERNEL"
+	OutputImageSHA256     = "IMAGE_SHA256"
+)
+
+// OutputsFromAction returns the outputs published by a successful action, false if it published none.
+func OutputsFromAction(action tink.Action) (map[string]string, bool) {
+	data, ok := messageLine(action.Message, actionOutputsPrefix)
+	if action.Status != tink.WorkflowStateSuccess || !ok {
+		return nil, false
+	}
+	var outputs map[string]string
+	if err := json.Unmarshal([]byte(data), &outputs); err != nil {
+		zlog.Debug().Msgf("Invalid outputs of action %s: %v", action.Name, err)
+		return nil, false
+	}
+	return outputs, true
+}
+
+// WorkflowOutputs returns the outputs published by the successful actions of the workflow. The outputs of an
+// action replace the ones of the same name published by the actions before it, as in the environment of the
+// actions.
+func WorkflowOutputs(workflow *tink.Workflow) map[string]string {
+	outputs := map[string]string{}
+	if workflow == nil {
+		return outputs
+	}
+	for _, task := range workflow.Status.Tasks {
+		for _, action := range task.Actions {
+			if actionOutputs, ok := OutputsFromAction(action); ok {
+				maps.Copy(outputs, actionOutputs)
+			}
+		}
+	}
+	return outputs
+}
+
+// OutputsStatusDetail returns the provisioning status detail of the outputs recorded in the instance, e.g.
+// "disk /dev/sda, kernel 6.8.0-52-generic, image sha256 <digest>", or an empty string if there is none.
+func OutputsStatusDetail(outputs map[string]string) string {
+	var details []string
+	for _, output := range []struct{ key, format string }{
+		{OutputTargetDisk, "disk %s"},
+		{OutputInstalledKernel, "kernel %s"},
+		{OutputImageSHA256, "image sha256 %s"},
+	} {
+		if value := outputs[output.key]; value != "" {
+			details = append(details, fmt.Sprintf(output.format, value))
+		}
+	}
+	return strings.Join(details, ", ")
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package tinkerbell_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
+)
+
+func TestOutputsFromAction(t *testing.T) {
+	tests := []struct {
+		name   string
+		action tink.Action
+		want   map[string]string
+		wantOk bool
+	}{
+		{
+			"Outputs",
+			tink.Action{Status: tink.WorkflowStateSuccess, Message: `outputs: {"TARGET_DISK":"/dev/sda"}`},
+			map[string]string{"TARGET_DISK": "/dev/sda"},
+			true,
+		},
+		{
+			"Report and outputs",
+			tink.Action{Status: tink.WorkflowStateSuccess, Message: "report: {}\noutputs: {\"TARGET_DISK\":\"/dev/sda\"}"},
+			map[string]string{"TARGET_DISK": "/dev/sda"},
+			true,
+		},
+		{"No outputs", tink.Action{Status: tink.WorkflowStateSuccess, Message: "finished execution successfully"}, nil, false},
+		{"Invalid", tink.Action{Status: tink.WorkflowStateSuccess, Message: `outputs: ["/dev/sda"]`}, nil, false},
+		{"Not successful", tink.Action{Status: tink.WorkflowStateFailed, Message: `outputs: {}`}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tinkerbell.OutputsFromAction(tt.action)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWorkflowOutputs(t *testing.T) {
+	assert.Empty(t, tinkerbell.WorkflowOutputs(nil))
+
+	workflow := &tink.Workflow{Status: tink.WorkflowStatus{Tasks: []tink.Task{{Actions: []tink.Action{
+		{Status: tink.WorkflowStateSuccess, Message: `outputs: {"TARGET_DISK":"/dev/sda","IMAGE_SHA256":"abc"}`},
+		{Status: tink.WorkflowStateSuccess, Message: `outputs: {"TARGET_DISK":"/dev/sdb"}`},
+		{Status: tink.WorkflowStateRunning, Message: `progress: {"stage":"upgrading kernel"}`},
+	}}}}}
+	assert.Equal(t, map[string]string{"TARGET_DISK": "/dev/sdb", "IMAGE_SHA256": "abc"}, tinkerbell.WorkflowOutputs(workflow))
+}
+
+func TestOutputsStatusDetail(t *testing.T) {
+	assert.Empty(t, tinkerbell.OutputsStatusDetail(nil))
+	assert.Equal(t, "disk /dev/sda, image sha256 abc", tinkerbell.OutputsStatusDetail(map[string]string{
+		tinkerbell.OutputTargetDisk:  "/dev/sda",
+		tinkerbell.OutputImageSHA256: "abc",
+		"UNRELATED":                  "ignored",
+	}))
+}
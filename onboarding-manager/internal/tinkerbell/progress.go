@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package tinkerbell
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+)
+
+// actionProgressPrefix prefixes the progress reported by tink-worker in the message of a running action.
+const actionProgressPrefix = "progress: "
+
+// ActionProgress is the byte-level progress published by a running action, as defined by the tinker actions
+// (tinker-actions/pkg/progress).
+type ActionProgress struct {
+	Stage          string `json:"stage"`
+	BytesWritten   int64  `json:"bytesWritten"`
+	TotalBytes     int64  `json:"totalBytes"`
+	Percent        int    `json:"percent"`
+	BytesPerSecond int64  `json:"bytesPerSecond"`
+}
+
+// ProgressFromAction returns the progress of a running action, false if it reported none.
+func ProgressFromAction(action tink.Action) (ActionProgress, bool) {
+	report, ok := messageLine(action.Message, actionProgressPrefix)
+	if action.Status != tink.WorkflowStateRunning || !ok {
+		return ActionProgress{}, false
+	}
+	progress := ActionProgress{Percent: -1}
+	if err := json.Unmarshal([]byte(report), &progress); err != nil {
+		zlog.Debug().Msgf("Invalid progress of action %s: %v", action.Name, err)
+		return ActionProgress{}, false
+	}
+	return progress, true
+}
+
+// String renders the progress, e.g. "writing OS image 62% (1.8 GB/2.9 GB, 48 MB/s)".
+func (p ActionProgress) String() string {
+	var sb strings.Builder
+	sb.WriteString(p.Stage)
+	if p.Percent >= 0 {
+		fmt.Fprintf(&sb, " %d%%", p.Percent)
+	}
+	sb.WriteString(" (")
+	sb.WriteString(formatBytes(p.BytesWritten))
+	if p.TotalBytes > 0 {
+		sb.WriteString("/" + formatBytes(p.TotalBytes))
+	}
+	if p.BytesPerSecond > 0 {
+		sb.WriteString(", " + formatBytes(p.BytesPerSecond) + "/s")
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
+// formatBytes renders a number of bytes with decimal units, with 2 significant digits below 10 units.
+func formatBytes(b int64) string {
+	const unit = 1000
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	value := float64(b)
+	for _, prefix := range []string{"kB", "MB", "GB", "TB"} {
+		value /= unit
+		// rounded up to the next unit otherwise
+		if value < unit-0.5 || prefix == "TB" {
+			if value < 10 {
+				return fmt.Sprintf("%.1f %s", value, prefix)
+			}
+			return fmt.Sprintf("%.0f %s", value, prefix)
+		}
+	}
+	return fmt.Sprintf("%d B", b)
+}
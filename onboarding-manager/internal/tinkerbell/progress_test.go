@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package tinkerbell_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
+)
+
+func TestProgressFromAction(t *testing.T) {
+	tests := []struct {
+		name   string
+		action tink.Action
+		want   tinkerbell.ActionProgress
+		wantOk bool
+	}{
+		{
+			"Progress",
+			tink.Action{Status: tink.WorkflowStateRunning, Message: `progress: {"stage":"writing OS image",` +
+				`"bytesWritten":1800000000,"totalBytes":2900000000,"percent":62,"bytesPerSecond":48000000}`},
+			tinkerbell.ActionProgress{
+				Stage: "writing OS image", BytesWritten: 1800000000, TotalBytes: 2900000000, Percent: 62,
+				BytesPerSecond: 48000000,
+			},
+			true,
+		},
+		{
+			"Unknown completion",
+			tink.Action{Status: tink.WorkflowStateRunning, Message: `progress: {"stage":"writing OS image","bytesWritten":10}`},
+			tinkerbell.ActionProgress{Stage: "writing OS image", BytesWritten: 10, Percent: -1},
+			true,
+		},
+		{
+			"Progress and heartbeat",
+			tink.Action{Status: tink.WorkflowStateRunning, Message: `progress: {"stage":"writing OS image","bytesWritten":10}` +
+				"\nattempt: 2/3\nheartbeat: {\"seq\":12,\"action\":\"stream-os-image\"}"},
+			tinkerbell.ActionProgress{Stage: "writing OS image", BytesWritten: 10, Percent: -1},
+			true,
+		},
+		{"Started", tink.Action{Status: tink.WorkflowStateRunning, Message: "Started execution"}, tinkerbell.ActionProgress{}, false},
+		{"Invalid", tink.Action{Status: tink.WorkflowStateRunning, Message: "progress: 62%"}, tinkerbell.ActionProgress{}, false},
+		{
+			"Not running",
+			tink.Action{Status: tink.WorkflowStateFailed, Message: `progress: {"stage":"writing OS image"}`},
+			tinkerbell.ActionProgress{},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tinkerbell.ProgressFromAction(tt.action)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestActionProgress_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		progress tinkerbell.ActionProgress
+		want     string
+	}{
+		{
+			"Known total",
+			tinkerbell.ActionProgress{
+				Stage: "writing OS image", BytesWritten: 1_800_000_000, TotalBytes: 2_900_000_000, Percent: 62,
+				BytesPerSecond: 48_000_000,
+			},
+			"writing OS image 62% (1.8 GB/2.9 GB, 48 MB/s)",
+		},
+		{
+			"Unknown total",
+			tinkerbell.ActionProgress{Stage: "writing OS image", BytesWritten: 12_345_678_901, Percent: 40, BytesPerSecond: 999},
+			"writing OS image 40% (12 GB, 999 B/s)",
+		},
+		{
+			"Unknown completion",
+			tinkerbell.ActionProgress{Stage: "downloading OS image", BytesWritten: 999_600, Percent: -1},
+			"downloading OS image (1.0 MB)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.progress.String())
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package tinkerbell
+
+import (
+	"encoding/json"
+	"strings"
+
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+)
+
+// actionReportPrefix prefixes the report written by an action, e.g. the disk sanitization report, in the message
+// of the finished action, successful or not.
+const actionReportPrefix = "report: "
+
+// ReportFromAction returns the JSON report written by a finished action, false if it wrote none.
+func ReportFromAction(action tink.Action) (json.RawMessage, bool) {
+	switch action.Status {
+	case tink.WorkflowStateSuccess, tink.WorkflowStateFailed, tink.WorkflowStateTimeout:
+	default:
+		return nil, false
+	}
+	report, ok := messageLine(action.Message, actionReportPrefix)
+	if !ok || !json.Valid([]byte(report)) {
+		return nil, false
+	}
+	return json.RawMessage(report), true
+}
+
+// messageLine returns the line of the message of an action that starts with prefix, without the prefix.
+// tink-worker reports the report and the outputs of a successful action on separate lines, and so are the progress,
+// the attempt and the heartbeat of a running action, and the report of a failed action.
+func messageLine(message, prefix string) (string, bool) {
+	for _, line := range strings.Split(message, "\n") {
+		if value, ok := strings.CutPrefix(line, prefix); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// failureMessage returns the failure message of a failed action, e.g. its exit status, without the report that
+// follows it.
+func failureMessage(action tink.Action) string {
+	message, _, _ := strings.Cut(action.Message, "\n")
+	return message
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package tinkerbell_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	tink "github.com/tinkerbell/tink/api/v1alpha1"
+
+	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/tinkerbell"
+)
+
+func TestReportFromAction(t *testing.T) {
+	tests := []struct {
+		name   string
+		action tink.Action
+		want   string
+		wantOk bool
+	}{
+		{
+			"Report",
+			tink.Action{Status: tink.WorkflowStateSuccess, Message: `report: {"disks":[{"device":"/dev/sda"}]}`},
+			`{"disks":[{"device":"/dev/sda"}]}`,
+			true,
+		},
+		{
+			"Report and outputs",
+			tink.Action{Status: tink.WorkflowStateSuccess, Message: "report: {\"disks\":[]}\noutputs: {\"TARGET_DISK\":\"/dev/sda\"}"},
+			`{"disks":[]}`,
+			true,
+		},
+		{"No report", tink.Action{Status: tink.WorkflowStateSuccess, Message: "finished execution successfully"}, "", false},
+		{"Invalid", tink.Action{Status: tink.WorkflowStateSuccess, Message: `report: {"disks":`}, "", false},
+		{
+			"Failed",
+			tink.Action{Status: tink.WorkflowStateFailed, Message: "exit status 91\nreport: {\"disks\":[]}"},
+			`{"disks":[]}`,
+			true,
+		},
+		{"Running", tink.Action{Status: tink.WorkflowStateRunning, Message: `report: {}`}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tinkerbell.ReportFromAction(tt.action)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
@@ -9,10 +9,15 @@ import (
 	tink "github.com/tinkerbell/tink/api/v1alpha1"
 )
 
+// actionAttemptPrefix prefixes the attempt of an action retried by tink-worker in the message of the action.
+const actionAttemptPrefix = "attempt: "
+
 // WorkflowStepToStatusDetail defines a configuration value.
 var WorkflowStepToStatusDetail = map[string]string{
 	ActionEraseNonRemovableDisk:    "Erasing data from all non-removable disks",
 	ActionSecureBootStatusFlagRead: "Verifying Secure Boot settings",
+	ActionHardwarePreflight:        "Checking hardware compliance",
+	ActionSanitizeDisks:            "Sanitizing disks",
 	ActionInstallScriptDownload:    "Downloading installation scripts",
 	ActionStreamOSImage:            "Streaming OS image",
 	ActionInstallScript:            "Installing packages",
@@ -86,15 +91,28 @@ func prepareStatusDetails(totalActions int, actions []tink.Action) string {
 
 		message = statusDetail
 
+		if progress, ok := ProgressFromAction(action); ok {
+			if progress.Stage == "" {
+				progress.Stage = statusDetail
+			}
+			message = progress.String()
+		}
+
+		// tink-worker reports the attempts of an action retried after a failure, e.g. "attempt: 2/3"
+		if attempt, ok := messageLine(action.Message, actionAttemptPrefix); ok && action.Status == tink.WorkflowStateRunning {
+			message += fmt.Sprintf(" (attempt %s)", attempt)
+		}
+
+		// the error code is reported in parentheses, for automation to parse it
 		if action.Status == tink.WorkflowStateFailed {
-			message = fmt.Sprintf("%s failed", statusDetail)
-			if action.Message != "" {
-				message += fmt.Sprintf(": %s", action.Message)
+			message = fmt.Sprintf("%s failed (%s)", statusDetail, ErrorCodeFromAction(action))
+			if failure := failureMessage(action); failure != "" {
+				message += fmt.Sprintf(": %s", failure)
 			}
 		}
 
 		if action.Status == tink.WorkflowStateTimeout {
-			message = fmt.Sprintf("%s timeout", statusDetail)
+			message = fmt.Sprintf("%s timeout (%s)", statusDetail, ErrorCodeFromAction(action))
 		}
 
 		currActionNumber = i + 1
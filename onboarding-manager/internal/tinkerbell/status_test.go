@@ -143,6 +143,48 @@ func getStaticWorkflowTests() []struct {
 			},
 			fmt.Sprintf("3/3: %s", tinkerbell.WorkflowStepToStatusDetail[tinkerbell.ActionReboot]),
 		},
+		{
+			"Running action with progress",
+			struct{ workflow *tink.Workflow }{
+				&tink.Workflow{Status: tink.WorkflowStatus{
+					Tasks: []tink.Task{{Actions: []tink.Action{
+						{Name: tinkerbell.ActionEraseNonRemovableDisk, Status: tink.WorkflowStateSuccess},
+						{Name: tinkerbell.ActionSecureBootStatusFlagRead, Status: tink.WorkflowStateSuccess},
+						{Name: tinkerbell.ActionStreamOSImage, Status: tink.WorkflowStateRunning, Message: "progress: " +
+							`{"stage":"writing OS image","bytesWritten":1800000000,"totalBytes":2900000000,"percent":62,` +
+							`"bytesPerSecond":48000000}`},
+						{Name: tinkerbell.ActionReboot, Status: tink.WorkflowStatePending},
+					}}},
+				}},
+			},
+			"3/4: writing OS image 62% (1.8 GB/2.9 GB, 48 MB/s)",
+		},
+		{
+			"Running action with progress of unknown stage",
+			struct{ workflow *tink.Workflow }{
+				&tink.Workflow{Status: tink.WorkflowStatus{
+					Tasks: []tink.Task{{Actions: []tink.Action{
+						{Name: tinkerbell.ActionStreamOSImage, Status: tink.WorkflowStateRunning,
+							Message: `progress: {"bytesWritten":1800000000,"percent":-1}`},
+						{Name: tinkerbell.ActionReboot, Status: tink.WorkflowStatePending},
+					}}},
+				}},
+			},
+			fmt.Sprintf("1/2: %s (1.8 GB)", tinkerbell.WorkflowStepToStatusDetail[tinkerbell.ActionStreamOSImage]),
+		},
+		{
+			"Running action retried after a failure",
+			struct{ workflow *tink.Workflow }{
+				&tink.Workflow{Status: tink.WorkflowStatus{
+					Tasks: []tink.Task{{Actions: []tink.Action{
+						{Name: tinkerbell.ActionFdeEncryption, Status: tink.WorkflowStateSuccess},
+						{Name: tinkerbell.ActionAddAptProxy, Status: tink.WorkflowStateRunning, Message: "attempt: 2/3"},
+						{Name: tinkerbell.ActionReboot, Status: tink.WorkflowStatePending},
+					}}},
+				}},
+			},
+			fmt.Sprintf("2/3: %s (attempt 2/3)", tinkerbell.WorkflowStepToStatusDetail[tinkerbell.ActionAddAptProxy]),
+		},
 		{
 			"Failed action with message",
 			struct{ workflow *tink.Workflow }{
@@ -153,7 +195,19 @@ func getStaticWorkflowTests() []struct {
 					}}},
 				}},
 			},
-			fmt.Sprintf("2/2: %s failed: some message", tinkerbell.WorkflowStepToStatusDetail[tinkerbell.ActionAddAptProxy]),
+			fmt.Sprintf("2/2: %s failed (UNKNOWN): some message", tinkerbell.WorkflowStepToStatusDetail[tinkerbell.ActionAddAptProxy]),
+		},
+		{
+			"Failed action with report",
+			struct{ workflow *tink.Workflow }{
+				&tink.Workflow{Status: tink.WorkflowStatus{
+					Tasks: []tink.Task{{Actions: []tink.Action{
+						{Name: tinkerbell.ActionSanitizeDisks, Status: tink.WorkflowStateFailed, Message: "exit status 91\nreport: {}"},
+					}}},
+				}},
+			},
+			fmt.Sprintf("1/1: %s failed (DISK_ERASE_FAILED): exit status 91",
+				tinkerbell.WorkflowStepToStatusDetail[tinkerbell.ActionSanitizeDisks]),
 		},
 		{
 			"Failed action without message",
@@ -165,7 +219,7 @@ func getStaticWorkflowTests() []struct {
 					}}},
 				}},
 			},
-			fmt.Sprintf("2/2: %s failed", tinkerbell.WorkflowStepToStatusDetail[tinkerbell.ActionAddAptProxy]),
+			fmt.Sprintf("2/2: %s failed (UNKNOWN)", tinkerbell.WorkflowStepToStatusDetail[tinkerbell.ActionAddAptProxy]),
 		},
 		{
 			"Timed out action",
@@ -177,7 +231,7 @@ func getStaticWorkflowTests() []struct {
 					}}},
 				}},
 			},
-			fmt.Sprintf("2/2: %s timeout", tinkerbell.WorkflowStepToStatusDetail[tinkerbell.ActionAddAptProxy]),
+			fmt.Sprintf("2/2: %s timeout (TIMEOUT)", tinkerbell.WorkflowStepToStatusDetail[tinkerbell.ActionAddAptProxy]),
 		},
 	}
 }
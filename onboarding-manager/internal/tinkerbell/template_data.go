@@ -30,6 +30,10 @@ const (
 	ActionEraseNonRemovableDisk = "erase-non-removable-disk" //#nosec G101 -- ignore false positive.
 	// ActionSecureBootStatusFlagRead defines a configuration value.
 	ActionSecureBootStatusFlagRead = "secure-boot-status-flag-read"
+	// ActionHardwarePreflight defines a configuration value.
+	ActionHardwarePreflight = "hardware-preflight"
+	// ActionSanitizeDisks defines a configuration value.
+	ActionSanitizeDisks = "sanitize-disks"
 	// ActionInstallScriptDownload defines a configuration value.
 	ActionInstallScriptDownload = "profile-pkg-and-node-agents-install-script-download"
 	// ActionStreamOSImage defines a configuration value.
@@ -68,6 +72,10 @@ const (
 
 	envTinkActionSecurebootFlagReadImage = "TINKER_SECUREBOOTFLAGREAD_IMAGE"
 
+	envTinkActionHardwarePreflightImage = "TINKER_HARDWARE_PREFLIGHT_IMAGE"
+
+	envTinkActionDiskSanitizeImage = "TINKER_DISK_SANITIZE_IMAGE"
+
 	envTinkActionWriteFileImage = "TINKER_WRITEFILE_IMAGE"
 
 	envTinkActionCexecImage = "TINKER_CEXEC_IMAGE"
@@ -94,6 +102,8 @@ const (
 	tinkerActionImage2Disk             = "image2disk"
 	tinkerActionWritefile              = "writefile"
 	tinkerActionSecurebootflag         = "securebootflag"
+	tinkerActionHardwarePreflight      = "hardware_preflight"
+	tinkerActionDiskSanitize           = "disk_sanitize"
 
 	// Use a delimiter that is highly unlikely to appear in any config or script.
 	// ASCII Unit Separator (0x1F) is a safe choice.
@@ -109,6 +119,8 @@ type TinkerActionImages struct {
 	EraseNonRemovableDisk string
 	WriteFile             string
 	SecureBootFlagRead    string
+	HardwarePreflight     string
+	DiskSanitize          string
 	Cexec                 string
 	Efibootset            string
 	KernelUpgrade         string
@@ -129,8 +141,12 @@ type WorkflowInputs struct {
 	DeviceInfo        onboarding_types.DeviceInfo
 	TinkerActionImage TinkerActionImages
 	CloudInitData     string
+	HardwareProfile   string
 	CustomConfigs     string
 	InstallerScript   string
+	// PackageArchitecture is the architecture of the host as named by container images and Debian packages,
+	// e.g. amd64 or arm64
+	PackageArchitecture string
 	// OsResourceID resource ID of Operating System that was specified initially at the provisioning time
 	OsResourceID string
 }
@@ -138,6 +154,8 @@ type WorkflowInputs struct {
 var (
 	defaultEraseNonRemovableDiskImage        = getTinkerActionImage(tinkerActionEraseNonRemovableDisks)
 	defaultTinkActionSecurebootFlagReadImage = getTinkerActionImage(tinkerActionSecurebootflag)
+	defaultTinkActionHardwarePreflightImage  = getTinkerActionImage(tinkerActionHardwarePreflight)
+	defaultTinkActionDiskSanitizeImage       = getTinkerActionImage(tinkerActionDiskSanitize)
 	defaultTinkActionWriteFileImage          = getTinkerActionImage(tinkerActionWritefile)
 	defaultTinkActionCexecImage              = getTinkerActionImage(tinkerActionCexec)
 	defaultTinkActionDiskImage               = getTinkerActionImage(tinkerActionImage2Disk)
@@ -237,6 +255,22 @@ func tinkActionSecurebootFlagReadImage(tinkerImageVersion string) string {
 	return fmt.Sprintf("%s:%s", defaultTinkActionSecurebootFlagReadImage, iv)
 }
 
+func tinkActionHardwarePreflightImage(tinkerImageVersion string) string {
+	iv := getTinkerImageVersion(tinkerImageVersion)
+	if v := os.Getenv(envTinkActionHardwarePreflightImage); v != "" {
+		return v
+	}
+	return fmt.Sprintf("%s:%s", defaultTinkActionHardwarePreflightImage, iv)
+}
+
+func tinkActionDiskSanitizeImage(tinkerImageVersion string) string {
+	iv := getTinkerImageVersion(tinkerImageVersion)
+	if v := os.Getenv(envTinkActionDiskSanitizeImage); v != "" {
+		return v
+	}
+	return fmt.Sprintf("%s:%s", defaultTinkActionDiskSanitizeImage, iv)
+}
+
 func tinkActionWriteFileImage(tinkerImageVersion string) string {
 	iv := getTinkerImageVersion(tinkerImageVersion)
 	if v := os.Getenv(envTinkActionWriteFileImage); v != "" {
@@ -387,19 +421,22 @@ func getStreamOSToDiskTinkerActionImage(ctx context.Context, imageURL, httpProxy
 func GenerateWorkflowInputs(ctx context.Context, deviceInfo onboarding_types.DeviceInfo) (map[string]string, error) {
 	infraConfig := config.GetInfraConfig()
 
+	arch := deviceInfo.Architecture
 	inputs := WorkflowInputs{
 		DeviceInfo: deviceInfo,
 		TinkerActionImage: TinkerActionImages{
-			EraseNonRemovableDisk: tinkActionEraseNonRemovableDisk(deviceInfo.TinkerVersion),
-			WriteFile:             tinkActionWriteFileImage(deviceInfo.TinkerVersion),
-			SecureBootFlagRead:    tinkActionSecurebootFlagReadImage(deviceInfo.TinkerVersion),
-			Cexec:                 tinkActionCexecImage(deviceInfo.TinkerVersion),
-			Efibootset:            tinkActionEfibootImage(deviceInfo.TinkerVersion),
-			KernelUpgrade:         tinkActionKernelupgradeImage(deviceInfo.TinkerVersion),
-			FdeDmv:                tinkActionFdeDmvImage(deviceInfo.TinkerVersion),
-			StreamOSImageToDisk: getStreamOSToDiskTinkerActionImage(ctx, deviceInfo.OSImageURL,
-				infraConfig.ENProxyHTTP, deviceInfo.TinkerVersion),
+			EraseNonRemovableDisk: actionImageForArch(tinkActionEraseNonRemovableDisk(deviceInfo.TinkerVersion), arch),
+			WriteFile:             actionImageForArch(tinkActionWriteFileImage(deviceInfo.TinkerVersion), arch),
+			SecureBootFlagRead:    actionImageForArch(tinkActionSecurebootFlagReadImage(deviceInfo.TinkerVersion), arch),
+			HardwarePreflight:     actionImageForArch(tinkActionHardwarePreflightImage(deviceInfo.TinkerVersion), arch),
+			Cexec:                 actionImageForArch(tinkActionCexecImage(deviceInfo.TinkerVersion), arch),
+			Efibootset:            actionImageForArch(tinkActionEfibootImage(deviceInfo.TinkerVersion), arch),
+			KernelUpgrade:         actionImageForArch(tinkActionKernelupgradeImage(deviceInfo.TinkerVersion), arch),
+			FdeDmv:                actionImageForArch(tinkActionFdeDmvImage(deviceInfo.TinkerVersion), arch),
+			StreamOSImageToDisk: actionImageForArch(getStreamOSToDiskTinkerActionImage(ctx, deviceInfo.OSImageURL,
+				infraConfig.ENProxyHTTP, deviceInfo.TinkerVersion), arch),
 		},
+		PackageArchitecture: PackageArchitecture(HostArchitecture(arch)),
 	}
 
 	opts := []cloudinit.Option{
@@ -446,6 +483,7 @@ func GenerateWorkflowInputs(ctx context.Context, deviceInfo onboarding_types.Dev
 
 	inputs.InstallerScript = strconv.Quote(installerScript)
 	inputs.CloudInitData = strconv.Quote(cloudInitData)
+	inputs.HardwareProfile = strconv.Quote(deviceInfo.HardwareProfile)
 	inputs.CustomConfigs = getCustomConfigs(deviceInfo)
 
 	inputs.Env = Env{
@@ -461,6 +499,18 @@ func GenerateWorkflowInputs(ctx context.Context, deviceInfo onboarding_types.Dev
 	return structToMapStringString(inputs), nil
 }
 
+// GenerateDecommissionWorkflowInputs returns the inputs of the decommission workflow, that sanitizes the disks of
+// a host before it is deleted.
+func GenerateDecommissionWorkflowInputs(deviceInfo onboarding_types.DeviceInfo) map[string]string {
+	return structToMapStringString(WorkflowInputs{
+		DeviceInfo: deviceInfo,
+		TinkerActionImage: TinkerActionImages{
+			DiskSanitize: actionImageForArch(tinkActionDiskSanitizeImage(deviceInfo.TinkerVersion), deviceInfo.Architecture),
+		},
+		PackageArchitecture: PackageArchitecture(HostArchitecture(deviceInfo.Architecture)),
+	})
+}
+
 func getCustomConfigs(deviceInfo onboarding_types.DeviceInfo) string {
 	concatenated := collections.ConcatMapValuesSorted(deviceInfo.CustomConfigs, customConfigDelimiter)
 	if concatenated != "" {
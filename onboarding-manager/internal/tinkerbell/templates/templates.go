@@ -26,10 +26,20 @@ var UbuntuTemplate []byte
 // UbuntuTemplateName defines a configuration value.
 var UbuntuTemplateName = "ubuntu"
 
+// DecommissionTemplate sanitizes the disks of a host before it is deleted.
+//
+//go:embed decommission.yaml
+var DecommissionTemplate []byte
+
+// DecommissionTemplateName defines a configuration value.
+var DecommissionTemplateName = "decommission"
+
 // TemplatesMap defines a configuration value.
 var TemplatesMap = map[string][]byte{
 	MicrovisorName:     MicrovisorTemplate,
 	UbuntuTemplateName: UbuntuTemplate,
+	// not selected by OS type, run when a host is deleted
+	DecommissionTemplateName: DecommissionTemplate,
 }
 
 // OSTypeToTemplateName defines a configuration value.
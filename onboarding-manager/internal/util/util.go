@@ -161,3 +161,25 @@ func IsStandalone(instance *computev1.InstanceResource) (bool, error) {
 
 	return isStandaloneMdValue == "true", nil
 }
+
+// MetadataValues returns the values of key in resource metadata, a JSON list of key/value pairs in which a key
+// may be repeated. It returns no values if the metadata is empty.
+func MetadataValues(metadata, key string) ([]string, error) {
+	if metadata == "" {
+		return nil, nil
+	}
+	var pairs []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(metadata), &pairs); err != nil {
+		return nil, inv_errors.Errorfc(codes.InvalidArgument, "Failed to parse metadata: %v", err)
+	}
+	var values []string
+	for _, pair := range pairs {
+		if pair.Key == key {
+			values = append(values, pair.Value)
+		}
+	}
+	return values, nil
+}
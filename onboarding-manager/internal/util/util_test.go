@@ -6,6 +6,11 @@ package util_test
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpc_status "google.golang.org/grpc/status"
+
 	computev1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/compute/v1"
 	inv_status "github.com/open-edge-platform/infra-core/inventory/v2/pkg/status"
 	"github.com/open-edge-platform/infra-onboarding/onboarding-manager/internal/util"
@@ -153,3 +158,22 @@ func TestPopulateInstanceTrustedAttestationStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestMetadataValues(t *testing.T) {
+	metadata := `[{"key":"maintenance-window","value":"0 22 * * * 2h"},{"key":"region","value":"eu"},` +
+		`{"key":"maintenance-window","value":"0 4 * * sat 4h"}]`
+	values, err := util.MetadataValues(metadata, "maintenance-window")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0 22 * * * 2h", "0 4 * * sat 4h"}, values)
+
+	values, err = util.MetadataValues(metadata, "site")
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	values, err = util.MetadataValues("", "region")
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	_, err = util.MetadataValues(`{"region":"eu"}`, "region")
+	assert.Equal(t, codes.InvalidArgument, grpc_status.Code(err))
+}
@@ -29,9 +29,10 @@ const (
 type OnboardNodeStreamResponse_NodeState int32
 
 const (
-	OnboardNodeStreamResponse_NODE_STATE_UNSPECIFIED OnboardNodeStreamResponse_NodeState = 0 // Edge Node state is unspecified or unknown
-	OnboardNodeStreamResponse_NODE_STATE_REGISTERED  OnboardNodeStreamResponse_NodeState = 1 // Allow to retry, Node is registered but not yet onboarded
-	OnboardNodeStreamResponse_NODE_STATE_ONBOARDED   OnboardNodeStreamResponse_NodeState = 2 // Edge Node successfully onboarded
+	OnboardNodeStreamResponse_NODE_STATE_UNSPECIFIED           OnboardNodeStreamResponse_NodeState = 0 // Edge Node state is unspecified or unknown
+	OnboardNodeStreamResponse_NODE_STATE_REGISTERED            OnboardNodeStreamResponse_NodeState = 1 // Allow to retry, Node is registered but not yet onboarded
+	OnboardNodeStreamResponse_NODE_STATE_ONBOARDED             OnboardNodeStreamResponse_NodeState = 2 // Edge Node successfully onboarded
+	OnboardNodeStreamResponse_NODE_STATE_ATTESTATION_CHALLENGE OnboardNodeStreamResponse_NodeState = 3 // Edge Node must answer the TPM credential activation challenge
 )
 
 // Enum value maps for OnboardNodeStreamResponse_NodeState.
@@ -40,11 +41,13 @@ var (
 		0: "NODE_STATE_UNSPECIFIED",
 		1: "NODE_STATE_REGISTERED",
 		2: "NODE_STATE_ONBOARDED",
+		3: "NODE_STATE_ATTESTATION_CHALLENGE",
 	}
 	OnboardNodeStreamResponse_NodeState_value = map[string]int32{
-		"NODE_STATE_UNSPECIFIED": 0,
-		"NODE_STATE_REGISTERED":  1,
-		"NODE_STATE_ONBOARDED":   2,
+		"NODE_STATE_UNSPECIFIED":           0,
+		"NODE_STATE_REGISTERED":            1,
+		"NODE_STATE_ONBOARDED":             2,
+		"NODE_STATE_ATTESTATION_CHALLENGE": 3,
 	}
 )
 
@@ -72,7 +75,7 @@ func (x OnboardNodeStreamResponse_NodeState) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OnboardNodeStreamResponse_NodeState.Descriptor instead.
 func (OnboardNodeStreamResponse_NodeState) EnumDescriptor() ([]byte, []int) {
-	return file_v1_onboarding_proto_rawDescGZIP(), []int{5, 0}
+	return file_v1_onboarding_proto_rawDescGZIP(), []int{14, 0}
 }
 
 type CreateNodesRequest struct {
@@ -177,6 +180,424 @@ func (x *CreateNodesResponse) GetProjectId() string {
 	return ""
 }
 
+type ReprovisionInstanceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The resource ID of the Instance to reprovision
+	ResourceId string `protobuf:"bytes,1,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+}
+
+func (x *ReprovisionInstanceRequest) Reset() {
+	*x = ReprovisionInstanceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_onboarding_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReprovisionInstanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReprovisionInstanceRequest) ProtoMessage() {}
+
+func (x *ReprovisionInstanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_onboarding_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReprovisionInstanceRequest.ProtoReflect.Descriptor instead.
+func (*ReprovisionInstanceRequest) Descriptor() ([]byte, []int) {
+	return file_v1_onboarding_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReprovisionInstanceRequest) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+type ReprovisionInstanceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResourceId string `protobuf:"bytes,1,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"` // The resource ID of the Instance being reprovisioned
+	Attempt    uint32 `protobuf:"varint,2,opt,name=attempt,proto3" json:"attempt,omitempty"`                        // The reprovisioning attempt, reported in the provisioning status of the Instance
+}
+
+func (x *ReprovisionInstanceResponse) Reset() {
+	*x = ReprovisionInstanceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_onboarding_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReprovisionInstanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReprovisionInstanceResponse) ProtoMessage() {}
+
+func (x *ReprovisionInstanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_onboarding_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReprovisionInstanceResponse.ProtoReflect.Descriptor instead.
+func (*ReprovisionInstanceResponse) Descriptor() ([]byte, []int) {
+	return file_v1_onboarding_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReprovisionInstanceResponse) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *ReprovisionInstanceResponse) GetAttempt() uint32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+type ConfirmFirstBootRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The UUID of the Edge Node
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// The boot ID of the installed OS, as reported by /proc/sys/kernel/random/boot_id
+	BootId string `protobuf:"bytes,2,opt,name=boot_id,json=bootId,proto3" json:"boot_id,omitempty"`
+	// The version of the running kernel, as reported by uname -r
+	KernelVersion string `protobuf:"bytes,3,opt,name=kernel_version,json=kernelVersion,proto3" json:"kernel_version,omitempty"`
+	// The readiness of the agents of the Edge Node, the first boot is only confirmed once all agents are ready
+	Agents []*AgentReadiness `protobuf:"bytes,4,rep,name=agents,proto3" json:"agents,omitempty"`
+}
+
+func (x *ConfirmFirstBootRequest) Reset() {
+	*x = ConfirmFirstBootRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_onboarding_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfirmFirstBootRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmFirstBootRequest) ProtoMessage() {}
+
+func (x *ConfirmFirstBootRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_onboarding_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmFirstBootRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmFirstBootRequest) Descriptor() ([]byte, []int) {
+	return file_v1_onboarding_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ConfirmFirstBootRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *ConfirmFirstBootRequest) GetBootId() string {
+	if x != nil {
+		return x.BootId
+	}
+	return ""
+}
+
+func (x *ConfirmFirstBootRequest) GetKernelVersion() string {
+	if x != nil {
+		return x.KernelVersion
+	}
+	return ""
+}
+
+func (x *ConfirmFirstBootRequest) GetAgents() []*AgentReadiness {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+type AgentReadiness struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`    // The name of the agent
+	Ready bool   `protobuf:"varint,2,opt,name=ready,proto3" json:"ready,omitempty"` // Whether the agent is ready
+}
+
+func (x *AgentReadiness) Reset() {
+	*x = AgentReadiness{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_onboarding_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AgentReadiness) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentReadiness) ProtoMessage() {}
+
+func (x *AgentReadiness) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_onboarding_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentReadiness.ProtoReflect.Descriptor instead.
+func (*AgentReadiness) Descriptor() ([]byte, []int) {
+	return file_v1_onboarding_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AgentReadiness) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AgentReadiness) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+type ConfirmFirstBootResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InstanceId    string   `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`          // The resource ID of the Instance of the Edge Node
+	Confirmed     bool     `protobuf:"varint,2,opt,name=confirmed,proto3" json:"confirmed,omitempty"`                             // Whether the first boot is confirmed, otherwise the Edge Node should call again later
+	PendingAgents []string `protobuf:"bytes,3,rep,name=pending_agents,json=pendingAgents,proto3" json:"pending_agents,omitempty"` // The agents the confirmation is waiting for
+}
+
+func (x *ConfirmFirstBootResponse) Reset() {
+	*x = ConfirmFirstBootResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_onboarding_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfirmFirstBootResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmFirstBootResponse) ProtoMessage() {}
+
+func (x *ConfirmFirstBootResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_onboarding_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmFirstBootResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmFirstBootResponse) Descriptor() ([]byte, []int) {
+	return file_v1_onboarding_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ConfirmFirstBootResponse) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *ConfirmFirstBootResponse) GetConfirmed() bool {
+	if x != nil {
+		return x.Confirmed
+	}
+	return false
+}
+
+func (x *ConfirmFirstBootResponse) GetPendingAgents() []string {
+	if x != nil {
+		return x.PendingAgents
+	}
+	return nil
+}
+
+type ReportActionMessageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The UUID of the Edge Node running the workflow
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// The ID of the workflow, as given to tink-worker by the Tinkerbell server, "<namespace>/<name>"
+	WorkflowId string `protobuf:"bytes,2,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	// The name of the task of the action
+	TaskName string `protobuf:"bytes,3,opt,name=task_name,json=taskName,proto3" json:"task_name,omitempty"`
+	// The name of the running action
+	ActionName string `protobuf:"bytes,4,opt,name=action_name,json=actionName,proto3" json:"action_name,omitempty"`
+	// The message of the action, it replaces the previous one
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ReportActionMessageRequest) Reset() {
+	*x = ReportActionMessageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_onboarding_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportActionMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportActionMessageRequest) ProtoMessage() {}
+
+func (x *ReportActionMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_onboarding_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportActionMessageRequest.ProtoReflect.Descriptor instead.
+func (*ReportActionMessageRequest) Descriptor() ([]byte, []int) {
+	return file_v1_onboarding_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReportActionMessageRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *ReportActionMessageRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *ReportActionMessageRequest) GetTaskName() string {
+	if x != nil {
+		return x.TaskName
+	}
+	return ""
+}
+
+func (x *ReportActionMessageRequest) GetActionName() string {
+	if x != nil {
+		return x.ActionName
+	}
+	return ""
+}
+
+func (x *ReportActionMessageRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ReportActionMessageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ReportActionMessageResponse) Reset() {
+	*x = ReportActionMessageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_onboarding_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportActionMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportActionMessageResponse) ProtoMessage() {}
+
+func (x *ReportActionMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_onboarding_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportActionMessageResponse.ProtoReflect.Descriptor instead.
+func (*ReportActionMessageResponse) Descriptor() ([]byte, []int) {
+	return file_v1_onboarding_proto_rawDescGZIP(), []int{8}
+}
+
 type NodeData struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -188,7 +609,7 @@ type NodeData struct {
 func (x *NodeData) Reset() {
 	*x = NodeData{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_onboarding_proto_msgTypes[2]
+		mi := &file_v1_onboarding_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -201,7 +622,7 @@ func (x *NodeData) String() string {
 func (*NodeData) ProtoMessage() {}
 
 func (x *NodeData) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_onboarding_proto_msgTypes[2]
+	mi := &file_v1_onboarding_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -214,7 +635,7 @@ func (x *NodeData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NodeData.ProtoReflect.Descriptor instead.
 func (*NodeData) Descriptor() ([]byte, []int) {
-	return file_v1_onboarding_proto_rawDescGZIP(), []int{2}
+	return file_v1_onboarding_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *NodeData) GetHwdata() []*HwData {
@@ -229,29 +650,343 @@ type HwData struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Uuid      string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	Serialnum string `protobuf:"bytes,2,opt,name=serialnum,proto3" json:"serialnum,omitempty"`
-	MacId     string `protobuf:"bytes,3,opt,name=mac_id,json=macId,proto3" json:"mac_id,omitempty"` // Mac ID of Edge Node
-	SutIp     string `protobuf:"bytes,4,opt,name=sut_ip,json=sutIp,proto3" json:"sut_ip,omitempty"` // sutip
+	Uuid      string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Serialnum string `protobuf:"bytes,2,opt,name=serialnum,proto3" json:"serialnum,omitempty"`
+	MacId     string `protobuf:"bytes,3,opt,name=mac_id,json=macId,proto3" json:"mac_id,omitempty"` // Mac ID of Edge Node
+	SutIp     string `protobuf:"bytes,4,opt,name=sut_ip,json=sutIp,proto3" json:"sut_ip,omitempty"` // sutip
+}
+
+func (x *HwData) Reset() {
+	*x = HwData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_onboarding_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HwData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HwData) ProtoMessage() {}
+
+func (x *HwData) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_onboarding_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HwData.ProtoReflect.Descriptor instead.
+func (*HwData) Descriptor() ([]byte, []int) {
+	return file_v1_onboarding_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *HwData) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *HwData) GetSerialnum() string {
+	if x != nil {
+		return x.Serialnum
+	}
+	return ""
+}
+
+func (x *HwData) GetMacId() string {
+	if x != nil {
+		return x.MacId
+	}
+	return ""
+}
+
+func (x *HwData) GetSutIp() string {
+	if x != nil {
+		return x.SutIp
+	}
+	return ""
+}
+
+// OnboardNodeStreamRequest represents a request sent from Edge Node to the Onboarding Manager
+type OnboardNodeStreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The UUID of the Edge Node being onboarded
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// The serial number of the Edge Node, validated against the host identity policy of its tenant
+	Serialnum string `protobuf:"bytes,2,opt,name=serialnum,proto3" json:"serialnum,omitempty"`
+	// The MAC ID of the Edge Node
+	MacId string `protobuf:"bytes,3,opt,name=mac_id,json=macId,proto3" json:"mac_id,omitempty"`
+	// The IP (IPv4 pattern) of the Edge Node
+	HostIp string `protobuf:"bytes,4,opt,name=host_ip,json=hostIp,proto3" json:"host_ip,omitempty"`
+	// The TPM evidence of the Edge Node, used for TPM-backed device identity
+	TpmAttestation *TpmAttestation `protobuf:"bytes,5,opt,name=tpm_attestation,json=tpmAttestation,proto3" json:"tpm_attestation,omitempty"`
+	// The Edge Node waits on the stream for the ONBOARDED response instead of re-sending requests
+	// while in the REGISTERED state
+	AwaitApproval bool `protobuf:"varint,6,opt,name=await_approval,json=awaitApproval,proto3" json:"await_approval,omitempty"`
+	// The CPU architecture of the Edge Node as reported by its kernel (uname -m), e.g. x86_64 or aarch64.
+	// Empty for Edge Nodes that predate multi-architecture support, which are x86_64.
+	Architecture string `protobuf:"bytes,7,opt,name=architecture,proto3" json:"architecture,omitempty"`
+}
+
+func (x *OnboardNodeStreamRequest) Reset() {
+	*x = OnboardNodeStreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_onboarding_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OnboardNodeStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnboardNodeStreamRequest) ProtoMessage() {}
+
+func (x *OnboardNodeStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_onboarding_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnboardNodeStreamRequest.ProtoReflect.Descriptor instead.
+func (*OnboardNodeStreamRequest) Descriptor() ([]byte, []int) {
+	return file_v1_onboarding_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *OnboardNodeStreamRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *OnboardNodeStreamRequest) GetSerialnum() string {
+	if x != nil {
+		return x.Serialnum
+	}
+	return ""
+}
+
+func (x *OnboardNodeStreamRequest) GetMacId() string {
+	if x != nil {
+		return x.MacId
+	}
+	return ""
+}
+
+func (x *OnboardNodeStreamRequest) GetHostIp() string {
+	if x != nil {
+		return x.HostIp
+	}
+	return ""
+}
+
+func (x *OnboardNodeStreamRequest) GetTpmAttestation() *TpmAttestation {
+	if x != nil {
+		return x.TpmAttestation
+	}
+	return nil
+}
+
+func (x *OnboardNodeStreamRequest) GetAwaitApproval() bool {
+	if x != nil {
+		return x.AwaitApproval
+	}
+	return false
+}
+
+func (x *OnboardNodeStreamRequest) GetArchitecture() string {
+	if x != nil {
+		return x.Architecture
+	}
+	return ""
+}
+
+// TpmAttestation carries the TPM endorsement key (EK) evidence of an Edge Node
+// and its answer to a credential activation challenge
+type TpmAttestation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EkCert          []byte `protobuf:"bytes,1,opt,name=ek_cert,json=ekCert,proto3" json:"ek_cert,omitempty"`                            // DER encoded EK certificate
+	EkPub           []byte `protobuf:"bytes,2,opt,name=ek_pub,json=ekPub,proto3" json:"ek_pub,omitempty"`                               // DER (PKIX) encoded EK public key, used when no EK certificate is available
+	AkName          []byte `protobuf:"bytes,3,opt,name=ak_name,json=akName,proto3" json:"ak_name,omitempty"`                            // TPM name of the key the credential activation challenge is bound to
+	ActivatedSecret []byte `protobuf:"bytes,4,opt,name=activated_secret,json=activatedSecret,proto3" json:"activated_secret,omitempty"` // Secret recovered by TPM2_ActivateCredential, sent in reply to a TpmChallenge
+}
+
+func (x *TpmAttestation) Reset() {
+	*x = TpmAttestation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_onboarding_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TpmAttestation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TpmAttestation) ProtoMessage() {}
+
+func (x *TpmAttestation) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_onboarding_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TpmAttestation.ProtoReflect.Descriptor instead.
+func (*TpmAttestation) Descriptor() ([]byte, []int) {
+	return file_v1_onboarding_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TpmAttestation) GetEkCert() []byte {
+	if x != nil {
+		return x.EkCert
+	}
+	return nil
+}
+
+func (x *TpmAttestation) GetEkPub() []byte {
+	if x != nil {
+		return x.EkPub
+	}
+	return nil
+}
+
+func (x *TpmAttestation) GetAkName() []byte {
+	if x != nil {
+		return x.AkName
+	}
+	return nil
+}
+
+func (x *TpmAttestation) GetActivatedSecret() []byte {
+	if x != nil {
+		return x.ActivatedSecret
+	}
+	return nil
+}
+
+// TpmChallenge is a credential activation challenge that only the TPM holding the EK can answer
+type TpmChallenge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CredentialBlob  []byte `protobuf:"bytes,1,opt,name=credential_blob,json=credentialBlob,proto3" json:"credential_blob,omitempty"`    // TPM2B_ID_OBJECT buffer for TPM2_ActivateCredential
+	EncryptedSecret []byte `protobuf:"bytes,2,opt,name=encrypted_secret,json=encryptedSecret,proto3" json:"encrypted_secret,omitempty"` // TPM2B_ENCRYPTED_SECRET buffer for TPM2_ActivateCredential
+}
+
+func (x *TpmChallenge) Reset() {
+	*x = TpmChallenge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_onboarding_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TpmChallenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TpmChallenge) ProtoMessage() {}
+
+func (x *TpmChallenge) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_onboarding_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TpmChallenge.ProtoReflect.Descriptor instead.
+func (*TpmChallenge) Descriptor() ([]byte, []int) {
+	return file_v1_onboarding_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TpmChallenge) GetCredentialBlob() []byte {
+	if x != nil {
+		return x.CredentialBlob
+	}
+	return nil
+}
+
+func (x *TpmChallenge) GetEncryptedSecret() []byte {
+	if x != nil {
+		return x.EncryptedSecret
+	}
+	return nil
+}
+
+// OnboardNodeStreamResponse represents a response sent from the Onboarding Manager to a Edge Node
+// over the bidirectional stream
+type OnboardNodeStreamResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status       *status.Status                      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                                                                                   // The status of the onboarding request
+	NodeState    OnboardNodeStreamResponse_NodeState `protobuf:"varint,2,opt,name=node_state,json=nodeState,proto3,enum=onboardingmgr.v1.OnboardNodeStreamResponse_NodeState" json:"node_state,omitempty"` // The current state of the device as stored in Infra Inventory
+	ClientId     string                              `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`                                                               // The client_id provided to the node upon successful onboarding
+	ClientSecret string                              `protobuf:"bytes,4,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`                                                   // The client_secret provided to the node upon successful onboarding
+	ProjectId    string                              `protobuf:"bytes,5,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`                                                            // The project_id associated with the node, identifying the project to which the node belongs
+	TpmChallenge *TpmChallenge                       `protobuf:"bytes,6,opt,name=tpm_challenge,json=tpmChallenge,proto3" json:"tpm_challenge,omitempty"`                                                   // The TPM credential activation challenge, set in the ATTESTATION_CHALLENGE state
+	// Set on REGISTERED responses when the Onboarding Manager keeps the stream open and pushes the ONBOARDED
+	// response once the host is approved. The Edge Node must not re-send requests in that case
+	AwaitingApproval bool `protobuf:"varint,7,opt,name=awaiting_approval,json=awaitingApproval,proto3" json:"awaiting_approval,omitempty"`
+	// Interval of the REGISTERED keepalive responses sent while awaiting approval
+	KeepaliveIntervalSeconds uint32 `protobuf:"varint,8,opt,name=keepalive_interval_seconds,json=keepaliveIntervalSeconds,proto3" json:"keepalive_interval_seconds,omitempty"`
 }
 
-func (x *HwData) Reset() {
-	*x = HwData{}
+func (x *OnboardNodeStreamResponse) Reset() {
+	*x = OnboardNodeStreamResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_onboarding_proto_msgTypes[3]
+		mi := &file_v1_onboarding_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *HwData) String() string {
+func (x *OnboardNodeStreamResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HwData) ProtoMessage() {}
+func (*OnboardNodeStreamResponse) ProtoMessage() {}
 
-func (x *HwData) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_onboarding_proto_msgTypes[3]
+func (x *OnboardNodeStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_onboarding_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -262,72 +997,98 @@ func (x *HwData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HwData.ProtoReflect.Descriptor instead.
-func (*HwData) Descriptor() ([]byte, []int) {
-	return file_v1_onboarding_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use OnboardNodeStreamResponse.ProtoReflect.Descriptor instead.
+func (*OnboardNodeStreamResponse) Descriptor() ([]byte, []int) {
+	return file_v1_onboarding_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *HwData) GetUuid() string {
+func (x *OnboardNodeStreamResponse) GetStatus() *status.Status {
 	if x != nil {
-		return x.Uuid
+		return x.Status
 	}
-	return ""
+	return nil
 }
 
-func (x *HwData) GetSerialnum() string {
+func (x *OnboardNodeStreamResponse) GetNodeState() OnboardNodeStreamResponse_NodeState {
 	if x != nil {
-		return x.Serialnum
+		return x.NodeState
+	}
+	return OnboardNodeStreamResponse_NODE_STATE_UNSPECIFIED
+}
+
+func (x *OnboardNodeStreamResponse) GetClientId() string {
+	if x != nil {
+		return x.ClientId
 	}
 	return ""
 }
 
-func (x *HwData) GetMacId() string {
+func (x *OnboardNodeStreamResponse) GetClientSecret() string {
 	if x != nil {
-		return x.MacId
+		return x.ClientSecret
 	}
 	return ""
 }
 
-func (x *HwData) GetSutIp() string {
+func (x *OnboardNodeStreamResponse) GetProjectId() string {
 	if x != nil {
-		return x.SutIp
+		return x.ProjectId
 	}
 	return ""
 }
 
-// OnboardNodeStreamRequest represents a request sent from Edge Node to the Onboarding Manager
-type OnboardNodeStreamRequest struct {
+func (x *OnboardNodeStreamResponse) GetTpmChallenge() *TpmChallenge {
+	if x != nil {
+		return x.TpmChallenge
+	}
+	return nil
+}
+
+func (x *OnboardNodeStreamResponse) GetAwaitingApproval() bool {
+	if x != nil {
+		return x.AwaitingApproval
+	}
+	return false
+}
+
+func (x *OnboardNodeStreamResponse) GetKeepaliveIntervalSeconds() uint32 {
+	if x != nil {
+		return x.KeepaliveIntervalSeconds
+	}
+	return 0
+}
+
+// GetOnboardingStatusRequest identifies the Edge Node whose status is queried
+type GetOnboardingStatusRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The UUID of the Edge Node being onboarded
+	// The UUID of the Edge Node
 	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	// The serial number of the Edge Node
+	// The serial number of the Edge Node, validated against the host identity policy of its tenant
 	Serialnum string `protobuf:"bytes,2,opt,name=serialnum,proto3" json:"serialnum,omitempty"`
 	// The MAC ID of the Edge Node
 	MacId string `protobuf:"bytes,3,opt,name=mac_id,json=macId,proto3" json:"mac_id,omitempty"`
-	// The IP (IPv4 pattern) of the Edge Node
-	HostIp string `protobuf:"bytes,4,opt,name=host_ip,json=hostIp,proto3" json:"host_ip,omitempty"`
 }
 
-func (x *OnboardNodeStreamRequest) Reset() {
-	*x = OnboardNodeStreamRequest{}
+func (x *GetOnboardingStatusRequest) Reset() {
+	*x = GetOnboardingStatusRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_onboarding_proto_msgTypes[4]
+		mi := &file_v1_onboarding_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *OnboardNodeStreamRequest) String() string {
+func (x *GetOnboardingStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OnboardNodeStreamRequest) ProtoMessage() {}
+func (*GetOnboardingStatusRequest) ProtoMessage() {}
 
-func (x *OnboardNodeStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_onboarding_proto_msgTypes[4]
+func (x *GetOnboardingStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_onboarding_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -338,70 +1099,67 @@ func (x *OnboardNodeStreamRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OnboardNodeStreamRequest.ProtoReflect.Descriptor instead.
-func (*OnboardNodeStreamRequest) Descriptor() ([]byte, []int) {
-	return file_v1_onboarding_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetOnboardingStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetOnboardingStatusRequest) Descriptor() ([]byte, []int) {
+	return file_v1_onboarding_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *OnboardNodeStreamRequest) GetUuid() string {
+func (x *GetOnboardingStatusRequest) GetUuid() string {
 	if x != nil {
 		return x.Uuid
 	}
 	return ""
 }
 
-func (x *OnboardNodeStreamRequest) GetSerialnum() string {
+func (x *GetOnboardingStatusRequest) GetSerialnum() string {
 	if x != nil {
 		return x.Serialnum
 	}
 	return ""
 }
 
-func (x *OnboardNodeStreamRequest) GetMacId() string {
+func (x *GetOnboardingStatusRequest) GetMacId() string {
 	if x != nil {
 		return x.MacId
 	}
 	return ""
 }
 
-func (x *OnboardNodeStreamRequest) GetHostIp() string {
-	if x != nil {
-		return x.HostIp
-	}
-	return ""
-}
-
-// OnboardNodeStreamResponse represents a response sent from the Onboarding Manager to a Edge Node
-// over the bidirectional stream
-type OnboardNodeStreamResponse struct {
+// GetOnboardingStatusResponse holds the onboarding and provisioning status of the Edge Node
+type GetOnboardingStatusResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status       *status.Status                      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                                                                                   // The status of the onboarding request
-	NodeState    OnboardNodeStreamResponse_NodeState `protobuf:"varint,2,opt,name=node_state,json=nodeState,proto3,enum=onboardingmgr.v1.OnboardNodeStreamResponse_NodeState" json:"node_state,omitempty"` // The current state of the device as stored in Infra Inventory
-	ClientId     string                              `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`                                                               // The client_id provided to the node upon successful onboarding
-	ClientSecret string                              `protobuf:"bytes,4,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`                                                   // The client_secret provided to the node upon successful onboarding
-	ProjectId    string                              `protobuf:"bytes,5,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`                                                            // The project_id associated with the node, identifying the project to which the node belongs
+	CurrentState string `protobuf:"bytes,1,opt,name=current_state,json=currentState,proto3" json:"current_state,omitempty"` // The current state of the host, e.g. HOST_STATE_REGISTERED
+	DesiredState string `protobuf:"bytes,2,opt,name=desired_state,json=desiredState,proto3" json:"desired_state,omitempty"` // The desired state of the host
+	// Set when the host is registered and awaits approval for onboarding
+	AwaitingApproval   bool   `protobuf:"varint,3,opt,name=awaiting_approval,json=awaitingApproval,proto3" json:"awaiting_approval,omitempty"`
+	RegistrationStatus string `protobuf:"bytes,4,opt,name=registration_status,json=registrationStatus,proto3" json:"registration_status,omitempty"` // The registration status of the host
+	OnboardingStatus   string `protobuf:"bytes,5,opt,name=onboarding_status,json=onboardingStatus,proto3" json:"onboarding_status,omitempty"`       // The onboarding status of the host
+	ProvisioningStatus string `protobuf:"bytes,6,opt,name=provisioning_status,json=provisioningStatus,proto3" json:"provisioning_status,omitempty"` // The provisioning status of the instance of the host, if any
+	// The current step of the provisioning workflow, e.g. "3/12: Streaming OS image"
+	CurrentAction string `protobuf:"bytes,7,opt,name=current_action,json=currentAction,proto3" json:"current_action,omitempty"`
+	LastError     string `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"` // The last error reported for the host or its instance
 }
 
-func (x *OnboardNodeStreamResponse) Reset() {
-	*x = OnboardNodeStreamResponse{}
+func (x *GetOnboardingStatusResponse) Reset() {
+	*x = GetOnboardingStatusResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_v1_onboarding_proto_msgTypes[5]
+		mi := &file_v1_onboarding_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *OnboardNodeStreamResponse) String() string {
+func (x *GetOnboardingStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OnboardNodeStreamResponse) ProtoMessage() {}
+func (*GetOnboardingStatusResponse) ProtoMessage() {}
 
-func (x *OnboardNodeStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_v1_onboarding_proto_msgTypes[5]
+func (x *GetOnboardingStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_onboarding_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -412,42 +1170,63 @@ func (x *OnboardNodeStreamResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OnboardNodeStreamResponse.ProtoReflect.Descriptor instead.
-func (*OnboardNodeStreamResponse) Descriptor() ([]byte, []int) {
-	return file_v1_onboarding_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use GetOnboardingStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetOnboardingStatusResponse) Descriptor() ([]byte, []int) {
+	return file_v1_onboarding_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *OnboardNodeStreamResponse) GetStatus() *status.Status {
+func (x *GetOnboardingStatusResponse) GetCurrentState() string {
 	if x != nil {
-		return x.Status
+		return x.CurrentState
 	}
-	return nil
+	return ""
 }
 
-func (x *OnboardNodeStreamResponse) GetNodeState() OnboardNodeStreamResponse_NodeState {
+func (x *GetOnboardingStatusResponse) GetDesiredState() string {
 	if x != nil {
-		return x.NodeState
+		return x.DesiredState
 	}
-	return OnboardNodeStreamResponse_NODE_STATE_UNSPECIFIED
+	return ""
 }
 
-func (x *OnboardNodeStreamResponse) GetClientId() string {
+func (x *GetOnboardingStatusResponse) GetAwaitingApproval() bool {
 	if x != nil {
-		return x.ClientId
+		return x.AwaitingApproval
+	}
+	return false
+}
+
+func (x *GetOnboardingStatusResponse) GetRegistrationStatus() string {
+	if x != nil {
+		return x.RegistrationStatus
 	}
 	return ""
 }
 
-func (x *OnboardNodeStreamResponse) GetClientSecret() string {
+func (x *GetOnboardingStatusResponse) GetOnboardingStatus() string {
 	if x != nil {
-		return x.ClientSecret
+		return x.OnboardingStatus
 	}
 	return ""
 }
 
-func (x *OnboardNodeStreamResponse) GetProjectId() string {
+func (x *GetOnboardingStatusResponse) GetProvisioningStatus() string {
 	if x != nil {
-		return x.ProjectId
+		return x.ProvisioningStatus
+	}
+	return ""
+}
+
+func (x *GetOnboardingStatusResponse) GetCurrentAction() string {
+	if x != nil {
+		return x.CurrentAction
+	}
+	return ""
+}
+
+func (x *GetOnboardingStatusResponse) GetLastError() string {
+	if x != nil {
+		return x.LastError
 	}
 	return ""
 }
@@ -472,94 +1251,249 @@ var file_v1_onboarding_proto_rawDesc = []byte{
 	0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f,
 	0x61, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49,
-	0x64, 0x22, 0x3c, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x30, 0x0a,
-	0x06, 0x68, 0x77, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e,
-	0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x6d, 0x67, 0x72, 0x2e, 0x76, 0x31,
-	0x2e, 0x48, 0x77, 0x44, 0x61, 0x74, 0x61, 0x52, 0x06, 0x68, 0x77, 0x64, 0x61, 0x74, 0x61, 0x22,
-	0xa4, 0x02, 0x0a, 0x06, 0x48, 0x77, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0,
-	0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x69,
-	0x61, 0x6c, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x1a, 0xfa, 0x42, 0x17,
-	0x72, 0x15, 0x32, 0x13, 0x5e, 0x5b, 0x41, 0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x5d,
-	0x7b, 0x35, 0x2c, 0x32, 0x30, 0x7d, 0x24, 0x52, 0x09, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x6e,
-	0x75, 0x6d, 0x12, 0x47, 0x0a, 0x06, 0x6d, 0x61, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x42, 0x30, 0xfa, 0x42, 0x2d, 0x72, 0x2b, 0x32, 0x29, 0x5e, 0x28, 0x5b, 0x30, 0x2d,
-	0x39, 0x61, 0x2d, 0x66, 0x41, 0x2d, 0x46, 0x5d, 0x7b, 0x32, 0x7d, 0x28, 0x5b, 0x2d, 0x3a, 0x5d,
-	0x29, 0x29, 0x7b, 0x35, 0x7d, 0x5b, 0x30, 0x2d, 0x39, 0x61, 0x2d, 0x66, 0x41, 0x2d, 0x46, 0x5d,
-	0x7b, 0x32, 0x7d, 0x24, 0x52, 0x05, 0x6d, 0x61, 0x63, 0x49, 0x64, 0x12, 0x79, 0x0a, 0x06, 0x73,
-	0x75, 0x74, 0x5f, 0x69, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x62, 0xfa, 0x42, 0x5f,
-	0x72, 0x5d, 0x32, 0x5b, 0x5e, 0x28, 0x3f, 0x3a, 0x28, 0x3f, 0x3a, 0x32, 0x35, 0x5b, 0x30, 0x2d,
-	0x35, 0x5d, 0x7c, 0x32, 0x5b, 0x30, 0x2d, 0x34, 0x5d, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x7c, 0x5b,
-	0x30, 0x31, 0x5d, 0x3f, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x3f, 0x29,
-	0x5c, 0x2e, 0x29, 0x7b, 0x33, 0x7d, 0x28, 0x3f, 0x3a, 0x32, 0x35, 0x5b, 0x30, 0x2d, 0x35, 0x5d,
+	0x64, 0x22, 0x58, 0x0a, 0x1a, 0x52, 0x65, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x19, 0xfa, 0x42, 0x16, 0x72, 0x14, 0x32, 0x12, 0x5e, 0x69, 0x6e,
+	0x73, 0x74, 0x2d, 0x5b, 0x30, 0x2d, 0x39, 0x61, 0x2d, 0x66, 0x5d, 0x7b, 0x38, 0x7d, 0x24, 0x52,
+	0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x22, 0x58, 0x0a, 0x1b, 0x52,
+	0x65, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x22, 0xd1, 0x01, 0x0a, 0x17, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72,
+	0x6d, 0x46, 0x69, 0x72, 0x73, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12,
+	0x21, 0x0a, 0x07, 0x62, 0x6f, 0x6f, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x06, 0x62, 0x6f, 0x6f, 0x74,
+	0x49, 0x64, 0x12, 0x31, 0x0a, 0x0e, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0xfa, 0x42, 0x07, 0x72,
+	0x05, 0x10, 0x01, 0x18, 0x80, 0x01, 0x52, 0x0d, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x42, 0x0a, 0x06, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69,
+	0x6e, 0x67, 0x6d, 0x67, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x61, 0x64, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x92, 0x01, 0x02, 0x10,
+	0x40, 0x52, 0x06, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x45, 0x0a, 0x0e, 0x41, 0x67, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x1d, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x09, 0xfa, 0x42, 0x06, 0x72, 0x04,
+	0x10, 0x01, 0x18, 0x40, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65,
+	0x61, 0x64, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79,
+	0x22, 0x80, 0x01, 0x0a, 0x18, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x46, 0x69, 0x72, 0x73,
+	0x74, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e,
+	0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x41, 0x67, 0x65,
+	0x6e, 0x74, 0x73, 0x22, 0xe2, 0x01, 0x0a, 0x1a, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64,
+	0x12, 0x2b, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0xfa, 0x42, 0x07, 0x72, 0x05, 0x10, 0x01, 0x18, 0xbd,
+	0x02, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x49, 0x64, 0x12, 0x27, 0x0a,
+	0x09, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x0a, 0xfa, 0x42, 0x07, 0x72, 0x05, 0x10, 0x01, 0x18, 0xc8, 0x01, 0x52, 0x08, 0x74, 0x61,
+	0x73, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0a, 0xfa, 0x42, 0x07,
+	0x72, 0x05, 0x10, 0x01, 0x18, 0xc8, 0x01, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x09, 0xfa, 0x42, 0x06, 0x72, 0x04, 0x28, 0x80, 0x80, 0x02, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x1d, 0x0a, 0x1b, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3c, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x30, 0x0a, 0x06, 0x68, 0x77, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x6d, 0x67, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x77, 0x44, 0x61, 0x74, 0x61, 0x52, 0x06, 0x68,
+	0x77, 0x64, 0x61, 0x74, 0x61, 0x22, 0xa4, 0x02, 0x0a, 0x06, 0x48, 0x77, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08,
+	0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x38,
+	0x0a, 0x09, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x1a, 0xfa, 0x42, 0x17, 0x72, 0x15, 0x32, 0x13, 0x5e, 0x5b, 0x41, 0x2d, 0x5a, 0x61,
+	0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x5d, 0x7b, 0x35, 0x2c, 0x32, 0x30, 0x7d, 0x24, 0x52, 0x09, 0x73,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x6e, 0x75, 0x6d, 0x12, 0x47, 0x0a, 0x06, 0x6d, 0x61, 0x63, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x30, 0xfa, 0x42, 0x2d, 0x72, 0x2b, 0x32,
+	0x29, 0x5e, 0x28, 0x5b, 0x30, 0x2d, 0x39, 0x61, 0x2d, 0x66, 0x41, 0x2d, 0x46, 0x5d, 0x7b, 0x32,
+	0x7d, 0x28, 0x5b, 0x2d, 0x3a, 0x5d, 0x29, 0x29, 0x7b, 0x35, 0x7d, 0x5b, 0x30, 0x2d, 0x39, 0x61,
+	0x2d, 0x66, 0x41, 0x2d, 0x46, 0x5d, 0x7b, 0x32, 0x7d, 0x24, 0x52, 0x05, 0x6d, 0x61, 0x63, 0x49,
+	0x64, 0x12, 0x79, 0x0a, 0x06, 0x73, 0x75, 0x74, 0x5f, 0x69, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x62, 0xfa, 0x42, 0x5f, 0x72, 0x5d, 0x32, 0x5b, 0x5e, 0x28, 0x3f, 0x3a, 0x28, 0x3f,
+	0x3a, 0x32, 0x35, 0x5b, 0x30, 0x2d, 0x35, 0x5d, 0x7c, 0x32, 0x5b, 0x30, 0x2d, 0x34, 0x5d, 0x5b,
+	0x30, 0x2d, 0x39, 0x5d, 0x7c, 0x5b, 0x30, 0x31, 0x5d, 0x3f, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x5b,
+	0x30, 0x2d, 0x39, 0x5d, 0x3f, 0x29, 0x5c, 0x2e, 0x29, 0x7b, 0x33, 0x7d, 0x28, 0x3f, 0x3a, 0x32,
+	0x35, 0x5b, 0x30, 0x2d, 0x35, 0x5d, 0x7c, 0x32, 0x5b, 0x30, 0x2d, 0x34, 0x5d, 0x5b, 0x30, 0x2d,
+	0x39, 0x5d, 0x7c, 0x5b, 0x30, 0x31, 0x5d, 0x3f, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x5b, 0x30, 0x2d,
+	0x39, 0x5d, 0x3f, 0x29, 0x24, 0x52, 0x05, 0x73, 0x75, 0x74, 0x49, 0x70, 0x22, 0xd3, 0x03, 0x0a,
+	0x18, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01,
+	0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x26, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x69, 0x61,
+	0x6c, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72,
+	0x03, 0x18, 0x80, 0x01, 0x52, 0x09, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x6e, 0x75, 0x6d, 0x12,
+	0x47, 0x0a, 0x06, 0x6d, 0x61, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x30, 0xfa, 0x42, 0x2d, 0x72, 0x2b, 0x32, 0x29, 0x5e, 0x28, 0x5b, 0x30, 0x2d, 0x39, 0x61, 0x2d,
+	0x66, 0x41, 0x2d, 0x46, 0x5d, 0x7b, 0x32, 0x7d, 0x28, 0x5b, 0x2d, 0x3a, 0x5d, 0x29, 0x29, 0x7b,
+	0x35, 0x7d, 0x5b, 0x30, 0x2d, 0x39, 0x61, 0x2d, 0x66, 0x41, 0x2d, 0x46, 0x5d, 0x7b, 0x32, 0x7d,
+	0x24, 0x52, 0x05, 0x6d, 0x61, 0x63, 0x49, 0x64, 0x12, 0x7b, 0x0a, 0x07, 0x68, 0x6f, 0x73, 0x74,
+	0x5f, 0x69, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x62, 0xfa, 0x42, 0x5f, 0x72, 0x5d,
+	0x32, 0x5b, 0x5e, 0x28, 0x3f, 0x3a, 0x28, 0x3f, 0x3a, 0x32, 0x35, 0x5b, 0x30, 0x2d, 0x35, 0x5d,
 	0x7c, 0x32, 0x5b, 0x30, 0x2d, 0x34, 0x5d, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x7c, 0x5b, 0x30, 0x31,
-	0x5d, 0x3f, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x3f, 0x29, 0x24, 0x52,
-	0x05, 0x73, 0x75, 0x74, 0x49, 0x70, 0x22, 0xb8, 0x02, 0x0a, 0x18, 0x4f, 0x6e, 0x62, 0x6f, 0x61,
-	0x72, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69,
-	0x64, 0x12, 0x38, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x6e, 0x75, 0x6d, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x42, 0x1a, 0xfa, 0x42, 0x17, 0x72, 0x15, 0x32, 0x13, 0x5e, 0x5b, 0x41,
-	0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x5d, 0x7b, 0x35, 0x2c, 0x32, 0x30, 0x7d, 0x24,
-	0x52, 0x09, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x6e, 0x75, 0x6d, 0x12, 0x47, 0x0a, 0x06, 0x6d,
-	0x61, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x30, 0xfa, 0x42, 0x2d,
-	0x72, 0x2b, 0x32, 0x29, 0x5e, 0x28, 0x5b, 0x30, 0x2d, 0x39, 0x61, 0x2d, 0x66, 0x41, 0x2d, 0x46,
-	0x5d, 0x7b, 0x32, 0x7d, 0x28, 0x5b, 0x2d, 0x3a, 0x5d, 0x29, 0x29, 0x7b, 0x35, 0x7d, 0x5b, 0x30,
-	0x2d, 0x39, 0x61, 0x2d, 0x66, 0x41, 0x2d, 0x46, 0x5d, 0x7b, 0x32, 0x7d, 0x24, 0x52, 0x05, 0x6d,
-	0x61, 0x63, 0x49, 0x64, 0x12, 0x7b, 0x0a, 0x07, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x70, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x62, 0xfa, 0x42, 0x5f, 0x72, 0x5d, 0x32, 0x5b, 0x5e, 0x28,
-	0x3f, 0x3a, 0x28, 0x3f, 0x3a, 0x32, 0x35, 0x5b, 0x30, 0x2d, 0x35, 0x5d, 0x7c, 0x32, 0x5b, 0x30,
-	0x2d, 0x34, 0x5d, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x7c, 0x5b, 0x30, 0x31, 0x5d, 0x3f, 0x5b, 0x30,
-	0x2d, 0x39, 0x5d, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x3f, 0x29, 0x5c, 0x2e, 0x29, 0x7b, 0x33, 0x7d,
-	0x28, 0x3f, 0x3a, 0x32, 0x35, 0x5b, 0x30, 0x2d, 0x35, 0x5d, 0x7c, 0x32, 0x5b, 0x30, 0x2d, 0x34,
-	0x5d, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x7c, 0x5b, 0x30, 0x31, 0x5d, 0x3f, 0x5b, 0x30, 0x2d, 0x39,
-	0x5d, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x3f, 0x29, 0x24, 0x52, 0x06, 0x68, 0x6f, 0x73, 0x74, 0x49,
-	0x70, 0x22, 0xdc, 0x02, 0x0a, 0x19, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4e, 0x6f, 0x64,
-	0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x2a, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x12, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x54, 0x0a, 0x0a, 0x6e,
-	0x6f, 0x64, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x35, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x6d, 0x67, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4e, 0x6f, 0x64,
-	0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74,
-	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x23,
-	0x0a, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x63,
-	0x72, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69,
-	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
-	0x49, 0x64, 0x22, 0x5c, 0x0a, 0x09, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
-	0x1a, 0x0a, 0x16, 0x4e, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e,
-	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x4e,
-	0x4f, 0x44, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54,
-	0x45, 0x52, 0x45, 0x44, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x4e, 0x4f, 0x44, 0x45, 0x5f, 0x53,
-	0x54, 0x41, 0x54, 0x45, 0x5f, 0x4f, 0x4e, 0x42, 0x4f, 0x41, 0x52, 0x44, 0x45, 0x44, 0x10, 0x02,
-	0x32, 0x7c, 0x0a, 0x1c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x4f,
-	0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x5c, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12,
-	0x24, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x6d, 0x67, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69,
-	0x6e, 0x67, 0x6d, 0x67, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e,
-	0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x95,
-	0x01, 0x0a, 0x1f, 0x4e, 0x6f, 0x6e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76,
-	0x65, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x72, 0x0a, 0x11, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4e, 0x6f, 0x64,
-	0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x2a, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72,
-	0x64, 0x69, 0x6e, 0x67, 0x6d, 0x67, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x6e, 0x62, 0x6f, 0x61,
-	0x72, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67,
-	0x6d, 0x67, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4e, 0x6f,
-	0x64, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x42, 0x60, 0x5a, 0x5e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x6c, 0x2f, 0x69, 0x6e, 0x66, 0x72, 0x61,
-	0x2d, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x6f, 0x6e, 0x62, 0x6f,
-	0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2d, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69,
-	0x6e, 0x67, 0x6d, 0x67, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64,
-	0x69, 0x6e, 0x67, 0x6d, 0x67, 0x72, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x5d, 0x3f, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x3f, 0x29, 0x5c, 0x2e,
+	0x29, 0x7b, 0x33, 0x7d, 0x28, 0x3f, 0x3a, 0x32, 0x35, 0x5b, 0x30, 0x2d, 0x35, 0x5d, 0x7c, 0x32,
+	0x5b, 0x30, 0x2d, 0x34, 0x5d, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x7c, 0x5b, 0x30, 0x31, 0x5d, 0x3f,
+	0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x3f, 0x29, 0x24, 0x52, 0x06, 0x68,
+	0x6f, 0x73, 0x74, 0x49, 0x70, 0x12, 0x49, 0x0a, 0x0f, 0x74, 0x70, 0x6d, 0x5f, 0x61, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x6d, 0x67, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x70, 0x6d, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0e, 0x74, 0x70, 0x6d, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x25, 0x0a, 0x0e, 0x61, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76,
+	0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x61, 0x77, 0x61, 0x69, 0x74, 0x41,
+	0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x12, 0x39, 0x0a, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69,
+	0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x42, 0x15, 0xfa,
+	0x42, 0x12, 0x72, 0x10, 0x18, 0x20, 0x32, 0x0c, 0x5e, 0x5b, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39,
+	0x5f, 0x5d, 0x2a, 0x24, 0x52, 0x0c, 0x61, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75,
+	0x72, 0x65, 0x22, 0x84, 0x01, 0x0a, 0x0e, 0x54, 0x70, 0x6d, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x65, 0x6b, 0x5f, 0x63, 0x65, 0x72, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x65, 0x6b, 0x43, 0x65, 0x72, 0x74, 0x12, 0x15,
+	0x0a, 0x06, 0x65, 0x6b, 0x5f, 0x70, 0x75, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x65, 0x6b, 0x50, 0x75, 0x62, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x6b, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x61, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x29,
+	0x0a, 0x10, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61,
+	0x74, 0x65, 0x64, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0x62, 0x0a, 0x0c, 0x54, 0x70, 0x6d,
+	0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x72, 0x65,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x62, 0x6c, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x6c,
+	0x6f, 0x62, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x5f,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x65, 0x6e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0xb3, 0x04,
+	0x0a, 0x19, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x54, 0x0a, 0x0a, 0x6e, 0x6f, 0x64, 0x65, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x35, 0x2e, 0x6f, 0x6e,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x6d, 0x67, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4f,
+	0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x43,
+	0x0a, 0x0d, 0x74, 0x70, 0x6d, 0x5f, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69,
+	0x6e, 0x67, 0x6d, 0x67, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x70, 0x6d, 0x43, 0x68, 0x61, 0x6c,
+	0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x0c, 0x74, 0x70, 0x6d, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65,
+	0x6e, 0x67, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x77, 0x61, 0x69, 0x74, 0x69, 0x6e, 0x67, 0x5f,
+	0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10,
+	0x61, 0x77, 0x61, 0x69, 0x74, 0x69, 0x6e, 0x67, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c,
+	0x12, 0x3c, 0x0a, 0x1a, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x18, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x82,
+	0x01, 0x0a, 0x09, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x16,
+	0x4e, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x4e, 0x4f, 0x44, 0x45,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x45,
+	0x44, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x4e, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x45, 0x5f, 0x4f, 0x4e, 0x42, 0x4f, 0x41, 0x52, 0x44, 0x45, 0x44, 0x10, 0x02, 0x12, 0x24, 0x0a,
+	0x20, 0x4e, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x41, 0x54, 0x54, 0x45,
+	0x53, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x48, 0x41, 0x4c, 0x4c, 0x45, 0x4e, 0x47,
+	0x45, 0x10, 0x03, 0x22, 0xab, 0x01, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x4f, 0x6e, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64,
+	0x12, 0x26, 0x0a, 0x09, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0x18, 0x80, 0x01, 0x52, 0x09, 0x73,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x6e, 0x75, 0x6d, 0x12, 0x47, 0x0a, 0x06, 0x6d, 0x61, 0x63, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x30, 0xfa, 0x42, 0x2d, 0x72, 0x2b, 0x32,
+	0x29, 0x5e, 0x28, 0x5b, 0x30, 0x2d, 0x39, 0x61, 0x2d, 0x66, 0x41, 0x2d, 0x46, 0x5d, 0x7b, 0x32,
+	0x7d, 0x28, 0x5b, 0x2d, 0x3a, 0x5d, 0x29, 0x29, 0x7b, 0x35, 0x7d, 0x5b, 0x30, 0x2d, 0x39, 0x61,
+	0x2d, 0x66, 0x41, 0x2d, 0x46, 0x5d, 0x7b, 0x32, 0x7d, 0x24, 0x52, 0x05, 0x6d, 0x61, 0x63, 0x49,
+	0x64, 0x22, 0xe9, 0x02, 0x0a, 0x1b, 0x47, 0x65, 0x74, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x73, 0x69, 0x72, 0x65,
+	0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64,
+	0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x61,
+	0x77, 0x61, 0x69, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x61, 0x77, 0x61, 0x69, 0x74, 0x69, 0x6e, 0x67,
+	0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x12, 0x2f, 0x0a, 0x13, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x6f, 0x6e, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x12, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e,
+	0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x32, 0xd5, 0x03,
+	0x0a, 0x1c, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x4f, 0x6e, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5c,
+	0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x24, 0x2e,
+	0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x6d, 0x67, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x6d, 0x67, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x6f, 0x64,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x74, 0x0a, 0x13,
+	0x52, 0x65, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x12, 0x2c, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x6d, 0x67, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2d, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x6d, 0x67,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x6b, 0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x46, 0x69, 0x72,
+	0x73, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x12, 0x29, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x6d, 0x67, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72,
+	0x6d, 0x46, 0x69, 0x72, 0x73, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2a, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x6d, 0x67,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x46, 0x69, 0x72, 0x73,
+	0x74, 0x42, 0x6f, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x74, 0x0a, 0x13, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2c, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x6d, 0x67, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x6d, 0x67, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x8b, 0x02, 0x0a, 0x1f, 0x4e, 0x6f, 0x6e, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69,
+	0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x72, 0x0a, 0x11, 0x4f, 0x6e, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x2a,
+	0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x6d, 0x67, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x6f, 0x6e, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x6d, 0x67, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x6e,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x74, 0x0a,
+	0x13, 0x47, 0x65, 0x74, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x2c, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x6d, 0x67, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x6e, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x6d,
+	0x67, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x42, 0x6c, 0x5a, 0x6a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x2d, 0x65, 0x64, 0x67, 0x65, 0x2d, 0x70, 0x6c, 0x61, 0x74,
+	0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x69, 0x6e, 0x66, 0x72, 0x61, 0x2d, 0x6f, 0x6e, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x2d, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x6d, 0x67, 0x72, 0x2f, 0x76,
+	0x31, 0x3b, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x6d, 0x67, 0x72, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -575,32 +1509,54 @@ func file_v1_onboarding_proto_rawDescGZIP() []byte {
 }
 
 var file_v1_onboarding_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_v1_onboarding_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_v1_onboarding_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_v1_onboarding_proto_goTypes = []interface{}{
 	(OnboardNodeStreamResponse_NodeState)(0), // 0: onboardingmgr.v1.OnboardNodeStreamResponse.NodeState
 	(*CreateNodesRequest)(nil),               // 1: onboardingmgr.v1.CreateNodesRequest
 	(*CreateNodesResponse)(nil),              // 2: onboardingmgr.v1.CreateNodesResponse
-	(*NodeData)(nil),                         // 3: onboardingmgr.v1.NodeData
-	(*HwData)(nil),                           // 4: onboardingmgr.v1.HwData
-	(*OnboardNodeStreamRequest)(nil),         // 5: onboardingmgr.v1.OnboardNodeStreamRequest
-	(*OnboardNodeStreamResponse)(nil),        // 6: onboardingmgr.v1.OnboardNodeStreamResponse
-	(*status.Status)(nil),                    // 7: google.rpc.Status
+	(*ReprovisionInstanceRequest)(nil),       // 3: onboardingmgr.v1.ReprovisionInstanceRequest
+	(*ReprovisionInstanceResponse)(nil),      // 4: onboardingmgr.v1.ReprovisionInstanceResponse
+	(*ConfirmFirstBootRequest)(nil),          // 5: onboardingmgr.v1.ConfirmFirstBootRequest
+	(*AgentReadiness)(nil),                   // 6: onboardingmgr.v1.AgentReadiness
+	(*ConfirmFirstBootResponse)(nil),         // 7: onboardingmgr.v1.ConfirmFirstBootResponse
+	(*ReportActionMessageRequest)(nil),       // 8: onboardingmgr.v1.ReportActionMessageRequest
+	(*ReportActionMessageResponse)(nil),      // 9: onboardingmgr.v1.ReportActionMessageResponse
+	(*NodeData)(nil),                         // 10: onboardingmgr.v1.NodeData
+	(*HwData)(nil),                           // 11: onboardingmgr.v1.HwData
+	(*OnboardNodeStreamRequest)(nil),         // 12: onboardingmgr.v1.OnboardNodeStreamRequest
+	(*TpmAttestation)(nil),                   // 13: onboardingmgr.v1.TpmAttestation
+	(*TpmChallenge)(nil),                     // 14: onboardingmgr.v1.TpmChallenge
+	(*OnboardNodeStreamResponse)(nil),        // 15: onboardingmgr.v1.OnboardNodeStreamResponse
+	(*GetOnboardingStatusRequest)(nil),       // 16: onboardingmgr.v1.GetOnboardingStatusRequest
+	(*GetOnboardingStatusResponse)(nil),      // 17: onboardingmgr.v1.GetOnboardingStatusResponse
+	(*status.Status)(nil),                    // 18: google.rpc.Status
 }
 var file_v1_onboarding_proto_depIdxs = []int32{
-	3, // 0: onboardingmgr.v1.CreateNodesRequest.payload:type_name -> onboardingmgr.v1.NodeData
-	3, // 1: onboardingmgr.v1.CreateNodesResponse.payload:type_name -> onboardingmgr.v1.NodeData
-	4, // 2: onboardingmgr.v1.NodeData.hwdata:type_name -> onboardingmgr.v1.HwData
-	7, // 3: onboardingmgr.v1.OnboardNodeStreamResponse.status:type_name -> google.rpc.Status
-	0, // 4: onboardingmgr.v1.OnboardNodeStreamResponse.node_state:type_name -> onboardingmgr.v1.OnboardNodeStreamResponse.NodeState
-	1, // 5: onboardingmgr.v1.InteractiveOnboardingService.CreateNodes:input_type -> onboardingmgr.v1.CreateNodesRequest
-	5, // 6: onboardingmgr.v1.NonInteractiveOnboardingService.OnboardNodeStream:input_type -> onboardingmgr.v1.OnboardNodeStreamRequest
-	2, // 7: onboardingmgr.v1.InteractiveOnboardingService.CreateNodes:output_type -> onboardingmgr.v1.CreateNodesResponse
-	6, // 8: onboardingmgr.v1.NonInteractiveOnboardingService.OnboardNodeStream:output_type -> onboardingmgr.v1.OnboardNodeStreamResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	10, // 0: onboardingmgr.v1.CreateNodesRequest.payload:type_name -> onboardingmgr.v1.NodeData
+	10, // 1: onboardingmgr.v1.CreateNodesResponse.payload:type_name -> onboardingmgr.v1.NodeData
+	6,  // 2: onboardingmgr.v1.ConfirmFirstBootRequest.agents:type_name -> onboardingmgr.v1.AgentReadiness
+	11, // 3: onboardingmgr.v1.NodeData.hwdata:type_name -> onboardingmgr.v1.HwData
+	13, // 4: onboardingmgr.v1.OnboardNodeStreamRequest.tpm_attestation:type_name -> onboardingmgr.v1.TpmAttestation
+	18, // 5: onboardingmgr.v1.OnboardNodeStreamResponse.status:type_name -> google.rpc.Status
+	0,  // 6: onboardingmgr.v1.OnboardNodeStreamResponse.node_state:type_name -> onboardingmgr.v1.OnboardNodeStreamResponse.NodeState
+	14, // 7: onboardingmgr.v1.OnboardNodeStreamResponse.tpm_challenge:type_name -> onboardingmgr.v1.TpmChallenge
+	1,  // 8: onboardingmgr.v1.InteractiveOnboardingService.CreateNodes:input_type -> onboardingmgr.v1.CreateNodesRequest
+	3,  // 9: onboardingmgr.v1.InteractiveOnboardingService.ReprovisionInstance:input_type -> onboardingmgr.v1.ReprovisionInstanceRequest
+	5,  // 10: onboardingmgr.v1.InteractiveOnboardingService.ConfirmFirstBoot:input_type -> onboardingmgr.v1.ConfirmFirstBootRequest
+	8,  // 11: onboardingmgr.v1.InteractiveOnboardingService.ReportActionMessage:input_type -> onboardingmgr.v1.ReportActionMessageRequest
+	12, // 12: onboardingmgr.v1.NonInteractiveOnboardingService.OnboardNodeStream:input_type -> onboardingmgr.v1.OnboardNodeStreamRequest
+	16, // 13: onboardingmgr.v1.NonInteractiveOnboardingService.GetOnboardingStatus:input_type -> onboardingmgr.v1.GetOnboardingStatusRequest
+	2,  // 14: onboardingmgr.v1.InteractiveOnboardingService.CreateNodes:output_type -> onboardingmgr.v1.CreateNodesResponse
+	4,  // 15: onboardingmgr.v1.InteractiveOnboardingService.ReprovisionInstance:output_type -> onboardingmgr.v1.ReprovisionInstanceResponse
+	7,  // 16: onboardingmgr.v1.InteractiveOnboardingService.ConfirmFirstBoot:output_type -> onboardingmgr.v1.ConfirmFirstBootResponse
+	9,  // 17: onboardingmgr.v1.InteractiveOnboardingService.ReportActionMessage:output_type -> onboardingmgr.v1.ReportActionMessageResponse
+	15, // 18: onboardingmgr.v1.NonInteractiveOnboardingService.OnboardNodeStream:output_type -> onboardingmgr.v1.OnboardNodeStreamResponse
+	17, // 19: onboardingmgr.v1.NonInteractiveOnboardingService.GetOnboardingStatus:output_type -> onboardingmgr.v1.GetOnboardingStatusResponse
+	14, // [14:20] is the sub-list for method output_type
+	8,  // [8:14] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_v1_onboarding_proto_init() }
@@ -634,7 +1590,7 @@ func file_v1_onboarding_proto_init() {
 			}
 		}
 		file_v1_onboarding_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NodeData); i {
+			switch v := v.(*ReprovisionInstanceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -646,7 +1602,7 @@ func file_v1_onboarding_proto_init() {
 			}
 		}
 		file_v1_onboarding_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HwData); i {
+			switch v := v.(*ReprovisionInstanceResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -658,7 +1614,7 @@ func file_v1_onboarding_proto_init() {
 			}
 		}
 		file_v1_onboarding_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OnboardNodeStreamRequest); i {
+			switch v := v.(*ConfirmFirstBootRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -670,6 +1626,114 @@ func file_v1_onboarding_proto_init() {
 			}
 		}
 		file_v1_onboarding_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AgentReadiness); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_onboarding_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfirmFirstBootResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_onboarding_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportActionMessageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_onboarding_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportActionMessageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_onboarding_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_onboarding_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HwData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_onboarding_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OnboardNodeStreamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_onboarding_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TpmAttestation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_onboarding_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TpmChallenge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_onboarding_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*OnboardNodeStreamResponse); i {
 			case 0:
 				return &v.state
@@ -681,6 +1745,30 @@ func file_v1_onboarding_proto_init() {
 				return nil
 			}
 		}
+		file_v1_onboarding_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOnboardingStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_onboarding_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetOnboardingStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -688,7 +1776,7 @@ func file_v1_onboarding_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_v1_onboarding_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   6,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -312,75 +312,53 @@ var _ interface {
 	ErrorName() string
 } = CreateNodesResponseValidationError{}
 
-// Validate checks the field values on NodeData with the rules defined in the
-// proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *NodeData) Validate() error {
+// Validate checks the field values on ReprovisionInstanceRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReprovisionInstanceRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on NodeData with the rules defined in
-// the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in NodeDataMultiError, or nil
-// if none found.
-func (m *NodeData) ValidateAll() error {
+// ValidateAll checks the field values on ReprovisionInstanceRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReprovisionInstanceRequestMultiError, or nil if none found.
+func (m *ReprovisionInstanceRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *NodeData) validate(all bool) error {
+func (m *ReprovisionInstanceRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	for idx, item := range m.GetHwdata() {
-		_, _ = idx, item
-
-		if all {
-			switch v := interface{}(item).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, NodeDataValidationError{
-						field:  fmt.Sprintf("Hwdata[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, NodeDataValidationError{
-						field:  fmt.Sprintf("Hwdata[%v]", idx),
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return NodeDataValidationError{
-					field:  fmt.Sprintf("Hwdata[%v]", idx),
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
+	if !_ReprovisionInstanceRequest_ResourceId_Pattern.MatchString(m.GetResourceId()) {
+		err := ReprovisionInstanceRequestValidationError{
+			field:  "ResourceId",
+			reason: "value does not match regex pattern \"^inst-[0-9a-f]{8}$\"",
 		}
-
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
 	}
 
 	if len(errors) > 0 {
-		return NodeDataMultiError(errors)
+		return ReprovisionInstanceRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// NodeDataMultiError is an error wrapping multiple validation errors returned
-// by NodeData.ValidateAll() if the designated constraints aren't met.
-type NodeDataMultiError []error
+// ReprovisionInstanceRequestMultiError is an error wrapping multiple
+// validation errors returned by ReprovisionInstanceRequest.ValidateAll() if
+// the designated constraints aren't met.
+type ReprovisionInstanceRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m NodeDataMultiError) Error() string {
+func (m ReprovisionInstanceRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -389,11 +367,11 @@ func (m NodeDataMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m NodeDataMultiError) AllErrors() []error { return m }
+func (m ReprovisionInstanceRequestMultiError) AllErrors() []error { return m }
 
-// NodeDataValidationError is the validation error returned by
-// NodeData.Validate if the designated constraints aren't met.
-type NodeDataValidationError struct {
+// ReprovisionInstanceRequestValidationError is the validation error returned
+// by ReprovisionInstanceRequest.Validate if the designated constraints aren't met.
+type ReprovisionInstanceRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -401,22 +379,24 @@ type NodeDataValidationError struct {
 }
 
 // Field function returns field value.
-func (e NodeDataValidationError) Field() string { return e.field }
+func (e ReprovisionInstanceRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e NodeDataValidationError) Reason() string { return e.reason }
+func (e ReprovisionInstanceRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e NodeDataValidationError) Cause() error { return e.cause }
+func (e ReprovisionInstanceRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e NodeDataValidationError) Key() bool { return e.key }
+func (e ReprovisionInstanceRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e NodeDataValidationError) ErrorName() string { return "NodeDataValidationError" }
+func (e ReprovisionInstanceRequestValidationError) ErrorName() string {
+	return "ReprovisionInstanceRequestValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e NodeDataValidationError) Error() string {
+func (e ReprovisionInstanceRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -428,14 +408,14 @@ func (e NodeDataValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sNodeData.%s: %s%s",
+		"invalid %sReprovisionInstanceRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = NodeDataValidationError{}
+var _ error = ReprovisionInstanceRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -443,95 +423,50 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = NodeDataValidationError{}
+} = ReprovisionInstanceRequestValidationError{}
 
-// Validate checks the field values on HwData with the rules defined in the
-// proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *HwData) Validate() error {
+var _ReprovisionInstanceRequest_ResourceId_Pattern = regexp.MustCompile("^inst-[0-9a-f]{8}$")
+
+// Validate checks the field values on ReprovisionInstanceResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReprovisionInstanceResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on HwData with the rules defined in the
-// proto definition for this message. If any rules are violated, the result is
-// a list of violation errors wrapped in HwDataMultiError, or nil if none found.
-func (m *HwData) ValidateAll() error {
+// ValidateAll checks the field values on ReprovisionInstanceResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReprovisionInstanceResponseMultiError, or nil if none found.
+func (m *ReprovisionInstanceResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *HwData) validate(all bool) error {
+func (m *ReprovisionInstanceResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if err := m._validateUuid(m.GetUuid()); err != nil {
-		err = HwDataValidationError{
-			field:  "Uuid",
-			reason: "value must be a valid UUID",
-			cause:  err,
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
-	if !_HwData_Serialnum_Pattern.MatchString(m.GetSerialnum()) {
-		err := HwDataValidationError{
-			field:  "Serialnum",
-			reason: "value does not match regex pattern \"^[A-Za-z0-9]{5,20}$\"",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
-	if !_HwData_MacId_Pattern.MatchString(m.GetMacId()) {
-		err := HwDataValidationError{
-			field:  "MacId",
-			reason: "value does not match regex pattern \"^([0-9a-fA-F]{2}([-:])){5}[0-9a-fA-F]{2}$\"",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
+	// no validation rules for ResourceId
 
-	if !_HwData_SutIp_Pattern.MatchString(m.GetSutIp()) {
-		err := HwDataValidationError{
-			field:  "SutIp",
-			reason: "value does not match regex pattern \"^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\\\\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$\"",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
+	// no validation rules for Attempt
 
 	if len(errors) > 0 {
-		return HwDataMultiError(errors)
-	}
-
-	return nil
-}
-
-func (m *HwData) _validateUuid(uuid string) error {
-	if matched := _onboarding_uuidPattern.MatchString(uuid); !matched {
-		return errors.New("invalid uuid format")
+		return ReprovisionInstanceResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// HwDataMultiError is an error wrapping multiple validation errors returned by
-// HwData.ValidateAll() if the designated constraints aren't met.
-type HwDataMultiError []error
+// ReprovisionInstanceResponseMultiError is an error wrapping multiple
+// validation errors returned by ReprovisionInstanceResponse.ValidateAll() if
+// the designated constraints aren't met.
+type ReprovisionInstanceResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m HwDataMultiError) Error() string {
+func (m ReprovisionInstanceResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -540,11 +475,12 @@ func (m HwDataMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m HwDataMultiError) AllErrors() []error { return m }
+func (m ReprovisionInstanceResponseMultiError) AllErrors() []error { return m }
 
-// HwDataValidationError is the validation error returned by HwData.Validate if
-// the designated constraints aren't met.
-type HwDataValidationError struct {
+// ReprovisionInstanceResponseValidationError is the validation error returned
+// by ReprovisionInstanceResponse.Validate if the designated constraints
+// aren't met.
+type ReprovisionInstanceResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -552,22 +488,24 @@ type HwDataValidationError struct {
 }
 
 // Field function returns field value.
-func (e HwDataValidationError) Field() string { return e.field }
+func (e ReprovisionInstanceResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e HwDataValidationError) Reason() string { return e.reason }
+func (e ReprovisionInstanceResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e HwDataValidationError) Cause() error { return e.cause }
+func (e ReprovisionInstanceResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e HwDataValidationError) Key() bool { return e.key }
+func (e ReprovisionInstanceResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e HwDataValidationError) ErrorName() string { return "HwDataValidationError" }
+func (e ReprovisionInstanceResponseValidationError) ErrorName() string {
+	return "ReprovisionInstanceResponseValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e HwDataValidationError) Error() string {
+func (e ReprovisionInstanceResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -579,14 +517,14 @@ func (e HwDataValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sHwData.%s: %s%s",
+		"invalid %sReprovisionInstanceResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = HwDataValidationError{}
+var _ error = ReprovisionInstanceResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -594,30 +532,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = HwDataValidationError{}
-
-var _HwData_Serialnum_Pattern = regexp.MustCompile("^[A-Za-z0-9]{5,20}$")
-
-var _HwData_MacId_Pattern = regexp.MustCompile("^([0-9a-fA-F]{2}([-:])){5}[0-9a-fA-F]{2}$")
-
-var _HwData_SutIp_Pattern = regexp.MustCompile("^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$")
+} = ReprovisionInstanceResponseValidationError{}
 
-// Validate checks the field values on OnboardNodeStreamRequest with the rules
+// Validate checks the field values on ConfirmFirstBootRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *OnboardNodeStreamRequest) Validate() error {
+func (m *ConfirmFirstBootRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on OnboardNodeStreamRequest with the
+// ValidateAll checks the field values on ConfirmFirstBootRequest with the
 // rules defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// OnboardNodeStreamRequestMultiError, or nil if none found.
-func (m *OnboardNodeStreamRequest) ValidateAll() error {
+// ConfirmFirstBootRequestMultiError, or nil if none found.
+func (m *ConfirmFirstBootRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *OnboardNodeStreamRequest) validate(all bool) error {
+func (m *ConfirmFirstBootRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -625,7 +557,7 @@ func (m *OnboardNodeStreamRequest) validate(all bool) error {
 	var errors []error
 
 	if err := m._validateUuid(m.GetUuid()); err != nil {
-		err = OnboardNodeStreamRequestValidationError{
+		err = ConfirmFirstBootRequestValidationError{
 			field:  "Uuid",
 			reason: "value must be a valid UUID",
 			cause:  err,
@@ -636,10 +568,11 @@ func (m *OnboardNodeStreamRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if !_OnboardNodeStreamRequest_Serialnum_Pattern.MatchString(m.GetSerialnum()) {
-		err := OnboardNodeStreamRequestValidationError{
-			field:  "Serialnum",
-			reason: "value does not match regex pattern \"^[A-Za-z0-9]{5,20}$\"",
+	if err := m._validateUuid(m.GetBootId()); err != nil {
+		err = ConfirmFirstBootRequestValidationError{
+			field:  "BootId",
+			reason: "value must be a valid UUID",
+			cause:  err,
 		}
 		if !all {
 			return err
@@ -647,10 +580,10 @@ func (m *OnboardNodeStreamRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if !_OnboardNodeStreamRequest_MacId_Pattern.MatchString(m.GetMacId()) {
-		err := OnboardNodeStreamRequestValidationError{
-			field:  "MacId",
-			reason: "value does not match regex pattern \"^([0-9a-fA-F]{2}([-:])){5}[0-9a-fA-F]{2}$\"",
+	if l := utf8.RuneCountInString(m.GetKernelVersion()); l < 1 || l > 128 {
+		err := ConfirmFirstBootRequestValidationError{
+			field:  "KernelVersion",
+			reason: "value length must be between 1 and 128 runes, inclusive",
 		}
 		if !all {
 			return err
@@ -658,10 +591,10 @@ func (m *OnboardNodeStreamRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if !_OnboardNodeStreamRequest_HostIp_Pattern.MatchString(m.GetHostIp()) {
-		err := OnboardNodeStreamRequestValidationError{
-			field:  "HostIp",
-			reason: "value does not match regex pattern \"^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\\\\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$\"",
+	if len(m.GetAgents()) > 64 {
+		err := ConfirmFirstBootRequestValidationError{
+			field:  "Agents",
+			reason: "value must contain no more than 64 item(s)",
 		}
 		if !all {
 			return err
@@ -669,14 +602,48 @@ func (m *OnboardNodeStreamRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	for idx, item := range m.GetAgents() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ConfirmFirstBootRequestValidationError{
+						field:  fmt.Sprintf("Agents[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ConfirmFirstBootRequestValidationError{
+						field:  fmt.Sprintf("Agents[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ConfirmFirstBootRequestValidationError{
+					field:  fmt.Sprintf("Agents[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
-		return OnboardNodeStreamRequestMultiError(errors)
+		return ConfirmFirstBootRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-func (m *OnboardNodeStreamRequest) _validateUuid(uuid string) error {
+func (m *ConfirmFirstBootRequest) _validateUuid(uuid string) error {
 	if matched := _onboarding_uuidPattern.MatchString(uuid); !matched {
 		return errors.New("invalid uuid format")
 	}
@@ -684,13 +651,13 @@ func (m *OnboardNodeStreamRequest) _validateUuid(uuid string) error {
 	return nil
 }
 
-// OnboardNodeStreamRequestMultiError is an error wrapping multiple validation
-// errors returned by OnboardNodeStreamRequest.ValidateAll() if the designated
+// ConfirmFirstBootRequestMultiError is an error wrapping multiple validation
+// errors returned by ConfirmFirstBootRequest.ValidateAll() if the designated
 // constraints aren't met.
-type OnboardNodeStreamRequestMultiError []error
+type ConfirmFirstBootRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m OnboardNodeStreamRequestMultiError) Error() string {
+func (m ConfirmFirstBootRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -699,11 +666,11 @@ func (m OnboardNodeStreamRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m OnboardNodeStreamRequestMultiError) AllErrors() []error { return m }
+func (m ConfirmFirstBootRequestMultiError) AllErrors() []error { return m }
 
-// OnboardNodeStreamRequestValidationError is the validation error returned by
-// OnboardNodeStreamRequest.Validate if the designated constraints aren't met.
-type OnboardNodeStreamRequestValidationError struct {
+// ConfirmFirstBootRequestValidationError is the validation error returned by
+// ConfirmFirstBootRequest.Validate if the designated constraints aren't met.
+type ConfirmFirstBootRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -711,24 +678,24 @@ type OnboardNodeStreamRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e OnboardNodeStreamRequestValidationError) Field() string { return e.field }
+func (e ConfirmFirstBootRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e OnboardNodeStreamRequestValidationError) Reason() string { return e.reason }
+func (e ConfirmFirstBootRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e OnboardNodeStreamRequestValidationError) Cause() error { return e.cause }
+func (e ConfirmFirstBootRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e OnboardNodeStreamRequestValidationError) Key() bool { return e.key }
+func (e ConfirmFirstBootRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e OnboardNodeStreamRequestValidationError) ErrorName() string {
-	return "OnboardNodeStreamRequestValidationError"
+func (e ConfirmFirstBootRequestValidationError) ErrorName() string {
+	return "ConfirmFirstBootRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e OnboardNodeStreamRequestValidationError) Error() string {
+func (e ConfirmFirstBootRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -740,14 +707,14 @@ func (e OnboardNodeStreamRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sOnboardNodeStreamRequest.%s: %s%s",
+		"invalid %sConfirmFirstBootRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = OnboardNodeStreamRequestValidationError{}
+var _ error = ConfirmFirstBootRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -755,48 +722,1245 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = OnboardNodeStreamRequestValidationError{}
-
-var _OnboardNodeStreamRequest_Serialnum_Pattern = regexp.MustCompile("^[A-Za-z0-9]{5,20}$")
-
-var _OnboardNodeStreamRequest_MacId_Pattern = regexp.MustCompile("^([0-9a-fA-F]{2}([-:])){5}[0-9a-fA-F]{2}$")
-
-var _OnboardNodeStreamRequest_HostIp_Pattern = regexp.MustCompile("^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$")
+} = ConfirmFirstBootRequestValidationError{}
 
-// Validate checks the field values on OnboardNodeStreamResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *OnboardNodeStreamResponse) Validate() error {
+// Validate checks the field values on AgentReadiness with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AgentReadiness) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on OnboardNodeStreamResponse with the
-// rules defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// OnboardNodeStreamResponseMultiError, or nil if none found.
-func (m *OnboardNodeStreamResponse) ValidateAll() error {
+// ValidateAll checks the field values on AgentReadiness with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AgentReadinessMultiError,
+// or nil if none found.
+func (m *AgentReadiness) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *OnboardNodeStreamResponse) validate(all bool) error {
+func (m *AgentReadiness) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetStatus()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, OnboardNodeStreamResponseValidationError{
-					field:  "Status",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
+	if l := utf8.RuneCountInString(m.GetName()); l < 1 || l > 64 {
+		err := AgentReadinessValidationError{
+			field:  "Name",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Ready
+
+	if len(errors) > 0 {
+		return AgentReadinessMultiError(errors)
+	}
+
+	return nil
+}
+
+// AgentReadinessMultiError is an error wrapping multiple validation errors
+// returned by AgentReadiness.ValidateAll() if the designated constraints
+// aren't met.
+type AgentReadinessMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AgentReadinessMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AgentReadinessMultiError) AllErrors() []error { return m }
+
+// AgentReadinessValidationError is the validation error returned by
+// AgentReadiness.Validate if the designated constraints aren't met.
+type AgentReadinessValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AgentReadinessValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AgentReadinessValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AgentReadinessValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AgentReadinessValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AgentReadinessValidationError) ErrorName() string { return "AgentReadinessValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AgentReadinessValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAgentReadiness.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AgentReadinessValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AgentReadinessValidationError{}
+
+// Validate checks the field values on ConfirmFirstBootResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ConfirmFirstBootResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ConfirmFirstBootResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ConfirmFirstBootResponseMultiError, or nil if none found.
+func (m *ConfirmFirstBootResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ConfirmFirstBootResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for InstanceId
+
+	// no validation rules for Confirmed
+
+	if len(errors) > 0 {
+		return ConfirmFirstBootResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ConfirmFirstBootResponseMultiError is an error wrapping multiple validation
+// errors returned by ConfirmFirstBootResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ConfirmFirstBootResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ConfirmFirstBootResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ConfirmFirstBootResponseMultiError) AllErrors() []error { return m }
+
+// ConfirmFirstBootResponseValidationError is the validation error returned by
+// ConfirmFirstBootResponse.Validate if the designated constraints aren't met.
+type ConfirmFirstBootResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ConfirmFirstBootResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ConfirmFirstBootResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ConfirmFirstBootResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ConfirmFirstBootResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ConfirmFirstBootResponseValidationError) ErrorName() string {
+	return "ConfirmFirstBootResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ConfirmFirstBootResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sConfirmFirstBootResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ConfirmFirstBootResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ConfirmFirstBootResponseValidationError{}
+
+// Validate checks the field values on ReportActionMessageRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReportActionMessageRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReportActionMessageRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReportActionMessageRequestMultiError, or nil if none found.
+func (m *ReportActionMessageRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReportActionMessageRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUuid()); err != nil {
+		err = ReportActionMessageRequestValidationError{
+			field:  "Uuid",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetWorkflowId()); l < 1 || l > 317 {
+		err := ReportActionMessageRequestValidationError{
+			field:  "WorkflowId",
+			reason: "value length must be between 1 and 317 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetTaskName()); l < 1 || l > 200 {
+		err := ReportActionMessageRequestValidationError{
+			field:  "TaskName",
+			reason: "value length must be between 1 and 200 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetActionName()); l < 1 || l > 200 {
+		err := ReportActionMessageRequestValidationError{
+			field:  "ActionName",
+			reason: "value length must be between 1 and 200 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetMessage()) > 32768 {
+		err := ReportActionMessageRequestValidationError{
+			field:  "Message",
+			reason: "value length must be at most 32768 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ReportActionMessageRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *ReportActionMessageRequest) _validateUuid(uuid string) error {
+	if matched := _onboarding_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// ReportActionMessageRequestMultiError is an error wrapping multiple
+// validation errors returned by ReportActionMessageRequest.ValidateAll() if
+// the designated constraints aren't met.
+type ReportActionMessageRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReportActionMessageRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReportActionMessageRequestMultiError) AllErrors() []error { return m }
+
+// ReportActionMessageRequestValidationError is the validation error returned
+// by ReportActionMessageRequest.Validate if the designated constraints aren't met.
+type ReportActionMessageRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReportActionMessageRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReportActionMessageRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReportActionMessageRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReportActionMessageRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReportActionMessageRequestValidationError) ErrorName() string {
+	return "ReportActionMessageRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReportActionMessageRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReportActionMessageRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReportActionMessageRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReportActionMessageRequestValidationError{}
+
+// Validate checks the field values on ReportActionMessageResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReportActionMessageResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReportActionMessageResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReportActionMessageResponseMultiError, or nil if none found.
+func (m *ReportActionMessageResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReportActionMessageResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return ReportActionMessageResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReportActionMessageResponseMultiError is an error wrapping multiple
+// validation errors returned by ReportActionMessageResponse.ValidateAll() if
+// the designated constraints aren't met.
+type ReportActionMessageResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReportActionMessageResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReportActionMessageResponseMultiError) AllErrors() []error { return m }
+
+// ReportActionMessageResponseValidationError is the validation error returned
+// by ReportActionMessageResponse.Validate if the designated constraints
+// aren't met.
+type ReportActionMessageResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReportActionMessageResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReportActionMessageResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReportActionMessageResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReportActionMessageResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReportActionMessageResponseValidationError) ErrorName() string {
+	return "ReportActionMessageResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReportActionMessageResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReportActionMessageResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReportActionMessageResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReportActionMessageResponseValidationError{}
+
+// Validate checks the field values on NodeData with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *NodeData) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on NodeData with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in NodeDataMultiError, or nil
+// if none found.
+func (m *NodeData) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *NodeData) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetHwdata() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, NodeDataValidationError{
+						field:  fmt.Sprintf("Hwdata[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, NodeDataValidationError{
+						field:  fmt.Sprintf("Hwdata[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return NodeDataValidationError{
+					field:  fmt.Sprintf("Hwdata[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return NodeDataMultiError(errors)
+	}
+
+	return nil
+}
+
+// NodeDataMultiError is an error wrapping multiple validation errors returned
+// by NodeData.ValidateAll() if the designated constraints aren't met.
+type NodeDataMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m NodeDataMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m NodeDataMultiError) AllErrors() []error { return m }
+
+// NodeDataValidationError is the validation error returned by
+// NodeData.Validate if the designated constraints aren't met.
+type NodeDataValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e NodeDataValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e NodeDataValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e NodeDataValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e NodeDataValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e NodeDataValidationError) ErrorName() string { return "NodeDataValidationError" }
+
+// Error satisfies the builtin error interface
+func (e NodeDataValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sNodeData.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = NodeDataValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = NodeDataValidationError{}
+
+// Validate checks the field values on HwData with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *HwData) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on HwData with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in HwDataMultiError, or nil if none found.
+func (m *HwData) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *HwData) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUuid()); err != nil {
+		err = HwDataValidationError{
+			field:  "Uuid",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_HwData_Serialnum_Pattern.MatchString(m.GetSerialnum()) {
+		err := HwDataValidationError{
+			field:  "Serialnum",
+			reason: "value does not match regex pattern \"^[A-Za-z0-9]{5,20}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_HwData_MacId_Pattern.MatchString(m.GetMacId()) {
+		err := HwDataValidationError{
+			field:  "MacId",
+			reason: "value does not match regex pattern \"^([0-9a-fA-F]{2}([-:])){5}[0-9a-fA-F]{2}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_HwData_SutIp_Pattern.MatchString(m.GetSutIp()) {
+		err := HwDataValidationError{
+			field:  "SutIp",
+			reason: "value does not match regex pattern \"^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\\\\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return HwDataMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *HwData) _validateUuid(uuid string) error {
+	if matched := _onboarding_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// HwDataMultiError is an error wrapping multiple validation errors returned by
+// HwData.ValidateAll() if the designated constraints aren't met.
+type HwDataMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m HwDataMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m HwDataMultiError) AllErrors() []error { return m }
+
+// HwDataValidationError is the validation error returned by HwData.Validate if
+// the designated constraints aren't met.
+type HwDataValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e HwDataValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e HwDataValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e HwDataValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e HwDataValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e HwDataValidationError) ErrorName() string { return "HwDataValidationError" }
+
+// Error satisfies the builtin error interface
+func (e HwDataValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sHwData.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = HwDataValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = HwDataValidationError{}
+
+var _HwData_Serialnum_Pattern = regexp.MustCompile("^[A-Za-z0-9]{5,20}$")
+
+var _HwData_MacId_Pattern = regexp.MustCompile("^([0-9a-fA-F]{2}([-:])){5}[0-9a-fA-F]{2}$")
+
+var _HwData_SutIp_Pattern = regexp.MustCompile("^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$")
+
+// Validate checks the field values on OnboardNodeStreamRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *OnboardNodeStreamRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on OnboardNodeStreamRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// OnboardNodeStreamRequestMultiError, or nil if none found.
+func (m *OnboardNodeStreamRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *OnboardNodeStreamRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUuid()); err != nil {
+		err = OnboardNodeStreamRequestValidationError{
+			field:  "Uuid",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetSerialnum()) > 128 {
+		err := OnboardNodeStreamRequestValidationError{
+			field:  "Serialnum",
+			reason: "value length must be at most 128 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_OnboardNodeStreamRequest_MacId_Pattern.MatchString(m.GetMacId()) {
+		err := OnboardNodeStreamRequestValidationError{
+			field:  "MacId",
+			reason: "value does not match regex pattern \"^([0-9a-fA-F]{2}([-:])){5}[0-9a-fA-F]{2}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_OnboardNodeStreamRequest_HostIp_Pattern.MatchString(m.GetHostIp()) {
+		err := OnboardNodeStreamRequestValidationError{
+			field:  "HostIp",
+			reason: "value does not match regex pattern \"^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\\\\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetTpmAttestation()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, OnboardNodeStreamRequestValidationError{
+					field:  "TpmAttestation",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, OnboardNodeStreamRequestValidationError{
+					field:  "TpmAttestation",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTpmAttestation()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return OnboardNodeStreamRequestValidationError{
+				field:  "TpmAttestation",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for AwaitApproval
+
+	if utf8.RuneCountInString(m.GetArchitecture()) > 32 {
+		err := OnboardNodeStreamRequestValidationError{
+			field:  "Architecture",
+			reason: "value length must be at most 32 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_OnboardNodeStreamRequest_Architecture_Pattern.MatchString(m.GetArchitecture()) {
+		err := OnboardNodeStreamRequestValidationError{
+			field:  "Architecture",
+			reason: "value does not match regex pattern \"^[a-z0-9_]*$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return OnboardNodeStreamRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *OnboardNodeStreamRequest) _validateUuid(uuid string) error {
+	if matched := _onboarding_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// OnboardNodeStreamRequestMultiError is an error wrapping multiple validation
+// errors returned by OnboardNodeStreamRequest.ValidateAll() if the designated
+// constraints aren't met.
+type OnboardNodeStreamRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m OnboardNodeStreamRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m OnboardNodeStreamRequestMultiError) AllErrors() []error { return m }
+
+// OnboardNodeStreamRequestValidationError is the validation error returned by
+// OnboardNodeStreamRequest.Validate if the designated constraints aren't met.
+type OnboardNodeStreamRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e OnboardNodeStreamRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e OnboardNodeStreamRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e OnboardNodeStreamRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e OnboardNodeStreamRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e OnboardNodeStreamRequestValidationError) ErrorName() string {
+	return "OnboardNodeStreamRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e OnboardNodeStreamRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sOnboardNodeStreamRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = OnboardNodeStreamRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = OnboardNodeStreamRequestValidationError{}
+
+var _OnboardNodeStreamRequest_MacId_Pattern = regexp.MustCompile("^([0-9a-fA-F]{2}([-:])){5}[0-9a-fA-F]{2}$")
+
+var _OnboardNodeStreamRequest_HostIp_Pattern = regexp.MustCompile("^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$")
+
+var _OnboardNodeStreamRequest_Architecture_Pattern = regexp.MustCompile("^[a-z0-9_]*$")
+
+// Validate checks the field values on TpmAttestation with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TpmAttestation) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TpmAttestation with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TpmAttestationMultiError,
+// or nil if none found.
+func (m *TpmAttestation) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TpmAttestation) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for EkCert
+
+	// no validation rules for EkPub
+
+	// no validation rules for AkName
+
+	// no validation rules for ActivatedSecret
+
+	if len(errors) > 0 {
+		return TpmAttestationMultiError(errors)
+	}
+
+	return nil
+}
+
+// TpmAttestationMultiError is an error wrapping multiple validation errors
+// returned by TpmAttestation.ValidateAll() if the designated constraints
+// aren't met.
+type TpmAttestationMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TpmAttestationMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TpmAttestationMultiError) AllErrors() []error { return m }
+
+// TpmAttestationValidationError is the validation error returned by
+// TpmAttestation.Validate if the designated constraints aren't met.
+type TpmAttestationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TpmAttestationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TpmAttestationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TpmAttestationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TpmAttestationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TpmAttestationValidationError) ErrorName() string { return "TpmAttestationValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TpmAttestationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTpmAttestation.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TpmAttestationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TpmAttestationValidationError{}
+
+// Validate checks the field values on TpmChallenge with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TpmChallenge) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TpmChallenge with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TpmChallengeMultiError, or
+// nil if none found.
+func (m *TpmChallenge) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TpmChallenge) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for CredentialBlob
+
+	// no validation rules for EncryptedSecret
+
+	if len(errors) > 0 {
+		return TpmChallengeMultiError(errors)
+	}
+
+	return nil
+}
+
+// TpmChallengeMultiError is an error wrapping multiple validation errors
+// returned by TpmChallenge.ValidateAll() if the designated constraints aren't met.
+type TpmChallengeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TpmChallengeMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TpmChallengeMultiError) AllErrors() []error { return m }
+
+// TpmChallengeValidationError is the validation error returned by
+// TpmChallenge.Validate if the designated constraints aren't met.
+type TpmChallengeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TpmChallengeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TpmChallengeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TpmChallengeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TpmChallengeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TpmChallengeValidationError) ErrorName() string { return "TpmChallengeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TpmChallengeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTpmChallenge.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TpmChallengeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TpmChallengeValidationError{}
+
+// Validate checks the field values on OnboardNodeStreamResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *OnboardNodeStreamResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on OnboardNodeStreamResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// OnboardNodeStreamResponseMultiError, or nil if none found.
+func (m *OnboardNodeStreamResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *OnboardNodeStreamResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetStatus()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, OnboardNodeStreamResponseValidationError{
+					field:  "Status",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
 				errors = append(errors, OnboardNodeStreamResponseValidationError{
 					field:  "Status",
 					reason: "embedded message failed validation",
@@ -822,6 +1986,39 @@ func (m *OnboardNodeStreamResponse) validate(all bool) error {
 
 	// no validation rules for ProjectId
 
+	if all {
+		switch v := interface{}(m.GetTpmChallenge()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, OnboardNodeStreamResponseValidationError{
+					field:  "TpmChallenge",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, OnboardNodeStreamResponseValidationError{
+					field:  "TpmChallenge",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTpmChallenge()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return OnboardNodeStreamResponseValidationError{
+				field:  "TpmChallenge",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for AwaitingApproval
+
+	// no validation rules for KeepaliveIntervalSeconds
+
 	if len(errors) > 0 {
 		return OnboardNodeStreamResponseMultiError(errors)
 	}
@@ -901,3 +2098,268 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = OnboardNodeStreamResponseValidationError{}
+
+// Validate checks the field values on GetOnboardingStatusRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetOnboardingStatusRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetOnboardingStatusRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetOnboardingStatusRequestMultiError, or nil if none found.
+func (m *GetOnboardingStatusRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetOnboardingStatusRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUuid()); err != nil {
+		err = GetOnboardingStatusRequestValidationError{
+			field:  "Uuid",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetSerialnum()) > 128 {
+		err := GetOnboardingStatusRequestValidationError{
+			field:  "Serialnum",
+			reason: "value length must be at most 128 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_GetOnboardingStatusRequest_MacId_Pattern.MatchString(m.GetMacId()) {
+		err := GetOnboardingStatusRequestValidationError{
+			field:  "MacId",
+			reason: "value does not match regex pattern \"^([0-9a-fA-F]{2}([-:])){5}[0-9a-fA-F]{2}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return GetOnboardingStatusRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *GetOnboardingStatusRequest) _validateUuid(uuid string) error {
+	if matched := _onboarding_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// GetOnboardingStatusRequestMultiError is an error wrapping multiple
+// validation errors returned by GetOnboardingStatusRequest.ValidateAll() if
+// the designated constraints aren't met.
+type GetOnboardingStatusRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetOnboardingStatusRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetOnboardingStatusRequestMultiError) AllErrors() []error { return m }
+
+// GetOnboardingStatusRequestValidationError is the validation error returned
+// by GetOnboardingStatusRequest.Validate if the designated constraints aren't met.
+type GetOnboardingStatusRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetOnboardingStatusRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetOnboardingStatusRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetOnboardingStatusRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetOnboardingStatusRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetOnboardingStatusRequestValidationError) ErrorName() string {
+	return "GetOnboardingStatusRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetOnboardingStatusRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetOnboardingStatusRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetOnboardingStatusRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetOnboardingStatusRequestValidationError{}
+
+var _GetOnboardingStatusRequest_MacId_Pattern = regexp.MustCompile("^([0-9a-fA-F]{2}([-:])){5}[0-9a-fA-F]{2}$")
+
+// Validate checks the field values on GetOnboardingStatusResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetOnboardingStatusResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetOnboardingStatusResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetOnboardingStatusResponseMultiError, or nil if none found.
+func (m *GetOnboardingStatusResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetOnboardingStatusResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for CurrentState
+
+	// no validation rules for DesiredState
+
+	// no validation rules for AwaitingApproval
+
+	// no validation rules for RegistrationStatus
+
+	// no validation rules for OnboardingStatus
+
+	// no validation rules for ProvisioningStatus
+
+	// no validation rules for CurrentAction
+
+	// no validation rules for LastError
+
+	if len(errors) > 0 {
+		return GetOnboardingStatusResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetOnboardingStatusResponseMultiError is an error wrapping multiple
+// validation errors returned by GetOnboardingStatusResponse.ValidateAll() if
+// the designated constraints aren't met.
+type GetOnboardingStatusResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetOnboardingStatusResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetOnboardingStatusResponseMultiError) AllErrors() []error { return m }
+
+// GetOnboardingStatusResponseValidationError is the validation error returned
+// by GetOnboardingStatusResponse.Validate if the designated constraints
+// aren't met.
+type GetOnboardingStatusResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetOnboardingStatusResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetOnboardingStatusResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetOnboardingStatusResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetOnboardingStatusResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetOnboardingStatusResponseValidationError) ErrorName() string {
+	return "GetOnboardingStatusResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetOnboardingStatusResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetOnboardingStatusResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetOnboardingStatusResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetOnboardingStatusResponseValidationError{}
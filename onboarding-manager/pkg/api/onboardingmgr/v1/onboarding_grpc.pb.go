@@ -23,6 +23,18 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type InteractiveOnboardingServiceClient interface {
 	CreateNodes(ctx context.Context, in *CreateNodesRequest, opts ...grpc.CallOption) (*CreateNodesResponse, error)
+	// ReprovisionInstance reinstalls a provisioned Instance with its current OS and configuration.
+	// The identity and the credentials of its Host are preserved. Only the onboarding users of the tenant, not its
+	// Edge Nodes, can reprovision its Instances.
+	ReprovisionInstance(ctx context.Context, in *ReprovisionInstanceRequest, opts ...grpc.CallOption) (*ReprovisionInstanceResponse, error)
+	// ConfirmFirstBoot is called by the OS installed on an Edge Node once it booted, with the credentials of
+	// the Edge Node. Its Instance is only reported as provisioned once its first boot is confirmed, when the first
+	// boot confirmation is enabled; it is disabled until the installed OSes call ConfirmFirstBoot.
+	ConfirmFirstBoot(ctx context.Context, in *ConfirmFirstBootRequest, opts ...grpc.CallOption) (*ConfirmFirstBootResponse, error)
+	// ReportActionMessage is called by the tink-worker of an Edge Node, with the credentials of the Edge Node, to
+	// report the message of the running action of its workflow, e.g. its progress or the report it published.
+	// The Tinkerbell server does not keep the messages reported with the status of the actions.
+	ReportActionMessage(ctx context.Context, in *ReportActionMessageRequest, opts ...grpc.CallOption) (*ReportActionMessageResponse, error)
 }
 
 type interactiveOnboardingServiceClient struct {
@@ -42,11 +54,50 @@ func (c *interactiveOnboardingServiceClient) CreateNodes(ctx context.Context, in
 	return out, nil
 }
 
+func (c *interactiveOnboardingServiceClient) ReprovisionInstance(ctx context.Context, in *ReprovisionInstanceRequest, opts ...grpc.CallOption) (*ReprovisionInstanceResponse, error) {
+	out := new(ReprovisionInstanceResponse)
+	err := c.cc.Invoke(ctx, "/onboardingmgr.v1.InteractiveOnboardingService/ReprovisionInstance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveOnboardingServiceClient) ConfirmFirstBoot(ctx context.Context, in *ConfirmFirstBootRequest, opts ...grpc.CallOption) (*ConfirmFirstBootResponse, error) {
+	out := new(ConfirmFirstBootResponse)
+	err := c.cc.Invoke(ctx, "/onboardingmgr.v1.InteractiveOnboardingService/ConfirmFirstBoot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveOnboardingServiceClient) ReportActionMessage(ctx context.Context, in *ReportActionMessageRequest, opts ...grpc.CallOption) (*ReportActionMessageResponse, error) {
+	out := new(ReportActionMessageResponse)
+	err := c.cc.Invoke(ctx, "/onboardingmgr.v1.InteractiveOnboardingService/ReportActionMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InteractiveOnboardingServiceServer is the server API for InteractiveOnboardingService service.
 // All implementations should embed UnimplementedInteractiveOnboardingServiceServer
 // for forward compatibility
 type InteractiveOnboardingServiceServer interface {
 	CreateNodes(context.Context, *CreateNodesRequest) (*CreateNodesResponse, error)
+	// ReprovisionInstance reinstalls a provisioned Instance with its current OS and configuration.
+	// The identity and the credentials of its Host are preserved. Only the onboarding users of the tenant, not its
+	// Edge Nodes, can reprovision its Instances.
+	ReprovisionInstance(context.Context, *ReprovisionInstanceRequest) (*ReprovisionInstanceResponse, error)
+	// ConfirmFirstBoot is called by the OS installed on an Edge Node once it booted, with the credentials of
+	// the Edge Node. Its Instance is only reported as provisioned once its first boot is confirmed, when the first
+	// boot confirmation is enabled; it is disabled until the installed OSes call ConfirmFirstBoot.
+	ConfirmFirstBoot(context.Context, *ConfirmFirstBootRequest) (*ConfirmFirstBootResponse, error)
+	// ReportActionMessage is called by the tink-worker of an Edge Node, with the credentials of the Edge Node, to
+	// report the message of the running action of its workflow, e.g. its progress or the report it published.
+	// The Tinkerbell server does not keep the messages reported with the status of the actions.
+	ReportActionMessage(context.Context, *ReportActionMessageRequest) (*ReportActionMessageResponse, error)
 }
 
 // UnimplementedInteractiveOnboardingServiceServer should be embedded to have forward compatible implementations.
@@ -56,6 +107,15 @@ type UnimplementedInteractiveOnboardingServiceServer struct {
 func (UnimplementedInteractiveOnboardingServiceServer) CreateNodes(context.Context, *CreateNodesRequest) (*CreateNodesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateNodes not implemented")
 }
+func (UnimplementedInteractiveOnboardingServiceServer) ReprovisionInstance(context.Context, *ReprovisionInstanceRequest) (*ReprovisionInstanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReprovisionInstance not implemented")
+}
+func (UnimplementedInteractiveOnboardingServiceServer) ConfirmFirstBoot(context.Context, *ConfirmFirstBootRequest) (*ConfirmFirstBootResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmFirstBoot not implemented")
+}
+func (UnimplementedInteractiveOnboardingServiceServer) ReportActionMessage(context.Context, *ReportActionMessageRequest) (*ReportActionMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportActionMessage not implemented")
+}
 
 // UnsafeInteractiveOnboardingServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to InteractiveOnboardingServiceServer will
@@ -86,6 +146,60 @@ func _InteractiveOnboardingService_CreateNodes_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InteractiveOnboardingService_ReprovisionInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReprovisionInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveOnboardingServiceServer).ReprovisionInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/onboardingmgr.v1.InteractiveOnboardingService/ReprovisionInstance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveOnboardingServiceServer).ReprovisionInstance(ctx, req.(*ReprovisionInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveOnboardingService_ConfirmFirstBoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmFirstBootRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveOnboardingServiceServer).ConfirmFirstBoot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/onboardingmgr.v1.InteractiveOnboardingService/ConfirmFirstBoot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveOnboardingServiceServer).ConfirmFirstBoot(ctx, req.(*ConfirmFirstBootRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveOnboardingService_ReportActionMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportActionMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveOnboardingServiceServer).ReportActionMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/onboardingmgr.v1.InteractiveOnboardingService/ReportActionMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveOnboardingServiceServer).ReportActionMessage(ctx, req.(*ReportActionMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InteractiveOnboardingService_ServiceDesc is the grpc.ServiceDesc for InteractiveOnboardingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -97,6 +211,18 @@ var InteractiveOnboardingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateNodes",
 			Handler:    _InteractiveOnboardingService_CreateNodes_Handler,
 		},
+		{
+			MethodName: "ReprovisionInstance",
+			Handler:    _InteractiveOnboardingService_ReprovisionInstance_Handler,
+		},
+		{
+			MethodName: "ConfirmFirstBoot",
+			Handler:    _InteractiveOnboardingService_ConfirmFirstBoot_Handler,
+		},
+		{
+			MethodName: "ReportActionMessage",
+			Handler:    _InteractiveOnboardingService_ReportActionMessage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "v1/onboarding.proto",
@@ -109,6 +235,9 @@ type NonInteractiveOnboardingServiceClient interface {
 	// OnboardNodeStream establishes a bidirectional stream between the Edge Node and the Onboarding Manager
 	// It allows Edge Node to send stream requests and receive responses
 	OnboardNodeStream(ctx context.Context, opts ...grpc.CallOption) (NonInteractiveOnboardingService_OnboardNodeStreamClient, error)
+	// GetOnboardingStatus returns the onboarding and provisioning status of the Edge Node,
+	// to be displayed on its console
+	GetOnboardingStatus(ctx context.Context, in *GetOnboardingStatusRequest, opts ...grpc.CallOption) (*GetOnboardingStatusResponse, error)
 }
 
 type nonInteractiveOnboardingServiceClient struct {
@@ -150,6 +279,15 @@ func (x *nonInteractiveOnboardingServiceOnboardNodeStreamClient) Recv() (*Onboar
 	return m, nil
 }
 
+func (c *nonInteractiveOnboardingServiceClient) GetOnboardingStatus(ctx context.Context, in *GetOnboardingStatusRequest, opts ...grpc.CallOption) (*GetOnboardingStatusResponse, error) {
+	out := new(GetOnboardingStatusResponse)
+	err := c.cc.Invoke(ctx, "/onboardingmgr.v1.NonInteractiveOnboardingService/GetOnboardingStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NonInteractiveOnboardingServiceServer is the server API for NonInteractiveOnboardingService service.
 // All implementations should embed UnimplementedNonInteractiveOnboardingServiceServer
 // for forward compatibility
@@ -157,6 +295,9 @@ type NonInteractiveOnboardingServiceServer interface {
 	// OnboardNodeStream establishes a bidirectional stream between the Edge Node and the Onboarding Manager
 	// It allows Edge Node to send stream requests and receive responses
 	OnboardNodeStream(NonInteractiveOnboardingService_OnboardNodeStreamServer) error
+	// GetOnboardingStatus returns the onboarding and provisioning status of the Edge Node,
+	// to be displayed on its console
+	GetOnboardingStatus(context.Context, *GetOnboardingStatusRequest) (*GetOnboardingStatusResponse, error)
 }
 
 // UnimplementedNonInteractiveOnboardingServiceServer should be embedded to have forward compatible implementations.
@@ -166,6 +307,9 @@ type UnimplementedNonInteractiveOnboardingServiceServer struct {
 func (UnimplementedNonInteractiveOnboardingServiceServer) OnboardNodeStream(NonInteractiveOnboardingService_OnboardNodeStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method OnboardNodeStream not implemented")
 }
+func (UnimplementedNonInteractiveOnboardingServiceServer) GetOnboardingStatus(context.Context, *GetOnboardingStatusRequest) (*GetOnboardingStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOnboardingStatus not implemented")
+}
 
 // UnsafeNonInteractiveOnboardingServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to NonInteractiveOnboardingServiceServer will
@@ -204,13 +348,36 @@ func (x *nonInteractiveOnboardingServiceOnboardNodeStreamServer) Recv() (*Onboar
 	return m, nil
 }
 
+func _NonInteractiveOnboardingService_GetOnboardingStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOnboardingStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NonInteractiveOnboardingServiceServer).GetOnboardingStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/onboardingmgr.v1.NonInteractiveOnboardingService/GetOnboardingStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NonInteractiveOnboardingServiceServer).GetOnboardingStatus(ctx, req.(*GetOnboardingStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NonInteractiveOnboardingService_ServiceDesc is the grpc.ServiceDesc for NonInteractiveOnboardingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var NonInteractiveOnboardingService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "onboardingmgr.v1.NonInteractiveOnboardingService",
 	HandlerType: (*NonInteractiveOnboardingServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetOnboardingStatus",
+			Handler:    _NonInteractiveOnboardingService_GetOnboardingStatus_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "OnboardNodeStream",
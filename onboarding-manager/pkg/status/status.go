@@ -6,6 +6,8 @@ package status
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	statusv1 "github.com/open-edge-platform/infra-core/inventory/v2/pkg/api/status/v1"
 	inv_status "github.com/open-edge-platform/infra-core/inventory/v2/pkg/status"
@@ -27,6 +29,28 @@ var (
 	ProvisioningStatusFailed = inv_status.New("Provisioning Failed", statusv1.StatusIndication_STATUS_INDICATION_ERROR)
 	// ProvisioningStatusDone defines a configuration value.
 	ProvisioningStatusDone = inv_status.New("Provisioned", statusv1.StatusIndication_STATUS_INDICATION_IDLE)
+	// ReprovisioningStatusInProgress defines a configuration value.
+	ReprovisioningStatusInProgress = inv_status.New("Reprovisioning In Progress",
+		statusv1.StatusIndication_STATUS_INDICATION_IN_PROGRESS)
+	// ReprovisioningStatusFailed defines a configuration value.
+	ReprovisioningStatusFailed = inv_status.New("Reprovisioning Failed", statusv1.StatusIndication_STATUS_INDICATION_ERROR)
+	// ReprovisioningStatusDone defines a configuration value.
+	ReprovisioningStatusDone = inv_status.New("Reprovisioned", statusv1.StatusIndication_STATUS_INDICATION_IDLE)
+	// ProvisioningStatusVerifyingFirstBoot is reported once the workflow succeeded, until the installed OS confirms
+	// its first boot.
+	ProvisioningStatusVerifyingFirstBoot = inv_status.New("Verifying First Boot",
+		statusv1.StatusIndication_STATUS_INDICATION_IN_PROGRESS)
+	// ProvisioningStatusQueued is reported while the provisioning of an Instance waits for admission,
+	// see QueuedStatus.
+	ProvisioningStatusQueued = inv_status.New("Queued for provisioning",
+		statusv1.StatusIndication_STATUS_INDICATION_IN_PROGRESS)
+	// ReprovisioningStatusQueued is reported while the reprovisioning of an Instance waits for admission.
+	ReprovisioningStatusQueued = inv_status.New("Queued for reprovisioning",
+		statusv1.StatusIndication_STATUS_INDICATION_IN_PROGRESS)
+	// ProvisioningStatusWaitingForMaintenanceWindow is reported while the provisioning of an Instance waits for a
+	// maintenance window of its site or tenant to open, see MaintenanceWindowStatus.
+	ProvisioningStatusWaitingForMaintenanceWindow = inv_status.New("Waiting for maintenance window",
+		statusv1.StatusIndication_STATUS_INDICATION_IN_PROGRESS)
 	// UpdateStatusUnknown defines a configuration value.
 	UpdateStatusUnknown = inv_status.New("Unknown", statusv1.StatusIndication_STATUS_INDICATION_UNSPECIFIED)
 	// TrustedAttestationStatusUnknown defines a configuration value.
@@ -56,6 +80,8 @@ var (
 
 	// HostStatusRebooting defines a configuration value.
 	HostStatusRebooting = inv_status.New("Rebooting", statusv1.StatusIndication_STATUS_INDICATION_IN_PROGRESS)
+	// HostStatusBMCUnreachable reports that the BMC of a host could not be reached to boot or power off the host.
+	HostStatusBMCUnreachable = inv_status.New("BMC Unreachable", statusv1.StatusIndication_STATUS_INDICATION_ERROR)
 
 	// DeletingStatus defines a configuration value.
 	DeletingStatus = inv_status.New("Deleting", statusv1.StatusIndication_STATUS_INDICATION_IN_PROGRESS)
@@ -107,6 +133,14 @@ func HostRegistrationUUIDFailedWithDetails(detail string) inv_status.ResourceSta
 	)
 }
 
+// HostRegistrationIdentifierFailedWithDetails performs operations for onboarding management.
+func HostRegistrationIdentifierFailedWithDetails(identifier, detail string) inv_status.ResourceStatus {
+	return inv_status.New(
+		fmt.Sprintf("Host Registration Failed due to mismatch of %s, Reported %s is: %s", identifier, identifier, detail),
+		statusv1.StatusIndication_STATUS_INDICATION_ERROR,
+	)
+}
+
 // HostRegistrationSerialNumFailedWithDetails performs operations for onboarding management.
 func HostRegistrationSerialNumFailedWithDetails(detail string) inv_status.ResourceStatus {
 	return inv_status.New(
@@ -114,3 +148,58 @@ func HostRegistrationSerialNumFailedWithDetails(detail string) inv_status.Resour
 		statusv1.StatusIndication_STATUS_INDICATION_ERROR,
 	)
 }
+
+// ProvisioningStatuses returns the in progress, done and failed provisioning statuses reported along a provisioning
+// workflow. A positive reprovisionAttempt returns the statuses of that reprovisioning attempt instead.
+func ProvisioningStatuses(reprovisionAttempt int) (inProgress, done, failed inv_status.ResourceStatus) {
+	if reprovisionAttempt <= 0 {
+		return ProvisioningStatusInProgress, ProvisioningStatusDone, ProvisioningStatusFailed
+	}
+	return withReprovisionAttempt(ReprovisioningStatusInProgress, reprovisionAttempt),
+		withReprovisionAttempt(ReprovisioningStatusDone, reprovisionAttempt),
+		withReprovisionAttempt(ReprovisioningStatusFailed, reprovisionAttempt)
+}
+
+// FirstBootStatus returns the provisioning status reported while the first boot of the installed OS is verified.
+// A positive reprovisionAttempt returns the status of that reprovisioning attempt instead.
+func FirstBootStatus(reprovisionAttempt int) inv_status.ResourceStatus {
+	if reprovisionAttempt <= 0 {
+		return ProvisioningStatusVerifyingFirstBoot
+	}
+	return withReprovisionAttempt(ProvisioningStatusVerifyingFirstBoot, reprovisionAttempt)
+}
+
+// IsVerifyingFirstBoot returns true if the provisioning status is a FirstBootStatus.
+func IsVerifyingFirstBoot(provisioningStatus string) bool {
+	return strings.HasPrefix(provisioningStatus, ProvisioningStatusVerifyingFirstBoot.Status)
+}
+
+// QueuedStatus returns the provisioning status reported while the provisioning of an Instance waits for admission
+// at position in the provisioning queue, e.g. "Queued for provisioning (position 3)".
+// A positive reprovisionAttempt returns the status of that reprovisioning attempt instead.
+func QueuedStatus(position, reprovisionAttempt int) inv_status.ResourceStatus {
+	status := ProvisioningStatusQueued
+	if reprovisionAttempt > 0 {
+		status = withReprovisionAttempt(ReprovisioningStatusQueued, reprovisionAttempt)
+	}
+	return inv_status.New(fmt.Sprintf("%s (position %d)", status.Status, position), status.StatusIndicator)
+}
+
+// MaintenanceWindowStatus returns the provisioning status reported while the provisioning of an Instance waits
+// for a maintenance window opening at next, e.g. "Waiting for maintenance window (opens 2025-06-02 22:00 CEST)".
+// A positive reprovisionAttempt returns the status of that reprovisioning attempt instead.
+func MaintenanceWindowStatus(next time.Time, reprovisionAttempt int) inv_status.ResourceStatus {
+	status := ProvisioningStatusWaitingForMaintenanceWindow
+	if reprovisionAttempt > 0 {
+		status = withReprovisionAttempt(status, reprovisionAttempt)
+	}
+	if next.IsZero() {
+		return status
+	}
+	return inv_status.New(fmt.Sprintf("%s (opens %s)", status.Status, next.Format("2006-01-02 15:04 MST")),
+		status.StatusIndicator)
+}
+
+func withReprovisionAttempt(status inv_status.ResourceStatus, attempt int) inv_status.ResourceStatus {
+	return inv_status.New(fmt.Sprintf("%s (attempt %d)", status.Status, attempt), status.StatusIndicator)
+}
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// heartbeatMessagePrefix prefixes the heartbeat of the worker on a separate line of the message of the running
+	// action, e.g. `heartbeat: {"seq":12,"action":"stream-os-image","memAvailable":...}`.
+	heartbeatMessagePrefix = "heartbeat: "
+
+	procMeminfo   = "/proc/meminfo"
+	procDiskstats = "/proc/diskstats"
+	sysBlock      = "/sys/block"
+
+	// diskSectorSize is the unit of the sectors counted by /proc/diskstats, whatever the sector size of the disk.
+	diskSectorSize = 512
+)
+
+// heartbeatSeq is the sequence number of the last heartbeat of the worker.
+var heartbeatSeq atomic.Int64
+
+// heartbeat tells the onboarding manager that the worker is alive while an action runs, with a snapshot of the resources of
+// the host. Seq increases with every heartbeat of the worker, for clients to tell a new heartbeat from the last
+// one they saw.
+type heartbeat struct {
+	Seq                     int64  `json:"seq"`
+	Action                  string `json:"action"`
+	MemTotal                int64  `json:"memTotal,omitempty"`
+	MemAvailable            int64  `json:"memAvailable,omitempty"`
+	DiskReadBytesPerSecond  int64  `json:"diskReadBytesPerSecond"`
+	DiskWriteBytesPerSecond int64  `json:"diskWriteBytesPerSecond"`
+}
+
+// diskSample is the number of sectors read from and written to the disks of the host at a point in time.
+type diskSample struct {
+	at            time.Time
+	read, written int64
+	ok            bool
+}
+
+// heartbeatLine returns the heartbeat line of the running action, and the disk sample the next heartbeat computes
+// the disk throughput from. The resources that cannot be read are left out.
+func heartbeatLine(actionName string, last diskSample) (string, diskSample) {
+	hb := heartbeat{Seq: heartbeatSeq.Add(1), Action: actionName}
+	hb.MemTotal, hb.MemAvailable, _ = readMemory(procMeminfo)
+
+	sample := diskSample{at: time.Now()}
+	var err error
+	sample.read, sample.written, err = readDiskSectors(procDiskstats, sysBlock)
+	sample.ok = err == nil
+	if sample.ok && last.ok {
+		if elapsed := sample.at.Sub(last.at).Seconds(); elapsed > 0 {
+			hb.DiskReadBytesPerSecond = int64(float64((sample.read-last.read)*diskSectorSize) / elapsed)
+			hb.DiskWriteBytesPerSecond = int64(float64((sample.written-last.written)*diskSectorSize) / elapsed)
+		}
+	}
+
+	b, _ := json.Marshal(hb)
+	return heartbeatMessagePrefix + string(b), sample
+}
+
+// readMemory returns the total and the available memory of the host in bytes, as reported by /proc/meminfo.
+func readMemory(path string) (int64, int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	var total, available int64
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		// e.g. "MemAvailable:   15000000 kB"
+		fields := strings.Fields(s.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		kb, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "MemTotal:":
+			total = kb * 1024
+		case "MemAvailable:":
+			available = kb * 1024
+		}
+	}
+	return total, available, s.Err()
+}
+
+// readDiskSectors returns the sectors read from and written to the disks of the host, as reported by
+// /proc/diskstats. Partitions are left out as they are counted by their disk, and so are virtual block devices
+// which are counted by the disks they are backed by.
+func readDiskSectors(diskstats, sysBlockDir string) (int64, int64, error) {
+	data, err := os.ReadFile(diskstats)
+	if err != nil {
+		return 0, 0, err
+	}
+	var read, written int64
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		// major minor name reads merged sectors-read ms writes merged sectors-written ...
+		fields := strings.Fields(s.Text())
+		if len(fields) < 10 || !isPhysicalDisk(fields[2], sysBlockDir) {
+			continue
+		}
+		r, err := strconv.ParseInt(fields[5], 10, 64)
+		if err != nil {
+			continue
+		}
+		wr, err := strconv.ParseInt(fields[9], 10, 64)
+		if err != nil {
+			continue
+		}
+		read += r
+		written += wr
+	}
+	return read, written, s.Err()
+}
+
+// isPhysicalDisk tells whether the block device is a disk rather than a partition or a virtual device.
+func isPhysicalDisk(name, sysBlockDir string) bool {
+	for _, prefix := range []string{"loop", "ram", "zram", "dm-", "md", "nbd"} {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+	// only disks are listed in /sys/block, partitions are listed in the directory of their disk
+	_, err := os.Stat(filepath.Join(sysBlockDir, name))
+	return err == nil
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/tinkerbell/tink/internal/proto"
+	"github.com/tinkerbell/tink/internal/proto/onboardingmgr/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultOnboardingGRPCAuthority is the proxy of the Hook OS to the onboarding manager, authenticating the
+	// requests with the credentials of the host.
+	DefaultOnboardingGRPCAuthority = "127.0.0.1:42114"
+
+	errReportActionMessage = "failed to report action message"
+
+	// productUUIDPath holds the UUID of the host.
+	productUUIDPath = "/sys/class/dmi/id/product_uuid"
+)
+
+// WithOnboardingClient reports the messages of the actions to the onboarding manager, for the host of UUID
+// hostUUID. The Tinkerbell server does not keep the messages reported with the status of the actions, the
+// onboarding manager keeps them in the status of the workflow. Without it, the messages are only reported to the
+// Tinkerbell server along with the final status of the actions, and the messages of the running actions, e.g.
+// their progress, are not reported.
+func WithOnboardingClient(client onboardingmgr.InteractiveOnboardingServiceClient, hostUUID string) Option {
+	return func(w *Worker) {
+		w.onboardingClient = client
+		w.hostUUID = hostUUID
+	}
+}
+
+// HostUUID returns the UUID of the host, as the onboarding manager knows it.
+func HostUUID() (string, error) {
+	uuid, err := os.ReadFile(productUUIDPath)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(strings.TrimSpace(string(uuid))), nil
+}
+
+// reportActionMessage reports the message of the running action to the onboarding manager, before its final
+// status is reported to the Tinkerbell server: the onboarding manager only sets the message of running actions.
+// Unlike the status of the action, the message is not reported forever: the onboarding manager may be unavailable
+// for longer than the worker can wait, and only the clients of the onboarding manager miss it.
+func (w *Worker) reportActionMessage(ctx context.Context, l logr.Logger, wfID string, action *proto.WorkflowAction,
+	message string,
+) {
+	for attempt := 0; ; attempt++ {
+		err := w.sendActionMessage(ctx, wfID, action, message)
+		if err == nil {
+			return
+		}
+		switch status.Code(err) {
+		case codes.Unavailable, codes.DeadlineExceeded:
+		default:
+			l.Error(err, errReportActionMessage)
+			return
+		}
+		if attempt >= w.retries {
+			l.Error(err, errReportActionMessage, "attempts", attempt+1)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.retryInterval):
+		}
+	}
+}
+
+// sendActionMessage reports the message of the running action to the onboarding manager once.
+func (w *Worker) sendActionMessage(ctx context.Context, wfID string, action *proto.WorkflowAction, message string) error {
+	if w.onboardingClient == nil {
+		return nil
+	}
+	_, err := w.onboardingClient.ReportActionMessage(ctx, &onboardingmgr.ReportActionMessageRequest{
+		Uuid:       w.hostUUID,
+		WorkflowId: wfID,
+		TaskName:   action.GetTaskName(),
+		ActionName: action.GetName(),
+		Message:    message,
+	})
+	return err
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/tinkerbell/tink/internal/proto"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+const (
+	// outputsFileName is the file, in the workflow directory mounted at /workflow, to which actions publish their
+	// outputs as KEY=value lines, e.g. the disk they installed the OS on.
+	outputsFileName = "outputs.env"
+	// workflowOutputsFileName is the file, in the workflow directory, holding the outputs of the successful
+	// actions of the workflow run by this worker, as a JSON object.
+	workflowOutputsFileName = "outputs.json"
+	// outputsMessagePrefix prefixes the outputs in the message of a successful action.
+	outputsMessagePrefix = "outputs: "
+	// maxActionOutputsLength bounds the outputs file of an action.
+	maxActionOutputsLength = 4 * 1024
+)
+
+var outputKeyPattern = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+// actionOutputs returns the outputs published by the action, nil if it published none.
+func (w *Worker) actionOutputs(wfID string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(w.dataDir, wfID, outputsFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxActionOutputsLength {
+		return nil, fmt.Errorf("outputs of %d bytes, at most %d are allowed", len(data), maxActionOutputsLength)
+	}
+	return parseOutputs(data)
+}
+
+// parseOutputs parses KEY=value lines. Empty lines and lines starting with # are ignored, and the last value of
+// a key wins.
+func parseOutputs(data []byte) (map[string]string, error) {
+	outputs := map[string]string{}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; s.Scan(); n++ {
+		line := s.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || !outputKeyPattern.MatchString(key) || strings.ContainsAny(value, "\r\x00") {
+			return nil, fmt.Errorf("line %d: invalid output %q", n, line)
+		}
+		outputs[key] = value
+	}
+	return outputs, s.Err()
+}
+
+// workflowOutputs returns the outputs of the actions of the workflow that succeeded so far, nil if there is none.
+func (w *Worker) workflowOutputs(wfID string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(w.dataDir, wfID, workflowOutputsFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var outputs map[string]string
+	if err := json.Unmarshal(data, &outputs); err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+// collectOutputs merges the outputs published by the successful action into the outputs of the workflow. The
+// outputs of the workflow are replaced atomically, so that they survive a restart of the worker.
+func (w *Worker) collectOutputs(wfID string) error {
+	outputs, err := w.actionOutputs(wfID)
+	if err != nil || len(outputs) == 0 {
+		return err
+	}
+	merged, err := w.workflowOutputs(wfID)
+	if err != nil {
+		return err
+	}
+	if merged == nil {
+		merged = map[string]string{}
+	}
+	maps.Copy(merged, outputs)
+	b, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(w.dataDir, wfID, workflowOutputsFileName)
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// withOutputs returns the action with the outputs of the workflow prepended to its environment. The environment
+// of the action wins over the outputs: they are only passed if the action does not set them.
+func withOutputs(action *proto.WorkflowAction, outputs map[string]string) *proto.WorkflowAction {
+	if len(outputs) == 0 {
+		return action
+	}
+	set := map[string]bool{}
+	for _, kv := range action.GetEnvironment() {
+		name, _, _ := strings.Cut(kv, "=")
+		set[name] = true
+	}
+	var env []string
+	for _, key := range slices.Sorted(maps.Keys(outputs)) {
+		if !set[key] {
+			env = append(env, key+"="+outputs[key])
+		}
+	}
+
+	action, _ = protobuf.Clone(action).(*proto.WorkflowAction)
+	action.Environment = append(env, action.GetEnvironment()...)
+	return action
+}
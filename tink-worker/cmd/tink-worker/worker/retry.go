@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tinkerbell/tink/internal/proto"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+const (
+	// envRetryMaxAttempts is the environment variable of an action setting how many times it is run before
+	// failing, 1 by default.
+	envRetryMaxAttempts = "ACTION_RETRY_MAX_ATTEMPTS"
+	// envRetryBackoff is the environment variable of an action setting the delay before its second attempt, as a
+	// duration. The delay doubles after each attempt, up to maxActionRetryBackoff.
+	envRetryBackoff = "ACTION_RETRY_BACKOFF"
+	// envRetryExitCodes is the environment variable of an action listing, separated by commas, the exit statuses
+	// it is retried on. It is retried on any non-zero exit status by default.
+	envRetryExitCodes = "ACTION_RETRY_EXIT_CODES"
+
+	defaultActionRetryBackoff = 10 * time.Second
+	maxActionRetryBackoff     = 5 * time.Minute
+	// maxActionAttempts bounds the attempts of an action, its timeout applies to all of them.
+	maxActionAttempts = 10
+
+	// attemptMessagePrefix prefixes the attempt number of an action retried after a failure, "attempt: 2/3", in
+	// the message of the running action and on a separate line of the message of the successful action.
+	attemptMessagePrefix = "attempt: "
+)
+
+// attemptContextKey is the key of the attempt message of the running action in its context.
+type attemptContextKey struct{}
+
+// retryPolicy tells whether and when a failed action is run again. Actions fail the workflow on their first
+// failure unless their environment sets a policy. Timeouts are never retried.
+type retryPolicy struct {
+	maxAttempts int
+	backoff     time.Duration
+	// exitCodes are the retried exit statuses, any non-zero one if empty.
+	exitCodes []int64
+}
+
+// actionRetryPolicy returns the retry policy set by the environment of the action.
+func actionRetryPolicy(action *proto.WorkflowAction) (retryPolicy, error) {
+	policy := retryPolicy{maxAttempts: 1, backoff: defaultActionRetryBackoff}
+	for _, kv := range action.GetEnvironment() {
+		name, value, _ := strings.Cut(kv, "=")
+		switch name {
+		case envRetryMaxAttempts:
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > maxActionAttempts {
+				return retryPolicy{}, fmt.Errorf("invalid %s %q: from 1 to %d attempts are allowed",
+					envRetryMaxAttempts, value, maxActionAttempts)
+			}
+			policy.maxAttempts = n
+		case envRetryBackoff:
+			d, err := time.ParseDuration(value)
+			if err != nil || d < 0 {
+				return retryPolicy{}, fmt.Errorf("invalid %s %q: a non-negative duration is required", envRetryBackoff, value)
+			}
+			policy.backoff = d
+		case envRetryExitCodes:
+			policy.exitCodes = nil
+			for _, field := range strings.Split(value, ",") {
+				code, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+				if err != nil || code < 1 || code > 255 {
+					return retryPolicy{}, fmt.Errorf("invalid %s %q: non-zero exit statuses are required",
+						envRetryExitCodes, value)
+				}
+				policy.exitCodes = append(policy.exitCodes, code)
+			}
+		}
+	}
+	return policy, nil
+}
+
+// retries tells whether an action whose attempt ended with st and err is run again.
+func (p retryPolicy) retries(attempt int, st proto.State, err error) bool {
+	var exitErr *ExitError
+	if attempt >= p.maxAttempts || st != proto.State_STATE_FAILED || !errors.As(err, &exitErr) {
+		return false
+	}
+	return len(p.exitCodes) == 0 || slices.Contains(p.exitCodes, exitErr.ExitCode)
+}
+
+// delay returns the delay after the failure of the attempt.
+func (p retryPolicy) delay(attempt int) time.Duration {
+	d := p.backoff
+	for i := 1; i < attempt && d < maxActionRetryBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxActionRetryBackoff)
+}
+
+// executeWithRetries executes the action, and executes it again after a failure as long as its retry policy
+// allows it. The action is retried by the worker alone: it is reported running to the Tinkerbell server once, whose
+// controller times it out once its timeout elapsed since then, so its attempts share its timeout and an attempt is
+// only made if it can start before the action times out. The attempts are reported in the message of the running
+// action. It returns the state and the error of the last attempt, and its attempt message if the action was
+// retried, e.g. "attempt: 2/3".
+func (w *Worker) executeWithRetries(ctx context.Context, wfID string, action *proto.WorkflowAction) (proto.State, string, error) {
+	l := w.getLogger(ctx)
+	policy, err := actionRetryPolicy(action)
+	if err != nil {
+		return proto.State_STATE_FAILED, "", err
+	}
+	var deadline time.Time
+	if action.GetTimeout() > 0 {
+		deadline = time.Now().Add(time.Duration(action.GetTimeout()) * time.Second)
+	}
+
+	attemptCtx, attemptAction := ctx, action
+	for attempt := 1; ; attempt++ {
+		st, err := w.execute(attemptCtx, wfID, attemptAction)
+		retry := policy.retries(attempt, st, err)
+		delay := policy.delay(attempt)
+		if retry && !deadline.IsZero() && time.Until(deadline)-delay < time.Second {
+			l.Info("not retrying failed action, it would time out", "attempt", attempt+1,
+				"maxAttempts", policy.maxAttempts, "delay", delay.String(), "error", err.Error())
+			retry = false
+		}
+		if !retry {
+			if attempt == 1 {
+				return st, "", err
+			}
+			return st, attemptMessage(attempt, policy.maxAttempts), err
+		}
+
+		l.Info("retrying failed action", "attempt", attempt+1, "maxAttempts", policy.maxAttempts,
+			"delay", delay.String(), "error", err.Error())
+		next := attemptMessage(attempt+1, policy.maxAttempts)
+		w.reportActionMessage(ctx, l, wfID, action, next)
+		// the next attempt is reported along with the progress and the heartbeats, which go on during the delay
+		attemptCtx = context.WithValue(ctx, attemptContextKey{}, next)
+		stopReporting := w.reportRunning(attemptCtx, wfID, action, "")
+		select {
+		case <-ctx.Done():
+			stopReporting()
+			return st, attemptMessage(attempt, policy.maxAttempts), err
+		case <-time.After(delay):
+		}
+		stopReporting()
+		if !deadline.IsZero() {
+			// the attempt times out with the action
+			attemptAction, _ = protobuf.Clone(action).(*proto.WorkflowAction)
+			attemptAction.Timeout = max(int64(time.Until(deadline).Seconds()), 1)
+		}
+	}
+}
+
+// attemptFromContext returns the attempt message of the running action, empty for its first attempt.
+func attemptFromContext(ctx context.Context) string {
+	attempt, _ := ctx.Value(attemptContextKey{}).(string)
+	return attempt
+}
+
+func attemptMessage(attempt, maxAttempts int) string {
+	return fmt.Sprintf("%s%d/%d", attemptMessagePrefix, attempt, maxAttempts)
+}
@@ -0,0 +1,727 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/tinkerbell/tink/internal/proto"
+	"github.com/tinkerbell/tink/internal/proto/onboardingmgr/v1"
+)
+
+const (
+	defaultDataDir = "/worker"
+
+	// Default worker configuration values.
+	DefaultRetryIntervalSeconds          = 3
+	DefaultRetryCount                    = 3
+	DefaultMaxFileSize                   = 10 * 1024 * 1024 // 10MB
+	DefaultTimeoutMinutes                = 60
+	DefaultPullImageRetryIntervalSeconds = 5
+	DefaultPullImageRetryCount           = 5
+	DefaultPullImageMaxBackoffSeconds    = 60
+	DefaultProgressIntervalSeconds       = 5
+	DefaultHeartbeatIntervalSeconds      = 30
+
+	errGetWfContext       = "failed to get workflow context"
+	errGetWfActions       = "failed to get actions for workflow"
+	errReportActionStatus = "failed to report action status"
+
+	msgTurn = "it's turn for a different worker: %s"
+
+	// maxActionMessageLength bounds the message reported for a failed action.
+	maxActionMessageLength = 256
+
+	// progressFileName is the file, in the workflow directory mounted at /workflow, to which actions publish their
+	// progress as a JSON document.
+	progressFileName = "progress.json"
+	// progressMessagePrefix prefixes the progress in the message of a running action.
+	progressMessagePrefix = "progress: "
+
+	// reportFileName is the file, in the workflow directory mounted at /workflow, to which actions publish their
+	// report as a JSON document, e.g. the per-disk report of a disk sanitization.
+	reportFileName = "report.json"
+	// reportMessagePrefix prefixes the report in the message of a finished action, successful or not.
+	reportMessagePrefix = "report: "
+	// maxActionReportLength bounds the report of an action. Unlike failure messages, reports are not truncated,
+	// they are dropped.
+	maxActionReportLength = 16 * 1024
+	// successMessage is the message of a successful action without report nor outputs.
+	successMessage = "finished execution successfully"
+)
+
+type loggingContext string
+
+var loggingContextKey loggingContext = "logger"
+
+// WorkflowMetadata is the metadata related to workflow data.
+type WorkflowMetadata struct {
+	WorkerID  string    `json:"workerID"`
+	Action    string    `json:"actionName"`
+	Task      string    `json:"taskName"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	SHA       string    `json:"sha256"`
+}
+
+// Option is a type for modifying a worker.
+type Option func(*Worker)
+
+// WithRetries adds custom retries to a worker.
+func WithRetries(interval time.Duration, retries int) Option {
+	return func(w *Worker) {
+		w.retries = retries
+		w.retryInterval = interval
+	}
+}
+
+// WithPullImageRetries configures retry parameters specifically for image pulls.
+// interval is the initial backoff duration, retries is the max number of retries,
+// and maxBackoff caps the exponential backoff growth.
+func WithPullImageRetries(interval time.Duration, retries int, maxBackoff time.Duration) Option {
+	return func(w *Worker) {
+		w.pullImageRetries = retries
+		w.pullImageRetryInterval = interval
+		w.pullImageMaxBackoff = maxBackoff
+	}
+}
+
+// WithProgressInterval changes the interval at which the progress published by actions is reported to the
+// onboarding manager. A zero interval disables the reporting.
+func WithProgressInterval(interval time.Duration) Option {
+	return func(w *Worker) {
+		w.progressInterval = interval
+	}
+}
+
+// WithHeartbeatInterval changes the interval at which the worker reports a heartbeat to the onboarding manager
+// while an action runs. A zero interval disables the heartbeats.
+func WithHeartbeatInterval(interval time.Duration) Option {
+	return func(w *Worker) {
+		w.heartbeatInterval = interval
+	}
+}
+
+// WithDataDir changes the default directory for a worker.
+func WithDataDir(dir string) Option {
+	return func(w *Worker) {
+		w.dataDir = dir
+	}
+}
+
+// WithMaxFileSize changes the max file size for a worker.
+func WithMaxFileSize(maxSize int64) Option {
+	return func(w *Worker) {
+		w.maxSize = maxSize
+	}
+}
+
+// WithLogCapture enables capture of container logs.
+func WithLogCapture(capture bool) Option {
+	return func(w *Worker) {
+		w.captureLogs = capture
+	}
+}
+
+// WithPrivileged enables containers to be privileged.
+func WithPrivileged(privileged bool) Option {
+	return func(w *Worker) {
+		w.createPrivileged = privileged
+	}
+}
+
+// LogCapturer emits container logs.
+type LogCapturer interface {
+	CaptureLogs(ctx context.Context, containerID string)
+}
+
+// ExitError is returned with a failed state by ContainerManager.WaitForContainer when the container of an action
+// exits with a non-zero status.
+type ExitError struct {
+	ExitCode int64
+}
+
+// Error returns the exit status, as reported in the message of the failed action. Actions report the error code
+// of their failure through their exit status, the message preserves it for the onboarding manager, see
+// WithOnboardingClient.
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.ExitCode)
+}
+
+// ContainerManager manages linux containers for Tinkerbell workers.
+type ContainerManager interface {
+	CreateContainer(ctx context.Context, cmd []string, wfID string, action *proto.WorkflowAction, captureLogs, privileged bool) (string, error)
+	StartContainer(ctx context.Context, id string) error
+	WaitForContainer(ctx context.Context, id string) (proto.State, error)
+	WaitForFailedContainer(ctx context.Context, id string, failedActionStatus chan proto.State)
+	RemoveContainer(ctx context.Context, id string) error
+	PullImage(ctx context.Context, image string) error
+}
+
+// Worker details provide all the context needed to run workflows.
+type Worker struct {
+	workerID         string
+	logCapturer      LogCapturer
+	containerManager ContainerManager
+	tinkClient       proto.WorkflowServiceClient
+	logger           logr.Logger
+
+	onboardingClient onboardingmgr.InteractiveOnboardingServiceClient
+	hostUUID         string
+
+	dataDir string
+	maxSize int64
+
+	createPrivileged bool
+	captureLogs      bool
+
+	retries       int
+	retryInterval time.Duration
+
+	pullImageRetries       int
+	pullImageRetryInterval time.Duration
+	pullImageMaxBackoff    time.Duration
+
+	progressInterval time.Duration
+
+	heartbeatInterval time.Duration
+}
+
+// NewWorker creates a new Worker, creating a new Docker registry client.
+func NewWorker(
+	workerID string,
+	tinkClient proto.WorkflowServiceClient,
+	containerManager ContainerManager,
+	logCapturer LogCapturer,
+	logger logr.Logger,
+	opts ...Option,
+) *Worker {
+	w := &Worker{
+		workerID:               workerID,
+		dataDir:                defaultDataDir,
+		containerManager:       containerManager,
+		logCapturer:            logCapturer,
+		tinkClient:             tinkClient,
+		logger:                 logger,
+		captureLogs:            false,
+		createPrivileged:       false,
+		retries:                DefaultRetryCount,
+		retryInterval:          time.Second * DefaultRetryIntervalSeconds,
+		pullImageRetries:       DefaultPullImageRetryCount,
+		pullImageRetryInterval: time.Second * DefaultPullImageRetryIntervalSeconds,
+		pullImageMaxBackoff:    time.Second * DefaultPullImageMaxBackoffSeconds,
+		maxSize:                DefaultMaxFileSize,
+		progressInterval:       time.Second * DefaultProgressIntervalSeconds,
+		heartbeatInterval:      time.Second * DefaultHeartbeatIntervalSeconds,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// getLogger is a helper function to get logging out of a context, or use the default logger.
+func (w Worker) getLogger(ctx context.Context) logr.Logger {
+	loggerIface := ctx.Value(loggingContextKey)
+	if loggerIface == nil {
+		return w.logger
+	}
+	l, _ := loggerIface.(logr.Logger)
+	return l
+}
+
+// execute executes a workflow action, optionally capturing logs.
+func (w *Worker) execute(ctx context.Context, wfID string, action *proto.WorkflowAction) (proto.State, error) {
+	l := w.getLogger(ctx).WithValues("workflowID", wfID, "workerID", action.GetWorkerId(), "actionName", action.GetName(), "actionImage", action.GetImage())
+
+	// the progress, the report and the outputs of a previous action must not be reported for this one
+	progressFile := filepath.Join(w.dataDir, wfID, progressFileName)
+	for _, file := range []string{
+		progressFile,
+		filepath.Join(w.dataDir, wfID, reportFileName),
+		filepath.Join(w.dataDir, wfID, outputsFileName),
+	} {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			l.Error(err, "remove action file", "path", file)
+		}
+	}
+
+	// the heartbeats also cover the image pull, which may take a while
+	stopReporting := w.reportRunning(ctx, wfID, action, progressFile)
+	defer stopReporting()
+
+	if err := w.pullImageWithRetry(ctx, action.GetImage()); err != nil {
+		return proto.State_STATE_RUNNING, errors.Wrap(err, "pull image")
+	}
+
+	// the outputs of the previous actions are passed to the environment of this one
+	outputs, err := w.workflowOutputs(wfID)
+	if err != nil {
+		return proto.State_STATE_RUNNING, errors.Wrap(err, "read workflow outputs")
+	}
+	action = withOutputs(action, outputs)
+
+	id, err := w.containerManager.CreateContainer(ctx, action.Command, wfID, action, w.captureLogs, w.createPrivileged)
+	if err != nil {
+		return proto.State_STATE_RUNNING, errors.Wrap(err, "create container")
+	}
+
+	l.Info("container created", "containerID", id, "command", action.Command)
+
+	var timeCtx context.Context
+	var cancel context.CancelFunc
+
+	if action.Timeout > 0 {
+		timeCtx, cancel = context.WithTimeout(ctx, time.Duration(action.Timeout)*time.Second)
+	} else {
+		timeCtx, cancel = context.WithTimeout(ctx, 1*time.Hour)
+	}
+	defer cancel()
+
+	err = w.containerManager.StartContainer(timeCtx, id)
+	if err != nil {
+		return proto.State_STATE_RUNNING, errors.Wrap(err, "start container")
+	}
+
+	if w.captureLogs {
+		go w.logCapturer.CaptureLogs(ctx, id)
+	}
+
+	st, err := w.containerManager.WaitForContainer(timeCtx, id)
+	l.Info("wait container completed", "status", st.String())
+
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		l.Info("action container exited with non-zero status", "exitCode", exitErr.ExitCode)
+		err = nil
+	}
+
+	// If we've made it this far, the container has successfully completed.
+	// Everything after this is just cleanup.
+
+	defer func() {
+		if err := w.containerManager.RemoveContainer(ctx, id); err != nil {
+			l.Error(err, "remove container", "containerID", id)
+		}
+		l.Info("container removed", "status", st.String())
+	}()
+
+	if err != nil {
+		return st, errors.Wrap(err, "wait container")
+	}
+
+	if st == proto.State_STATE_SUCCESS {
+		l.Info("action container exited with success", "status", st)
+		if err := w.collectOutputs(wfID); err != nil {
+			return proto.State_STATE_FAILED, errors.Wrap(err, "collect outputs")
+		}
+		return st, nil
+	}
+
+	if st == proto.State_STATE_TIMEOUT && action.OnTimeout != nil {
+		rst := w.executeReaction(ctx, st.String(), action.OnTimeout, wfID, action)
+		l.Info("action timeout", "status", rst)
+	} else if action.OnFailure != nil {
+		rst := w.executeReaction(ctx, st.String(), action.OnFailure, wfID, action)
+		l.Info("action failed", "status", rst)
+	}
+
+	l.Info(infoWaitFinished)
+	if err != nil {
+		l.Error(err, errFailedToWait)
+	}
+
+	l.Info("action container exited", "status", st)
+	if exitErr != nil {
+		return st, exitErr
+	}
+	return st, nil
+}
+
+// reportRunning reports the message of the running action to the onboarding manager until the returned function is
+// called, see WithOnboardingClient. The running status is not reported again to the Tinkerbell server, which would
+// restart the timeout of the action and drop the message anyway. The message holds, on separate lines and as is
+// for its clients to parse them:
+//   - the progress published by the action to progressFile, "progress: <JSON document>", polled every progress
+//     interval and reported when it changed; no progress is reported if progressFile is empty,
+//   - the attempt of an action retried after a failure, "attempt: 2/3",
+//   - the heartbeat of the worker, "heartbeat: <JSON document>", reported at least every heartbeat interval.
+func (w *Worker) reportRunning(ctx context.Context, wfID string, action *proto.WorkflowAction, progressFile string) func() {
+	pollProgress := progressFile != "" && w.progressInterval > 0
+	if w.onboardingClient == nil || (!pollProgress && w.heartbeatInterval <= 0) {
+		return func() {}
+	}
+	interval := w.heartbeatInterval
+	if pollProgress && (interval <= 0 || w.progressInterval < interval) {
+		interval = w.progressInterval
+	}
+	l := w.getLogger(ctx)
+	attempt := attemptFromContext(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var last []byte
+		var progressLine string
+		var disks diskSample
+		lastReport := time.Now()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			changed := false
+			if pollProgress {
+				// the progress is only reported again once it changed
+				if progress, err := os.ReadFile(progressFile); err == nil && !bytes.Equal(progress, last) {
+					last = progress
+					if line, ok := progressMessage(l, progress); ok {
+						progressLine, changed = line, true
+					}
+				}
+			}
+			heartbeatDue := w.heartbeatInterval > 0 && time.Since(lastReport) >= w.heartbeatInterval
+			if !changed && !heartbeatDue {
+				// no progress since the last report, and the last heartbeat is recent enough
+				continue
+			}
+
+			var lines []string
+			if progressLine != "" {
+				lines = append(lines, progressLine)
+			}
+			if attempt != "" {
+				lines = append(lines, attempt)
+			}
+			if w.heartbeatInterval > 0 {
+				var heartbeat string
+				heartbeat, disks = heartbeatLine(action.GetName(), disks)
+				lines = append(lines, heartbeat)
+			}
+			lastReport = time.Now()
+
+			// a single attempt, the next report supersedes this one anyway
+			err := w.sendActionMessage(ctx, wfID, action, strings.Join(lines, "\n"))
+			if err != nil && ctx.Err() == nil {
+				l.Error(err, errReportActionMessage)
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// progressMessage returns the progress line of the progress published by an action, false if it is invalid.
+func progressMessage(l logr.Logger, progress []byte) (string, bool) {
+	var message bytes.Buffer
+	message.WriteString(progressMessagePrefix)
+	if err := json.Compact(&message, progress); err != nil || message.Len() > maxActionMessageLength {
+		l.Info("ignoring invalid progress", "progress", truncateStr(string(progress), maxActionMessageLength))
+		return "", false
+	}
+	return message.String(), true
+}
+
+// pullImageWithRetry attempts to pull an image with exponential backoff.
+// It retries up to w.pullImageRetries times, starting with w.pullImageRetryInterval
+// and doubling the backoff on each attempt, capped at w.pullImageMaxBackoff.
+func (w *Worker) pullImageWithRetry(ctx context.Context, image string) error {
+	l := w.getLogger(ctx)
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = w.pullImageRetryInterval
+	bo.MaxInterval = w.pullImageMaxBackoff
+	bo.Multiplier = 2.0
+
+	//nolint:gosec // pullImageRetries is properly initialized and won't be negative in a way that causes issues here.
+	b := backoff.WithContext(backoff.WithMaxRetries(bo, uint64(w.pullImageRetries)), ctx)
+	attempt := 0
+
+	operation := func() error {
+		attempt++
+		return w.containerManager.PullImage(ctx, image)
+	}
+
+	retryNotifier := func(err error, d time.Duration) {
+		l.Info("retrying image pull", "attempt", attempt, "duration", d.String(), "image", image, "error", err.Error())
+	}
+
+	err := backoff.RetryNotify(operation, b, retryNotifier)
+	if err != nil {
+		return fmt.Errorf("failed to pull image after %d attempts: %w", attempt, err)
+	}
+
+	return nil
+}
+
+// executeReaction executes special case OnTimeout/OnFailure actions.
+func (w *Worker) executeReaction(ctx context.Context, reaction string, cmd []string, wfID string, action *proto.WorkflowAction) proto.State {
+	l := w.getLogger(ctx)
+	id, err := w.containerManager.CreateContainer(ctx, cmd, wfID, action, w.captureLogs, w.createPrivileged)
+	if err != nil {
+		l.Error(err, errFailedToRunCmd)
+	}
+	l.Info("container created", "containerID", id, "actionStatus", reaction, "command", cmd)
+
+	if w.captureLogs {
+		go w.logCapturer.CaptureLogs(ctx, id)
+	}
+
+	st := make(chan proto.State)
+
+	go w.containerManager.WaitForFailedContainer(ctx, id, st)
+	err = w.containerManager.StartContainer(ctx, id)
+	if err != nil {
+		l.Error(err, errFailedToRunCmd)
+	}
+
+	return <-st
+}
+
+// ProcessWorkflowActions gets all Workflow contexts and processes their actions.
+func (w *Worker) ProcessWorkflowActions(ctx context.Context) error {
+	l := w.logger.WithValues("workerID", w.workerID)
+	l.Info("starting to process workflow actions")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+		res, err := w.tinkClient.GetWorkflowContexts(ctx, &proto.WorkflowContextRequest{WorkerId: w.workerID})
+		if err != nil {
+			l.Error(err, errGetWfContext)
+			<-time.After(w.retryInterval)
+			continue
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+			}
+			wfContext, err := res.Recv()
+			if err != nil || wfContext == nil {
+				if !errors.Is(err, io.EOF) {
+					l.Info(err.Error())
+				}
+				<-time.After(w.retryInterval)
+				break
+			}
+			wfID := wfContext.GetWorkflowId()
+			l = l.WithValues("workflowID", wfID)
+			ctx := context.WithValue(ctx, loggingContextKey, l)
+
+			actions, err := w.tinkClient.GetWorkflowActions(ctx, &proto.WorkflowActionsRequest{WorkflowId: wfID})
+			if err != nil {
+				l.Error(err, errGetWfActions)
+				continue
+			}
+
+			turn := false
+			actionIndex := 0
+			var nextAction *proto.WorkflowAction
+			if wfContext.GetCurrentAction() == "" {
+				if actions.GetActionList()[0].GetWorkerId() == w.workerID {
+					actionIndex = 0
+					turn = true
+				}
+			} else {
+				switch wfContext.GetCurrentActionState() {
+				case proto.State_STATE_SUCCESS:
+					if isLastAction(wfContext, actions) {
+						continue
+					}
+					nextAction = actions.GetActionList()[wfContext.GetCurrentActionIndex()+1]
+					actionIndex = int(wfContext.GetCurrentActionIndex()) + 1
+				case proto.State_STATE_FAILED:
+					continue
+				case proto.State_STATE_TIMEOUT:
+					continue
+				default:
+					nextAction = actions.GetActionList()[wfContext.GetCurrentActionIndex()]
+					actionIndex = int(wfContext.GetCurrentActionIndex())
+				}
+				if nextAction.GetWorkerId() == w.workerID {
+					turn = true
+				}
+			}
+
+			for turn {
+				l.Info("starting action")
+				action := actions.GetActionList()[actionIndex]
+				l := l.WithValues(
+					"actionName", action.GetName(),
+					"taskName", action.GetTaskName(),
+				)
+				ctx := context.WithValue(ctx, loggingContextKey, l)
+				if wfContext.GetCurrentActionState() != proto.State_STATE_RUNNING {
+					actionStatus := &proto.WorkflowActionStatus{
+						WorkflowId:   wfID,
+						TaskName:     action.GetTaskName(),
+						ActionName:   action.GetName(),
+						ActionStatus: proto.State_STATE_RUNNING,
+						Seconds:      0,
+						Message:      "Started execution",
+						WorkerId:     action.GetWorkerId(),
+					}
+					w.reportActionStatus(ctx, l, actionStatus)
+					l.Info("sent action status", "status", actionStatus.ActionStatus, "duration", strconv.FormatInt(actionStatus.Seconds, 10))
+				}
+
+				// start executing the action
+				start := time.Now()
+				st, attempt, err := w.executeWithRetries(ctx, wfID, action)
+				elapsed := time.Since(start)
+
+				actionStatus := &proto.WorkflowActionStatus{
+					WorkflowId: wfID,
+					TaskName:   action.GetTaskName(),
+					ActionName: action.GetName(),
+					Seconds:    int64(elapsed.Seconds()),
+					WorkerId:   action.GetWorkerId(),
+				}
+
+				if err != nil || st != proto.State_STATE_SUCCESS {
+					if st == proto.State_STATE_TIMEOUT {
+						actionStatus.ActionStatus = proto.State_STATE_TIMEOUT
+					} else {
+						actionStatus.ActionStatus = proto.State_STATE_FAILED
+					}
+					actionStatus.Message = w.failedActionMessage(l, wfID, st, err)
+					l = l.WithValues("actionStatus", actionStatus.ActionStatus.String())
+					l.Error(err, "execute workflow")
+					w.reportActionMessage(ctx, l, wfID, action, actionStatus.Message)
+					w.reportActionStatus(ctx, l, actionStatus)
+					break
+				}
+
+				actionStatus.ActionStatus = proto.State_STATE_SUCCESS
+				actionStatus.Message = w.actionSuccessMessage(l, wfID)
+				if attempt != "" {
+					// the exit status alone is reported for failures, for clients to parse it, see ExitError
+					actionStatus.Message += "\n" + attempt
+				}
+				w.reportActionMessage(ctx, l, wfID, action, actionStatus.Message)
+				w.reportActionStatus(ctx, l, actionStatus)
+				l.Info("sent action status")
+
+				if len(actions.GetActionList()) == actionIndex+1 {
+					l.Info("reached to end of workflow")
+					break
+				}
+
+				nextAction := actions.GetActionList()[actionIndex+1]
+				if nextAction.GetWorkerId() != w.workerID {
+					l.Info(fmt.Sprintf(msgTurn, nextAction.GetWorkerId()))
+					turn = false
+				} else {
+					actionIndex++
+				}
+			}
+		}
+		// sleep before asking for new workflows
+		<-time.After(w.retryInterval)
+	}
+}
+
+// actionFailureMessage returns the message reported for a failed or timed out action. The exit status of the
+// action container is reported as is, see ExitError.
+func actionFailureMessage(st proto.State, err error) string {
+	switch {
+	case st == proto.State_STATE_TIMEOUT:
+		return "timeout"
+	case err != nil:
+		return truncateStr(err.Error(), maxActionMessageLength)
+	default:
+		return "failed execution"
+	}
+}
+
+// actionSuccessMessage returns the message reported for a successful action: the report it published, as is for
+// its clients to parse it, "report: <JSON document>", and the outputs it published, "outputs: <JSON object>", on
+// separate lines, or a fixed message if it published neither.
+func (w *Worker) actionSuccessMessage(l logr.Logger, wfID string) string {
+	var lines []string
+	if report, ok := w.actionReport(l, wfID); ok {
+		lines = append(lines, reportMessagePrefix+report)
+	}
+	// the outputs were validated when collected
+	if outputs, err := w.actionOutputs(wfID); err == nil && len(outputs) > 0 {
+		b, _ := json.Marshal(outputs)
+		lines = append(lines, outputsMessagePrefix+string(b))
+	}
+	if len(lines) == 0 {
+		return successMessage
+	}
+	return strings.Join(lines, "\n")
+}
+
+// failedActionMessage returns the message reported for a failed or timed out action: its failure message, see
+// actionFailureMessage, followed by the report it published, "report: <JSON document>", on a separate line, e.g.
+// the disks a sanitization could not erase. Clients parse the exit status from the first line.
+func (w *Worker) failedActionMessage(l logr.Logger, wfID string, st proto.State, err error) string {
+	message := actionFailureMessage(st, err)
+	if report, ok := w.actionReport(l, wfID); ok {
+		message += "\n" + reportMessagePrefix + report
+	}
+	return message
+}
+
+// actionReport returns the compacted report published by the action, if any and not longer than
+// maxActionReportLength.
+func (w *Worker) actionReport(l logr.Logger, wfID string) (string, bool) {
+	report, err := os.ReadFile(filepath.Join(w.dataDir, wfID, reportFileName))
+	if err != nil {
+		return "", false
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, report); err != nil || len(reportMessagePrefix)+compacted.Len() > maxActionReportLength {
+		l.Info("ignoring invalid report", "report", truncateStr(string(report), maxActionMessageLength))
+		return "", false
+	}
+	return compacted.String(), true
+}
+
+func isLastAction(wfContext *proto.WorkflowContext, actions *proto.WorkflowActionList) bool {
+	return int(wfContext.GetCurrentActionIndex()) == len(actions.GetActionList())-1
+}
+
+// reportActionStatus reports the status of an action to the Tinkerbell server and retries forever on error.
+func (w *Worker) reportActionStatus(ctx context.Context, l logr.Logger, actionStatus *proto.WorkflowActionStatus) {
+	for {
+		l.Info("reporting Action Status")
+		_, err := w.tinkClient.ReportActionStatus(ctx, actionStatus)
+		if err != nil {
+			l.Error(err, errReportActionStatus)
+			<-time.After(w.retryInterval)
+
+			continue
+		}
+		return
+	}
+}
@@ -0,0 +1,777 @@
+// SPDX-FileCopyrightText: 2025 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/tinkerbell/tink/internal/proto"
+	"github.com/tinkerbell/tink/internal/proto/onboardingmgr/v1"
+	"google.golang.org/grpc"
+)
+
+// mockContainerManager is a mock implementation of ContainerManager for testing.
+type mockContainerManager struct {
+	pullImageFunc        func(ctx context.Context, image string) error
+	waitForContainerFunc func(ctx context.Context, id string) (proto.State, error)
+	// created records the actions the containers are created for.
+	created []*proto.WorkflowAction
+}
+
+func (m *mockContainerManager) CreateContainer(_ context.Context, _ []string, _ string, action *proto.WorkflowAction, _, _ bool) (string, error) {
+	m.created = append(m.created, action)
+	return "", nil
+}
+
+func (m *mockContainerManager) StartContainer(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockContainerManager) WaitForContainer(ctx context.Context, id string) (proto.State, error) {
+	if m.waitForContainerFunc != nil {
+		return m.waitForContainerFunc(ctx, id)
+	}
+	return proto.State_STATE_SUCCESS, nil
+}
+
+func (m *mockContainerManager) WaitForFailedContainer(_ context.Context, _ string, _ chan proto.State) {
+}
+
+func (m *mockContainerManager) RemoveContainer(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockContainerManager) PullImage(ctx context.Context, image string) error {
+	if m.pullImageFunc != nil {
+		return m.pullImageFunc(ctx, image)
+	}
+	return nil
+}
+
+func TestPullImageWithRetry_SuccessOnFirstAttempt(t *testing.T) {
+	var callCount int32
+	w := &Worker{
+		containerManager: &mockContainerManager{
+			pullImageFunc: func(_ context.Context, _ string) error {
+				atomic.AddInt32(&callCount, 1)
+				return nil
+			},
+		},
+		pullImageRetries:       3,
+		pullImageRetryInterval: 10 * time.Millisecond,
+		pullImageMaxBackoff:    60 * time.Second,
+	}
+
+	err := w.pullImageWithRetry(context.Background(), "test-image:latest")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if got := atomic.LoadInt32(&callCount); got != 1 {
+		t.Fatalf("expected PullImage to be called 1 time, got %d", got)
+	}
+}
+
+func TestPullImageWithRetry_SuccessAfterRetries(t *testing.T) {
+	var callCount int32
+	failTimes := int32(2) // fail the first 2 attempts, succeed on the 3rd
+
+	w := &Worker{
+		containerManager: &mockContainerManager{
+			pullImageFunc: func(_ context.Context, _ string) error {
+				n := atomic.AddInt32(&callCount, 1)
+				if n <= failTimes {
+					return fmt.Errorf("transient error attempt %d", n)
+				}
+				return nil
+			},
+		},
+		pullImageRetries:       3,
+		pullImageRetryInterval: 10 * time.Millisecond,
+		pullImageMaxBackoff:    60 * time.Second,
+	}
+
+	err := w.pullImageWithRetry(context.Background(), "test-image:latest")
+	if err != nil {
+		t.Fatalf("expected no error after retries, got: %v", err)
+	}
+	if got := atomic.LoadInt32(&callCount); got != 3 {
+		t.Fatalf("expected PullImage to be called 3 times, got %d", got)
+	}
+}
+
+func TestPullImageWithRetry_AllAttemptsFail(t *testing.T) {
+	var callCount int32
+	maxRetries := 3
+
+	w := &Worker{
+		containerManager: &mockContainerManager{
+			pullImageFunc: func(_ context.Context, _ string) error {
+				atomic.AddInt32(&callCount, 1)
+				return fmt.Errorf("persistent error")
+			},
+		},
+		pullImageRetries:       maxRetries,
+		pullImageRetryInterval: 10 * time.Millisecond,
+		pullImageMaxBackoff:    60 * time.Second,
+	}
+
+	err := w.pullImageWithRetry(context.Background(), "test-image:latest")
+	if err == nil {
+		t.Fatal("expected error after all retries exhausted, got nil")
+	}
+
+	expectedCalls := int32(maxRetries) + 1 // 1 initial + 3 retries
+	if got := atomic.LoadInt32(&callCount); got != expectedCalls {
+		t.Fatalf("expected PullImage to be called %d times, got %d", expectedCalls, got)
+	}
+
+	expectedMsg := fmt.Sprintf("failed to pull image after %d attempts", maxRetries+1)
+	if err.Error()[:len(expectedMsg)] != expectedMsg {
+		t.Fatalf("expected error message to start with %q, got: %v", expectedMsg, err)
+	}
+}
+
+func TestPullImageWithRetry_ContextCancelled(t *testing.T) {
+	var callCount int32
+
+	w := &Worker{
+		containerManager: &mockContainerManager{
+			pullImageFunc: func(_ context.Context, _ string) error {
+				atomic.AddInt32(&callCount, 1)
+				return fmt.Errorf("transient error")
+			},
+		},
+		pullImageRetries:       5,
+		pullImageRetryInterval: 100 * time.Millisecond,
+		pullImageMaxBackoff:    60 * time.Second,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	// Cancel after a short delay so the first attempt fails but the retry backoff gets canceled
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	err := w.pullImageWithRetry(ctx, "test-image:latest")
+	if err == nil {
+		t.Fatal("expected error when context is canceled, got nil")
+	}
+
+	// Should have made at least 1 call (the initial attempt) but not all 6
+	got := atomic.LoadInt32(&callCount)
+	if got < 1 {
+		t.Fatalf("expected at least 1 call, got %d", got)
+	}
+	if got >= 6 {
+		t.Fatalf("expected fewer than 6 calls due to cancellation, got %d", got)
+	}
+}
+
+func TestPullImageWithRetry_ExponentialBackoff(t *testing.T) {
+	var timestamps []time.Time
+	var callCount int32
+	failTimes := int32(3)
+
+	w := &Worker{
+		containerManager: &mockContainerManager{
+			pullImageFunc: func(_ context.Context, _ string) error {
+				atomic.AddInt32(&callCount, 1)
+				timestamps = append(timestamps, time.Now())
+				n := atomic.LoadInt32(&callCount)
+				if n <= failTimes {
+					return fmt.Errorf("transient error")
+				}
+				return nil
+			},
+		},
+		pullImageRetries:       4,
+		pullImageRetryInterval: 50 * time.Millisecond,
+		pullImageMaxBackoff:    60 * time.Second,
+	}
+
+	err := w.pullImageWithRetry(context.Background(), "test-image:latest")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	if len(timestamps) < 4 {
+		t.Fatalf("expected at least 4 timestamps, got %d", len(timestamps))
+	}
+
+	// Verify exponential backoff: each gap should be roughly double the previous.
+	// Gap 0->1: ~50ms, Gap 1->2: ~100ms, Gap 2->3: ~200ms
+	// We use generous tolerances since exponential backoff includes jitter
+	// and CI can be unpredictable.
+	expectedMinBackoffs := []time.Duration{
+		25 * time.Millisecond,  // 1st retry: ~50ms, jitter can bring it down
+		50 * time.Millisecond,  // 2nd retry: ~100ms
+		100 * time.Millisecond, // 3rd retry: ~200ms
+	}
+
+	for i := 0; i < len(timestamps)-1 && i < len(expectedMinBackoffs); i++ {
+		gap := timestamps[i+1].Sub(timestamps[i])
+		if gap < expectedMinBackoffs[i] {
+			t.Errorf("gap between attempt %d and %d was %v, expected at least %v",
+				i+1, i+2, gap, expectedMinBackoffs[i])
+		}
+	}
+}
+
+func TestPullImageWithRetry_ZeroRetries(t *testing.T) {
+	var callCount int32
+
+	w := &Worker{
+		containerManager: &mockContainerManager{
+			pullImageFunc: func(_ context.Context, _ string) error {
+				atomic.AddInt32(&callCount, 1)
+				return fmt.Errorf("error")
+			},
+		},
+		pullImageRetries:       0,
+		pullImageRetryInterval: 10 * time.Millisecond,
+		pullImageMaxBackoff:    60 * time.Second,
+	}
+
+	err := w.pullImageWithRetry(context.Background(), "test-image:latest")
+	if err == nil {
+		t.Fatal("expected error with zero retries, got nil")
+	}
+	if got := atomic.LoadInt32(&callCount); got != 1 {
+		t.Fatalf("expected PullImage to be called 1 time with zero retries, got %d", got)
+	}
+}
+
+func TestExecute_ExitStatus(t *testing.T) {
+	w := &Worker{
+		logger: logr.Discard(),
+		containerManager: &mockContainerManager{
+			waitForContainerFunc: func(_ context.Context, _ string) (proto.State, error) {
+				return proto.State_STATE_FAILED, &ExitError{ExitCode: 85}
+			},
+		},
+	}
+
+	st, err := w.execute(context.Background(), "workflow", &proto.WorkflowAction{Name: "secure-boot-status-flag-read"})
+	if st != proto.State_STATE_FAILED {
+		t.Fatalf("expected state %s, got %s", proto.State_STATE_FAILED, st)
+	}
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode != 85 {
+		t.Fatalf("expected exit status 85, got: %v", err)
+	}
+	if got := actionFailureMessage(st, err); got != "exit status 85" {
+		t.Fatalf("expected message %q, got %q", "exit status 85", got)
+	}
+}
+
+func TestActionFailureMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		st   proto.State
+		err  error
+		want string
+	}{
+		{"exit status", proto.State_STATE_FAILED, &ExitError{ExitCode: 1}, "exit status 1"},
+		{"timeout", proto.State_STATE_TIMEOUT, context.DeadlineExceeded, "timeout"},
+		{"worker error", proto.State_STATE_RUNNING, errors.New("pull image: not found"), "pull image: not found"},
+		{"no error", proto.State_STATE_FAILED, nil, "failed execution"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := actionFailureMessage(tt.st, tt.err); got != tt.want {
+				t.Fatalf("expected message %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// mockWorkflowServiceClient is a mock implementation of proto.WorkflowServiceClient recording the reported statuses.
+type mockWorkflowServiceClient struct {
+	proto.WorkflowServiceClient
+
+	mu       sync.Mutex
+	statuses []*proto.WorkflowActionStatus
+}
+
+func (m *mockWorkflowServiceClient) ReportActionStatus(_ context.Context, in *proto.WorkflowActionStatus, _ ...grpc.CallOption) (*proto.Empty, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses = append(m.statuses, in)
+	return &proto.Empty{}, nil
+}
+
+// mockOnboardingClient is a mock implementation of onboardingmgr.InteractiveOnboardingServiceClient recording the
+// reported messages.
+type mockOnboardingClient struct {
+	onboardingmgr.InteractiveOnboardingServiceClient
+
+	mu       sync.Mutex
+	messages []*onboardingmgr.ReportActionMessageRequest
+}
+
+func (m *mockOnboardingClient) ReportActionMessage(_ context.Context, in *onboardingmgr.ReportActionMessageRequest,
+	_ ...grpc.CallOption,
+) (*onboardingmgr.ReportActionMessageResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, in)
+	return &onboardingmgr.ReportActionMessageResponse{}, nil
+}
+
+func TestExecute_ReportProgress(t *testing.T) {
+	dataDir := t.TempDir()
+	wfDir := filepath.Join(dataDir, "workflow")
+	if err := os.MkdirAll(wfDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	progressFile := filepath.Join(wfDir, progressFileName)
+	// left over by a previous action
+	if err := os.WriteFile(progressFile, []byte(`{"stage":"previous action"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	progress := []string{
+		`{"stage":"writing OS image","bytesWritten":1000,"totalBytes":4000,"percent":25,"bytesPerSecond":500}`,
+		"not a JSON document",
+		`{
+			"stage": "writing OS image",
+			"bytesWritten": 2000,
+			"totalBytes": 4000,
+			"percent": 50,
+			"bytesPerSecond": 500
+		}`,
+	}
+	tinkClient := &mockWorkflowServiceClient{}
+	client := &mockOnboardingClient{}
+	w := &Worker{
+		logger:           logr.Discard(),
+		dataDir:          dataDir,
+		tinkClient:       tinkClient,
+		onboardingClient: client,
+		hostUUID:         testHostUUID,
+		progressInterval: 10 * time.Millisecond,
+		containerManager: &mockContainerManager{
+			waitForContainerFunc: func(_ context.Context, _ string) (proto.State, error) {
+				// let the progress be polled a few times each, including when unchanged
+				for _, p := range progress {
+					if err := os.WriteFile(progressFile, []byte(p), 0o600); err != nil {
+						return proto.State_STATE_FAILED, err
+					}
+					time.Sleep(50 * time.Millisecond)
+				}
+				return proto.State_STATE_SUCCESS, nil
+			},
+		},
+	}
+
+	action := &proto.WorkflowAction{Name: "stream-os-image", TaskName: "os-installation", WorkerId: "worker"}
+	st, err := w.execute(context.Background(), "workflow", action)
+	if err != nil || st != proto.State_STATE_SUCCESS {
+		t.Fatalf("expected success, got %s: %v", st, err)
+	}
+
+	if len(tinkClient.statuses) != 0 {
+		t.Errorf("expected the running status not to be reported again, got %v", tinkClient.statuses)
+	}
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	want := []string{
+		`progress: {"stage":"writing OS image","bytesWritten":1000,"totalBytes":4000,"percent":25,"bytesPerSecond":500}`,
+		`progress: {"stage":"writing OS image","bytesWritten":2000,"totalBytes":4000,"percent":50,"bytesPerSecond":500}`,
+	}
+	if len(client.messages) != len(want) {
+		t.Fatalf("expected %d progress reports, got %d", len(want), len(client.messages))
+	}
+	for i, message := range client.messages {
+		if message.GetMessage() != want[i] {
+			t.Errorf("expected message %q, got %q", want[i], message.GetMessage())
+		}
+		if message.GetUuid() != testHostUUID || message.GetWorkflowId() != "workflow" ||
+			message.GetActionName() != action.GetName() || message.GetTaskName() != action.GetTaskName() {
+			t.Errorf("unexpected progress report %v", message)
+		}
+	}
+}
+
+func TestActionSuccessMessage(t *testing.T) {
+	dataDir := t.TempDir()
+	wfDir := filepath.Join(dataDir, "workflow")
+	if err := os.MkdirAll(wfDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	w := &Worker{logger: logr.Discard(), dataDir: dataDir}
+
+	if got := w.actionSuccessMessage(logr.Discard(), "workflow"); got != successMessage {
+		t.Errorf("expected message %q without report, got %q", successMessage, got)
+	}
+
+	tests := []struct {
+		name   string
+		report string
+		want   string
+	}{
+		{"report", "{\n  \"disks\": [{\"device\": \"/dev/sda\", \"result\": \"sanitized\"}]\n}",
+			`report: {"disks":[{"device":"/dev/sda","result":"sanitized"}]}`},
+		{"invalid report", "sanitized", successMessage},
+		{"report too long", `{"padding":"` + strings.Repeat("x", maxActionReportLength) + `"}`, successMessage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(filepath.Join(wfDir, reportFileName), []byte(tt.report), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if got := w.actionSuccessMessage(logr.Discard(), "workflow"); got != tt.want {
+				t.Errorf("expected message %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	if err := os.WriteFile(filepath.Join(wfDir, reportFileName), []byte(`{"disks": []}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(wfDir, outputsFileName), []byte("TARGET_DISK=/dev/sda\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	want := "report: {\"disks\":[]}\noutputs: {\"TARGET_DISK\":\"/dev/sda\"}"
+	if got := w.actionSuccessMessage(logr.Discard(), "workflow"); got != want {
+		t.Errorf("expected the report and the outputs on separate lines %q, got %q", want, got)
+	}
+}
+
+func TestFailedActionMessage(t *testing.T) {
+	dataDir := t.TempDir()
+	wfDir := filepath.Join(dataDir, "workflow")
+	if err := os.MkdirAll(wfDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	w := &Worker{logger: logr.Discard(), dataDir: dataDir}
+	failed := &ExitError{ExitCode: 91}
+
+	if got := w.failedActionMessage(w.logger, "workflow", proto.State_STATE_FAILED, failed); got != "exit status 91" {
+		t.Errorf("expected the exit status alone without report, got %q", got)
+	}
+
+	report := "{\n  \"disks\": [{\"device\": \"/dev/sda\", \"result\": \"failed\"}]\n}"
+	if err := os.WriteFile(filepath.Join(wfDir, reportFileName), []byte(report), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	want := "exit status 91\nreport: {\"disks\":[{\"device\":\"/dev/sda\",\"result\":\"failed\"}]}"
+	if got := w.failedActionMessage(w.logger, "workflow", proto.State_STATE_FAILED, failed); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	want = "timeout\nreport: {\"disks\":[{\"device\":\"/dev/sda\",\"result\":\"failed\"}]}"
+	if got := w.failedActionMessage(w.logger, "workflow", proto.State_STATE_TIMEOUT, nil); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestExecute_RemovesPreviousReport(t *testing.T) {
+	dataDir := t.TempDir()
+	wfDir := filepath.Join(dataDir, "workflow")
+	if err := os.MkdirAll(wfDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	reportFile := filepath.Join(wfDir, reportFileName)
+	if err := os.WriteFile(reportFile, []byte(`{"disks":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	w := &Worker{logger: logr.Discard(), dataDir: dataDir, containerManager: &mockContainerManager{}}
+
+	if _, err := w.execute(context.Background(), "workflow", &proto.WorkflowAction{Name: "reboot"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := w.actionSuccessMessage(logr.Discard(), "workflow"); got != successMessage {
+		t.Errorf("the report of a previous action must not be reported, got %q", got)
+	}
+}
+
+func TestExecute_Outputs(t *testing.T) {
+	dataDir := t.TempDir()
+	wfDir := filepath.Join(dataDir, "workflow")
+	if err := os.MkdirAll(wfDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	outputsFile := filepath.Join(wfDir, outputsFileName)
+	// the actions publish their outputs while they run
+	var published []string
+	cm := &mockContainerManager{waitForContainerFunc: func(context.Context, string) (proto.State, error) {
+		if len(published) > 0 {
+			if err := os.WriteFile(outputsFile, []byte(published[0]), 0o600); err != nil {
+				return proto.State_STATE_FAILED, err
+			}
+			published = published[1:]
+		}
+		return proto.State_STATE_SUCCESS, nil
+	}}
+	w := &Worker{logger: logr.Discard(), dataDir: dataDir, containerManager: cm}
+	ctx := context.Background()
+
+	published = []string{"# image2disk\nTARGET_DISK=/dev/sda\nIMAGE_SHA256=abc\n"}
+	if _, err := w.execute(ctx, "workflow", &proto.WorkflowAction{Name: "stream-image"}); err != nil {
+		t.Fatal(err)
+	}
+	want := `outputs: {"IMAGE_SHA256":"abc","TARGET_DISK":"/dev/sda"}`
+	if got := w.actionSuccessMessage(logr.Discard(), "workflow"); got != want {
+		t.Errorf("expected message %q, got %q", want, got)
+	}
+
+	published = []string{"INSTALLED_KERNEL=6.6.0\n"}
+	action := &proto.WorkflowAction{Name: "kernel-upgrade", Environment: []string{"IMAGE_SHA256=override", "KERNEL_VERSION=6.6"}}
+	if _, err := w.execute(ctx, "workflow", action); err != nil {
+		t.Fatal(err)
+	}
+	env := strings.Join(cm.created[1].GetEnvironment(), " ")
+	if want := "TARGET_DISK=/dev/sda IMAGE_SHA256=override KERNEL_VERSION=6.6"; env != want {
+		t.Errorf("expected environment %q, got %q", want, env)
+	}
+	if len(action.GetEnvironment()) != 2 {
+		t.Errorf("the action must not be modified, got %v", action.GetEnvironment())
+	}
+
+	if _, err := w.execute(ctx, "workflow", &proto.WorkflowAction{Name: "reboot"}); err != nil {
+		t.Fatal(err)
+	}
+	env = strings.Join(cm.created[2].GetEnvironment(), " ")
+	if want := "IMAGE_SHA256=abc INSTALLED_KERNEL=6.6.0 TARGET_DISK=/dev/sda"; env != want {
+		t.Errorf("expected the outputs of all the previous actions, got %q", env)
+	}
+	if got := w.actionSuccessMessage(logr.Discard(), "workflow"); got != successMessage {
+		t.Errorf("the outputs of a previous action must not be reported, got %q", got)
+	}
+}
+
+func TestExecute_InvalidOutputs(t *testing.T) {
+	dataDir := t.TempDir()
+	wfDir := filepath.Join(dataDir, "workflow")
+	if err := os.MkdirAll(wfDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]string{
+		"not KEY=value": "TARGET_DISK\n",
+		"lowercase key": "target_disk=/dev/sda\n",
+		"too long":      "PADDING=" + strings.Repeat("x", maxActionOutputsLength) + "\n",
+	}
+	for name, outputs := range tests {
+		t.Run(name, func(t *testing.T) {
+			cm := &mockContainerManager{waitForContainerFunc: func(context.Context, string) (proto.State, error) {
+				return proto.State_STATE_SUCCESS, os.WriteFile(filepath.Join(wfDir, outputsFileName), []byte(outputs), 0o600)
+			}}
+			w := &Worker{logger: logr.Discard(), dataDir: dataDir, containerManager: cm}
+			st, err := w.execute(context.Background(), "workflow", &proto.WorkflowAction{Name: "stream-image"})
+			if st != proto.State_STATE_FAILED || err == nil || !strings.HasPrefix(err.Error(), "collect outputs") {
+				t.Errorf("expected the action to fail on invalid outputs, got %v, %v", st, err)
+			}
+			if _, err := os.Stat(filepath.Join(wfDir, workflowOutputsFileName)); !os.IsNotExist(err) {
+				t.Errorf("invalid outputs must not be collected: %v", err)
+			}
+		})
+	}
+}
+
+func TestActionRetryPolicy(t *testing.T) {
+	policy, err := actionRetryPolicy(&proto.WorkflowAction{Environment: []string{"FS_TYPE=ext4"}})
+	if err != nil || policy.maxAttempts != 1 || policy.retries(1, proto.State_STATE_FAILED, &ExitError{ExitCode: 1}) {
+		t.Errorf("expected no retry by default, got %+v, %v", policy, err)
+	}
+
+	policy, err = actionRetryPolicy(&proto.WorkflowAction{Environment: []string{
+		envRetryMaxAttempts + "=3", envRetryBackoff + "=1m", envRetryExitCodes + "=82, 87",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		attempt int
+		st      proto.State
+		err     error
+		want    bool
+	}{
+		{"retryable exit status", 1, proto.State_STATE_FAILED, &ExitError{ExitCode: 82}, true},
+		{"last attempt", 3, proto.State_STATE_FAILED, &ExitError{ExitCode: 82}, false},
+		{"other exit status", 1, proto.State_STATE_FAILED, &ExitError{ExitCode: 84}, false},
+		{"timeout", 1, proto.State_STATE_TIMEOUT, context.DeadlineExceeded, false},
+		{"worker error", 1, proto.State_STATE_RUNNING, errors.New("create container"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.retries(tt.attempt, tt.st, tt.err); got != tt.want {
+				t.Errorf("expected retries() = %v, got %v", tt.want, got)
+			}
+		})
+	}
+	for attempt, want := range map[int]time.Duration{1: time.Minute, 2: 2 * time.Minute, 4: maxActionRetryBackoff} {
+		if got := policy.delay(attempt); got != want {
+			t.Errorf("expected a delay of %s after attempt %d, got %s", want, attempt, got)
+		}
+	}
+
+	for _, env := range []string{
+		envRetryMaxAttempts + "=0", envRetryMaxAttempts + "=100", envRetryBackoff + "=10",
+		envRetryExitCodes + "=0", envRetryExitCodes + "=82,",
+	} {
+		if _, err := actionRetryPolicy(&proto.WorkflowAction{Environment: []string{env}}); err == nil {
+			t.Errorf("expected %s to be invalid", env)
+		}
+	}
+}
+
+func TestExecuteWithRetries(t *testing.T) {
+	exitCodes := []int64{87, 87, 0}
+	cm := &mockContainerManager{waitForContainerFunc: func(context.Context, string) (proto.State, error) {
+		code := exitCodes[0]
+		exitCodes = exitCodes[1:]
+		if code != 0 {
+			return proto.State_STATE_FAILED, &ExitError{ExitCode: code}
+		}
+		return proto.State_STATE_SUCCESS, nil
+	}}
+	tinkClient := &mockWorkflowServiceClient{}
+	client := &mockOnboardingClient{}
+	w := &Worker{
+		logger: logr.Discard(), dataDir: t.TempDir(), containerManager: cm, tinkClient: tinkClient,
+		onboardingClient: client, hostUUID: testHostUUID,
+	}
+	action := &proto.WorkflowAction{
+		Name:        "add-apt-proxy",
+		Environment: []string{envRetryMaxAttempts + "=3", envRetryBackoff + "=1ms", envRetryExitCodes + "=87"},
+	}
+
+	st, attempt, err := w.executeWithRetries(context.Background(), "workflow", action)
+	if st != proto.State_STATE_SUCCESS || err != nil || attempt != "attempt: 3/3" {
+		t.Fatalf("expected the third attempt to succeed, got %s, %q, %v", st, attempt, err)
+	}
+	if len(tinkClient.statuses) != 0 {
+		t.Errorf("expected the running status not to be reported again, got %v", tinkClient.statuses)
+	}
+	var messages []string
+	for _, message := range client.messages {
+		if message.GetActionName() != "add-apt-proxy" {
+			t.Errorf("expected messages of the action, got %v", message)
+		}
+		messages = append(messages, message.GetMessage())
+	}
+	if want := "attempt: 2/3,attempt: 3/3"; strings.Join(messages, ",") != want {
+		t.Errorf("expected the attempts %q to be reported, got %q", want, messages)
+	}
+
+	exitCodes = []int64{87, 85}
+	st, attempt, err = w.executeWithRetries(context.Background(), "workflow", action)
+	var exitErr *ExitError
+	if st != proto.State_STATE_FAILED || !errors.As(err, &exitErr) || exitErr.ExitCode != 85 || attempt != "attempt: 2/3" {
+		t.Errorf("expected the failure of the second attempt, got %s, %q, %v", st, attempt, err)
+	}
+
+	exitCodes = []int64{87}
+	st, attempt, err = w.executeWithRetries(context.Background(), "workflow", &proto.WorkflowAction{Name: "reboot"})
+	if st != proto.State_STATE_FAILED || attempt != "" || actionFailureMessage(st, err) != "exit status 87" {
+		t.Errorf("expected a single attempt without retry policy, got %s, %q, %v", st, attempt, err)
+	}
+
+	// the attempts share the timeout of the action
+	exitCodes = []int64{87}
+	action.Timeout = 2
+	action.Environment = []string{envRetryMaxAttempts + "=3", envRetryBackoff + "=1500ms"}
+	start := time.Now()
+	st, attempt, err = w.executeWithRetries(context.Background(), "workflow", action)
+	if st != proto.State_STATE_FAILED || attempt != "" || time.Since(start) > time.Second {
+		t.Errorf("expected no attempt after the action timed out, got %s, %q, %v", st, attempt, err)
+	}
+}
+
+func TestExecute_ReportHeartbeat(t *testing.T) {
+	tinkClient := &mockWorkflowServiceClient{}
+	client := &mockOnboardingClient{}
+	w := &Worker{
+		logger:            logr.Discard(),
+		dataDir:           t.TempDir(),
+		tinkClient:        tinkClient,
+		onboardingClient:  client,
+		hostUUID:          testHostUUID,
+		heartbeatInterval: 20 * time.Millisecond,
+		containerManager: &mockContainerManager{
+			waitForContainerFunc: func(_ context.Context, _ string) (proto.State, error) {
+				time.Sleep(150 * time.Millisecond)
+				return proto.State_STATE_SUCCESS, nil
+			},
+		},
+	}
+
+	action := &proto.WorkflowAction{Name: "stream-os-image", TaskName: "os-installation", WorkerId: "worker"}
+	seq := heartbeatSeq.Load()
+	ctx := context.WithValue(context.Background(), attemptContextKey{}, "attempt: 2/3")
+	if st, err := w.execute(ctx, "workflow", action); err != nil || st != proto.State_STATE_SUCCESS {
+		t.Fatalf("expected success, got %s: %v", st, err)
+	}
+
+	if len(tinkClient.statuses) != 0 {
+		t.Errorf("expected the running status not to be reported again, got %v", tinkClient.statuses)
+	}
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if len(client.messages) < 2 {
+		t.Fatalf("expected heartbeats while the action runs, got %d", len(client.messages))
+	}
+	for i, message := range client.messages {
+		attempt, line, ok := strings.Cut(message.GetMessage(), "\n")
+		if !ok || attempt != "attempt: 2/3" || !strings.HasPrefix(line, heartbeatMessagePrefix) {
+			t.Fatalf("expected the attempt and a heartbeat, got %q", message.GetMessage())
+		}
+		var hb heartbeat
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, heartbeatMessagePrefix)), &hb); err != nil {
+			t.Fatalf("invalid heartbeat %q: %v", line, err)
+		}
+		if hb.Seq != seq+int64(i)+1 || hb.Action != action.GetName() {
+			t.Errorf("expected heartbeat %d of %s, got %+v", seq+int64(i)+1, action.GetName(), hb)
+		}
+		if message.GetActionName() != action.GetName() {
+			t.Errorf("expected a message of %s, got %v", action.GetName(), message)
+		}
+	}
+}
+
+func TestReadResources(t *testing.T) {
+	dir := t.TempDir()
+	meminfo := filepath.Join(dir, "meminfo")
+	if err := os.WriteFile(meminfo, []byte("MemTotal:       16000000 kB\nMemFree:         1000000 kB\n"+
+		"MemAvailable:    8000000 kB\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	total, available, err := readMemory(meminfo)
+	if err != nil || total != 16000000*1024 || available != 8000000*1024 {
+		t.Errorf("expected 16000000 kB total and 8000000 kB available, got %d, %d: %v", total, available, err)
+	}
+
+	sysBlockDir := filepath.Join(dir, "block")
+	for _, disk := range []string{"sda", "nvme0n1", "loop0"} {
+		if err := os.MkdirAll(filepath.Join(sysBlockDir, disk), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	diskstats := filepath.Join(dir, "diskstats")
+	if err := os.WriteFile(diskstats, []byte(`   8       0 sda 100 0 1000 10 200 0 2000 20 0 30 30 0 0 0 0
+   8       1 sda1 50 0 500 5 100 0 1000 10 0 15 15 0 0 0 0
+ 259       0 nvme0n1 10 0 100 1 20 0 200 2 0 3 3 0 0 0 0
+   7       0 loop0 10 0 100 1 0 0 0 0 0 1 1 0 0 0 0
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	read, written, err := readDiskSectors(diskstats, sysBlockDir)
+	if err != nil || read != 1100 || written != 2200 {
+		t.Errorf("expected 1100 sectors read and 2200 written by the disks, got %d, %d: %v", read, written, err)
+	}
+}
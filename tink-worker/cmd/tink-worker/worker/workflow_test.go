@@ -0,0 +1,453 @@
+// SPDX-FileCopyrightText: 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/tinkerbell/tink/internal/proto"
+	"github.com/tinkerbell/tink/internal/proto/onboardingmgr/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testHostUUID   = "0b8a3b8e-6a0f-4b8e-9d4c-2f5b1c7e9a10"
+	testWorkflowID = "default/workflow-" + testHostUUID
+	testWorkerID   = "worker"
+	testTaskName   = "os-installation"
+)
+
+// fakeAction is the status of an action kept by fakeWorkflowServer.
+type fakeAction struct {
+	action    *proto.WorkflowAction
+	state     proto.State
+	startedAt time.Time
+	seconds   int64
+	message   string
+	// timedOut tells whether the controller timed out the action.
+	timedOut bool
+}
+
+// fakeWorkflowServer keeps the status of a workflow of a single task as the Tinkerbell server v0.12.2 does
+// (internal/server/kubernetes_api_workflow.go):
+//   - the current action is the first one that did not succeed, the worker cannot report on another one,
+//   - reporting an action RUNNING sets the time it started, reporting it finished sets the time it took,
+//   - the message reported with the status of an action is not kept.
+//
+// It also plays the Tinkerbell controller (internal/deprecated/workflow/reconciler.go), which times out the running
+// action once its timeout elapsed since it started, see reconcile, and the onboarding manager, which sets the
+// message of the running action, see ReportActionMessage.
+type fakeWorkflowServer struct {
+	proto.WorkflowServiceClient
+	onboardingmgr.InteractiveOnboardingServiceClient
+
+	mu      sync.Mutex
+	now     func() time.Time
+	actions []*fakeAction
+	// statuses are the statuses reported by the worker, in order.
+	statuses []*proto.WorkflowActionStatus
+	// messages are the messages reported by the worker to the onboarding manager, in order.
+	messages []*onboardingmgr.ReportActionMessageRequest
+}
+
+func newFakeWorkflowServer(actions ...*proto.WorkflowAction) *fakeWorkflowServer {
+	s := &fakeWorkflowServer{now: time.Now}
+	for _, action := range actions {
+		action.TaskName = testTaskName
+		action.WorkerId = testWorkerID
+		s.actions = append(s.actions, &fakeAction{action: action, state: proto.State_STATE_PENDING})
+	}
+	return s
+}
+
+// current returns the index of the current action, the number of actions once they all succeeded.
+func (s *fakeWorkflowServer) current() int {
+	for i, action := range s.actions {
+		if action.state != proto.State_STATE_SUCCESS {
+			return i
+		}
+	}
+	return len(s.actions)
+}
+
+// finished tells whether the workflow succeeded, failed or timed out.
+func (s *fakeWorkflowServer) finished() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.current()
+	return i == len(s.actions) || s.actions[i].state == proto.State_STATE_FAILED ||
+		s.actions[i].state == proto.State_STATE_TIMEOUT
+}
+
+// action returns a copy of the status of the action.
+func (s *fakeWorkflowServer) action(name string) fakeAction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, action := range s.actions {
+		if action.action.GetName() == name {
+			return *action
+		}
+	}
+	return fakeAction{}
+}
+
+// reconcile times out the running action once its timeout elapsed since it started, as the Tinkerbell controller.
+func (s *fakeWorkflowServer) reconcile() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, action := range s.actions {
+		timeout := time.Duration(action.action.GetTimeout()) * time.Second
+		if action.state == proto.State_STATE_RUNNING && !action.startedAt.IsZero() &&
+			s.now().After(action.startedAt.Add(timeout)) {
+			action.state = proto.State_STATE_TIMEOUT
+			action.timedOut = true
+			action.message = "Action timed out"
+			action.seconds = int64(s.now().Sub(action.startedAt).Seconds())
+		}
+	}
+}
+
+func (s *fakeWorkflowServer) GetWorkflowContexts(_ context.Context, _ *proto.WorkflowContextRequest, _ ...grpc.CallOption) (
+	proto.WorkflowService_GetWorkflowContextsClient, error,
+) {
+	if s.finished() {
+		return &fakeWorkflowContexts{}, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.current()
+	return &fakeWorkflowContexts{contexts: []*proto.WorkflowContext{{
+		WorkflowId:           testWorkflowID,
+		CurrentWorker:        testWorkerID,
+		CurrentTask:          testTaskName,
+		CurrentAction:        s.actions[i].action.GetName(),
+		CurrentActionIndex:   int64(i),
+		CurrentActionState:   s.actions[i].state,
+		TotalNumberOfActions: int64(len(s.actions)),
+	}}}, nil
+}
+
+func (s *fakeWorkflowServer) GetWorkflowActions(_ context.Context, _ *proto.WorkflowActionsRequest, _ ...grpc.CallOption) (
+	*proto.WorkflowActionList, error,
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := &proto.WorkflowActionList{}
+	for _, action := range s.actions {
+		list.ActionList = append(list.ActionList, action.action)
+	}
+	return list, nil
+}
+
+func (s *fakeWorkflowServer) ReportActionStatus(_ context.Context, in *proto.WorkflowActionStatus, _ ...grpc.CallOption) (
+	*proto.Empty, error,
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses = append(s.statuses, in)
+	i := s.current()
+	if in.GetWorkflowId() != testWorkflowID || i == len(s.actions) || in.GetTaskName() != testTaskName ||
+		in.GetActionName() != s.actions[i].action.GetName() {
+		return nil, status.Error(codes.InvalidArgument, "reported action name does not match the current action details")
+	}
+	action := s.actions[i]
+	action.state = in.GetActionStatus()
+	switch in.GetActionStatus() {
+	case proto.State_STATE_RUNNING:
+		action.startedAt = s.now()
+	case proto.State_STATE_FAILED, proto.State_STATE_TIMEOUT, proto.State_STATE_SUCCESS:
+		if !action.startedAt.IsZero() {
+			action.seconds = int64(s.now().Sub(action.startedAt).Seconds())
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "no update requested")
+	}
+	// the message is not kept
+	return &proto.Empty{}, nil
+}
+
+func (s *fakeWorkflowServer) ReportActionMessage(_ context.Context, in *onboardingmgr.ReportActionMessageRequest,
+	_ ...grpc.CallOption,
+) (*onboardingmgr.ReportActionMessageResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, in)
+	if in.GetUuid() != testHostUUID || in.GetWorkflowId() != testWorkflowID {
+		return nil, status.Error(codes.PermissionDenied, "workflow is not a workflow of the host")
+	}
+	for _, action := range s.actions {
+		if action.action.GetName() == in.GetActionName() && in.GetTaskName() == testTaskName {
+			if action.state != proto.State_STATE_RUNNING {
+				return nil, status.Error(codes.FailedPrecondition, "action is not running")
+			}
+			action.message = in.GetMessage()
+			return &onboardingmgr.ReportActionMessageResponse{}, nil
+		}
+	}
+	return nil, status.Error(codes.FailedPrecondition, "action is not running")
+}
+
+// reportedStates returns the states reported for the action, in order.
+func (s *fakeWorkflowServer) reportedStates(name string) []proto.State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var states []proto.State
+	for _, st := range s.statuses {
+		if st.GetActionName() == name {
+			states = append(states, st.GetActionStatus())
+		}
+	}
+	return states
+}
+
+// fakeWorkflowContexts streams the workflow contexts of fakeWorkflowServer.
+type fakeWorkflowContexts struct {
+	grpc.ClientStream
+	contexts []*proto.WorkflowContext
+}
+
+func (c *fakeWorkflowContexts) Recv() (*proto.WorkflowContext, error) {
+	if len(c.contexts) == 0 {
+		return nil, io.EOF
+	}
+	wfContext := c.contexts[0]
+	c.contexts = c.contexts[1:]
+	return wfContext, nil
+}
+
+// newWorkflowWorker returns a worker of the workflow of server, reporting the messages of the actions to server.
+func newWorkflowWorker(t *testing.T, server *fakeWorkflowServer, cm ContainerManager, opts ...Option) (*Worker, string) {
+	t.Helper()
+	dataDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dataDir, testWorkflowID), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	opts = append([]Option{
+		WithDataDir(dataDir),
+		WithRetries(time.Millisecond, 3),
+		WithProgressInterval(0),
+		WithHeartbeatInterval(0),
+		WithOnboardingClient(server, testHostUUID),
+	}, opts...)
+	return NewWorker(testWorkerID, server, cm, nil, logr.Discard(), opts...), filepath.Join(dataDir, testWorkflowID)
+}
+
+// runWorkflow runs the workflow of server with the worker until it finished.
+func runWorkflow(t *testing.T, w *Worker, server *fakeWorkflowServer) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- w.ProcessWorkflowActions(ctx)
+	}()
+	deadline := time.Now().Add(10 * time.Second)
+	for !server.finished() && time.Now().Before(deadline) {
+		server.reconcile()
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if !server.finished() {
+		t.Fatal("the workflow did not finish")
+	}
+}
+
+func TestProcessWorkflowActions_Messages(t *testing.T) {
+	server := newFakeWorkflowServer(
+		&proto.WorkflowAction{Name: "sanitize-disks", Image: "disk-sanitize"},
+		&proto.WorkflowAction{Name: "secure-boot-status-flag-read", Image: "securebootflag"},
+	)
+	var wfDir string
+	cm := &mockContainerManager{waitForContainerFunc: func(context.Context, string) (proto.State, error) {
+		if len(server.reportedStates("secure-boot-status-flag-read")) == 0 {
+			if err := os.WriteFile(filepath.Join(wfDir, outputsFileName), []byte("TARGET_DISK=/dev/sda\n"), 0o600); err != nil {
+				return proto.State_STATE_FAILED, err
+			}
+			return proto.State_STATE_SUCCESS, nil
+		}
+		return proto.State_STATE_FAILED, &ExitError{ExitCode: 85}
+	}}
+	var w *Worker
+	w, wfDir = newWorkflowWorker(t, server, cm)
+
+	runWorkflow(t, w, server)
+
+	// the messages are kept although the Tinkerbell server drops the ones reported with the statuses
+	sanitize := server.action("sanitize-disks")
+	if sanitize.state != proto.State_STATE_SUCCESS || sanitize.message != `outputs: {"TARGET_DISK":"/dev/sda"}` {
+		t.Errorf("expected the outputs of the successful action to be kept, got %s %q", sanitize.state, sanitize.message)
+	}
+	secureBoot := server.action("secure-boot-status-flag-read")
+	if secureBoot.state != proto.State_STATE_FAILED || secureBoot.message != "exit status 85" {
+		t.Errorf("expected the exit status of the failed action to be kept, got %s %q", secureBoot.state, secureBoot.message)
+	}
+	for _, name := range []string{"sanitize-disks", "secure-boot-status-flag-read"} {
+		want := []proto.State{proto.State_STATE_RUNNING, server.action(name).state}
+		if got := server.reportedStates(name); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+			t.Errorf("expected the states %v to be reported for %s, got %v", want, name, got)
+		}
+	}
+}
+
+func TestProcessWorkflowActions_Progress(t *testing.T) {
+	server := newFakeWorkflowServer(&proto.WorkflowAction{Name: "stream-os-image", Image: "image2disk", Timeout: 3600})
+	progress := `progress: {"stage":"writing OS image","percent":50}`
+	var wfDir string
+	var startedAt time.Time
+	var running fakeAction
+	cm := &mockContainerManager{waitForContainerFunc: func(context.Context, string) (proto.State, error) {
+		startedAt = server.action("stream-os-image").startedAt
+		if err := os.WriteFile(filepath.Join(wfDir, progressFileName), []byte(`{"stage":"writing OS image","percent":50}`),
+			0o600); err != nil {
+			return proto.State_STATE_FAILED, err
+		}
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+			if running = server.action("stream-os-image"); running.message == progress {
+				break
+			}
+		}
+		return proto.State_STATE_SUCCESS, nil
+	}}
+	var w *Worker
+	w, wfDir = newWorkflowWorker(t, server, cm, WithProgressInterval(time.Millisecond))
+
+	runWorkflow(t, w, server)
+
+	// the progress is kept while the action runs, and does not restart its timeout
+	if running.message != progress || running.state != proto.State_STATE_RUNNING {
+		t.Errorf("expected the progress of the running action to be kept, got %s %q", running.state, running.message)
+	}
+	if startedAt.IsZero() || !running.startedAt.Equal(startedAt) {
+		t.Errorf("expected the action to have started at %v, got %v", startedAt, running.startedAt)
+	}
+	if got := server.reportedStates("stream-os-image"); len(got) != 2 || got[0] != proto.State_STATE_RUNNING ||
+		got[1] != proto.State_STATE_SUCCESS {
+		t.Errorf("expected the action to be reported running once, got %v", got)
+	}
+}
+
+func TestProcessWorkflowActions_Report(t *testing.T) {
+	report := `{"disks":[{"device":"/dev/sda","result":"failed"}]}`
+	for _, tt := range []struct {
+		name     string
+		exitCode int64
+		want     string
+	}{
+		{"success", 0, "report: " + report},
+		{"failure", 91, "exit status 91\nreport: " + report},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeWorkflowServer(&proto.WorkflowAction{Name: "sanitize-disks", Image: "disk-sanitize"})
+			var wfDir string
+			cm := &mockContainerManager{waitForContainerFunc: func(context.Context, string) (proto.State, error) {
+				if err := os.WriteFile(filepath.Join(wfDir, reportFileName), []byte(report), 0o600); err != nil {
+					return proto.State_STATE_FAILED, err
+				}
+				if tt.exitCode != 0 {
+					return proto.State_STATE_FAILED, &ExitError{ExitCode: tt.exitCode}
+				}
+				return proto.State_STATE_SUCCESS, nil
+			}}
+			var w *Worker
+			w, wfDir = newWorkflowWorker(t, server, cm)
+
+			runWorkflow(t, w, server)
+
+			// the sanitization report is kept for the onboarding manager to record it
+			if got := server.action("sanitize-disks").message; got != tt.want {
+				t.Errorf("expected the message %q to be kept, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProcessWorkflowActions_RetriesTimeout(t *testing.T) {
+	server := newFakeWorkflowServer(&proto.WorkflowAction{
+		Name: "add-apt-proxy", Image: "cexec", Timeout: 100,
+		Environment: []string{envRetryMaxAttempts + "=10", envRetryBackoff + "=1ms"},
+	})
+	// every attempt takes 40s of the time of the server
+	var elapsed atomic.Int64
+	server.now = func() time.Time { return time.Now().Add(time.Duration(elapsed.Load())) }
+	var startedAt []time.Time
+	cm := &mockContainerManager{waitForContainerFunc: func(context.Context, string) (proto.State, error) {
+		startedAt = append(startedAt, server.action("add-apt-proxy").startedAt)
+		elapsed.Add(int64(40 * time.Second))
+		return proto.State_STATE_FAILED, &ExitError{ExitCode: 87}
+	}}
+	w, _ := newWorkflowWorker(t, server, cm)
+
+	runWorkflow(t, w, server)
+
+	// the retries do not restart the action, which the controller times out during its third attempt
+	action := server.action("add-apt-proxy")
+	if !action.timedOut || action.message != "Action timed out" {
+		t.Errorf("expected the action to time out, got %s %q", action.state, action.message)
+	}
+	if len(startedAt) < 3 || !startedAt[len(startedAt)-1].Equal(startedAt[0]) {
+		t.Errorf("expected the attempts of the action to share the time it started, got %v", startedAt)
+	}
+	server.mu.Lock()
+	if len(server.messages) == 0 || server.messages[0].GetMessage() != "attempt: 2/10" {
+		t.Errorf("expected the second attempt to be reported, got %v", server.messages)
+	}
+	server.mu.Unlock()
+	if got := server.reportedStates("add-apt-proxy"); len(got) == 0 || got[0] != proto.State_STATE_RUNNING ||
+		slices.Contains(got[1:], proto.State_STATE_RUNNING) {
+		t.Errorf("expected the action to be reported running once, got %v", got)
+	}
+}
+
+func TestProcessWorkflowActions_Heartbeat(t *testing.T) {
+	server := newFakeWorkflowServer(&proto.WorkflowAction{Name: "stream-os-image", Image: "image2disk", Timeout: 100})
+	var elapsed atomic.Int64
+	server.now = func() time.Time { return time.Now().Add(time.Duration(elapsed.Load())) }
+	var startedAt time.Time
+	var running fakeAction
+	cm := &mockContainerManager{waitForContainerFunc: func(ctx context.Context, _ string) (proto.State, error) {
+		startedAt = server.action("stream-os-image").startedAt
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+			if running = server.action("stream-os-image"); strings.Contains(running.message, heartbeatMessagePrefix) {
+				break
+			}
+		}
+		// the action hangs past its timeout, while the worker goes on sending heartbeats
+		elapsed.Add(int64(120 * time.Second))
+		<-ctx.Done()
+		return proto.State_STATE_FAILED, ctx.Err()
+	}}
+	w, _ := newWorkflowWorker(t, server, cm, WithHeartbeatInterval(time.Millisecond))
+
+	runWorkflow(t, w, server)
+
+	// the heartbeats are kept, and neither restart the action nor keep the controller from timing it out
+	if !strings.HasPrefix(running.message, heartbeatMessagePrefix) || running.state != proto.State_STATE_RUNNING {
+		t.Errorf("expected the heartbeat of the running action to be kept, got %s %q", running.state, running.message)
+	}
+	if startedAt.IsZero() || !running.startedAt.Equal(startedAt) {
+		t.Errorf("expected the action to have started at %v, got %v", startedAt, running.startedAt)
+	}
+	if action := server.action("stream-os-image"); !action.timedOut || action.message != "Action timed out" {
+		t.Errorf("expected the action to time out, got %s %q", action.state, action.message)
+	}
+	if got := server.reportedStates("stream-os-image"); len(got) == 0 || got[0] != proto.State_STATE_RUNNING ||
+		slices.Contains(got[1:], proto.State_STATE_RUNNING) {
+		t.Errorf("expected the action to be reported running once, got %v", got)
+	}
+}
@@ -0,0 +1,112 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: internal/proto/onboardingmgr/v1/onboarding.proto
+
+package onboardingmgr
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// InteractiveOnboardingServiceClient is the client API for InteractiveOnboardingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type InteractiveOnboardingServiceClient interface {
+	// ReportActionMessage is called by the tink-worker of an Edge Node, with the credentials of the Edge Node, to
+	// report the message of the running action of its workflow, e.g. its progress or the report it published.
+	// The Tinkerbell server does not keep the messages reported with the status of the actions.
+	ReportActionMessage(ctx context.Context, in *ReportActionMessageRequest, opts ...grpc.CallOption) (*ReportActionMessageResponse, error)
+}
+
+type interactiveOnboardingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInteractiveOnboardingServiceClient(cc grpc.ClientConnInterface) InteractiveOnboardingServiceClient {
+	return &interactiveOnboardingServiceClient{cc}
+}
+
+func (c *interactiveOnboardingServiceClient) ReportActionMessage(ctx context.Context, in *ReportActionMessageRequest, opts ...grpc.CallOption) (*ReportActionMessageResponse, error) {
+	out := new(ReportActionMessageResponse)
+	err := c.cc.Invoke(ctx, "/onboardingmgr.v1.InteractiveOnboardingService/ReportActionMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InteractiveOnboardingServiceServer is the server API for InteractiveOnboardingService service.
+// All implementations must embed UnimplementedInteractiveOnboardingServiceServer
+// for forward compatibility
+type InteractiveOnboardingServiceServer interface {
+	// ReportActionMessage is called by the tink-worker of an Edge Node, with the credentials of the Edge Node, to
+	// report the message of the running action of its workflow, e.g. its progress or the report it published.
+	// The Tinkerbell server does not keep the messages reported with the status of the actions.
+	ReportActionMessage(context.Context, *ReportActionMessageRequest) (*ReportActionMessageResponse, error)
+	mustEmbedUnimplementedInteractiveOnboardingServiceServer()
+}
+
+// UnimplementedInteractiveOnboardingServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedInteractiveOnboardingServiceServer struct {
+}
+
+func (UnimplementedInteractiveOnboardingServiceServer) ReportActionMessage(context.Context, *ReportActionMessageRequest) (*ReportActionMessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportActionMessage not implemented")
+}
+func (UnimplementedInteractiveOnboardingServiceServer) mustEmbedUnimplementedInteractiveOnboardingServiceServer() {
+}
+
+// UnsafeInteractiveOnboardingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InteractiveOnboardingServiceServer will
+// result in compilation errors.
+type UnsafeInteractiveOnboardingServiceServer interface {
+	mustEmbedUnimplementedInteractiveOnboardingServiceServer()
+}
+
+func RegisterInteractiveOnboardingServiceServer(s grpc.ServiceRegistrar, srv InteractiveOnboardingServiceServer) {
+	s.RegisterService(&InteractiveOnboardingService_ServiceDesc, srv)
+}
+
+func _InteractiveOnboardingService_ReportActionMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportActionMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveOnboardingServiceServer).ReportActionMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/onboardingmgr.v1.InteractiveOnboardingService/ReportActionMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveOnboardingServiceServer).ReportActionMessage(ctx, req.(*ReportActionMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// InteractiveOnboardingService_ServiceDesc is the grpc.ServiceDesc for InteractiveOnboardingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InteractiveOnboardingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "onboardingmgr.v1.InteractiveOnboardingService",
+	HandlerType: (*InteractiveOnboardingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReportActionMessage",
+			Handler:    _InteractiveOnboardingService_ReportActionMessage_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "internal/proto/onboardingmgr/v1/onboarding.proto",
+}
@@ -5,10 +5,12 @@ package drive_detection
 
 import (
 	"encoding/json"
+	"os"
 	"os/exec"
 	"sort"
 	"strings"
 
+	"github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/outputs"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -71,6 +73,21 @@ func DriveDetection(drives []DriveInfo) (string, error) {
 
 }
 
+// SelectedDrive returns the disk selected by a previous action of the workflow, published as the TARGET_DISK
+// output, so that all the actions of a workflow agree on the disk. Without it, the disk is detected with
+// DriveDetection.
+func SelectedDrive() (string, error) {
+	if disk := os.Getenv(outputs.TargetDisk); disk != "" {
+		log.Infof("Drive selected by a previous action: %s", disk)
+		return disk, nil
+	}
+	drives, err := GetDrives()
+	if err != nil {
+		return "", err
+	}
+	return DriveDetection(drives)
+}
+
 // CustomError is a custom error type that satisfies the error interface.
 type CustomError struct {
 	Message string
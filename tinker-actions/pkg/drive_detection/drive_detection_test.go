@@ -100,3 +100,18 @@ func TestDriveDetection_SingleValidDrive(t *testing.T) {
 		t.Errorf("Expected /dev/sdc, got: %s", disk)
 	}
 }
+
+func TestSelectedDrive(t *testing.T) {
+	// The disk selected by a previous action wins over the detection
+	t.Setenv("TARGET_DISK", "/dev/nvme1n1")
+
+	disk, err := SelectedDrive()
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if disk != "/dev/nvme1n1" {
+		t.Errorf("Expected /dev/nvme1n1, got: %s", disk)
+	}
+}
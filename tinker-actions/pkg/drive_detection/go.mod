@@ -5,6 +5,11 @@ module github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/drive_d
 
 go 1.24.9
 
-require github.com/sirupsen/logrus v1.9.3
+require (
+	github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/outputs v0.0.0
+	github.com/sirupsen/logrus v1.9.3
+)
 
 require golang.org/x/sys v0.31.0 // indirect
+
+replace github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/outputs => ../outputs
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package efivars reads UEFI variables from efivarfs.
+//
+// Every file of efivarfs is a variable named "<name>-<vendor GUID>", whose content is its 4-byte little-endian
+// attributes followed by its value. Tinker actions read the host efivarfs, usually mounted in their container.
+package efivars
+
+import (
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// DefaultDir is where efivarfs is mounted.
+	DefaultDir = "/sys/firmware/efi/efivars"
+
+	// GlobalVariableGUID is the vendor GUID of the variables defined by the UEFI specification.
+	GlobalVariableGUID = "8be4df61-93ca-11d2-aa0d-00e098032b8c"
+	// ImageSecurityDatabaseGUID is the vendor GUID of the db and dbx signature databases.
+	ImageSecurityDatabaseGUID = "d719b2cb-3d3a-4596-a3bc-dad00e67656f"
+
+	// certX509GUID is the type of the signature lists of X.509 certificates, EFI_CERT_X509_GUID.
+	certX509GUID = "a5c059a1-94e4-4aa7-87b5-ab155c2bf072"
+)
+
+// ErrNotUEFI is returned when efivarfs is not available, e.g. on hosts booted in legacy BIOS mode.
+var ErrNotUEFI = errors.New("efivarfs not available, the host was not booted in UEFI mode")
+
+// Read returns the attributes and the value of a variable in the efivarfs mounted at dir. It returns an
+// fs.ErrNotExist error if the variable is not defined.
+func Read(dir, name, guid string) (uint32, []byte, error) {
+	if _, err := os.Stat(dir); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil, ErrNotUEFI
+		}
+		return 0, nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name+"-"+guid))
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(data) < 4 {
+		return 0, nil, fmt.Errorf("invalid UEFI variable %s: %d bytes", name, len(data))
+	}
+	return binary.LittleEndian.Uint32(data[:4]), data[4:], nil
+}
+
+// ReadBool returns the value of a global 1-byte variable such as SecureBoot, false if it is not defined.
+func ReadBool(dir, name string) (bool, error) {
+	_, value, err := Read(dir, name, GlobalVariableGUID)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if len(value) != 1 {
+		return false, fmt.Errorf("invalid UEFI variable %s: expected 1 byte, got %d", name, len(value))
+	}
+	return value[0] == 1, nil
+}
+
+// SecureBoot returns true if the firmware booted the host with Secure Boot enforced.
+func SecureBoot(dir string) (bool, error) {
+	return ReadBool(dir, "SecureBoot")
+}
+
+// SetupMode returns true if the firmware is in Setup Mode, i.e. no Platform Key is enrolled and Secure Boot
+// is not enforced.
+func SetupMode(dir string) (bool, error) {
+	return ReadBool(dir, "SetupMode")
+}
+
+// AuditMode returns true if the firmware is in Audit Mode, i.e. image signatures are verified but not enforced.
+// Firmware older than UEFI 2.5 does not define the variable.
+func AuditMode(dir string) (bool, error) {
+	return ReadBool(dir, "AuditMode")
+}
+
+// Certificates returns the X.509 certificates of a signature database such as PK, KEK or db, none if the database
+// is not defined. Signatures other than certificates, e.g. SHA-256 hashes, are skipped.
+func Certificates(dir, name, guid string) ([]*x509.Certificate, error) {
+	_, value, err := Read(dir, name, guid)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	certs, err := ParseSignatureLists(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid UEFI variable %s: %w", name, err)
+	}
+	return certs, nil
+}
+
+// ParseSignatureLists returns the X.509 certificates of a sequence of EFI_SIGNATURE_LIST structures.
+func ParseSignatureLists(data []byte) ([]*x509.Certificate, error) {
+	const listHeaderSize, ownerSize = 28, 16
+	var certs []*x509.Certificate
+	for len(data) > 0 {
+		if len(data) < listHeaderSize {
+			return nil, fmt.Errorf("truncated signature list header: %d bytes", len(data))
+		}
+		sigType := guidString(data[:16])
+		listSize := binary.LittleEndian.Uint32(data[16:20])
+		headerSize := binary.LittleEndian.Uint32(data[20:24])
+		sigSize := binary.LittleEndian.Uint32(data[24:28])
+		if uint64(listSize) > uint64(len(data)) || uint64(listHeaderSize)+uint64(headerSize) > uint64(listSize) ||
+			sigSize <= ownerSize {
+			return nil, fmt.Errorf("invalid signature list: size %d, header size %d, signature size %d",
+				listSize, headerSize, sigSize)
+		}
+		sigs := data[listHeaderSize+headerSize : listSize]
+		if uint32(len(sigs))%sigSize != 0 {
+			return nil, fmt.Errorf("invalid signature list: %d bytes of %d-byte signatures", len(sigs), sigSize)
+		}
+		for ; sigType == certX509GUID && len(sigs) > 0; sigs = sigs[sigSize:] {
+			cert, err := x509.ParseCertificate(sigs[ownerSize:sigSize])
+			if err != nil {
+				return nil, err
+			}
+			certs = append(certs, cert)
+		}
+		data = data[listSize:]
+	}
+	return certs, nil
+}
+
+// guidString formats a GUID in its mixed-endian binary encoding.
+func guidString(b []byte) string {
+	return fmt.Sprintf("%08x-%04x-%04x-%x-%x", binary.LittleEndian.Uint32(b[0:4]), binary.LittleEndian.Uint16(b[4:6]),
+		binary.LittleEndian.Uint16(b[6:8]), b[8:10], b[10:16])
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package efivars
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeVar(t *testing.T, dir, name string, value ...byte) {
+	t.Helper()
+	data := append([]byte{0x06, 0x00, 0x00, 0x00}, value...)
+	if err := os.WriteFile(filepath.Join(dir, name+"-"+GlobalVariableGUID), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSecureBoot(t *testing.T) {
+	dir := t.TempDir()
+
+	enabled, err := SecureBoot(dir)
+	if err != nil || enabled {
+		t.Errorf("SecureBoot() without variable = %v, %v, want false, nil", enabled, err)
+	}
+
+	writeVar(t, dir, "SecureBoot", 1)
+	enabled, err = SecureBoot(dir)
+	if err != nil || !enabled {
+		t.Errorf("SecureBoot() = %v, %v, want true, nil", enabled, err)
+	}
+
+	writeVar(t, dir, "SecureBoot", 0)
+	enabled, err = SecureBoot(dir)
+	if err != nil || enabled {
+		t.Errorf("SecureBoot() = %v, %v, want false, nil", enabled, err)
+	}
+
+	writeVar(t, dir, "SecureBoot", 1, 0)
+	if _, err = SecureBoot(dir); err == nil {
+		t.Error("SecureBoot() with a 2-byte value must fail")
+	}
+
+	if _, err = SecureBoot(filepath.Join(dir, "missing")); !errors.Is(err, ErrNotUEFI) {
+		t.Errorf("SecureBoot() without efivarfs = %v, want %v", err, ErrNotUEFI)
+	}
+}
+
+func TestRead(t *testing.T) {
+	dir := t.TempDir()
+	writeVar(t, dir, "BootCurrent", 0x01, 0x00)
+
+	attrs, value, err := Read(dir, "BootCurrent", GlobalVariableGUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attrs != 0x06 || len(value) != 2 || value[0] != 0x01 {
+		t.Errorf("Read() = %#x, %v", attrs, value)
+	}
+
+	if _, _, err = Read(dir, "Missing", GlobalVariableGUID); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Read() of a missing variable = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestModes(t *testing.T) {
+	dir := t.TempDir()
+	writeVar(t, dir, "SetupMode", 1)
+
+	setup, err := SetupMode(dir)
+	if err != nil || !setup {
+		t.Errorf("SetupMode() = %v, %v, want true, nil", setup, err)
+	}
+	audit, err := AuditMode(dir)
+	if err != nil || audit {
+		t.Errorf("AuditMode() without variable = %v, %v, want false, nil", audit, err)
+	}
+}
+
+func selfSigned(t *testing.T, cn string) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn, Organization: []string{"Intel"}},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+// signatureList encodes an EFI_SIGNATURE_LIST of signatures of the same size.
+func signatureList(sigType []byte, sigs ...[]byte) []byte {
+	owner := make([]byte, 16)
+	list := make([]byte, 28)
+	copy(list, sigType)
+	binary.LittleEndian.PutUint32(list[16:], uint32(28+len(sigs)*(16+len(sigs[0]))))
+	binary.LittleEndian.PutUint32(list[24:], uint32(16+len(sigs[0])))
+	for _, sig := range sigs {
+		list = append(append(list, owner...), sig...)
+	}
+	return list
+}
+
+func TestCertificates(t *testing.T) {
+	x509Type := []byte{0xa1, 0x59, 0xc0, 0xa5, 0xe4, 0x94, 0xa7, 0x4a, 0x87, 0xb5, 0xab, 0x15, 0x5c, 0x2b, 0xf0, 0x72}
+	sha256Type := []byte{0x26, 0x16, 0xc4, 0xc1, 0x4c, 0x50, 0x92, 0x40, 0xac, 0xa9, 0x41, 0xf9, 0x36, 0x93, 0x43, 0x28}
+
+	dir := t.TempDir()
+	db := append(signatureList(x509Type, selfSigned(t, "Platform DB")), signatureList(sha256Type, make([]byte, 32))...)
+	db = append(db, signatureList(x509Type, selfSigned(t, "Vendor DB"))...)
+	if err := os.WriteFile(filepath.Join(dir, "db-"+ImageSecurityDatabaseGUID),
+		append([]byte{0x27, 0, 0, 0}, db...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	certs, err := Certificates(dir, "db", ImageSecurityDatabaseGUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certs) != 2 || certs[0].Subject.CommonName != "Platform DB" || certs[1].Subject.CommonName != "Vendor DB" {
+		t.Errorf("Certificates() = %v", certs)
+	}
+
+	certs, err = Certificates(dir, "PK", GlobalVariableGUID)
+	if err != nil || len(certs) != 0 {
+		t.Errorf("Certificates() without variable = %v, %v", certs, err)
+	}
+
+	if _, err = ParseSignatureLists(db[:len(db)-1]); err == nil {
+		t.Error("ParseSignatureLists() of a truncated list must fail")
+	}
+}
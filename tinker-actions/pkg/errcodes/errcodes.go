@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package errcodes is the catalogue of the error codes reported by the tinker actions.
+//
+// An action reports the class of its failure through its exit status, tink-worker reports it back
+// as "exit status <N>" in the action message, and the onboarding manager maps it back onto the code.
+// Exit statuses outside of the catalogue are reported as UNKNOWN. Shell actions source errcodes.sh,
+// which must be kept in sync with this file.
+package errcodes
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Code is a machine-readable class of provisioning failure.
+type Code string
+
+const (
+	// Unknown is any failure not classified by the action, e.g. a plain "exit 1".
+	Unknown Code = "UNKNOWN"
+	// Timeout is an action that did not complete within its timeout. It is reported by tink-worker,
+	// actions never exit with it.
+	Timeout Code = "TIMEOUT"
+
+	InvalidConfiguration Code = "INVALID_CONFIGURATION"
+	NoTargetDisk         Code = "NO_TARGET_DISK"
+	DownloadFailed       Code = "DOWNLOAD_FAILED"
+	ImageDigestMismatch  Code = "IMAGE_DIGEST_MISMATCH"
+	DiskWriteFailed      Code = "DISK_WRITE_FAILED"
+	SecureBootMismatch   Code = "SECURE_BOOT_MISMATCH"
+	FDEFailed            Code = "FDE_FAILED"
+	CommandFailed        Code = "COMMAND_FAILED"
+	BootConfigFailed     Code = "BOOT_CONFIG_FAILED"
+	PartitioningFailed   Code = "PARTITIONING_FAILED"
+	KernelUpgradeFailed  Code = "KERNEL_UPGRADE_FAILED"
+	DiskEraseFailed      Code = "DISK_ERASE_FAILED"
+	HardwareNonCompliant Code = "HARDWARE_NONCOMPLIANT"
+)
+
+// exitCodes are the exit statuses of the codes, in the range 80-99 reserved for the catalogue. It stays clear
+// of the statuses used by shells (1, 2, 126 and above) and of the sysexits.h range (64-78).
+var exitCodes = map[Code]int{
+	InvalidConfiguration: 80,
+	NoTargetDisk:         81,
+	DownloadFailed:       82,
+	ImageDigestMismatch:  83,
+	DiskWriteFailed:      84,
+	SecureBootMismatch:   85,
+	FDEFailed:            86,
+	CommandFailed:        87,
+	BootConfigFailed:     88,
+	PartitioningFailed:   89,
+	KernelUpgradeFailed:  90,
+	DiskEraseFailed:      91,
+	HardwareNonCompliant: 92,
+}
+
+// Codes returns the codes actions can exit with, keyed by their exit status.
+func Codes() map[int]Code {
+	codes := make(map[int]Code, len(exitCodes))
+	for code, exitCode := range exitCodes {
+		codes[exitCode] = code
+	}
+	return codes
+}
+
+// ExitCode returns the exit status of the code, 1 for codes actions cannot exit with.
+func (c Code) ExitCode() int {
+	if exitCode, ok := exitCodes[c]; ok {
+		return exitCode
+	}
+	return 1
+}
+
+// FromExitCode returns the code of an exit status, Unknown if it is not in the catalogue.
+func FromExitCode(exitCode int) Code {
+	for code, ec := range exitCodes {
+		if ec == exitCode {
+			return code
+		}
+	}
+	return Unknown
+}
+
+// Error is an error classified with a Code.
+type Error struct {
+	Code Code
+	Err  error
+}
+
+// Wrap classifies err with code. It returns nil if err is nil.
+func Wrap(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Err: err}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %v", e.Code, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// CodeOf returns the code of the first Error in the chain of err, Unknown if there is none.
+func CodeOf(err error) Code {
+	var codeErr *Error
+	if errors.As(err, &codeErr) {
+		return codeErr.Code
+	}
+	return Unknown
+}
+
+// Exit terminates the action with the exit status of code. The failure must be logged beforehand.
+func Exit(code Code) {
+	os.Exit(code.ExitCode())
+}
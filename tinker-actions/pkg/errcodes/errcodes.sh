@@ -0,0 +1,36 @@
+#!/bin/sh
+
+# SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+# SPDX-License-Identifier: Apache-2.0
+
+# Exit statuses of the error codes reported by the tinker actions, see errcodes.go.
+# Shell actions source this file and exit with one of these statuses on failure.
+
+ERR_INVALID_CONFIGURATION=80
+ERR_NO_TARGET_DISK=81
+ERR_DOWNLOAD_FAILED=82
+ERR_IMAGE_DIGEST_MISMATCH=83
+ERR_DISK_WRITE_FAILED=84
+ERR_SECURE_BOOT_MISMATCH=85
+ERR_FDE_FAILED=86
+ERR_COMMAND_FAILED=87
+ERR_BOOT_CONFIG_FAILED=88
+ERR_PARTITIONING_FAILED=89
+ERR_KERNEL_UPGRADE_FAILED=90
+ERR_DISK_ERASE_FAILED=91
+ERR_HARDWARE_NONCOMPLIANT=92
+
+# fail prints a message to stderr and exits with the given status.
+# usage: fail "$ERR_NO_TARGET_DISK" "no disk found"
+fail() {
+    echo "Error: $2" >&2
+    exit "$1"
+}
+
+# exit_with_on_failure makes the script exit with the given status when it fails with a status outside of
+# the catalogue, for scripts that do not classify each of their failures.
+# usage: exit_with_on_failure "$ERR_KERNEL_UPGRADE_FAILED"
+exit_with_on_failure() {
+    # shellcheck disable=SC2064 # the status is expanded when the trap is set
+    trap "_status=\$?; if [ \"\$_status\" -ne 0 ] && { [ \"\$_status\" -lt 80 ] || [ \"\$_status\" -gt 99 ]; }; then exit $1; fi" EXIT
+}
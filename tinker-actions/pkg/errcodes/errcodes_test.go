@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package errcodes
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// builtinActionCodes are the codes each built-in action, in src/, exits with.
+var builtinActionCodes = map[string][]Code{
+	"cexec":                     {InvalidConfiguration, NoTargetDisk, CommandFailed},
+	"disk_sanitize":             {InvalidConfiguration, DiskEraseFailed},
+	"efibootset":                {BootConfigFailed},
+	"emt_partition":             {NoTargetDisk, PartitioningFailed},
+	"erase_non_removable_disks": {DiskEraseFailed},
+	"fde_dmv":                   {NoTargetDisk, FDEFailed},
+	"hardware_preflight":        {InvalidConfiguration, HardwareNonCompliant},
+	"image2disk":                {InvalidConfiguration, NoTargetDisk, DownloadFailed, ImageDigestMismatch, DiskWriteFailed},
+	"kernelupgrd":               {KernelUpgradeFailed},
+	"qemu_nbd_image2disk":       {InvalidConfiguration, NoTargetDisk, DownloadFailed, ImageDigestMismatch, DiskWriteFailed},
+	"securebootflag":            {InvalidConfiguration, SecureBootMismatch},
+	"writefile":                 {InvalidConfiguration, NoTargetDisk, DiskWriteFailed},
+}
+
+func TestExitCodes(t *testing.T) {
+	codes := Codes()
+	if len(codes) != len(exitCodes) {
+		t.Fatalf("expected %d distinct exit statuses, got %d", len(exitCodes), len(codes))
+	}
+	for exitCode, code := range codes {
+		if exitCode < 80 || exitCode > 99 {
+			t.Errorf("exit status %d of %s is outside of the reserved range", exitCode, code)
+		}
+		if got := FromExitCode(exitCode); got != code {
+			t.Errorf("FromExitCode(%d) = %s, expected %s", exitCode, got, code)
+		}
+		if got := code.ExitCode(); got != exitCode {
+			t.Errorf("%s.ExitCode() = %d, expected %d", code, got, exitCode)
+		}
+	}
+	if got := FromExitCode(1); got != Unknown {
+		t.Errorf("FromExitCode(1) = %s, expected %s", got, Unknown)
+	}
+	if got := Timeout.ExitCode(); got != 1 {
+		t.Errorf("Timeout.ExitCode() = %d, expected 1", got)
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	if err := Wrap(DownloadFailed, nil); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	cause := errors.New("connection refused")
+	err := fmt.Errorf("error writing image to disk: %w", Wrap(DownloadFailed, cause))
+	if got := CodeOf(err); got != DownloadFailed {
+		t.Errorf("CodeOf(%v) = %s, expected %s", err, got, DownloadFailed)
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("expected %v to wrap %v", err, cause)
+	}
+	if got := CodeOf(cause); got != Unknown {
+		t.Errorf("CodeOf(%v) = %s, expected %s", cause, got, Unknown)
+	}
+}
+
+// TestShellCatalogue verifies that errcodes.sh defines the same exit statuses as the catalogue.
+func TestShellCatalogue(t *testing.T) {
+	script, err := os.ReadFile("errcodes.sh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defined := map[Code]int{}
+	for _, m := range regexp.MustCompile(`(?m)^ERR_([A-Z_]+)=(\d+)$`).FindAllStringSubmatch(string(script), -1) {
+		exitCode, _ := strconv.Atoi(m[2])
+		defined[Code(m[1])] = exitCode
+	}
+	if len(defined) != len(exitCodes) {
+		t.Errorf("errcodes.sh defines %d codes, expected %d", len(defined), len(exitCodes))
+	}
+	for code, exitCode := range exitCodes {
+		if defined[code] != exitCode {
+			t.Errorf("errcodes.sh defines ERR_%s=%d, expected %d", code, defined[code], exitCode)
+		}
+	}
+}
+
+// TestBuiltinActions verifies that every built-in action exits with its codes of the catalogue.
+func TestBuiltinActions(t *testing.T) {
+	actions, err := os.ReadDir(filepath.Join("..", "..", "src"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, action := range actions {
+		if !action.IsDir() {
+			continue
+		}
+		t.Run(action.Name(), func(t *testing.T) {
+			codes, ok := builtinActionCodes[action.Name()]
+			if !ok {
+				t.Fatalf("no error codes defined for action %s", action.Name())
+			}
+			sources := actionSources(t, filepath.Join("..", "..", "src", action.Name()))
+			for _, code := range codes {
+				if _, ok := exitCodes[code]; !ok {
+					t.Errorf("%s is not a code actions can exit with", code)
+				}
+				// Go actions import the package as ec
+				goRef, shRef := "ec."+goName(code), "$ERR_"+string(code)
+				if !strings.Contains(sources, goRef) && !strings.Contains(sources, shRef) {
+					t.Errorf("action %s never exits with %s", action.Name(), code)
+				}
+			}
+		})
+	}
+}
+
+// actionSources returns the Go and shell sources of an action, concatenated.
+func actionSources(t *testing.T, dir string) string {
+	t.Helper()
+	var sources strings.Builder
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == "pkg" {
+			// copy of the shared packages made by the image build
+			return filepath.SkipDir
+		}
+		if ext := filepath.Ext(path); ext == ".go" || ext == ".sh" {
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			sources.Write(b)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sources.String()
+}
+
+// goName returns the name of the Go constant of a code, e.g. NoTargetDisk for NO_TARGET_DISK.
+func goName(code Code) string {
+	for name, c := range map[string]Code{
+		"InvalidConfiguration": InvalidConfiguration,
+		"NoTargetDisk":         NoTargetDisk,
+		"DownloadFailed":       DownloadFailed,
+		"ImageDigestMismatch":  ImageDigestMismatch,
+		"DiskWriteFailed":      DiskWriteFailed,
+		"SecureBootMismatch":   SecureBootMismatch,
+		"FDEFailed":            FDEFailed,
+		"CommandFailed":        CommandFailed,
+		"BootConfigFailed":     BootConfigFailed,
+		"PartitioningFailed":   PartitioningFailed,
+		"KernelUpgradeFailed":  KernelUpgradeFailed,
+		"DiskEraseFailed":      DiskEraseFailed,
+		"HardwareNonCompliant": HardwareNonCompliant,
+	} {
+		if c == code {
+			return name
+		}
+	}
+	return string(code)
+}
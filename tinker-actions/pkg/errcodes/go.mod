@@ -0,0 +1,6 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+module github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes
+
+go 1.24.9
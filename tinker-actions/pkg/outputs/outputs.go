@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package outputs publishes the results of a tinker action to the actions that run after it.
+//
+// An action writes KEY=value lines to the outputs file in the workflow directory, which tink-worker mounts in
+// every action container. When the action succeeds, tink-worker merges its outputs into the outputs of the
+// workflow, passes them to the environment of the later actions, and reports them as "outputs: <JSON object>" in
+// the message of the action, for the onboarding manager to record the selected ones in the instance.
+package outputs
+
+import (
+	"bufio"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DefaultPath is the outputs file collected by tink-worker.
+const DefaultPath = "/workflow/outputs.env"
+
+// The outputs shared by the actions.
+const (
+	// TargetDisk is the disk the OS is installed on, e.g. /dev/sda. The actions that detect a disk use it
+	// instead when it is set.
+	TargetDisk = "TARGET_DISK"
+	// ImageSHA256 is the SHA-256 digest of the OS image written to the target disk.
+	ImageSHA256 = "IMAGE_SHA256"
+	// InstalledKernel is the version of the kernel installed on the target disk.
+	InstalledKernel = "INSTALLED_KERNEL"
+)
+
+var keyPattern = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+// Validate returns an error if key cannot be an output or value cannot be its value: the key is passed as an
+// environment variable, and the value must fit on one line.
+func Validate(key, value string) error {
+	if !keyPattern.MatchString(key) {
+		return fmt.Errorf("invalid output %q: the key must match %s", key, keyPattern)
+	}
+	if strings.ContainsAny(value, "\n\r\x00") {
+		return fmt.Errorf("invalid output %s: the value must be a single line", key)
+	}
+	return nil
+}
+
+// Read returns the outputs of the outputs file at path, nil if there is none.
+func Read(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(bufio.NewScanner(f))
+}
+
+// Parse returns the outputs of the KEY=value lines of s. Empty lines and lines starting with # are ignored, and
+// the last value of a key wins.
+func Parse(s *bufio.Scanner) (map[string]string, error) {
+	outputs := map[string]string{}
+	for n := 1; s.Scan(); n++ {
+		line := s.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: %q is not KEY=value", n, line)
+		}
+		if err := Validate(key, value); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		outputs[key] = value
+	}
+	return outputs, s.Err()
+}
+
+// Set adds or replaces the given outputs in the outputs file at path. The file is replaced atomically, so that
+// tink-worker never reads it partially written.
+func Set(path string, values map[string]string) error {
+	for key, value := range values {
+		if err := Validate(key, value); err != nil {
+			return err
+		}
+	}
+	outputs, err := Read(path)
+	if err != nil {
+		return err
+	}
+	if outputs == nil {
+		outputs = map[string]string{}
+	}
+	maps.Copy(outputs, values)
+
+	var b strings.Builder
+	for _, key := range slices.Sorted(maps.Keys(outputs)) {
+		b.WriteString(key + "=" + outputs[key] + "\n")
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(b.String()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
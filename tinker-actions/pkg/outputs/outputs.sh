@@ -0,0 +1,34 @@
+#!/bin/sh
+
+# SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+# SPDX-License-Identifier: Apache-2.0
+
+# Outputs of the tinker actions, see outputs.go. Shell actions source this file to publish their results to the
+# actions that run after them.
+
+OUTPUTS_FILE=${OUTPUTS_FILE:-/workflow/outputs.env}
+
+# set_output adds or replaces an output. The key must match ^[A-Z_][A-Z0-9_]*$ and the value fit on one line.
+# usage: set_output INSTALLED_KERNEL "$kernel_version"
+set_output() {
+    case "$1" in
+        "" | [0-9]* | *[!A-Z0-9_]*)
+            echo "Error: invalid output key $1" >&2
+            return 1
+            ;;
+    esac
+    case "$2" in
+        *"
+"*)
+            echo "Error: the value of the output $1 must be a single line" >&2
+            return 1
+            ;;
+    esac
+    [ -d "$(dirname "$OUTPUTS_FILE")" ] || return 0
+    _tmp=$(mktemp "$OUTPUTS_FILE.XXXXXX") || return 1
+    if [ -f "$OUTPUTS_FILE" ]; then
+        grep -v "^$1=" "$OUTPUTS_FILE" >"$_tmp"
+    fi
+    printf '%s=%s\n' "$1" "$2" >>"$_tmp"
+    mv "$_tmp" "$OUTPUTS_FILE"
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package outputs
+
+import (
+	"bufio"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outputs.env")
+	if err := Set(path, map[string]string{TargetDisk: "/dev/sda", ImageSHA256: "abc"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set(path, map[string]string{TargetDisk: "/dev/nvme0n1", "EMPTY": ""}); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "EMPTY=\nIMAGE_SHA256=abc\nTARGET_DISK=/dev/nvme0n1\n"
+	if string(b) != expected {
+		t.Errorf("outputs file = %q, expected %q", b, expected)
+	}
+}
+
+func TestSet_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outputs.env")
+	for _, values := range []map[string]string{
+		{"target_disk": "/dev/sda"},
+		{"1DISK": "/dev/sda"},
+		{"A-B": "c"},
+		{"": "c"},
+		{TargetDisk: "/dev/sda\n/dev/sdb"},
+	} {
+		if err := Set(path, values); err == nil {
+			t.Errorf("Set(%q) must fail", values)
+		}
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("invalid outputs must not be written: %v", err)
+	}
+}
+
+func TestRead(t *testing.T) {
+	dir := t.TempDir()
+	if outputs, err := Read(filepath.Join(dir, "missing.env")); err != nil || outputs != nil {
+		t.Errorf("Read(missing) = %v, %v, expected no outputs", outputs, err)
+	}
+
+	path := filepath.Join(dir, "outputs.env")
+	if err := os.WriteFile(path, []byte("# selected by image2disk\nTARGET_DISK=/dev/sda\n\nINSTALLED_KERNEL=6.6.0=rt\nTARGET_DISK=/dev/sdb\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	outputs, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{TargetDisk: "/dev/sdb", InstalledKernel: "6.6.0=rt"}
+	if !maps.Equal(outputs, expected) {
+		t.Errorf("Read() = %v, expected %v", outputs, expected)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, data := range []string{"TARGET_DISK", "export TARGET_DISK=/dev/sda", "disk=/dev/sda"} {
+		if _, err := Parse(bufio.NewScanner(strings.NewReader(data))); err == nil {
+			t.Errorf("Parse(%q) must fail", data)
+		}
+	}
+}
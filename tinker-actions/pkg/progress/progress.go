@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package progress publishes the byte-level progress of long running tinker actions.
+//
+// An action periodically replaces the progress file in the workflow directory, which tink-worker mounts in every
+// action container. tink-worker polls the file while the action is running and reports every new progress as
+// "progress: <JSON report>" in the message of the running action, for the onboarding manager to render it in the
+// provisioning status.
+package progress
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultPath is the progress file polled by tink-worker.
+const DefaultPath = "/workflow/progress.json"
+
+// Report is the progress of an action, as published in the progress file.
+type Report struct {
+	// Stage describes what the action is doing, e.g. "writing OS image".
+	Stage string `json:"stage"`
+	// BytesWritten is the number of bytes processed so far.
+	BytesWritten int64 `json:"bytesWritten"`
+	// TotalBytes is the number of bytes to process, 0 if unknown.
+	TotalBytes int64 `json:"totalBytes,omitempty"`
+	// Percent is the completion of the stage from 0 to 100, -1 if unknown.
+	Percent int `json:"percent"`
+	// BytesPerSecond is the throughput since the previous report.
+	BytesPerSecond int64 `json:"bytesPerSecond"`
+}
+
+// Publisher publishes the progress of a stage to a progress file. It is safe for concurrent use.
+type Publisher struct {
+	path  string
+	stage string
+
+	mu        sync.Mutex
+	lastBytes int64
+	lastTime  time.Time
+	now       func() time.Time
+}
+
+// NewPublisher returns a Publisher of the progress of stage to the progress file at path.
+func NewPublisher(path, stage string) *Publisher {
+	return &Publisher{path: path, stage: stage, lastTime: time.Now(), now: time.Now}
+}
+
+// Percent returns the completion of done out of total, -1 if total is unknown.
+func Percent(done, total int64) int {
+	if total <= 0 {
+		return -1
+	}
+	if done >= total {
+		return 100
+	}
+	return int(done * 100 / total)
+}
+
+// Publish publishes that written out of total bytes (0 if unknown) are processed, and the completion of the
+// stage in percent (-1 if unknown). The throughput is computed since the previous call.
+func (p *Publisher) Publish(written, total int64, percent int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var rate int64
+	if elapsed := now.Sub(p.lastTime).Seconds(); elapsed > 0 && written >= p.lastBytes {
+		rate = int64(float64(written-p.lastBytes) / elapsed)
+	}
+	p.lastBytes, p.lastTime = written, now
+
+	return Write(p.path, Report{
+		Stage:          p.stage,
+		BytesWritten:   written,
+		TotalBytes:     total,
+		Percent:        percent,
+		BytesPerSecond: rate,
+	})
+}
+
+// Write atomically replaces the progress file at path with report, so that it is never read partially written.
+func Write(path string, report Report) error {
+	b, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
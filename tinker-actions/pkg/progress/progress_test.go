@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package progress
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		done, total int64
+		want        int
+	}{
+		{0, 0, -1},
+		{10, -1, -1},
+		{0, 200, 0},
+		{124, 200, 62},
+		{200, 200, 100},
+		{300, 200, 100},
+	}
+	for _, tt := range tests {
+		if got := Percent(tt.done, tt.total); got != tt.want {
+			t.Errorf("Percent(%d, %d) = %d, expected %d", tt.done, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestPublish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+	start := time.Now()
+	now := start
+	p := NewPublisher(path, "writing OS image")
+	p.lastTime, p.now = start, func() time.Time { return now }
+
+	now = start.Add(2 * time.Second)
+	if err := p.Publish(100_000_000, 200_000_000, 50); err != nil {
+		t.Fatal(err)
+	}
+	expected := Report{
+		Stage: "writing OS image", BytesWritten: 100_000_000, TotalBytes: 200_000_000, Percent: 50,
+		BytesPerSecond: 50_000_000,
+	}
+	if got := readReport(t, path); got != expected {
+		t.Errorf("got %+v, expected %+v", got, expected)
+	}
+
+	now = start.Add(6 * time.Second)
+	if err := p.Publish(180_000_000, 0, -1); err != nil {
+		t.Fatal(err)
+	}
+	expected = Report{Stage: "writing OS image", BytesWritten: 180_000_000, Percent: -1, BytesPerSecond: 20_000_000}
+	if got := readReport(t, path); got != expected {
+		t.Errorf("got %+v, expected %+v", got, expected)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the progress file, got %d files", len(entries))
+	}
+}
+
+func readReport(t *testing.T, path string) Report {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r Report
+	if err := json.Unmarshal(b, &r); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
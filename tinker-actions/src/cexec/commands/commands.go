@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package commands runs an ordered list of commands, each with its own timeout, working directory, environment
+// and allowed exit codes, and reports their bounded output.
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultInterpreter runs the scripts of the commands that do not set an interpreter.
+const DefaultInterpreter = "/bin/sh -c"
+
+// Command is one command to run.
+type Command struct {
+	// Name identifies the command in the report, "command <n>" by default.
+	Name string `json:"name,omitempty"`
+	// Args is the command and its arguments, run without a shell.
+	Args []string `json:"args,omitempty"`
+	// Script is run by the interpreter, e.g. "apt-get -y update && apt-get -y upgrade".
+	Script string `json:"script,omitempty"`
+	// Interpreter runs the script, DEFAULT_INTERPRETER or DefaultInterpreter by default.
+	Interpreter string `json:"interpreter,omitempty"`
+	// Timeout is a duration, e.g. "10m". The command is killed, with its children, when it expires. Without
+	// timeout the command is only bounded by the timeout of the action.
+	Timeout string `json:"timeout,omitempty"`
+	// Dir is the working directory, the root of the target filesystem by default.
+	Dir string `json:"dir,omitempty"`
+	// Env is added to the environment of the action.
+	Env map[string]string `json:"env,omitempty"`
+	// AllowedExitCodes are the exit codes of a successful command, 0 by default.
+	AllowedExitCodes []int `json:"allowedExitCodes,omitempty"`
+
+	argv    []string
+	timeout time.Duration
+}
+
+// Parse decodes a JSON list of commands and prepares them with Prepare.
+func Parse(data, defaultInterpreter string) ([]Command, error) {
+	var cmds []Command
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cmds); err != nil {
+		return nil, fmt.Errorf("invalid commands: %w", err)
+	}
+	if len(cmds) == 0 {
+		return nil, errors.New("invalid commands: no command")
+	}
+	if err := Prepare(cmds, defaultInterpreter); err != nil {
+		return nil, err
+	}
+	return cmds, nil
+}
+
+// FromCmdLine returns the commands of the legacy CMD_LINE: the command line run by the default interpreter, or
+// else its commands separated by semicolons, split on spaces.
+func FromCmdLine(cmdLine, defaultInterpreter string) ([]Command, error) {
+	var cmds []Command
+	if defaultInterpreter != "" {
+		cmds = []Command{{Script: cmdLine, Interpreter: defaultInterpreter}}
+	} else {
+		for _, line := range strings.Split(cmdLine, ";") {
+			cmds = append(cmds, Command{Args: strings.Split(line, " ")})
+		}
+	}
+	if err := Prepare(cmds, defaultInterpreter); err != nil {
+		return nil, err
+	}
+	return cmds, nil
+}
+
+// Prepare validates the commands and sets their defaults, so that no command runs unless all of them are valid.
+func Prepare(cmds []Command, defaultInterpreter string) error {
+	if defaultInterpreter == "" {
+		defaultInterpreter = DefaultInterpreter
+	}
+	for i := range cmds {
+		c := &cmds[i]
+		if c.Name == "" {
+			c.Name = fmt.Sprintf("command %d", i+1)
+		}
+		if err := c.prepare(defaultInterpreter); err != nil {
+			return fmt.Errorf("invalid command %q: %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c *Command) prepare(defaultInterpreter string) error {
+	switch {
+	case len(c.Args) > 0 && c.Script != "":
+		return errors.New("args and script are mutually exclusive")
+	case len(c.Args) > 0:
+		if c.Interpreter != "" {
+			return errors.New("only scripts have an interpreter")
+		}
+		if c.Args[0] == "" {
+			return errors.New("empty command")
+		}
+		c.argv = c.Args
+	case c.Script != "":
+		interpreter := c.Interpreter
+		if interpreter == "" {
+			interpreter = defaultInterpreter
+		}
+		c.argv = append(strings.Fields(interpreter), c.Script)
+		if len(c.argv) == 1 {
+			return errors.New("empty interpreter")
+		}
+	default:
+		return errors.New("args or script is required")
+	}
+
+	if c.Timeout != "" {
+		timeout, err := time.ParseDuration(c.Timeout)
+		if err != nil {
+			return fmt.Errorf("timeout: %w", err)
+		}
+		if timeout <= 0 {
+			return errors.New("timeout must be positive")
+		}
+		c.timeout = timeout
+	}
+	if c.Dir != "" && !strings.HasPrefix(c.Dir, "/") {
+		return errors.New("dir must be absolute")
+	}
+	for name := range c.Env {
+		if name == "" || strings.ContainsAny(name, "=\x00") {
+			return fmt.Errorf("invalid environment variable %q", name)
+		}
+	}
+	if len(c.AllowedExitCodes) == 0 {
+		c.AllowedExitCodes = []int{0}
+	}
+	for _, code := range c.AllowedExitCodes {
+		if code < 0 || code > 255 {
+			return fmt.Errorf("invalid exit code %d", code)
+		}
+	}
+	return nil
+}
+
+func (c *Command) allows(exitCode int) bool {
+	return slices.Contains(c.AllowedExitCodes, exitCode)
+}
+
+// environ returns the environment of the action extended with the environment of the command.
+func (c *Command) environ(base []string) []string {
+	env := slices.Clone(base)
+	for _, name := range slices.Sorted(maps.Keys(c.Env)) {
+		env = append(env, name+"="+c.Env[name])
+	}
+	return env
+}
@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func parse(t *testing.T, data string) []Command {
+	t.Helper()
+	cmds, err := Parse(data, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cmds
+}
+
+func TestParse(t *testing.T) {
+	cmds := parse(t, `[
+		{"name": "update", "args": ["apt-get", "-y", "update"], "timeout": "10m", "allowedExitCodes": [0, 100]},
+		{"script": "echo $GREETING", "env": {"GREETING": "hello"}, "dir": "/tmp"},
+		{"script": "print('hello')", "interpreter": "/usr/bin/python3 -c"}
+	]`)
+
+	if cmds[0].Name != "update" || cmds[0].timeout != 10*time.Minute || !cmds[0].allows(100) || cmds[0].allows(1) {
+		t.Errorf("args command = %+v", cmds[0])
+	}
+	if got := strings.Join(cmds[1].argv, "|"); got != "/bin/sh|-c|echo $GREETING" {
+		t.Errorf("argv of the script = %q", got)
+	}
+	if cmds[1].Name != "command 2" || !cmds[1].allows(0) || cmds[1].Dir != "/tmp" {
+		t.Errorf("script command = %+v", cmds[1])
+	}
+	if got := strings.Join(cmds[2].argv, "|"); got != "/usr/bin/python3|-c|print('hello')" {
+		t.Errorf("argv of the interpreted script = %q", got)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"Not a list":           `{"script": "true"}`,
+		"No command":           `[]`,
+		"Unknown field":        `[{"script": "true", "retries": 3}]`,
+		"Nothing to run":       `[{"name": "empty"}]`,
+		"Args and script":      `[{"args": ["true"], "script": "true"}]`,
+		"Args and interpreter": `[{"args": ["true"], "interpreter": "/bin/sh -c"}]`,
+		"Empty command":        `[{"args": [""]}]`,
+		"Empty interpreter":    `[{"script": "true", "interpreter": " "}]`,
+		"Bad timeout":          `[{"script": "true", "timeout": "10"}]`,
+		"Negative timeout":     `[{"script": "true", "timeout": "-1s"}]`,
+		"Relative dir":         `[{"script": "true", "dir": "tmp"}]`,
+		"Bad environment":      `[{"script": "true", "env": {"A=B": "C"}}]`,
+		"Bad exit code":        `[{"script": "true", "allowedExitCodes": [256]}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse(data, ""); err == nil {
+				t.Errorf("Parse(%s) must fail", data)
+			}
+		})
+	}
+}
+
+func TestFromCmdLine(t *testing.T) {
+	cmds, err := FromCmdLine("apt-get -y update; apt-get -y upgrade", "/bin/bash -c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cmds) != 1 || strings.Join(cmds[0].argv, "|") != "/bin/bash|-c|apt-get -y update; apt-get -y upgrade" {
+		t.Errorf("FromCmdLine() with interpreter = %+v", cmds)
+	}
+
+	cmds, err = FromCmdLine("update-grub;grub-install /dev/sda", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cmds) != 2 || strings.Join(cmds[1].argv, "|") != "grub-install|/dev/sda" || cmds[1].Dir != "" {
+		t.Errorf("FromCmdLine() without interpreter = %+v", cmds)
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	cmds := parse(t, `[
+		{"name": "env", "script": "echo $GREETING from $(pwd); echo oops >&2", "env": {"GREETING": "hello"}, "dir": "`+dir+`"},
+		{"name": "allowed", "args": ["sh", "-c", "exit 100"], "allowedExitCodes": [0, 100]},
+		{"name": "denied", "args": ["sh", "-c", "echo failing; exit 3"]},
+		{"name": "never", "args": ["true"]}
+	]`)
+	var stdout bytes.Buffer
+	report, err := Runner{Stdout: &stdout}.Run(context.Background(), cmds)
+	if err == nil || !strings.Contains(err.Error(), "denied: exit code 3 is not allowed") {
+		t.Fatalf("Run() = %v, want the failure of the denied command", err)
+	}
+
+	if len(report.Commands) != 3 {
+		t.Fatalf("the commands after a failure must not run: %+v", report)
+	}
+	env := report.Commands[0]
+	if env.Stdout != "hello from "+dir+"\n" || env.Stderr != "oops\n" || env.ExitCode != 0 || env.Error != "" {
+		t.Errorf("env result = %+v", env)
+	}
+	if allowed := report.Commands[1]; allowed.ExitCode != 100 || allowed.Error != "" {
+		t.Errorf("allowed result = %+v", allowed)
+	}
+	if denied := report.Commands[2]; denied.ExitCode != 3 || denied.Stdout != "failing\n" {
+		t.Errorf("denied result = %+v", denied)
+	}
+	if got := stdout.String(); got != "hello from "+dir+"\nfailing\n" {
+		t.Errorf("streamed output = %q", got)
+	}
+}
+
+func TestRun_Timeout(t *testing.T) {
+	// The background sleep holds the output open: the whole process group must be killed.
+	cmds := parse(t, `[{"name": "stalled apt", "script": "echo started; sleep 30 & sleep 30", "timeout": "200ms"}]`)
+	start := time.Now()
+	report, err := Runner{}.Run(context.Background(), cmds)
+	if elapsed := time.Since(start); elapsed > waitDelay {
+		t.Errorf("Run() took %s, the command must be killed with its children on timeout", elapsed)
+	}
+	if err == nil || !strings.Contains(err.Error(), "timed out after 200ms") {
+		t.Errorf("Run() = %v, want a timeout", err)
+	}
+	if r := report.Commands[0]; !r.TimedOut || r.ExitCode != -1 || r.Stdout != "started\n" {
+		t.Errorf("timed out result = %+v", r)
+	}
+}
+
+func TestRun_NotFound(t *testing.T) {
+	report, err := Runner{}.Run(context.Background(), parse(t, `[{"args": ["no-such-command"]}]`))
+	if err == nil || report.Commands[0].ExitCode != -1 || report.Commands[0].Error == "" {
+		t.Errorf("Run() = %+v, %v, want a failure to start", report, err)
+	}
+}
+
+func TestRun_OutputLimit(t *testing.T) {
+	cmds := parse(t, `[{"script": "seq 1 1000"}]`)
+	report, err := Runner{OutputLimit: 16}.Run(context.Background(), cmds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := report.Commands[0]; r.Stdout != "97\n998\n999\n1000\n" || !r.Truncated {
+		t.Errorf("bounded result = %+v", r)
+	}
+}
+
+// TestRun_Root runs a command chrooted in its own mount namespace, in a private bind mount of the root of the test.
+// It requires root.
+func TestRun_Root(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("chroot requires root")
+	}
+	root := t.TempDir()
+	if err := syscall.Mount("/", root, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		t.Skipf("cannot bind mount the root: %v", err)
+	}
+	defer func() { _ = syscall.Unmount(root, syscall.MNT_DETACH) }()
+	if err := syscall.Mount("", root, "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	cmds := parse(t, `[{"script": "mount -t tmpfs none /mnt && pwd"}]`)
+	report, err := Runner{Root: root}.Run(context.Background(), cmds)
+	if err != nil {
+		t.Skipf("cannot create mount namespaces: %v: %+v", err, report)
+	}
+	if got := report.Commands[0].Stdout; got != "/\n" {
+		t.Errorf("working directory = %q, want the root", got)
+	}
+	mounts, err := os.ReadFile("/proc/self/mounts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(mounts), filepath.Join(root, "mnt")+" tmpfs") {
+		t.Error("the mounts of a command must not outlive it")
+	}
+}
+
+func TestLookPath_Root(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"usr/bin", "etc/alternatives", "sbin"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "usr", "bin", "vim.basic"), nil, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "usr", "bin", "notes"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// An absolute link must be resolved in the root, not in the filesystem of the action.
+	if err := os.Symlink("/usr/bin/vim.basic", filepath.Join(root, "etc", "alternatives", "editor")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("/etc/alternatives/editor", filepath.Join(root, "usr", "bin", "editor")); err != nil {
+		t.Fatal(err)
+	}
+
+	r := Runner{Root: root}
+	env := []string{"PATH=/sbin:/usr/bin"}
+	if got, err := r.lookPath("editor", env); err != nil || got != "/usr/bin/editor" {
+		t.Errorf("lookPath(editor) = %q, %v", got, err)
+	}
+	if got, err := r.lookPath("/opt/tool", env); err != nil || got != "/opt/tool" {
+		t.Errorf("lookPath(/opt/tool) = %q, %v", got, err)
+	}
+	for _, name := range []string{"notes", "missing"} {
+		if _, err := r.lookPath(name, env); err == nil {
+			t.Errorf("lookPath(%s) must fail", name)
+		}
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	report := Report{Commands: []Result{
+		{Name: "small", Stdout: "ok\n"},
+		{Name: "large", ExitCode: 1, Stdout: strings.Repeat("é", MaxReportSize), Error: "exit code 1 is not allowed"},
+	}}
+	if err := WriteReport(path, report); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) > MaxReportSize {
+		t.Errorf("report of %d bytes, want at most %d", len(data), MaxReportSize)
+	}
+	var got Report
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Commands[0].Stdout != "ok\n" || got.Commands[0].Truncated {
+		t.Errorf("small output = %+v", got.Commands[0])
+	}
+	if large := got.Commands[1]; !large.Truncated || !strings.HasSuffix(large.Stdout, "éé") ||
+		strings.ContainsRune(large.Stdout, '�') || large.Error == "" {
+		t.Errorf("large output must keep its end: %+v", large)
+	}
+}
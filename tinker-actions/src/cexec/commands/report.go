@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultReportPath is the report file tink-worker reports in the message of the action.
+	DefaultReportPath = "/workflow/report.json"
+	// MaxReportSize is the size above which tink-worker drops a report. WriteReport shortens the output of the
+	// commands to stay below it.
+	MaxReportSize = 15 * 1024
+)
+
+// Report is the structured output of the commands, as published in the report file.
+type Report struct {
+	Commands []Result `json:"commands"`
+}
+
+// Result is the outcome of one command.
+type Result struct {
+	Name string `json:"name"`
+	// ExitCode is -1 if the command did not start or was killed.
+	ExitCode int    `json:"exitCode"`
+	TimedOut bool   `json:"timedOut,omitempty"`
+	Duration string `json:"duration,omitempty"`
+	// Stdout and Stderr are the end of the output of the command.
+	Stdout string `json:"stdout,omitempty"`
+	Stderr string `json:"stderr,omitempty"`
+	// Truncated is true if the beginning of the output was dropped.
+	Truncated bool `json:"truncated,omitempty"`
+	// Error is why the command failed, empty if it succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// WriteReport atomically replaces the report file at path. The output of the commands is shortened, keeping its
+// end, until the report fits in MaxReportSize.
+func WriteReport(path string, report Report) error {
+	b, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	for limit := DefaultOutputLimit; len(b) > MaxReportSize && limit > 0; limit /= 2 {
+		shortened := Report{Commands: make([]Result, len(report.Commands))}
+		for i, r := range report.Commands {
+			var stdoutCut, stderrCut bool
+			r.Stdout, stdoutCut = lastBytes(r.Stdout, limit)
+			r.Stderr, stderrCut = lastBytes(r.Stderr, limit)
+			r.Truncated = r.Truncated || stdoutCut || stderrCut
+			shortened.Commands[i] = r
+		}
+		if b, err = json.Marshal(shortened); err != nil {
+			return err
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// lastBytes returns the last n bytes of s at most, starting on a rune, and whether s was cut.
+func lastBytes(s string, n int) (string, bool) {
+	if len(s) <= n {
+		return s, false
+	}
+	return trimPartialRune(s[len(s)-n:]), true
+}
+
+// trimPartialRune drops the continuation bytes a cut left at the beginning of s.
+func trimPartialRune(s string) string {
+	for len(s) > 0 && !utf8.RuneStart(s[0]) {
+		s = s[1:]
+	}
+	return s
+}
+
+// tail is a writer keeping the last bytes written to it. It is safe for concurrent use.
+type tail struct {
+	mu        sync.Mutex
+	limit     int
+	buf       []byte
+	truncated bool
+}
+
+func newTail(limit int) *tail {
+	return &tail{limit: limit}
+}
+
+func (t *tail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.buf = append(t.buf, p...)
+	if over := len(t.buf) - t.limit; over > 0 {
+		t.buf = append(t.buf[:0], t.buf[over:]...)
+		t.truncated = true
+	}
+	return len(p), nil
+}
+
+func (t *tail) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.truncated {
+		return trimPartialRune(string(t.buf))
+	}
+	return string(t.buf)
+}
@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultOutputLimit is the number of bytes kept of each output stream of each command.
+	DefaultOutputLimit = 4096
+	// waitDelay bounds the wait for the output of a killed command, which its orphaned children may hold open.
+	waitDelay = 5 * time.Second
+	// defaultPath looks up the commands in the target filesystem when the environment has no PATH.
+	defaultPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+)
+
+// Runner runs commands.
+type Runner struct {
+	// Root is the target filesystem the commands run in, each in its own mount namespace so that what they mount
+	// does not outlive them. The mounts under Root must be private for theirs not to propagate back. The commands
+	// run in the filesystem of the action if Root is empty.
+	Root string
+	// OutputLimit bounds the output kept of each stream of each command, DefaultOutputLimit if zero.
+	OutputLimit int
+	// Stdout and Stderr, if not nil, receive the output of the commands as it is produced.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Run runs the prepared commands in order, and stops at the first one that fails, times out or exits with a
+// code it does not allow. The report holds the results of the commands run so far.
+func (r Runner) Run(ctx context.Context, cmds []Command) (Report, error) {
+	var report Report
+	for i := range cmds {
+		result := r.run(ctx, &cmds[i])
+		report.Commands = append(report.Commands, result)
+		if result.Error != "" {
+			return report, fmt.Errorf("%s: %s", result.Name, result.Error)
+		}
+	}
+	return report, nil
+}
+
+func (r Runner) run(ctx context.Context, c *Command) Result {
+	result := Result{Name: c.Name, ExitCode: -1}
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	env := c.environ(os.Environ())
+	path, err := r.lookPath(c.argv[0], env)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	limit := r.OutputLimit
+	if limit <= 0 {
+		limit = DefaultOutputLimit
+	}
+	stdout, stderr := newTail(limit), newTail(limit)
+
+	cmd := exec.CommandContext(ctx, path, c.argv[1:]...)
+	cmd.Args[0] = c.argv[0]
+	cmd.Env = env
+	cmd.Dir = c.Dir
+	cmd.Stdout, cmd.Stderr = tee(r.Stdout, stdout), tee(r.Stderr, stderr)
+	// The command runs in its own process group, to kill its children with it.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if r.Root != "" {
+		cmd.SysProcAttr.Chroot = r.Root
+		cmd.SysProcAttr.Cloneflags = syscall.CLONE_NEWNS
+		if cmd.Dir == "" {
+			cmd.Dir = "/"
+		}
+	}
+	cmd.Cancel = func() error { return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL) }
+	cmd.WaitDelay = waitDelay
+
+	start := time.Now()
+	err = cmd.Run()
+	result.Duration = time.Since(start).Round(time.Millisecond).String()
+	result.Stdout, result.Stderr = stdout.String(), stderr.String()
+	result.Truncated = stdout.truncated || stderr.truncated
+
+	var exitErr *exec.ExitError
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		result.TimedOut = true
+		result.Error = fmt.Sprintf("timed out after %s", c.timeout)
+	case ctx.Err() != nil:
+		result.Error = ctx.Err().Error()
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+		if result.ExitCode < 0 {
+			result.Error = exitErr.Error()
+		} else if !c.allows(result.ExitCode) {
+			result.Error = fmt.Sprintf("exit code %d is not allowed", result.ExitCode)
+		}
+	case err != nil:
+		result.Error = err.Error()
+	default:
+		result.ExitCode = 0
+		if !c.allows(0) {
+			result.Error = "exit code 0 is not allowed"
+		}
+	}
+	return result
+}
+
+// lookPath returns the path of the command name, looked up in the PATH of env inside the root of the runner.
+func (r Runner) lookPath(name string, env []string) (string, error) {
+	if r.Root == "" {
+		return exec.LookPath(name)
+	}
+	if strings.Contains(name, "/") {
+		return name, nil
+	}
+	path := defaultPath
+	for _, kv := range env {
+		if p, ok := strings.CutPrefix(kv, "PATH="); ok {
+			path = p
+		}
+	}
+	for _, dir := range filepath.SplitList(path) {
+		if !filepath.IsAbs(dir) {
+			continue
+		}
+		candidate := filepath.Join(dir, name)
+		info, err := r.stat(candidate)
+		if err == nil && info.Mode().IsRegular() && info.Mode().Perm()&0o111 != 0 {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("%s: executable file not found in $PATH of %s", name, r.Root)
+}
+
+// stat returns the FileInfo of path inside the root of the runner, following the symbolic links of its last
+// component as the command would: absolute targets are resolved from the root, e.g. the links of
+// /etc/alternatives.
+func (r Runner) stat(path string) (os.FileInfo, error) {
+	for range 40 {
+		full := filepath.Join(r.Root, path)
+		info, err := os.Lstat(full)
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			return info, err
+		}
+		target, err := os.Readlink(full)
+		if err != nil {
+			return nil, err
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(path), target)
+		}
+		path = target
+	}
+	return nil, fmt.Errorf("%s: too many levels of symbolic links", path)
+}
+
+func tee(w io.Writer, t *tail) io.Writer {
+	if w == nil {
+		return t
+	}
+	return io.MultiWriter(w, t)
+}
@@ -13,13 +13,13 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"os/exec"
 	"os/signal"
 	"path/filepath"
-	"strings"
 	"syscall"
 
+	"cexec/commands"
 	dd "github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/drive_detection"
+	ec "github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes"
 	"github.com/peterbourgon/ff/v3"
 )
 
@@ -34,6 +34,10 @@ type settings struct {
 	chroot             string
 	defaultInterpreter string
 	cmdLine            string
+	commands           string
+	mountNamespace     bool
+	outputLimit        int
+	reportPath         string
 	updateResolvConf   bool
 }
 
@@ -48,33 +52,32 @@ func main() {
 	fs.StringVar(&s.filesystemType, "fs-type", "", "filesystem type (required)")
 	fs.StringVar(&s.chroot, "chroot", "", "use chroot environment to run given command (deprecated)")
 	fs.StringVar(&s.defaultInterpreter, "default-interpreter", "", "default interpreter (optional)")
-	fs.StringVar(&s.cmdLine, "cmd-line", "", "command line to execute (required)")
+	fs.StringVar(&s.cmdLine, "cmd-line", "", "command line to execute (required unless commands are given)")
+	fs.StringVar(&s.commands, "commands", "", "JSON list of commands to execute in order (optional)")
+	fs.BoolVar(&s.mountNamespace, "mount-namespace", false, "run the commands in the block device filesystem, each in its own mount namespace (optional)")
+	fs.IntVar(&s.outputLimit, "output-limit", commands.DefaultOutputLimit, "bytes of output kept of each stream of each command in the report (optional)")
+	fs.StringVar(&s.reportPath, "report-path", commands.DefaultReportPath, "file to write the report of the commands to, empty to disable (optional)")
 	fs.BoolVar(&s.updateResolvConf, "update-resolv-conf", false, "update /etc/resolv.conf in chroot environment (optional)")
 	jsonLogger := fs.Bool("json-outout", true, "enable json output for logging")
 
 	if err := ff.Parse(fs, os.Args[1:], ff.WithEnvVarNoPrefix()); err != nil {
 		logger.Error(err.Error())
-		os.Exit(10)
+		ec.Exit(ec.InvalidConfiguration)
 	}
 
 	blockDevice := s.blockDevice
 	if len(blockDevice) == 0 {
-		// Get a list of drives
-		drives, err := dd.GetDrives()
-		if err != nil {
-			logger.Error("Get Drive Error", "err", err)
-			os.Exit(1)
-		}
-		detectedDisk, err := dd.DriveDetection(drives)
+		// The disk selected by a previous action, or else detected
+		detectedDisk, err := dd.SelectedDrive()
 		if err != nil {
 			logger.Error("Drive detection Error", "err", err)
-			os.Exit(1)
+			ec.Exit(ec.NoTargetDisk)
 		}
 		logger.Info("Detected drive:", "detectedDisk", detectedDisk)
 		blockDevice, err = dd.FindRootPartitionForDisk(detectedDisk)
 		if err != nil {
 			logger.Error("Root partition find Error", "err", err)
-			os.Exit(1)
+			ec.Exit(ec.NoTargetDisk)
 		}
 		logger.Info("Drive detected by automation:", "blockDevice", blockDevice)
 	} else {
@@ -87,10 +90,14 @@ func main() {
 		fmt.Fprintln(os.Stderr, "missing required fields", missingFields)
 		fmt.Fprintln(os.Stderr)
 		fs.Usage()
-		os.Exit(20)
+		ec.Exit(ec.InvalidConfiguration)
 	}
 
-	// TODO(jacobweinstock): add field validations for the settings struct.
+	cmds, err := s.parseCommands()
+	if err != nil {
+		logger.Error(err.Error())
+		ec.Exit(ec.InvalidConfiguration)
+	}
 
 	if *jsonLogger {
 		logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
@@ -102,13 +109,22 @@ func main() {
 		"chroot", s.chroot,
 		"default-interpreter", s.defaultInterpreter,
 		"cmd-line", s.cmdLine,
+		"commands", len(cmds),
+		"mount-namespace", s.mountNamespace,
 		"json-output", *jsonLogger,
 		"update-resolv-conf", s.updateResolvConf,
 	)
 
-	if err := s.cexec(ctx, logger); err != nil {
+	// The report is written once out of the chroot, where the workflow directory is mounted.
+	report, err := s.cexec(ctx, logger, cmds)
+	if s.reportPath != "" && len(report.Commands) > 0 {
+		if err := commands.WriteReport(s.reportPath, report); err != nil {
+			logger.Warn("unable to write the report", "path", s.reportPath, "error", err)
+		}
+	}
+	if err != nil {
 		logger.ErrorContext(ctx, err.Error())
-		os.Exit(30)
+		ec.Exit(ec.CommandFailed)
 	}
 }
 
@@ -120,31 +136,51 @@ func (s settings) checkRequiredFields() []string {
 	if s.filesystemType == "" {
 		missingFields = append(missingFields, "fs-type")
 	}
-	if s.cmdLine == "" {
+	if s.cmdLine == "" && s.commands == "" {
 		missingFields = append(missingFields, "cmd-line")
 	}
 	return missingFields
 }
 
-func (s settings) cexec(ctx context.Context, log *slog.Logger) error {
+// parseCommands validates the settings of the commands and returns them, either the COMMANDS or the commands of
+// the CMD_LINE.
+func (s settings) parseCommands() ([]commands.Command, error) {
+	if s.cmdLine != "" && s.commands != "" {
+		return nil, errors.New("cmd-line and commands are mutually exclusive")
+	}
+	if s.chroot != "" && s.mountNamespace {
+		return nil, errors.New("chroot and mount-namespace are mutually exclusive")
+	}
+	if s.outputLimit <= 0 {
+		return nil, fmt.Errorf("output-limit must be positive, got %d", s.outputLimit)
+	}
+	if s.commands != "" {
+		return commands.Parse(s.commands, s.defaultInterpreter)
+	}
+	return commands.FromCmdLine(s.cmdLine, s.defaultInterpreter)
+}
+
+// cexec mounts the block device and runs the commands, in a chroot, in mount namespaces or in the filesystem of the
+// action. It returns the report of the commands it ran.
+func (s settings) cexec(ctx context.Context, log *slog.Logger, cmds []commands.Command) (commands.Report, error) {
 	log.Info("CEXEC - Chroot Exec")
 
 	if s.blockDevice == "" {
-		return errors.New("no Block Device speified with Environment Variable [BLOCK_DEVICE]")
+		return commands.Report{}, errors.New("no Block Device speified with Environment Variable [BLOCK_DEVICE]")
 	}
 
 	// Create the /mountAction mountpoint (no folders exist previously in scratch container)
 	if err := os.Mkdir(mountAction, os.ModeDir); err != nil {
-		return fmt.Errorf("error creating the mount point [%s], error: %w", mountAction, err)
+		return commands.Report{}, fmt.Errorf("error creating the mount point [%s], error: %w", mountAction, err)
 	}
 
 	// Mount the block device to the /mountAction point
 	if err := syscall.Mount(s.blockDevice, mountAction, s.filesystemType, 0, ""); err != nil {
-		return fmt.Errorf("error mounting [%s] -> [%s], error: %v", s.blockDevice, mountAction, err)
+		return commands.Report{}, fmt.Errorf("error mounting [%s] -> [%s], error: %v", s.blockDevice, mountAction, err)
 	}
 	log.Info("mounted device successfully", "source", s.blockDevice, "destination", mountAction)
 
-	if s.chroot != "" {
+	if s.chroot != "" || s.mountNamespace {
 		if s.updateResolvConf {
 			// fix resolv.conf as it normally doesn't work in chroot
 			// backup the original resolv.conf
@@ -158,63 +194,39 @@ func (s settings) cexec(ctx context.Context, log *slog.Logger) error {
 				}()
 				// create an empty resolv.conf in the chroot so that it can be bind mounted
 				if _, err := os.Create(resolv); err != nil {
-					return fmt.Errorf("error creating resolv.conf, resolv.conf will not work in the chroot: %w", err)
+					return commands.Report{}, fmt.Errorf("error creating resolv.conf, resolv.conf will not work in the chroot: %w", err)
 				}
 			} else {
-				return fmt.Errorf("error backing up resolv.conf, resolv.conf will not work in the chroot: %w", err)
+				return commands.Report{}, fmt.Errorf("error backing up resolv.conf, resolv.conf will not work in the chroot: %w", err)
 			}
 		}
 
-		if err := s.mountSpecialDirs(); err != nil {
-			return err
+		if err := s.mountSpecialDirs(s.mountNamespace); err != nil {
+			return commands.Report{}, err
 		}
 		defer s.umountSpecialDirs()
+	}
+
+	runner := commands.Runner{OutputLimit: s.outputLimit, Stdout: os.Stdout, Stderr: os.Stderr}
+	switch {
+	case s.mountNamespace:
+		log.Info("Executing commands in their own mount namespace", "root", mountAction)
+		runner.Root = mountAction
+	case s.chroot != "":
 		log.Info("Changing root before executing command")
 		exitChroot, err := chroot(mountAction)
 		if err != nil {
-			return fmt.Errorf("error changing root to [%s], error: %w", mountAction, err)
+			return commands.Report{}, fmt.Errorf("error changing root to [%s], error: %w", mountAction, err)
 		}
 		defer exitChroot()
 	}
 
-	if s.defaultInterpreter != "" {
-		// Split the interpreter by space, in the event that the default intprepretter has flags.
-		di := strings.Split(s.defaultInterpreter, " ")
-		if len(di) == 0 {
-			return fmt.Errorf("error parsing [\"DEFAULT_INTERPRETER\"] [%s]", s.defaultInterpreter)
-		}
-		// Look for default shell intepreter
-		if _, err := os.Stat(di[0]); os.IsNotExist(err) {
-			return fmt.Errorf("unable to find the [\"DEFAULT_INTERPRETER\"] [%s], check chroot and interpreter path", s.defaultInterpreter)
-		}
-		di = append(di, s.cmdLine)
-		cmd := exec.Command(di[0], di[1:]...)
-		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
-		debugCMD := fmt.Sprintf("%s %v", di[0], di[1:])
-		if err := cmd.Start(); err != nil {
-			return fmt.Errorf("error starting [%s] [%w]", debugCMD, err)
-		}
-		if err := cmd.Wait(); err != nil {
-			return fmt.Errorf("error running [%s] [%w]", debugCMD, err)
-		}
-	} else {
-		// Format the cmdLine string into separate execution tasks
-		commandLines := strings.Split(s.cmdLine, ";")
-		for x := range commandLines {
-			command := strings.Split(commandLines[x], " ")
-			cmd := exec.Command(command[0], command[1:]...)
-			cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
-			debugCMD := fmt.Sprintf("%s %v", command[0], command[1:])
-			if err := cmd.Start(); err != nil {
-				return fmt.Errorf("error starting [%s] [%w]", debugCMD, err)
-			}
-			if err := cmd.Wait(); err != nil {
-				return fmt.Errorf("error running [%s] [%w]", debugCMD, err)
-			}
-		}
+	report, err := runner.Run(ctx, cmds)
+	for _, result := range report.Commands {
+		log.Info("command completed", "name", result.Name, "exitCode", result.ExitCode, "duration", result.Duration,
+			"timedOut", result.TimedOut)
 	}
-
-	return nil
+	return report, err
 }
 
 // chroot handles changing the root, and returning a function to return back to the present directory.
@@ -248,24 +260,38 @@ func chroot(path string) (func() error, error) {
 	}, nil
 }
 
-// mountSpecialDirs ensures that /dev /proc /sys /etc/resolv.conf exist in the chroot.
-func (s settings) mountSpecialDirs() error {
-	// Mount dev
-	dev := filepath.Join(mountAction, "dev")
-	if err := syscall.Mount("none", dev, "devtmpfs", 0, ""); err != nil {
-		return fmt.Errorf("couldn't mount /dev to %v: %w", dev, err)
-	}
+// mountSpecialDirs ensures that /dev /proc /sys /etc/resolv.conf exist in the chroot. With bind, /dev /proc and
+// /sys of the action are bind-mounted, and the mounts under the mount point are made private so that the mount
+// namespaces of the commands do not propagate their mounts back.
+func (s settings) mountSpecialDirs(bind bool) error {
+	if bind {
+		if err := syscall.Mount("", mountAction, "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+			return fmt.Errorf("couldn't make %v private: %w", mountAction, err)
+		}
+		for _, dir := range []string{"/dev", "/proc", "/sys"} {
+			target := filepath.Join(mountAction, dir)
+			if err := syscall.Mount(dir, target, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+				return fmt.Errorf("couldn't bind mount %v to %v: %w", dir, target, err)
+			}
+		}
+	} else {
+		// Mount dev
+		dev := filepath.Join(mountAction, "dev")
+		if err := syscall.Mount("none", dev, "devtmpfs", 0, ""); err != nil {
+			return fmt.Errorf("couldn't mount /dev to %v: %w", dev, err)
+		}
 
-	// Mount proc
-	proc := filepath.Join(mountAction, "proc")
-	if err := syscall.Mount("none", proc, "proc", syscall.MS_RDONLY, ""); err != nil {
-		return fmt.Errorf("couldn't mount /proc to %v: %w", proc, err)
-	}
+		// Mount proc
+		proc := filepath.Join(mountAction, "proc")
+		if err := syscall.Mount("none", proc, "proc", syscall.MS_RDONLY, ""); err != nil {
+			return fmt.Errorf("couldn't mount /proc to %v: %w", proc, err)
+		}
 
-	// Mount sys
-	sys := filepath.Join(mountAction, "sys")
-	if err := syscall.Mount("none", sys, "sysfs", syscall.MS_RDONLY, ""); err != nil {
-		return fmt.Errorf("couldn't mount /sys to %v: %w", sys, err)
+		// Mount sys
+		sys := filepath.Join(mountAction, "sys")
+		if err := syscall.Mount("none", sys, "sysfs", syscall.MS_RDONLY, ""); err != nil {
+			return fmt.Errorf("couldn't mount /sys to %v: %w", sys, err)
+		}
 	}
 
 	if s.updateResolvConf {
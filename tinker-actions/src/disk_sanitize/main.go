@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"disk_sanitize/sanitize"
+	ec "github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes"
+	"github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/progress"
+)
+
+// Environment variables of the action.
+const (
+	// envDisks is a comma-separated list of the devices to sanitize. All the non-removable disks by default.
+	envDisks = "DISKS"
+	// envPasses is the number of passes of the overwrite fallback.
+	envPasses = "SANITIZE_PASSES"
+	// envMethod is "auto" to use the firmware erase commands of the disks, or "overwrite".
+	envMethod  = "SANITIZE_METHOD"
+	envSysFS   = "SYSFS_ROOT"
+	envUdev    = "UDEV_DATA_DIR"
+	envReport  = "REPORT_PATH"
+	defPasses  = 3
+	defSysFS   = "/sys"
+	defUdev    = "/run/udev/data"
+	defReport  = "/workflow/report.json"
+	methodAuto = "auto"
+)
+
+func main() {
+	fmt.Printf("Disk sanitize - Sanitize the disks of a decommissioned node\n------------------------\n")
+
+	passes := defPasses
+	if v := os.Getenv(envPasses); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			fatalf(ec.InvalidConfiguration, "invalid %s %q", envPasses, v)
+		}
+		passes = n
+	}
+	method := getEnv(envMethod, methodAuto)
+	if method != methodAuto && method != string(sanitize.MethodOverwrite) {
+		fatalf(ec.InvalidConfiguration, "invalid %s %q, must be %s or %s", envMethod, method, methodAuto,
+			sanitize.MethodOverwrite)
+	}
+
+	var disks []sanitize.Disk
+	var err error
+	if v := os.Getenv(envDisks); v != "" {
+		disks, err = sanitize.FromPaths(strings.Split(v, ","))
+	} else {
+		disks, err = sanitize.Discover(getEnv(envSysFS, defSysFS), getEnv(envUdev, defUdev))
+	}
+	if err != nil {
+		fatalf(ec.InvalidConfiguration, "failed to list the disks: %v", err)
+	}
+
+	s := sanitize.NewSanitizer(sanitize.ExecRunner{}, slog.Default(), passes, progress.DefaultPath)
+	s.OverwriteOnly = method == string(sanitize.MethodOverwrite)
+	report := s.SanitizeAll(context.Background(), disks)
+
+	if out, err := json.Marshal(report); err == nil {
+		fmt.Println(string(out))
+	}
+	if err := sanitize.WriteReport(getEnv(envReport, defReport), report); err != nil {
+		fatalf(ec.DiskEraseFailed, "failed to write the sanitization report: %v", err)
+	}
+	if !report.Sanitized() {
+		fatalf(ec.DiskEraseFailed, "Some disks could not be sanitized")
+	}
+	log.Printf("Sanitized %d disks", len(report.Disks))
+}
+
+func getEnv(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
+
+func fatalf(code ec.Code, format string, args ...any) {
+	log.Printf("Error: "+format, args...)
+	ec.Exit(code)
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package sanitize
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+)
+
+// ataPassword is the temporary user password that enables the ATA security feature set for the erase. The drive
+// clears it when the erase completes.
+const ataPassword = "sanitize"
+
+// ataSecurity is the state of the ATA security feature set, as printed by hdparm -I.
+type ataSecurity struct {
+	Supported     bool
+	Enabled       bool
+	Locked        bool
+	Frozen        bool
+	EnhancedErase bool
+	CountExpired  bool
+}
+
+// parseATASecurity parses the Security section of the output of hdparm -I.
+func parseATASecurity(out []byte) ataSecurity {
+	var sec ataSecurity
+	in := false
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "Security:") {
+			in = true
+			continue
+		}
+		if !in {
+			continue
+		}
+		if line != "" && !strings.HasPrefix(line, "\t") && !strings.HasPrefix(line, " ") {
+			break
+		}
+		fields := strings.Fields(line)
+		negated := len(fields) > 0 && fields[0] == "not"
+		if negated {
+			fields = fields[1:]
+		}
+		switch field := strings.Join(fields, " "); {
+		case field == "supported":
+			sec.Supported = !negated
+		case field == "enabled":
+			sec.Enabled = !negated
+		case field == "locked":
+			sec.Locked = !negated
+		case field == "frozen":
+			sec.Frozen = !negated
+		case strings.HasPrefix(field, "expired"):
+			sec.CountExpired = !negated
+		case field == "supported: enhanced erase":
+			sec.EnhancedErase = !negated
+		}
+	}
+	return sec
+}
+
+// usable returns whether the drive accepts a security erase.
+func (sec ataSecurity) usable() bool {
+	return sec.Supported && !sec.Enabled && !sec.Locked && !sec.Frozen && !sec.CountExpired
+}
+
+// ataErase erases disk with the ATA security erase if it supports it. Drives that the firmware froze, or whose
+// security feature set is already enabled with an unknown password, are left to the overwrite.
+func (s *Sanitizer) ataErase(ctx context.Context, disk Disk) (Method, error) {
+	out, err := s.Runner.Run(ctx, "hdparm", "-I", disk.Path)
+	if err != nil {
+		return "", nil
+	}
+	sec := parseATASecurity(out)
+	if !sec.usable() {
+		if sec.Frozen {
+			s.Log.Info("The ATA security feature set is frozen", "device", disk.Path)
+		}
+		return "", nil
+	}
+
+	method, erase := MethodATASecureErase, "--security-erase"
+	if sec.EnhancedErase {
+		method, erase = MethodATAEnhancedSecureErase, "--security-erase-enhanced"
+	}
+	if _, err := s.Runner.Run(ctx, "hdparm", "--user-master", "u", "--security-set-pass", ataPassword, disk.Path); err != nil {
+		return method, err
+	}
+	if _, err := s.Runner.Run(ctx, "hdparm", "--user-master", "u", erase, ataPassword, disk.Path); err != nil {
+		// do not leave the drive locked with the temporary password
+		_, _ = s.Runner.Run(ctx, "hdparm", "--user-master", "u", "--security-disable", ataPassword, disk.Path)
+		return method, err
+	}
+	return method, nil
+}
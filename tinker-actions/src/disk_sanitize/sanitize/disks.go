@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package sanitize
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const sectorBytes = 512
+
+// Disk is a disk to sanitize.
+type Disk struct {
+	// Name is the kernel name of the disk, e.g. "nvme0n1".
+	Name string
+	// Path is the device node of the disk, e.g. "/dev/nvme0n1".
+	Path      string
+	Model     string
+	Serial    string
+	SizeBytes int64
+}
+
+// Discover returns the non-removable physical disks of the sysfs mounted at sysfs. Virtual block devices, e.g. loop
+// and device-mapper devices, have no device link and are skipped. The serial numbers missing from sysfs are read
+// from the udev database at udevData.
+func Discover(sysfs, udevData string) ([]Disk, error) {
+	entries, err := os.ReadDir(filepath.Join(sysfs, "block"))
+	if err != nil {
+		return nil, err
+	}
+	var disks []Disk
+	for _, entry := range entries {
+		dir := filepath.Join(sysfs, "block", entry.Name())
+		if _, err := os.Stat(filepath.Join(dir, "device")); err != nil {
+			continue
+		}
+		if removable, _ := readTrimmed(filepath.Join(dir, "removable")); removable == "1" {
+			continue
+		}
+		sectors, err := readTrimmed(filepath.Join(dir, "size"))
+		if err != nil {
+			continue
+		}
+		n, err := strconv.ParseInt(sectors, 10, 64)
+		if err != nil || n == 0 {
+			continue
+		}
+		disk := Disk{
+			Name:      entry.Name(),
+			Path:      filepath.Join("/dev", entry.Name()),
+			SizeBytes: n * sectorBytes,
+		}
+		disk.Model, _ = readTrimmed(filepath.Join(dir, "device", "model"))
+		disk.Serial = serial(dir, udevData)
+		disks = append(disks, disk)
+	}
+	sort.Slice(disks, func(i, j int) bool { return disks[i].Name < disks[j].Name })
+	return disks, nil
+}
+
+// serial returns the serial number of the disk at dir in sysfs: the serial attribute of NVMe devices, the unit
+// serial number VPD page of SCSI devices, or the one recorded by udev.
+func serial(dir, udevData string) string {
+	if s, err := readTrimmed(filepath.Join(dir, "device", "serial")); err == nil && s != "" {
+		return s
+	}
+	// the page starts with a 4-byte header
+	if page, err := os.ReadFile(filepath.Join(dir, "device", "vpd_pg80")); err == nil && len(page) > 4 {
+		if s := strings.TrimSpace(strings.Trim(string(page[4:]), "\x00")); s != "" {
+			return s
+		}
+	}
+	dev, err := readTrimmed(filepath.Join(dir, "dev"))
+	if err != nil {
+		return ""
+	}
+	f, err := os.Open(filepath.Join(udevData, "b"+dev))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if s, ok := strings.CutPrefix(scanner.Text(), "E:ID_SERIAL_SHORT="); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// FromPaths returns the disks at the given device nodes, e.g. loop devices standing in for disks. Their model
+// and serial number are unknown.
+func FromPaths(paths []string) ([]Disk, error) {
+	disks := make([]Disk, 0, len(paths))
+	for _, path := range paths {
+		size, err := deviceSize(path)
+		if err != nil {
+			return nil, err
+		}
+		disks = append(disks, Disk{Name: filepath.Base(path), Path: path, SizeBytes: size})
+	}
+	return disks, nil
+}
+
+// deviceSize returns the size of a block device or a file.
+func deviceSize(path string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the size of %s: %w", path, err)
+	}
+	return size, nil
+}
+
+func readTrimmed(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	return strings.TrimSpace(string(data)), err
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package sanitize
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// The bits of the SANICAP and FNA fields of the NVMe Identify Controller data structure.
+const (
+	sanicapCryptoErase = 1 << 0
+	sanicapBlockErase  = 1 << 1
+	fnaCryptoErase     = 1 << 2
+)
+
+// The sanitize actions and secure erase settings of the NVMe Sanitize and Format NVM commands.
+const (
+	sanactBlockErase  = "2"
+	sanactCryptoErase = "4"
+	sesUserDataErase  = "1"
+	sesCryptoErase    = "2"
+)
+
+// The status of the most recent sanitize operation, in the NVMe Sanitize Status Log page.
+const (
+	sstatNeverSanitized = iota
+	sstatCompleted
+	sstatInProgress
+	sstatFailed
+	sstatCompletedNoDeallocate
+)
+
+var nvmeNamespace = regexp.MustCompile(`^(nvme\d+)n\d+$`)
+
+type nvmeController struct {
+	Sanicap uint32 `json:"sanicap"`
+	FNA     uint32 `json:"fna"`
+}
+
+// nvmeErase sanitizes the NVMe controller of disk if it supports it, and otherwise formats disk with secure erase.
+func (s *Sanitizer) nvmeErase(ctx context.Context, disk Disk) (Method, error) {
+	out, err := s.Runner.Run(ctx, "nvme", "id-ctrl", disk.Path, "--output-format=json")
+	if err != nil {
+		return "", nil
+	}
+	var ctrl nvmeController
+	if err := json.Unmarshal(out, &ctrl); err != nil {
+		return "", nil
+	}
+
+	if m := nvmeNamespace.FindStringSubmatch(disk.Name); m != nil && ctrl.Sanicap&(sanicapCryptoErase|sanicapBlockErase) != 0 {
+		controller := "/dev/" + m[1]
+		if done, ok := s.controllers[controller]; ok {
+			return done.Method, nil
+		}
+		method, sanact := MethodNVMeSanitizeBlockErase, sanactBlockErase
+		if ctrl.Sanicap&sanicapCryptoErase != 0 {
+			method, sanact = MethodNVMeSanitizeCryptoErase, sanactCryptoErase
+		}
+		if err := s.nvmeSanitize(ctx, controller, sanact); err != nil {
+			return method, err
+		}
+		if s.controllers == nil {
+			s.controllers = make(map[string]DiskReport)
+		}
+		s.controllers[controller] = DiskReport{Method: method}
+		return method, nil
+	}
+
+	method, ses := MethodNVMeFormatUserDataErase, sesUserDataErase
+	if ctrl.FNA&fnaCryptoErase != 0 {
+		method, ses = MethodNVMeFormatCryptoErase, sesCryptoErase
+	}
+	_, err = s.Runner.Run(ctx, "nvme", "format", disk.Path, "--ses="+ses, "--force")
+	return method, err
+}
+
+// nvmeSanitize starts a sanitize operation of controller and waits until it completes.
+func (s *Sanitizer) nvmeSanitize(ctx context.Context, controller, sanact string) error {
+	if _, err := s.Runner.Run(ctx, "nvme", "sanitize", controller, "--sanact="+sanact); err != nil {
+		return err
+	}
+	for {
+		out, err := s.Runner.Run(ctx, "nvme", "sanitize-log", controller, "--output-format=json")
+		if err != nil {
+			return err
+		}
+		sstat, sprog, err := parseSanitizeLog(out)
+		if err != nil {
+			return err
+		}
+		switch sstat & 0x7 {
+		case sstatCompleted, sstatCompletedNoDeallocate:
+			return nil
+		case sstatFailed:
+			return errors.New("the sanitize operation failed")
+		case sstatNeverSanitized:
+			return errors.New("the controller did not start the sanitize operation")
+		}
+		s.Log.Info("Sanitize in progress", "controller", controller, "percent", sprog*100/0x10000)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.PollInterval):
+		}
+	}
+}
+
+// parseSanitizeLog returns the SSTAT and SPROG fields of the sanitize log printed by nvme-cli. Depending on its
+// version, nvme-cli prints the fields at the top level or in an object keyed by the controller, and SSTAT as a
+// number or as an object of its decoded fields.
+func parseSanitizeLog(out []byte) (sstat, sprog uint32, err error) {
+	var log map[string]json.RawMessage
+	if err := json.Unmarshal(out, &log); err != nil {
+		return 0, 0, fmt.Errorf("invalid sanitize log: %w", err)
+	}
+	if _, ok := log["sstat"]; !ok {
+		for _, nested := range log {
+			var inner map[string]json.RawMessage
+			if json.Unmarshal(nested, &inner) == nil {
+				if _, ok := inner["sstat"]; ok {
+					log = inner
+					break
+				}
+			}
+		}
+	}
+	raw, ok := log["sstat"]
+	if !ok {
+		return 0, 0, errors.New("invalid sanitize log: no sstat")
+	}
+	if err := json.Unmarshal(raw, &sstat); err != nil {
+		var decoded struct {
+			Status uint32 `json:"status"`
+		}
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return 0, 0, fmt.Errorf("invalid sanitize log sstat: %s", raw)
+		}
+		sstat = decoded.Status
+	}
+	if raw, ok := log["sprog"]; ok {
+		_ = json.Unmarshal(raw, &sprog)
+	}
+	return sstat, sprog, nil
+}
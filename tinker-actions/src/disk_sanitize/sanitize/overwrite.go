@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package sanitize
+
+import (
+	"bytes"
+	"context"
+	crand "crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"time"
+
+	"github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/progress"
+)
+
+const (
+	chunkSize = 4 << 20
+	// verifySamples is the number of chunks read back after the overwrite, besides the first and the last one.
+	verifySamples = 64
+	// publishInterval is the interval between progress reports of a pass.
+	publishInterval = 5 * time.Second
+)
+
+// overwrite overwrites disk with random data and then zeros, and verifies that a sample of the disk reads back
+// as zeros.
+func (s *Sanitizer) overwrite(ctx context.Context, disk Disk) error {
+	if s.Passes < 1 {
+		return fmt.Errorf("invalid number of passes %d", s.Passes)
+	}
+	f, err := os.OpenFile(disk.Path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	for pass := 1; pass <= s.Passes; pass++ {
+		random := pass < s.Passes
+		stage := fmt.Sprintf("overwriting %s, pass %d of %d", disk.Name, pass, s.Passes)
+		if err := s.overwritePass(ctx, f, size, random, progress.NewPublisher(s.ProgressPath, stage)); err != nil {
+			return fmt.Errorf("pass %d: %w", pass, err)
+		}
+	}
+	return verifyZeros(f, size)
+}
+
+// overwritePass writes size bytes of random data, or zeros, to f.
+func (s *Sanitizer) overwritePass(ctx context.Context, f *os.File, size int64, random bool,
+	publisher *progress.Publisher,
+) error {
+	buf := make([]byte, chunkSize)
+	var rng *rand.ChaCha8
+	if random {
+		var seed [32]byte
+		if _, err := crand.Read(seed[:]); err != nil {
+			return err
+		}
+		rng = rand.NewChaCha8(seed)
+	}
+
+	var written int64
+	last := time.Now()
+	for written < size {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n := int64(len(buf))
+		if size-written < n {
+			n = size - written
+		}
+		if rng != nil {
+			_, _ = rng.Read(buf[:n])
+		}
+		if _, err := f.WriteAt(buf[:n], written); err != nil {
+			return err
+		}
+		written += n
+		if time.Since(last) >= publishInterval {
+			last = time.Now()
+			if err := publisher.Publish(written, size, progress.Percent(written, size)); err != nil {
+				s.Log.Warn("Failed to publish progress", "error", err)
+			}
+		}
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if err := publisher.Publish(written, size, 100); err != nil {
+		s.Log.Warn("Failed to publish progress", "error", err)
+	}
+	return nil
+}
+
+// verifyZeros reads back the first and the last chunk of f and a random sample of the others, and returns an
+// error if any of them is not zeroed.
+func verifyZeros(f *os.File, size int64) error {
+	chunks := (size + chunkSize - 1) / chunkSize
+	if chunks == 0 {
+		return nil
+	}
+	offsets := []int64{0, (chunks - 1) * chunkSize}
+	for range verifySamples {
+		offsets = append(offsets, rand.Int64N(chunks)*chunkSize)
+	}
+
+	buf := make([]byte, chunkSize)
+	zeros := make([]byte, chunkSize)
+	for _, off := range offsets {
+		n := int64(chunkSize)
+		if size-off < n {
+			n = size - off
+		}
+		if _, err := f.ReadAt(buf[:n], off); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("verification: %w", err)
+		}
+		if !bytes.Equal(buf[:n], zeros[:n]) {
+			return fmt.Errorf("verification: the disk is not zeroed at offset %d", off)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sanitize erases all user data of the disks of a decommissioned node, with the strongest method that the
+// disk supports, and reports how every disk was sanitized.
+package sanitize
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Method is a sanitization method.
+type Method string
+
+// The sanitization methods, from the strongest to the weakest.
+const (
+	MethodNVMeSanitizeCryptoErase Method = "nvme-sanitize-crypto-erase"
+	MethodNVMeSanitizeBlockErase  Method = "nvme-sanitize-block-erase"
+	MethodNVMeFormatCryptoErase   Method = "nvme-format-crypto-erase"
+	MethodNVMeFormatUserDataErase Method = "nvme-format-user-data-erase"
+	MethodATAEnhancedSecureErase  Method = "ata-enhanced-secure-erase"
+	MethodATASecureErase          Method = "ata-secure-erase"
+	MethodOverwrite               Method = "overwrite"
+)
+
+// The results of sanitizing a disk.
+const (
+	ResultSanitized = "sanitized"
+	ResultFailed    = "failed"
+)
+
+// Report is the sanitization report of a node.
+type Report struct {
+	StartedAt   time.Time    `json:"startedAt"`
+	CompletedAt time.Time    `json:"completedAt"`
+	Disks       []DiskReport `json:"disks"`
+}
+
+// Sanitized returns whether all the disks of the report are sanitized.
+func (r Report) Sanitized() bool {
+	for _, d := range r.Disks {
+		if d.Result != ResultSanitized {
+			return false
+		}
+	}
+	return true
+}
+
+// DiskReport is the sanitization report of a disk.
+type DiskReport struct {
+	Device    string `json:"device"`
+	Model     string `json:"model,omitempty"`
+	Serial    string `json:"serial,omitempty"`
+	SizeBytes int64  `json:"sizeBytes"`
+	Method    Method `json:"method,omitempty"`
+	// Passes is the number of passes of the overwrite method.
+	Passes int    `json:"passes,omitempty"`
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+	// Verified is whether the sanitized disk was read back and found erased.
+	Verified bool `json:"verified"`
+	// Notes records the methods that failed before the one that sanitized the disk.
+	Notes       []string  `json:"notes,omitempty"`
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+// WriteReport writes report to the file at path, for tink-worker to report it to the onboarding manager.
+func WriteReport(path string, report Report) error {
+	b, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Runner runs the disk utilities.
+type Runner interface {
+	// Run runs name with args and returns its standard output.
+	Run(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+// ExecRunner runs the disk utilities as processes.
+type ExecRunner struct{}
+
+// Run implements Runner.
+func (ExecRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	var stderr strings.Builder
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return out, fmt.Errorf("%s %s: %w: %s", name, strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// Sanitizer sanitizes disks.
+type Sanitizer struct {
+	Runner Runner
+	Log    *slog.Logger
+	// Passes is the number of passes of the overwrite method. The last pass writes zeros, the others random data.
+	Passes int
+	// OverwriteOnly disables the firmware erase commands of the disks.
+	OverwriteOnly bool
+	// ProgressPath is the progress file of the overwrite method.
+	ProgressPath string
+	// PollInterval is the interval between polls of the progress of an NVMe sanitize operation.
+	PollInterval time.Duration
+	Now          func() time.Time
+
+	// controllers records the NVMe controllers already sanitized: a sanitize operation erases all their namespaces.
+	controllers map[string]DiskReport
+}
+
+// NewSanitizer returns a Sanitizer running the disk utilities with runner.
+func NewSanitizer(runner Runner, log *slog.Logger, passes int, progressPath string) *Sanitizer {
+	return &Sanitizer{
+		Runner:       runner,
+		Log:          log,
+		Passes:       passes,
+		ProgressPath: progressPath,
+		PollInterval: 10 * time.Second,
+		Now:          time.Now,
+	}
+}
+
+// SanitizeAll sanitizes disks one by one and returns the report of all of them.
+func (s *Sanitizer) SanitizeAll(ctx context.Context, disks []Disk) Report {
+	report := Report{StartedAt: s.Now().UTC(), Disks: make([]DiskReport, 0, len(disks))}
+	for _, disk := range disks {
+		report.Disks = append(report.Disks, s.Sanitize(ctx, disk))
+	}
+	report.CompletedAt = s.Now().UTC()
+	return report
+}
+
+// Sanitize sanitizes disk with the strongest method that it supports, falling back to overwriting it.
+func (s *Sanitizer) Sanitize(ctx context.Context, disk Disk) DiskReport {
+	r := DiskReport{
+		Device:    disk.Path,
+		Model:     disk.Model,
+		Serial:    disk.Serial,
+		SizeBytes: disk.SizeBytes,
+		StartedAt: s.Now().UTC(),
+	}
+	s.Log.Info("Sanitizing disk", "device", disk.Path, "model", disk.Model, "serial", disk.Serial, "size", disk.SizeBytes)
+
+	if !s.OverwriteOnly {
+		method, err := s.firmwareErase(ctx, disk)
+		if err == nil && method != "" {
+			r.Method, r.Result = method, ResultSanitized
+			r.CompletedAt = s.Now().UTC()
+			s.Log.Info("Sanitized disk", "device", disk.Path, "method", method)
+			return r
+		}
+		if err != nil {
+			s.Log.Warn("Firmware erase failed, overwriting the disk", "device", disk.Path, "method", method, "error", err)
+			r.Notes = append(r.Notes, fmt.Sprintf("%s failed: %v", method, err))
+		}
+	}
+
+	r.Method, r.Passes = MethodOverwrite, s.Passes
+	if err := s.overwrite(ctx, disk); err != nil {
+		r.Result, r.Error = ResultFailed, err.Error()
+		s.Log.Error("Failed to sanitize disk", "device", disk.Path, "error", err)
+	} else {
+		r.Result, r.Verified = ResultSanitized, true
+		s.Log.Info("Sanitized disk", "device", disk.Path, "method", MethodOverwrite, "passes", s.Passes)
+	}
+	r.CompletedAt = s.Now().UTC()
+	return r
+}
+
+// firmwareErase erases disk with the strongest firmware erase command that it supports. It returns the method
+// that it attempted, or no method if the disk supports none.
+func (s *Sanitizer) firmwareErase(ctx context.Context, disk Disk) (Method, error) {
+	if strings.HasPrefix(disk.Name, "nvme") {
+		return s.nvmeErase(ctx, disk)
+	}
+	return s.ataErase(ctx, disk)
+}
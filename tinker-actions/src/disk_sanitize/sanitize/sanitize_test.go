@@ -0,0 +1,396 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package sanitize
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeRunner returns the output of the first response whose key prefixes the command line.
+type fakeRunner struct {
+	responses []response
+	calls     []string
+}
+
+type response struct {
+	prefix string
+	out    string
+	err    error
+}
+
+func (f *fakeRunner) Run(_ context.Context, name string, args ...string) ([]byte, error) {
+	cmd := strings.Join(append([]string{name}, args...), " ")
+	f.calls = append(f.calls, cmd)
+	for i, r := range f.responses {
+		if strings.HasPrefix(cmd, r.prefix) {
+			// responses are consumed, so that a command can return different outputs over time
+			f.responses = append(f.responses[:i], f.responses[i+1:]...)
+			return []byte(r.out), r.err
+		}
+	}
+	return nil, errors.New("command not found")
+}
+
+func newTestSanitizer(t *testing.T, runner Runner) *Sanitizer {
+	t.Helper()
+	s := NewSanitizer(runner, slog.New(slog.NewTextHandler(io.Discard, nil)), 2,
+		filepath.Join(t.TempDir(), "progress.json"))
+	s.PollInterval = 0
+	return s
+}
+
+// fakeDisk returns a disk backed by a file of size bytes of non-zero data.
+func fakeDisk(t *testing.T, name string, size int) Disk {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, bytes.Repeat([]byte{0xa5}, size), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return Disk{Name: name, Path: path, SizeBytes: int64(size)}
+}
+
+func assertZeroed(t *testing.T, path string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i := bytes.IndexFunc(data, func(r rune) bool { return r != 0 }); i >= 0 {
+		t.Errorf("%s is not zeroed at offset %d", path, i)
+	}
+}
+
+const hdparmSecurity = `
+/dev/sda:
+
+ATA device, with non-removable media
+	Model Number:       INTEL SSDSC2KB480G8
+Security: 
+	Master password revision code = 65534
+		supported
+	not	enabled
+	not	locked
+	%s	frozen
+	not	expired: security count
+		supported: enhanced erase
+	2min for SECURITY ERASE UNIT. 2min for ENHANCED SECURITY ERASE UNIT.
+Logical Unit WWN Device Identifier: 55cd2e415123abcd
+`
+
+func TestParseATASecurity(t *testing.T) {
+	sec := parseATASecurity([]byte(strings.Replace(hdparmSecurity, "%s", "not", 1)))
+	want := ataSecurity{Supported: true, EnhancedErase: true}
+	if sec != want || !sec.usable() {
+		t.Errorf("parseATASecurity() = %+v, want %+v", sec, want)
+	}
+
+	sec = parseATASecurity([]byte(strings.Replace(hdparmSecurity, "%s", "", 1)))
+	if !sec.Frozen || sec.usable() {
+		t.Errorf("a frozen drive must not be usable: %+v", sec)
+	}
+
+	if sec := parseATASecurity([]byte("/dev/sda:\n\tModel Number: QEMU HARDDISK\n")); sec.usable() {
+		t.Errorf("a drive without security feature set must not be usable: %+v", sec)
+	}
+}
+
+func TestParseSanitizeLog(t *testing.T) {
+	tests := []struct {
+		name  string
+		out   string
+		sstat uint32
+		sprog uint32
+	}{
+		{name: "Flat", out: `{"sprog":32768,"sstat":2,"cdw10_info":0}`, sstat: 2, sprog: 32768},
+		{name: "Keyed by controller", out: `{"/dev/nvme0":{"sprog":65535,"sstat":257}}`, sstat: 257, sprog: 65535},
+		{name: "Decoded status", out: `{"sprog":0,"sstat":{"status":3,"global_erased":1}}`, sstat: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sstat, sprog, err := parseSanitizeLog([]byte(tt.out))
+			if err != nil || sstat != tt.sstat || sprog != tt.sprog {
+				t.Errorf("parseSanitizeLog() = %d, %d, %v, want %d, %d", sstat, sprog, err, tt.sstat, tt.sprog)
+			}
+		})
+	}
+	if _, _, err := parseSanitizeLog([]byte(`{"sprog":0}`)); err == nil {
+		t.Error("a sanitize log without sstat must be rejected")
+	}
+}
+
+func TestSanitize_Methods(t *testing.T) {
+	tests := []struct {
+		name      string
+		disk      string
+		responses []response
+		method    Method
+		verified  bool
+		notes     int
+		last      string
+	}{
+		{
+			name: "NVMe sanitize crypto erase",
+			disk: "nvme0n1",
+			responses: []response{
+				{prefix: "nvme id-ctrl", out: `{"sanicap":3,"fna":4}`},
+				{prefix: "nvme sanitize ", out: ""},
+				{prefix: "nvme sanitize-log", out: `{"sstat":2,"sprog":1000}`},
+				{prefix: "nvme sanitize-log", out: `{"sstat":1,"sprog":65535}`},
+			},
+			method: MethodNVMeSanitizeCryptoErase,
+			last:   "nvme sanitize-log /dev/nvme0",
+		},
+		{
+			name: "NVMe format crypto erase",
+			disk: "nvme1n1",
+			responses: []response{
+				{prefix: "nvme id-ctrl", out: `{"sanicap":0,"fna":4}`},
+				{prefix: "nvme format", out: ""},
+			},
+			method: MethodNVMeFormatCryptoErase,
+			last:   "--ses=2 --force",
+		},
+		{
+			name: "NVMe sanitize failure falls back to the overwrite",
+			disk: "nvme0n1",
+			responses: []response{
+				{prefix: "nvme id-ctrl", out: `{"sanicap":2,"fna":0}`},
+				{prefix: "nvme sanitize ", out: ""},
+				{prefix: "nvme sanitize-log", out: `{"sstat":3,"sprog":0}`},
+			},
+			method:   MethodOverwrite,
+			verified: true,
+			notes:    1,
+		},
+		{
+			name: "ATA enhanced secure erase",
+			disk: "sda",
+			responses: []response{
+				{prefix: "hdparm -I", out: strings.Replace(hdparmSecurity, "%s", "not", 1)},
+				{prefix: "hdparm --user-master u --security-set-pass", out: ""},
+				{prefix: "hdparm --user-master u --security-erase-enhanced", out: ""},
+			},
+			method: MethodATAEnhancedSecureErase,
+			last:   "--security-erase-enhanced sanitize",
+		},
+		{
+			name: "ATA erase failure unlocks the drive",
+			disk: "sda",
+			responses: []response{
+				{prefix: "hdparm -I", out: strings.Replace(hdparmSecurity, "%s", "not", 1)},
+				{prefix: "hdparm --user-master u --security-set-pass", out: ""},
+				{prefix: "hdparm --user-master u --security-erase-enhanced", err: errors.New("I/O error")},
+				{prefix: "hdparm --user-master u --security-disable", out: ""},
+			},
+			method:   MethodOverwrite,
+			verified: true,
+			notes:    1,
+			last:     "--security-disable sanitize",
+		},
+		{
+			name:      "Frozen ATA drive is overwritten",
+			disk:      "sda",
+			responses: []response{{prefix: "hdparm -I", out: strings.Replace(hdparmSecurity, "%s", "", 1)}},
+			method:    MethodOverwrite,
+			verified:  true,
+		},
+		{
+			name:     "Unknown disk is overwritten",
+			disk:     "vda",
+			method:   MethodOverwrite,
+			verified: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &fakeRunner{responses: tt.responses}
+			disk := fakeDisk(t, tt.disk, 1<<20)
+			r := newTestSanitizer(t, runner).Sanitize(context.Background(), disk)
+
+			if r.Result != ResultSanitized || r.Method != tt.method || r.Verified != tt.verified {
+				t.Errorf("Sanitize() = %+v, want %s sanitized, verified %v", r, tt.method, tt.verified)
+			}
+			if len(r.Notes) != tt.notes {
+				t.Errorf("notes %v, want %d", r.Notes, tt.notes)
+			}
+			if len(runner.responses) != 0 {
+				t.Errorf("commands not run: %+v", runner.responses)
+			}
+			if tt.last != "" && !strings.Contains(strings.Join(runner.calls, "\n"), tt.last) {
+				t.Errorf("no command contains %q: %v", tt.last, runner.calls)
+			}
+			if tt.method == MethodOverwrite {
+				assertZeroed(t, disk.Path)
+			}
+		})
+	}
+}
+
+func TestSanitizeAll_NVMeControllerSanitizedOnce(t *testing.T) {
+	runner := &fakeRunner{responses: []response{
+		{prefix: "nvme id-ctrl", out: `{"sanicap":1}`},
+		{prefix: "nvme sanitize ", out: ""},
+		{prefix: "nvme sanitize-log", out: `{"sstat":1}`},
+		{prefix: "nvme id-ctrl", out: `{"sanicap":1}`},
+	}}
+	disks := []Disk{{Name: "nvme0n1", Path: "/dev/nvme0n1"}, {Name: "nvme0n2", Path: "/dev/nvme0n2"}}
+	report := newTestSanitizer(t, runner).SanitizeAll(context.Background(), disks)
+
+	if !report.Sanitized() || len(report.Disks) != 2 {
+		t.Fatalf("SanitizeAll() = %+v", report)
+	}
+	for _, d := range report.Disks {
+		if d.Method != MethodNVMeSanitizeCryptoErase {
+			t.Errorf("%s sanitized with %s", d.Device, d.Method)
+		}
+	}
+	if len(runner.calls) != 4 {
+		t.Errorf("the controller must be sanitized once: %v", runner.calls)
+	}
+}
+
+func TestSanitize_OverwriteFailure(t *testing.T) {
+	s := newTestSanitizer(t, &fakeRunner{})
+	s.OverwriteOnly = true
+	report := s.SanitizeAll(context.Background(), []Disk{{Name: "sdz", Path: filepath.Join(t.TempDir(), "missing")}})
+	if report.Sanitized() || report.Disks[0].Result != ResultFailed || report.Disks[0].Error == "" {
+		t.Errorf("SanitizeAll() = %+v, want a failed disk", report)
+	}
+}
+
+func TestOverwrite_MultipleChunks(t *testing.T) {
+	s := newTestSanitizer(t, &fakeRunner{})
+	s.OverwriteOnly, s.Passes = true, 3
+	// not a multiple of the chunk size
+	disk := fakeDisk(t, "disk.img", 2*chunkSize+4096+17)
+	r := s.Sanitize(context.Background(), disk)
+	if r.Result != ResultSanitized || !r.Verified || r.Passes != 3 {
+		t.Fatalf("Sanitize() = %+v", r)
+	}
+	assertZeroed(t, disk.Path)
+	if _, err := os.Stat(s.ProgressPath); err != nil {
+		t.Errorf("no progress published: %v", err)
+	}
+}
+
+func TestVerifyZeros(t *testing.T) {
+	disk := fakeDisk(t, "disk.img", chunkSize+10)
+	f, err := os.OpenFile(disk.Path, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteAt(make([]byte, chunkSize+9), 0); err != nil {
+		t.Fatal(err)
+	}
+	// the last byte of the last, partial, chunk is still set
+	if err := verifyZeros(f, disk.SizeBytes); err == nil {
+		t.Error("verifyZeros() must detect the last non-zero byte")
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	sysfs, udev := filepath.Join(root, "sys"), filepath.Join(root, "udev")
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	disk := func(name, removable, sectors string, physical bool) string {
+		dir := filepath.Join(sysfs, "block", name)
+		write(filepath.Join(dir, "removable"), removable+"\n")
+		write(filepath.Join(dir, "size"), sectors+"\n")
+		if physical {
+			write(filepath.Join(dir, "device", "model"), "disk "+name+"  \n")
+		}
+		return dir
+	}
+	nvme := disk("nvme0n1", "0", "1000215216", true)
+	write(filepath.Join(nvme, "device", "serial"), "S675NX0T123456   \n")
+	sda := disk("sda", "0", "937703088", true)
+	write(filepath.Join(sda, "device", "vpd_pg80"), "\x00\x80\x00\x0cBTYF12345678")
+	sdb := disk("sdb", "0", "1953525168", true)
+	write(filepath.Join(sdb, "dev"), "8:16\n")
+	write(filepath.Join(udev, "b8:16"), "S:disk/by-id/ata-WDC\nE:ID_SERIAL=WDC_WD10_WX12\nE:ID_SERIAL_SHORT=WX12\n")
+	disk("sdc", "1", "62521344", true)
+	disk("sdd", "0", "0", true)
+	disk("loop0", "0", "2048", false)
+
+	disks, err := Discover(sysfs, udev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Disk{
+		{Name: "nvme0n1", Path: "/dev/nvme0n1", Model: "disk nvme0n1", Serial: "S675NX0T123456", SizeBytes: 1000215216 * 512},
+		{Name: "sda", Path: "/dev/sda", Model: "disk sda", Serial: "BTYF12345678", SizeBytes: 937703088 * 512},
+		{Name: "sdb", Path: "/dev/sdb", Model: "disk sdb", Serial: "WX12", SizeBytes: 1953525168 * 512},
+	}
+	if len(disks) != len(want) {
+		t.Fatalf("Discover() = %+v, want %+v", disks, want)
+	}
+	for i := range want {
+		if disks[i] != want[i] {
+			t.Errorf("Discover()[%d] = %+v, want %+v", i, disks[i], want[i])
+		}
+	}
+}
+
+// TestSanitize_LoopDevice sanitizes a loop device standing in for a disk. It requires root and losetup.
+func TestSanitize_LoopDevice(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("loop devices require root")
+	}
+	if _, err := exec.LookPath("losetup"); err != nil {
+		t.Skip("losetup not found")
+	}
+	backing := fakeDisk(t, "backing.img", 3*chunkSize)
+	out, err := exec.Command("losetup", "--find", "--show", backing.Path).Output()
+	if err != nil {
+		t.Skipf("no loop device available: %v", err)
+	}
+	loop := strings.TrimSpace(string(out))
+	defer func() { _ = exec.Command("losetup", "--detach", loop).Run() }()
+
+	disks, err := FromPaths([]string{loop})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if disks[0].SizeBytes != backing.SizeBytes {
+		t.Errorf("size of %s = %d, want %d", loop, disks[0].SizeBytes, backing.SizeBytes)
+	}
+	report := newTestSanitizer(t, ExecRunner{}).SanitizeAll(context.Background(), disks)
+	if !report.Sanitized() || report.Disks[0].Method != MethodOverwrite || !report.Disks[0].Verified {
+		t.Fatalf("SanitizeAll() = %+v", report)
+	}
+	assertZeroed(t, backing.Path)
+}
+
+func TestWriteReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	report := Report{Disks: []DiskReport{{Device: "/dev/sda", Result: ResultSanitized, Method: MethodOverwrite}}}
+	if err := WriteReport(path, report); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"method":"overwrite"`) || !strings.Contains(string(data), `"result":"sanitized"`) {
+		t.Errorf("report.json = %s", data)
+	}
+}
@@ -3,6 +3,8 @@
 # SPDX-FileCopyrightText: (C) 2025 Intel Corporation
 # SPDX-License-Identifier: Apache-2.0
 
+source /errcodes.sh
+
 ####################################################################################
 #delete the pile up HOOK OS partitions from bootMenu
 while IFS= read -r boot_part_number; do
@@ -38,10 +40,10 @@ final_boot_order=$(echo "$final_boot_order" | sed -e  's/^,//;s/,$//' )
 echo "final_boot order--->" $final_boot_order
 
 # Update the boot order using efibootmgr
-efibootmgr -o "$final_boot_order"
+efibootmgr -o "$final_boot_order" || fail "$ERR_BOOT_CONFIG_FAILED" "failed to set the boot order $final_boot_order"
 
 #Make UEFI boot as inactive 
-efibootmgr -b $pxe_boot_number -A
+efibootmgr -b $pxe_boot_number -A || fail "$ERR_BOOT_CONFIG_FAILED" "failed to deactivate the PXE boot option $pxe_boot_number"
 
 echo "Made Disk as first boot and PXE boot order at end"
 # #####################################################################################
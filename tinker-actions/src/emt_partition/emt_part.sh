@@ -5,6 +5,9 @@
 
 set -x
 
+. /errcodes.sh
+exit_with_on_failure "$ERR_PARTITIONING_FAILED"
+
 ##global variables#####
 os_disk=""
 part_number=""
@@ -316,6 +319,10 @@ else
     data_part_number=$(blkid | grep "edge_persistent" | awk -F'[/:]' '{print $3}' | sed 's/[^0-9]*//g')
 fi
 
+if [ -z "$os_disk" ]; then
+    fail "$ERR_NO_TARGET_DISK" "no disk with an Edge Microvisor Toolkit rootfs partition found"
+fi
+
 #check the ram size && decide the sawp size based on it
 
 ram_size=$(free -g | grep -i mem | awk '{ print $2 }')
@@ -5,13 +5,15 @@
 
 set -eu
 
+. /errcodes.sh
+exit_with_on_failure "$ERR_DISK_ERASE_FAILED"
+
 # Source the eject script (ensure this file exists and is correct)
 source eject_all_removable_disks.sh
 
 # Eject all removable devices
 if ! eject_all_removable_devices; then
-    echo "Error: Failed to eject all removable devices."
-    exit 1
+    fail "$ERR_DISK_ERASE_FAILED" "Failed to eject all removable devices."
 fi
 
 # Format drives
@@ -130,7 +130,13 @@ get_dest_disk()
 {
     disk_device=""
 
-    list_block_devices=($(lsblk -o NAME,TYPE,SIZE,RM | grep -i disk | awk '$1 ~ /sd*|nvme*/ {if ($3 !="0B" && $4 ==0)  {print $1}}'))
+    # the disk selected by a previous action of the workflow, if any, else the disks with an OS
+    if [[ -n $TARGET_DISK ]];
+    then
+        list_block_devices=(${TARGET_DISK#/dev/})
+    else
+        list_block_devices=($(lsblk -o NAME,TYPE,SIZE,RM | grep -i disk | awk '$1 ~ /sd*|nvme*/ {if ($3 !="0B" && $4 ==0)  {print $1}}'))
+    fi
     for block_dev in ${list_block_devices[@]};
     do
         #if there were any problems when the ubuntu was streamed.
@@ -3,6 +3,9 @@
 # SPDX-FileCopyrightText: (C) 2025 Intel Corporation
 # SPDX-License-Identifier: Apache-2.0
 
+source /errcodes.sh
+exit_with_on_failure "$ERR_FDE_FAILED"
+
 #####################################################################################
 get_partition_suffix() {
     part_variable=''
@@ -21,7 +24,13 @@ run_enable_fde()
 {
     disk_device=""
 
-    list_block_devices=($(lsblk -o NAME,TYPE,SIZE,RM | grep -i disk | awk '$1 ~ /sd*|nvme*/ {if ($3 !="0B" && $4 ==0)  {print $1}}'))
+    # the disk selected by a previous action of the workflow, if any, else the disks with an OS
+    if [[ -n $TARGET_DISK ]];
+    then
+        list_block_devices=(${TARGET_DISK#/dev/})
+    else
+        list_block_devices=($(lsblk -o NAME,TYPE,SIZE,RM | grep -i disk | awk '$1 ~ /sd*|nvme*/ {if ($3 !="0B" && $4 ==0)  {print $1}}'))
+    fi
     for block_dev in ${list_block_devices[@]};
     do
 	#if there were any problems when the ubuntu was streamed.
@@ -39,8 +48,7 @@ run_enable_fde()
 
     if [[ -z $disk_device ]];
     then
-	echo "Failed to get the disk device: Most likely no OS was installed"
-	exit 1
+	fail "$ERR_NO_TARGET_DISK" "Failed to get the disk device: Most likely no OS was installed"
     fi
 
     DEST_DISK=$disk_device
@@ -0,0 +1,15 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+module hardware_preflight
+
+go 1.26.3
+
+require (
+	github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/efivars v0.0.0
+	github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes v0.0.0
+)
+
+replace github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/efivars => ../../pkg/efivars
+
+replace github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes => ../../pkg/errcodes
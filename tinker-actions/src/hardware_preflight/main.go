@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	ec "github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes"
+	"hardware_preflight/preflight"
+)
+
+// Environment variables of the action. The roots default to the file systems of the container, the workflow
+// mounts the ones of the host.
+const (
+	envProfile = "HARDWARE_PROFILE"
+	envProcFS  = "PROCFS_ROOT"
+	envSysFS   = "SYSFS_ROOT"
+	envEFIVars = "EFIVARS_DIR"
+)
+
+func main() {
+	fmt.Printf("Hardware preflight - Check the hardware before provisioning\n------------------------\n")
+
+	profile, err := preflight.ParseProfile(os.Getenv(envProfile))
+	if err != nil {
+		fatalf(ec.InvalidConfiguration, "%v", err)
+	}
+
+	checker := preflight.NewChecker()
+	if root := os.Getenv(envProcFS); root != "" {
+		checker.ProcFS = root
+	}
+	if root := os.Getenv(envSysFS); root != "" {
+		checker.SysFS = root
+	}
+	if dir := os.Getenv(envEFIVars); dir != "" {
+		checker.EFIVars = dir
+	}
+
+	report := checker.Check(profile)
+	for _, r := range report.Results {
+		status := "PASS"
+		if !r.Passed {
+			status = "FAIL"
+		}
+		if r.Required == "" {
+			log.Printf("%s %-11s found: %s", status, r.Check, r.Found)
+		} else {
+			log.Printf("%s %-11s required: %s, found: %s", status, r.Check, r.Required, r.Found)
+		}
+	}
+	if out, err := json.Marshal(report); err == nil {
+		fmt.Println(string(out))
+	}
+
+	if !report.Compliant {
+		fatalf(ec.HardwareNonCompliant, "The hardware does not meet the hardware profile, the disks were not erased")
+	}
+	log.Printf("The hardware meets the hardware profile")
+}
+
+func fatalf(code ec.Code, format string, args ...any) {
+	log.Printf("Error: "+format, args...)
+	ec.Exit(code)
+}
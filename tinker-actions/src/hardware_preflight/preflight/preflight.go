@@ -0,0 +1,384 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package preflight checks the hardware of a node against a declarative hardware profile, from procfs, sysfs and
+// efivarfs.
+package preflight
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/efivars"
+)
+
+const (
+	mib         = 1 << 20
+	gib         = 1 << 30
+	sectorBytes = 512
+)
+
+// Profile is the hardware a node must have. Zero fields are not checked, the empty profile accepts any node.
+type Profile struct {
+	// CPUFlags are the flags of /proc/cpuinfo the CPU must have. A flag may list alternatives separated by "|",
+	// e.g. "vmx|svm" for hardware virtualization on Intel or AMD CPUs.
+	CPUFlags []string `json:"cpuFlags,omitempty"`
+	// MinMemoryMiB is the minimum memory size in MiB.
+	MinMemoryMiB uint64 `json:"minMemoryMiB,omitempty"`
+	// TPM2 requires a TPM 2.0.
+	TPM2 bool `json:"tpm2,omitempty"`
+	// SecureBoot requires Secure Boot to be enabled if true, disabled if false.
+	SecureBoot *bool `json:"secureBoot,omitempty"`
+	// MinDisks is the minimum number of non-removable disks of at least MinDiskSizeGiB.
+	MinDisks int `json:"minDisks,omitempty"`
+	// MinDiskSizeGiB is the minimum size in GiB of the disks counted by MinDisks. At least one disk of this size
+	// is required if MinDisks is 0.
+	MinDiskSizeGiB uint64 `json:"minDiskSizeGiB,omitempty"`
+	// MinLinkedNICs is the minimum number of physical network interfaces with a link.
+	MinLinkedNICs int `json:"minLinkedNICs,omitempty"`
+}
+
+// ParseProfile parses a JSON hardware profile. Unknown fields are rejected, so that a misspelled requirement is
+// not silently ignored.
+func ParseProfile(data string) (Profile, error) {
+	var p Profile
+	if strings.TrimSpace(data) == "" {
+		return p, nil
+	}
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&p); err != nil {
+		return p, fmt.Errorf("invalid hardware profile: %w", err)
+	}
+	if p.MinDisks < 0 || p.MinLinkedNICs < 0 {
+		return p, errors.New("invalid hardware profile: negative minimum")
+	}
+	for _, flag := range p.CPUFlags {
+		if strings.Trim(flag, "|") == "" {
+			return p, fmt.Errorf("invalid hardware profile: empty CPU flag %q", flag)
+		}
+	}
+	return p, nil
+}
+
+// Result is the outcome of one check.
+type Result struct {
+	// Check is the name of the check, e.g. "memory".
+	Check string `json:"check"`
+	// Passed is false if the node does not meet the requirement.
+	Passed bool `json:"passed"`
+	// Required describes the requirement, empty if the profile does not require anything.
+	Required string `json:"required,omitempty"`
+	// Found describes what the node has.
+	Found string `json:"found"`
+}
+
+// Report is the outcome of all the checks.
+type Report struct {
+	Compliant bool     `json:"compliant"`
+	Results   []Result `json:"results"`
+}
+
+// Checker reads the hardware of a node from the file systems mounted at its roots.
+type Checker struct {
+	ProcFS  string
+	SysFS   string
+	EFIVars string
+}
+
+// NewChecker returns a Checker of the node the action runs on.
+func NewChecker() Checker {
+	return Checker{ProcFS: "/proc", SysFS: "/sys", EFIVars: efivars.DefaultDir}
+}
+
+// Check checks the node against the profile. The hardware not required by the profile is reported as found.
+func (c Checker) Check(p Profile) Report {
+	report := Report{Compliant: true}
+	add := func(r Result) {
+		report.Compliant = report.Compliant && r.Passed
+		report.Results = append(report.Results, r)
+	}
+	add(c.checkCPU(p))
+	add(c.checkMemory(p))
+	add(c.checkTPM(p))
+	add(c.checkSecureBoot(p))
+	add(c.checkDisks(p))
+	add(c.checkNICs(p))
+	return report
+}
+
+func (c Checker) checkCPU(p Profile) Result {
+	r := Result{Check: "cpu", Required: strings.Join(p.CPUFlags, " "), Passed: true}
+	flags, err := c.cpuFlags()
+	if err != nil {
+		r.Found = "unknown: " + err.Error()
+		r.Passed = len(p.CPUFlags) == 0
+		return r
+	}
+	var missing []string
+	for _, required := range p.CPUFlags {
+		found := false
+		for _, alt := range strings.Split(required, "|") {
+			found = found || flags[alt]
+		}
+		if !found {
+			missing = append(missing, required)
+		}
+	}
+	if len(missing) > 0 {
+		r.Passed = false
+		r.Found = "missing " + strings.Join(missing, " ")
+		return r
+	}
+	r.Found = fmt.Sprintf("%d flags", len(flags))
+	return r
+}
+
+// cpuFlags returns the flags of the first CPU, "Features" on Arm.
+func (c Checker) cpuFlags() (map[string]bool, error) {
+	f, err := os.Open(filepath.Join(c.ProcFS, "cpuinfo"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		if key = strings.TrimSpace(key); key == "flags" || key == "Features" {
+			flags := make(map[string]bool)
+			for _, flag := range strings.Fields(value) {
+				flags[flag] = true
+			}
+			return flags, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("no CPU flags in cpuinfo")
+}
+
+func (c Checker) checkMemory(p Profile) Result {
+	r := Result{Check: "memory", Passed: true}
+	if p.MinMemoryMiB > 0 {
+		r.Required = fmt.Sprintf(">= %d MiB", p.MinMemoryMiB)
+	}
+	total, err := c.memTotal()
+	if err != nil {
+		r.Found = "unknown: " + err.Error()
+		r.Passed = p.MinMemoryMiB == 0
+		return r
+	}
+	r.Found = fmt.Sprintf("%d MiB", total/mib)
+	r.Passed = total/mib >= p.MinMemoryMiB
+	return r
+}
+
+// memTotal returns the memory size in bytes.
+func (c Checker) memTotal() (uint64, error) {
+	data, err := os.ReadFile(filepath.Join(c.ProcFS, "meminfo"))
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "MemTotal:" {
+			kib, err := strconv.ParseUint(fields[1], 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid MemTotal %q", fields[1])
+			}
+			return kib * 1024, nil
+		}
+	}
+	return 0, errors.New("no MemTotal in meminfo")
+}
+
+func (c Checker) checkTPM(p Profile) Result {
+	r := Result{Check: "tpm", Passed: true}
+	if p.TPM2 {
+		r.Required = "TPM 2.0"
+	}
+	version := c.tpmVersion()
+	switch version {
+	case 0:
+		r.Found = "none"
+	default:
+		r.Found = fmt.Sprintf("TPM %d", version)
+	}
+	r.Passed = !p.TPM2 || version == 2
+	return r
+}
+
+// tpmVersion returns the major version of the newest TPM, 0 if there is none.
+func (c Checker) tpmVersion() int {
+	devices, _ := filepath.Glob(filepath.Join(c.SysFS, "class", "tpm", "tpm*"))
+	version := 0
+	for _, dev := range devices {
+		v := 0
+		if data, err := os.ReadFile(filepath.Join(dev, "tpm_version_major")); err == nil {
+			v, _ = strconv.Atoi(strings.TrimSpace(string(data)))
+		} else if _, err := os.Stat(filepath.Join(dev, "device", "caps")); err == nil {
+			// kernels older than 5.5 only expose the capabilities of TPM 1.2 devices
+			v = 1
+		}
+		if v > version {
+			version = v
+		}
+	}
+	return version
+}
+
+func (c Checker) checkSecureBoot(p Profile) Result {
+	r := Result{Check: "secure-boot", Passed: true}
+	if p.SecureBoot != nil {
+		r.Required = enabled(*p.SecureBoot)
+	}
+	sb, err := efivars.SecureBoot(c.EFIVars)
+	switch {
+	case errors.Is(err, efivars.ErrNotUEFI):
+		r.Found = "disabled (legacy BIOS)"
+	case err != nil:
+		r.Found = "unknown: " + err.Error()
+		r.Passed = p.SecureBoot == nil
+		return r
+	default:
+		r.Found = enabled(sb)
+	}
+	r.Passed = p.SecureBoot == nil || *p.SecureBoot == sb
+	return r
+}
+
+func enabled(b bool) string {
+	if b {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+func (c Checker) checkDisks(p Profile) Result {
+	r := Result{Check: "disks", Passed: true}
+	minDisks := p.MinDisks
+	if minDisks == 0 && p.MinDiskSizeGiB > 0 {
+		minDisks = 1
+	}
+	if minDisks > 0 {
+		r.Required = fmt.Sprintf(">= %d of >= %d GiB", minDisks, p.MinDiskSizeGiB)
+	}
+	disks, err := c.disks()
+	if err != nil {
+		r.Found = "unknown: " + err.Error()
+		r.Passed = minDisks == 0
+		return r
+	}
+	names := make([]string, 0, len(disks))
+	eligible := 0
+	for name, size := range disks {
+		names = append(names, fmt.Sprintf("%s %d GiB", name, size/gib))
+		if size/gib >= p.MinDiskSizeGiB {
+			eligible++
+		}
+	}
+	sort.Strings(names)
+	r.Found = strings.Join(names, ", ")
+	if r.Found == "" {
+		r.Found = "none"
+	}
+	r.Passed = eligible >= minDisks
+	return r
+}
+
+// disks returns the size in bytes of the non-removable physical disks by name. Virtual block devices, e.g. loop
+// and device-mapper devices, have no device link.
+func (c Checker) disks() (map[string]uint64, error) {
+	entries, err := os.ReadDir(filepath.Join(c.SysFS, "block"))
+	if err != nil {
+		return nil, err
+	}
+	disks := make(map[string]uint64)
+	for _, entry := range entries {
+		dir := filepath.Join(c.SysFS, "block", entry.Name())
+		if _, err := os.Stat(filepath.Join(dir, "device")); err != nil {
+			continue
+		}
+		if removable, _ := readTrimmed(filepath.Join(dir, "removable")); removable == "1" {
+			continue
+		}
+		sectors, err := readTrimmed(filepath.Join(dir, "size"))
+		if err != nil {
+			continue
+		}
+		n, err := strconv.ParseUint(sectors, 10, 64)
+		if err != nil || n == 0 {
+			continue
+		}
+		disks[entry.Name()] = n * sectorBytes
+	}
+	return disks, nil
+}
+
+func (c Checker) checkNICs(p Profile) Result {
+	r := Result{Check: "nics", Passed: true}
+	if p.MinLinkedNICs > 0 {
+		r.Required = fmt.Sprintf(">= %d with link", p.MinLinkedNICs)
+	}
+	nics, err := c.nics()
+	if err != nil {
+		r.Found = "unknown: " + err.Error()
+		r.Passed = p.MinLinkedNICs == 0
+		return r
+	}
+	names := make([]string, 0, len(nics))
+	linked := 0
+	for name, link := range nics {
+		state := "no link"
+		if link {
+			state = "link"
+			linked++
+		}
+		names = append(names, name+" "+state)
+	}
+	sort.Strings(names)
+	r.Found = strings.Join(names, ", ")
+	if r.Found == "" {
+		r.Found = "none"
+	}
+	r.Passed = linked >= p.MinLinkedNICs
+	return r
+}
+
+// nics returns whether the physical network interfaces have a link, by name.
+func (c Checker) nics() (map[string]bool, error) {
+	entries, err := os.ReadDir(filepath.Join(c.SysFS, "class", "net"))
+	if err != nil {
+		return nil, err
+	}
+	nics := make(map[string]bool)
+	for _, entry := range entries {
+		dir := filepath.Join(c.SysFS, "class", "net", entry.Name())
+		if _, err := os.Stat(filepath.Join(dir, "device")); err != nil {
+			continue
+		}
+		// reading the carrier of an interface that is down fails
+		carrier, _ := readTrimmed(filepath.Join(dir, "carrier"))
+		nics[entry.Name()] = carrier == "1"
+	}
+	return nics, nil
+}
+
+func readTrimmed(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	return string(bytes.TrimSpace(data)), err
+}
@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package preflight
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/efivars"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fakeNode returns a Checker of a fake node with 16 GiB of memory, a TPM 2.0, Secure Boot enabled, a 512 GiB NVMe
+// disk, a 32 GiB removable USB disk, a loop device, a NIC with link, a NIC without link and a bridge.
+func fakeNode(t *testing.T) Checker {
+	t.Helper()
+	root := t.TempDir()
+	c := Checker{
+		ProcFS:  filepath.Join(root, "proc"),
+		SysFS:   filepath.Join(root, "sys"),
+		EFIVars: filepath.Join(root, "sys", "firmware", "efi", "efivars"),
+	}
+
+	writeFile(t, filepath.Join(c.ProcFS, "cpuinfo"),
+		"processor\t: 0\nvendor_id\t: GenuineIntel\nflags\t\t: fpu vme vmx aes avx2\n\n"+
+			"processor\t: 1\nvendor_id\t: GenuineIntel\nflags\t\t: fpu vme vmx aes avx2\n")
+	writeFile(t, filepath.Join(c.ProcFS, "meminfo"), "MemTotal:       16384000 kB\nMemFree:         1024000 kB\n")
+
+	writeFile(t, filepath.Join(c.SysFS, "class", "tpm", "tpm0", "tpm_version_major"), "2\n")
+	writeFile(t, filepath.Join(c.EFIVars, "SecureBoot-"+efivars.GlobalVariableGUID), "\x06\x00\x00\x00\x01")
+
+	disk := func(name, removable string, sectors string, physical bool) {
+		dir := filepath.Join(c.SysFS, "block", name)
+		writeFile(t, filepath.Join(dir, "removable"), removable+"\n")
+		writeFile(t, filepath.Join(dir, "size"), sectors+"\n")
+		if physical {
+			writeFile(t, filepath.Join(dir, "device", "model"), "disk\n")
+		}
+	}
+	disk("nvme0n1", "0", "1073741824", true)
+	disk("sda", "1", "67108864", true)
+	disk("loop0", "0", "1048576", false)
+
+	nic := func(name, carrier string, physical bool) {
+		dir := filepath.Join(c.SysFS, "class", "net", name)
+		writeFile(t, filepath.Join(dir, "carrier"), carrier)
+		if physical {
+			writeFile(t, filepath.Join(dir, "device", "vendor"), "0x8086\n")
+		}
+	}
+	nic("lo", "1\n", false)
+	nic("br0", "1\n", false)
+	nic("eno1", "1\n", true)
+	nic("eno2", "", true)
+	return c
+}
+
+func results(report Report) map[string]Result {
+	byCheck := make(map[string]Result)
+	for _, r := range report.Results {
+		byCheck[r.Check] = r
+	}
+	return byCheck
+}
+
+func TestCheck_EmptyProfile(t *testing.T) {
+	report := fakeNode(t).Check(Profile{})
+	if !report.Compliant {
+		t.Fatalf("the empty profile must accept any node: %+v", report)
+	}
+	got := results(report)
+	want := map[string]string{
+		"cpu":         "5 flags",
+		"memory":      "16000 MiB",
+		"tpm":         "TPM 2",
+		"secure-boot": "enabled",
+		"disks":       "nvme0n1 512 GiB",
+		"nics":        "eno1 link, eno2 no link",
+	}
+	for check, found := range want {
+		if got[check].Found != found {
+			t.Errorf("%s: found %q, want %q", check, got[check].Found, found)
+		}
+		if got[check].Required != "" {
+			t.Errorf("%s: required %q, want nothing", check, got[check].Required)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	enabled, disabled := true, false
+	tests := []struct {
+		name    string
+		profile Profile
+		failed  []string
+	}{
+		{
+			name: "Compliant",
+			profile: Profile{
+				CPUFlags:       []string{"vmx|svm", "aes"},
+				MinMemoryMiB:   16000,
+				TPM2:           true,
+				SecureBoot:     &enabled,
+				MinDisks:       1,
+				MinDiskSizeGiB: 500,
+				MinLinkedNICs:  1,
+			},
+		},
+		{
+			name:    "Missing CPU flags",
+			profile: Profile{CPUFlags: []string{"svm", "avx512f", "aes"}},
+			failed:  []string{"cpu"},
+		},
+		{
+			name:    "Not enough memory",
+			profile: Profile{MinMemoryMiB: 32768},
+			failed:  []string{"memory"},
+		},
+		{
+			name:    "Secure Boot must be disabled",
+			profile: Profile{SecureBoot: &disabled},
+			failed:  []string{"secure-boot"},
+		},
+		{
+			name:    "Removable and virtual disks are not counted",
+			profile: Profile{MinDisks: 2},
+			failed:  []string{"disks"},
+		},
+		{
+			name:    "Disk too small",
+			profile: Profile{MinDiskSizeGiB: 1024},
+			failed:  []string{"disks"},
+		},
+		{
+			name:    "Not enough NICs with link",
+			profile: Profile{MinLinkedNICs: 2, MinMemoryMiB: 65536},
+			failed:  []string{"memory", "nics"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := fakeNode(t).Check(tt.profile)
+			if report.Compliant != (len(tt.failed) == 0) {
+				t.Errorf("compliant = %v, want %v", report.Compliant, len(tt.failed) == 0)
+			}
+			var failed []string
+			for _, r := range report.Results {
+				if !r.Passed {
+					failed = append(failed, r.Check)
+				}
+			}
+			if len(failed) != len(tt.failed) {
+				t.Fatalf("failed checks %v, want %v", failed, tt.failed)
+			}
+			for i := range failed {
+				if failed[i] != tt.failed[i] {
+					t.Errorf("failed checks %v, want %v", failed, tt.failed)
+				}
+			}
+		})
+	}
+}
+
+func TestCheck_MissingHardware(t *testing.T) {
+	root := t.TempDir()
+	c := Checker{
+		ProcFS:  filepath.Join(root, "proc"),
+		SysFS:   filepath.Join(root, "sys"),
+		EFIVars: filepath.Join(root, "sys", "firmware", "efi", "efivars"),
+	}
+	writeFile(t, filepath.Join(c.ProcFS, "meminfo"), "MemTotal:       16384000 kB\n")
+
+	got := results(c.Check(Profile{}))
+	if got["tpm"].Found != "none" || !got["tpm"].Passed {
+		t.Errorf("tpm: %+v", got["tpm"])
+	}
+	if got["secure-boot"].Found != "disabled (legacy BIOS)" || !got["secure-boot"].Passed {
+		t.Errorf("secure-boot: %+v", got["secure-boot"])
+	}
+
+	enabled := true
+	report := c.Check(Profile{TPM2: true, SecureBoot: &enabled, CPUFlags: []string{"vmx"}})
+	got = results(report)
+	if report.Compliant || got["tpm"].Passed || got["secure-boot"].Passed || got["cpu"].Passed {
+		t.Errorf("a node without TPM, UEFI and cpuinfo must not be compliant: %+v", report)
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	p, err := ParseProfile("")
+	if err != nil || len(p.CPUFlags) != 0 || p.SecureBoot != nil {
+		t.Errorf("ParseProfile(\"\") = %+v, %v", p, err)
+	}
+
+	p, err = ParseProfile(`{"cpuFlags":["vmx|svm"],"minMemoryMiB":8192,"tpm2":true,"secureBoot":false}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.MinMemoryMiB != 8192 || !p.TPM2 || p.SecureBoot == nil || *p.SecureBoot || p.CPUFlags[0] != "vmx|svm" {
+		t.Errorf("ParseProfile() = %+v", p)
+	}
+
+	for _, invalid := range []string{
+		`{"minMemory":8192}`,
+		`{"minDisks":-1}`,
+		`{"cpuFlags":["|"]}`,
+		`["vmx"]`,
+		`{"tpm2":"yes"}`,
+	} {
+		if _, err := ParseProfile(invalid); err == nil {
+			t.Errorf("ParseProfile(%s) must fail", invalid)
+		}
+	}
+}
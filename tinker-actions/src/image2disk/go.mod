@@ -19,7 +19,16 @@ require (
 
 require (
 	github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/drive_detection v0.0.0-20250324105403-f8fa27a1b024
+	github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes v0.0.0
+	github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/outputs v0.0.0
+	github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/progress v0.0.0
 	github.com/sirupsen/logrus v1.9.3 // indirect
 )
 
 replace github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/drive_detection => ../../pkg/drive_detection
+
+replace github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes => ../../pkg/errcodes
+
+replace github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/outputs => ../../pkg/outputs
+
+replace github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/progress => ../../pkg/progress
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 	"math"
 	"net/http"
@@ -30,6 +31,8 @@ import (
 	"time"
 
 	"github.com/klauspost/compress/zstd"
+	ec "github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes"
+	"github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/progress"
 	"github.com/ulikunitz/xz"
 	"golang.org/x/sys/unix"
 )
@@ -57,12 +60,12 @@ func (p *Progress) Write(b []byte) (n int, err error) {
 
 func (p *Progress) Read(b []byte) (n int, err error) {
 	nu, err := p.r.Read(b)
-	if err != nil {
-		p.rBytes.Add(int64(nu))
+	p.rBytes.Add(int64(nu))
+	if err != nil && !errors.Is(err, io.EOF) {
 		return nu, fmt.Errorf("error with read: %w", err)
 	}
-	p.rBytes.Add(int64(nu))
-	return nu, nil
+	// io.EOF is returned as is, readers detect the end of the stream by equality
+	return nu, err
 }
 
 func (p *Progress) readBytes() int64 {
@@ -84,6 +87,23 @@ func prettyByteSize(b int64) string {
 	return fmt.Sprintf("%.6fYiB", bf)
 }
 
+// progressPath is the progress file published for tink-worker.
+var progressPath = progress.DefaultPath
+
+// publishProgress publishes the bytes written to the disk. The total size is only known for uncompressed images,
+// the completion of compressed images is the one of their download.
+func publishProgress(log *slog.Logger, publisher *progress.Publisher, p *Progress, contentLength int64, compressed bool) {
+	written := p.writeBytes()
+	total, percent := contentLength, progress.Percent(written, contentLength)
+	if compressed {
+		total, percent = 0, progress.Percent(p.readBytes(), contentLength)
+	}
+	if err := publisher.Publish(written, max(total, 0), percent); err != nil {
+		// not running under tink-worker, the progress is logged anyway
+		log.Debug("failed to publish progress", "error", err)
+	}
+}
+
 // WriteCounter counts the number of bytes written to it. It implements to the io.Writer interface
 // and we can pass this into io.TeeReader() which will report progress on each write cycle.
 type WriteCounter struct {
@@ -98,34 +118,35 @@ func (wc *WriteCounter) Write(p []byte) (int, error) {
 
 // Write will pull an image and write it to local storage device
 // with compress set to true it will use gzip compression to expand the data before
-// writing to an underlying device.
-func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice string, compressed bool, progressInterval time.Duration, tlsCaCert []byte) error {
+// writing to an underlying device. It returns the SHA-256 digest of the downloaded image.
+func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice string, compressed bool, progressInterval time.Duration, tlsCaCert []byte) (string, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", sourceImage, nil)
 	if err != nil {
-		return err
+		return "", err
 	}
 	client := http.DefaultClient
 	if len(tlsCaCert) > 0 {
 		err, valid := validate_cert(log, tlsCaCert)
 		if err != nil {
 			log.Error("Failed to validate CA certificate", "error", err)
-			return fmt.Errorf("failed to validate CA certificate: %w", err)
+			return "", ec.Wrap(ec.InvalidConfiguration, fmt.Errorf("failed to validate CA certificate: %w", err))
 		}
 		if !valid {
 			log.Error("Invalid CA certificate")
-			return fmt.Errorf("invalid CA certificate")
+			return "", ec.Wrap(ec.InvalidConfiguration, fmt.Errorf("invalid CA certificate"))
 		}
 
 		caCertPool := x509.NewCertPool()
 		if !caCertPool.AppendCertsFromPEM(tlsCaCert) {
 			log.Error("Failed to append CA cert to pool - certificate may be corrupted or invalid")
-			return fmt.Errorf("failed to append CA cert to pool: certificate is not valid PEM format or is corrupted")
+			return "", ec.Wrap(ec.InvalidConfiguration,
+				fmt.Errorf("failed to append CA cert to pool: certificate is not valid PEM format or is corrupted"))
 		}
 
 		log.Info("Successfully added CA certificate to trust pool")
 
 		if !caCertPool.AppendCertsFromPEM(tlsCaCert) {
-			return errors.New("failed to append CA cert to pool")
+			return "", errors.New("failed to append CA cert to pool")
 		}
 
 		transport := &http.Transport{
@@ -142,7 +163,7 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("Failed to download the image from URL: %v", err)
+		return "", ec.Wrap(ec.DownloadFailed, fmt.Errorf("Failed to download the image from URL: %v", err))
 	}
 
 	defer resp.Body.Close()
@@ -150,14 +171,14 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 	if resp.StatusCode > 300 {
 		// Customize response for the 404 to make debugging simpler
 		if resp.StatusCode == 404 {
-			return fmt.Errorf("%s not found", sourceImage)
+			return "", ec.Wrap(ec.DownloadFailed, fmt.Errorf("%s not found", sourceImage))
 		}
-		return fmt.Errorf("%s", resp.Status)
+		return "", ec.Wrap(ec.DownloadFailed, fmt.Errorf("%s", resp.Status))
 	}
 
 	fileOut, err := os.OpenFile(destinationDevice, os.O_WRONLY, 0o644)
 	if err != nil {
-		return err
+		return "", ec.Wrap(ec.DiskWriteFailed, err)
 	}
 	defer fileOut.Close()
 
@@ -165,7 +186,7 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 
 	// Create a SHA-256 hash writer
 	hash := sha256.New()
-	hashWriter := io.TeeReader(resp.Body, hash)
+	hashWriter := io.TeeReader(progressRW, hash)
 
 	var out io.Reader = hashWriter
 
@@ -173,7 +194,7 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 		// Find compression algorithm based upon extension
 		decompressor, err := findDecompressor(sourceImage, hashWriter)
 		if err != nil {
-			return err
+			return "", err
 		}
 		defer decompressor.Close()
 		out = decompressor
@@ -184,23 +205,27 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 	done := make(chan bool)
 	go func() {
 		totalSize := resp.ContentLength
+		publisher := progress.NewPublisher(progressPath, "writing OS image")
 		for {
 			select {
 			case <-done:
 				log.Info("read and write progress", "written", prettyByteSize(progressRW.writeBytes()), "compressedSize", prettyByteSize(totalSize), "read", prettyByteSize(progressRW.readBytes()))
+				publishProgress(log, publisher, progressRW, totalSize, compressed)
 				return
 			case <-ticker.C:
 				log.Info("read and write progress", "written", prettyByteSize(progressRW.writeBytes()), "compressedSize", prettyByteSize(totalSize), "read", prettyByteSize(progressRW.readBytes()))
+				publishProgress(log, publisher, progressRW, totalSize, compressed)
 			}
 		}
 	}()
 
-	count, err := io.Copy(fileOut, out)
+	count, err := io.Copy(progressRW, out)
 	// EOF and ErrUnexpectedEOF can be ignored.
 	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
 		ticker.Stop()
 		done <- true
-		return fmt.Errorf("error writing %s bytes to disk [%s] -> %w", prettyByteSize(count), destinationDevice, err)
+		return "", ec.Wrap(copyErrorCode(err),
+			fmt.Errorf("error writing %s bytes to disk [%s] -> %w", prettyByteSize(count), destinationDevice, err))
 	}
 
 	ticker.Stop()
@@ -208,7 +233,7 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 
 	// Do the equivalent of partprobe on the device
 	if err := fileOut.Sync(); err != nil {
-		return fmt.Errorf("failed to sync the block device")
+		return "", ec.Wrap(ec.DiskWriteFailed, fmt.Errorf("failed to sync the block device"))
 	}
 
 	if err := unix.IoctlSetInt(int(fileOut.Fd()), unix.BLKRRPART, 0); err != nil {
@@ -226,10 +251,19 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 	if len(expectedSHA256) != 0 && actualSHA256 != expectedSHA256 {
 		fmt.Printf("-----Mismatch SHA256 for actualSHA256 & expectedSHA256 ---\n")
 		log.Error("------SHA256 MISMATCH---------")
-		return fmt.Errorf("Image SHA-256 hash mismatch")
+		return "", ec.Wrap(ec.ImageDigestMismatch, fmt.Errorf("Image SHA-256 hash mismatch"))
 	}
 
-	return nil
+	return actualSHA256, nil
+}
+
+// copyErrorCode tells failures to write to the disk apart from failures to read the image while streaming it.
+func copyErrorCode(err error) ec.Code {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) && pathErr.Op == "write" {
+		return ec.DiskWriteFailed
+	}
+	return ec.DownloadFailed
 }
 
 func findDecompressor(imageURL string, r io.Reader) (io.ReadCloser, error) {
@@ -9,10 +9,22 @@ package image
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/progress"
 	"github.com/ulikunitz/xz"
 )
 
@@ -96,3 +108,76 @@ func Test_findDecompressor(t *testing.T) {
 		})
 	}
 }
+
+func TestWrite_Progress(t *testing.T) {
+	data := bytes.Repeat([]byte("YourDataHere"), 100_000)
+	var gz bytes.Buffer
+	gzW := gzip.NewWriter(&gz)
+	if _, err := gzW.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzW.Close(); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := data
+		if strings.HasSuffix(r.URL.Path, ".gz") {
+			body = gz.Bytes()
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		_, _ = w.Write(body)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		image      string
+		compressed bool
+		body       []byte
+		wantTotal  int64
+	}{
+		{"Uncompressed", "/image.raw", false, data, int64(len(data))},
+		{"Compressed", "/image.raw.gz", true, gz.Bytes(), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			progressPath = filepath.Join(dir, "progress.json")
+			defer func() { progressPath = progress.DefaultPath }()
+			disk := filepath.Join(dir, "disk")
+			if err := os.WriteFile(disk, nil, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			digest := sha256.Sum256(tt.body)
+			t.Setenv("SHA256", hex.EncodeToString(digest[:]))
+
+			sha, err := Write(context.Background(), slog.New(slog.DiscardHandler), srv.URL+tt.image, disk, tt.compressed,
+				time.Hour, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sha != hex.EncodeToString(digest[:]) {
+				t.Errorf("expected the digest of the downloaded image %x, got %s", digest, sha)
+			}
+
+			written, err := os.ReadFile(disk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(written, data) {
+				t.Fatalf("expected %d bytes written to the disk, got %d", len(data), len(written))
+			}
+			b, err := os.ReadFile(progressPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var report progress.Report
+			if err := json.Unmarshal(b, &report); err != nil {
+				t.Fatal(err)
+			}
+			if report.BytesWritten != int64(len(data)) || report.TotalBytes != tt.wantTotal || report.Percent != 100 {
+				t.Errorf("unexpected final progress %+v", report)
+			}
+		})
+	}
+}
@@ -5,6 +5,10 @@
 
 set -ex
 
+. /errcodes.sh
+. /outputs.sh
+exit_with_on_failure "$ERR_KERNEL_UPGRADE_FAILED"
+
 ##global variables#####
 os_disk=""
 part_number=""
@@ -130,6 +134,9 @@ for mount in $(mount | grep '/mnt' | awk '{print $3}' | sort -nr); do
   umount "$mount"
 done
 
+# the onboarding manager records the installed kernel
+set_output INSTALLED_KERNEL "${kernel_version}-generic"
+
 }
 
 #lvm creation on disk
@@ -16,7 +16,16 @@ require (
 require (
 	github.com/klauspost/compress v1.18.1
 	github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/drive_detection v0.0.0-20250324105403-f8fa27a1b024
+	github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes v0.0.0
+	github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/outputs v0.0.0
+	github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/progress v0.0.0
 	github.com/ulikunitz/xz v0.5.15
 )
 
 replace github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/drive_detection => ../../pkg/drive_detection
+
+replace github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes => ../../pkg/errcodes
+
+replace github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/outputs => ../../pkg/outputs
+
+replace github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/progress => ../../pkg/progress
@@ -23,33 +23,114 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/klauspost/compress/zstd"
+	ec "github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes"
+	"github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/progress"
 	"github.com/ulikunitz/xz"
 	"golang.org/x/sys/unix"
 )
 
+// progressPath is the progress file published for tink-worker.
+var progressPath = progress.DefaultPath
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n atomic.Int64
+}
+
+func (c *countingReader) Read(b []byte) (int, error) {
+	n, err := c.r.Read(b)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// publishProgress publishes the bytes written out of the total bytes (0 if unknown) returned by current, every
+// interval until the returned function is called. The returned function publishes the final progress.
+func publishProgress(log *slog.Logger, interval time.Duration, stage string, current func() (int64, int64)) func() {
+	publisher := progress.NewPublisher(progressPath, stage)
+	publish := func() {
+		written, total := current()
+		if err := publisher.Publish(written, total, progress.Percent(written, total)); err != nil {
+			// not running under tink-worker
+			log.Debug("failed to publish progress", "error", err)
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				written, total := current()
+				log.Info(stage, "written", written, "total", total)
+				publish()
+			}
+		}
+	}()
+	return func() {
+		ticker.Stop()
+		close(done)
+		<-stopped
+		publish()
+	}
+}
+
+// bytesWrittenBy returns the number of bytes written so far by the process pid, as accounted by the kernel.
+func bytesWrittenBy(pid int) (int64, error) {
+	b, err := os.ReadFile(fmt.Sprintf("/proc/%d/io", pid))
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		if value, ok := strings.CutPrefix(line, "wchar: "); ok {
+			return strconv.ParseInt(value, 10, 64)
+		}
+	}
+	return 0, fmt.Errorf("no wchar in /proc/%d/io", pid)
+}
+
+// deviceSize returns the size of the block device in bytes.
+func deviceSize(device string) (int64, error) {
+	f, err := os.Open(device)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return f.Seek(0, io.SeekEnd)
+}
+
 // Write will pull an image and write it to network boot device (nbd) using qemu-nbd
-// before writing to an underlying device.
-func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice string, compressed bool, progressInterval time.Duration, tlsCaCert []byte) error {
+// before writing to an underlying device. It returns the SHA-256 digest of the downloaded image.
+func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice string, compressed bool, progressInterval time.Duration, tlsCaCert []byte) (string, error) {
 	// Create HTTP client with custom TLS configuration if CA cert is provided
 	client := http.DefaultClient
 	if len(tlsCaCert) > 0 {
 		err, valid := validate_cert(log, tlsCaCert)
 		if err != nil {
 			log.Error("Failed to validate CA certificate", "error", err)
-			return fmt.Errorf("failed to validate CA certificate: %w", err)
+			return "", ec.Wrap(ec.InvalidConfiguration, fmt.Errorf("failed to validate CA certificate: %w", err))
 		}
 		if !valid {
 			log.Error("Invalid CA certificate")
-			return fmt.Errorf("invalid CA certificate")
+			return "", ec.Wrap(ec.InvalidConfiguration, fmt.Errorf("invalid CA certificate"))
 		}
 
 		caCertPool := x509.NewCertPool()
 		if !caCertPool.AppendCertsFromPEM(tlsCaCert) {
 			log.Error("Failed to append CA cert to pool - certificate may be corrupted or invalid")
-			return fmt.Errorf("failed to append CA cert to pool: certificate is not valid PEM format or is corrupted")
+			return "", ec.Wrap(ec.InvalidConfiguration,
+				fmt.Errorf("failed to append CA cert to pool: certificate is not valid PEM format or is corrupted"))
 		}
 
 		log.Info("Successfully added CA certificate to trust pool")
@@ -69,31 +150,32 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 	// Create and execute an HTTP GET request to download the image
 	req, err := http.NewRequestWithContext(ctx, "GET", sourceImage, nil)
 	if err != nil {
-		return err
+		return "", err
 	}
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to download image from the URL: %v", err)
+		return "", ec.Wrap(ec.DownloadFailed, fmt.Errorf("failed to download image from the URL: %v", err))
 	}
 	defer resp.Body.Close()
 	log.Info("Successfully downloaded image")
 
 	// Check if the response status code is 200
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download image, HTTP status code: %d", resp.StatusCode)
+		return "", ec.Wrap(ec.DownloadFailed, fmt.Errorf("failed to download image, HTTP status code: %d", resp.StatusCode))
 	}
 
 	// Create a temp file for storing the cloud image in qcow2 format
 	tmpFile, err := os.CreateTemp("", "img-*.qcow2")
 	if err != nil {
-		return fmt.Errorf("temp file creation failed: %v", err)
+		return "", fmt.Errorf("temp file creation failed: %v", err)
 	}
 	defer os.Remove(tmpFile.Name())
 	log.Info("Successfully created empty temp file")
 
 	// Create a SHA-256 hash object
 	hash := sha256.New()
-	hashReader := io.TeeReader(resp.Body, hash)
+	downloaded := &countingReader{r: resp.Body}
+	hashReader := io.TeeReader(downloaded, hash)
 
 	var dataReader io.Reader = hashReader
 
@@ -102,16 +184,20 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 		log.Info("Decompressing image", "format", filepath.Ext(sourceImage))
 		decompressor, err := createDecompressor(sourceImage, hashReader)
 		if err != nil {
-			return fmt.Errorf("failed to create decompressor: %w", err)
+			return "", fmt.Errorf("failed to create decompressor: %w", err)
 		}
 		defer decompressor.Close()
 		dataReader = decompressor
 	}
 
 	// Copy the image to tmp file and simultaneously write to the hash
+	stopProgress := publishProgress(log, progressInterval, "downloading OS image", func() (int64, int64) {
+		return downloaded.n.Load(), max(resp.ContentLength, 0)
+	})
 	_, err = io.Copy(tmpFile, dataReader)
+	stopProgress()
 	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
-		return fmt.Errorf("failed to save the image to temp file: %v", err)
+		return "", ec.Wrap(ec.DownloadFailed, fmt.Errorf("failed to save the image to temp file: %v", err))
 	}
 	tmpFile.Close()
 	log.Info("Successfully saved image to tmpFile")
@@ -122,7 +208,7 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 	cmdLsTmp.Stdout = &lsTmpOut
 	cmdLsTmp.Stderr = &lsTmpErr
 	if err := cmdLsTmp.Run(); err != nil {
-		return fmt.Errorf("failed to run ls -lh /tmp: %v\nstdout:%s\nstderr:\n%s", err, lsTmpOut.String(), lsTmpErr.String())
+		return "", fmt.Errorf("failed to run ls -lh /tmp: %v\nstdout:%s\nstderr:\n%s", err, lsTmpOut.String(), lsTmpErr.String())
 	}
 	log.Info("ls -lh /tmp output", "stdout", lsTmpOut.String(), "stderr", lsTmpErr.String())
 
@@ -138,7 +224,7 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 		log.Info(fmt.Sprintf("expectedSHA256 : [%s] ", expectedSHA256))
 		log.Info(fmt.Sprintf("actualSHA256 : [%s] ", actualSHA256))
 		log.Error("------SHA256 MISMATCH---------")
-		return fmt.Errorf("image SHA-256 hash mismatch")
+		return "", ec.Wrap(ec.ImageDigestMismatch, fmt.Errorf("image SHA-256 hash mismatch"))
 	}
 	log.Info(fmt.Sprintf("SHA-256 hash of the downloaded file: %s", actualSHA256))
 	log.Info("Successfully verified SHA-256 checksum")
@@ -148,7 +234,7 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 	cmdModprobe.Stdout = os.Stdout
 	cmdModprobe.Stderr = os.Stderr
 	if err := cmdModprobe.Run(); err != nil {
-		return fmt.Errorf("failed to load nbd kernel module: %v", err)
+		return "", fmt.Errorf("failed to load nbd kernel module: %v", err)
 	}
 	log.Info("Successfully loaded nbd kernel module")
 
@@ -158,7 +244,7 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 	cmdLs.Stdout = &lsOut
 	cmdLs.Stderr = &lsErr
 	if err := cmdLs.Run(); err != nil {
-		return fmt.Errorf("failed to run ls: %v\nstdout:%s\nstderr:\n%s", err, lsOut.String(), lsErr.String())
+		return "", fmt.Errorf("failed to run ls: %v\nstdout:%s\nstderr:\n%s", err, lsOut.String(), lsErr.String())
 	}
 	log.Info("ls output", "stdout", lsOut.String(), "stderr", lsErr.String())
 
@@ -169,7 +255,7 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 	cmdNbd.Stdout = &outBuf
 	cmdNbd.Stderr = &errBuf
 	if err := cmdNbd.Run(); err != nil {
-		return fmt.Errorf("network block device attach failed: %v\nstdout:%s\nstderr:\n%s", err, outBuf.String(), errBuf.String())
+		return "", fmt.Errorf("network block device attach failed: %v\nstdout:%s\nstderr:\n%s", err, outBuf.String(), errBuf.String())
 	}
 	log.Info("qemu-nbd connect output", "stdout", outBuf.String(), "stderr", errBuf.String())
 	defer exec.Command("qemu-nbd", "--disconnect", nbdDevice).Run()
@@ -182,7 +268,7 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 		cmdLsblk.Stdout = &lsblkOut
 		cmdLsblk.Stderr = &lsblkErr
 		if err := cmdLsblk.Run(); err != nil {
-			return fmt.Errorf("failed to run lsblk: %v\nstdout:%s\nstderr:\n%s", err, lsblkOut.String(), lsblkErr.String())
+			return "", fmt.Errorf("failed to run lsblk: %v\nstdout:%s\nstderr:\n%s", err, lsblkOut.String(), lsblkErr.String())
 		}
 		log.Info("lsblk output", "stdout", lsblkOut.String(), "stderr", lsblkErr.String())
 		if bytes.Contains(lsblkOut.Bytes(), []byte("nbd0p1")) {
@@ -200,7 +286,7 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 	cmdLs1.Stdout = &lsOut1
 	cmdLs1.Stderr = &lsErr1
 	if err := cmdLs1.Run(); err != nil {
-		return fmt.Errorf("failed to run ls: %v\nstdout:%s\nstderr:\n%s", err, lsOut1.String(), lsErr1.String())
+		return "", fmt.Errorf("failed to run ls: %v\nstdout:%s\nstderr:\n%s", err, lsOut1.String(), lsErr1.String())
 	}
 	log.Info("ls output", "stdout", lsOut1.String(), "stderr", lsErr1.String())
 
@@ -209,8 +295,28 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 	cmdDD.Stdout = os.Stdout
 	cmdDD.Stderr = os.Stderr
 
-	if err := cmdDD.Run(); err != nil {
-		return fmt.Errorf("failed to write image to disk: %v", err)
+	imageSize, err := deviceSize(nbdDevice)
+	if err != nil {
+		log.Info("failed to get the size of the image", "err", err)
+	}
+	if err := cmdDD.Start(); err != nil {
+		return "", ec.Wrap(ec.DiskWriteFailed, fmt.Errorf("failed to write image to disk: %v", err))
+	}
+	// busybox dd does not report its progress, the bytes it wrote are read from procfs instead
+	var written atomic.Int64
+	stopProgress = publishProgress(log, progressInterval, "writing OS image", func() (int64, int64) {
+		if n, err := bytesWrittenBy(cmdDD.Process.Pid); err == nil {
+			written.Store(n)
+		}
+		return written.Load(), imageSize
+	})
+	err = cmdDD.Wait()
+	if err == nil && imageSize > 0 {
+		written.Store(imageSize)
+	}
+	stopProgress()
+	if err != nil {
+		return "", ec.Wrap(ec.DiskWriteFailed, fmt.Errorf("failed to write image to disk: %v", err))
 	}
 	log.Info(fmt.Sprintf("Successfully installed  cloud image on %s", destinationDevice))
 
@@ -220,21 +326,21 @@ func Write(ctx context.Context, log *slog.Logger, sourceImage, destinationDevice
 	cmdLsblk2.Stdout = &lsblkOut2
 	cmdLsblk2.Stderr = &lsblkErr2
 	if err := cmdLsblk2.Run(); err != nil {
-		return fmt.Errorf("failed to rerun lsblk: %v\nstdout:%s\nstderr:\n%s", err, lsblkOut2.String(), lsblkErr2.String())
+		return "", fmt.Errorf("failed to rerun lsblk: %v\nstdout:%s\nstderr:\n%s", err, lsblkOut2.String(), lsblkErr2.String())
 	}
 	log.Info("lsblk output (rerun)", "stdout", lsblkOut2.String(), "stderr", lsblkErr2.String())
 
 	// Run partition table re-probing
 	file, err := os.OpenFile(destinationDevice, os.O_RDWR, 0600)
 	if err != nil {
-		return fmt.Errorf("failed to open device %s: %v", destinationDevice, err)
+		return "", ec.Wrap(ec.DiskWriteFailed, fmt.Errorf("failed to open device %s: %v", destinationDevice, err))
 	}
 	defer file.Close()
 	err = unix.IoctlSetInt(int(file.Fd()), unix.BLKRRPART, 0)
 	if err != nil {
-		return fmt.Errorf("failed to re-probe partitions on %s: %v", destinationDevice, err)
+		return "", fmt.Errorf("failed to re-probe partitions on %s: %v", destinationDevice, err)
 	}
-	return nil
+	return actualSHA256, nil
 }
 
 func validate_cert(log *slog.Logger, tlsCaCert []byte) (error, bool) {
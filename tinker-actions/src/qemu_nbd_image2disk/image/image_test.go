@@ -0,0 +1,241 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/progress"
+	"github.com/ulikunitz/xz"
+)
+
+// chunkReader is a fake image download returning the data in chunks of at most size bytes.
+type chunkReader struct {
+	data []byte
+	size int
+}
+
+func (c *chunkReader) Read(b []byte) (int, error) {
+	if len(c.data) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(b[:min(len(b), c.size)], c.data)
+	c.data = c.data[n:]
+	return n, nil
+}
+
+func compress(t *testing.T, data []byte, newWriter func(io.Writer) (io.WriteCloser, error)) io.Reader {
+	t.Helper()
+
+	var b bytes.Buffer
+	w, err := newWriter(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(b.Bytes())
+}
+
+func setProgressPath(t *testing.T) {
+	t.Helper()
+
+	progressPath = filepath.Join(t.TempDir(), "progress.json")
+	t.Cleanup(func() { progressPath = progress.DefaultPath })
+}
+
+func readProgress(t *testing.T) (progress.Report, bool) {
+	t.Helper()
+
+	b, err := os.ReadFile(progressPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return progress.Report{}, false
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report progress.Report
+	if err := json.Unmarshal(b, &report); err != nil {
+		t.Fatal(err)
+	}
+	return report, true
+}
+
+func TestCountingReader(t *testing.T) {
+	data := bytes.Repeat([]byte("YourDataHere"), 1000)
+	downloaded := &countingReader{r: &chunkReader{data: data, size: 1000}}
+
+	b := make([]byte, 100)
+	if _, err := io.ReadFull(downloaded, b); err != nil {
+		t.Fatal(err)
+	}
+	if n := downloaded.n.Load(); n != 100 {
+		t.Errorf("expected 100 bytes read, got %d", n)
+	}
+	if _, err := io.Copy(io.Discard, downloaded); err != nil {
+		t.Fatal(err)
+	}
+	if n := downloaded.n.Load(); n != int64(len(data)) {
+		t.Errorf("expected %d bytes read, got %d", len(data), n)
+	}
+}
+
+func TestPublishProgress(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int64
+		wantPercent int
+	}{
+		{"Known total", 12_000, 100},
+		{"Unknown total", 0, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setProgressPath(t)
+			downloaded := &countingReader{r: &chunkReader{data: bytes.Repeat([]byte("YourDataHere"), 1000), size: 1000}}
+			if _, err := io.CopyN(io.Discard, downloaded, 3000); err != nil {
+				t.Fatal(err)
+			}
+
+			stop := publishProgress(slog.New(slog.DiscardHandler), time.Millisecond, "downloading OS image",
+				func() (int64, int64) { return downloaded.n.Load(), tt.total })
+			// the progress is published on every tick while the image is written
+			deadline := time.Now().Add(5 * time.Second)
+			report, ok := readProgress(t)
+			for !ok && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+				report, ok = readProgress(t)
+			}
+			if !ok {
+				t.Fatal("no progress published before the image is written")
+			}
+			if report.Stage != "downloading OS image" || report.BytesWritten != 3000 || report.TotalBytes != tt.total {
+				t.Errorf("unexpected progress %+v", report)
+			}
+
+			if _, err := io.Copy(io.Discard, downloaded); err != nil {
+				t.Fatal(err)
+			}
+			stop()
+			report, _ = readProgress(t)
+			if report.BytesWritten != 12_000 || report.TotalBytes != tt.total || report.Percent != tt.wantPercent {
+				t.Errorf("unexpected final progress %+v", report)
+			}
+		})
+	}
+}
+
+func TestPublishProgress_NotUnderTinkWorker(t *testing.T) {
+	progressPath = filepath.Join(t.TempDir(), "missing", "progress.json")
+	t.Cleanup(func() { progressPath = progress.DefaultPath })
+
+	var calls atomic.Int64
+	stop := publishProgress(slog.New(slog.DiscardHandler), time.Millisecond, "writing OS image",
+		func() (int64, int64) { return calls.Add(1), 0 })
+	time.Sleep(10 * time.Millisecond)
+	// failing to publish does not stop the image from being written
+	stop()
+	if calls.Load() == 0 {
+		t.Error("expected the progress to be read at least once")
+	}
+}
+
+func TestBytesWrittenBy(t *testing.T) {
+	before, err := bytesWrittenBy(os.Getpid())
+	if err != nil {
+		t.Skipf("procfs I/O accounting unavailable: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(t.TempDir(), "disk"), make([]byte, 4096), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	after, err := bytesWrittenBy(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after-before < 4096 {
+		t.Errorf("expected at least 4096 more bytes written, got %d", after-before)
+	}
+
+	if _, err := bytesWrittenBy(-1); err == nil {
+		t.Error("expected an error for a missing process")
+	}
+}
+
+func TestDeviceSize(t *testing.T) {
+	disk := filepath.Join(t.TempDir(), "disk")
+	if err := os.WriteFile(disk, make([]byte, 12_345), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	size, err := deviceSize(disk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != 12_345 {
+		t.Errorf("expected a size of 12345 bytes, got %d", size)
+	}
+
+	if _, err := deviceSize(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected an error for a missing device")
+	}
+}
+
+func Test_createDecompressor(t *testing.T) {
+	data := []byte("YourDataHere")
+	gzipped := func(t *testing.T) io.Reader {
+		return compress(t, data, func(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w), nil })
+	}
+	xzed := func(t *testing.T) io.Reader {
+		return compress(t, data, func(w io.Writer) (io.WriteCloser, error) { return xz.NewWriter(w) })
+	}
+	zstded := func(t *testing.T) io.Reader {
+		return compress(t, data, func(w io.Writer) (io.WriteCloser, error) { return zstd.NewWriter(w) })
+	}
+
+	tests := []struct {
+		name      string
+		imagePath string
+		reader    func(*testing.T) io.Reader
+		wantErr   bool
+	}{
+		{"gzip", "http://192.168.0.1/a.qcow2.gz", gzipped, false},
+		{"broken gzip", "http://192.168.0.1/a.qcow2.gz", xzed, true},
+		{"xz", "http://192.168.0.1/a.qcow2.xz", xzed, false},
+		{"zstd", "http://192.168.0.1/a.qcow2.zst", zstded, false},
+		{"unknown", "http://192.168.0.1/a.qcow2.abc", xzed, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := createDecompressor(tt.imagePath, tt.reader(t))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("createDecompressor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer r.Close()
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Errorf("expected %q, got %q", data, got)
+			}
+		})
+	}
+}
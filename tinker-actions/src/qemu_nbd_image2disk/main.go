@@ -21,6 +21,8 @@ import (
 	"qemu-nbd-img2disk/image"
 
 	dd "github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/drive_detection"
+	ec "github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes"
+	"github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/outputs"
 
 	"github.com/cenkalti/backoff"
 	"github.com/lmittmann/tint"
@@ -56,16 +58,11 @@ func main() {
 
 	// Check if a string is empty
 	if len(disk) == 0 {
-		// Get a list of drives
-		drives, err := dd.GetDrives()
-		if err != nil {
-			log.Error("Get Drive Error", "err", err)
-			os.Exit(1)
-		}
-		detectedDisk, err := dd.DriveDetection(drives)
+		// The disk selected by a previous action, or else detected
+		detectedDisk, err := dd.SelectedDrive()
 		if err != nil {
 			log.Error("Drive detection Error", "err", err)
-			os.Exit(1)
+			ec.Exit(ec.NoTargetDisk)
 		}
 		log.Info("Detected drive:", "detectedDisk", detectedDisk)
 		disk = detectedDisk
@@ -77,18 +74,18 @@ func main() {
 
 	if img == "" {
 		log.Error("IMG_URL is required", "image", img)
-		os.Exit(1)
+		ec.Exit(ec.InvalidConfiguration)
 	}
 
 	if disk == "" {
 		log.Error("DEST_DISK is required", "disk", disk)
-		os.Exit(1)
+		ec.Exit(ec.NoTargetDisk)
 	}
 
 	u, err := url.Parse(img)
 	if err != nil {
 		log.Error("error parsing image URL (IMG_URL)", "err", err, "image", img)
-		os.Exit(1)
+		ec.Exit(ec.InvalidConfiguration)
 	}
 
 	expectedSHA256 := os.Getenv("SHA256")
@@ -104,7 +101,7 @@ func main() {
 		decoded, err := base64.StdEncoding.DecodeString(tls_ca_cert_str)
 		if err != nil {
 			log.Error("Error decoding base64 TLS CA certificate", "err", err)
-			os.Exit(1)
+			ec.Exit(ec.InvalidConfiguration)
 		}
 		tls_ca_cert = decoded
 		log.Info("TLS CA certificate decoded successfully")
@@ -123,8 +120,10 @@ func main() {
 	// convert progress interval to duration in seconds
 	interval := time.Duration(pi) * time.Second
 
+	var digest string
 	operation := func() error {
-		if err := image.Write(ctx, log, u.String(), disk, cmp, interval, tls_ca_cert); err != nil {
+		var err error
+		if digest, err = image.Write(ctx, log, u.String(), disk, cmp, interval, tls_ca_cert); err != nil {
 			return fmt.Errorf("error writing image to disk: %w", err)
 		}
 		return nil
@@ -150,16 +149,25 @@ func main() {
 		}
 		// try to write the image to disk with exponential backoff for 10 minutes
 		if err := backoff.RetryNotify(operation, bctx, retryNotifier); err != nil {
-			log.Error("error writing image to disk", "err", err, "image", img, "disk", disk)
-			os.Exit(1)
+			log.Error("error writing image to disk", "err", err, "image", img, "disk", disk, "code", ec.CodeOf(err))
+			ec.Exit(ec.CodeOf(err))
 		}
 	} else {
 		// try to write the image to disk without retry
 		if err := operation(); err != nil {
-			log.Error("error writing image to disk", "err", err, "image", img, "disk", disk)
-			os.Exit(1)
+			log.Error("error writing image to disk", "err", err, "image", img, "disk", disk, "code", ec.CodeOf(err))
+			ec.Exit(ec.CodeOf(err))
 		}
 	}
 
 	log.Info("Successfully wrote image to disk", "image", img, "disk", disk)
+
+	// the later actions of the workflow use the same disk, and the onboarding manager records the digest. Without
+	// the outputs, e.g. outside of a workflow, the later actions detect the disk themselves.
+	if err := outputs.Set(outputs.DefaultPath, map[string]string{
+		outputs.TargetDisk:  disk,
+		outputs.ImageSHA256: digest,
+	}); err != nil {
+		log.Error("error publishing the outputs", "err", err)
+	}
 }
@@ -6,3 +6,12 @@ module securebootflag
 go 1.26.3
 
 //toolchain go1.21.4
+
+require (
+	github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/efivars v0.0.0
+	github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes v0.0.0
+)
+
+replace github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/efivars => ../../pkg/efivars
+
+replace github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes => ../../pkg/errcodes
@@ -4,41 +4,43 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
-	"os/exec"
-	"strings"
-)
 
-const (
-	SecureBootDisabled = "1" // Failure case
-	SecureBootEnabled  = "0" // Success case
+	"github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/efivars"
+	ec "github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes"
 )
 
+// defaultEFIVarsDir is the efivarfs of the host, which the workflow mounts at /host.
+const defaultEFIVarsDir = "/host" + efivars.DefaultDir
+
 func main() {
 	securityFeatureFlagSetBySI := os.Getenv("SECURITY_FEATURE_FLAG")
-	// Extract the secure boot status from dmesg command
-	cmd := exec.Command("/bin/sh", "-c", `cat /host/sblog.txt | grep -i "secure boot enabled" > /dev/null ;  echo $?`)
-	output, err := cmd.Output()
-	if err != nil {
-		log.Fatal(err)
-		return
+	dir := os.Getenv("EFIVARS_DIR")
+	if dir == "" {
+		dir = defaultEFIVarsDir
 	}
 
-	outputString := string(output)
-	// Split the output into lines
-	lines := strings.Split(outputString, "\n")
-	// Get the last line (containing the exit status)
-	exitStatus := lines[len(lines)-2]
-	ENsecBootstr := exitStatus
+	state, err := ReadState(dir)
+	if err != nil {
+		log.Fatalf("Failed to read the Secure Boot state from %s: %v", dir, err)
+	}
+	result, err := Verify(state, securityFeatureFlagSetBySI)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		ec.Exit(ec.InvalidConfiguration)
+	}
 
-	if (ENsecBootstr == SecureBootDisabled && securityFeatureFlagSetBySI == "SECURITY_FEATURE_SECURE_BOOT_AND_FULL_DISK_ENCRYPTION") ||
-		(ENsecBootstr == SecureBootDisabled && securityFeatureFlagSetBySI == "SECURITY_FEATURE_UNSPECIFIED") ||
-		(ENsecBootstr == SecureBootEnabled && securityFeatureFlagSetBySI == "SECURITY_FEATURE_NONE") {
-		/* Note : Do not change the case in 'Mismatch', as this message is grep'ed in run_sb.sh */
-		fmt.Printf("Verifying Secure Boot Settings Mismatch\n")
-		return
+	out, err := json.Marshal(result)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(out))
+	if !result.Match {
+		log.Printf("Verifying Secure Boot Settings Mismatch: %s", result.Reason)
+		ec.Exit(ec.SecureBootMismatch)
 	}
-	fmt.Printf("Verifying Secure Boot Settings Match\n")
+	log.Printf("Verifying Secure Boot Settings Match")
 }
@@ -16,21 +16,28 @@ function display_msg_to_tty_devices() {
 
 }
 
+. ./errcodes.sh
+
 main() {
-    cat /proc/kmsg > /host/sblog.txt &
     result=$(./main)
+    status=$?
     echo " output is $result "
-    case "$result" in
-        "") display_msg_to_tty_devices "Unable to read secure boot status" 1 &
-	sleep 1
-        exit 1
-        ;;
-        *Mismatch*) display_msg_to_tty_devices "Secure Boot Status MISMATCH" 1 &
-	sleep 1
+    if [ "$status" -eq "$ERR_SECURE_BOOT_MISMATCH" ]; then
+        display_msg_to_tty_devices "Secure Boot Status MISMATCH" 1 &
+        sleep 1
+        exit "$ERR_SECURE_BOOT_MISMATCH"
+    fi
+    if [ "$status" -eq "$ERR_INVALID_CONFIGURATION" ]; then
+        display_msg_to_tty_devices "Unknown security feature" 1 &
+        sleep 1
+        exit "$ERR_INVALID_CONFIGURATION"
+    fi
+    if [ "$status" -ne 0 ] || [ -z "$result" ]; then
+        display_msg_to_tty_devices "Unable to read secure boot status" 1 &
+        sleep 1
         exit 1
-        ;;
-        *) display_msg_to_tty_devices "Secure Boot Status MATCH" 2 ;;
-    esac
+    fi
+    display_msg_to_tty_devices "Secure Boot Status MATCH" 2
     sleep 1
     exit 0
 }
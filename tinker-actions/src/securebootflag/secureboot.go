@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/efivars"
+)
+
+// Security features of the OS resource, as passed by the onboarding manager in SECURITY_FEATURE_FLAG.
+const (
+	securityFeatureUnspecified = "SECURITY_FEATURE_UNSPECIFIED"
+	securityFeatureNone        = "SECURITY_FEATURE_NONE"
+	securityFeatureSBAndFDE    = "SECURITY_FEATURE_SECURE_BOOT_AND_FULL_DISK_ENCRYPTION"
+)
+
+// State is the Secure Boot state of the firmware.
+type State struct {
+	// UEFI is false if the host was booted in legacy BIOS mode, which has no Secure Boot.
+	UEFI       bool `json:"uefi"`
+	SecureBoot bool `json:"secureBoot"`
+	SetupMode  bool `json:"setupMode"`
+	AuditMode  bool `json:"auditMode"`
+	// PK, KEK and DB are the subjects of the certificates enrolled in the signature databases. They are only
+	// informational, a database that cannot be read is left empty.
+	PK  []string `json:"pk,omitempty"`
+	KEK []string `json:"kek,omitempty"`
+	DB  []string `json:"db,omitempty"`
+}
+
+// Enforced returns true if the firmware only boots signed images.
+func (s State) Enforced() bool {
+	return s.UEFI && s.SecureBoot && !s.SetupMode && !s.AuditMode
+}
+
+// Result is the outcome of the verification of the Secure Boot state against the security feature of the OS.
+type Result struct {
+	State
+	SecurityFeature string `json:"securityFeature"`
+	// Expected is true if the security feature requires Secure Boot to be enforced.
+	Expected bool `json:"expected"`
+	Match    bool `json:"match"`
+	// Reason explains a mismatch.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ReadState reads the Secure Boot state from the efivarfs mounted at dir.
+func ReadState(dir string) (State, error) {
+	var s State
+	var err error
+	if s.SecureBoot, err = efivars.SecureBoot(dir); errors.Is(err, efivars.ErrNotUEFI) {
+		return s, nil
+	} else if err != nil {
+		return s, err
+	}
+	s.UEFI = true
+	if s.SetupMode, err = efivars.SetupMode(dir); err != nil {
+		return s, err
+	}
+	if s.AuditMode, err = efivars.AuditMode(dir); err != nil {
+		return s, err
+	}
+	s.PK = subjects(dir, "PK", efivars.GlobalVariableGUID)
+	s.KEK = subjects(dir, "KEK", efivars.GlobalVariableGUID)
+	s.DB = subjects(dir, "db", efivars.ImageSecurityDatabaseGUID)
+	return s, nil
+}
+
+func subjects(dir, name, guid string) []string {
+	certs, err := efivars.Certificates(dir, name, guid)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(certs))
+	for _, cert := range certs {
+		names = append(names, cert.Subject.String())
+	}
+	return names
+}
+
+// Verify compares the Secure Boot state against a security feature. The security features other than
+// SECURITY_FEATURE_NONE require Secure Boot.
+func Verify(s State, securityFeature string) (Result, error) {
+	r := Result{State: s, SecurityFeature: securityFeature}
+	switch securityFeature {
+	case securityFeatureSBAndFDE, securityFeatureUnspecified:
+		r.Expected = true
+	case securityFeatureNone:
+	default:
+		return r, fmt.Errorf("unknown security feature %q", securityFeature)
+	}
+
+	r.Match = r.Expected == s.Enforced()
+	switch {
+	case r.Match:
+	case !r.Expected:
+		r.Reason = fmt.Sprintf("Secure Boot is enabled but %s requires it to be disabled", securityFeature)
+	case !s.UEFI:
+		r.Reason = fmt.Sprintf("the host was booted in legacy BIOS mode but %s requires Secure Boot", securityFeature)
+	case s.SetupMode:
+		r.Reason = fmt.Sprintf("the firmware is in Setup Mode, no Platform Key is enrolled, but %s requires Secure Boot",
+			securityFeature)
+	case s.AuditMode:
+		r.Reason = fmt.Sprintf("the firmware is in Audit Mode, signatures are not enforced, but %s requires Secure Boot",
+			securityFeature)
+	default:
+		r.Reason = fmt.Sprintf("Secure Boot is disabled but %s requires it", securityFeature)
+	}
+	return r, nil
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/efivars"
+)
+
+// fakeEFIVars returns an efivarfs directory with the given 1-byte global variables.
+func fakeEFIVars(t *testing.T, vars map[string]byte) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, value := range vars {
+		data := []byte{0x06, 0x00, 0x00, 0x00, value}
+		if err := os.WriteFile(filepath.Join(dir, name+"-"+efivars.GlobalVariableGUID), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestReadState(t *testing.T) {
+	state, err := ReadState(fakeEFIVars(t, map[string]byte{"SecureBoot": 1, "SetupMode": 0, "AuditMode": 0}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.UEFI || !state.SecureBoot || state.SetupMode || state.AuditMode || !state.Enforced() {
+		t.Errorf("ReadState() = %+v", state)
+	}
+
+	state, err = ReadState(filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.UEFI || state.Enforced() {
+		t.Errorf("ReadState() of a legacy BIOS host = %+v", state)
+	}
+
+	dir := fakeEFIVars(t, nil)
+	if err := os.WriteFile(filepath.Join(dir, "SetupMode-"+efivars.GlobalVariableGUID), []byte{0x06}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ReadState(dir); err == nil {
+		t.Error("ReadState() with a corrupted variable must fail")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name            string
+		vars            map[string]byte
+		noUEFI          bool
+		securityFeature string
+		match           bool
+		reason          string
+	}{
+		{
+			name:            "Secure Boot enforced",
+			vars:            map[string]byte{"SecureBoot": 1, "SetupMode": 0},
+			securityFeature: securityFeatureSBAndFDE,
+			match:           true,
+		},
+		{
+			name:            "Secure Boot required by default",
+			vars:            map[string]byte{"SecureBoot": 0, "SetupMode": 0},
+			securityFeature: securityFeatureUnspecified,
+			reason:          "Secure Boot is disabled",
+		},
+		{
+			name:            "Secure Boot disabled",
+			vars:            map[string]byte{"SecureBoot": 0, "SetupMode": 0},
+			securityFeature: securityFeatureNone,
+			match:           true,
+		},
+		{
+			name:            "Secure Boot must be disabled",
+			vars:            map[string]byte{"SecureBoot": 1, "SetupMode": 0},
+			securityFeature: securityFeatureNone,
+			reason:          "requires it to be disabled",
+		},
+		{
+			name:            "Setup Mode",
+			vars:            map[string]byte{"SecureBoot": 1, "SetupMode": 1},
+			securityFeature: securityFeatureSBAndFDE,
+			reason:          "Setup Mode",
+		},
+		{
+			name:            "Audit Mode",
+			vars:            map[string]byte{"SecureBoot": 1, "SetupMode": 0, "AuditMode": 1},
+			securityFeature: securityFeatureSBAndFDE,
+			reason:          "Audit Mode",
+		},
+		{
+			name:            "Audit Mode is not enforced",
+			vars:            map[string]byte{"SecureBoot": 1, "SetupMode": 0, "AuditMode": 1},
+			securityFeature: securityFeatureNone,
+			match:           true,
+		},
+		{
+			name:            "Legacy BIOS",
+			noUEFI:          true,
+			securityFeature: securityFeatureSBAndFDE,
+			reason:          "legacy BIOS",
+		},
+		{
+			name:            "Legacy BIOS without Secure Boot",
+			noUEFI:          true,
+			securityFeature: securityFeatureNone,
+			match:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := fakeEFIVars(t, tt.vars)
+			if tt.noUEFI {
+				dir = filepath.Join(dir, "missing")
+			}
+			state, err := ReadState(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, err := Verify(state, tt.securityFeature)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Match != tt.match {
+				t.Errorf("Verify() = %+v, want match %v", result, tt.match)
+			}
+			if tt.match && result.Reason != "" {
+				t.Errorf("Verify() of a match has reason %q", result.Reason)
+			}
+			if !strings.Contains(result.Reason, tt.reason) {
+				t.Errorf("Verify() reason %q does not contain %q", result.Reason, tt.reason)
+			}
+		})
+	}
+
+	if _, err := Verify(State{}, "SECURITY_FEATURE_UNKNOWN"); err == nil {
+		t.Error("Verify() of an unknown security feature must fail")
+	}
+}
@@ -7,11 +7,17 @@ go 1.26.3
 
 require (
 	github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/drive_detection v0.0.0-20250324105403-f8fa27a1b024
+	github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes v0.0.0
+	github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/outputs v0.0.0 // indirect
 	github.com/sirupsen/logrus v1.9.3
 )
 
 replace github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/drive_detection => ../../pkg/drive_detection
 
+replace github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes => ../../pkg/errcodes
+
+replace github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/outputs => ../../pkg/outputs
+
 require (
 	golang.org/x/sys v0.31.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
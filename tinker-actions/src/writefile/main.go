@@ -9,7 +9,6 @@ package main
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -17,7 +16,9 @@ import (
 	"syscall"
 
 	dd "github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/drive_detection"
+	ec "github.com/open-edge-platform/infra-onboarding/tinker-actions/pkg/errcodes"
 	log "github.com/sirupsen/logrus"
+	"github.com/tinkerbell/hub/actions/writefile/v1/manifest"
 )
 
 const mountAction = "/mountAction"
@@ -30,174 +31,108 @@ func main() {
 	driveName := ""
 	// Check if a string is empty
 	if len(blockDevice) == 0 {
-		// Get a list of drives
-		drives, err := dd.GetDrives()
+		// The disk selected by a previous action, or else detected
+		detectedDisk, err := dd.SelectedDrive()
 		if err != nil {
-			log.Fatal(err)
-		}
-		detectedDisk, err := dd.DriveDetection(drives)
-		if err != nil {
-			log.Fatal(err)
+			fatalf(ec.NoTargetDisk, "%v", err)
 		}
 		log.Infof("Detected drive: [%s] ", detectedDisk)
 		driveName = detectedDisk
 		blockDevice, err = dd.FindRootPartitionForDisk(driveName)
 		if err != nil {
-			log.Fatal(err)
+			fatalf(ec.NoTargetDisk, "%v", err)
 		}
 		log.Infof("Drive detected by automation: [%s] ", blockDevice)
 	} else {
 		log.Infof("Drive provided by the user: [%s] ", blockDevice)
 	}
 
-	filePath := os.Getenv("DEST_PATH")
-
-	contents := os.Getenv("CONTENTS")
-	contents = strings.ReplaceAll(contents, "$DEST_DISK", driveName)
-	rootPart := strings.ReplaceAll(blockDevice, driveName, "")
-	contents = strings.Replace(contents, "$ID", rootPart, 1)
-
-	uid := os.Getenv("UID")
-	gid := os.Getenv("GID")
-	mode := os.Getenv("MODE")
-	dirMode := os.Getenv("DIRMODE")
-
-	// Validate inputs
-	if blockDevice == "" {
-		log.Fatalf("No Block Device speified with Environment Variable [DEST_DISK]")
-	}
-
-	if !filepath.IsAbs(filePath) {
-		log.Fatal("Provide path must be an absolute path")
-	}
-
-	modePrime, err := strconv.ParseUint(mode, 8, 32)
-	if err != nil {
-		log.Fatalf("Could not parse mode: %v", err)
-	}
-
-	fileMode := os.FileMode(modePrime)
-
-	dirModePrime, err := strconv.ParseUint(dirMode, 8, 32)
+	entries, err := manifestEntries(driveName, blockDevice)
 	if err != nil {
-		log.Fatalf("Could not parse dirmode: %v", err)
+		fatalf(ec.InvalidConfiguration, "%v", err)
 	}
 
-	newDirMode := os.FileMode(dirModePrime)
-
-	fileUID, err := strconv.Atoi(uid)
-	if err != nil {
-		log.Fatalf("Could not parse uid: %v", err)
-	}
-
-	fileGID, err := strconv.Atoi(gid)
-	if err != nil {
-		log.Fatalf("Could not parse gid: %v", err)
-	}
-
-	dirPath, fileName := filepath.Split(filePath)
-	if len(fileName) == 0 {
-		log.Fatal("Provide path must include a file component")
+	// Validate inputs
+	if blockDevice == "" {
+		fatalf(ec.NoTargetDisk, "No Block Device speified with Environment Variable [DEST_DISK]")
 	}
 
 	// Create the /mountAction mountpoint (no folders exist previously in scratch container)
 	if err := os.Mkdir(mountAction, os.ModeDir); err != nil {
-		log.Fatalf("Error creating the action Mountpoint [%s]", mountAction)
+		fatalf(ec.DiskWriteFailed, "Error creating the action Mountpoint [%s]", mountAction)
 	}
 
 	// Mount the block device to the /mountAction point
 	if err := syscall.Mount(blockDevice, mountAction, filesystemType, 0, ""); err != nil {
-		log.Fatalf("Mounting [%s] -> [%s] error [%v]", blockDevice, mountAction, err)
+		fatalf(ec.DiskWriteFailed, "Mounting [%s] -> [%s] error [%v]", blockDevice, mountAction, err)
 	}
 
 	log.Infof("Mounted [%s] -> [%s]", blockDevice, mountAction)
 
-	if err := recursiveEnsureDir(mountAction, dirPath, newDirMode, fileUID, fileGID); err != nil {
-		log.Fatalf("Failed to ensure directory exists: %v", err)
+	// Write all entries in the single mount, the manifest rolls back what was written if one of them fails
+	applyErr := manifest.Apply(mountAction, entries)
+	if err := syscall.Unmount(mountAction, 0); err != nil {
+		fatalf(ec.DiskWriteFailed, "Unmounting [%s] error [%v]", mountAction, err)
 	}
-
-	fqFilePath := filepath.Join(mountAction, filePath)
-	// Write the file to disk
-	if err := ioutil.WriteFile(fqFilePath, []byte(contents), fileMode); err != nil {
-		log.Fatalf("Could not write file %s: %v", filePath, err)
+	if applyErr != nil {
+		fatalf(ec.DiskWriteFailed, "%v", applyErr)
 	}
 
-	if err := os.Chown(fqFilePath, fileUID, fileGID); err != nil {
-		log.Fatalf("Could not modify ownership of file %s: %v", filePath, err)
+	for _, e := range entries {
+		log.Infof("Successfully wrote %s [%s] to device [%s]", e.Type, e.Path, blockDevice)
 	}
-
-	log.Infof("Successfully wrote file [%s] to device [%s]", filePath, blockDevice)
 }
 
-func dirExists(mountPath, path string) (bool, error) {
-	fqPath := filepath.Join(mountPath, path)
-	info, err := os.Stat(fqPath)
-
-	switch {
-	// Any error that does not indicate the directory doesn't exist
-	case err != nil && !os.IsNotExist(err):
-		return false, fmt.Errorf("failed to stat path %s: %w", path, err)
-	// The directory already exists
-	case err == nil:
-		if !info.IsDir() {
-			return false, fmt.Errorf("expected %s to be a path, but it is a file", path)
+// manifestEntries returns the entries of the MANIFEST, rendered against the VARS, or else the single file
+// described by DEST_PATH, CONTENTS, UID, GID, MODE and DIRMODE.
+func manifestEntries(driveName, blockDevice string) ([]manifest.Entry, error) {
+	if data := os.Getenv("MANIFEST"); data != "" {
+		vars, err := manifest.ParseVars(os.Getenv("VARS"))
+		if err != nil {
+			return nil, err
 		}
+		return manifest.Parse(data, vars)
 	}
 
-	return !os.IsNotExist(err), nil
-}
-
-func recursiveEnsureDir(mountPath, path string, mode os.FileMode, uid, gid int) error {
-	// Does the directory already exist? If so we can return early
-	exists, err := dirExists(mountPath, path)
-	if err != nil {
-		return err
-	}
-
-	if exists {
-		return nil
+	filePath := os.Getenv("DEST_PATH")
+	if !filepath.IsAbs(filePath) {
+		return nil, errors.New("provided path must be an absolute path")
 	}
-
-	pathParts := strings.Split(path, string(os.PathSeparator))
-	if len(pathParts) == 1 && pathParts[0] == path {
-		return errors.New("bad path")
+	if _, fileName := filepath.Split(filePath); len(fileName) == 0 {
+		return nil, errors.New("provided path must include a file component")
 	}
 
-	basePath := string(os.PathSeparator)
-	for _, part := range pathParts {
-		basePath = filepath.Join(basePath, part)
-		if err := ensureDir(mountPath, basePath, mode, uid, gid); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
+	contents := os.Getenv("CONTENTS")
+	contents = strings.ReplaceAll(contents, "$DEST_DISK", driveName)
+	rootPart := strings.ReplaceAll(blockDevice, driveName, "")
+	contents = strings.Replace(contents, "$ID", rootPart, 1)
 
-func ensureDir(mountPath, path string, mode os.FileMode, uid, gid int) error {
-	exists, err := dirExists(mountPath, path)
+	fileUID, err := strconv.Atoi(os.Getenv("UID"))
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("could not parse uid: %w", err)
 	}
+	fileGID, err := strconv.Atoi(os.Getenv("GID"))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse gid: %w", err)
+	}
+
+	entries := []manifest.Entry{{
+		Path:     filePath,
+		Type:     manifest.TypeFile,
+		Mode:     os.Getenv("MODE"),
+		DirMode:  os.Getenv("DIRMODE"),
+		UID:      fileUID,
+		GID:      fileGID,
+		Contents: contents,
+	}}
+	if err := manifest.Prepare(entries, nil); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
 
-	if exists {
-		return nil
-	}
-
-	// The directory doesn't exist, let's create it.
-	fqPath := filepath.Join(mountPath, path)
-
-	if err := os.Mkdir(fqPath, mode); err != nil {
-		return fmt.Errorf("failed to create directory %s: %w", path, err)
-	}
-
-	log.Infof("Successfully created directory: %s", path)
-
-	if err := os.Chown(fqPath, uid, gid); err != nil {
-		return fmt.Errorf("failed to set ownership of directory %s to %d:%d: %w", path, uid, gid, err)
-	}
-
-	log.Infof("Successfully set ownernership of directory %s to %d:%d", path, uid, gid)
-
-	return nil
+// fatalf logs the failure and terminates the action with the exit status of its error code.
+func fatalf(code ec.Code, format string, args ...interface{}) {
+	log.Errorf(format, args...)
+	ec.Exit(code)
 }
@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// Apply writes the prepared entries, in order, under root. If an entry cannot be written, the entries already
+// written are rolled back: replaced objects are restored and created ones are removed.
+func Apply(root string, entries []Entry) error {
+	t := &txn{root: root}
+	for i := range entries {
+		if err := t.apply(&entries[i]); err != nil {
+			err = fmt.Errorf("could not write %s: %w", entries[i].Path, err)
+			if rbErr := t.rollback(); rbErr != nil {
+				err = errors.Join(err, fmt.Errorf("rollback failed: %w", rbErr))
+			}
+			return err
+		}
+	}
+	return t.commit()
+}
+
+// txn records how to undo the changes made so far. Replaced objects are kept aside as backups until commit.
+type txn struct {
+	root    string
+	undo    []func() error
+	backups []string
+}
+
+func (t *txn) apply(e *Entry) error {
+	full := filepath.Join(t.root, e.Path)
+	if err := t.ensureDir(filepath.Dir(e.Path), e.dirMode, e.UID, e.GID); err != nil {
+		return err
+	}
+	switch e.Type {
+	case TypeDirectory:
+		return t.writeDir(full, e)
+	case TypeSymlink:
+		staged, err := stageName(full)
+		if err != nil {
+			return err
+		}
+		if err := os.Symlink(e.Target, staged); err != nil {
+			return err
+		}
+		if err := os.Lchown(staged, e.UID, e.GID); err != nil {
+			_ = os.Remove(staged)
+			return err
+		}
+		return t.install(full, staged)
+	default:
+		staged, err := t.stageFile(full, e)
+		if err != nil {
+			return err
+		}
+		return t.install(full, staged)
+	}
+}
+
+// ensureDir creates the missing directories of path, from the root down.
+func (t *txn) ensureDir(path string, mode os.FileMode, uid, gid int) error {
+	if path == string(filepath.Separator) {
+		return nil
+	}
+	if err := t.ensureDir(filepath.Dir(path), mode, uid, gid); err != nil {
+		return err
+	}
+	full := filepath.Join(t.root, path)
+	info, err := os.Stat(full)
+	switch {
+	case err == nil && info.IsDir():
+		return nil
+	case err == nil:
+		return fmt.Errorf("%s is not a directory", path)
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+	if err := mkdir(full, mode, uid, gid); err != nil {
+		return err
+	}
+	t.undo = append(t.undo, func() error { return os.Remove(full) })
+	return nil
+}
+
+func (t *txn) writeDir(full string, e *Entry) error {
+	info, err := os.Lstat(full)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		if err := mkdir(full, e.mode, e.UID, e.GID); err != nil {
+			return err
+		}
+		t.undo = append(t.undo, func() error { return os.Remove(full) })
+		return nil
+	case err != nil:
+		return err
+	case !info.IsDir():
+		return errors.New("a file is in the way of the directory")
+	}
+
+	// The directory exists: restore its mode and ownership on rollback.
+	mode := info.Mode() & (fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky)
+	uid, gid := -1, -1
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		uid, gid = int(st.Uid), int(st.Gid)
+	}
+	t.undo = append(t.undo, func() error {
+		if err := os.Chmod(full, mode); err != nil {
+			return err
+		}
+		return os.Chown(full, uid, gid)
+	})
+	if err := os.Chmod(full, e.mode); err != nil {
+		return err
+	}
+	return os.Chown(full, e.UID, e.GID)
+}
+
+// stageFile writes the new contents of a file next to it.
+func (t *txn) stageFile(full string, e *Entry) (string, error) {
+	data := e.data
+	if e.Append {
+		existing, err := os.ReadFile(full)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		data = append(existing, data...)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(full), stagePattern(full))
+	if err != nil {
+		return "", err
+	}
+	staged := f.Name()
+	err = func() error {
+		if _, err := f.Write(data); err != nil {
+			return err
+		}
+		if err := f.Sync(); err != nil {
+			return err
+		}
+		return f.Close()
+	}()
+	if err == nil {
+		// Set the mode explicitly, CreateTemp is subject to the umask.
+		err = os.Chmod(staged, e.mode)
+	}
+	if err == nil {
+		err = os.Chown(staged, e.UID, e.GID)
+	}
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(staged)
+		return "", err
+	}
+	return staged, nil
+}
+
+// install renames the staged object over full, keeping what it replaces as a backup.
+func (t *txn) install(full, staged string) error {
+	info, err := os.Lstat(full)
+	switch {
+	case err == nil && info.IsDir():
+		_ = os.Remove(staged)
+		return errors.New("a directory is in the way")
+	case err == nil:
+		backup := staged + ".orig"
+		if err := os.Rename(full, backup); err != nil {
+			_ = os.Remove(staged)
+			return err
+		}
+		t.backups = append(t.backups, backup)
+		// Renaming the backup back also replaces the new object, whether or not it was installed.
+		t.undo = append(t.undo, func() error { return os.Rename(backup, full) })
+	case errors.Is(err, fs.ErrNotExist):
+	default:
+		_ = os.Remove(staged)
+		return err
+	}
+
+	if err := os.Rename(staged, full); err != nil {
+		_ = os.Remove(staged)
+		return err
+	}
+	if info == nil {
+		t.undo = append(t.undo, func() error { return os.Remove(full) })
+	}
+	return nil
+}
+
+func (t *txn) rollback() error {
+	var errs []error
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		if err := t.undo[i](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// commit removes the backups of the replaced objects.
+func (t *txn) commit() error {
+	var errs []error
+	for _, backup := range t.backups {
+		if err := os.Remove(backup); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("could not remove backups: %w", err)
+	}
+	return nil
+}
+
+func mkdir(full string, mode os.FileMode, uid, gid int) error {
+	if err := os.Mkdir(full, mode); err != nil {
+		return err
+	}
+	// Set the mode explicitly, Mkdir is subject to the umask.
+	if err := os.Chmod(full, mode); err != nil {
+		_ = os.Remove(full)
+		return err
+	}
+	if err := os.Chown(full, uid, gid); err != nil {
+		_ = os.Remove(full)
+		return err
+	}
+	return nil
+}
+
+func stagePattern(full string) string {
+	return "." + strings.ReplaceAll(filepath.Base(full), "*", "") + ".writefile-*"
+}
+
+// stageName reserves a unique name next to full.
+func stageName(full string) (string, error) {
+	f, err := os.CreateTemp(filepath.Dir(full), stagePattern(full))
+	if err != nil {
+		return "", err
+	}
+	name := f.Name()
+	_ = f.Close()
+	return name, os.Remove(name)
+}
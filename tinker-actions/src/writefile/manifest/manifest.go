@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package manifest writes a list of files, directories and symbolic links onto a mounted filesystem. Either
+// every entry of a manifest is written, or the filesystem is left as it was found.
+package manifest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Type is the kind of filesystem object an entry creates.
+type Type string
+
+const (
+	TypeFile      Type = "file"
+	TypeDirectory Type = "directory"
+	TypeSymlink   Type = "symlink"
+)
+
+const (
+	defaultFileMode = "0644"
+	defaultDirMode  = "0755"
+)
+
+// Entry is one object to write.
+type Entry struct {
+	// Path is the absolute path of the object on the target filesystem.
+	Path string `json:"path"`
+	// Type defaults to TypeFile.
+	Type Type `json:"type,omitempty"`
+	// Mode is the octal mode of the object, 0644 for files and 0755 for directories by default. It is ignored for
+	// symbolic links.
+	Mode string `json:"mode,omitempty"`
+	// DirMode is the octal mode of the missing parent directories, 0755 by default.
+	DirMode string `json:"dirMode,omitempty"`
+	// UID and GID own the object and its missing parent directories.
+	UID int `json:"uid,omitempty"`
+	GID int `json:"gid,omitempty"`
+	// Contents or ContentsBase64 hold the contents of a file.
+	Contents       string `json:"contents,omitempty"`
+	ContentsBase64 string `json:"contentsBase64,omitempty"`
+	// Target is the target of a symbolic link.
+	Target string `json:"target,omitempty"`
+	// Append appends the contents to the file instead of overwriting it.
+	Append bool `json:"append,omitempty"`
+	// Template renders the contents as a Go template against the manifest variables.
+	Template bool `json:"template,omitempty"`
+
+	mode    os.FileMode
+	dirMode os.FileMode
+	data    []byte
+}
+
+// Parse decodes a JSON list of entries and prepares them with Prepare.
+func Parse(data string, vars map[string]string) ([]Entry, error) {
+	var entries []Entry
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&entries); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("invalid manifest: no entries")
+	}
+	if err := Prepare(entries, vars); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ParseVars decodes the JSON object of template variables. An empty string has no variables.
+func ParseVars(data string) (map[string]string, error) {
+	vars := make(map[string]string)
+	if strings.TrimSpace(data) == "" {
+		return vars, nil
+	}
+	if err := json.Unmarshal([]byte(data), &vars); err != nil {
+		return nil, fmt.Errorf("invalid template variables: %w", err)
+	}
+	return vars, nil
+}
+
+// Prepare validates the entries and renders their contents, so that nothing is written unless all of them are
+// valid.
+func Prepare(entries []Entry, vars map[string]string) error {
+	seen := make(map[string]bool, len(entries))
+	for i := range entries {
+		e := &entries[i]
+		if err := e.prepare(vars); err != nil {
+			return fmt.Errorf("invalid manifest entry %d (%s): %w", i, e.Path, err)
+		}
+		if seen[e.Path] {
+			return fmt.Errorf("invalid manifest entry %d: %s is written twice", i, e.Path)
+		}
+		seen[e.Path] = true
+	}
+	return nil
+}
+
+func (e *Entry) prepare(vars map[string]string) error {
+	if !filepath.IsAbs(e.Path) {
+		return errors.New("path must be absolute")
+	}
+	e.Path = filepath.Clean(e.Path)
+	if e.Path == string(filepath.Separator) {
+		return errors.New("path must not be the root directory")
+	}
+	if e.UID < 0 || e.GID < 0 {
+		return errors.New("uid and gid must not be negative")
+	}
+	if e.Type == "" {
+		e.Type = TypeFile
+	}
+
+	var err error
+	if e.dirMode, err = parseMode(e.DirMode, defaultDirMode); err != nil {
+		return fmt.Errorf("dirMode: %w", err)
+	}
+	hasContents := e.Contents != "" || e.ContentsBase64 != ""
+
+	switch e.Type {
+	case TypeFile:
+		if e.Target != "" {
+			return errors.New("only symbolic links have a target")
+		}
+		if e.Contents != "" && e.ContentsBase64 != "" {
+			return errors.New("contents and contentsBase64 are mutually exclusive")
+		}
+		if e.mode, err = parseMode(e.Mode, defaultFileMode); err != nil {
+			return fmt.Errorf("mode: %w", err)
+		}
+		return e.render(vars)
+	case TypeDirectory:
+		if hasContents || e.Target != "" || e.Append || e.Template {
+			return errors.New("a directory has no contents or target")
+		}
+		if e.mode, err = parseMode(e.Mode, defaultDirMode); err != nil {
+			return fmt.Errorf("mode: %w", err)
+		}
+	case TypeSymlink:
+		if e.Target == "" {
+			return errors.New("a symbolic link needs a target")
+		}
+		if hasContents || e.Append || e.Template || e.Mode != "" {
+			return errors.New("a symbolic link has no contents or mode")
+		}
+	default:
+		return fmt.Errorf("unknown type %q", e.Type)
+	}
+	return nil
+}
+
+func (e *Entry) render(vars map[string]string) error {
+	e.data = []byte(e.Contents)
+	if e.ContentsBase64 != "" {
+		data, err := base64.StdEncoding.DecodeString(e.ContentsBase64)
+		if err != nil {
+			return fmt.Errorf("contentsBase64: %w", err)
+		}
+		e.data = data
+	}
+	if !e.Template {
+		return nil
+	}
+	tmpl, err := template.New(e.Path).Option("missingkey=error").Parse(string(e.data))
+	if err != nil {
+		return fmt.Errorf("template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return fmt.Errorf("template: %w", err)
+	}
+	e.data = buf.Bytes()
+	return nil
+}
+
+// parseMode parses an octal mode, including the setuid, setgid and sticky bits.
+func parseMode(mode, def string) (os.FileMode, error) {
+	if mode == "" {
+		mode = def
+	}
+	m, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil {
+		return 0, err
+	}
+	if m > 0o7777 {
+		return 0, fmt.Errorf("%s is not a file mode", mode)
+	}
+	fileMode := os.FileMode(m & 0o777)
+	if m&0o4000 != 0 {
+		fileMode |= os.ModeSetuid
+	}
+	if m&0o2000 != 0 {
+		fileMode |= os.ModeSetgid
+	}
+	if m&0o1000 != 0 {
+		fileMode |= os.ModeSticky
+	}
+	return fileMode, nil
+}
@@ -0,0 +1,317 @@
+// SPDX-FileCopyrightText: (C) 2025 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func parse(t *testing.T, data string, vars map[string]string) []Entry {
+	t.Helper()
+	entries, err := Parse(data, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
+
+// owner is the manifest fragment owning entries by the current user, so that tests also run without root.
+func owner() string {
+	return fmt.Sprintf(`"uid":%d,"gid":%d`, os.Getuid(), os.Getgid())
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func assertMode(t *testing.T, path string, want os.FileMode) {
+	t.Helper()
+	info, err := os.Lstat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info.Mode() & (os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky); got != want {
+		t.Errorf("mode of %s = %v, want %v", path, got, want)
+	}
+}
+
+func assertNotExist(t *testing.T, path string) {
+	t.Helper()
+	if _, err := os.Lstat(path); !os.IsNotExist(err) {
+		t.Errorf("%s must not exist: %v", path, err)
+	}
+}
+
+// listDir returns the names in dir, to spot leftover staging files and backups.
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestParse(t *testing.T) {
+	entries := parse(t, `[
+		{"path": "/etc/apt/apt.conf", "contents": "Acquire::http::Proxy \"{{ .proxy }}\";\n", "template": true},
+		{"path": "/opt/bin/run", "contentsBase64": "IyEvYmluL3NoCg==", "mode": "4755"},
+		{"path": "/opt/data", "type": "directory", "mode": "1777"},
+		{"path": "/opt/bin/../current", "type": "symlink", "target": "bin"}
+	]`, map[string]string{"proxy": "http://proxy:911"})
+
+	if got := string(entries[0].data); got != "Acquire::http::Proxy \"http://proxy:911\";\n" {
+		t.Errorf("rendered contents = %q", got)
+	}
+	if entries[0].mode != 0o644 || entries[0].dirMode != 0o755 {
+		t.Errorf("default modes = %v, %v", entries[0].mode, entries[0].dirMode)
+	}
+	if got := string(entries[1].data); got != "#!/bin/sh\n" || entries[1].mode != os.ModeSetuid|0o755 {
+		t.Errorf("base64 entry = %q, %v", got, entries[1].mode)
+	}
+	if entries[2].mode != os.ModeSticky|0o777 {
+		t.Errorf("directory mode = %v", entries[2].mode)
+	}
+	if entries[3].Path != "/opt/current" || entries[3].Type != TypeSymlink {
+		t.Errorf("symlink entry = %+v", entries[3])
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"Not a list":           `{"path": "/etc/foo"}`,
+		"No entries":           `[]`,
+		"Unknown field":        `[{"path": "/etc/foo", "owner": "root"}]`,
+		"Relative path":        `[{"path": "etc/foo"}]`,
+		"Root directory":       `[{"path": "/", "type": "directory"}]`,
+		"Written twice":        `[{"path": "/etc/foo"}, {"path": "/etc/bar/../foo"}]`,
+		"Unknown type":         `[{"path": "/etc/foo", "type": "fifo"}]`,
+		"Bad mode":             `[{"path": "/etc/foo", "mode": "0999"}]`,
+		"Mode too large":       `[{"path": "/etc/foo", "mode": "17777"}]`,
+		"Bad dir mode":         `[{"path": "/etc/foo", "dirMode": "rwx"}]`,
+		"Negative uid":         `[{"path": "/etc/foo", "uid": -1}]`,
+		"Both contents":        `[{"path": "/etc/foo", "contents": "a", "contentsBase64": "YQ=="}]`,
+		"Bad base64":           `[{"path": "/etc/foo", "contentsBase64": "%%%"}]`,
+		"File with target":     `[{"path": "/etc/foo", "target": "/etc/bar"}]`,
+		"Directory contents":   `[{"path": "/etc/foo", "type": "directory", "contents": "a"}]`,
+		"Symlink no target":    `[{"path": "/etc/foo", "type": "symlink"}]`,
+		"Symlink mode":         `[{"path": "/etc/foo", "type": "symlink", "target": "bar", "mode": "0644"}]`,
+		"Bad template":         `[{"path": "/etc/foo", "contents": "{{ .proxy", "template": true}]`,
+		"Missing template var": `[{"path": "/etc/foo", "contents": "{{ .missing }}", "template": true}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse(data, map[string]string{"proxy": "http://proxy:911"}); err == nil {
+				t.Errorf("Parse(%s) must fail", data)
+			}
+		})
+	}
+}
+
+func TestParseVars(t *testing.T) {
+	vars, err := ParseVars("")
+	if err != nil || len(vars) != 0 {
+		t.Errorf("ParseVars(\"\") = %v, %v", vars, err)
+	}
+	vars, err = ParseVars(`{"proxy": "http://proxy:911"}`)
+	if err != nil || vars["proxy"] != "http://proxy:911" {
+		t.Errorf("ParseVars() = %v, %v", vars, err)
+	}
+	if _, err := ParseVars(`{"port": 911}`); err == nil {
+		t.Error("ParseVars() must only accept strings")
+	}
+}
+
+func TestApply(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "etc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "etc", "hosts"), []byte("127.0.0.1 localhost\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "etc", "motd"), []byte("old\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := parse(t, `[
+		{"path": "/home/postinstall/Setup/installer.sh", "contents": "#!/bin/sh\n", "mode": "0755", "dirMode": "0700", `+owner()+`},
+		{"path": "/etc/hosts", "contents": "10.0.0.1 {{ .host }}\n", "append": true, "template": true, `+owner()+`},
+		{"path": "/etc/motd", "contents": "new\n", `+owner()+`},
+		{"path": "/var/lib/agent", "type": "directory", "mode": "0750", `+owner()+`},
+		{"path": "/home/postinstall/setup", "type": "symlink", "target": "Setup", `+owner()+`}
+	]`, map[string]string{"host": "orchestrator"})
+
+	if err := Apply(root, entries); err != nil {
+		t.Fatal(err)
+	}
+
+	installer := filepath.Join(root, "home", "postinstall", "Setup", "installer.sh")
+	if got := readFile(t, installer); got != "#!/bin/sh\n" {
+		t.Errorf("installer.sh = %q", got)
+	}
+	assertMode(t, installer, 0o755)
+	assertMode(t, filepath.Join(root, "home", "postinstall"), 0o700)
+	if got := readFile(t, filepath.Join(root, "etc", "hosts")); got != "127.0.0.1 localhost\n10.0.0.1 orchestrator\n" {
+		t.Errorf("appended hosts = %q", got)
+	}
+	if got := readFile(t, filepath.Join(root, "etc", "motd")); got != "new\n" {
+		t.Errorf("overwritten motd = %q", got)
+	}
+	assertMode(t, filepath.Join(root, "var", "lib", "agent"), 0o750)
+	if target, err := os.Readlink(filepath.Join(root, "home", "postinstall", "setup")); err != nil || target != "Setup" {
+		t.Errorf("symlink target = %q, %v", target, err)
+	}
+	if got := listDir(t, filepath.Join(root, "etc")); strings.Join(got, " ") != "hosts motd" {
+		t.Errorf("leftover staging files or backups in /etc: %v", got)
+	}
+}
+
+func TestApply_RollsBackOnFailure(t *testing.T) {
+	root := t.TempDir()
+	etc := filepath.Join(root, "etc")
+	if err := os.MkdirAll(filepath.Join(etc, "cloud"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(etc, "motd"), []byte("old\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("motd", filepath.Join(etc, "issue")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(filepath.Join(etc, "cloud"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	// The last entry fails because /etc/motd is not a directory.
+	entries := parse(t, `[
+		{"path": "/etc/motd", "contents": "new\n", `+owner()+`},
+		{"path": "/etc/issue", "contents": "welcome\n", `+owner()+`},
+		{"path": "/etc/cloud", "type": "directory", "mode": "0755", `+owner()+`},
+		{"path": "/opt/agent/bin/agent", "contents": "binary", `+owner()+`},
+		{"path": "/etc/motd.d", "type": "symlink", "target": "motd", `+owner()+`},
+		{"path": "/etc/motd/extra", "contents": "c", `+owner()+`}
+	]`, nil)
+
+	err := Apply(root, entries)
+	if err == nil || !strings.Contains(err.Error(), "could not write /etc/motd/extra") {
+		t.Fatalf("Apply() = %v, want a failure of /etc/motd/extra", err)
+	}
+
+	if got := readFile(t, filepath.Join(etc, "motd")); got != "old\n" {
+		t.Errorf("motd was not restored: %q", got)
+	}
+	if target, err := os.Readlink(filepath.Join(etc, "issue")); err != nil || target != "motd" {
+		t.Errorf("issue symlink was not restored: %q, %v", target, err)
+	}
+	assertMode(t, filepath.Join(etc, "cloud"), 0o700)
+	assertNotExist(t, filepath.Join(root, "opt"))
+	assertNotExist(t, filepath.Join(etc, "motd.d"))
+	if got := listDir(t, etc); strings.Join(got, " ") != "cloud issue motd" {
+		t.Errorf("leftover staging files or backups in /etc: %v", got)
+	}
+}
+
+func TestApply_FileInTheWayOfDirectory(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "opt"), []byte("not a directory"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	entries := parse(t, `[
+		{"path": "/etc/created", "contents": "a", `+owner()+`},
+		{"path": "/opt/agent/config", "contents": "b", `+owner()+`}
+	]`, nil)
+	if err := Apply(root, entries); err == nil {
+		t.Fatal("Apply() must fail when a parent directory is a file")
+	}
+	assertNotExist(t, filepath.Join(root, "etc"))
+}
+
+// TestApply_LoopMountedExt4 writes a manifest onto a loop-mounted ext4 image. It requires root and mkfs.ext4.
+func TestApply_LoopMountedExt4(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("loop mounts require root")
+	}
+	if _, err := exec.LookPath("mkfs.ext4"); err != nil {
+		t.Skip("mkfs.ext4 not found")
+	}
+	image := filepath.Join(t.TempDir(), "rootfs.img")
+	if out, err := exec.Command("mkfs.ext4", "-q", "-F", image, "16M").CombinedOutput(); err != nil {
+		t.Fatalf("mkfs.ext4: %v: %s", err, out)
+	}
+	out, err := exec.Command("losetup", "--find", "--show", image).Output()
+	if err != nil {
+		t.Skipf("no loop device available: %v", err)
+	}
+	loop := strings.TrimSpace(string(out))
+	defer func() { _ = exec.Command("losetup", "--detach", loop).Run() }()
+
+	mnt := t.TempDir()
+	mount := func() {
+		t.Helper()
+		if err := syscall.Mount(loop, mnt, "ext4", 0, ""); err != nil {
+			t.Skipf("cannot mount ext4: %v", err)
+		}
+	}
+	unmount := func() {
+		t.Helper()
+		if err := syscall.Unmount(mnt, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mount()
+	entries := parse(t, `[
+		{"path": "/etc/systemd/system/agent.service", "contents": "[Unit]\nDescription={{ .name }}\n", "template": true},
+		{"path": "/home/user/.ssh/authorized_keys", "contents": "ssh-ed25519 AAAA\n", "mode": "0600", "dirMode": "0700", "uid": 1000, "gid": 1000},
+		{"path": "/etc/systemd/system/multi-user.target.wants/agent.service", "type": "symlink", "target": "/etc/systemd/system/agent.service"}
+	]`, map[string]string{"name": "Node agent"})
+	if err := Apply(mnt, entries); err != nil {
+		unmount()
+		t.Fatal(err)
+	}
+	failing := parse(t, `[
+		{"path": "/etc/systemd/system/agent.service", "contents": "overwritten"},
+		{"path": "/etc/systemd/system/multi-user.target.wants", "contents": "in the way of a directory"}
+	]`, nil)
+	if err := Apply(mnt, failing); err == nil {
+		t.Error("Apply() must fail when a directory is in the way")
+	}
+	unmount()
+
+	// Remount to read what reached the filesystem.
+	mount()
+	defer unmount()
+	if got := readFile(t, filepath.Join(mnt, "etc", "systemd", "system", "agent.service")); got != "[Unit]\nDescription=Node agent\n" {
+		t.Errorf("agent.service = %q", got)
+	}
+	keys := filepath.Join(mnt, "home", "user", ".ssh", "authorized_keys")
+	assertMode(t, keys, 0o600)
+	assertMode(t, filepath.Join(mnt, "home", "user", ".ssh"), 0o700)
+	info, err := os.Stat(keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st := info.Sys().(*syscall.Stat_t); st.Uid != 1000 || st.Gid != 1000 {
+		t.Errorf("authorized_keys is owned by %d:%d, want 1000:1000", st.Uid, st.Gid)
+	}
+	link := filepath.Join(mnt, "etc", "systemd", "system", "multi-user.target.wants", "agent.service")
+	if target, err := os.Readlink(link); err != nil || target != "/etc/systemd/system/agent.service" {
+		t.Errorf("symlink target = %q, %v", target, err)
+	}
+}